@@ -42,6 +42,14 @@ func New(config API) *API {
 	}
 }
 
+// NewWithHTTPClient returns an API sending its requests with the given
+// client, such as one trusting the certificate of a test server
+func NewWithHTTPClient(config API, client *http.Client) *API {
+	api := New(config)
+	api.client = client
+	return api
+}
+
 var ErrTokenInvalid = errors.New("bearer token is invalid")
 
 func (api *API) makeRequest(route string, httpMethod string, data interface{}) ([]byte, error) {
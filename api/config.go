@@ -15,6 +15,7 @@ type Endpoint struct {
 type APIEndpoints struct {
 	RequestFileUpload Endpoint
 	ScanFinished      Endpoint
+	ScanFindings      Endpoint
 	FetchIgnores      Endpoint
 	Hello             Endpoint
 	Version           Endpoint
@@ -29,6 +30,10 @@ var Endpoints = APIEndpoints{
 		HttpMethod: "POST",
 		Route:      "/cloud/scans",
 	},
+	ScanFindings: Endpoint{
+		HttpMethod: "POST",
+		Route:      "/cloud/scan_findings",
+	},
 	FetchIgnores: Endpoint{
 		HttpMethod: "GET",
 		Route:      "/cloud/ignores",
@@ -5,7 +5,6 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/bearer/bearer/api"
 	"github.com/google/uuid"
@@ -14,19 +13,14 @@ import (
 func SignForAPI(req *UploadRequestS3) (*api.RequestFileUpload, error) {
 	fileUuid := uuid.NewString()
 
-	reportFile, err := os.Open(req.FilePath)
+	reportFile, err := openContent(req.FilePath, req.Content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file for upload %e", err)
 	}
 	defer reportFile.Close()
 
-	stats, err := reportFile.Stat()
-	if err != nil {
-		return nil, fmt.Errorf("failed to stat file %e", err)
-	}
-
 	hash := md5.New()
-	_, err = io.Copy(hash, reportFile)
+	byteSize, err := io.Copy(hash, reportFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed copying file content to hash %e", err)
 	}
@@ -35,7 +29,7 @@ func SignForAPI(req *UploadRequestS3) (*api.RequestFileUpload, error) {
 
 	return &api.RequestFileUpload{
 		Checksum:        base64.StdEncoding.EncodeToString(checksumMD5[:]),
-		ByteSize:        int(stats.Size()),
+		ByteSize:        int(byteSize),
 		UUID:            fileUuid,
 		Prefix:          req.FilePrefix,
 		ContentType:     req.ContentType,
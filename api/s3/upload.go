@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 type UploadRequest struct {
 	Client   *http.Client
 	FilePath string
+	Content  []byte
 	FileSize int64
 	URL      string
 	Headers  map[string]string
@@ -21,6 +23,7 @@ type UploadRequest struct {
 type UploadRequestS3 struct {
 	Api             *api.API
 	FilePath        string
+	Content         []byte
 	FilePrefix      string
 	FileType        string
 	ContentType     string
@@ -28,7 +31,7 @@ type UploadRequestS3 struct {
 }
 
 func GetSignedURL(req UploadRequest) error {
-	reportFile, err := os.Open(req.FilePath)
+	reportFile, err := openContent(req.FilePath, req.Content)
 	if err != nil {
 		return fmt.Errorf("failed to open file for uploading: %s", err)
 	}
@@ -75,6 +78,7 @@ func UploadS3(req *UploadRequestS3) (fileUploadOffer *api.FileUploadOffer, err e
 	err = GetSignedURL(UploadRequest{
 		Client:   api.UploadClient,
 		FilePath: req.FilePath,
+		Content:  req.Content,
 		FileSize: int64(requestFileUploadAction.ByteSize),
 		URL:      fileUploadOffer.DirectUpload.URL,
 		Headers:  fileUploadOffer.DirectUpload.Headers,
@@ -86,3 +90,13 @@ func UploadS3(req *UploadRequestS3) (fileUploadOffer *api.FileUploadOffer, err e
 
 	return fileUploadOffer, nil
 }
+
+// openContent returns the content to upload, which is read from the file
+// unless it is given directly
+func openContent(filePath string, content []byte) (io.ReadCloser, error) {
+	if content != nil {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+
+	return os.Open(filePath)
+}
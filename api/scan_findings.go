@@ -0,0 +1,33 @@
+package api
+
+import "encoding/json"
+
+// ScanFindingsBatch holds findings sent ahead of the report of a scan. The
+// findings are shown as partial results until the report with the same stream
+// id is sent once the scan finishes.
+type ScanFindingsBatch struct {
+	StreamID string          `json:"stream_id"`
+	Findings json.RawMessage `json:"findings"`
+}
+
+// ScanFindings sends a batch of findings to the scan findings endpoint. The
+// request body is a success message whose data holds the stream id and the
+// redacted findings, in the format of the security report's raw findings:
+//
+//	{"type": "success", "data": {"stream_id": "<uuid>", "findings": [...]}}
+//
+// The endpoint responds with 200 or 204. Batches of a stream are sent in
+// order, and never again once one of them fails. The report sent to the scans
+// endpoint carries the stream id in its meta, and replaces the batches.
+func (api *API) ScanFindings(batch ScanFindingsBatch) error {
+	endpoint := Endpoints.ScanFindings
+	_, err := api.makeRequest(
+		endpoint.Route,
+		endpoint.HttpMethod,
+		Message{
+			Type: MessageTypeSuccess,
+			Data: batch,
+		})
+
+	return err
+}
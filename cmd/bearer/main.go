@@ -1,6 +1,9 @@
 package main
 
 import (
+	"errors"
+	"os"
+
 	"github.com/bearer/bearer/cmd/bearer/build"
 
 	"github.com/bearer/bearer/internal/commands"
@@ -9,6 +12,11 @@ import (
 
 func main() {
 	if err := run(); err != nil {
+		var exitCodeError *commands.ExitCodeError
+		if errors.As(err, &exitCodeError) {
+			os.Exit(exitCodeError.Code)
+		}
+
 		output.Fatal(err.Error())
 	}
 }
@@ -26,3 +26,26 @@ func TestApiKeyFlags(t *testing.T) {
 
 	testhelper.RunTests(t, tests)
 }
+
+func TestApiKeyFlagsOffline(t *testing.T) {
+	t.Parallel()
+	arguments := []string{
+		"scan",
+		filepath.Join("e2e", "flags", "testdata", "simple"),
+		"--offline",
+		"--disable-default-rules",
+		"--api-key",
+		"123",
+		"--format",
+		"json",
+	}
+	tests := []testhelper.TestCase{
+		testhelper.NewTestCase("offline-api-key", arguments, testhelper.TestCaseOptions{DisplayStdErr: true, IgnoreForce: false}),
+	}
+
+	for i := range tests {
+		tests[i].ShouldSucceed = false
+	}
+
+	testhelper.RunTests(t, tests)
+}
@@ -24,6 +24,8 @@ func newScanTest(name string, arguments []string) testhelper.TestCase {
 func TestReportFlags(t *testing.T) {
 	tests := []testhelper.TestCase{
 		newScanTest("report-dataflow", []string{"--report=dataflow"}),
+		newScanTest("report-ropa", []string{"--report=ropa"}),
+		newScanTest("report-logs", []string{"--report=logs"}),
 	}
 
 	testhelper.RunTests(t, tests)
@@ -37,6 +39,15 @@ func TestReportFlagsShouldFail(t *testing.T) {
 		newScanTest("invalid-format-flag-privacy", []string{"--report=privacy", "--format=testing"}),
 		newScanTest("invalid-context-flag", []string{"--context=testing"}),
 		newScanTest("format-jsonv2", []string{"--format=jsonv2", "--external-rule-dir=e2e/testdata/rules"}),
+		newScanTest("format-jsonv2-meta", []string{"--format=jsonv2", "--external-rule-dir=e2e/testdata/rules", "--meta=tier=1,business-unit=payments"}),
+		newScanTest("invalid-meta-flag", []string{"--meta=tier"}),
+		newScanTest("multiple-formats-without-output-dir", []string{"--format=json,sarif"}),
+		newScanTest("invalid-format-flag-ropa", []string{"--report=ropa", "--format=html"}),
+		newScanTest("invalid-format-flag-logs", []string{"--report=logs", "--format=sarif"}),
+		newScanTest("residency-without-region", []string{"--report=residency"}),
+		newScanTest("invalid-processor-category", []string{"--report=privacy", "--processor-category=marketing"}),
+		newScanTest("fail-on-processor-category-privacy", []string{"--report=privacy", "--fail-on-processor-category=advertising"}),
+		newScanTest("processing-purposes-without-ropa", []string{"--processing-purposes=purposes.yml"}),
 	}
 
 	for i := range tests {
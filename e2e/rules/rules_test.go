@@ -2,6 +2,7 @@ package rules_test
 
 import (
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/bearer/bearer/e2e/internal/testhelper"
@@ -44,6 +45,176 @@ func TestSanitizer(t *testing.T) {
 	runRulesTest("sanitizer", "sanitizer_test", t)
 }
 
+func TestSanitizerRules(t *testing.T) {
+	testDataDir := filepath.Join("e2e", "rules", "testdata/data/sanitizer_rules")
+
+	testCases := []testhelper.TestCase{
+		testhelper.NewTestCase(
+			"sanitizer_rules",
+			[]string{
+				"scan",
+				testDataDir,
+				"--only-rule=sanitizer_rules_test",
+				"--format=yaml",
+				"--disable-default-rules",
+				"--exit-code=0",
+				"--external-rule-dir=" + filepath.Join("e2e", "rules", "testdata", "rules"),
+				"--config-file=" + filepath.Join(testDataDir, "bearer.yml"),
+			},
+			testhelper.TestCaseOptions{},
+		),
+	}
+
+	testhelper.RunTestsWithSnapshotSubdirectory(t, testCases, ".snapshots")
+}
+
+func TestMappingFilters(t *testing.T) {
+	testDataDir := filepath.Join("e2e", "rules", "testdata/data/mapping_filters")
+	arguments := []string{
+		"scan",
+		testDataDir,
+		"--format=yaml",
+		"--disable-default-rules",
+		"--exit-code=0",
+		"--external-rule-dir=" + filepath.Join("e2e", "rules", "testdata", "rules"),
+	}
+
+	testCases := []testhelper.TestCase{
+		testhelper.NewTestCase(
+			"only_cwe",
+			append(slices.Clone(arguments), "--only-cwe=CWE-95"),
+			testhelper.TestCaseOptions{},
+		),
+		testhelper.NewTestCase(
+			"only_owasp",
+			append(slices.Clone(arguments), "--only-owasp=A09"),
+			testhelper.TestCaseOptions{},
+		),
+	}
+
+	testhelper.RunTests(t, testCases)
+}
+
+func TestDeprecatedRules(t *testing.T) {
+	testDataDir := filepath.Join("e2e", "rules", "testdata/data/deprecated_rules")
+
+	testCases := []testhelper.TestCase{
+		testhelper.NewTestCase(
+			"deprecated_rules",
+			[]string{
+				"scan",
+				testDataDir,
+				"--only-rule=deprecated_rules_old_test",
+				"--format=yaml",
+				"--disable-default-rules",
+				"--exit-code=0",
+				"--external-rule-dir=" + filepath.Join("e2e", "rules", "testdata", "rules"),
+				"--config-file=" + filepath.Join(testDataDir, "bearer.yml"),
+				"--ignore-file=" + filepath.Join(testDataDir, "bearer.ignore"),
+			},
+			testhelper.TestCaseOptions{},
+		),
+	}
+
+	testhelper.RunTests(t, testCases)
+}
+
+func TestParameterizedRules(t *testing.T) {
+	testDataDir := filepath.Join("e2e", "rules", "testdata/data/parameterized_rules")
+	arguments := []string{
+		"scan",
+		testDataDir,
+		"--only-rule=parameterized_rules_test",
+		"--format=yaml",
+		"--disable-default-rules",
+		"--exit-code=0",
+		"--external-rule-dir=" + filepath.Join("e2e", "rules", "testdata", "rules"),
+	}
+
+	testCases := []testhelper.TestCase{
+		testhelper.NewTestCase(
+			"parameter_defaults",
+			slices.Clone(arguments),
+			testhelper.TestCaseOptions{},
+		),
+		testhelper.NewTestCase(
+			"configured_parameters",
+			append(slices.Clone(arguments), "--config-file="+filepath.Join(testDataDir, "bearer.yml")),
+			testhelper.TestCaseOptions{},
+		),
+	}
+
+	testhelper.RunTests(t, testCases)
+}
+
+func TestDependencyVersions(t *testing.T) {
+	runRulesTest("dependency_versions", "dependency_versions_test", t)
+}
+
+func TestNamespacedRules(t *testing.T) {
+	runRulesTest("namespaced_rules", "acme:namespaced_rules_test", t)
+}
+
+func TestRuleIDCollisions(t *testing.T) {
+	testCases := []testhelper.TestCase{
+		testhelper.NewTestCase(
+			"rule_id_collisions",
+			[]string{
+				"scan",
+				filepath.Join("e2e", "rules", "testdata/data/namespaced_rules"),
+				"--format=yaml",
+				"--disable-default-rules",
+				"--exit-code=0",
+				"--external-rule-dir=" + filepath.Join("e2e", "rules", "testdata", "colliding_rules"),
+			},
+			testhelper.TestCaseOptions{},
+		),
+	}
+	testCases[0].ShouldSucceed = false
+
+	testhelper.RunTestsWithSnapshotSubdirectory(t, testCases, ".snapshots")
+}
+
+func TestRulesLockfile(t *testing.T) {
+	newTestCase := func(name string, lockfile string) testhelper.TestCase {
+		return testhelper.NewTestCase(
+			name,
+			[]string{
+				"scan",
+				filepath.Join("e2e", "rules", "testdata/data/namespaced_rules"),
+				"--format=yaml",
+				"--disable-default-rules",
+				"--exit-code=0",
+				"--external-rule-dir=e2e/rules/testdata/locked_rules",
+				"--rules-lockfile=" + filepath.Join("e2e", "rules", "testdata", "rules_locks", lockfile),
+			},
+			testhelper.TestCaseOptions{},
+		)
+	}
+
+	testCases := []testhelper.TestCase{
+		newTestCase("rules_lockfile_match", "bearer_rules.lock"),
+		newTestCase("rules_lockfile_tampered", "tampered.lock"),
+		newTestCase("rules_lockfile_missing_source", "other_rules.lock"),
+	}
+	testCases[1].ShouldSucceed = false
+	testCases[2].ShouldSucceed = false
+
+	testhelper.RunTestsWithSnapshotSubdirectory(t, testCases, ".snapshots")
+}
+
+func TestTaintExtensions(t *testing.T) {
+	runRulesTest("taint_extensions", "taint_extensions_test", t)
+}
+
+func TestCompositeRules(t *testing.T) {
+	runRulesTest("composite_rules", "composite_rules_test", t)
+}
+
+func TestVariableConstraints(t *testing.T) {
+	runRulesTest("variable_constraints", "variable_constraints_test", t)
+}
+
 func TestSimpleRuby(t *testing.T) {
 	runRulesTest("simple_ruby", "ruby_rails_insecure_communication_test", t)
 }
@@ -56,6 +227,18 @@ func TestRubyRailsDefaultEncryptionSchema(t *testing.T) {
 	runRulesTest("ruby_rails_default_encryption_schema_rb", "ruby_rails_default_encryption", t)
 }
 
+func TestUnusedDataTypes(t *testing.T) {
+	runRulesTest("unused_data_types", "ruby_rails_unused_sensitive_data", t)
+}
+
+func TestEmbeddedLanguages(t *testing.T) {
+	runRulesTest(
+		"embedded_languages",
+		"embedded_languages_sql_test,embedded_languages_shell_test,embedded_languages_html_test",
+		t,
+	)
+}
+
 func TestExpectedRule(t *testing.T) {
 	testDataDir := "testdata/data/expected_rule"
 
@@ -70,3 +253,54 @@ func TestExpectedRule(t *testing.T) {
 
 	testhelper.RunTestsWithSnapshotSubdirectory(t, testCases, ".snapshots")
 }
+
+func TestRuleTimeout(t *testing.T) {
+	testDataDir := "testdata/data/simple_ruby"
+
+	testCases := []testhelper.TestCase{
+		testhelper.NewTestCase(
+			"rule_timeout",
+			[]string{
+				"scan",
+				filepath.Join("e2e", "rules", testDataDir),
+				"--only-rule=ruby_rails_insecure_communication_test",
+				"--report=dataflow",
+				"--format=yaml",
+				"--disable-default-rules",
+				"--exit-code=0",
+				"--rule-timeout=1ns",
+				"--external-rule-dir=" + filepath.Join("e2e", "rules", "testdata", "rules"),
+			},
+			testhelper.TestCaseOptions{},
+		),
+	}
+
+	testhelper.RunTestsWithSnapshotSubdirectory(t, testCases, ".snapshots")
+}
+
+func TestConfidence(t *testing.T) {
+	testDataDir := filepath.Join("e2e", "rules", "testdata/data/confidence")
+	arguments := []string{
+		"scan",
+		testDataDir,
+		"--only-rule=confidence_test",
+		"--format=yaml",
+		"--disable-default-rules",
+		"--external-rule-dir=" + filepath.Join("e2e", "rules", "testdata", "rules"),
+	}
+
+	testCases := []testhelper.TestCase{
+		testhelper.NewTestCase(
+			"confidence",
+			append(slices.Clone(arguments), "--exit-code=0"),
+			testhelper.TestCaseOptions{},
+		),
+		testhelper.NewTestCase(
+			"min_confidence",
+			append(slices.Clone(arguments), "--min-confidence=high"),
+			testhelper.TestCaseOptions{},
+		),
+	}
+
+	testhelper.RunTests(t, testCases)
+}
@@ -0,0 +1,13 @@
+def show
+  name = params[:name]
+  name.html_safe
+end
+
+def preview
+  name = params[:name]
+  sanitize(name).html_safe
+end
+
+def help
+  HELP_TEXT.html_safe
+end
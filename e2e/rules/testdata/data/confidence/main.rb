@@ -0,0 +1,2 @@
+user.public_send(params[:method])
+user.send(params[:method])
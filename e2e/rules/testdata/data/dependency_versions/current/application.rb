@@ -0,0 +1 @@
+config.action_dispatch.cookies_serializer = :marshal
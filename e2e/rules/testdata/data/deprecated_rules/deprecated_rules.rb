@@ -0,0 +1,4 @@
+logger.info(params[:name])
+# bearer:disable deprecated_rules_old_test
+logger.info(params[:other])
+logger.info(params[:third])
@@ -0,0 +1,13 @@
+public class Main {
+  public void find(HttpServletRequest request) {
+    String query = """
+      SELECT name
+      FROM users
+      WHERE id = """ + request.getParameter("id");
+    statement.executeQuery(query);
+
+    statement.executeQuery("""
+      SELECT name FROM users WHERE id = 1
+      """);
+  }
+}
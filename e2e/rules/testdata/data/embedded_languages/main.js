@@ -0,0 +1,11 @@
+const query = `SELECT name FROM users WHERE id = ${req.query.id} ORDER BY name`
+db.query(query)
+
+db.query(`SELECT name FROM users WHERE id = ?`, [req.query.id])
+
+const status = "active"
+db.query(`SELECT name FROM users WHERE status = '${status}'`)
+
+exec("rm -f " + req.query.path)
+
+res.send(`<p><a class="link" href="${req.query.url}">home</a></p>`)
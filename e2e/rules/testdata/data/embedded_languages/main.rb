@@ -0,0 +1,14 @@
+query = <<~SQL
+  SELECT name
+  FROM users
+  WHERE id = #{params[:id]}
+SQL
+DB.execute(query)
+
+status = "active"
+DB.execute("SELECT name FROM users WHERE status = '#{status}'")
+
+system("rm -rf /tmp/#{params[:name]}")
+system("rm -rf /tmp/cache")
+
+link = "<a href='#{params[:url]}'>home</a>"
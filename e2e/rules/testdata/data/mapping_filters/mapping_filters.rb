@@ -0,0 +1,2 @@
+logger.info(params[:name])
+eval(params[:code])
@@ -0,0 +1,4 @@
+AuditLog.write(params[:event])
+
+# bearer:disable acme:namespaced_rules_test
+AuditLog.write(params[:other_event])
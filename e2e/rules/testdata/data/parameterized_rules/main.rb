@@ -0,0 +1,3 @@
+session_store(:cookie, expire_after: 3600)
+session_store(:cookie, expire_after: 7200)
+session_store(:cookie, expire_after: 172800)
@@ -0,0 +1,10 @@
+# unsanitized
+DB.execute(params[:id])
+
+# sanitized by a sanitizer rule
+DB.execute(sanitize_sql(params[:id]))
+
+# sanitized by a sanitizer from the configuration
+DB.execute(escape_input(params[:id]))
+name = escape_input(params[:name])
+DB.execute(name)
@@ -0,0 +1,8 @@
+DB.execute("SELECT * FROM users WHERE id = #{params[:id]}")
+DB.execute("SELECT * FROM users WHERE id = #{AcmeRequest.input(:id)}")
+
+id = AcmeRequest.input(:id)
+AcmeClient.run_query("SELECT * FROM users WHERE id = #{id}")
+
+DB.execute("SELECT * FROM users")
+AcmeClient.run_query("SELECT * FROM users WHERE id = #{Current.user.id}")
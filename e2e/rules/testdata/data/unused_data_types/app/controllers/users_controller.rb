@@ -0,0 +1,7 @@
+class UsersController < ApplicationController
+  def show
+    user = User.find(params[:id])
+
+    render json: { email: user.email, first_name: user.first_name }
+  end
+end
@@ -0,0 +1,9 @@
+ActiveRecord::Schema[7.0].define(version: 2023_03_02_101500) do
+  create_table "users", force: :cascade do |t|
+    t.string "email", null: false
+    t.string "first_name"
+    t.date "date_of_birth"
+    t.string "phone_number"
+    t.datetime "created_at", null: false
+  end
+end
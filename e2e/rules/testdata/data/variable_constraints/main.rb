@@ -0,0 +1,6 @@
+Digest::MD5.hexdigest(password)
+Digest::SHA1.hexdigest(password)
+Digest::SHA256.hexdigest(password)
+
+OpenSSL::Cipher.new("des")
+OpenSSL::Cipher.new("aes-256-gcm")
@@ -24,9 +24,12 @@ type Config struct {
 }
 
 func NewClassifier(config *Config) (*Classifier, error) {
+	// merge custom recipes, if present
+	recipes := db.Default().WithRecipes(config.Config.Recipes).Recipes
+
 	interfacesClassifier, err := interfaces.New(
 		interfaces.Config{
-			Recipes:         db.Default().Recipes,
+			Recipes:         recipes,
 			InternalDomains: config.Config.Scan.InternalDomains,
 			DomainResolver: url.NewDomainResolver(
 				!config.Config.Scan.DisableDomainResolution,
@@ -39,31 +42,42 @@ func NewClassifier(config *Config) (*Classifier, error) {
 	}
 
 	// apply subject mapping override, if present
-	var knownPersonObjectPatterns []db.KnownPersonObjectPattern
+	defaultDB := db.Default()
 	if config.Config.Scan.DataSubjectMapping != "" {
-		knownPersonObjectPatterns = db.DefaultWithMapping(config.Config.Scan.DataSubjectMapping).KnownPersonObjectPatterns
-	} else {
-		knownPersonObjectPatterns = db.Default().KnownPersonObjectPatterns
+		defaultDB = db.DefaultWithMapping(config.Config.Scan.DataSubjectMapping)
+	}
+
+	// merge custom data types, if present
+	defaultDB, err = defaultDB.WithDataTypeDefinitions(config.Config.Scan.DataTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	// apply data type overrides, if present
+	defaultDB, err = defaultDB.WithDataTypeOverrides(config.Config.Scan.DataTypeOverrides)
+	if err != nil {
+		return nil, err
 	}
 
 	schemaClassifier := schema.New(
 		schema.Config{
-			DataTypes:                      db.Default().DataTypes,
-			DataTypeClassificationPatterns: db.Default().DataTypeClassificationPatterns,
-			KnownPersonObjectPatterns:      knownPersonObjectPatterns,
+			DataTypes:                      defaultDB.DataTypes,
+			DataTypeClassificationPatterns: defaultDB.DataTypeClassificationPatterns,
+			KnownPersonObjectPatterns:      defaultDB.KnownPersonObjectPatterns,
+			DataSubjects:                   config.Config.Scan.DataSubjects,
 			Context:                        config.Config.Scan.Context,
 		},
 	)
 
 	dependenciesClassifier := dependencies.New(
 		dependencies.Config{
-			Recipes: db.Default().Recipes,
+			Recipes: recipes,
 		},
 	)
 
 	frameworksClassifier := frameworks.New(
 		frameworks.Config{
-			Recipes: db.Default().Recipes,
+			Recipes: recipes,
 		},
 	)
 
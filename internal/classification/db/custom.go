@@ -0,0 +1,205 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/util/normalize_key"
+)
+
+// Custom patterns are given ids after the built-in ones so they never clash
+const customPatternIdOffset = 10000
+
+var customDataTypeNamespace = uuid.MustParse("5c4a2a5e-6f0d-4b8e-9b3a-2a8e0c1f7d42")
+
+// LoadDataTypeDefinitions reads the custom data types declared in the .yml
+// files of the given directories. Each file contains a list of definitions.
+func LoadDataTypeDefinitions(dirs []string) ([]flag.DataTypeDefinition, error) {
+	var definitions []flag.DataTypeDefinition
+
+	for _, dir := range dirs {
+		if strings.HasPrefix(dir, "~/") {
+			dirname, _ := os.UserHomeDir()
+			dir = filepath.Join(dirname, dir[2:])
+		}
+
+		err := filepath.WalkDir(dir, func(path string, dirEntry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			ext := filepath.Ext(path)
+			if dirEntry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			var fileDefinitions []flag.DataTypeDefinition
+			if err := yaml.Unmarshal(content, &fileDefinitions); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+
+			definitions = append(definitions, fileDefinitions...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load data types from %s: %w", dir, err)
+		}
+	}
+
+	return definitions, nil
+}
+
+// WithDataTypeDefinitions returns a copy of the database with the given custom
+// data types merged in. A definition with the same name as a built-in data
+// type adds its patterns to the built-in type. Custom patterns are checked
+// before the built-in ones.
+func (defaultDB DefaultDB) WithDataTypeDefinitions(definitions []flag.DataTypeDefinition) (DefaultDB, error) {
+	if len(definitions) == 0 {
+		return defaultDB, nil
+	}
+
+	dataTypes := slices.Clone(defaultDB.DataTypes)
+	var patterns []DataTypeClassificationPattern
+
+	for i, definition := range definitions {
+		dataType, isNew, err := resolveDataType(defaultDB, dataTypes, definition)
+		if err != nil {
+			return DefaultDB{}, fmt.Errorf("data type %q: %w", definition.Name, err)
+		}
+		if isNew {
+			dataTypes = append(dataTypes, dataType)
+		}
+
+		pattern, err := customPattern(customPatternIdOffset+i, dataType, definition)
+		if err != nil {
+			return DefaultDB{}, fmt.Errorf("data type %q: %w", definition.Name, err)
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	defaultDB.DataTypes = dataTypes
+	defaultDB.DataTypeClassificationPatterns = append(patterns, defaultDB.DataTypeClassificationPatterns...)
+
+	return defaultDB, nil
+}
+
+func resolveDataType(
+	defaultDB DefaultDB,
+	dataTypes []DataType,
+	definition flag.DataTypeDefinition,
+) (DataType, bool, error) {
+	if strings.TrimSpace(definition.Name) == "" {
+		return DataType{}, false, errors.New("name is required")
+	}
+
+	for _, dataType := range dataTypes {
+		if !strings.EqualFold(dataType.Name, definition.Name) {
+			continue
+		}
+
+		if definition.Category != "" && !strings.EqualFold(dataType.Category.Name, definition.Category) {
+			return DataType{}, false, fmt.Errorf("already exists in category %q", dataType.Category.Name)
+		}
+
+		return dataType, false, nil
+	}
+
+	if definition.Category == "" {
+		return DataType{}, false, errors.New("category is required")
+	}
+
+	var categoryNames []string
+	for _, category := range defaultDB.DataCategories {
+		if strings.EqualFold(category.Name, definition.Category) {
+			return DataType{
+				Name:         definition.Name,
+				UUID:         uuid.NewSHA1(customDataTypeNamespace, []byte(definition.Name)).String(),
+				CategoryUUID: category.UUID,
+				Category:     category,
+			}, true, nil
+		}
+
+		categoryNames = append(categoryNames, category.Name)
+	}
+
+	slices.Sort(categoryNames)
+	return DataType{}, false, fmt.Errorf(
+		"unknown category %q; supported values: %s",
+		definition.Category,
+		strings.Join(categoryNames, ", "),
+	)
+}
+
+func customPattern(id int, dataType DataType, definition flag.DataTypeDefinition) (DataTypeClassificationPattern, error) {
+	columnNames := slices.Clone(definition.ColumnNames)
+	locales := maps.Keys(definition.Locales)
+	slices.Sort(locales)
+	for _, locale := range locales {
+		columnNames = append(columnNames, definition.Locales[locale]...)
+	}
+
+	var includes []string
+	for _, pattern := range definition.Patterns {
+		includes = append(includes, "(?:"+pattern+")")
+	}
+	for _, columnName := range columnNames {
+		includes = append(includes, "^"+regexp.QuoteMeta(normalize_key.Normalize(columnName))+"$")
+	}
+	if len(includes) == 0 {
+		return DataTypeClassificationPattern{}, errors.New("at least one pattern or column name is required")
+	}
+
+	pattern := DataTypeClassificationPattern{
+		Id:                  id,
+		DataTypeUUID:        dataType.UUID,
+		DataType:            dataType,
+		IncludeRegexp:       strings.Join(includes, "|"),
+		ExcludeTypes:        definition.ExcludeTypes,
+		ExcludeTypesMapping: map[string]struct{}{},
+		FriendlyName:        dataType.Name,
+		MatchColumn:         true,
+		MatchIdentifier:     true,
+		ObjectType:          []string{string(KnownObject), string(ExtendedUnknownObject), string(UnknownObject)},
+		ObjectTypeMapping:   map[string]struct{}{},
+	}
+
+	var err error
+	pattern.IncludeRegexpMatcher, err = regexp.Compile(pattern.IncludeRegexp)
+	if err != nil {
+		return DataTypeClassificationPattern{}, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	if len(definition.ExcludePatterns) != 0 {
+		pattern.ExcludeRegexp = strings.Join(definition.ExcludePatterns, "|")
+		pattern.ExcludeRegexpMatcher, err = regexp.Compile(pattern.ExcludeRegexp)
+		if err != nil {
+			return DataTypeClassificationPattern{}, fmt.Errorf("invalid exclude pattern: %w", err)
+		}
+	}
+
+	for _, excludeType := range pattern.ExcludeTypes {
+		pattern.ExcludeTypesMapping[excludeType] = struct{}{}
+	}
+	for _, objectType := range pattern.ObjectType {
+		pattern.ObjectTypeMapping[objectType] = struct{}{}
+	}
+
+	return pattern, nil
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+
+	globaltypes "github.com/bearer/bearer/internal/types"
+)
+
+var customRecipeNamespace = uuid.MustParse("0f3b6d0e-8a47-4d1c-9d59-6f7e3a2b9c15")
+
+var recipeTypes = []string{"data_store", "external_service", "internal_service"}
+
+// LoadRecipes reads the custom component recipes in the .json and .yml files
+// of the given directories. Each file contains one recipe, in the same format
+// as the built-in recipes.
+func LoadRecipes(dirs []string) ([]Recipe, error) {
+	var recipes []Recipe
+
+	for _, dir := range dirs {
+		if strings.HasPrefix(dir, "~/") {
+			dirname, _ := os.UserHomeDir()
+			dir = filepath.Join(dirname, dir[2:])
+		}
+
+		err := filepath.WalkDir(dir, func(path string, dirEntry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			ext := filepath.Ext(path)
+			if dirEntry.IsDir() || (ext != ".json" && ext != ".yml" && ext != ".yaml") {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			// YAML is a superset of JSON, so this handles both formats
+			var recipe Recipe
+			if err := yaml.Unmarshal(content, &recipe); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+
+			if err := validateRecipe(&recipe); err != nil {
+				return fmt.Errorf("recipe %s: %w", path, err)
+			}
+
+			recipes = append(recipes, recipe)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load recipes from %s: %w", dir, err)
+		}
+	}
+
+	return recipes, nil
+}
+
+func validateRecipe(recipe *Recipe) error {
+	if strings.TrimSpace(recipe.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	validType := false
+	for _, recipeType := range recipeTypes {
+		if recipe.Type == recipeType {
+			validType = true
+		}
+	}
+	if !validType {
+		return fmt.Errorf("invalid type %q; supported values: %s", recipe.Type, strings.Join(recipeTypes, ", "))
+	}
+
+	if recipe.Category != "" && !slices.Contains(globaltypes.ProcessorCategories, recipe.Category) {
+		return fmt.Errorf("invalid category %q; supported values: %s", recipe.Category, strings.Join(globaltypes.ProcessorCategories, ", "))
+	}
+
+	if len(recipe.URLS) == 0 && len(recipe.Packages) == 0 {
+		return errors.New("at least one url or package is required")
+	}
+
+	if recipe.UUID == "" {
+		recipe.UUID = uuid.NewSHA1(customRecipeNamespace, []byte(recipe.Name)).String()
+	}
+
+	return nil
+}
+
+// WithRecipes returns a copy of the database with the given custom recipes
+// added. A custom recipe with the same name as a built-in one replaces it.
+func (defaultDB DefaultDB) WithRecipes(recipes []Recipe) DefaultDB {
+	if len(recipes) == 0 {
+		return defaultDB
+	}
+
+	customNames := make(map[string]struct{})
+	for _, recipe := range recipes {
+		customNames[strings.ToLower(recipe.Name)] = struct{}{}
+	}
+
+	result := make([]Recipe, 0, len(defaultDB.Recipes)+len(recipes))
+	for _, recipe := range defaultDB.Recipes {
+		if _, replaced := customNames[strings.ToLower(recipe.Name)]; !replaced {
+			result = append(result, recipe)
+		}
+	}
+
+	defaultDB.Recipes = append(result, recipes...)
+
+	return defaultDB
+}
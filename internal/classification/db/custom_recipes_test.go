@@ -0,0 +1,98 @@
+package db_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/classification/db"
+)
+
+func TestLoadRecipes(t *testing.T) {
+	recipes, err := db.LoadRecipes([]string{"testdata/recipes"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if assert.Len(t, recipes, 2) {
+		assert.Equal(t, "Billing Service", recipes[0].Name)
+		assert.Equal(t, []string{"https://billing.internal.example.com"}, recipes[0].URLS)
+		assert.NotEmpty(t, recipes[0].UUID, "a uuid is derived from the name")
+
+		assert.Equal(t, db.Recipe{
+			Name:     "PayNordic",
+			Type:     "external_service",
+			SubType:  "third_party",
+			UUID:     "6d1c7a52-3f0e-4f0b-8f8e-2a9d4c1b7e63",
+			URLS:     []string{"https://api.paynordic.example"},
+			Packages: []db.Package{{Name: "paynordic", PackageManager: "rubygems"}},
+		}, recipes[1])
+	}
+
+	_, err = db.LoadRecipes([]string{"testdata/missing"})
+	assert.Error(t, err)
+
+	for _, testCase := range []struct {
+		Name    string
+		Content string
+		Error   string
+	}{
+		{
+			Name:    "missing name",
+			Content: `{"type": "data_store", "urls": ["https://db.example.com"]}`,
+			Error:   "name is required",
+		},
+		{
+			Name:    "invalid type",
+			Content: `{"name": "Queue", "type": "queue", "urls": ["https://queue.example.com"]}`,
+			Error:   `invalid type "queue"; supported values: data_store, external_service, internal_service`,
+		},
+		{
+			Name:    "invalid category",
+			Content: `{"name": "Queue", "type": "external_service", "category": "queue", "urls": ["https://queue.example.com"]}`,
+			Error:   `invalid category "queue"; supported values: advertising, analytics, communication, infrastructure, monitoring, payment`,
+		},
+		{
+			Name:    "no urls or packages",
+			Content: `{"name": "Queue", "type": "data_store"}`,
+			Error:   "at least one url or package is required",
+		},
+	} {
+		t.Run(testCase.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "recipe.json"), []byte(testCase.Content), 0644); err != nil {
+				t.Fatalf("failed to write recipe, err: %s", err)
+			}
+
+			_, err := db.LoadRecipes([]string{dir})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), testCase.Error)
+			}
+		})
+	}
+}
+
+func TestWithRecipes(t *testing.T) {
+	defaultDB := db.Default()
+
+	result := defaultDB.WithRecipes([]db.Recipe{
+		{Name: "Billing Service", Type: "internal_service", URLS: []string{"https://billing.internal.example.com"}},
+		{Name: "stripe", Type: "external_service", URLS: []string{"https://stripe.internal.example.com"}},
+	})
+
+	assert.Len(t, result.Recipes, len(defaultDB.Recipes)+1)
+	assert.Len(t, defaultDB.Recipes, len(db.Default().Recipes), "the original database is unchanged")
+
+	var stripeRecipes []db.Recipe
+	for _, recipe := range result.Recipes {
+		if recipe.Name == "Stripe" || recipe.Name == "stripe" {
+			stripeRecipes = append(stripeRecipes, recipe)
+		}
+	}
+
+	if assert.Len(t, stripeRecipes, 1, "custom recipes replace built-in ones with the same name") {
+		assert.Equal(t, []string{"https://stripe.internal.example.com"}, stripeRecipes[0].URLS)
+	}
+}
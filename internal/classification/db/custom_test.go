@@ -0,0 +1,114 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/classification/db"
+	"github.com/bearer/bearer/internal/flag"
+)
+
+func TestLoadDataTypeDefinitions(t *testing.T) {
+	definitions, err := db.LoadDataTypeDefinitions([]string{"testdata/data_types"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	assert.Equal(t, []flag.DataTypeDefinition{
+		{
+			Name:        "Claim ID",
+			Category:    "Financial Accounts",
+			ColumnNames: []string{"claim_id", "claim_number"},
+		},
+		{
+			Name:     "Vehicle Identification Number",
+			Category: "Personal Ownership",
+			Patterns: []string{`\bvin\b`},
+			Locales:  map[string][]string{"de": {"fahrgestellnummer"}},
+		},
+	}, definitions)
+
+	_, err = db.LoadDataTypeDefinitions([]string{"testdata/missing"})
+	assert.Error(t, err)
+}
+
+func TestWithDataTypeDefinitions(t *testing.T) {
+	defaultDB := db.Default()
+
+	t.Run("adds new data types before the built-in patterns", func(t *testing.T) {
+		result, err := defaultDB.WithDataTypeDefinitions([]flag.DataTypeDefinition{
+			{Name: "Claim ID", Category: "financial accounts", ColumnNames: []string{"claimNumber"}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		assert.Len(t, result.DataTypes, len(defaultDB.DataTypes)+1)
+		assert.Len(t, result.DataTypeClassificationPatterns, len(defaultDB.DataTypeClassificationPatterns)+1)
+
+		pattern := result.DataTypeClassificationPatterns[0]
+		assert.Equal(t, "Claim ID", pattern.DataType.Name)
+		assert.Equal(t, "Financial Accounts", pattern.DataType.Category.Name)
+		assert.Equal(t, pattern.DataType.Category.UUID, pattern.DataType.CategoryUUID)
+		assert.Equal(t, `^claim number$`, pattern.IncludeRegexp)
+		assert.NotEmpty(t, pattern.DataTypeUUID)
+		assert.Len(t, defaultDB.DataTypes, len(db.Default().DataTypes), "the original database is unchanged")
+	})
+
+	t.Run("extends built-in data types", func(t *testing.T) {
+		result, err := defaultDB.WithDataTypeDefinitions([]flag.DataTypeDefinition{
+			{Name: "email address", Patterns: []string{`\bcourriel\b`}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		assert.Len(t, result.DataTypes, len(defaultDB.DataTypes))
+		assert.Equal(t, "Email Address", result.DataTypeClassificationPatterns[0].DataType.Name)
+	})
+
+	for _, testCase := range []struct {
+		Name       string
+		Definition flag.DataTypeDefinition
+		Error      string
+	}{
+		{
+			Name:       "missing name",
+			Definition: flag.DataTypeDefinition{Category: "Contact", Patterns: []string{"x"}},
+			Error:      `data type "": name is required`,
+		},
+		{
+			Name:       "missing category",
+			Definition: flag.DataTypeDefinition{Name: "VIN", Patterns: []string{"vin"}},
+			Error:      `data type "VIN": category is required`,
+		},
+		{
+			Name:       "unknown category",
+			Definition: flag.DataTypeDefinition{Name: "VIN", Category: "Vehicles", Patterns: []string{"vin"}},
+			Error:      `data type "VIN": unknown category "Vehicles"; supported values: Authenticating,`,
+		},
+		{
+			Name:       "conflicting category",
+			Definition: flag.DataTypeDefinition{Name: "Email Address", Category: "Location", Patterns: []string{"mail"}},
+			Error:      `data type "Email Address": already exists in category "Contact"`,
+		},
+		{
+			Name:       "no patterns",
+			Definition: flag.DataTypeDefinition{Name: "VIN", Category: "Personal Ownership"},
+			Error:      `data type "VIN": at least one pattern or column name is required`,
+		},
+		{
+			Name:       "invalid pattern",
+			Definition: flag.DataTypeDefinition{Name: "VIN", Category: "Personal Ownership", Patterns: []string{"(vin"}},
+			Error:      `data type "VIN": invalid pattern`,
+		},
+	} {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := defaultDB.WithDataTypeDefinitions([]flag.DataTypeDefinition{testCase.Definition})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), testCase.Error)
+			}
+		})
+	}
+}
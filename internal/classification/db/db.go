@@ -49,6 +49,7 @@ type Recipe struct {
 	Name        string    `json:"name" yaml:"name"`
 	Type        string    `json:"type" yaml:"type"`
 	SubType     string    `json:"sub_type" yaml:"sub_type"`
+	Category    string    `json:"category,omitempty" yaml:"category,omitempty"`
 	Packages    []Package `json:"packages" yaml:"packages"`
 	UUID        string    `json:"uuid" yaml:"uuid"`
 }
@@ -116,6 +117,7 @@ type DataTypeClassificationPattern struct {
 	HealthContextDataType     DataType            `json:"health_context_data_type" yaml:"health_context_data_type"`
 	MatchColumn               bool                `json:"match_column" yaml:"match_column"`
 	MatchObject               bool                `json:"match_object" yaml:"match_object"`
+	MatchIdentifier           bool                `json:"match_identifier" yaml:"match_identifier"`
 	ObjectType                []string            `json:"object_type" yaml:"object_type"`
 	ObjectTypeMapping         map[string]struct{} `json:"object_types_mapping" yaml:"object_types_mapping"`
 }
@@ -145,6 +147,17 @@ func DefaultWithContext(context flag.Context) DefaultDB {
 	return defaultDB(context, "")
 }
 
+// DefaultForScan returns the database for the context of the scan, with its
+// custom data types and data type overrides applied
+func DefaultForScan(options flag.ScanOptions) (DefaultDB, error) {
+	defaultDB, err := DefaultWithContext(options.Context).WithDataTypeDefinitions(options.DataTypes)
+	if err != nil {
+		return DefaultDB{}, err
+	}
+
+	return defaultDB.WithDataTypeOverrides(options.DataTypeOverrides)
+}
+
 func defaultDB(context flag.Context, subjectMappingPath string) DefaultDB {
 	dataCategories := defaultDataCategories(context)
 	categories := map[string]DataCategory{}
@@ -196,18 +209,7 @@ func defaultDataCategories(context flag.Context) []DataCategory {
 	}
 
 	dataCategories := []DataCategory{}
-
-	categoryGroupingJson, err := categoryGroupingFile.ReadFile("category_grouping.json")
-	if err != nil {
-		handleError(err)
-	}
-
-	var dataCategoryGrouping DataCategoryGrouping
-	rawBytes := []byte(categoryGroupingJson)
-	err = json.Unmarshal(rawBytes, &dataCategoryGrouping)
-	if err != nil {
-		handleError(err)
-	}
+	dataCategoryGrouping := defaultDataCategoryGrouping()
 
 	files, err := dataCategoriesDir.ReadDir("data_categories")
 	if err != nil {
@@ -254,6 +256,22 @@ func defaultDataCategories(context flag.Context) []DataCategory {
 	return dataCategories
 }
 
+func defaultDataCategoryGrouping() DataCategoryGrouping {
+	categoryGroupingJson, err := categoryGroupingFile.ReadFile("category_grouping.json")
+	if err != nil {
+		handleError(err)
+	}
+
+	var dataCategoryGrouping DataCategoryGrouping
+	rawBytes := []byte(categoryGroupingJson)
+	err = json.Unmarshal(rawBytes, &dataCategoryGrouping)
+	if err != nil {
+		handleError(err)
+	}
+
+	return dataCategoryGrouping
+}
+
 func defaultDataTypes(
 	categories map[string]DataCategory,
 ) []DataType {
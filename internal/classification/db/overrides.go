@@ -0,0 +1,182 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
+
+	"github.com/bearer/bearer/internal/flag"
+)
+
+// SensitivityNone is the sensitivity of data types that belong to no category
+// group, and so don't raise the severity of findings
+const SensitivityNone = "none"
+
+var overriddenCategoryNamespace = uuid.MustParse("0c7e5d1a-3b9f-4f6e-8a2d-61c4f0b7e913")
+
+// WithDataTypeOverrides returns a copy of the database with the category or
+// sensitivity of the given data types changed. A data type with an overridden
+// sensitivity is moved into a copy of its category holding only that group,
+// so that the groups looked up from the category, for severity and in the
+// reports, reflect the override.
+func (defaultDB DefaultDB) WithDataTypeOverrides(overrides []flag.DataTypeOverride) (DefaultDB, error) {
+	if len(overrides) == 0 {
+		return defaultDB, nil
+	}
+
+	dataTypes := slices.Clone(defaultDB.DataTypes)
+	dataCategories := slices.Clone(defaultDB.DataCategories)
+	grouping := defaultDataCategoryGrouping()
+
+	for _, override := range overrides {
+		index, err := findOverriddenDataType(dataTypes, override)
+		if err != nil {
+			return DefaultDB{}, fmt.Errorf("data type %q: %w", override.Name, err)
+		}
+
+		category := dataTypes[index].Category
+		if override.Category != "" {
+			category, err = findCategory(defaultDB.DataCategories, override.Category)
+			if err != nil {
+				return DefaultDB{}, fmt.Errorf("data type %q: %w", override.Name, err)
+			}
+		}
+
+		if override.Sensitivity != "" {
+			category, err = withSensitivity(grouping, category, override.Sensitivity)
+			if err != nil {
+				return DefaultDB{}, fmt.Errorf("data type %q: %w", override.Name, err)
+			}
+
+			if !slices.ContainsFunc(dataCategories, func(existing DataCategory) bool {
+				return existing.UUID == category.UUID
+			}) {
+				dataCategories = append(dataCategories, category)
+			}
+		}
+
+		dataTypes[index].CategoryUUID = category.UUID
+		dataTypes[index].Category = category
+	}
+
+	overridden := make(map[string]DataType)
+	for _, dataType := range dataTypes {
+		overridden[dataType.UUID] = dataType
+	}
+
+	patterns := slices.Clone(defaultDB.DataTypeClassificationPatterns)
+	for i, pattern := range patterns {
+		if dataType, ok := overridden[pattern.DataType.UUID]; ok {
+			patterns[i].DataType = dataType
+		}
+		if dataType, ok := overridden[pattern.HealthContextDataType.UUID]; ok {
+			patterns[i].HealthContextDataType = dataType
+		}
+	}
+
+	knownPersonObjectPatterns := slices.Clone(defaultDB.KnownPersonObjectPatterns)
+	for i, pattern := range knownPersonObjectPatterns {
+		if dataType, ok := overridden[pattern.DataType.UUID]; ok {
+			knownPersonObjectPatterns[i].DataType = dataType
+		}
+	}
+
+	defaultDB.DataTypes = dataTypes
+	defaultDB.DataCategories = dataCategories
+	defaultDB.DataTypeClassificationPatterns = patterns
+	defaultDB.KnownPersonObjectPatterns = knownPersonObjectPatterns
+
+	return defaultDB, nil
+}
+
+func findOverriddenDataType(dataTypes []DataType, override flag.DataTypeOverride) (int, error) {
+	if strings.TrimSpace(override.Name) == "" {
+		return 0, errors.New("name is required")
+	}
+
+	if override.Category == "" && override.Sensitivity == "" {
+		return 0, errors.New("category or sensitivity is required")
+	}
+
+	for i, dataType := range dataTypes {
+		if strings.EqualFold(dataType.Name, override.Name) {
+			return i, nil
+		}
+	}
+
+	return 0, errors.New("unknown data type")
+}
+
+func findCategory(dataCategories []DataCategory, name string) (DataCategory, error) {
+	var categoryNames []string
+	for _, category := range dataCategories {
+		if strings.EqualFold(category.Name, name) {
+			return category, nil
+		}
+
+		categoryNames = append(categoryNames, category.Name)
+	}
+
+	slices.Sort(categoryNames)
+	return DataCategory{}, fmt.Errorf(
+		"unknown category %q; supported values: %s",
+		name,
+		strings.Join(categoryNames, ", "),
+	)
+}
+
+// withSensitivity returns a copy of the category belonging only to the group
+// named by the sensitivity, and its parents
+func withSensitivity(grouping DataCategoryGrouping, category DataCategory, sensitivity string) (DataCategory, error) {
+	groups := make(map[string]DataCategoryGroup)
+
+	if !strings.EqualFold(sensitivity, SensitivityNone) {
+		groupUUID, err := findGroup(grouping, sensitivity)
+		if err != nil {
+			return DataCategory{}, err
+		}
+
+		group := grouping.Groups[groupUUID]
+		groups[groupUUID] = DataCategoryGroup{Name: group.Name, UUID: groupUUID}
+		for _, parentUUID := range group.ParentUUIDs {
+			groups[parentUUID] = DataCategoryGroup{
+				Name: grouping.Groups[parentUUID].Name,
+				UUID: parentUUID,
+			}
+		}
+	}
+
+	groupUUIDs := maps.Keys(groups)
+	slices.Sort(groupUUIDs)
+
+	return DataCategory{
+		Name: category.Name,
+		UUID: uuid.NewSHA1(
+			overriddenCategoryNamespace,
+			[]byte(category.UUID+"/"+strings.Join(groupUUIDs, ",")),
+		).String(),
+		Groups: groups,
+	}, nil
+}
+
+func findGroup(grouping DataCategoryGrouping, name string) (string, error) {
+	groupNames := []string{SensitivityNone}
+	for groupUUID, group := range grouping.Groups {
+		if strings.EqualFold(group.Name, name) {
+			return groupUUID, nil
+		}
+
+		groupNames = append(groupNames, group.Name)
+	}
+
+	slices.Sort(groupNames)
+	return "", fmt.Errorf(
+		"unknown sensitivity %q; supported values: %s",
+		name,
+		strings.Join(groupNames, ", "),
+	)
+}
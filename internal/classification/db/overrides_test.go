@@ -0,0 +1,130 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/classification/db"
+	"github.com/bearer/bearer/internal/flag"
+)
+
+func findDataType(defaultDB db.DefaultDB, name string) db.DataType {
+	for _, dataType := range defaultDB.DataTypes {
+		if dataType.Name == name {
+			return dataType
+		}
+	}
+
+	return db.DataType{}
+}
+
+func groupNames(category db.DataCategory) []string {
+	var names []string
+	for _, group := range category.Groups {
+		names = append(names, group.Name)
+	}
+
+	return names
+}
+
+func TestWithDataTypeOverrides(t *testing.T) {
+	defaultDB := db.Default()
+
+	t.Run("changes the sensitivity of a data type", func(t *testing.T) {
+		result, err := defaultDB.WithDataTypeOverrides([]flag.DataTypeOverride{
+			{Name: "email address", Sensitivity: "personal data (sensitive)"},
+			{Name: "Device identifier", Sensitivity: "none"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		email := findDataType(result, "Email Address")
+		assert.Equal(t, "Contact", email.Category.Name)
+		assert.Equal(t, email.Category.UUID, email.CategoryUUID)
+		assert.ElementsMatch(t, []string{"Personal Data (Sensitive)"}, groupNames(email.Category))
+		assert.Contains(t, result.DataCategories, email.Category, "the overridden category is looked up by uuid")
+
+		device := findDataType(result, "Device identifier")
+		assert.Equal(t, "Computer Device", device.Category.Name)
+		assert.Empty(t, device.Category.Groups)
+
+		for _, pattern := range result.DataTypeClassificationPatterns {
+			if pattern.DataType.UUID == email.UUID {
+				assert.Equal(t, email, pattern.DataType)
+			}
+		}
+
+		assert.ElementsMatch(
+			t,
+			[]string{"PII", "Personal Data"},
+			groupNames(findDataType(defaultDB, "Email Address").Category),
+			"the original database is unchanged",
+		)
+	})
+
+	t.Run("moves a data type to another category", func(t *testing.T) {
+		result, err := defaultDB.WithDataTypeOverrides([]flag.DataTypeOverride{
+			{Name: "Email Address", Category: "Authenticating"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		email := findDataType(result, "Email Address")
+		assert.Equal(t, "Authenticating", email.Category.Name)
+		assert.Len(t, result.DataCategories, len(defaultDB.DataCategories))
+	})
+
+	t.Run("includes the parent groups", func(t *testing.T) {
+		result, err := defaultDB.WithDataTypeOverrides([]flag.DataTypeOverride{
+			{Name: "Device identifier", Sensitivity: "PII"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		device := findDataType(result, "Device identifier")
+		assert.ElementsMatch(t, []string{"PII", "Personal Data"}, groupNames(device.Category))
+	})
+
+	for _, testCase := range []struct {
+		Name     string
+		Override flag.DataTypeOverride
+		Error    string
+	}{
+		{
+			Name:     "missing name",
+			Override: flag.DataTypeOverride{Sensitivity: "PII"},
+			Error:    `data type "": name is required`,
+		},
+		{
+			Name:     "nothing overridden",
+			Override: flag.DataTypeOverride{Name: "Email Address"},
+			Error:    `data type "Email Address": category or sensitivity is required`,
+		},
+		{
+			Name:     "unknown data type",
+			Override: flag.DataTypeOverride{Name: "VIN", Sensitivity: "PII"},
+			Error:    `data type "VIN": unknown data type`,
+		},
+		{
+			Name:     "unknown category",
+			Override: flag.DataTypeOverride{Name: "Email Address", Category: "Vehicles"},
+			Error:    `data type "Email Address": unknown category "Vehicles"; supported values: Authenticating,`,
+		},
+		{
+			Name:     "unknown sensitivity",
+			Override: flag.DataTypeOverride{Name: "Email Address", Sensitivity: "Restricted"},
+			Error:    `data type "Email Address": unknown sensitivity "Restricted"; supported values: PHI, PII, Personal Data, Personal Data (Sensitive), none`,
+		},
+	} {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := defaultDB.WithDataTypeOverrides([]flag.DataTypeOverride{testCase.Override})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), testCase.Error)
+			}
+		})
+	}
+}
@@ -15,12 +15,13 @@ type ClassifiedDependency struct {
 }
 
 type Classification struct {
-	RecipeMatch   bool                            `json:"recipe_match" yaml:"recipe_match"`
-	RecipeUUID    string                          `json:"recipe_uuid,omitempty"`
-	RecipeName    string                          `json:"recipe_name,omitempty"`
-	RecipeType    string                          `json:"recipe_type,omitempty"`
-	RecipeSubType string                          `json:"recipe_sub_type,omitempty"`
-	Decision      classify.ClassificationDecision `json:"decision" yaml:"decision"`
+	RecipeMatch    bool                            `json:"recipe_match" yaml:"recipe_match"`
+	RecipeUUID     string                          `json:"recipe_uuid,omitempty"`
+	RecipeName     string                          `json:"recipe_name,omitempty"`
+	RecipeType     string                          `json:"recipe_type,omitempty"`
+	RecipeSubType  string                          `json:"recipe_sub_type,omitempty"`
+	RecipeCategory string                          `json:"recipe_category,omitempty"`
+	Decision       classify.ClassificationDecision `json:"decision" yaml:"decision"`
 }
 
 type Classifier struct {
@@ -71,11 +72,12 @@ func (classifier *Classifier) Classify(data detections.Detection) (*ClassifiedDe
 		for _, recipePackage := range recipe.Packages {
 			if isRecipeMatch(recipePackage, value) {
 				classification = &Classification{
-					RecipeUUID:    recipe.UUID,
-					RecipeName:    recipe.Name,
-					RecipeType:    recipe.Type,
-					RecipeSubType: recipe.SubType,
-					RecipeMatch:   true,
+					RecipeUUID:     recipe.UUID,
+					RecipeName:     recipe.Name,
+					RecipeType:     recipe.Type,
+					RecipeSubType:  recipe.SubType,
+					RecipeCategory: recipe.Category,
+					RecipeMatch:    true,
 					Decision: classify.ClassificationDecision{
 						State:  classify.Valid,
 						Reason: "recipe_match",
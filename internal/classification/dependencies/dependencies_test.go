@@ -31,11 +31,12 @@ func TestDependencies(t *testing.T) {
 				Type: detections.TypeDependency,
 			},
 			Want: &dependencies.Classification{
-				RecipeMatch:   true,
-				RecipeName:    "Stripe",
-				RecipeType:    "external_service",
-				RecipeSubType: "third_party",
-				RecipeUUID:    "c24b836a-d035-49dc-808f-1912f16f690d",
+				RecipeMatch:    true,
+				RecipeName:     "Stripe",
+				RecipeType:     "external_service",
+				RecipeSubType:  "third_party",
+				RecipeCategory: "payment",
+				RecipeUUID:     "c24b836a-d035-49dc-808f-1912f16f690d",
 				Decision: classify.ClassificationDecision{
 					State:  classify.Valid,
 					Reason: "recipe_match",
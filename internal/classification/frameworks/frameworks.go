@@ -12,18 +12,25 @@ type classifiableFramework interface {
 	GetTechnologyKey() string
 }
 
+// definiteFramework is implemented by frameworks identified precisely enough
+// to be classified even when found by a potential detector
+type definiteFramework interface {
+	IsDefinite() bool
+}
+
 type ClassifiedFramework struct {
 	*detections.Detection
 	Classification *Classification `json:"classification" yaml:"classification"`
 }
 
 type Classification struct {
-	RecipeMatch   bool                            `json:"recipe_match" yaml:"recipe_match"`
-	RecipeName    string                          `json:"recipe_name,omitempty"`
-	RecipeUUID    string                          `json:"recipe_uuid,omitempty"`
-	RecipeType    string                          `json:"recipe_type,omitempty"`
-	RecipeSubType string                          `json:"recipe_sub_type,omitempty"`
-	Decision      classify.ClassificationDecision `json:"decision" yaml:"decision"`
+	RecipeMatch    bool                            `json:"recipe_match" yaml:"recipe_match"`
+	RecipeName     string                          `json:"recipe_name,omitempty"`
+	RecipeUUID     string                          `json:"recipe_uuid,omitempty"`
+	RecipeType     string                          `json:"recipe_type,omitempty"`
+	RecipeSubType  string                          `json:"recipe_sub_type,omitempty"`
+	RecipeCategory string                          `json:"recipe_category,omitempty"`
+	Decision       classify.ClassificationDecision `json:"decision" yaml:"decision"`
 }
 
 type Classifier struct {
@@ -56,7 +63,7 @@ func (classifier *Classifier) Classify(data detections.Detection) (*ClassifiedFr
 		}, nil
 	}
 
-	if classify.IsPotentialDetector(data.DetectorType) {
+	if classify.IsPotentialDetector(data.DetectorType) && !isDefinite(data.Value) {
 		return &ClassifiedFramework{
 			Detection:      &data,
 			Classification: classification,
@@ -79,11 +86,12 @@ func (classifier *Classifier) Classify(data detections.Detection) (*ClassifiedFr
 		for _, recipe := range classifier.config.Recipes {
 			if isRecipeMatch(recipe, technologyKey) {
 				classification = &Classification{
-					RecipeUUID:    recipe.UUID,
-					RecipeName:    recipe.Name,
-					RecipeType:    recipe.Type,
-					RecipeSubType: recipe.SubType,
-					RecipeMatch:   true,
+					RecipeUUID:     recipe.UUID,
+					RecipeName:     recipe.Name,
+					RecipeType:     recipe.Type,
+					RecipeSubType:  recipe.SubType,
+					RecipeCategory: recipe.Category,
+					RecipeMatch:    true,
 					Decision: classify.ClassificationDecision{
 						State:  classify.Valid,
 						Reason: "recipe_match",
@@ -100,6 +108,11 @@ func (classifier *Classifier) Classify(data detections.Detection) (*ClassifiedFr
 	}, nil
 }
 
+func isDefinite(value interface{}) bool {
+	definite, ok := value.(definiteFramework)
+	return ok && definite.IsDefinite()
+}
+
 func isRecipeMatch(recipe db.Recipe, technologyKey string) bool {
 	return recipe.UUID == technologyKey
 }
@@ -18,13 +18,14 @@ type ClassifiedInterface struct {
 }
 
 type Classification struct {
-	URL           string                          `json:"url" yaml:"url"`
-	RecipeMatch   bool                            `json:"recipe_match" yaml:"recipe_match"`
-	RecipeName    string                          `json:"recipe_name,omitempty"`
-	RecipeUUID    string                          `json:"recipe_uuid,omitempty"`
-	RecipeType    string                          `json:"recipe_type,omitempty"`
-	RecipeSubType string                          `json:"recipe_sub_type,omitempty"`
-	Decision      classify.ClassificationDecision `json:"decision" yaml:"decision"`
+	URL            string                          `json:"url" yaml:"url"`
+	RecipeMatch    bool                            `json:"recipe_match" yaml:"recipe_match"`
+	RecipeName     string                          `json:"recipe_name,omitempty"`
+	RecipeUUID     string                          `json:"recipe_uuid,omitempty"`
+	RecipeType     string                          `json:"recipe_type,omitempty"`
+	RecipeSubType  string                          `json:"recipe_sub_type,omitempty"`
+	RecipeCategory string                          `json:"recipe_category,omitempty"`
+	Decision       classify.ClassificationDecision `json:"decision" yaml:"decision"`
 }
 
 type Classifier struct {
@@ -44,6 +45,7 @@ type Recipe struct {
 	Name        string
 	Type        string
 	SubType     string
+	Category    string
 	URLS        []RecipeURL
 	ExcludeURLS []RecipeURL
 }
@@ -60,6 +62,7 @@ type RecipeURLMatch struct {
 	RecipeName       string
 	RecipeType       string
 	RecipeSubType    string
+	RecipeCategory   string
 	ExcludedURL      bool
 }
 
@@ -79,10 +82,11 @@ func New(config Config) (*Classifier, error) {
 	var preparedRecipes []Recipe
 	for _, recipe := range config.Recipes {
 		preparedRecipe := Recipe{
-			UUID:    recipe.UUID,
-			Name:    recipe.Name,
-			Type:    recipe.Type,
-			SubType: recipe.SubType,
+			UUID:     recipe.UUID,
+			Name:     recipe.Name,
+			Type:     recipe.Type,
+			SubType:  recipe.SubType,
+			Category: recipe.Category,
 		}
 		for _, recipeURL := range recipe.URLS {
 			regexpMatcher, err := url.PrepareRegexpMatcher(recipeURL)
@@ -220,12 +224,13 @@ func (classifier *Classifier) Classify(data detections.Detection) (*ClassifiedIn
 		classifiedInterface := &ClassifiedInterface{
 			Detection: &data,
 			Classification: &Classification{
-				URL:           recipeMatch.DetectionURLPart,
-				RecipeMatch:   true,
-				RecipeUUID:    recipeMatch.RecipeUUID,
-				RecipeName:    recipeMatch.RecipeName,
-				RecipeType:    recipeMatch.RecipeType,
-				RecipeSubType: recipeMatch.RecipeSubType,
+				URL:            recipeMatch.DetectionURLPart,
+				RecipeMatch:    true,
+				RecipeUUID:     recipeMatch.RecipeUUID,
+				RecipeName:     recipeMatch.RecipeName,
+				RecipeType:     recipeMatch.RecipeType,
+				RecipeSubType:  recipeMatch.RecipeSubType,
+				RecipeCategory: recipeMatch.RecipeCategory,
 			},
 		}
 		if strings.Contains(recipeMatch.DetectionURLPart, "*") {
@@ -304,6 +309,7 @@ func (classifier *Classifier) FindMatchingRecipeUrl(detectionURL string) (*Recip
 				RecipeName:       recipe.Name,
 				RecipeType:       recipe.Type,
 				RecipeSubType:    recipe.SubType,
+				RecipeCategory:   recipe.Category,
 			}
 		}
 	}
@@ -42,12 +42,13 @@ func TestInterface(t *testing.T) {
 				},
 			},
 			Want: &interfaces.Classification{
-				URL:           "https://api.stripe.com",
-				RecipeName:    "Stripe",
-				RecipeType:    "external_service",
-				RecipeSubType: "third_party",
-				RecipeUUID:    "c24b836a-d035-49dc-808f-1912f16f690d",
-				RecipeMatch:   true,
+				URL:            "https://api.stripe.com",
+				RecipeName:     "Stripe",
+				RecipeType:     "external_service",
+				RecipeSubType:  "third_party",
+				RecipeCategory: "payment",
+				RecipeUUID:     "c24b836a-d035-49dc-808f-1912f16f690d",
+				RecipeMatch:    true,
 				Decision: classify.ClassificationDecision{
 					State:  classify.Valid,
 					Reason: "recipe_match",
@@ -128,12 +129,13 @@ func TestInterface(t *testing.T) {
 				},
 			},
 			Want: &interfaces.Classification{
-				URL:           "http://*.stripe.com",
-				RecipeName:    "Stripe",
-				RecipeType:    "external_service",
-				RecipeSubType: "third_party",
-				RecipeUUID:    "c24b836a-d035-49dc-808f-1912f16f690d",
-				RecipeMatch:   true,
+				URL:            "http://*.stripe.com",
+				RecipeName:     "Stripe",
+				RecipeType:     "external_service",
+				RecipeSubType:  "third_party",
+				RecipeCategory: "payment",
+				RecipeUUID:     "c24b836a-d035-49dc-808f-1912f16f690d",
+				RecipeMatch:    true,
 				Decision: classify.ClassificationDecision{
 					State:  classify.Potential,
 					Reason: "recipe_match_with_wildcard",
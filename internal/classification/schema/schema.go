@@ -33,18 +33,20 @@ type Classification struct {
 }
 
 type Classifier struct {
-	config Config
+	config   Config
+	subjects []subjectMatcher
 }
 
 type Config struct {
 	DataTypes                      []db.DataType
 	DataTypeClassificationPatterns []db.DataTypeClassificationPattern
 	KnownPersonObjectPatterns      []db.KnownPersonObjectPattern
+	DataSubjects                   []flag.DataSubjectDefinition
 	Context                        flag.Context
 }
 
 func New(config Config) *Classifier {
-	return &Classifier{config: config}
+	return &Classifier{config: config, subjects: newSubjectMatchers(config.DataSubjects)}
 }
 
 type ClassificationRequestDetection struct {
@@ -60,11 +62,19 @@ type ClassificationRequest struct {
 }
 
 func (classifier *Classifier) Classify(data ClassificationRequest) *ClassifiedDatatype {
+	classifiedDatatype := classifier.classify(data)
+	classifier.assignDataSubjects(data.Value.Name, classifiedDatatype)
+
+	return classifiedDatatype
+}
+
+func (classifier *Classifier) classify(data ClassificationRequest) *ClassifiedDatatype {
 	var classifiedDatatype *ClassifiedDatatype
 	var normalizedName = normalize_key.Normalize(data.Value.Name)
 
 	// general checks
-	if classify.IsVendored(data.Filename) {
+	// SQL table definitions are commonly kept in a migrations folder
+	if classify.IsVendored(data.Filename) && data.DetectorType != detectors.DetectorSQLCreateTable {
 		classifiedDatatype = classifyObjectAsInvalid(data.Value, classify.IncludedInVendorFolderReason)
 	}
 
@@ -186,7 +196,7 @@ func (classifier *Classifier) matchObjectPatterns(name string, simpleType string
 			continue
 		}
 
-		if !classify.IsExpectedIdentifierDataTypeId(pattern.Id) && regexpIdentifierMatcher.MatchString(name) {
+		if !pattern.MatchIdentifier && !classify.IsExpectedIdentifierDataTypeId(pattern.Id) && regexpIdentifierMatcher.MatchString(name) {
 			continue
 		}
 
@@ -5,9 +5,11 @@ import (
 
 	"github.com/bearer/bearer/internal/classification/db"
 	"github.com/bearer/bearer/internal/classification/schema"
+	"github.com/bearer/bearer/internal/flag"
 	"github.com/bearer/bearer/internal/report/detectors"
 	reportschema "github.com/bearer/bearer/internal/report/schema"
 	"github.com/bearer/bearer/internal/util/classify"
+	"github.com/bearer/bearer/internal/util/pointers"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -964,3 +966,109 @@ func TestSchemaObjectClassification(t *testing.T) {
 		}, output)
 	})
 }
+
+func TestCustomDataTypeClassification(t *testing.T) {
+	defaultDB, err := db.Default().WithDataTypeDefinitions([]flag.DataTypeDefinition{
+		{
+			Name:        "Vehicle Identification Number",
+			Category:    "Personal Ownership",
+			Patterns:    []string{`\bvin\b`},
+			ColumnNames: []string{"vehicle_identification_number"},
+			Locales:     map[string][]string{"de": {"fahrgestellnummer"}},
+		},
+		{
+			Name:         "Claim ID",
+			Category:     "Financial Accounts",
+			ColumnNames:  []string{"claim_id"},
+			ExcludeTypes: []string{reportschema.SimpleTypeBool},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	classifier := schema.New(
+		schema.Config{
+			DataTypes:                      defaultDB.DataTypes,
+			DataTypeClassificationPatterns: defaultDB.DataTypeClassificationPatterns,
+			KnownPersonObjectPatterns:      defaultDB.KnownPersonObjectPatterns,
+		},
+	)
+
+	classifyProperty := func(name string, simpleType string) *db.DataType {
+		output := classifier.Classify(schema.ClassificationRequest{
+			Filename:     "db/schema.rb",
+			DetectorType: detectors.DetectorRuby,
+			Value: &schema.ClassificationRequestDetection{
+				Name:       "User",
+				SimpleType: reportschema.SimpleTypeObject,
+				Properties: []*schema.ClassificationRequestDetection{{Name: name, SimpleType: simpleType}},
+			},
+		})
+
+		return output.Properties[0].Classification.DataType
+	}
+
+	for _, name := range []string{"vin", "carVin", "vehicle_identification_number", "Fahrgestellnummer"} {
+		dataType := classifyProperty(name, reportschema.SimpleTypeString)
+		if assert.NotNil(t, dataType, name) {
+			assert.Equal(t, "Vehicle Identification Number", dataType.Name)
+			assert.Equal(t, "Personal Ownership", dataType.Category.Name)
+		}
+	}
+
+	if dataType := classifyProperty("claimId", reportschema.SimpleTypeString); assert.NotNil(t, dataType) {
+		assert.Equal(t, "Claim ID", dataType.Name)
+	}
+	assert.Nil(t, classifyProperty("claim_id", reportschema.SimpleTypeBool))
+	assert.Nil(t, classifyProperty("vinyl", reportschema.SimpleTypeString))
+}
+
+func TestDataSubjectClassification(t *testing.T) {
+	defaultDB := db.Default()
+	classifier := schema.New(
+		schema.Config{
+			DataTypes:                      defaultDB.DataTypes,
+			DataTypeClassificationPatterns: defaultDB.DataTypeClassificationPatterns,
+			KnownPersonObjectPatterns:      defaultDB.KnownPersonObjectPatterns,
+			DataSubjects: []flag.DataSubjectDefinition{
+				{Name: "Employee", Objects: []string{"staff_member", "employee"}, DataTypes: []string{"salary"}},
+				{Name: "Patient", Objects: []string{"Patient"}},
+			},
+		},
+	)
+
+	classifySubject := func(objectName string, propertyName string) *string {
+		output := classifier.Classify(schema.ClassificationRequest{
+			Filename:     "db/schema.rb",
+			DetectorType: detectors.DetectorRuby,
+			Value: &schema.ClassificationRequestDetection{
+				Name:       objectName,
+				SimpleType: reportschema.SimpleTypeObject,
+				Properties: []*schema.ClassificationRequestDetection{
+					{Name: propertyName, SimpleType: reportschema.SimpleTypeString},
+				},
+			},
+		})
+
+		if !assert.NotNil(t, output.Properties[0].Classification.DataType, objectName+"."+propertyName) {
+			return nil
+		}
+
+		return output.Properties[0].Classification.SubjectName
+	}
+
+	for _, testCase := range []struct {
+		Object, Property string
+		Expected         *string
+	}{
+		{Object: "StaffMembers", Property: "email", Expected: pointers.String("Employee")},
+		{Object: "Employee", Property: "email", Expected: pointers.String("Employee")},
+		{Object: "patients", Property: "email", Expected: pointers.String("Patient")},
+		{Object: "Patient", Property: "salary", Expected: pointers.String("Patient")},
+		{Object: "User", Property: "salary", Expected: pointers.String("Employee")},
+		{Object: "User", Property: "email", Expected: pointers.String("User")},
+	} {
+		assert.Equal(t, testCase.Expected, classifySubject(testCase.Object, testCase.Property), testCase.Object+"."+testCase.Property)
+	}
+}
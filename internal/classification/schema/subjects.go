@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/tangzero/inflector"
+
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/util/normalize_key"
+)
+
+type subjectMatcher struct {
+	name      string
+	objects   map[string]struct{}
+	dataTypes map[string]struct{}
+}
+
+func newSubjectMatchers(definitions []flag.DataSubjectDefinition) []subjectMatcher {
+	var matchers []subjectMatcher
+
+	for _, definition := range definitions {
+		matcher := subjectMatcher{
+			name:      definition.Name,
+			objects:   make(map[string]struct{}),
+			dataTypes: make(map[string]struct{}),
+		}
+
+		for _, object := range definition.Objects {
+			matcher.objects[normalizeObjectName(object)] = struct{}{}
+		}
+		for _, dataType := range definition.DataTypes {
+			matcher.dataTypes[strings.ToLower(dataType)] = struct{}{}
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	return matchers
+}
+
+// assignDataSubjects overrides the subject of the classified data with the
+// first user defined subject matching the object, or else the data type
+func (classifier *Classifier) assignDataSubjects(objectName string, classifiedDatatype *ClassifiedDatatype) {
+	if len(classifier.subjects) == 0 || classifiedDatatype == nil {
+		return
+	}
+
+	objectSubject := classifier.objectSubject(objectName)
+
+	classifier.assignDataSubject(objectSubject, &classifiedDatatype.Classification)
+	for _, property := range classifiedDatatype.Properties {
+		classifier.assignDataSubject(objectSubject, &property.Classification)
+	}
+}
+
+func (classifier *Classifier) assignDataSubject(objectSubject *string, classification *Classification) {
+	if classification.DataType == nil {
+		return
+	}
+
+	if objectSubject != nil {
+		classification.SubjectName = objectSubject
+		return
+	}
+
+	dataTypeName := strings.ToLower(classification.DataType.Name)
+	for i, matcher := range classifier.subjects {
+		if _, ok := matcher.dataTypes[dataTypeName]; ok {
+			classification.SubjectName = &classifier.subjects[i].name
+			return
+		}
+	}
+}
+
+func (classifier *Classifier) objectSubject(objectName string) *string {
+	name := normalizeObjectName(objectName)
+	if name == "" {
+		return nil
+	}
+
+	for i, matcher := range classifier.subjects {
+		if _, ok := matcher.objects[name]; ok {
+			return &classifier.subjects[i].name
+		}
+	}
+
+	return nil
+}
+
+// normalizeObjectName allows objects to be matched regardless of their case
+// and plural form, e.g. StaffMembers matches staff_member
+func normalizeObjectName(name string) string {
+	return inflector.Singularize(normalize_key.Normalize(name))
+}
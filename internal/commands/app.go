@@ -17,6 +17,15 @@ func NewApp(version string, commitSHA string) *cobra.Command {
 		NewInitCommand(),
 		NewScanCommand(),
 		NewIgnoreCommand(),
+		NewDiffCommand(),
+		NewTrendCommand(),
+		NewConformanceCommand(),
+		NewMergeDataflowCommand(),
+		NewDSARCommand(),
+		NewRulesCommand(),
+		NewDocsCommand(),
+		NewFeedbackCommand(),
+		NewFixCommand(),
 		NewVersionCommand(version, commitSHA),
 	)
 
@@ -40,6 +49,12 @@ Available Commands:
 	scan              Scan a directory or file
 	init              Write the default config to bearer.yml
 	ignore            Manage ignored fingerprints
+	diff              Compare two security reports
+	trend             Show the trend of findings across recorded scans
+	rules             Search and install community rule packs
+	docs              Search the documentation available offline
+	feedback          Report a false positive finding
+	fix               Fix findings using the fixes suggested by their rules
 	version           Print the version
 
 Examples:
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -13,18 +14,23 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/bearer/bearer/api"
 	"github.com/bearer/bearer/internal/commands/artifact/scanid"
 	"github.com/bearer/bearer/internal/commands/process/filelist"
 	"github.com/bearer/bearer/internal/commands/process/filelist/files"
+	"github.com/bearer/bearer/internal/commands/process/filelist/priority"
 	"github.com/bearer/bearer/internal/commands/process/gitrepository"
 	"github.com/bearer/bearer/internal/commands/process/orchestrator"
 	"github.com/bearer/bearer/internal/commands/process/orchestrator/work"
 	"github.com/bearer/bearer/internal/commands/process/settings"
 	"github.com/bearer/bearer/internal/flag"
 	"github.com/bearer/bearer/internal/report/basebranchfindings"
+	"github.com/bearer/bearer/internal/report/history"
 	reportoutput "github.com/bearer/bearer/internal/report/output"
+	"github.com/bearer/bearer/internal/report/output/gate"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
 	"github.com/bearer/bearer/internal/report/output/stats"
 	outputtypes "github.com/bearer/bearer/internal/report/output/types"
 	scannerstats "github.com/bearer/bearer/internal/scanner/stats"
@@ -32,11 +38,15 @@ import (
 	"github.com/bearer/bearer/internal/util/ignore"
 	ignoretypes "github.com/bearer/bearer/internal/util/ignore/types"
 	outputhandler "github.com/bearer/bearer/internal/util/output"
+	"github.com/bearer/bearer/internal/util/workdir"
 	"github.com/bearer/bearer/internal/version_check"
 
 	"github.com/bearer/bearer/internal/types"
 )
 
+// the number of rules listed in the rule timings summary
+const maxRuleTimingsSummary = 10
+
 // TargetKind represents what kind of artifact bearer scans
 type TargetKind string
 
@@ -55,6 +65,8 @@ type Runner interface {
 	CacheUsed() bool
 	// ReportPath returns the filename of the report
 	ReportPath() string
+	// GateFailure returns the findings which failed the report, if any
+	GateFailure() *securitytypes.GateFailure
 	// Scan gathers the findings
 	Scan(ctx context.Context, opts flag.Options) ([]files.File, *basebranchfindings.Findings, error)
 	// Report a writes a report
@@ -69,6 +81,7 @@ type runner struct {
 	scanSettings   settings.Config
 	stats          *scannerstats.Stats
 	gitContext     *gitrepository.Context
+	gateFailure    *securitytypes.GateFailure
 }
 
 // NewRunner initializes Runner that provides scanning functionalities.
@@ -93,7 +106,7 @@ func NewRunner(
 		return nil, fmt.Errorf("failed to build scan id for caching: %w", err)
 	}
 
-	path := os.TempDir() + "/bearer" + scanID
+	path := filepath.Join(workdir.Dir(), "bearer"+scanID)
 	completedPath := strings.Replace(path, ".jsonl", "-completed.jsonl", 1)
 
 	r.reportPath = path
@@ -101,8 +114,9 @@ func NewRunner(
 	log.Debug().Msgf("creating report %s", path)
 
 	if _, err := os.Stat(completedPath); err == nil {
-		// diff can't use the cache because the base branch scan data is not in the report
-		if !scanSettings.Scan.Force && !scanSettings.Scan.Diff {
+		// diff can't use the cache because the base branch scan data is not in the report,
+		// and a time-bounded scan may not have scanned every file
+		if !scanSettings.Scan.Force && !scanSettings.Scan.Diff && scanSettings.Scan.MaxScanDuration == 0 {
 			// force is not set, and we are not running a diff scan
 			r.reuseDetection = true
 			log.Debug().Msgf("reuse detection for %s", path)
@@ -139,6 +153,10 @@ func (r *runner) CacheUsed() bool {
 	return r.reuseDetection
 }
 
+func (r *runner) GateFailure() *securitytypes.GateFailure {
+	return r.gateFailure
+}
+
 func (r *runner) Scan(ctx context.Context, opts flag.Options) ([]files.File, *basebranchfindings.Findings, error) {
 	if r.reuseDetection {
 		return nil, nil, nil
@@ -158,6 +176,14 @@ func (r *runner) Scan(ctx context.Context, opts flag.Options) ([]files.File, *ba
 		return nil, nil, err
 	}
 
+	if !opts.Quiet && fileList.SkippedBinaryFiles != nil {
+		outputhandler.StdErrLog(filelist.SkippedBinaryFilesSummary(fileList.SkippedBinaryFiles))
+	}
+
+	if r.scanSettings.Scan.MaxScanDuration != 0 {
+		r.prioritizeFiles(fileList)
+	}
+
 	orchestrator, err := orchestrator.New(
 		work.Repository{Dir: r.targetPath},
 		r.scanSettings,
@@ -196,6 +222,22 @@ func (r *runner) Scan(ctx context.Context, opts flag.Options) ([]files.File, *ba
 	return fileList.Files, baseBranchFindings, nil
 }
 
+// prioritizeFiles orders the files so that a time-bounded scan covers the
+// riskiest files first, using the scan history when there is one
+func (r *runner) prioritizeFiles(fileList *files.List) {
+	var entries []history.Entry
+	if r.scanSettings.Report.HistoryFile != "" {
+		var err error
+		entries, err = history.Read(r.scanSettings.Report.HistoryFile, r.scanSettings.Offline)
+		if err != nil {
+			log.Debug().Msgf("unable to read scan history to prioritize files: %s", err)
+		}
+	}
+
+	priority.Sort(r.targetPath, fileList.Files, entries)
+	priority.Sort(r.targetPath, fileList.BaseFiles, entries)
+}
+
 func (r *runner) scanBaseBranch(
 	orchestrator *orchestrator.Orchestrator,
 	fileList *files.List,
@@ -216,7 +258,7 @@ func (r *runner) scanBaseBranch(
 		HasFiles:    len(fileList.BaseFiles) != 0,
 	}
 
-	reportData, err := reportoutput.GetData(report, r.scanSettings, r.gitContext, nil)
+	reportData, err := reportoutput.GetData(report, r.scanSettings, r.gitContext, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -261,6 +303,10 @@ func getIgnoredFingerprints(client *api.API, settings settings.Config, gitContex
 
 // Run performs artifact scanning
 func Run(ctx context.Context, opts flag.Options) (err error) {
+	if err := workdir.Preflight(); err != nil {
+		return err
+	}
+
 	targetPath, err := file.CanonicalPath(opts.Target)
 	if err != nil {
 		return fmt.Errorf("failed to get absolute target: %w", err)
@@ -323,7 +369,7 @@ func Run(ctx context.Context, opts flag.Options) (err error) {
 	}()
 
 	var stats *scannerstats.Stats
-	if scanSettings.Debug {
+	if scanSettings.Debug || scanSettings.Scan.RuleTimings != "" {
 		stats = scannerstats.New()
 	}
 
@@ -352,18 +398,52 @@ func Run(ctx context.Context, opts flag.Options) (err error) {
 		}
 	}
 
-	if stats != nil {
+	if stats != nil && scanSettings.Debug {
 		outputhandler.StdErrLog(fmt.Sprintf("=====================================\n\nProfile\n\n%s", stats.String()))
 	}
 
+	if scanSettings.Scan.RuleTimings != "" {
+		if err := writeRuleTimings(scanSettings.Scan.RuleTimings, stats.RuleTimings()); err != nil {
+			return err
+		}
+	}
+
 	if reportFailed {
-		if scanSettings.Scan.ExitCode == -1 {
-			defer os.Exit(1)
-		} else {
-			defer os.Exit(scanSettings.Scan.ExitCode)
+		exitCode := scanSettings.Scan.ExitCode
+		if exitCode == -1 {
+			exitCode = 1
+		}
+
+		// only explain the failure when the scan actually fails
+		if gateFailure := r.GateFailure(); gateFailure != nil && exitCode != 0 && !scanSettings.Scan.Quiet {
+			gateFailureOutput(gateFailure)
 		}
+
+		defer os.Exit(exitCode)
+	}
+
+	return nil
+}
+
+func gateFailureOutput(gateFailure *securitytypes.GateFailure) {
+	outputhandler.StdErrLog("\n=====================================\n")
+	outputhandler.StdErrLog(gate.Explain(gateFailure))
+	outputhandler.StdErrLog("\n=====================================")
+}
+
+// writeRuleTimings writes the rule timings as JSON to the given path, and
+// prints a summary of the slowest rules
+func writeRuleTimings(path string, timings scannerstats.RuleTimings) error {
+	content, err := timings.JSON()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write rule timings: %w", err)
 	}
 
+	outputhandler.StdErrLog(timings.Summary(maxRuleTimingsSummary))
 	return nil
 }
 
@@ -395,11 +475,31 @@ func (r *runner) Report(
 		outputhandler.StdErrLog("Using cached data")
 	}
 
-	reportData, err := reportoutput.GetData(report, r.scanSettings, r.gitContext, baseBranchFindings)
+	var findingStream outputtypes.FindingStream
+	if r.scanSettings.Report.Format == flag.FormatJSONL {
+		findingStream = func(finding securitytypes.RawFinding) error {
+			findingStr, err := outputhandler.ReportJSON(finding)
+			if err != nil {
+				return err
+			}
+
+			logger(findingStr)
+			return nil
+		}
+	}
+
+	reportData, err := reportoutput.GetData(report, r.scanSettings, r.gitContext, baseBranchFindings, findingStream)
 	if err != nil {
 		return false, err
 	}
+	r.gateFailure = reportData.GateFailure
 	reportoutput.UploadReportToCloud(reportData, r.scanSettings, r.gitContext)
+	if err := reportoutput.AppendHistory(reportData, r.scanSettings, r.gitContext); err != nil {
+		return false, err
+	}
+	if err := reportoutput.WriteRuleCoverage(reportData, r.scanSettings, report.Inputgocloc); err != nil {
+		return false, err
+	}
 
 	endTime := time.Now()
 
@@ -408,7 +508,13 @@ func (r *runner) Report(
 		return false, err
 	}
 
-	if !reportSupported && r.scanSettings.Report.Report != flag.ReportPrivacy {
+	if findingStream != nil {
+		// findings have already been written as they were evaluated
+		r.displayScanMessages(cacheUsed)
+		return reportData.ReportFailed, nil
+	}
+
+	if !reportSupported && !slices.Contains([]string{flag.ReportPrivacy, flag.ReportRoPA, flag.ReportResidency}, r.scanSettings.Report.Report) {
 		var placeholderStr *strings.Builder
 		placeholderStr, err = getPlaceholderOutput(reportData, report, r.scanSettings, report.Inputgocloc)
 		if err != nil {
@@ -419,6 +525,28 @@ func (r *runner) Report(
 		return true, nil
 	}
 
+	if r.scanSettings.Report.OutputDir != "" {
+		paths, err := reportoutput.WriteOutputDir(
+			reportData,
+			r.scanSettings,
+			report.Inputgocloc,
+			startTime,
+			endTime,
+		)
+		if err != nil {
+			return false, fmt.Errorf("error generating report %s", err)
+		}
+
+		if !r.scanSettings.Scan.Quiet {
+			for _, path := range paths {
+				outputhandler.StdErrLog(fmt.Sprintf("Report written to %s", path))
+			}
+		}
+		r.displayScanMessages(cacheUsed)
+
+		return reportData.ReportFailed, nil
+	}
+
 	formatStr, err := reportoutput.FormatOutput(
 		reportData,
 		r.scanSettings,
@@ -431,24 +559,29 @@ func (r *runner) Report(
 	}
 
 	logger(formatStr)
+	r.displayScanMessages(cacheUsed)
 
-	if !r.scanSettings.Scan.Quiet {
-		// add cached data warning message
-		if cacheUsed {
-			outputhandler.StdErrLog("Cached data used (no code changes detected). Unexpected? Use --force to force a re-scan.\n")
-		}
-		// add cloud info message
-		if r.scanSettings.Client != nil {
-			if r.scanSettings.Client.Error == nil {
-				outputhandler.StdErrLog("Data successfully sent to Bearer Cloud.")
-			} else {
-				// client error
-				outputhandler.StdErrLog(fmt.Sprintf("Failed to send data to Bearer Cloud. %s ", *r.scanSettings.Client.Error))
-			}
-		}
+	return reportData.ReportFailed, nil
+}
+
+func (r *runner) displayScanMessages(cacheUsed bool) {
+	if r.scanSettings.Scan.Quiet {
+		return
 	}
 
-	return reportData.ReportFailed, nil
+	// add cached data warning message
+	if cacheUsed {
+		outputhandler.StdErrLog("Cached data used (no code changes detected). Unexpected? Use --force to force a re-scan.\n")
+	}
+	// add cloud info message
+	if r.scanSettings.Client != nil {
+		if r.scanSettings.Client.Error == nil {
+			outputhandler.StdErrLog("Data successfully sent to Bearer Cloud.")
+		} else {
+			// client error
+			outputhandler.StdErrLog(fmt.Sprintf("Failed to send data to Bearer Cloud. %s ", *r.scanSettings.Client.Error))
+		}
+	}
 }
 
 func (r *runner) ReportPath() string {
@@ -480,6 +613,13 @@ func anySupportedLanguagesPresent(inputgocloc *gocloc.Result, config settings.Co
 		}
 	}
 
+	// files checked by detector rules, eg. Kubernetes manifests
+	for _, rule := range config.BuiltInRules {
+		if rule.IsDetectorRule() && foundLanguages[strings.ToLower(rule.Language())] {
+			return true, nil
+		}
+	}
+
 	log.Debug().Msg("No language found for which rules are applicable")
 	return false, nil
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -70,6 +71,26 @@ func hashConfig(scanSettings settings.Config) (string, error) {
 	if _, err := hashBuilder.Write(scannersHash); err != nil {
 		return "", err
 	}
+	// inline suppressions expire by day
+	if _, err := hashBuilder.Write([]byte(time.Now().Format(time.DateOnly))); err != nil {
+		return "", err
+	}
+	// files with a mapped extension are detected differently
+	if len(scanSettings.Scan.LanguageExtensions) != 0 {
+		languageExtensions, err := json.Marshal(scanSettings.Scan.LanguageExtensions)
+		if err != nil {
+			return "", err
+		}
+		if _, err := hashBuilder.Write(languageExtensions); err != nil {
+			return "", err
+		}
+	}
+	// following calls finds data in files which are otherwise unchanged
+	if depth := scanSettings.Scan.InterproceduralDepth; depth != 0 {
+		if _, err := hashBuilder.Write([]byte(fmt.Sprintf("interprocedural-depth:%d", depth))); err != nil {
+			return "", err
+		}
+	}
 
 	return hex.EncodeToString(hashBuilder.Sum(nil)[:]), nil
 }
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/conformance"
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/util/output"
+	"github.com/bearer/bearer/internal/version_check"
+)
+
+var ErrConformanceFailed = errors.New("some languages don't pass every conformance scenario")
+
+func NewConformanceCommand() *cobra.Command {
+	var ConformanceFlags = flag.Flags{
+		flag.ConformanceFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "conformance",
+		Short: "Check the language analyzers against the conformance suite",
+		Long: `Scan the fixtures of the conformance suite and report, for each language,
+which of the shared scenarios its analyzer supports: log leaks, data sent to
+third parties, insecure transport and hard-coded secrets.`,
+		Example: `# Check every language
+$ bearer conformance
+
+# Check the Ruby and Python analyzers, with the results as JSON
+$ bearer conformance --language ruby,python --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := ConformanceFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			setLogLevel(cmd)
+
+			options, err := ConformanceFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			config, err := standaloneScanConfig(nil)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			report, err := conformance.Run(cmd.Context(), config, options.ConformanceOptions.ConformanceLanguages)
+			if err != nil {
+				return err
+			}
+
+			content := report.String()
+			if options.ConformanceOptions.ConformanceFormat == flag.FormatJSON {
+				content, err = output.ReportJSON(report)
+				if err != nil {
+					return err
+				}
+				content += "\n"
+			}
+
+			cmd.Print(content)
+
+			if report.Failed() {
+				return ErrConformanceFailed
+			}
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	ConformanceFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, ConformanceFlags.Usages(cmd)))
+
+	return cmd
+}
+
+// standaloneScanConfig returns the default scan settings, without loading any
+// config file or default rules, with only the rules of the given directories.
+// When rule ids are given, only those rules (and the rules they need) run.
+func standaloneScanConfig(externalRuleDirs []string, onlyRuleIDs ...string) (settings.Config, error) {
+	if err := ScanFlags.BindForConfigInit(NewScanCommand()); err != nil {
+		return settings.Config{}, fmt.Errorf("flag bind error: %w", err)
+	}
+
+	options, err := ScanFlags.ToOptions([]string{})
+	if err != nil {
+		return settings.Config{}, fmt.Errorf("flag error: %s", err)
+	}
+	options.RuleOptions.DisableDefaultRules = true
+	options.ScanOptions.ExternalRuleDir = externalRuleDirs
+	if len(onlyRuleIDs) != 0 {
+		options.RuleOptions.OnlyRule = make(map[string]bool)
+		for _, id := range onlyRuleIDs {
+			options.RuleOptions.OnlyRule[id] = true
+		}
+	}
+
+	return settings.FromOptions(options, &version_check.VersionMeta{
+		Rules: version_check.RuleVersionMeta{
+			Packages: make(map[string]string),
+		},
+		Binary: version_check.BinaryVersionMeta{
+			Latest: true,
+		},
+	})
+}
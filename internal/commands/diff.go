@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/diff"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/util/output"
+)
+
+var ErrBothReportsFromStdin = errors.New("only one of the reports can be read from standard input")
+
+func NewDiffCommand() *cobra.Command {
+	var DiffFlags = flag.Flags{
+		flag.DiffFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff <baseline-report> <current-report>",
+		Short: "Compare two security reports",
+		Example: `# Compare two saved security reports
+$ bearer diff baseline.json current.json
+
+# Compare a saved report with the current scan
+$ bearer scan . --format jsonv2 --quiet | bearer diff baseline.json - --format markdown`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := DiffFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			if len(args) != 2 {
+				return cmd.Help()
+			}
+
+			if args[0] == "-" && args[1] == "-" {
+				return ErrBothReportsFromStdin
+			}
+
+			setLogLevel(cmd)
+
+			options, err := DiffFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			baseline, err := readReportFindings(args[0])
+			if err != nil {
+				return fmt.Errorf("error reading baseline report %s: %w", args[0], err)
+			}
+
+			current, err := readReportFindings(args[1])
+			if err != nil {
+				return fmt.Errorf("error reading current report %s: %w", args[1], err)
+			}
+
+			report := diff.Compare(baseline, current)
+
+			var content string
+			switch options.DiffOptions.OutputFormat {
+			case flag.DiffFormatMarkdown:
+				content = report.Markdown()
+			default:
+				content, err = output.ReportJSON(report)
+				if err != nil {
+					return err
+				}
+			}
+
+			writer := cmd.OutOrStdout()
+			if options.DiffOptions.OutputPath != "" {
+				file, err := os.Create(options.DiffOptions.OutputPath)
+				if err != nil {
+					return fmt.Errorf("error creating output file %s: %w", options.DiffOptions.OutputPath, err)
+				}
+				defer file.Close()
+
+				writer = file
+			}
+
+			if _, err := fmt.Fprintln(writer, content); err != nil {
+				return err
+			}
+
+			if len(report.New) != 0 {
+				exitCode := options.DiffOptions.NewFindingsExitCode
+				if exitCode == -1 {
+					exitCode = 1
+				}
+
+				if exitCode != 0 {
+					// the comparison has been written, so there is no error to show
+					cmd.SilenceErrors = true
+					cmd.SilenceUsage = true
+					return &ExitCodeError{Code: exitCode}
+				}
+			}
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	DiffFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, DiffFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func readReportFindings(path string) ([]securitytypes.RawFinding, error) {
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		reader = file
+	}
+
+	return diff.ReadFindings(reader)
+}
@@ -0,0 +1,52 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands"
+)
+
+const (
+	baselineReport = "../report/diff/testdata/baseline.json"
+	currentReport  = "../report/diff/testdata/current.json"
+)
+
+func runDiff(args ...string) (string, error) {
+	var out bytes.Buffer
+
+	cmd := commands.NewDiffCommand()
+	cmd.SetArgs(args)
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestDiffWithNewFindingsReturnsExitCode(t *testing.T) {
+	out, err := runDiff(baselineReport, currentReport, "--exit-code", "-1")
+
+	var exitCodeError *commands.ExitCodeError
+	if !errors.As(err, &exitCodeError) {
+		t.Fatalf("expected an exit code error, got %v", err)
+	}
+	assert.Equal(t, 1, exitCodeError.Code)
+	assert.Contains(t, out, `"new"`)
+	assert.NotContains(t, out, "Error:")
+}
+
+func TestDiffWithExitCodeZero(t *testing.T) {
+	_, err := runDiff(baselineReport, currentReport, "--exit-code", "0")
+
+	assert.NoError(t, err)
+}
+
+func TestDiffRejectsBothReportsFromStdin(t *testing.T) {
+	_, err := runDiff("-", "-")
+
+	assert.ErrorIs(t, err, commands.ErrBothReportsFromStdin)
+}
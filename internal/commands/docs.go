@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/internal/classification/db"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/docs"
+	"github.com/bearer/bearer/internal/flag"
+)
+
+func NewDocsCommand() *cobra.Command {
+	usageTemplate := `
+Usage: bearer docs <command> [flags]
+
+Available Commands:
+    search           Search the rule, data type and command documentation
+
+Examples:
+    # Search the documentation for a rule
+    $ bearer docs search ruby_lang_logger
+
+    # Search the documentation for a topic
+    $ bearer docs search sql injection
+
+`
+
+	cmd := &cobra.Command{
+		Use:           "docs [subcommand]",
+		Short:         "Search the documentation available offline",
+		Args:          cobra.NoArgs,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+
+	cmd.AddCommand(newDocsSearchCommand())
+
+	cmd.SetUsageTemplate(usageTemplate)
+
+	return cmd
+}
+
+func newDocsSearchCommand() *cobra.Command {
+	var DocsSearchFlags = flag.Flags{
+		flag.DocsFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "search <term>",
+		Short: "Search the rule, data type and command documentation",
+		Long: `Search the documentation of rules, data types and commands. No network
+access is needed: default rules are read from the local rule cache, which is
+filled by any previous scan.`,
+		Example: `# Search the documentation for a rule
+$ bearer docs search ruby_lang_logger
+
+# Search the documentation for a topic, including external rules
+$ bearer docs search sql injection --external-rule-dir /path/to/rules`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := DocsSearchFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+
+			setLogLevel(cmd)
+
+			options, err := DocsSearchFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			definitions, err := settings.LoadRuleDocumentation(options.DocsOptions.DocsExternalRuleDir)
+			if err != nil {
+				return err
+			}
+
+			index := docs.NewIndex(
+				docs.RuleEntries(definitions),
+				docs.DataTypeEntries(db.Default()),
+				docs.CommandEntries(cmd.Root()),
+			)
+
+			term := strings.Join(args, " ")
+			results := index.Search(term)
+			if len(results) == 0 {
+				cmd.Printf("No documentation found matching '%s'\n", term)
+				return nil
+			}
+
+			limit := options.DocsOptions.DocsLimit
+			if limit > 0 && len(results) > limit {
+				defer cmd.Printf("Showing %d of %d results, use --limit to show more\n", limit, len(results))
+				results = results[:limit]
+			}
+
+			for _, result := range results {
+				cmd.Printf("[%s] %s\n", result.Kind, result.ID)
+				if result.Title != "" && result.Title != result.ID {
+					cmd.Printf("  %s\n", result.Title)
+				}
+				if excerpt := result.Excerpt(term); excerpt != "" {
+					cmd.Printf("  %s\n", excerpt)
+				}
+				if result.URL != "" {
+					cmd.Printf("  %s\n", result.URL)
+				}
+				cmd.Print("\n")
+			}
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	DocsSearchFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, DocsSearchFlags.Usages(cmd)))
+
+	return cmd
+}
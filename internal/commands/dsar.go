@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/dsar"
+	"github.com/bearer/bearer/internal/report/merge"
+	"github.com/bearer/bearer/internal/util/output"
+)
+
+func NewDSARCommand() *cobra.Command {
+	var DSARFlags = flag.Flags{
+		flag.DSARFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "dsar <report> [<report>...] --data-type <data-type>",
+		Short: "Export where a data type is processed, for data subject requests",
+		Long: `Export every location of a data type, such as email, along with the data
+stores, internal services and third parties found in the same files, as a
+machine-readable map. This is a starting point for tooling that answers data
+subject access and deletion requests.
+
+The reports are dataflow reports. Several reports are merged, as with the
+merge-dataflow command.`,
+		Example: `# Export where email addresses are processed
+$ bearer scan . --report dataflow --output dataflow.json
+$ bearer dsar dataflow.json --data-type email
+
+# Export across several services in YAML format
+$ bearer dsar api=api.json web=web.json --data-type email --format yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := DSARFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+
+			setLogLevel(cmd)
+
+			options, err := DSARFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			cmd.SilenceUsage = true
+
+			var services []merge.Service
+			for _, arg := range args {
+				service, err := merge.ReadService(arg)
+				if err != nil {
+					return fmt.Errorf("error reading dataflow report %s: %w", arg, err)
+				}
+
+				services = append(services, service)
+			}
+
+			dataflow := services[0].Dataflow
+			if len(services) > 1 {
+				dataflow, err = merge.Merge(services)
+				if err != nil {
+					return err
+				}
+			}
+
+			export, err := dsar.Build(dataflow, options.DSAROptions.DSARDataType)
+			if err != nil {
+				return err
+			}
+
+			var content string
+			switch options.DSAROptions.DSARFormat {
+			case flag.FormatYAML:
+				content, err = output.ReportYAML(export)
+			default:
+				content, err = output.ReportJSON(export)
+			}
+			if err != nil {
+				return err
+			}
+
+			writer := cmd.OutOrStdout()
+			if options.DSAROptions.DSAROutput != "" {
+				file, err := os.Create(options.DSAROptions.DSAROutput)
+				if err != nil {
+					return fmt.Errorf("error creating output file %s: %w", options.DSAROptions.DSAROutput, err)
+				}
+				defer file.Close()
+
+				writer = file
+			}
+
+			_, err = fmt.Fprintln(writer, content)
+			return err
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	DSARFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, DSARFlags.Usages(cmd)))
+
+	return cmd
+}
@@ -0,0 +1,13 @@
+package commands
+
+import "fmt"
+
+// ExitCodeError ends a command with the given exit code once it has written
+// its output. It is not reported as an error.
+type ExitCodeError struct {
+	Code int
+}
+
+func (err *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit code %d", err.Code)
+}
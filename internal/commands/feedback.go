@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/feedback"
+	"github.com/bearer/bearer/internal/util/output"
+)
+
+func NewFeedbackCommand() *cobra.Command {
+	var FeedbackFlags = flag.Flags{
+		flag.FeedbackFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "feedback <fingerprint>",
+		Short: "Report a false positive finding",
+		Long: `Package the anonymized context of a false positive finding into a report you
+can send to Bearer or to your own rules team. The report contains the rule,
+the language and a hash of the shape of the matched code, but no source code,
+filenames or fingerprints.`,
+		Example: `# Save a report, then give feedback on one of its findings
+$ bearer scan . --format json --output report.json
+$ bearer feedback <fingerprint> --report-file report.json --reason "sanitized upstream"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := FeedbackFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			setLogLevel(cmd)
+
+			options, err := FeedbackFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			findings, err := readReportFindings(options.FeedbackOptions.FeedbackReportFile)
+			if err != nil {
+				return fmt.Errorf("error reading report %s: %w", options.FeedbackOptions.FeedbackReportFile, err)
+			}
+
+			finding := feedback.FindFinding(findings, args[0])
+			if finding == nil {
+				return fmt.Errorf("no finding with fingerprint %s in %s", args[0], options.FeedbackOptions.FeedbackReportFile)
+			}
+
+			report := feedback.New(*finding, options.FeedbackOptions.FeedbackReason, time.Now())
+			if err := report.AddShape(finding.FullFilename, finding.Sink); err != nil {
+				output.StdErrLog(fmt.Sprintf("Unable to include the shape of the matched code: %s", err))
+			}
+
+			content, err := output.ReportJSON(report)
+			if err != nil {
+				return err
+			}
+
+			writer := cmd.OutOrStdout()
+			if options.FeedbackOptions.FeedbackOutput != "" {
+				file, err := os.Create(options.FeedbackOptions.FeedbackOutput)
+				if err != nil {
+					return fmt.Errorf("error creating output file %s: %w", options.FeedbackOptions.FeedbackOutput, err)
+				}
+				defer file.Close()
+
+				writer = file
+			}
+
+			_, err = fmt.Fprintln(writer, content)
+			return err
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	FeedbackFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, FeedbackFlags.Usages(cmd)))
+
+	return cmd
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/feedback"
+	"github.com/bearer/bearer/internal/report/fix"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/util/output"
+)
+
+func NewFixCommand() *cobra.Command {
+	var FixFlags = flag.Flags{
+		flag.FixFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "fix [fingerprint...]",
+		Short: "Fix findings using the fixes suggested by their rules",
+		Long: `Rewrite the code of findings whose rule suggests a fix, such as weak hash
+functions or insecure cookie flags. The findings are read from a saved security
+report, and can be limited to the given fingerprints. By default the fixes are
+output as unified diffs; use --apply to change the files.`,
+		Example: `# Save a report, then review its fixes
+$ bearer scan . --format json --output report.json
+$ bearer fix --report-file report.json --dry-run
+
+# Apply the fix of one finding
+$ bearer fix <fingerprint> --report-file report.json --apply`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := FixFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			setLogLevel(cmd)
+
+			options, err := FixFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			findings, err := readReportFindings(options.FixOptions.FixReportFile)
+			if err != nil {
+				return fmt.Errorf("error reading report %s: %w", options.FixOptions.FixReportFile, err)
+			}
+
+			if len(args) != 0 {
+				var selected []securitytypes.RawFinding
+				for _, fingerprint := range args {
+					finding := feedback.FindFinding(findings, fingerprint)
+					if finding == nil {
+						return fmt.Errorf("no finding with fingerprint %s in %s", fingerprint, options.FixOptions.FixReportFile)
+					}
+
+					selected = append(selected, *finding)
+				}
+
+				findings = selected
+			}
+
+			files, skipped := fix.Plan(findings)
+
+			fixCount := 0
+			for _, file := range files {
+				fixCount += len(file.Fingerprints)
+
+				if options.FixOptions.FixApply {
+					if err := file.Apply(); err != nil {
+						return fmt.Errorf("error writing %s: %w", file.Filename, err)
+					}
+
+					continue
+				}
+
+				diff, err := file.Diff()
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprint(cmd.OutOrStdout(), diff)
+			}
+
+			for _, skippedFinding := range skipped {
+				output.StdErrLog(fmt.Sprintf(
+					"Skipped %s (%s): %s",
+					skippedFinding.Fingerprint,
+					skippedFinding.RuleID,
+					skippedFinding.Reason,
+				))
+			}
+
+			if options.FixOptions.FixApply {
+				output.StdErrLog(fmt.Sprintf("Fixed %d finding(s) in %d file(s)", fixCount, len(files)))
+			} else {
+				output.StdErrLog(fmt.Sprintf("%d finding(s) can be fixed in %d file(s). Use --apply to change the files", fixCount, len(files)))
+			}
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	FixFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, FixFlags.Usages(cmd)))
+
+	return cmd
+}
@@ -3,16 +3,23 @@ package commands
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/exp/maps"
 
 	"github.com/bearer/bearer/internal/commands/process/gitrepository"
+	"github.com/bearer/bearer/internal/commands/process/settings"
 	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/output/security"
 	"github.com/bearer/bearer/internal/util/ignore"
 	ignoretypes "github.com/bearer/bearer/internal/util/ignore/types"
 	"github.com/bearer/bearer/internal/util/output"
@@ -30,6 +37,7 @@ Available Commands:
     remove           Remove an ignored fingerprint
     pull             Pull ignored fingerprints from Cloud
     migrate          Migrate ignored fingerprints
+    rewrite          Rewrite ignored fingerprints after moving files
 
 Examples:
     # Add an ignored fingerprint to your ignore file
@@ -47,6 +55,9 @@ Examples:
     # Migrate existing ignored (excluded) fingerprints from bearer.yml file
     $ bearer ignore migrate
 
+    # Rewrite ignored fingerprints after moving a directory
+    $ bearer ignore rewrite /path/to/your_project --path-prefix app/=services/app/
+
 `
 
 	cmd := &cobra.Command{
@@ -63,6 +74,7 @@ Examples:
 		newIgnoreRemoveCommand(),
 		newIgnorePullCommand(),
 		newIgnoreMigrateCommand(),
+		newIgnoreRewriteCommand(),
 	)
 
 	cmd.SetUsageTemplate(usageTemplate)
@@ -467,6 +479,150 @@ $ bearer ignore migrate`,
 	return cmd
 }
 
+func newIgnoreRewriteCommand() *cobra.Command {
+	flags := flag.Flags{
+		flag.GeneralFlagGroup,
+		flag.IgnoreRewriteFlagGroup,
+	}
+	cmd := &cobra.Command{
+		Use:   "rewrite [path]",
+		Short: "Rewrite ignored fingerprints after moving files",
+		Example: `# Rewrite ignored fingerprints after moving a directory
+$ bearer ignore rewrite /path/to/your_project --path-prefix app/=services/app/
+
+# Rewrite ignored fingerprints using a file mapping old paths to new paths
+$ bearer ignore rewrite /path/to/your_project --rename-map renames.yml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			setLogLevel(cmd)
+
+			_, loadFileMessage, _ := readConfig(args)
+			log.Debug().Msgf(loadFileMessage)
+
+			options, err := flags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			target := "."
+			if len(args) > 0 {
+				target = args[0]
+			}
+
+			rewriteOptions := options.IgnoreRewriteOptions
+			var renames []ignore.Rename
+			for _, pathPrefix := range rewriteOptions.RewritePathPrefixes {
+				rename, err := ignore.ParsePathPrefix(pathPrefix)
+				if err != nil {
+					return fmt.Errorf("flag error: %s", err)
+				}
+				renames = append(renames, rename)
+			}
+			if rewriteOptions.RewriteRenameMap != "" {
+				renameMap, err := ignore.ReadRenameMap(rewriteOptions.RewriteRenameMap)
+				if err != nil {
+					return err
+				}
+				renames = append(renames, renameMap...)
+			}
+			if len(renames) == 0 {
+				return errors.New("no renames given; use --path-prefix or --rename-map")
+			}
+
+			ignoredFingerprints, ignoreFilePath, fileExists, err := ignore.GetIgnoredFingerprints(options.GeneralOptions.IgnoreFile, &target)
+			if err != nil {
+				return fmt.Errorf("error retrieving existing ignores: %s", err)
+			}
+			if !fileExists {
+				cmd.Printf("No ignore file found at %s\n", ignoreFilePath)
+				return nil
+			}
+
+			definitions, err := settings.LoadRuleDocumentation(rewriteOptions.RewriteExternalRuleDir)
+			if err != nil {
+				return err
+			}
+
+			files, err := listTargetFiles(target)
+			if err != nil {
+				return fmt.Errorf("error listing files in %s: %s", target, err)
+			}
+
+			config := settings.Config{Report: flag.ReportOptions{
+				FingerprintHash: rewriteOptions.RewriteFingerprintHash,
+				FingerprintSalt: rewriteOptions.RewriteFingerprintSalt,
+			}}
+			rewrites := ignore.RewriteFingerprints(
+				ignoredFingerprints,
+				files,
+				maps.Keys(definitions),
+				renames,
+				func(ruleID string, filename string, instance int) string {
+					return security.Fingerprint(config, ruleID, filename, instance)
+				},
+			)
+			rewritten := ignore.ApplyRewrites(ignoredFingerprints, rewrites)
+
+			for _, fingerprint := range rewritten {
+				cmd.Printf("%s -> %s\n", fingerprint, rewrites[fingerprint])
+			}
+			if skipped := len(rewrites) - len(rewritten); skipped != 0 {
+				cmd.Printf("Skipped %d fingerprints already ignored under their new path\n", skipped)
+			}
+
+			if rewriteOptions.RewriteDryRun {
+				cmd.Printf("Found %d fingerprints to rewrite in:\n\t%s\n", len(rewritten), ignoreFilePath)
+				return nil
+			}
+
+			cmd.Printf("Rewrote %d fingerprints in:\n\t%s\n", len(rewritten), ignoreFilePath)
+			if len(rewritten) == 0 {
+				return nil
+			}
+
+			return writeIgnoreFile(ignoredFingerprints, ignoreFilePath)
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	flags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, flags.Usages(cmd)))
+
+	return cmd
+}
+
+// listTargetFiles returns the files in the target, relative to it, as they
+// appear in fingerprints
+func listTargetFiles(target string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(target, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(target, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relativePath))
+
+		return nil
+	})
+
+	return files, err
+}
+
 func setLogLevel(cmd *cobra.Command) {
 	logLevel := viper.GetString(flag.LogLevelFlag.ConfigName)
 	if viper.GetBool(flag.DebugFlag.ConfigName) {
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/merge"
+	"github.com/bearer/bearer/internal/report/output/diagram"
+	"github.com/bearer/bearer/internal/util/output"
+)
+
+func NewMergeDataflowCommand() *cobra.Command {
+	var MergeFlags = flag.Flags{
+		flag.MergeFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "merge-dataflow <report> [<report>...]",
+		Short: "Merge the dataflow reports of several services",
+		Long: `Join the dataflow reports of several repositories or services into a single
+report. Filenames are prefixed with the name of their service, and the
+components shared by the services, such as third parties and data stores, are
+merged into one.
+
+Each service is named after its report file, or with the <name>=<path> form.`,
+		Example: `# Merge the dataflow reports of two services
+$ bearer scan ./api --report dataflow --output api.json
+$ bearer scan ./web --report dataflow --output web.json
+$ bearer merge-dataflow api.json web.json
+
+# Name the services and render the merged data flow as a Mermaid diagram
+$ bearer merge-dataflow payments=reports/a.json storefront=reports/b.json --format mermaid`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := MergeFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+
+			setLogLevel(cmd)
+
+			options, err := MergeFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			cmd.SilenceUsage = true
+
+			var services []merge.Service
+			for _, arg := range args {
+				service, err := merge.ReadService(arg)
+				if err != nil {
+					return fmt.Errorf("error reading dataflow report %s: %w", arg, err)
+				}
+
+				services = append(services, service)
+			}
+
+			dataflow, err := merge.Merge(services)
+			if err != nil {
+				return err
+			}
+
+			var content string
+			switch options.MergeOptions.MergeFormat {
+			case flag.FormatYAML:
+				content, err = output.ReportYAML(dataflow)
+			case flag.FormatMermaid:
+				content, err = diagram.ReportMermaid(dataflow)
+			case flag.FormatDOT:
+				content, err = diagram.ReportDOT(dataflow)
+			default:
+				content, err = output.ReportJSON(dataflow)
+			}
+			if err != nil {
+				return err
+			}
+
+			writer := cmd.OutOrStdout()
+			if options.MergeOptions.MergeOutput != "" {
+				file, err := os.Create(options.MergeOptions.MergeOutput)
+				if err != nil {
+					return fmt.Errorf("error creating output file %s: %w", options.MergeOptions.MergeOutput, err)
+				}
+				defer file.Close()
+
+				writer = file
+			}
+
+			_, err = fmt.Fprintln(writer, content)
+			return err
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	MergeFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, MergeFlags.Usages(cmd)))
+
+	return cmd
+}
@@ -1,6 +1,7 @@
 package filelist
 
 import (
+	"fmt"
 	"io/fs"
 	"path/filepath"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/bearer/bearer/internal/commands/process/filelist/timeout"
 	"github.com/bearer/bearer/internal/commands/process/gitrepository"
 	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/util/maputil"
 )
 
 // Discover searches directory for files to scan, skipping the ones specified by skip config and assigning timeout speficfied by timeout config
@@ -28,6 +30,7 @@ func Discover(repository *gitrepository.Repository, targetPath string, goclocRes
 
 		if fileList != nil {
 			log.Debug().Msg("Files found from Git")
+			fileList.SkippedBinaryFiles = ignore.SkippedBinaryFiles()
 			return fileList, nil
 		}
 
@@ -73,5 +76,24 @@ func Discover(repository *gitrepository.Repository, targetPath string, goclocRes
 		return nil
 	})
 
-	return &flfiles.List{Files: files}, err
+	return &flfiles.List{Files: files, SkippedBinaryFiles: ignore.SkippedBinaryFiles()}, err
+}
+
+// SkippedBinaryFilesSummary describes the number of binary files skipped by
+// kind, e.g. "Skipped 3 binary files (2 image, 1 font)"
+func SkippedBinaryFilesSummary(skippedBinaryFiles map[string]int) string {
+	total := 0
+	var kinds []string
+	for _, kind := range maputil.SortedStringKeys(skippedBinaryFiles) {
+		count := skippedBinaryFiles[kind]
+		total += count
+		kinds = append(kinds, fmt.Sprintf("%d %s", count, kind))
+	}
+
+	noun := "files"
+	if total == 1 {
+		noun = "file"
+	}
+
+	return fmt.Sprintf("Skipped %d binary %s (%s)", total, noun, strings.Join(kinds, ", "))
 }
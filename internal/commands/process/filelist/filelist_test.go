@@ -121,6 +121,31 @@ func TestFileList(t *testing.T) {
 			},
 			Want: &files.List{},
 		},
+		{
+			Name: "Find files - binary files are skipped",
+			Input: input{
+				projectPath: filepath.Join("testdata", "happy_path", "binary"),
+				config: settings.Config{
+					Worker: settings.WorkerOptions{
+						FileSizeMaximum:           100000,
+						TimeoutFileBytesPerSecond: 1,
+					},
+				},
+			},
+			Want: &files.List{
+				Files: []files.File{
+					{
+						FilePath: "main.rb",
+						Timeout:  0,
+					},
+				},
+				SkippedBinaryFiles: map[string]int{
+					"binary": 1,
+					"font":   1,
+					"image":  2,
+				},
+			},
+		},
 	}
 
 	for _, testCase := range tests {
@@ -155,3 +180,12 @@ func TestFileList(t *testing.T) {
 	}
 
 }
+
+func TestSkippedBinaryFilesSummary(t *testing.T) {
+	assert.Equal(
+		t,
+		"Skipped 4 binary files (1 binary, 1 font, 2 image)",
+		filelist.SkippedBinaryFilesSummary(map[string]int{"image": 2, "font": 1, "binary": 1}),
+	)
+	assert.Equal(t, "Skipped 1 binary file (1 archive)", filelist.SkippedBinaryFilesSummary(map[string]int{"archive": 1}))
+}
@@ -11,6 +11,8 @@ type List struct {
 	BaseFiles []File
 	Renames   map[string]string
 	Chunks    map[string]git.Chunks
+	// number of binary files skipped for each kind of content
+	SkippedBinaryFiles map[string]int
 }
 
 type File struct {
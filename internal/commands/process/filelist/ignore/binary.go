@@ -0,0 +1,77 @@
+package ignore
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// number of bytes used by http.DetectContentType
+const sniffLength = 512
+
+const (
+	BinaryKindImage    = "image"
+	BinaryKindAudio    = "audio"
+	BinaryKindVideo    = "video"
+	BinaryKindFont     = "font"
+	BinaryKindArchive  = "archive"
+	BinaryKindDocument = "document"
+	BinaryKindBinary   = "binary"
+)
+
+var binaryKindsByMIMEType = map[string]string{
+	"application/ogg":               BinaryKindAudio,
+	"application/vnd.ms-fontobject": BinaryKindFont,
+	"application/pdf":               BinaryKindDocument,
+	"application/postscript":        BinaryKindDocument,
+	"application/zip":               BinaryKindArchive,
+	"application/x-gzip":            BinaryKindArchive,
+	"application/x-rar-compressed":  BinaryKindArchive,
+	"application/wasm":              BinaryKindBinary,
+	"application/octet-stream":      BinaryKindBinary,
+}
+
+var binaryKindsByMIMEPrefix = map[string]string{
+	"image/": BinaryKindImage,
+	"audio/": BinaryKindAudio,
+	"video/": BinaryKindVideo,
+	"font/":  BinaryKindFont,
+}
+
+// sniffBinaryKind detects the MIME type of a file from its first bytes and
+// returns the kind of binary content it holds, if any
+func sniffBinaryKind(filePath string) (string, bool) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	buffer := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, buffer)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", false
+	}
+	if n == 0 {
+		return "", false
+	}
+
+	return binaryKind(http.DetectContentType(buffer[:n]))
+}
+
+func binaryKind(mimeType string) (string, bool) {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+
+	if kind, ok := binaryKindsByMIMEType[mimeType]; ok {
+		return kind, true
+	}
+
+	for prefix, kind := range binaryKindsByMIMEPrefix {
+		if strings.HasPrefix(mimeType, prefix) {
+			return kind, true
+		}
+	}
+
+	return "", false
+}
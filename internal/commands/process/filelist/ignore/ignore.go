@@ -13,17 +13,29 @@ import (
 )
 
 type FileIgnore struct {
-	ignorer *ignore.GitIgnore
-	config  settings.Config
+	ignorer            *ignore.GitIgnore
+	config             settings.Config
+	skippedBinaryFiles map[string]int
 }
 
 func New(projectPath string, config settings.Config) *FileIgnore {
 	return &FileIgnore{
-		ignorer: ignorerFromStrings(config.Scan.SkipPath),
-		config:  config,
+		ignorer:            ignorerFromStrings(config.Scan.SkipPath),
+		config:             config,
+		skippedBinaryFiles: make(map[string]int),
 	}
 }
 
+// SkippedBinaryFiles returns the number of binary files skipped for each kind
+// of content, or nil if none were skipped
+func (fileignore *FileIgnore) SkippedBinaryFiles() map[string]int {
+	if len(fileignore.skippedBinaryFiles) == 0 {
+		return nil
+	}
+
+	return fileignore.skippedBinaryFiles
+}
+
 func (fileignore *FileIgnore) Ignore(
 	projectPath string,
 	filePath string,
@@ -53,6 +65,11 @@ func (fileignore *FileIgnore) Ignore(
 			log.Debug().Msgf("skipping file (suspected minified JS): %s %s", projectPath, relativePath)
 			return true
 		}
+		if kind, isBinary := sniffBinaryKind(filePath); isBinary {
+			log.Debug().Msgf("skipping %s file: %s %s", kind, projectPath, relativePath)
+			fileignore.skippedBinaryFiles[kind]++
+			return true
+		}
 	}
 
 	dirTrimmedPath := filepath.Dir(trimmedPath)
@@ -0,0 +1,73 @@
+package priority
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bearer/bearer/internal/commands/process/filelist/files"
+	"github.com/bearer/bearer/internal/report/history"
+)
+
+type fileScore struct {
+	changed   bool
+	findings  int
+	dataTypes int
+	modTime   time.Time
+}
+
+// Sort orders the files so that those most likely to have findings are scanned
+// first. Files modified since the last recorded scan come first, followed by
+// the files with the most findings, then the most data types, across the
+// history entries. Remaining ties are broken by the most recently modified.
+func Sort(targetPath string, fileList []files.File, entries []history.Entry) {
+	var lastScan time.Time
+	findings := make(map[string]int)
+	dataTypes := make(map[string]int)
+
+	for _, entry := range entries {
+		if entry.Timestamp.After(lastScan) {
+			lastScan = entry.Timestamp
+		}
+
+		for filename, fileEntry := range entry.Files {
+			findings[filename] += fileEntry.Findings
+			dataTypes[filename] += fileEntry.DataTypes
+		}
+	}
+
+	scores := make(map[string]fileScore, len(fileList))
+	for _, file := range fileList {
+		score := fileScore{
+			findings:  findings[file.FilePath],
+			dataTypes: dataTypes[file.FilePath],
+		}
+
+		if fileInfo, err := os.Stat(filepath.Join(targetPath, file.FilePath)); err == nil {
+			score.modTime = fileInfo.ModTime()
+			score.changed = !lastScan.IsZero() && score.modTime.After(lastScan)
+		}
+
+		scores[file.FilePath] = score
+	}
+
+	sort.SliceStable(fileList, func(i, j int) bool {
+		scoreI := scores[fileList[i].FilePath]
+		scoreJ := scores[fileList[j].FilePath]
+
+		if scoreI.changed != scoreJ.changed {
+			return scoreI.changed
+		}
+
+		if scoreI.findings != scoreJ.findings {
+			return scoreI.findings > scoreJ.findings
+		}
+
+		if scoreI.dataTypes != scoreJ.dataTypes {
+			return scoreI.dataTypes > scoreJ.dataTypes
+		}
+
+		return scoreI.modTime.After(scoreJ.modTime)
+	})
+}
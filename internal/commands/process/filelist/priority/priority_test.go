@@ -0,0 +1,77 @@
+package priority_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands/process/filelist/files"
+	"github.com/bearer/bearer/internal/commands/process/filelist/priority"
+	"github.com/bearer/bearer/internal/report/history"
+)
+
+func writeFile(t *testing.T, dir, name string, modTime time.Time) files.File {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+		t.Fatalf("failed to write file, err: %s", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to set file times, err: %s", err)
+	}
+
+	return files.File{FilePath: name}
+}
+
+func filePaths(fileList []files.File) []string {
+	paths := make([]string, len(fileList))
+	for i, file := range fileList {
+		paths[i] = file.FilePath
+	}
+
+	return paths
+}
+
+func TestSort(t *testing.T) {
+	dir := t.TempDir()
+	lastScan := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	fileList := []files.File{
+		writeFile(t, dir, "quiet.rb", lastScan.Add(-48*time.Hour)),
+		writeFile(t, dir, "datatypes.rb", lastScan.Add(-48*time.Hour)),
+		writeFile(t, dir, "findings.rb", lastScan.Add(-48*time.Hour)),
+		writeFile(t, dir, "recent.rb", lastScan.Add(-time.Hour)),
+		writeFile(t, dir, "changed.rb", lastScan.Add(time.Hour)),
+	}
+
+	t.Run("without history", func(t *testing.T) {
+		sorted := append([]files.File{}, fileList...)
+		priority.Sort(dir, sorted, nil)
+
+		assert.Equal(t, []string{"changed.rb", "recent.rb", "quiet.rb", "datatypes.rb", "findings.rb"}, filePaths(sorted))
+	})
+
+	t.Run("with history", func(t *testing.T) {
+		entries := []history.Entry{
+			{
+				Timestamp: lastScan.Add(-24 * time.Hour),
+				Files:     map[string]history.FileEntry{"findings.rb": {Findings: 1}},
+			},
+			{
+				Timestamp: lastScan,
+				Files: map[string]history.FileEntry{
+					"findings.rb":  {Findings: 2, DataTypes: 1},
+					"datatypes.rb": {DataTypes: 5},
+					"changed.rb":   {DataTypes: 1},
+				},
+			},
+		}
+
+		sorted := append([]files.File{}, fileList...)
+		priority.Sort(dir, sorted, entries)
+
+		assert.Equal(t, []string{"changed.rb", "findings.rb", "datatypes.rb", "recent.rb", "quiet.rb"}, filePaths(sorted))
+	})
+}
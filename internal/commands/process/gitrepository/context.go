@@ -215,6 +215,11 @@ func lookupBaseCommitHashFromGithub(options *flag.Options, baseBranch string, cu
 		return "", nil
 	}
 
+	if options.Offline {
+		log.Debug().Msg("skipping github api merge base lookup as offline mode is enabled")
+		return "", nil
+	}
+
 	log.Debug().Msg("finding merge base using github api")
 
 	splitRepository := strings.SplitN(options.GithubRepository, "/", 2)
@@ -82,6 +82,10 @@ func (repository *Repository) fetchMergeBaseCommit() error {
 		return err
 	}
 
+	if repository.config.Offline {
+		return fmt.Errorf("merge base commit %s is not present locally and cannot be fetched in offline mode", hash)
+	}
+
 	log.Debug().Msgf("merge base commit not present, fetching")
 
 	if err := git.FetchRef(repository.ctx, repository.context.RootDir, hash); err != nil {
@@ -7,6 +7,7 @@ import (
 	"path"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/bearer/bearer/internal/report/detections"
 	"github.com/bearer/bearer/internal/scanner/stats"
 	"github.com/bearer/bearer/internal/util/jsonlines"
+	"github.com/bearer/bearer/internal/util/output"
 	bearerprogress "github.com/bearer/bearer/internal/util/progressbar"
 	"github.com/bearer/bearer/internal/util/tmpfile"
 
@@ -54,6 +56,7 @@ func (orchestrator *Orchestrator) Scan(
 	files []files.File,
 ) error {
 	fileComplete := make(chan struct{}, len(files))
+	dispatchComplete := make(chan int, 1)
 
 	reportFile, err := os.Create(reportPath)
 	if err != nil {
@@ -61,22 +64,64 @@ func (orchestrator *Orchestrator) Scan(
 	}
 	defer reportFile.Close()
 
-	for _, file := range files {
+	var deadline time.Time
+	if orchestrator.config.Scan.MaxScanDuration != 0 {
+		deadline = time.Now().Add(orchestrator.config.Scan.MaxScanDuration)
+	}
+
+	go orchestrator.dispatch(reportFile, fileComplete, dispatchComplete, files, deadline)
+
+	scannedCount := orchestrator.waitForScan(fileComplete, dispatchComplete, len(files))
+	if scannedCount < len(files) && !orchestrator.config.Scan.Quiet {
+		output.StdErrLog(fmt.Sprintf(
+			"Maximum scan duration of %s reached, %d of %d files were not scanned",
+			orchestrator.config.Scan.MaxScanDuration,
+			len(files)-scannedCount,
+			len(files),
+		))
+	}
+
+	return orchestrator.writeFileList(reportFile, files[:scannedCount])
+}
+
+// dispatch starts scanning the files in the given order, as workers become
+// available. No further files are started once the deadline is reached, and
+// the number of files started is sent on dispatchComplete.
+func (orchestrator *Orchestrator) dispatch(
+	reportFile *os.File,
+	fileComplete chan struct{},
+	dispatchComplete chan int,
+	files []files.File,
+	deadline time.Time,
+) {
+	for i, file := range files {
 		select {
 		case <-orchestrator.done:
 			log.Debug().Msgf("scan stopping early due to close")
-			return nil
-		default:
+			return
+		case orchestrator.maxWorkersSemaphore <- struct{}{}:
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			log.Debug().Msgf("maximum scan duration reached after starting %d files", i)
+			<-orchestrator.maxWorkersSemaphore
+			dispatchComplete <- i
+			return
 		}
 
 		go orchestrator.scanFile(reportFile, fileComplete, file)
 	}
 
-	orchestrator.waitForScan(fileComplete, len(files))
-	return orchestrator.writeFileList(reportFile, files)
+	dispatchComplete <- len(files)
 }
 
-func (orchestrator *Orchestrator) waitForScan(fileComplete chan struct{}, totalCount int) {
+// waitForScan returns the number of files that were scanned, which is less
+// than totalCount when the scan was cut short by the maximum duration
+func (orchestrator *Orchestrator) waitForScan(
+	fileComplete chan struct{},
+	dispatchComplete chan int,
+	totalCount int,
+) int {
 	progressBar := bearerprogress.GetProgressBar(totalCount, orchestrator.config)
 	count := 0
 
@@ -88,7 +133,7 @@ func (orchestrator *Orchestrator) waitForScan(fileComplete chan struct{}, totalC
 
 	if totalCount == 0 {
 		log.Debug().Msgf("no files to scan")
-		return
+		return 0
 	}
 
 	for {
@@ -96,7 +141,13 @@ func (orchestrator *Orchestrator) waitForScan(fileComplete chan struct{}, totalC
 		case <-orchestrator.done:
 			log.Debug().Msgf("scan stopping early due to close")
 
-			return
+			return totalCount
+		case dispatchedCount := <-dispatchComplete:
+			totalCount = dispatchedCount
+
+			if count == totalCount {
+				return totalCount
+			}
 		case <-fileComplete:
 			count++
 
@@ -105,14 +156,14 @@ func (orchestrator *Orchestrator) waitForScan(fileComplete chan struct{}, totalC
 			}
 
 			if count == totalCount {
-				return
+				return totalCount
 			}
 		}
 	}
 }
 
+// scanFile expects the caller to have acquired a worker from the semaphore
 func (orchestrator *Orchestrator) scanFile(reportFile *os.File, fileComplete chan struct{}, file files.File) {
-	orchestrator.maxWorkersSemaphore <- struct{}{}
 	tmpReportPath := tmpfile.Create(".jsonl")
 
 	defer func() {
@@ -117,6 +117,7 @@ func (pool *Pool) get() (*Process, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error spawning %s: %w", id, err)
 	}
+	pool.stats.AddCompileStats(process.compileStats)
 
 	return process, nil
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/bearer/bearer/internal/commands/process/orchestrator/work"
 	"github.com/bearer/bearer/internal/commands/process/orchestrator/worker"
 	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/scanner/stats"
 	"github.com/bearer/bearer/internal/util/output"
 )
 
@@ -41,6 +42,8 @@ type Process struct {
 	client        *http.Client
 	baseURL       string
 	memoryUsage   uint64
+	// the time the process took to compile each rule
+	compileStats *stats.CompileStats
 }
 
 type ProcessOptions struct {
@@ -310,6 +313,7 @@ func (process *Process) initializationResponse(response *http.Response) (bool, e
 	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
 		return true, fmt.Errorf("error decoding status response: %w", err)
 	}
+	process.compileStats = result.CompileStats
 
 	if result.Error != "" {
 		return true, errors.New(result.Error)
@@ -6,7 +6,8 @@ import (
 )
 
 type InitializeResponse struct {
-	Error string
+	CompileStats *stats.CompileStats
+	Error        string
 }
 
 type ProcessResponse struct {
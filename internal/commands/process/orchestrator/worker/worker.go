@@ -30,42 +30,61 @@ import (
 var ErrorTimeoutReached = errors.New("file processing time exceeded")
 
 type Worker struct {
-	debug           bool
+	// whether to collect the stats of each file
+	collectStats    bool
 	classifer       *classification.Classifier
 	enabledScanners []string
 	sastScanner     *scanner.Scanner
+	// names of the annotations marking fields and functions as sanitized
+	sanitizerAnnotations []string
+	// languages assigned to file extensions, overriding the detected language
+	languageExtensions map[string]string
 }
 
-func (worker *Worker) Setup(config config.Config) error {
-	worker.debug = config.Debug
+func (worker *Worker) Setup(config config.Config) (*stats.CompileStats, error) {
+	worker.collectStats = config.Debug || config.Scan.RuleTimings != ""
 	worker.enabledScanners = config.Scan.Scanner
+	worker.sanitizerAnnotations = config.Scan.SanitizerAnnotations
+	worker.languageExtensions = config.Scan.LanguageExtensions
+
+	var compileStats *stats.CompileStats
+	if worker.collectStats {
+		compileStats = stats.NewCompileStats()
+	}
 
 	if slices.Contains(worker.enabledScanners, "sast") {
 		classifier, err := classification.NewClassifier(&classification.Config{Config: config})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		err = detectors.SetupLegacyDetector(config.BuiltInRules)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		sastScanner, err := scanner.New(classifier.Schema, config.Rules)
+		sastScanner, err := scanner.New(
+			classifier.Schema,
+			config.Rules,
+			config.Scan.SanitizerAnnotations,
+			config.Scan.InterproceduralDepth,
+			config.Scan.RuleTimeout,
+			compileStats,
+		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		worker.sastScanner = sastScanner
 		worker.classifer = classifier
 	}
 
-	return nil
+	return compileStats, nil
 }
 
 func (worker *Worker) Scan(ctx context.Context, scanRequest work.ProcessRequest) (*stats.FileStats, error) {
 	var fileStats *stats.FileStats
-	if worker.debug {
+	if worker.collectStats {
 		fileStats = stats.NewFileStats()
 	}
 
@@ -80,12 +99,14 @@ func (worker *Worker) Scan(ctx context.Context, scanRequest work.ProcessRequest)
 		scanRequest.Dir,
 		scanRequest.File.FilePath,
 		&writer.Detectors{
-			Classifier: worker.classifer,
-			File:       file,
+			Classifier:           worker.classifer,
+			File:                 file,
+			SanitizerAnnotations: worker.sanitizerAnnotations,
 		},
 		fileStats,
 		worker.enabledScanners,
 		worker.sastScanner,
+		worker.languageExtensions,
 	)
 
 	if ctx.Err() != nil {
@@ -122,10 +143,11 @@ func Start(parentProcessID int, port string) error {
 
 				response := work.InitializeResponse{}
 
-				err := worker.Setup(config)
+				compileStats, err := worker.Setup(config)
 				if err != nil {
 					response.Error = err.Error()
 				}
+				response.CompileStats = compileStats
 
 				json.NewEncoder(rw).Encode(response) //nolint:all,errcheck
 			case work.RouteProcess:
@@ -0,0 +1,17 @@
+#include <openssl/evp.h>
+#include <openssl/rc4.h>
+
+void encrypt(EVP_CIPHER_CTX *context, const unsigned char *key, const unsigned char *iv, RC4_KEY *rc4) {
+  // ruleid: c_openssl_weak_encryption
+  EVP_EncryptInit_ex(context, EVP_des_cbc(), NULL, key, iv);
+  // ruleid: c_openssl_weak_encryption
+  EVP_EncryptInit_ex(context, EVP_aes_128_ecb(), NULL, key, NULL);
+  // ruleid: c_openssl_weak_encryption
+  RC4_set_key(rc4, 16, key);
+  // ruleid: c_openssl_weak_encryption
+  EVP_CIPHER *bf = EVP_CIPHER_fetch(NULL, "BF-CBC", NULL);
+  // ok: c_openssl_weak_encryption
+  EVP_EncryptInit_ex(context, EVP_aes_256_gcm(), NULL, key, iv);
+  // ok: c_openssl_weak_encryption
+  EVP_CIPHER *aes = EVP_CIPHER_fetch(NULL, "AES-256-GCM", NULL);
+}
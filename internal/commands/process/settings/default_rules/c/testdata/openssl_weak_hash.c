@@ -0,0 +1,15 @@
+#include <openssl/evp.h>
+#include <openssl/md5.h>
+
+void digest(EVP_MD_CTX *context, const unsigned char *data, size_t length, unsigned char *out) {
+  // ruleid: c_openssl_weak_hash
+  EVP_DigestInit_ex(context, EVP_md5(), NULL);
+  // ruleid: c_openssl_weak_hash
+  MD5(data, length, out);
+  // ruleid: c_openssl_weak_hash
+  EVP_MD *sha1 = EVP_MD_fetch(NULL, "SHA1", NULL);
+  // ok: c_openssl_weak_hash
+  EVP_DigestInit_ex(context, EVP_sha256(), NULL);
+  // ok: c_openssl_weak_hash
+  EVP_MD *sha256 = EVP_MD_fetch(NULL, "SHA2-256", NULL);
+}
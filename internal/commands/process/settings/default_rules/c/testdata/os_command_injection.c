@@ -0,0 +1,18 @@
+#include <stdio.h>
+#include <stdlib.h>
+#include <unistd.h>
+
+void convert(const char *filename) {
+  char command[256];
+  snprintf(command, sizeof(command), "convert %s out.png", filename);
+  // ruleid: c_lang_os_command_injection
+  system(command);
+  // ruleid: c_lang_os_command_injection
+  FILE *output = popen(getenv("COMMAND"), "r");
+  // ruleid: c_lang_os_command_injection
+  execl("/bin/sh", "sh", "-c", command, NULL);
+  // ok: c_lang_os_command_injection
+  system("convert in.png out.png");
+  // ok: c_lang_os_command_injection
+  execlp("convert", "convert", filename, "out.png", NULL);
+}
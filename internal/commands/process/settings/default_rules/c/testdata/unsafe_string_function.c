@@ -0,0 +1,19 @@
+#include <stdio.h>
+#include <string.h>
+
+void greet(const char *input) {
+  char name[32];
+  char message[64];
+  // ruleid: c_lang_unsafe_string_function
+  strcpy(name, input);
+  // ruleid: c_lang_unsafe_string_function
+  sprintf(message, "Hello %s", name);
+  // ruleid: c_lang_unsafe_string_function
+  scanf("%s", name);
+  // ok: c_lang_unsafe_string_function
+  scanf("%31s", name);
+  // ok: c_lang_unsafe_string_function
+  snprintf(message, sizeof(message), "Hello %s", name);
+  // ok: c_lang_unsafe_string_function
+  strncpy(name, input, sizeof(name) - 1);
+}
@@ -0,0 +1,13 @@
+fun Application.module() {
+    routing {
+        get("/login") {
+            val next = call.parameters["next"]
+            // ruleid: kotlin_ktor_open_redirect
+            call.respondRedirect(next!!)
+            // ruleid: kotlin_ktor_open_redirect
+            call.respondRedirect("/welcome?from=${call.request.queryParameters["from"]}")
+            // ok: kotlin_ktor_open_redirect
+            call.respondRedirect("/home")
+        }
+    }
+}
@@ -0,0 +1,8 @@
+fun Application.module() {
+    install(CORS) {
+        // ruleid: kotlin_ktor_permissive_cors
+        anyHost()
+        // ok: kotlin_ktor_permissive_cors
+        allowHost("app.example.com", schemes = listOf("https"))
+    }
+}
@@ -0,0 +1,8 @@
+fun notify(user: User) {
+    // ruleid: kotlin_lang_logger_leak
+    Log.d("notifications", "sending to ${user.email}")
+    // ruleid: kotlin_lang_logger_leak
+    logger.info(user.email)
+    // ok: kotlin_lang_logger_leak
+    logger.info("notification sent")
+}
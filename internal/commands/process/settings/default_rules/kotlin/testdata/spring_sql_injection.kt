@@ -0,0 +1,10 @@
+class UserRepository(private val jdbcTemplate: JdbcTemplate) {
+    fun findByEmail(email: String): List<Map<String, Any>> {
+        // ruleid: kotlin_spring_sql_injection
+        jdbcTemplate.queryForList("SELECT * FROM users WHERE email = '$email'")
+        // ruleid: kotlin_spring_sql_injection
+        jdbcTemplate.update("DELETE FROM users WHERE email = '" + email + "'")
+        // ok: kotlin_spring_sql_injection
+        return jdbcTemplate.queryForList("SELECT * FROM users WHERE email = ?", email)
+    }
+}
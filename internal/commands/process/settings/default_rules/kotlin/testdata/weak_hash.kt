@@ -0,0 +1,10 @@
+import java.security.MessageDigest
+
+fun digest(input: ByteArray) {
+    // ruleid: kotlin_lang_weak_hash
+    MessageDigest.getInstance("MD5").digest(input)
+    // ruleid: kotlin_lang_weak_hash
+    MessageDigest.getInstance("SHA-1").digest(input)
+    // ok: kotlin_lang_weak_hash
+    MessageDigest.getInstance("SHA-256").digest(input)
+}
@@ -0,0 +1,15 @@
+async fn login(query: web::Query<LoginParams>) -> HttpResponse {
+    // ruleid: rust_actix_open_redirect
+    HttpResponse::Found().append_header((header::LOCATION, query.return_to.as_str())).finish()
+}
+
+async fn logout(req: HttpRequest) -> HttpResponse {
+    let target = req.query_string().to_string();
+    // ruleid: rust_actix_open_redirect
+    HttpResponse::Found().insert_header(("Location", target)).finish()
+}
+
+async fn home(query: web::Query<HomeParams>) -> HttpResponse {
+    // ok: rust_actix_open_redirect
+    HttpResponse::Found().append_header((header::LOCATION, "/")).finish()
+}
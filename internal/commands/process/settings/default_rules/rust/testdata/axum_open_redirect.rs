@@ -0,0 +1,15 @@
+async fn login(Query(params): Query<LoginParams>) -> Redirect {
+    // ruleid: rust_axum_open_redirect
+    Redirect::to(&params.return_to)
+}
+
+async fn logout(form: Form<LogoutForm>) -> Redirect {
+    let target = form.return_to.clone();
+    // ruleid: rust_axum_open_redirect
+    Redirect::temporary(&target)
+}
+
+async fn home(Query(params): Query<HomeParams>) -> Redirect {
+    // ok: rust_axum_open_redirect
+    Redirect::to("/")
+}
@@ -0,0 +1,8 @@
+fn notify(user: User) {
+    // ruleid: rust_lang_logger_leak
+    info!("sending to {}", user.email);
+    // ruleid: rust_lang_logger_leak
+    log::error!("{}", user.email);
+    // ok: rust_lang_logger_leak
+    tracing::info!("notification sent");
+}
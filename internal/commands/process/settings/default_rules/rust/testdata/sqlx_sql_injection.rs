@@ -0,0 +1,9 @@
+async fn find_by_email(pool: &PgPool, email: &str) -> Result<Vec<User>, sqlx::Error> {
+    // ruleid: rust_sqlx_sql_injection
+    sqlx::query(&format!("DELETE FROM users WHERE email = '{}'", email)).execute(pool).await?;
+    let query = "SELECT * FROM users WHERE email = '".to_string() + email + "'";
+    // ruleid: rust_sqlx_sql_injection
+    sqlx::query_as(&query).fetch_all(pool).await?;
+    // ok: rust_sqlx_sql_injection
+    sqlx::query_as("SELECT * FROM users WHERE email = $1").bind(email).fetch_all(pool).await
+}
@@ -0,0 +1,19 @@
+#!/bin/bash
+
+# ruleid: shell_lang_command_injection
+eval "kubectl rollout restart deployment/$1"
+
+# ruleid: shell_lang_command_injection
+bash -c "$DEPLOY_COMMAND"
+
+# ruleid: shell_lang_command_injection
+sh -c "cd /srv/app && $RESTART_COMMAND"
+
+# ok: shell_lang_command_injection
+eval 'set -euo pipefail'
+
+# ok: shell_lang_command_injection
+bash -c "make release"
+
+# ok: shell_lang_command_injection
+kubectl rollout restart "deployment/$1"
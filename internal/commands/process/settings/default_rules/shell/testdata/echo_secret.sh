@@ -0,0 +1,25 @@
+#!/bin/bash
+
+# ruleid: shell_lang_echo_secret
+echo "Deploying with token $DEPLOY_TOKEN"
+
+# ruleid: shell_lang_echo_secret
+echo "$DB_PASSWORD"
+
+# ruleid: shell_lang_echo_secret
+printf '%s\n' "${GITHUB_TOKEN}"
+
+# ok: shell_lang_echo_secret
+echo "$REGISTRY_PASSWORD" | docker login --username deploy --password-stdin registry.example.com
+
+# ok: shell_lang_echo_secret
+echo "//registry.npmjs.org/:_authToken=${NPM_TOKEN}" > "$HOME/.npmrc"
+
+# ok: shell_lang_echo_secret
+echo 'Set $API_KEY before running this script'
+
+# ok: shell_lang_echo_secret
+echo "Deploying $APP_VERSION"
+
+# ok: shell_lang_echo_secret
+echo "Token file: $TOKEN_FILE"
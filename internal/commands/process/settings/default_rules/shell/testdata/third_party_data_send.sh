@@ -0,0 +1,18 @@
+#!/bin/bash
+
+CUSTOMER_EMAIL="$(psql -tA -c 'SELECT email FROM customers LIMIT 1')"
+
+# ruleid: shell_lang_third_party_data_send
+curl -X POST -d "email=$CUSTOMER_EMAIL" https://hooks.example.com/notify
+
+# ruleid: shell_lang_third_party_data_send
+echo "$CUSTOMER_EMAIL" | mail -s "New signup" ops@example.com
+
+# ruleid: shell_lang_third_party_data_send
+aws sns publish --topic-arn "$TOPIC_ARN" --message "${user_phone_number}"
+
+# ok: shell_lang_third_party_data_send
+curl -X POST -d "customer=$CUSTOMER_ID" https://hooks.example.com/notify
+
+# ok: shell_lang_third_party_data_send
+echo "$CUSTOMER_EMAIL" | grep -q "@example.com"
@@ -0,0 +1,25 @@
+#!/bin/bash
+
+# ruleid: shell_lang_unquoted_variable
+rm -rf $BUILD_DIR/*
+
+# ruleid: shell_lang_unquoted_variable
+cp ${ARTIFACT} /srv/releases
+
+# ruleid: shell_lang_unquoted_variable
+docker push $1
+
+# ruleid: shell_lang_unquoted_variable
+chmod 600 $(find . -name "*.pem")
+
+# ok: shell_lang_unquoted_variable
+rm -rf -- "$BUILD_DIR"/*
+
+# ok: shell_lang_unquoted_variable
+docker push "$1"
+
+# ok: shell_lang_unquoted_variable
+exit $?
+
+# ok: shell_lang_unquoted_variable
+sleep $((RETRY_DELAY * 2))
@@ -0,0 +1,11 @@
+-- ruleid: sql_lang_excessive_grant
+GRANT ALL PRIVILEGES ON orders TO app;
+
+-- ruleid: sql_lang_excessive_grant
+GRANT SELECT ON orders TO reporting WITH GRANT OPTION;
+
+-- ok: sql_lang_excessive_grant
+GRANT SELECT ON orders TO app;
+
+-- ok: sql_lang_excessive_grant
+GRANT INSERT ON orders TO app;
@@ -0,0 +1,8 @@
+-- ruleid: sql_lang_grant_to_public
+GRANT SELECT ON customers TO PUBLIC;
+
+-- ruleid: sql_lang_grant_to_public
+grant all privileges on table orders to public;
+
+-- ok: sql_lang_grant_to_public
+GRANT SELECT ON customers TO reporting;
@@ -0,0 +1,18 @@
+-- ruleid: sql_lang_sensitive_data_export
+CREATE TABLE marketing_export AS SELECT id, email, phone_number FROM users;
+
+-- ruleid: sql_lang_sensitive_data_export
+INSERT INTO crm_contacts (user_id, email)
+SELECT u.id, u.email FROM users u WHERE u.opted_in;
+
+-- ruleid: sql_lang_sensitive_data_export
+SELECT first_name, last_name INTO users_backup FROM users;
+
+-- ok: sql_lang_sensitive_data_export
+CREATE TABLE plan_stats AS SELECT plan, count(*) FROM subscriptions GROUP BY plan;
+
+-- ok: sql_lang_sensitive_data_export
+INSERT INTO order_exports SELECT id, user_id FROM orders;
+
+-- ok: sql_lang_sensitive_data_export
+SELECT email FROM users WHERE id = 1;
@@ -0,0 +1,42 @@
+package settings_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/ruletest"
+	"github.com/bearer/bearer/internal/version_check"
+)
+
+func TestDefaultRulesPassTheirTests(t *testing.T) {
+	err := commands.ScanFlags.BindForConfigInit(commands.NewScanCommand())
+	if err != nil {
+		t.Fatalf("failed to bind flags: %s", err)
+	}
+
+	options, err := commands.ScanFlags.ToOptions([]string{})
+	if err != nil {
+		t.Fatalf("failed to generate default flags: %s", err)
+	}
+
+	config, err := settings.FromOptions(options, &version_check.VersionMeta{
+		Rules: version_check.RuleVersionMeta{
+			Packages: make(map[string]string),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate default scan settings: %s", err)
+	}
+
+	report, err := ruletest.Run(context.Background(), config, "default_rules")
+	if err != nil {
+		t.Fatalf("failed to run rule tests: %s", err)
+	}
+
+	assert.Len(t, report.Rules, 21)
+	assert.False(t, report.Failed(), report.String())
+}
@@ -0,0 +1,67 @@
+package settings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bearer/bearer/internal/util/maputil"
+)
+
+// GetSupportedEmbeddedLanguages returns the languages which rules can match
+// within the string literals of the languages they apply to
+func GetSupportedEmbeddedLanguages() map[string]bool {
+	return map[string]bool{
+		"html":  true,
+		"shell": true,
+		"sql":   true,
+	}
+}
+
+// embeddedLanguageProblems are the reasons a rule definition can't be matched
+// within string literals, or uses filters which only apply to such rules.
+// Fragments of an embedded language are parsed on their own, so filters can
+// only look at the content of variables.
+func embeddedLanguageProblems(definition *RuleDefinition) []string {
+	var problems []string
+
+	if definition.EmbeddedLanguage == "" {
+		forEachFilterOf(definition, func(filter *PatternFilter) {
+			if filter.Interpolated != nil {
+				problems = append(problems, "interpolated filters can only be used in rules with an embedded language")
+			}
+		})
+
+		return problems
+	}
+
+	supportedLanguages := GetSupportedEmbeddedLanguages()
+	if !supportedLanguages[definition.EmbeddedLanguage] {
+		problems = append(problems, fmt.Sprintf(
+			"unsupported embedded language '%s'; supported languages: %s",
+			definition.EmbeddedLanguage,
+			strings.Join(maputil.SortedStringKeys(supportedLanguages), ", "),
+		))
+	}
+
+	if len(definition.Auxiliary) != 0 {
+		problems = append(problems, "auxiliary rules cannot be specified for a rule with an embedded language")
+	}
+
+	if len(definition.Extends) != 0 {
+		problems = append(problems, "extends cannot be specified for a rule with an embedded language")
+	}
+
+	forEachFilterOf(definition, func(filter *PatternFilter) {
+		switch {
+		case filter.Detection != "":
+			problems = append(problems, fmt.Sprintf(
+				"detection filter on '%s' cannot be used in a rule with an embedded language",
+				filter.Detection,
+			))
+		case filter.StringRegex != nil, filter.EntropyGreaterThan != nil, filter.LengthLessThan != nil:
+			problems = append(problems, "string filters cannot be used in a rule with an embedded language; use regex instead")
+		}
+	})
+
+	return problems
+}
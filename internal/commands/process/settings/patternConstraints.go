@@ -0,0 +1,74 @@
+package settings
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// variableConstraintRegex matches variables constrained inline in a pattern,
+// either by a regular expression (`$<NAME=~/md5|sha1/>`) or by a list of
+// values (`$<NAME=[md5,sha1]>`). A node type may be given before the
+// constraint (`$<NAME:identifier=~/.../>`)
+var variableConstraintRegex = regexp.MustCompile(
+	`\$<(?P<name>[^>:!\.=]+)(?P<types>:[^>=]+)?(?:=~/(?P<regex>(?:\\.|[^\\/])*)/|=\[(?P<values>[^\]]*)\])>`,
+)
+
+// expandVariableConstraints rewrites the inline variable constraints of a
+// pattern into plain variables and the equivalent filters
+func expandVariableConstraints(rulePattern *RulePattern) error {
+	nameIndex := variableConstraintRegex.SubexpIndex("name")
+	typesIndex := variableConstraintRegex.SubexpIndex("types")
+	regexIndex := variableConstraintRegex.SubexpIndex("regex")
+	valuesIndex := variableConstraintRegex.SubexpIndex("values")
+
+	var filters []PatternFilter
+	var err error
+
+	pattern := variableConstraintRegex.ReplaceAllStringFunc(rulePattern.Pattern, func(match string) string {
+		indices := variableConstraintRegex.FindStringSubmatchIndex(match)
+		submatch := func(index int) string {
+			if indices[2*index] == -1 {
+				return ""
+			}
+
+			return match[indices[2*index]:indices[2*index+1]]
+		}
+
+		name := submatch(nameIndex)
+		types := submatch(typesIndex)
+		filter := PatternFilter{Variable: name}
+
+		if indices[2*regexIndex] != -1 {
+			compiled, compileErr := regexp.Compile(submatch(regexIndex))
+			if compileErr != nil && err == nil {
+				err = fmt.Errorf("invalid regex constraint on variable %s: %w", name, compileErr)
+			}
+
+			filter.Regex = &Regexp{compiled}
+		} else {
+			for _, value := range strings.Split(submatch(valuesIndex), ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					filter.Values = append(filter.Values, value)
+				}
+			}
+
+			if len(filter.Values) == 0 && err == nil {
+				err = fmt.Errorf("empty value constraint on variable %s", name)
+			}
+		}
+
+		filters = append(filters, filter)
+
+		return "$<" + name + types + ">"
+	})
+
+	if err != nil {
+		return err
+	}
+
+	rulePattern.Pattern = pattern
+	rulePattern.Filters = append(rulePattern.Filters, filters...)
+
+	return nil
+}
@@ -64,6 +64,7 @@ build_local_item(location, data_type) := {
 		},
 	},
 	"line_number": location.source.start_line_number,
+	"confidence": object.get(location.source, "confidence", ""),
 } if {
 	not input.rule.has_detailed_context == true
 }
@@ -90,6 +91,7 @@ build_item(location) := {
 		},
 	},
 	"line_number": location.start_line_number,
+	"confidence": object.get(location.source, "confidence", ""),
 } if {
 	not input.rule.has_detailed_context == true
 }
@@ -173,6 +173,61 @@ policy_failure contains item if {
 	}
 }
 
+# - stored data types that are never used outside of the stored detectors
+used_elsewhere(data_type, stored_location) if {
+	some detector in data_type.detectors
+	not contains(input.rule.detectors, detector.name)
+
+	some location in detector.locations
+	not location.stored == true
+	lower(location.field_name) == lower(stored_location.field_name)
+}
+
+policy_failure contains item if {
+	input.rule.trigger.match_on == "unused_data_types"
+
+	data_type = input.dataflow.data_types[_]
+	not contains(input.rule.skip_data_types, data_type.name)
+
+	some detector in data_type.detectors
+	contains(input.rule.detectors, detector.name)
+
+	location = detector.locations[_]
+	location.stored == true
+	location.field_name != ""
+
+	not used_elsewhere(data_type, location)
+
+	item := {
+		"is_local": true,
+		"category_groups": data.bearer.common.groups_for_datatype(data_type),
+		"data_type": {
+			"category_uuid": data_type.category_uuid,
+			"name": data_type.name,
+		},
+		"filename": location.filename,
+		"full_filename": location.full_filename,
+		"sink": {
+			"start": location.source.start_line_number,
+			"end": location.source.end_line_number,
+			"content": location.source.content,
+			"column": {
+				"start": location.source.start_column_number,
+				"end": location.source.end_column_number,
+			},
+		},
+		"source": {
+			"start": location.start_line_number,
+			"end": location.start_line_number,
+			"column": {
+				"start": location.start_column_number,
+				"end": location.end_column_number,
+			},
+		},
+		"line_number": location.start_line_number,
+	}
+}
+
 # used by inventory report
 local_rule_failure contains item if {
 	some detector in presence_failures
@@ -0,0 +1,295 @@
+package settings
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/internal/git"
+	"github.com/bearer/bearer/internal/util/rulepack"
+)
+
+const (
+	gitRuleSourcePrefix = "git::"
+	checksumPrefix      = "sha256:"
+)
+
+// errRulesNotCached is returned in offline mode for external rules which
+// haven't been fetched yet
+var errRulesNotCached = errors.New("run a scan with network access first")
+
+// gitRuleSource is an external rule directory in a git repository, given as
+// git::<url>?ref=<ref>&checksum=sha256:<checksum>
+type gitRuleSource struct {
+	source   string
+	url      string
+	ref      string
+	checksum string
+}
+
+func isGitRuleSource(dir string) bool {
+	return strings.HasPrefix(dir, gitRuleSourcePrefix)
+}
+
+func parseGitRuleSource(source string) (gitRuleSource, error) {
+	repositoryURL, rawQuery, _ := strings.Cut(strings.TrimPrefix(source, gitRuleSourcePrefix), "?")
+	if repositoryURL == "" {
+		return gitRuleSource{}, fmt.Errorf("invalid external rules %s: missing repository url", source)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return gitRuleSource{}, fmt.Errorf("invalid external rules %s: %w", source, err)
+	}
+
+	result := gitRuleSource{source: source, url: repositoryURL}
+	for key, values := range query {
+		switch key {
+		case "ref":
+			result.ref = values[0]
+		case "checksum":
+			if !strings.HasPrefix(values[0], checksumPrefix) {
+				return gitRuleSource{}, fmt.Errorf(
+					"invalid external rules %s: checksum must be given as %s<checksum>",
+					source,
+					checksumPrefix,
+				)
+			}
+			result.checksum = strings.ToLower(strings.TrimPrefix(values[0], checksumPrefix))
+		default:
+			return gitRuleSource{}, fmt.Errorf("invalid external rules %s: unknown parameter %s", source, key)
+		}
+	}
+
+	return result, nil
+}
+
+// cacheDir is where the rules of the source are cloned to. Each url and ref
+// is cloned once, so refs are expected to be tags or commits.
+func (source gitRuleSource) cacheDir() string {
+	key := sha256.Sum256([]byte(source.url + "@" + source.ref))
+	return filepath.Join(bearerRulesDir(), "git", fmt.Sprintf("%x", key))
+}
+
+// resolveExternalRuleDirs replaces the external rules in git repositories and
+// OCI registries with the local directories they are cached in. Rules from git
+// are verified against any pinned checksum, and rules from registries against
+// the public key, if given.
+func resolveExternalRuleDirs(externalRuleDirs []string, publicKeyPath string, offline bool) ([]string, error) {
+	result := make([]string, len(externalRuleDirs))
+
+	for i, dir := range externalRuleDirs {
+		var err error
+		switch {
+		case isGitRuleSource(dir):
+			result[i], err = resolveGitRuleSource(dir, offline)
+		case strings.HasPrefix(dir, rulepack.OCIPrefix):
+			result[i], err = resolveOCIRuleSource(dir, publicKeyPath, offline)
+		default:
+			result[i] = dir
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// resolveCachedExternalRuleDirs resolves the external rules available without
+// network access, leaving out the rules in git repositories and OCI registries
+// which aren't cached yet
+func resolveCachedExternalRuleDirs(externalRuleDirs []string) ([]string, error) {
+	var result []string
+	for _, dir := range externalRuleDirs {
+		resolved, err := resolveExternalRuleDirs([]string{dir}, "", true)
+		if errors.Is(err, errRulesNotCached) {
+			log.Debug().Msgf("skipping external rules %s as they are not cached", dir)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, resolved...)
+	}
+
+	return result, nil
+}
+
+func resolveGitRuleSource(dir string, offline bool) (string, error) {
+	source, err := parseGitRuleSource(dir)
+	if err != nil {
+		return "", err
+	}
+
+	cacheDir, err := cloneGitRuleSource(source, offline)
+	if err != nil {
+		return "", err
+	}
+
+	checksum, err := dirChecksum(cacheDir)
+	if err != nil {
+		return "", fmt.Errorf("error computing checksum of external rules %s: %w", source.source, err)
+	}
+
+	if source.checksum == "" {
+		log.Debug().Msgf("external rules %s have checksum %s%s", source.source, checksumPrefix, checksum)
+	} else if source.checksum != checksum {
+		return "", fmt.Errorf(
+			"checksum mismatch for external rules %s: expected %s%s, got %s%s",
+			source.source,
+			checksumPrefix,
+			source.checksum,
+			checksumPrefix,
+			checksum,
+		)
+	}
+
+	return cacheDir, nil
+}
+
+// resolveOCIRuleSource pulls a rule pack from an OCI registry once for each
+// reference. The cached pack is pulled again if it wasn't verified with the
+// public key.
+func resolveOCIRuleSource(source string, publicKeyPath string, offline bool) (string, error) {
+	reference, err := rulepack.ParseOCIReference(source)
+	if err != nil {
+		return "", err
+	}
+
+	var publicKey ed25519.PublicKey
+	if publicKeyPath != "" {
+		if publicKey, err = rulepack.LoadPublicKey(publicKeyPath); err != nil {
+			return "", err
+		}
+	}
+
+	key := sha256.Sum256([]byte(reference.String()))
+	cacheDir := filepath.Join(bearerRulesDir(), "oci", fmt.Sprintf("%x", key))
+
+	if provenance, err := rulepack.ReadProvenance(cacheDir); err == nil && (publicKey == nil || provenance.VerifiedWith(publicKey)) {
+		log.Debug().Msgf("using local cache for external rules %s", source)
+		return cacheDir, nil
+	}
+
+	if offline {
+		return "", fmt.Errorf(
+			"external rules %s are not cached; they cannot be pulled in offline mode. %w",
+			source,
+			errRulesNotCached,
+		)
+	}
+
+	log.Debug().Msgf("pulling external rules %s", source)
+	if _, err := rulepack.Pull(reference, cacheDir, publicKey, offline); err != nil {
+		return "", fmt.Errorf("error pulling external rules %s: %w", source, err)
+	}
+
+	return cacheDir, nil
+}
+
+// cloneGitRuleSource returns the directory the rules of a git repository are
+// cached in, cloning them when needed. A source without a ref tracks the
+// default branch, so it is cloned again on every scan, and its cache is only
+// used in offline mode.
+func cloneGitRuleSource(source gitRuleSource, offline bool) (string, error) {
+	cacheDir := source.cacheDir()
+	if _, err := os.Stat(cacheDir); err == nil && (source.ref != "" || offline) {
+		log.Debug().Msgf("using local cache for external rules %s", source.source)
+		return cacheDir, nil
+	}
+
+	if offline {
+		return "", fmt.Errorf(
+			"external rules %s are not cached; they cannot be cloned in offline mode. %w",
+			source.source,
+			errRulesNotCached,
+		)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cacheDir), os.ModePerm); err != nil {
+		return "", fmt.Errorf("could not create external rules cache directory: %w", err)
+	}
+
+	// clone next to the cache so that a failed clone is never used
+	cloneDir, err := os.MkdirTemp(filepath.Dir(cacheDir), "clone-")
+	if err != nil {
+		return "", fmt.Errorf("could not create external rules cache directory: %w", err)
+	}
+	defer os.RemoveAll(cloneDir)
+
+	log.Debug().Msgf("cloning external rules %s", source.source)
+	if err := git.CloneRef(context.TODO(), cloneDir, source.url, source.ref); err != nil {
+		return "", fmt.Errorf("error cloning external rules %s: %w", source.source, err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(cloneDir, ".git")); err != nil {
+		return "", err
+	}
+
+	if err := os.RemoveAll(cacheDir); err != nil {
+		return "", fmt.Errorf("could not remove previous cache of external rules %s: %w", source.source, err)
+	}
+
+	if err := os.Rename(cloneDir, cacheDir); err != nil {
+		return "", fmt.Errorf("could not cache external rules %s: %w", source.source, err)
+	}
+
+	return cacheDir, nil
+}
+
+// dirChecksum is the sha256 checksum of the paths and contents of all files
+// within the directory
+func dirChecksum(dir string) (string, error) {
+	return dirChecksumExcluding(dir, "")
+}
+
+// dirChecksumExcluding is the checksum of the directory leaving out the files
+// with the given name, if any
+func dirChecksumExcluding(dir string, excludedName string) (string, error) {
+	var paths []string
+	if err := filepath.WalkDir(dir, func(path string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !dirEntry.IsDir() && (excludedName == "" || dirEntry.Name() != excludedName) {
+			paths = append(paths, path)
+		}
+
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	sort.Strings(paths)
+
+	hash := sha256.New()
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(hash, "%s\x00%x\n", filepath.ToSlash(relativePath), sha256.Sum256(content))
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
@@ -0,0 +1,71 @@
+package settings
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/util/workdir"
+)
+
+func TestCloneGitRuleSourceWithoutRefIsRefreshed(t *testing.T) {
+	defer workdir.Setup("")
+	if err := workdir.Setup(t.TempDir()); err != nil {
+		t.Fatalf("failed to set up workdir: %s", err)
+	}
+
+	repositoryDir := t.TempDir()
+	git := func(args ...string) {
+		command := exec.Command("git", append([]string{"-C", repositoryDir}, args...)...)
+		if output, err := command.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s %s", args, err, output)
+		}
+	}
+	commitRule := func(content string) {
+		if err := os.WriteFile(filepath.Join(repositoryDir, "rule.yml"), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write rule: %s", err)
+		}
+
+		git("add", "rule.yml")
+		git("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", content)
+	}
+
+	git("init", "--quiet")
+	source := gitRuleSource{source: "git::" + repositoryDir, url: repositoryDir}
+
+	commitRule("first")
+	assertClonedRule(t, source, false, "first")
+
+	commitRule("second")
+	assertClonedRule(t, source, false, "second")
+
+	commitRule("third")
+	assertClonedRule(t, source, true, "second")
+}
+
+func TestLoadRuleDocumentationSkipsUncachedGitRuleSource(t *testing.T) {
+	defer workdir.Setup("")
+	if err := workdir.Setup(t.TempDir()); err != nil {
+		t.Fatalf("failed to set up workdir: %s", err)
+	}
+
+	_, err := LoadRuleDocumentation([]string{"git::" + t.TempDir() + "?ref=v1"})
+	assert.NoError(t, err)
+}
+
+func assertClonedRule(t *testing.T, source gitRuleSource, offline bool, expected string) {
+	dir, err := cloneGitRuleSource(source, offline)
+	if err != nil {
+		t.Fatalf("failed to clone rules: %s", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "rule.yml"))
+	if err != nil {
+		t.Fatalf("failed to read cloned rule: %s", err)
+	}
+
+	assert.Equal(t, expected, string(content))
+}
@@ -4,22 +4,43 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"crypto/md5"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/maps"
 	"gopkg.in/yaml.v3"
 )
 
-const BASE_RULE_FOLDER = "/"
+const (
+	BASE_RULE_FOLDER = "/"
 
-func LoadRuleDefinitionsFromUrls(ruleDefinitions map[string]RuleDefinition, languageDownloads []string) (err error) {
+	rulePackagesIndexFilename = "packages.json"
+)
+
+// rulePackagesIndex records the version of the cached rule packages, and the
+// archive of each language
+type rulePackagesIndex struct {
+	Version  string            `json:"version"`
+	Packages map[string]string `json:"packages"`
+}
+
+// LoadRuleDefinitionsFromUrls loads rule definitions from the package of each
+// language, downloading the packages which aren't cached yet. Cached packages
+// of other versions are removed.
+func LoadRuleDefinitionsFromUrls(
+	ruleDefinitions map[string]RuleDefinition,
+	version string,
+	languageDownloads map[string]string,
+) (err error) {
 
 	bearerRulesDir := bearerRulesDir()
 	if _, err := os.Stat(bearerRulesDir); errors.Is(err, os.ErrNotExist) {
@@ -29,10 +50,21 @@ func LoadRuleDefinitionsFromUrls(ruleDefinitions map[string]RuleDefinition, lang
 		}
 	}
 
-	for _, languagePackageUrl := range languageDownloads {
+	index := readRulePackagesIndex()
+	if index.Version != version {
+		index = rulePackagesIndex{Version: version, Packages: make(map[string]string)}
+	}
+
+	languages := maps.Keys(languageDownloads)
+	sort.Strings(languages)
+
+	for _, language := range languages {
+		languagePackageUrl := languageDownloads[language]
 		// Prepare filepath
 		urlHash := md5.Sum([]byte(languagePackageUrl))
-		filepath, err := filepath.Abs(filepath.Join(bearerRulesDir, fmt.Sprintf("%x.tar.gz", urlHash)))
+		packageFilename := fmt.Sprintf("%x.tar.gz", urlHash)
+		index.Packages[language] = packageFilename
+		filepath, err := filepath.Abs(filepath.Join(bearerRulesDir, packageFilename))
 
 		if err != nil {
 			return err
@@ -81,6 +113,86 @@ func LoadRuleDefinitionsFromUrls(ruleDefinitions map[string]RuleDefinition, lang
 		}
 	}
 
+	return writeRulePackagesIndex(index)
+}
+
+// LoadRuleDefinitionsFromCache loads rule definitions from the rule packages
+// of the version last downloaded to the rules directory
+func LoadRuleDefinitionsFromCache(ruleDefinitions map[string]RuleDefinition) (bool, error) {
+	index := readRulePackagesIndex()
+
+	languages := maps.Keys(index.Packages)
+	sort.Strings(languages)
+
+	for _, language := range languages {
+		packagePath := filepath.Join(bearerRulesDir(), index.Packages[language])
+		log.Trace().Msgf("Using local cache for %s rule package %s: %s", language, index.Version, packagePath)
+		file, err := os.Open(packagePath)
+		if err != nil {
+			return false, err
+		}
+
+		err = ReadRuleDefinitions(ruleDefinitions, file)
+		file.Close()
+		if err != nil {
+			return false, fmt.Errorf("failed to read rule package %s: %w", packagePath, err)
+		}
+	}
+
+	return len(languages) != 0, nil
+}
+
+// readRulePackagesIndex returns the index of the cached rule packages. Any
+// error is treated as an empty cache.
+func readRulePackagesIndex() rulePackagesIndex {
+	index := rulePackagesIndex{Packages: make(map[string]string)}
+
+	content, err := os.ReadFile(filepath.Join(bearerRulesDir(), rulePackagesIndexFilename))
+	if err != nil {
+		return index
+	}
+
+	if err := json.Unmarshal(content, &index); err != nil || index.Packages == nil {
+		log.Debug().Msgf("ignoring invalid rule packages index: %s", err)
+		return rulePackagesIndex{Packages: make(map[string]string)}
+	}
+
+	return index
+}
+
+// writeRulePackagesIndex writes the index of the cached rule packages, and
+// removes the packages it doesn't list, such as those of previous versions
+func writeRulePackagesIndex(index rulePackagesIndex) error {
+	content, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(bearerRulesDir(), rulePackagesIndexFilename), content, 0o644); err != nil {
+		return fmt.Errorf("could not write rule packages index: %w", err)
+	}
+
+	packagePaths, err := filepath.Glob(filepath.Join(bearerRulesDir(), "*.tar.gz"))
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]bool)
+	for _, packageFilename := range index.Packages {
+		current[packageFilename] = true
+	}
+
+	for _, packagePath := range packagePaths {
+		if current[filepath.Base(packagePath)] {
+			continue
+		}
+
+		log.Debug().Msgf("removing superseded rule package: %s", packagePath)
+		if err := os.Remove(packagePath); err != nil {
+			return fmt.Errorf("could not remove superseded rule package %s: %w", packagePath, err)
+		}
+	}
+
 	return nil
 }
 
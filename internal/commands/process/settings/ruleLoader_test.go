@@ -0,0 +1,90 @@
+package settings
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/maps"
+
+	"github.com/bearer/bearer/internal/util/workdir"
+)
+
+func TestLoadRuleDefinitionsFromCacheOnlyLoadsLatestVersion(t *testing.T) {
+	defer workdir.Setup("")
+	if err := workdir.Setup(t.TempDir()); err != nil {
+		t.Fatalf("failed to set up workdir: %s", err)
+	}
+
+	// serves /<version>/<language>.tar.gz with a single <language>_<version> rule
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version, filename, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		language := strings.TrimSuffix(filename, ".tar.gz")
+		_, _ = w.Write(rulePackage(t, language, fmt.Sprintf("%s_%s", language, version)))
+	}))
+	defer server.Close()
+
+	download := func(version string, languages ...string) {
+		urls := make(map[string]string)
+		for _, language := range languages {
+			urls[language] = fmt.Sprintf("%s/%s/%s.tar.gz", server.URL, version, language)
+		}
+
+		if err := LoadRuleDefinitionsFromUrls(make(map[string]RuleDefinition), version, urls); err != nil {
+			t.Fatalf("failed to download rules: %s", err)
+		}
+	}
+
+	download("v1", "ruby", "javascript")
+	download("v2", "ruby")
+	download("v2", "python")
+
+	definitions := make(map[string]RuleDefinition)
+	loaded, err := LoadRuleDefinitionsFromCache(definitions)
+	if err != nil {
+		t.Fatalf("failed to load cached rules: %s", err)
+	}
+
+	assert.True(t, loaded)
+	assert.ElementsMatch(t, []string{"python_v2", "ruby_v2"}, maps.Keys(definitions))
+
+	packagePaths, err := filepath.Glob(filepath.Join(bearerRulesDir(), "*.tar.gz"))
+	if err != nil {
+		t.Fatalf("failed to list cached packages: %s", err)
+	}
+	assert.Len(t, packagePaths, 2)
+}
+
+func rulePackage(t *testing.T, language string, id string) []byte {
+	var content bytes.Buffer
+	gzipWriter := gzip.NewWriter(&content)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	rule := []byte(fmt.Sprintf("languages:\n  - %s\nmetadata:\n  id: %s\n", language, id))
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name: fmt.Sprintf("rules/%s/%s.yml", language, id),
+		Mode: 0o644,
+		Size: int64(len(rule)),
+	}); err != nil {
+		t.Fatalf("failed to write rule package: %s", err)
+	}
+	if _, err := tarWriter.Write(rule); err != nil {
+		t.Fatalf("failed to write rule package: %s", err)
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("failed to write rule package: %s", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("failed to write rule package: %s", err)
+	}
+
+	return content.Bytes()
+}
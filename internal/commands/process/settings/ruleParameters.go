@@ -0,0 +1,272 @@
+package settings
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+
+	"github.com/bearer/bearer/internal/util/maputil"
+)
+
+// parameterComparisonKeys are the filter comparison keys which can take their
+// value from a rule parameter
+var parameterComparisonKeys = map[string]struct{}{
+	"values":                {},
+	"regex":                 {},
+	"string_regex":          {},
+	"filename_regex":        {},
+	"length_less_than":      {},
+	"less_than":             {},
+	"less_than_or_equal":    {},
+	"greater_than":          {},
+	"greater_than_or_equal": {},
+}
+
+// ruleParameterProblems are the reasons the parameters of a rule definition,
+// and the filters referring to them, are invalid
+func ruleParameterProblems(definition *RuleDefinition) []string {
+	var problems []string
+
+	forEachFilterOf(definition, func(filter *PatternFilter) {
+		for _, key := range maputil.SortedStringKeys(filter.Parameters) {
+			name := filter.Parameters[key]
+
+			if _, supported := parameterComparisonKeys[key]; !supported {
+				problems = append(problems, fmt.Sprintf("filter parameter for unsupported key '%s'", key))
+			}
+
+			parameter, declared := definition.Parameters[name]
+			if !declared {
+				problems = append(problems, fmt.Sprintf("filter references undeclared parameter '%s'", name))
+				continue
+			}
+
+			if parameter.Default != nil {
+				if err := applyFilterParameter(&PatternFilter{}, key, parameter.Default); err != nil {
+					problems = append(problems, fmt.Sprintf("invalid default for parameter '%s': %s", name, err))
+				}
+			}
+		}
+	})
+
+	return problems
+}
+
+// validateRuleParameters checks that the parameters given in the
+// configuration are declared by their rules
+func validateRuleParameters(
+	parameters map[string]map[string]interface{},
+	definitionSets ...map[string]RuleDefinition,
+) error {
+	for _, id := range maputil.SortedStringKeys(parameters) {
+		found := false
+
+		for _, definitions := range definitionSets {
+			definition, ok := definitions[id]
+			if !ok {
+				continue
+			}
+
+			found = true
+			for _, name := range maputil.SortedStringKeys(parameters[id]) {
+				if _, declared := definition.Parameters[name]; !declared {
+					return fmt.Errorf("unknown parameter %s for rule %s", name, id)
+				}
+			}
+		}
+
+		if !found {
+			log.Debug().Msgf("ignoring parameters for rule %s as it is not loaded", id)
+		}
+	}
+
+	return nil
+}
+
+// applyRuleParameters sets the comparison values of the filters of enabled
+// rules which refer to rule parameters, using the values given in the
+// configuration or else the defaults of the parameters
+func applyRuleParameters(
+	parameters map[string]map[string]interface{},
+	definitions map[string]RuleDefinition,
+	enabledRules map[string]struct{},
+) error {
+	for _, id := range maputil.SortedStringKeys(definitions) {
+		definition := definitions[id]
+		if _, enabled := enabledRules[id]; !enabled || len(definition.Parameters) == 0 {
+			continue
+		}
+
+		resolved, err := resolveDefinitionParameters(definition, parameters[id])
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", id, err)
+		}
+
+		definitions[id] = resolved
+	}
+
+	return nil
+}
+
+func resolveDefinitionParameters(definition RuleDefinition, values map[string]interface{}) (RuleDefinition, error) {
+	var err error
+
+	resolvePatterns := func(patterns []RulePattern) []RulePattern {
+		result := make([]RulePattern, len(patterns))
+		for i, pattern := range patterns {
+			result[i] = pattern
+			result[i].Filters = resolveFilters(pattern.Filters, func(filter *PatternFilter) {
+				for _, key := range maputil.SortedStringKeys(filter.Parameters) {
+					name := filter.Parameters[key]
+
+					value, given := values[name]
+					if !given {
+						value = definition.Parameters[name].Default
+					}
+
+					if value == nil {
+						if err == nil {
+							err = fmt.Errorf("parameter %s must be set", name)
+						}
+						continue
+					}
+
+					if applyErr := applyFilterParameter(filter, key, value); applyErr != nil && err == nil {
+						err = fmt.Errorf("invalid value for parameter %s: %w", name, applyErr)
+					}
+				}
+			})
+		}
+
+		return result
+	}
+
+	definition.Patterns = resolvePatterns(definition.Patterns)
+
+	auxiliary := make([]Auxiliary, len(definition.Auxiliary))
+	for i, auxiliaryDefinition := range definition.Auxiliary {
+		auxiliary[i] = auxiliaryDefinition
+		auxiliary[i].Patterns = resolvePatterns(auxiliaryDefinition.Patterns)
+	}
+	definition.Auxiliary = auxiliary
+
+	return definition, err
+}
+
+// resolveFilters copies the filters, calling resolve on each (nested) copy
+// which refers to parameters
+func resolveFilters(filters []PatternFilter, resolve func(filter *PatternFilter)) []PatternFilter {
+	if filters == nil {
+		return nil
+	}
+
+	result := make([]PatternFilter, len(filters))
+	for i, filter := range filters {
+		result[i] = resolveFilter(filter, resolve)
+	}
+
+	return result
+}
+
+func resolveFilter(filter PatternFilter, resolve func(filter *PatternFilter)) PatternFilter {
+	if filter.Not != nil {
+		not := resolveFilter(*filter.Not, resolve)
+		filter.Not = &not
+	}
+
+	filter.Either = resolveFilters(filter.Either, resolve)
+	filter.Filters = resolveFilters(filter.Filters, resolve)
+
+	if len(filter.Parameters) != 0 {
+		resolve(&filter)
+	}
+
+	return filter
+}
+
+func applyFilterParameter(filter *PatternFilter, key string, value interface{}) error {
+	switch key {
+	case "values":
+		values, err := cast.ToStringSliceE(value)
+		if err != nil {
+			return err
+		}
+
+		filter.Values = values
+	case "regex", "string_regex", "filename_regex":
+		pattern, err := cast.ToStringE(value)
+		if err != nil {
+			return err
+		}
+
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "regex":
+			filter.Regex = &Regexp{compiled}
+		case "string_regex":
+			filter.StringRegex = &Regexp{compiled}
+		default:
+			filter.FilenameRegex = &Regexp{compiled}
+		}
+	default:
+		number, err := cast.ToIntE(value)
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "length_less_than":
+			filter.LengthLessThan = &number
+		case "less_than":
+			filter.LessThan = &number
+		case "less_than_or_equal":
+			filter.LessThanOrEqual = &number
+		case "greater_than":
+			filter.GreaterThan = &number
+		default:
+			filter.GreaterThanOrEqual = &number
+		}
+	}
+
+	return nil
+}
+
+// forEachFilterOf calls fn with each (nested) filter of the patterns of the
+// definition and its auxiliary rules
+func forEachFilterOf(definition *RuleDefinition, fn func(filter *PatternFilter)) {
+	var visit func(filter *PatternFilter)
+	visit = func(filter *PatternFilter) {
+		fn(filter)
+
+		if filter.Not != nil {
+			visit(filter.Not)
+		}
+
+		for i := range filter.Either {
+			visit(&filter.Either[i])
+		}
+
+		for i := range filter.Filters {
+			visit(&filter.Filters[i])
+		}
+	}
+
+	visitPatterns := func(patterns []RulePattern) {
+		for _, pattern := range patterns {
+			for i := range pattern.Filters {
+				visit(&pattern.Filters[i])
+			}
+		}
+	}
+
+	visitPatterns(definition.Patterns)
+	for _, auxiliaryDefinition := range definition.Auxiliary {
+		visitPatterns(auxiliaryDefinition.Patterns)
+	}
+}
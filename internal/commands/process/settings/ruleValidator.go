@@ -6,14 +6,22 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/spf13/viper"
 	"github.com/xeipuuv/gojsonschema"
 	"sigs.k8s.io/yaml"
+
+	"github.com/bearer/bearer/internal/flag"
 )
 
 const SCHEMA_URL = "https://raw.githubusercontent.com/Bearer/bearer-rules/main/scripts/rule_schema.json"
 
 func ValidateRule(entry []byte, filename string) string {
 	validationStr := &strings.Builder{}
+	if viper.GetBool(flag.OfflineFlag.ConfigName) {
+		validationStr.WriteString(fmt.Sprintf("Failed to load %s\nSchema validation is not available in offline mode\n", filename))
+		return validationStr.String()
+	}
+
 	validationStr.WriteString(fmt.Sprintf("Failed to load %s\nValidating against %s\n\n", filename, SCHEMA_URL))
 	schema, err := loadSchema(SCHEMA_URL)
 	if err != nil {
@@ -45,6 +53,31 @@ func ValidateRule(entry []byte, filename string) string {
 	return validationStr.String()
 }
 
+// LoadRuleSchema loads the JSON schema of rule files
+func LoadRuleSchema() (*gojsonschema.Schema, error) {
+	return loadSchema(SCHEMA_URL)
+}
+
+// ValidateRuleSchema returns the issues of the rule file against the schema
+func ValidateRuleSchema(schema *gojsonschema.Schema, entry []byte) ([]string, error) {
+	jsonData, err := yaml.YAMLToJSON(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := validateData(jsonData, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []string
+	for _, desc := range result.Errors() {
+		issues = append(issues, desc.String())
+	}
+
+	return issues, nil
+}
+
 func loadSchema(url string) (*gojsonschema.Schema, error) {
 	response, err := http.Get(url)
 	if err != nil {
@@ -5,6 +5,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/rs/zerolog/log"
@@ -12,14 +14,30 @@ import (
 
 	"github.com/bearer/bearer/internal/flag"
 	"github.com/bearer/bearer/internal/report/customdetectors"
+	"github.com/bearer/bearer/internal/report/facts"
+	globaltypes "github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/maputil"
 	"github.com/bearer/bearer/internal/util/output"
 	"github.com/bearer/bearer/internal/util/set"
+	"github.com/bearer/bearer/internal/util/workdir"
 	"github.com/bearer/bearer/internal/version_check"
 )
 
 const (
 	defaultRuleType          = customdetectors.TypeRisk
 	defaultAuxiliaryRuleType = customdetectors.TypeVerifier
+	RecipesDirName           = "recipes"
+	// configSanitizerRuleIDPrefix prefixes the ids of the rules built from the
+	// sanitizers in the configuration
+	configSanitizerRuleIDPrefix = "config_sanitizer_"
+	// TestdataDirName is the directory holding the fixtures of custom rules
+	TestdataDirName = "testdata"
+	// RuleNamespaceSeparator separates the namespace of a custom rule from the
+	// rest of its id, eg. `acme:ruby_internal_logger`
+	RuleNamespaceSeparator = ":"
+	// reservedRuleNamespace can't be used by custom rules, so that they can't
+	// be mistaken for default rules
+	reservedRuleNamespace = "bearer"
 )
 
 var (
@@ -28,17 +46,54 @@ var (
 		"insecure_url",
 		"string_literal",
 	}
+
+	ruleNamespacePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
 )
 
+// SplitRuleID returns the namespace of a rule id, if any, and the rest of the
+// id. Default rules have no namespace.
+func SplitRuleID(id string) (namespace string, name string) {
+	namespace, name, found := strings.Cut(id, RuleNamespaceSeparator)
+	if !found {
+		return "", id
+	}
+
+	return namespace, name
+}
+
+// RuleIDProblem describes what is wrong with the namespace of a rule id, if
+// anything
+func RuleIDProblem(id string) string {
+	if !strings.Contains(id, RuleNamespaceSeparator) {
+		return ""
+	}
+
+	namespace, name := SplitRuleID(id)
+	switch {
+	case !ruleNamespacePattern.MatchString(namespace):
+		return fmt.Sprintf("invalid namespace '%s' in metadata.id; namespaces are lowercase letters, digits, '-' and '_'", namespace)
+	case namespace == reservedRuleNamespace:
+		return fmt.Sprintf("the '%s' namespace is reserved for default rules", reservedRuleNamespace)
+	case name == "" || strings.Contains(name, RuleNamespaceSeparator):
+		return fmt.Sprintf("metadata.id must be of the form <namespace>%s<name>", RuleNamespaceSeparator)
+	}
+
+	return ""
+}
+
 func GetSupportedRuleLanguages() map[string]bool {
 	return map[string]bool{
 		"python":     true,
 		"php":        true,
 		"go":         true,
 		"java":       true,
-		"sql":        true, // partly supported but not exposed
+		"sql":        true,
 		"ruby":       true,
 		"javascript": true,
+		"kotlin":     true,
+		"rust":       true,
+		"shell":      true,
+		"c":          true,
 		"typescript": true,
 	}
 }
@@ -48,6 +103,7 @@ func loadRules(
 	options flag.RuleOptions,
 	versionMeta *version_check.VersionMeta,
 	force bool,
+	offline bool,
 ) (
 	result LoadRulesResult,
 	err error,
@@ -61,34 +117,500 @@ func loadRules(
 
 	log.Debug().Msg("Loading rules")
 
-	loadRuleDefinitionsFromRemote(definitions, options, versionMeta)
+	if offline {
+		if err := loadRuleDefinitionsFromCache(definitions, options); err != nil {
+			return result, err
+		}
+	} else {
+		loadRuleDefinitionsFromRemote(definitions, options, versionMeta)
+	}
+
+	if err := loadShippedRuleDefinitions(definitions, options); err != nil {
+		return result, fmt.Errorf("error loading default rules: %w", err)
+	}
 
 	if err := loadRuleDefinitionsFromDir(builtInDefinitions, buildInRulesFs); err != nil {
 		return result, fmt.Errorf("error loading built-in rules: %w", err)
 	}
 
+	if err := loadExternalRuleDefinitions(definitions, externalRuleDirs, builtInDefinitions); err != nil {
+		return result, err
+	}
+
+	if err := validateRuleOptionIDs(options, definitions, builtInDefinitions); err != nil {
+		return result, err
+	}
+
+	deprecatedRules := getDeprecatedRules(definitions, builtInDefinitions)
+	options = replaceDeprecatedRuleOptions(options, deprecatedRules)
+	result.PathOverrides = options.PathOverrides
+
+	if err := validateRuleParameters(options.Parameters, definitions, builtInDefinitions); err != nil {
+		return result, err
+	}
+
+	enabledRules := getEnabledRules(options, definitions, nil, deprecatedRules)
+	builtInRules := getEnabledRules(options, builtInDefinitions, enabledRules, deprecatedRules)
+	result.DisabledRules = getDisabledRules(options, definitions, enabledRules, deprecatedRules)
+
+	if err := applyRuleParameters(options.Parameters, definitions, enabledRules); err != nil {
+		return result, err
+	}
+	if err := applyRuleParameters(options.Parameters, builtInDefinitions, builtInRules); err != nil {
+		return result, err
+	}
+
+	result.Rules = BuildRules(definitions, enabledRules)
+	result.BuiltInRules = BuildRules(builtInDefinitions, builtInRules)
+	addReplacedRuleIDs(deprecatedRules, result.Rules, result.BuiltInRules)
+
+	if err := applyRuleExtensions(
+		options.DisableDefaultRules,
+		[]map[string]RuleDefinition{definitions, builtInDefinitions},
+		result.Rules,
+		result.BuiltInRules,
+	); err != nil {
+		return result, err
+	}
+
+	if err := addConfigSanitizerRules(options.Sanitizers, result.Rules); err != nil {
+		return result, err
+	}
+
+	applyRuleMappings(options.Mappings, result.Rules, result.BuiltInRules)
+	applyRuleOverrides(options.Overrides, result.Rules, result.BuiltInRules)
+
+	return result, nil
+}
+
+// addConfigSanitizerRules adds a sanitizer rule for each of the sanitizers
+// declared in the configuration
+func addConfigSanitizerRules(sanitizers []flag.RuleSanitizer, rules map[string]*Rule) error {
+	for i, sanitizer := range sanitizers {
+		for _, language := range sanitizer.Languages {
+			if !GetSupportedRuleLanguages()[language] {
+				return fmt.Errorf("unsupported language '%s' for rule sanitizer %d", language, i+1)
+			}
+		}
+
+		id := fmt.Sprintf("%s%d", configSanitizerRuleIDPrefix, i+1)
+		if _, exists := rules[id]; exists {
+			return fmt.Errorf("duplicate rule ID %s", id)
+		}
+
+		patterns := make([]RulePattern, len(sanitizer.Patterns))
+		for j, pattern := range sanitizer.Patterns {
+			patterns[j] = RulePattern{Pattern: pattern}
+		}
+
+		rules[id] = &Rule{
+			Id:        id,
+			Type:      customdetectors.TypeSanitizer,
+			Languages: sanitizer.Languages,
+			Patterns:  patterns,
+		}
+	}
+
+	return nil
+}
+
+// applyRuleExtensions adds the patterns of shared rules to the rules they
+// extend. Extensions of rules which aren't enabled are left out, as are
+// extensions of unknown rules when default rules are disabled, since they
+// usually extend default rules.
+func applyRuleExtensions(
+	disableDefaultRules bool,
+	definitionSets []map[string]RuleDefinition,
+	ruleSets ...map[string]*Rule,
+) error {
+	findRule := func(id string) *Rule {
+		for _, rules := range ruleSets {
+			if rule, exists := rules[id]; exists {
+				return rule
+			}
+		}
+
+		return nil
+	}
+
+	isDefined := func(id string) bool {
+		for _, definitions := range definitionSets {
+			if _, exists := definitions[id]; exists {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, rules := range ruleSets {
+		for _, id := range maputil.SortedStringKeys(rules) {
+			extension := rules[id]
+
+			for _, extendedID := range extension.Extends {
+				extendedRule := findRule(extendedID)
+				if extendedRule == nil {
+					if isDefined(extendedID) || disableDefaultRules {
+						log.Debug().Msgf("%s: extended rule '%s' is not enabled", id, extendedID)
+						continue
+					}
+
+					return fmt.Errorf("rule %s extends unknown rule '%s'", id, extendedID)
+				}
+
+				if !slices.ContainsFunc(extension.Languages, func(language string) bool {
+					return slices.Contains(extendedRule.Languages, language)
+				}) {
+					return fmt.Errorf(
+						"rule %s extends rule '%s' of other languages (%s)",
+						id,
+						extendedID,
+						strings.Join(extendedRule.Languages, ", "),
+					)
+				}
+
+				patterns := make([]RulePattern, 0, len(extendedRule.Patterns)+len(extension.Patterns))
+				patterns = append(patterns, extendedRule.Patterns...)
+				extendedRule.Patterns = append(patterns, extension.Patterns...)
+				extendedRule.IsLocal = extendedRule.IsLocal || extension.IsLocal
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyRuleMappings replaces the CWE ids and OWASP categories of rules with
+// the ones given in the configuration
+func applyRuleMappings(mappings map[string]flag.RuleMapping, ruleSets ...map[string]*Rule) {
+	for id, mapping := range mappings {
+		found := false
+
+		for _, rules := range ruleSets {
+			rule, ok := rules[id]
+			if !ok {
+				continue
+			}
+
+			found = true
+			if mapping.CWEIDs != nil {
+				rule.CWEIDs = NormalizeCWEIDs(mapping.CWEIDs)
+			}
+			if mapping.OWASP != nil {
+				rule.OWASP = mapping.OWASP
+			}
+		}
+
+		if !found {
+			log.Debug().Msgf("ignoring mappings for rule %s as it is not enabled", id)
+		}
+	}
+}
+
+// getDeprecatedRules maps the ids of deprecated rules to the id of the rule
+// replacing them, following chains of renames. Rules whose replacement isn't
+// available are not included, so that they keep running.
+func getDeprecatedRules(definitionSets ...map[string]RuleDefinition) map[string]string {
+	deprecatedBy := make(map[string]string)
+	for _, definitions := range definitionSets {
+		for id, definition := range definitions {
+			if definition.Metadata != nil && definition.Metadata.DeprecatedBy != "" {
+				deprecatedBy[id] = definition.Metadata.DeprecatedBy
+			}
+		}
+	}
+
+	exists := func(id string) bool {
+		for _, definitions := range definitionSets {
+			if _, ok := definitions[id]; ok {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	result := make(map[string]string)
+	for id, replacementID := range deprecatedBy {
+		seen := set.New[string]()
+		seen.Add(id)
+
+		for {
+			next, deprecated := deprecatedBy[replacementID]
+			if !deprecated || !seen.Add(replacementID) {
+				break
+			}
+
+			replacementID = next
+		}
+
+		if _, deprecated := deprecatedBy[replacementID]; deprecated || !exists(replacementID) {
+			log.Debug().Msgf("rule %s is deprecated by %s, which is not available", id, replacementID)
+			continue
+		}
+
+		result[id] = replacementID
+	}
+
+	return result
+}
+
+// replaceDeprecatedRuleOptions replaces the ids of deprecated rules in the
+// options with the id of the rule replacing them, warning about each one
+func replaceDeprecatedRuleOptions(options flag.RuleOptions, deprecatedRules map[string]string) flag.RuleOptions {
+	if len(deprecatedRules) == 0 {
+		return options
+	}
+
+	warned := set.New[string]()
+	replace := func(id string) string {
+		replacementID, deprecated := deprecatedRules[id]
+		if !deprecated {
+			return id
+		}
+
+		if warned.Add(id) {
+			output.StdErrLog(fmt.Sprintf(
+				"Rule %s is deprecated and replaced by %s. Applying its configuration to %s; update the configuration to refer to %s.",
+				id,
+				replacementID,
+				replacementID,
+				replacementID,
+			))
+		}
+
+		return replacementID
+	}
+
+	replaceIDs := func(ids map[string]bool) map[string]bool {
+		result := make(map[string]bool, len(ids))
+		for id, value := range ids {
+			result[replace(id)] = value
+		}
+
+		return result
+	}
+
+	replaceKeys := func(overrides map[string]flag.RuleOverride) map[string]flag.RuleOverride {
+		if overrides == nil {
+			return nil
+		}
+
+		result := make(map[string]flag.RuleOverride, len(overrides))
+		for id, override := range overrides {
+			result[replace(id)] = override
+		}
+
+		return result
+	}
+
+	options.OnlyRule = replaceIDs(options.OnlyRule)
+	options.SkipRule = replaceIDs(options.SkipRule)
+	options.Overrides = replaceKeys(options.Overrides)
+
+	if options.Parameters != nil {
+		parameters := make(map[string]map[string]interface{}, len(options.Parameters))
+		for id, values := range options.Parameters {
+			parameters[replace(id)] = values
+		}
+		options.Parameters = parameters
+	}
+
+	if options.Mappings != nil {
+		mappings := make(map[string]flag.RuleMapping, len(options.Mappings))
+		for id, mapping := range options.Mappings {
+			mappings[replace(id)] = mapping
+		}
+		options.Mappings = mappings
+	}
+
+	pathOverrides := make([]flag.PathOverride, len(options.PathOverrides))
+	for i, pathOverride := range options.PathOverrides {
+		skipRule := make([]string, len(pathOverride.SkipRule))
+		for j, id := range pathOverride.SkipRule {
+			skipRule[j] = replace(id)
+		}
+
+		pathOverrides[i] = flag.PathOverride{
+			Paths:     pathOverride.Paths,
+			SkipRule:  skipRule,
+			Overrides: replaceKeys(pathOverride.Overrides),
+		}
+	}
+	options.PathOverrides = pathOverrides
+
+	return options
+}
+
+// addReplacedRuleIDs records the deprecated rules replaced by each rule
+func addReplacedRuleIDs(deprecatedRules map[string]string, ruleSets ...map[string]*Rule) {
+	for _, id := range maputil.SortedStringKeys(deprecatedRules) {
+		for _, rules := range ruleSets {
+			if rule, ok := rules[deprecatedRules[id]]; ok {
+				rule.Replaces = append(rule.Replaces, id)
+			}
+		}
+	}
+}
+
+// applyRuleOverrides replaces the severity of rules with the one given in the
+// configuration
+func applyRuleOverrides(overrides map[string]flag.RuleOverride, ruleSets ...map[string]*Rule) {
+	for id, override := range overrides {
+		found := false
+
+		for _, rules := range ruleSets {
+			rule, ok := rules[id]
+			if !ok {
+				continue
+			}
+
+			found = true
+			if override.Severity != "" {
+				rule.Severity = override.Severity
+			}
+		}
+
+		if !found {
+			log.Debug().Msgf("ignoring overrides for rule %s as it is not enabled", id)
+		}
+	}
+}
+
+// NormalizeCWEIDs strips any CWE- prefix so that ids can be written either
+// way in rules and configuration
+func NormalizeCWEIDs(cweIDs []string) []string {
+	if cweIDs == nil {
+		return nil
+	}
+
+	result := make([]string, len(cweIDs))
+	for i, cweID := range cweIDs {
+		cweID = strings.TrimSpace(cweID)
+		if len(cweID) > 4 && strings.EqualFold(cweID[:4], "cwe-") {
+			cweID = cweID[4:]
+		}
+
+		result[i] = cweID
+	}
+
+	return result
+}
+
+// LoadRuleDocumentation loads the definitions of every rule available
+// without network access: the locally cached default rules, the built-in
+// rules and any external rules. External rules in git repositories and OCI
+// registries are only loaded once cached. The definitions are only suitable for reading their
+// metadata.
+func LoadRuleDocumentation(externalRuleDirs []string) (map[string]RuleDefinition, error) {
+	definitions := make(map[string]RuleDefinition)
+
+	externalRuleDirs, err := resolveCachedExternalRuleDirs(externalRuleDirs)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded, err := LoadRuleDefinitionsFromCache(definitions)
+	if err != nil {
+		return nil, fmt.Errorf("error loading cached rules: %w", err)
+	}
+	if !loaded {
+		log.Debug().Msgf("no cached rules found in %s", bearerRulesDir())
+	}
+
+	if err := loadRuleDefinitionsFromDir(definitions, buildInRulesFs); err != nil {
+		return nil, fmt.Errorf("error loading built-in rules: %w", err)
+	}
+
+	if err := loadExternalRuleDefinitions(definitions, externalRuleDirs, nil); err != nil {
+		return nil, err
+	}
+
+	return definitions, nil
+}
+
+// loadExternalRuleDefinitions adds the rules of the external rule directories
+// to the definitions. External rules can't share an id with each other, nor
+// with the rules already loaded or the built-in rules, so that they never
+// silently replace one another.
+func loadExternalRuleDefinitions(
+	definitions map[string]RuleDefinition,
+	externalRuleDirs []string,
+	builtInDefinitions map[string]RuleDefinition,
+) error {
+	upstreamIDs := set.New[string]()
+	upstreamIDs.AddAll(maputil.SortedStringKeys(definitions))
+	upstreamIDs.AddAll(maputil.SortedStringKeys(builtInDefinitions))
+
+	loadedDirs := set.New[string]()
+	ruleDirs := make(map[string]string)
+
 	for _, dir := range externalRuleDirs {
 		if strings.HasPrefix(dir, "~/") {
 			dirname, _ := os.UserHomeDir()
 			dir = filepath.Join(dirname, dir[2:])
 		}
+
+		if !loadedDirs.Add(filepath.Clean(dir)) {
+			continue
+		}
+
 		log.Debug().Msgf("loading external rules from: %s", dir)
-		if err := loadRuleDefinitionsFromDir(definitions, os.DirFS(dir)); err != nil {
-			return result, fmt.Errorf("external rules %w", err)
+		dirDefinitions := make(map[string]RuleDefinition)
+		if err := loadRuleDefinitionsFromDir(dirDefinitions, os.DirFS(dir)); err != nil {
+			return fmt.Errorf("external rules %w", err)
 		}
-	}
 
-	if err := validateRuleOptionIDs(options, definitions, builtInDefinitions); err != nil {
-		return result, err
+		for _, id := range maputil.SortedStringKeys(dirDefinitions) {
+			if upstreamIDs.Has(id) {
+				return fmt.Errorf(
+					"external rule %s in %s has the same ID as a default rule; use a namespace for custom rules, eg. acme%s%s",
+					id,
+					dir,
+					RuleNamespaceSeparator,
+					id,
+				)
+			}
+
+			if otherDir, exists := ruleDirs[id]; exists {
+				return fmt.Errorf("external rule %s is defined in both %s and %s", id, otherDir, dir)
+			}
+
+			ruleDirs[id] = dir
+			definitions[id] = dirDefinitions[id]
+		}
 	}
 
-	enabledRules := getEnabledRules(options, definitions, nil)
-	builtInRules := getEnabledRules(options, builtInDefinitions, enabledRules)
+	return nil
+}
 
-	result.Rules = BuildRules(definitions, enabledRules)
-	result.BuiltInRules = BuildRules(builtInDefinitions, builtInRules)
+// ruleRecipeDirs finds the recipes directories within the external rule
+// directories, such as those shipped in installed rule packs
+func ruleRecipeDirs(externalRuleDirs []string) []string {
+	var recipeDirs []string
 
-	return result, nil
+	for _, dir := range externalRuleDirs {
+		if strings.HasPrefix(dir, "~/") {
+			dirname, _ := os.UserHomeDir()
+			dir = filepath.Join(dirname, dir[2:])
+		}
+
+		// errors are reported when the rules themselves are loaded
+		_ = filepath.WalkDir(dir, func(path string, dirEntry fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			if dirEntry.IsDir() && dirEntry.Name() == RecipesDirName {
+				recipeDirs = append(recipeDirs, path)
+				return filepath.SkipDir
+			}
+
+			return nil
+		})
+	}
+
+	return recipeDirs
 }
 
 func loadRuleDefinitionsFromRemote(
@@ -105,19 +627,39 @@ func loadRuleDefinitionsFromRemote(
 		return
 	}
 
-	urls := make([]string, 0, len(versionMeta.Rules.Packages))
 	for _, value := range versionMeta.Rules.Packages {
 		log.Debug().Msgf("Added rule package URL %s", value)
-		urls = append(urls, value)
 	}
 
-	err := LoadRuleDefinitionsFromUrls(definitions, urls)
+	err := LoadRuleDefinitionsFromUrls(definitions, *versionMeta.Rules.Version, versionMeta.Rules.Packages)
 	if err != nil {
 		output.Fatal(fmt.Sprintf("Error loading rules: %s", err))
 		// sysexit
 	}
 }
 
+// loadShippedRuleDefinitions adds the default rules embedded in the binary, for
+// the languages which the rule packages don't cover yet. Rules of the packages
+// take precedence over them.
+func loadShippedRuleDefinitions(definitions map[string]RuleDefinition, options flag.RuleOptions) error {
+	if options.DisableDefaultRules {
+		return nil
+	}
+
+	shippedDefinitions := make(map[string]RuleDefinition)
+	if err := loadRuleDefinitionsFromDir(shippedDefinitions, defaultRulesFs); err != nil {
+		return err
+	}
+
+	for id, definition := range shippedDefinitions {
+		if _, exists := definitions[id]; !exists {
+			definitions[id] = definition
+		}
+	}
+
+	return nil
+}
+
 func loadRuleDefinitionsFromDir(definitions map[string]RuleDefinition, dir fs.FS) error {
 	loadedDefinitions := make(map[string]RuleDefinition)
 	if err := fs.WalkDir(dir, ".", func(path string, dirEntry fs.DirEntry, err error) error {
@@ -126,6 +668,16 @@ func loadRuleDefinitionsFromDir(definitions map[string]RuleDefinition, dir fs.FS
 		}
 
 		if dirEntry.IsDir() {
+			// recipes shipped alongside rules are loaded separately
+			if dirEntry.Name() == RecipesDirName {
+				return fs.SkipDir
+			}
+
+			// fixtures of rules may be yaml files themselves
+			if dirEntry.Name() == TestdataDirName {
+				return fs.SkipDir
+			}
+
 			return nil
 		}
 
@@ -187,12 +739,27 @@ func loadRuleDefinitionsFromDir(definitions map[string]RuleDefinition, dir fs.FS
 }
 
 func validateRuleDefinition(allDefinitions map[string]RuleDefinition, definition *RuleDefinition) bool {
+	problems := RuleDefinitionProblems(allDefinitions, definition)
+	for _, problem := range problems {
+		log.Debug().Msgf("%s: %s", definition.Metadata.ID, problem)
+	}
+
+	if len(problems) != 0 {
+		log.Debug().Msgf("%s ignored due to validation errors", definition.Metadata.ID)
+		return false
+	}
+
+	return true
+}
+
+// RuleDefinitionProblems are the reasons a rule definition can't be loaded,
+// given the other definitions of its directory
+func RuleDefinitionProblems(allDefinitions map[string]RuleDefinition, definition *RuleDefinition) []string {
 	metadata := definition.Metadata
 
-	valid := true
+	var problems []string
 	fail := func(message string) {
-		valid = false
-		log.Debug().Msgf("%s: %s", metadata.ID, message)
+		problems = append(problems, message)
 	}
 
 	visibleRuleIDs := set.New[string]()
@@ -230,32 +797,123 @@ func validateRuleDefinition(allDefinitions map[string]RuleDefinition, definition
 		}
 	}
 
+	if trigger := definition.Trigger; trigger != nil {
+		for _, conditionRuleID := range trigger.RequiredDetections {
+			if !visibleRuleIDs.Has(conditionRuleID) {
+				fail(fmt.Sprintf("required detection references invalid or non-imported rule '%s'", conditionRuleID))
+			}
+		}
+
+		for _, conditionRuleID := range trigger.ExcludedDetections {
+			if !visibleRuleIDs.Has(conditionRuleID) {
+				fail(fmt.Sprintf("excluded detection references invalid or non-imported rule '%s'", conditionRuleID))
+			}
+		}
+
+		hasConditions := len(trigger.RequiredDetections) != 0 || len(trigger.ExcludedDetections) != 0
+		if hasConditions && trigger.MatchOn != nil && *trigger.MatchOn != PRESENCE {
+			fail("required and excluded detections can only be used when matching on presence")
+		}
+
+		if scope := trigger.Scope; scope != nil {
+			if *scope != FILE_CONDITION_SCOPE && *scope != FUNCTION_CONDITION_SCOPE {
+				fail(fmt.Sprintf("invalid trigger scope '%s'", *scope))
+			}
+
+			if !hasConditions {
+				fail("trigger scope cannot be specified without required or excluded detections")
+			}
+		}
+	}
+
+	if definition.Confidence != "" && !slices.Contains(globaltypes.Confidences, definition.Confidence) {
+		fail(fmt.Sprintf("invalid confidence '%s'", definition.Confidence))
+	}
+
+	for _, pattern := range definition.Patterns {
+		if pattern.Confidence != "" && !slices.Contains(globaltypes.Confidences, pattern.Confidence) {
+			fail(fmt.Sprintf("invalid pattern confidence '%s'", pattern.Confidence))
+		}
+	}
+
 	if metadata.ID == "" {
 		fail("metadata.id must be specified")
 	}
 
-	if definition.Type == customdetectors.TypeShared {
+	if problem := RuleIDProblem(metadata.ID); problem != "" {
+		fail(problem)
+	}
+
+	if metadata.DeprecatedBy != "" && metadata.DeprecatedBy == metadata.ID {
+		fail("metadata.deprecated_by cannot refer to the rule itself")
+	}
+
+	if len(definition.Extends) != 0 && definition.Type != customdetectors.TypeShared {
+		fail("extends can only be specified for a shared rule")
+	}
+
+	if slices.Contains(definition.Extends, metadata.ID) {
+		fail("extends cannot refer to the rule itself")
+	}
+
+	// shared and sanitizer rules are only used by other rules and don't result
+	// in findings
+	if definition.Type == customdetectors.TypeShared || definition.Type == customdetectors.TypeSanitizer {
 		metadata := definition.Metadata
 		if metadata != nil {
 			if metadata.CWEIDs != nil {
-				fail("cwe ids cannot be specified for a shared rule")
+				fail(fmt.Sprintf("cwe ids cannot be specified for a %s rule", definition.Type))
+			}
+
+			if metadata.OWASP != nil {
+				fail(fmt.Sprintf("owasp categories cannot be specified for a %s rule", definition.Type))
 			}
 
 			if metadata.RemediationMessage != "" {
-				fail("remediation message cannot be specified for a shared rule")
+				fail(fmt.Sprintf("remediation message cannot be specified for a %s rule", definition.Type))
 			}
 		}
 
 		if definition.Severity != "" {
-			fail("severity cannot be specified for a shared rule")
+			fail(fmt.Sprintf("severity cannot be specified for a %s rule", definition.Type))
+		}
+
+		if len(definition.Requires) != 0 {
+			fail(fmt.Sprintf("requires cannot be specified for a %s rule", definition.Type))
+		}
+
+		if definition.Paths != nil {
+			fail(fmt.Sprintf("paths cannot be specified for a %s rule", definition.Type))
+		}
+	}
+
+	for _, value := range definition.Requires {
+		if _, err := facts.ParsePrecondition(value); err != nil {
+			fail(err.Error())
+		}
+	}
+
+	if fix := definition.Fix; fix != nil {
+		if definition.Type == customdetectors.TypeShared || definition.Type == customdetectors.TypeSanitizer {
+			fail(fmt.Sprintf("fix cannot be specified for a %s rule", definition.Type))
+		}
+
+		if fix.Replace == "" {
+			fail("fix.replace must be specified")
+		} else if _, err := regexp.Compile(fix.Replace); err != nil {
+			fail(fmt.Sprintf("invalid fix.replace regular expression: %s", err))
 		}
 	}
 
-	if !valid {
-		log.Debug().Msgf("%s ignored due to validation errors", metadata.ID)
+	for _, problem := range ruleParameterProblems(definition) {
+		fail(problem)
+	}
+
+	for _, problem := range embeddedLanguageProblems(definition) {
+		fail(problem)
 	}
 
-	return valid
+	return problems
 }
 
 func getFilterRuleReferences(definition *RuleDefinition) set.Set[string] {
@@ -341,7 +999,12 @@ func validateRuleOptionIDs(
 	return nil
 }
 
-func getEnabledRules(options flag.RuleOptions, definitions map[string]RuleDefinition, rules map[string]struct{}) map[string]struct{} {
+func getEnabledRules(
+	options flag.RuleOptions,
+	definitions map[string]RuleDefinition,
+	rules map[string]struct{},
+	deprecatedRules map[string]string,
+) map[string]struct{} {
 	enabledRules := make(map[string]struct{})
 
 	for ruleId := range rules {
@@ -376,7 +1039,14 @@ func getEnabledRules(options flag.RuleOptions, definitions map[string]RuleDefini
 	for _, definition := range definitions {
 		id := definition.Metadata.ID
 
-		if len(options.OnlyRule) > 0 && !options.OnlyRule[id] {
+		// sanitizers apply to every rule, and extensions to the rules they
+		// extend, so are kept when only running some
+		appliesToOthers := definition.Type == customdetectors.TypeSanitizer || len(definition.Extends) != 0
+		if len(options.OnlyRule) > 0 && !options.OnlyRule[id] && !appliesToOthers {
+			continue
+		}
+
+		if !matchesMappingFilters(options, definition) && !appliesToOthers {
 			continue
 		}
 
@@ -384,12 +1054,107 @@ func getEnabledRules(options flag.RuleOptions, definitions map[string]RuleDefini
 			continue
 		}
 
+		// the rule replacing it runs instead
+		if _, deprecated := deprecatedRules[id]; deprecated {
+			continue
+		}
+
 		enableRule(definition)
 	}
 
 	return enabledRules
 }
 
+// getDisabledRules returns the risk rules which aren't enabled, with the
+// reason each one is left out, in the order getEnabledRules checks them
+func getDisabledRules(
+	options flag.RuleOptions,
+	definitions map[string]RuleDefinition,
+	enabledRules map[string]struct{},
+	deprecatedRules map[string]string,
+) map[string]DisabledRule {
+	disabledRules := make(map[string]DisabledRule)
+
+	for _, definition := range definitions {
+		id := definition.Metadata.ID
+
+		if _, enabled := enabledRules[id]; enabled {
+			continue
+		}
+
+		if definition.Type != "" && definition.Type != defaultRuleType {
+			continue
+		}
+
+		var reason string
+		switch _, deprecated := deprecatedRules[id]; {
+		case len(options.OnlyRule) > 0 && !options.OnlyRule[id]:
+			reason = DisabledReasonOnlyRule
+		case !matchesMappingFilters(options, definition):
+			reason = DisabledReasonMapping
+		case options.SkipRule[id]:
+			reason = DisabledReasonSkipRule
+		case deprecated:
+			reason = DisabledReasonDeprecated
+		case definition.Disabled:
+			reason = DisabledReasonDisabled
+		default:
+			continue
+		}
+
+		disabledRules[id] = DisabledRule{Languages: definition.Languages, Reason: reason}
+	}
+
+	return disabledRules
+}
+
+// matchesMappingFilters tells whether a rule is mapped to one of the CWE ids
+// or OWASP categories of the only-cwe and only-owasp options, taking mappings
+// from the configuration into account. Every rule matches when neither option
+// is given.
+func matchesMappingFilters(options flag.RuleOptions, definition RuleDefinition) bool {
+	if len(options.OnlyCWE) == 0 && len(options.OnlyOWASP) == 0 {
+		return true
+	}
+
+	cweIDs := definition.Metadata.CWEIDs
+	owaspCategories := definition.Metadata.OWASP
+	if mapping, ok := options.Mappings[definition.Metadata.ID]; ok {
+		if mapping.CWEIDs != nil {
+			cweIDs = mapping.CWEIDs
+		}
+		if mapping.OWASP != nil {
+			owaspCategories = mapping.OWASP
+		}
+	}
+
+	onlyCWE := NormalizeCWEIDs(options.OnlyCWE)
+	for _, cweID := range NormalizeCWEIDs(cweIDs) {
+		if slices.Contains(onlyCWE, cweID) {
+			return true
+		}
+	}
+
+	for _, category := range owaspCategories {
+		for _, onlyCategory := range options.OnlyOWASP {
+			if matchesOWASPCategory(category, onlyCategory) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchesOWASPCategory compares OWASP Top 10 categories, where a category
+// without a year, eg. A03, matches that category of any year
+func matchesOWASPCategory(category string, filter string) bool {
+	category = strings.ToUpper(strings.TrimSpace(category))
+	filter = strings.ToUpper(strings.TrimSpace(filter))
+
+	return category == filter || strings.HasPrefix(category, filter+":")
+}
+
 func BuildRules(definitions map[string]RuleDefinition, enabledRules map[string]struct{}) map[string]*Rule {
 	rules := make(map[string]*Rule)
 
@@ -421,6 +1186,14 @@ func BuildRules(definitions map[string]RuleDefinition, enabledRules map[string]s
 			if definition.Trigger.RequiredDetection != nil {
 				ruleTrigger.RequiredDetection = definition.Trigger.RequiredDetection
 			}
+			ruleTrigger.RequiredDetections = definition.Trigger.RequiredDetections
+			ruleTrigger.ExcludedDetections = definition.Trigger.ExcludedDetections
+			if len(ruleTrigger.RequiredDetections) != 0 || len(ruleTrigger.ExcludedDetections) != 0 {
+				ruleTrigger.Scope = DefaultConditionScope
+				if definition.Trigger.Scope != nil {
+					ruleTrigger.Scope = *definition.Trigger.Scope
+				}
+			}
 		}
 
 		isLocal := false
@@ -440,13 +1213,15 @@ func BuildRules(definitions map[string]RuleDefinition, enabledRules map[string]s
 			SkipDataTypes:      definition.SkipDataTypes,
 			OnlyDataTypes:      definition.OnlyDataTypes,
 			Severity:           definition.Severity,
+			Confidence:         definition.Confidence,
 			Description:        definition.Metadata.Description,
 			RemediationMessage: definition.Metadata.RemediationMessage,
 			Stored:             definition.Stored,
 			Detectors:          definition.Detectors,
 			Processors:         definition.Processors,
 			AutoEncrytPrefix:   definition.AutoEncrytPrefix,
-			CWEIDs:             definition.Metadata.CWEIDs,
+			CWEIDs:             NormalizeCWEIDs(definition.Metadata.CWEIDs),
+			OWASP:              definition.Metadata.OWASP,
 			Languages:          definition.Languages,
 			ParamParenting:     definition.ParamParenting,
 			Patterns:           definition.Patterns,
@@ -455,6 +1230,12 @@ func BuildRules(definitions map[string]RuleDefinition, enabledRules map[string]s
 			HasDetailedContext: definition.HasDetailedContext,
 			DependencyCheck:    definition.DependencyCheck,
 			Dependency:         definition.Dependency,
+			Requires:           definition.Requires,
+			Paths:              definition.Paths,
+			Fix:                definition.Fix,
+			Version:            definition.Metadata.Version,
+			Extends:            definition.Extends,
+			EmbeddedLanguage:   definition.EmbeddedLanguage,
 		}
 
 		for _, auxiliaryDefinition := range definition.Auxiliary {
@@ -474,6 +1255,29 @@ func BuildRules(definitions map[string]RuleDefinition, enabledRules map[string]s
 	return rules
 }
 
+func loadRuleDefinitionsFromCache(definitions map[string]RuleDefinition, options flag.RuleOptions) error {
+	if options.DisableDefaultRules {
+		return nil
+	}
+
+	log.Debug().Msg("Loading rule packages from local cache")
+
+	loaded, err := LoadRuleDefinitionsFromCache(definitions)
+	if err != nil {
+		return fmt.Errorf("error loading cached rules: %w", err)
+	}
+
+	if !loaded {
+		return fmt.Errorf(
+			"no cached rules found in %s; default rules cannot be downloaded in offline mode. "+
+				"run a scan with network access first, or use --disable-default-rules",
+			bearerRulesDir(),
+		)
+	}
+
+	return nil
+}
+
 func bearerRulesDir() string {
-	return filepath.Join(os.TempDir(), "bearer-rules")
+	return filepath.Join(workdir.Dir(), "bearer-rules")
 }
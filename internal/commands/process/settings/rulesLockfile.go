@@ -0,0 +1,246 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/internal/util/rulepack"
+)
+
+const rulesLockVersion = 1
+
+// RulesLock records the external rules a scan used, so that later scans can
+// verify they use exactly the same rules
+type RulesLock struct {
+	Version     int                `json:"version"`
+	RuleSources []LockedRuleSource `json:"rule_sources"`
+}
+
+// LockedRuleSource is an external rule directory as given in the options,
+// with the digest of its resolved content
+type LockedRuleSource struct {
+	Source  string           `json:"source"`
+	Version string           `json:"version,omitempty"`
+	Digest  string           `json:"digest"`
+	Packs   []LockedRulePack `json:"packs,omitempty"`
+}
+
+// LockedRulePack is a rule pack installed within an external rule directory
+type LockedRulePack struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Digest  string `json:"digest"`
+}
+
+// LockExternalRules resolves the external rule directories and records the
+// version and digest of each of them, and of the rule packs they contain
+func LockExternalRules(externalRuleDirs []string, publicKeyPath string, offline bool) (*RulesLock, error) {
+	resolvedDirs, err := resolveExternalRuleDirs(externalRuleDirs, publicKeyPath, offline)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &RulesLock{Version: rulesLockVersion, RuleSources: []LockedRuleSource{}}
+	for i, source := range externalRuleDirs {
+		lockedSource, err := lockRuleSource(source, resolvedDirs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		lock.RuleSources = append(lock.RuleSources, *lockedSource)
+	}
+
+	return lock, nil
+}
+
+func lockRuleSource(source string, dir string) (*LockedRuleSource, error) {
+	digest, err := rulesDigest(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error computing digest of external rules %s: %w", source, err)
+	}
+
+	lockedSource := &LockedRuleSource{Source: source, Digest: digest}
+
+	if isGitRuleSource(source) {
+		gitSource, err := parseGitRuleSource(source)
+		if err != nil {
+			return nil, err
+		}
+
+		lockedSource.Version = gitSource.ref
+	} else if provenance, err := rulepack.ReadProvenance(dir); err == nil {
+		lockedSource.Version = provenance.Version
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading external rules %s: %w", source, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		packDir := filepath.Join(dir, entry.Name())
+		provenance, err := rulepack.ReadProvenance(packDir)
+		if err != nil {
+			continue
+		}
+
+		packDigest, err := rulesDigest(packDir)
+		if err != nil {
+			return nil, fmt.Errorf("error computing digest of rule pack %s: %w", provenance.Name, err)
+		}
+
+		lockedSource.Packs = append(lockedSource.Packs, LockedRulePack{
+			Name:    provenance.Name,
+			Version: provenance.Version,
+			Digest:  packDigest,
+		})
+	}
+
+	sort.Slice(lockedSource.Packs, func(i, j int) bool {
+		return lockedSource.Packs[i].Name < lockedSource.Packs[j].Name
+	})
+
+	return lockedSource, nil
+}
+
+// rulesDigest is the checksum of a rules directory. Provenance files are left
+// out as they record when packs were installed, not what they contain.
+func rulesDigest(dir string) (string, error) {
+	checksum, err := dirChecksumExcluding(dir, rulepack.ProvenanceFilename)
+	if err != nil {
+		return "", err
+	}
+
+	return checksumPrefix + checksum, nil
+}
+
+// Write saves the lock to the given path
+func (lock *RulesLock) Write(path string) error {
+	content, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rules lockfile: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("could not write rules lockfile %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// readRulesLock reads the lockfile at the given path, returning nil if there
+// isn't one
+func readRulesLock(path string) (*RulesLock, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("could not read rules lockfile %s: %w", path, err)
+	}
+
+	var lock RulesLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("invalid rules lockfile %s: %w", path, err)
+	}
+
+	if lock.Version != rulesLockVersion {
+		return nil, fmt.Errorf("unsupported rules lockfile %s version %d", path, lock.Version)
+	}
+
+	return &lock, nil
+}
+
+// verifyRulesLock checks every external rule directory against the lockfile,
+// if there is one. Directories not recorded in the lockfile are rejected so
+// that rules can't be added without updating it.
+func verifyRulesLock(lockfilePath string, externalRuleDirs []string, resolvedDirs []string) error {
+	lock, err := readRulesLock(lockfilePath)
+	if err != nil || lock == nil {
+		return err
+	}
+
+	lockedSources := make(map[string]LockedRuleSource)
+	for _, lockedSource := range lock.RuleSources {
+		lockedSources[lockedSource.Source] = lockedSource
+	}
+
+	for i, source := range externalRuleDirs {
+		lockedSource, exists := lockedSources[source]
+		if !exists {
+			return fmt.Errorf(
+				"external rules %s are not in %s; run `bearer rules lock` to update it",
+				source,
+				lockfilePath,
+			)
+		}
+
+		actualSource, err := lockRuleSource(source, resolvedDirs[i])
+		if err != nil {
+			return err
+		}
+
+		if actualSource.Digest == lockedSource.Digest {
+			log.Debug().Msgf("external rules %s match %s", source, lockfilePath)
+			continue
+		}
+
+		if pack := changedPack(lockedSource.Packs, actualSource.Packs); pack != nil {
+			return fmt.Errorf(
+				"external rules %s do not match %s: rule pack %s expected %s, got %s",
+				source,
+				lockfilePath,
+				pack.Name,
+				pack.Digest,
+				digestOf(actualSource.Packs, pack.Name),
+			)
+		}
+
+		return fmt.Errorf(
+			"external rules %s do not match %s: expected %s, got %s",
+			source,
+			lockfilePath,
+			lockedSource.Digest,
+			actualSource.Digest,
+		)
+	}
+
+	return nil
+}
+
+// changedPack is the first locked rule pack whose content has changed or
+// which is no longer installed
+func changedPack(lockedPacks []LockedRulePack, actualPacks []LockedRulePack) *LockedRulePack {
+	for i, lockedPack := range lockedPacks {
+		if digestOf(actualPacks, lockedPack.Name) != lockedPack.Digest {
+			return &lockedPacks[i]
+		}
+	}
+
+	return nil
+}
+
+func digestOf(packs []LockedRulePack, name string) string {
+	for _, pack := range packs {
+		if pack.Name == name {
+			return pack.Digest
+		}
+	}
+
+	return "nothing"
+}
@@ -4,13 +4,17 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v3"
 
 	"github.com/bearer/bearer/api"
+	"github.com/bearer/bearer/internal/classification/db"
 	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/facts"
 	"github.com/bearer/bearer/internal/util/ignore"
 	ignoretypes "github.com/bearer/bearer/internal/util/ignore/types"
 	"github.com/bearer/bearer/internal/util/output"
@@ -59,6 +63,10 @@ type Config struct {
 	IgnoreFile                 string                                    `mapstructure:"ignore_file" json:"ignore_file" yaml:"ignore_file"`
 	Rules                      map[string]*Rule                          `mapstructure:"rules" json:"rules" yaml:"rules"`
 	BuiltInRules               map[string]*Rule                          `mapstructure:"built_in_rules" json:"built_in_rules" yaml:"built_in_rules"`
+	PathOverrides              []flag.PathOverride                       `mapstructure:"path_overrides" json:"path_overrides,omitempty" yaml:"path_overrides,omitempty"`
+	DisabledRules              map[string]DisabledRule                   `mapstructure:"disabled_rules" json:"disabled_rules,omitempty" yaml:"disabled_rules,omitempty"`
+	FindingPolicies            Modules                                   `mapstructure:"finding_policies" json:"finding_policies,omitempty" yaml:"finding_policies,omitempty"`
+	Recipes                    []db.Recipe                               `mapstructure:"recipes" json:"recipes,omitempty" yaml:"recipes,omitempty"`
 	CacheUsed                  bool                                      `mapstructure:"cache_used" json:"cache_used" yaml:"cache_used"`
 	BearerRulesVersion         string                                    `mapstructure:"bearer_rules_version" json:"bearer_rules_version" yaml:"bearer_rules_version"`
 	NoColor                    bool                                      `mapstructure:"no_color" json:"no_color" yaml:"no_color"`
@@ -66,6 +74,7 @@ type Config struct {
 	LogLevel                   string                                    `mapstructure:"log_level" json:"log_level" yaml:"log_level"`
 	DebugProfile               bool                                      `mapstructure:"debug_profile" json:"debug_profile" yaml:"debug_profile"`
 	IgnoreGit                  bool                                      `mapstructure:"ignore_git" json:"ignore_git" yaml:"ignore_git"`
+	Offline                    bool                                      `mapstructure:"offline" json:"offline" yaml:"offline"`
 }
 
 type Modules []*PolicyModule
@@ -88,6 +97,7 @@ const (
 	PRESENCE          MatchOn = "presence"
 	ABSENCE           MatchOn = "absence"
 	STORED_DATA_TYPES MatchOn = "stored_data_types"
+	UNUSED_DATA_TYPES MatchOn = "unused_data_types"
 )
 
 type RuleReferenceScope string
@@ -102,56 +112,132 @@ const (
 	DefaultScope = NESTED_SCOPE
 )
 
+// RuleConditionScope is where the detections a rule requires, or excludes,
+// are looked for relative to each of its detections
+type RuleConditionScope string
+
+const (
+	FILE_CONDITION_SCOPE     RuleConditionScope = "file"
+	FUNCTION_CONDITION_SCOPE RuleConditionScope = "function"
+
+	DefaultConditionScope = FILE_CONDITION_SCOPE
+)
+
 type LoadRulesResult struct {
 	BuiltInRules       map[string]*Rule
 	Rules              map[string]*Rule
 	CacheUsed          bool
 	BearerRulesVersion string
+	// PathOverrides are those of the options, with deprecated rules replaced
+	PathOverrides []flag.PathOverride
+	DisabledRules map[string]DisabledRule
+}
+
+// Reasons a loaded rule definition isn't run
+const (
+	DisabledReasonDisabled   = "disabled"
+	DisabledReasonOnlyRule   = "only_rule"
+	DisabledReasonMapping    = "mapping_filter"
+	DisabledReasonSkipRule   = "skip_rule"
+	DisabledReasonDeprecated = "deprecated"
+)
+
+// DisabledRule is a risk rule which was loaded but won't be run, with the
+// reason why
+type DisabledRule struct {
+	Languages []string `mapstructure:"languages" json:"languages" yaml:"languages"`
+	Reason    string   `mapstructure:"reason" json:"reason" yaml:"reason"`
 }
 
 type RuleTrigger struct {
 	MatchOn           MatchOn `mapstructure:"match_on" json:"match_on" yaml:"match_on"`
 	DataTypesRequired bool    `mapstructure:"data_types_required" json:"data_types_required" yaml:"data_types_required"`
 	RequiredDetection *string `mapstructure:"required_detection" json:"required_detection" yaml:"required_detection"`
+	// RequiredDetections are rules which must match, and ExcludedDetections
+	// rules which must not, in the same scope as a detection for it to be kept
+	RequiredDetections []string           `mapstructure:"required_detections" json:"required_detections,omitempty" yaml:"required_detections,omitempty"`
+	ExcludedDetections []string           `mapstructure:"excluded_detections" json:"excluded_detections,omitempty" yaml:"excluded_detections,omitempty"`
+	Scope              RuleConditionScope `mapstructure:"scope" json:"scope,omitempty" yaml:"scope,omitempty"`
 }
 
 type RuleDefinitionTrigger struct {
-	MatchOn           *MatchOn `mapstructure:"match_on" json:"match_on" yaml:"match_on"`
-	RequiredDetection *string  `mapstructure:"required_detection" json:"required_detection" yaml:"required_detection"`
-	DataTypesRequired *bool    `mapstructure:"data_types_required" json:"data_types_required" yaml:"data_types_required"`
+	MatchOn            *MatchOn            `mapstructure:"match_on" json:"match_on" yaml:"match_on"`
+	RequiredDetection  *string             `mapstructure:"required_detection" json:"required_detection" yaml:"required_detection"`
+	DataTypesRequired  *bool               `mapstructure:"data_types_required" json:"data_types_required" yaml:"data_types_required"`
+	RequiredDetections []string            `mapstructure:"required_detections" json:"required_detections,omitempty" yaml:"required_detections,omitempty"`
+	ExcludedDetections []string            `mapstructure:"excluded_detections" json:"excluded_detections,omitempty" yaml:"excluded_detections,omitempty"`
+	Scope              *RuleConditionScope `mapstructure:"scope" json:"scope,omitempty" yaml:"scope,omitempty"`
 }
 
 type RuleMetadata struct {
 	Description        string   `mapstructure:"description" json:"description" yaml:"description"`
 	RemediationMessage string   `mapstructure:"remediation_message" json:"remediation_message" yaml:"remediation_message"`
 	CWEIDs             []string `mapstructure:"cwe_id" json:"cwe_id" yaml:"cwe_id"`
+	OWASP              []string `mapstructure:"owasp" json:"owasp,omitempty" yaml:"owasp,omitempty"`
 	AssociatedRecipe   string   `mapstructure:"associated_recipe" json:"associated_recipe" yaml:"associated_recipe"`
 	ID                 string   `mapstructure:"id" json:"id" yaml:"id"`
 	DocumentationUrl   string   `mapstructure:"documentation_url" json:"documentation_url" yaml:"documentation_url"`
+	Version            string   `mapstructure:"version" json:"version,omitempty" yaml:"version,omitempty"`
+	// DeprecatedBy is the id of the rule replacing this one, eg. after a
+	// rename. Deprecated rules don't run when their replacement is available.
+	DeprecatedBy string `mapstructure:"deprecated_by" json:"deprecated_by,omitempty" yaml:"deprecated_by,omitempty"`
 }
 
 type RuleDefinition struct {
-	Disabled           bool                   `mapstructure:"disabled" json:"disabled" yaml:"disabled"`
-	Type               string                 `mapstructure:"type" json:"type" yaml:"type"`
-	Languages          []string               `mapstructure:"languages" json:"languages" yaml:"languages"`
-	Imports            []string               `mapstructure:"imports" json:"imports" yaml:"imports"`
-	ParamParenting     bool                   `mapstructure:"param_parenting" json:"param_parenting" yaml:"param_parenting"`
-	Patterns           []RulePattern          `mapstructure:"patterns" json:"patterns" yaml:"patterns"`
-	SanitizerRuleID    string                 `mapstructure:"sanitizer" json:"sanitizer" yaml:"sanitizer"`
-	Stored             bool                   `mapstructure:"stored" json:"stored" yaml:"stored"`
-	Detectors          []string               `mapstructure:"detectors" json:"detectors,omitempty" yaml:"detectors,omitempty"`
-	Processors         []string               `mapstructure:"processors" json:"processors,omitempty" yaml:"processors,omitempty"`
-	AutoEncrytPrefix   string                 `mapstructure:"auto_encrypt_prefix" json:"auto_encrypt_prefix,omitempty" yaml:"auto_encrypt_prefix,omitempty"`
-	DetectPresence     bool                   `mapstructure:"detect_presence" json:"detect_presence" yaml:"detect_presence"`
-	Trigger            *RuleDefinitionTrigger `mapstructure:"trigger" json:"trigger" yaml:"trigger"` // TODO: use enum value
-	Severity           string                 `mapstructure:"severity" json:"severity,omitempty" yaml:"severity,omitempty"`
-	SkipDataTypes      []string               `mapstructure:"skip_data_types" json:"skip_data_types,omitempty" yaml:"skip_data_types,omitempty"`
-	OnlyDataTypes      []string               `mapstructure:"only_data_types" json:"only_data_types,omitempty" yaml:"only_data_types,omitempty"`
-	HasDetailedContext bool                   `mapstructure:"has_detailed_context" json:"has_detailed_context,omitempty" yaml:"has_detailed_context,omitempty"`
-	Metadata           *RuleMetadata          `mapstructure:"metadata" json:"metadata" yaml:"metadata"`
-	Auxiliary          []Auxiliary            `mapstructure:"auxiliary" json:"auxiliary" yaml:"auxiliary"`
-	DependencyCheck    bool                   `mapstructure:"dependency_check" json:"dependency_check" yaml:"dependency_check"`
-	Dependency         *Dependency            `mapstructure:"dependency" json:"dependency" yaml:"dependency"`
+	Disabled           bool                     `mapstructure:"disabled" json:"disabled" yaml:"disabled"`
+	Type               string                   `mapstructure:"type" json:"type" yaml:"type"`
+	Languages          []string                 `mapstructure:"languages" json:"languages" yaml:"languages"`
+	Imports            []string                 `mapstructure:"imports" json:"imports" yaml:"imports"`
+	Extends            []string                 `mapstructure:"extends" json:"extends,omitempty" yaml:"extends,omitempty"`
+	EmbeddedLanguage   string                   `mapstructure:"embedded_language" json:"embedded_language,omitempty" yaml:"embedded_language,omitempty"`
+	ParamParenting     bool                     `mapstructure:"param_parenting" json:"param_parenting" yaml:"param_parenting"`
+	Patterns           []RulePattern            `mapstructure:"patterns" json:"patterns" yaml:"patterns"`
+	SanitizerRuleID    string                   `mapstructure:"sanitizer" json:"sanitizer" yaml:"sanitizer"`
+	Stored             bool                     `mapstructure:"stored" json:"stored" yaml:"stored"`
+	Detectors          []string                 `mapstructure:"detectors" json:"detectors,omitempty" yaml:"detectors,omitempty"`
+	Processors         []string                 `mapstructure:"processors" json:"processors,omitempty" yaml:"processors,omitempty"`
+	AutoEncrytPrefix   string                   `mapstructure:"auto_encrypt_prefix" json:"auto_encrypt_prefix,omitempty" yaml:"auto_encrypt_prefix,omitempty"`
+	DetectPresence     bool                     `mapstructure:"detect_presence" json:"detect_presence" yaml:"detect_presence"`
+	Trigger            *RuleDefinitionTrigger   `mapstructure:"trigger" json:"trigger" yaml:"trigger"` // TODO: use enum value
+	Severity           string                   `mapstructure:"severity" json:"severity,omitempty" yaml:"severity,omitempty"`
+	Confidence         string                   `mapstructure:"confidence" json:"confidence,omitempty" yaml:"confidence,omitempty"`
+	SkipDataTypes      []string                 `mapstructure:"skip_data_types" json:"skip_data_types,omitempty" yaml:"skip_data_types,omitempty"`
+	OnlyDataTypes      []string                 `mapstructure:"only_data_types" json:"only_data_types,omitempty" yaml:"only_data_types,omitempty"`
+	HasDetailedContext bool                     `mapstructure:"has_detailed_context" json:"has_detailed_context,omitempty" yaml:"has_detailed_context,omitempty"`
+	Metadata           *RuleMetadata            `mapstructure:"metadata" json:"metadata" yaml:"metadata"`
+	Auxiliary          []Auxiliary              `mapstructure:"auxiliary" json:"auxiliary" yaml:"auxiliary"`
+	DependencyCheck    bool                     `mapstructure:"dependency_check" json:"dependency_check" yaml:"dependency_check"`
+	Dependency         *Dependency              `mapstructure:"dependency" json:"dependency" yaml:"dependency"`
+	Requires           []string                 `mapstructure:"requires" json:"requires,omitempty" yaml:"requires,omitempty"`
+	Paths              *RulePaths               `mapstructure:"paths" json:"paths,omitempty" yaml:"paths,omitempty"`
+	Fix                *RuleFix                 `mapstructure:"fix" json:"fix,omitempty" yaml:"fix,omitempty"`
+	Parameters         map[string]RuleParameter `mapstructure:"parameters" json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// RuleParameter is a setting of a rule which users can give in the
+// configuration, eg. a list of allowed ciphers. Filters refer to parameters to
+// take their comparison values from them.
+type RuleParameter struct {
+	Description string      `mapstructure:"description" json:"description,omitempty" yaml:"description,omitempty"`
+	Default     interface{} `mapstructure:"default" json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// RulePaths restricts the findings of a rule to some files of the project,
+// using gitignore-style patterns relative to the project root. Findings are
+// reported in files matching any include pattern (or any file when there are
+// none), unless they match an exclude pattern.
+type RulePaths struct {
+	Include []string `mapstructure:"include" json:"include,omitempty" yaml:"include,omitempty"`
+	Exclude []string `mapstructure:"exclude" json:"exclude,omitempty" yaml:"exclude,omitempty"`
+}
+
+// RuleFix rewrites the code matched by a rule, replacing the matches of a
+// regular expression with a template, which can refer to the groups of the
+// expression, eg. `$1`
+type RuleFix struct {
+	Description string `mapstructure:"description" json:"description,omitempty" yaml:"description,omitempty"`
+	Replace     string `mapstructure:"replace" json:"replace" yaml:"replace"`
+	With        string `mapstructure:"with" json:"with" yaml:"with"`
 }
 
 type Dependency struct {
@@ -195,9 +281,11 @@ type Rule struct {
 	SkipDataTypes      []string      `mapstructure:"skip_data_types" json:"skip_data_types,omitempty" yaml:"skip_data_types,omitempty"`
 	OnlyDataTypes      []string      `mapstructure:"only_data_types" json:"only_data_types,omitempty" yaml:"only_data_types,omitempty"`
 	Severity           string        `mapstructure:"severity" json:"severity,omitempty" yaml:"severity,omitempty"`
+	Confidence         string        `mapstructure:"confidence" json:"confidence,omitempty" yaml:"confidence,omitempty"`
 	Description        string        `mapstructure:"description" json:"description" yaml:"description"`
 	RemediationMessage string        `mapstructure:"remediation_message" json:"remediation_messafe" yaml:"remediation_messafe"`
 	CWEIDs             []string      `mapstructure:"cwe_ids" json:"cwe_ids" yaml:"cwe_ids"`
+	OWASP              []string      `mapstructure:"owasp" json:"owasp,omitempty" yaml:"owasp,omitempty"`
 	Languages          []string      `mapstructure:"languages" json:"languages" yaml:"languages"`
 	Patterns           []RulePattern `mapstructure:"patterns" json:"patterns" yaml:"patterns"`
 	SanitizerRuleID    string        `mapstructure:"sanitizer" json:"sanitizer" yaml:"sanitizer"`
@@ -205,6 +293,19 @@ type Rule struct {
 	IsAuxilary         bool          `mapstructure:"is_auxilary" json:"is_auxilary" yaml:"is_auxilary"`
 	DependencyCheck    bool          `mapstructure:"dependency_check" json:"dependency_check" yaml:"dependency_check"`
 	Dependency         *Dependency   `mapstructure:"dependency" json:"dependency" yaml:"dependency"`
+	Requires           []string      `mapstructure:"requires" json:"requires,omitempty" yaml:"requires,omitempty"`
+	Paths              *RulePaths    `mapstructure:"paths" json:"paths,omitempty" yaml:"paths,omitempty"`
+	Fix                *RuleFix      `mapstructure:"fix" json:"fix,omitempty" yaml:"fix,omitempty"`
+	Version            string        `mapstructure:"version" json:"version,omitempty" yaml:"version,omitempty"`
+	// Replaces are the ids of the deprecated rules replaced by this one, whose
+	// suppressions and ignored fingerprints apply to this rule
+	Replaces []string `mapstructure:"replaces" json:"replaces,omitempty" yaml:"replaces,omitempty"`
+	// Extends are the ids of the rules the patterns of this shared rule are
+	// added to, eg. to declare more sources of user input
+	Extends []string `mapstructure:"extends" json:"extends,omitempty" yaml:"extends,omitempty"`
+	// EmbeddedLanguage is the language of the patterns, when they match within
+	// the string literals of the rule's languages, eg. SQL queries
+	EmbeddedLanguage string `mapstructure:"embedded_language" json:"embedded_language,omitempty" yaml:"embedded_language,omitempty"`
 
 	// FIXME: remove after refactor of sql
 	Metavars       map[string]MetaVar `mapstructure:"metavars" json:"metavars" yaml:"metavars"`
@@ -238,12 +339,20 @@ type PatternFilter struct {
 	StringRegex        *Regexp  `mapstructure:"string_regex" json:"string_regex" yaml:"string_regex"`
 	EntropyGreaterThan *float64 `mapstructure:"entropy_greater_than" json:"entropy_greater_than" yaml:"entropy_greater_than"`
 	FilenameRegex      *Regexp  `mapstructure:"filename_regex" json:"filename_regex" yaml:"filename_regex"`
+	// Interpolated matches variables of embedded language patterns whose
+	// content comes, or doesn't come, from a non-literal part of the string
+	Interpolated *bool `mapstructure:"interpolated" json:"interpolated,omitempty" yaml:"interpolated,omitempty"`
+	// Parameters maps comparison keys to the rule parameters giving their value
+	Parameters map[string]string `mapstructure:"parameters" json:"parameters,omitempty" yaml:"parameters,omitempty"`
 }
 
 type RulePattern struct {
 	Pattern string          `mapstructure:"pattern" json:"pattern" yaml:"pattern"`
 	Focus   string          `mapstructure:"focus" json:"focus" yaml:"focus"`
 	Filters []PatternFilter `mapstructure:"filters" json:"filters" yaml:"filters"`
+	// Confidence overrides the confidence of the rule for the findings of the
+	// pattern, eg. for a broad pattern
+	Confidence string `mapstructure:"confidence" json:"confidence,omitempty" yaml:"confidence,omitempty"`
 }
 
 type Processor struct {
@@ -263,6 +372,9 @@ var defaultPolicies []byte
 //go:embed built_in_rules/*
 var buildInRulesFs embed.FS
 
+//go:embed default_rules/*
+var defaultRulesFs embed.FS
+
 //go:embed policies/*
 var policiesFs embed.FS
 
@@ -273,6 +385,19 @@ func (rule *Rule) PolicyType() bool {
 	return rule.Type == "risk"
 }
 
+// Preconditions returns the project facts the rule requires. The requirements
+// are validated when the rule is loaded, so invalid ones are ignored here.
+func (rule *Rule) Preconditions() []facts.Precondition {
+	var preconditions []facts.Precondition
+	for _, value := range rule.Requires {
+		if precondition, err := facts.ParsePrecondition(value); err == nil {
+			preconditions = append(preconditions, precondition)
+		}
+	}
+
+	return preconditions
+}
+
 func (rule *Rule) GetSeverity() string {
 	if rule.Severity == "" {
 		return globaltypes.LevelLow
@@ -281,18 +406,58 @@ func (rule *Rule) GetSeverity() string {
 	return rule.Severity
 }
 
+// GetConfidence returns how confident the rule is that its findings are true
+// positives. Rules are considered precise unless they declare otherwise.
+func (rule *Rule) GetConfidence() string {
+	if rule.Confidence == "" {
+		return globaltypes.ConfidenceHigh
+	}
+
+	return rule.Confidence
+}
+
+// detectorRuleLanguages are the languages of the files checked by the built-in
+// rules which are reported by a detector of the SAST scanner, rather than by
+// the patterns of a supported language
+var detectorRuleLanguages = map[string]string{
+	"kubernetes_hardcoded_secret_in_env":      "YAML",
+	"kubernetes_sensitive_data_in_config_map": "YAML",
+	"dockerfile_hardcoded_secret":             "Dockerfile",
+	"dockerfile_sensitive_file_added":         "Dockerfile",
+}
+
+// IsDetectorRule tells whether a built-in rule is reported by a detector of
+// the SAST scanner, such as the detectors of Kubernetes manifests and
+// Dockerfiles
+func (rule *Rule) IsDetectorRule() bool {
+	_, ok := detectorRuleLanguages[rule.Id]
+	return ok
+}
+
 func (rule *Rule) Language() string {
+	if language, ok := detectorRuleLanguages[rule.Id]; ok {
+		return language
+	}
+
 	if rule.Languages == nil {
 		return "secret"
 	}
 
 	switch rule.Languages[0] {
+	case "c":
+		return "C"
 	case "java":
 		return "Java"
 	case "javascript":
 		return "JavaScript"
+	case "kotlin":
+		return "Kotlin"
 	case "ruby":
 		return "Ruby"
+	case "rust":
+		return "Rust"
+	case "shell":
+		return "Shell"
 	case "sql":
 		return "SQL"
 	case "go":
@@ -321,11 +486,25 @@ func defaultWorkerOptions() WorkerOptions {
 func FromOptions(opts flag.Options, versionMeta *version_check.VersionMeta) (Config, error) {
 	policies := DefaultPolicies()
 	workerOptions := defaultWorkerOptions()
-	result, err := loadRules(
+	externalRuleDirs, err := resolveExternalRuleDirs(
 		opts.ExternalRuleDir,
+		opts.ExternalRulePublicKey,
+		opts.GeneralOptions.Offline,
+	)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if err := verifyRulesLock(opts.RulesLockfile, opts.ExternalRuleDir, externalRuleDirs); err != nil {
+		return Config{}, err
+	}
+
+	result, err := loadRules(
+		externalRuleDirs,
 		opts.RuleOptions,
 		versionMeta,
 		opts.ScanOptions.Force,
+		opts.GeneralOptions.Offline,
 	)
 	if err != nil {
 		return Config{}, err
@@ -345,6 +524,29 @@ func FromOptions(opts flag.Options, versionMeta *version_check.VersionMeta) (Con
 		}
 	}
 
+	findingPolicies, err := loadFindingPolicies(opts.ReportOptions.Policy)
+	if err != nil {
+		return Config{}, err
+	}
+
+	dataTypes, err := db.LoadDataTypeDefinitions(opts.ScanOptions.DataTypesDir)
+	if err != nil {
+		return Config{}, err
+	}
+	opts.ScanOptions.DataTypes = append(opts.ScanOptions.DataTypes, dataTypes...)
+	customDB, err := db.Default().WithDataTypeDefinitions(opts.ScanOptions.DataTypes)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid custom data types: %w", err)
+	}
+	if _, err := customDB.WithDataTypeOverrides(opts.ScanOptions.DataTypeOverrides); err != nil {
+		return Config{}, fmt.Errorf("invalid data type overrides: %w", err)
+	}
+
+	recipes, err := db.LoadRecipes(append(opts.ScanOptions.RecipesDir, ruleRecipeDirs(externalRuleDirs)...))
+	if err != nil {
+		return Config{}, err
+	}
+
 	ignoredFingerprints, _, _, err := ignore.GetIgnoredFingerprints(opts.GeneralOptions.IgnoreFile, &opts.ScanOptions.Target)
 	if err != nil {
 		return Config{}, err
@@ -362,9 +564,14 @@ func FromOptions(opts flag.Options, versionMeta *version_check.VersionMeta) (Con
 		LogLevel:            opts.GeneralOptions.LogLevel,
 		IgnoreFile:          opts.GeneralOptions.IgnoreFile,
 		IgnoreGit:           opts.GeneralOptions.IgnoreGit,
+		Offline:             opts.GeneralOptions.Offline,
 		Policies:            policies,
 		Rules:               result.Rules,
 		BuiltInRules:        result.BuiltInRules,
+		PathOverrides:       result.PathOverrides,
+		DisabledRules:       result.DisabledRules,
+		FindingPolicies:     findingPolicies,
+		Recipes:             recipes,
 		CacheUsed:           result.CacheUsed,
 		BearerRulesVersion:  result.BearerRulesVersion,
 	}
@@ -378,17 +585,51 @@ func FromOptions(opts flag.Options, versionMeta *version_check.VersionMeta) (Con
 	return config, nil
 }
 
+// loadFindingPolicies reads the Rego policies matching the given paths
+func loadFindingPolicies(paths []string) (Modules, error) {
+	var modules Modules
+
+	for _, pattern := range paths {
+		filenames, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy path %s: %w", pattern, err)
+		}
+		if len(filenames) == 0 {
+			return nil, fmt.Errorf("no policy files match %s", pattern)
+		}
+
+		for _, filename := range filenames {
+			content, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read policy file %s: %w", filename, err)
+			}
+
+			modules = append(modules, &PolicyModule{
+				Path:    filename,
+				Name:    filename,
+				Content: string(content),
+			})
+		}
+	}
+
+	return modules, nil
+}
+
 func (rulePattern *RulePattern) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// Try to parse as a string
 	var pattern string
 	if err := unmarshal(&pattern); err == nil {
 		rulePattern.Pattern = pattern
-		return nil
+		return expandVariableConstraints(rulePattern)
 	}
 
 	// Wasn't a string so it must be the structured format
 	type rawRulePattern RulePattern
-	return unmarshal((*rawRulePattern)(rulePattern))
+	if err := unmarshal((*rawRulePattern)(rulePattern)); err != nil {
+		return err
+	}
+
+	return expandVariableConstraints(rulePattern)
 }
 
 func (filter *PatternFilter) UnmarshalYAML(unmarshal func(interface{}) error) error {
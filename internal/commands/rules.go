@@ -0,0 +1,870 @@
+package commands
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/ruleimport"
+	"github.com/bearer/bearer/internal/rulelint"
+	"github.com/bearer/bearer/internal/rulelist"
+	"github.com/bearer/bearer/internal/rulenew"
+	"github.com/bearer/bearer/internal/ruletest"
+	"github.com/bearer/bearer/internal/util/rulepack"
+)
+
+var (
+	ErrRuleTestsFailed = errors.New("some rules don't match their annotated fixtures")
+	ErrRuleLintFailed  = errors.New("some rule files have errors")
+)
+
+func NewRulesCommand() *cobra.Command {
+	usageTemplate := `
+Usage: bearer rules <command> [flags]
+
+Available Commands:
+    list             List the available rules
+    search           Search the community rule pack index
+    install          Install a community rule pack
+    new              Create a rule and its test fixture
+    import           Convert rules from other tools into Bearer rules
+    push             Push a rule pack to an OCI registry
+    pull             Pull a rule pack from an OCI registry
+    lock             Record the digests of external rules in a lockfile
+    test             Test rules against their annotated fixtures
+    lint             Check rule files for mistakes
+
+Examples:
+    # List the Ruby rules of high severity as JSON
+    $ bearer rules list --language ruby --severity high --format json
+
+    # Search for rule packs about Django
+    $ bearer rules search django
+
+    # Install the latest version of a rule pack
+    $ bearer rules install <pack>
+
+    # Install a specific version of a rule pack
+    $ bearer rules install <pack>@1.2.0
+
+    # Push a signed rule pack to a registry
+    $ bearer rules push ghcr.io/org/rules:v3 --rules-dir ./rules --signing-key key.pem
+
+    # Pull a rule pack from a registry, verifying its signature
+    $ bearer rules pull ghcr.io/org/rules:v3 --public-key key.pub.pem
+
+    # Lock the external rules of the project, so scans fail if they change
+    $ bearer rules lock --external-rule-dir .bearer/rules
+
+    # Create a Ruby rule and its fixture in the rules directory
+    $ bearer rules new ./rules --id acme:ruby_insecure_call --language ruby
+
+    # Convert Semgrep rules into Bearer rules in the rules directory
+    $ bearer rules import semgrep ./semgrep-rules ./rules
+
+    # Test the rules of a directory against the fixtures in its testdata
+    $ bearer rules test ./rules
+
+    # Check the rules of a directory for mistakes
+    $ bearer rules lint ./rules
+
+`
+
+	cmd := &cobra.Command{
+		Use:           "rules [subcommand]",
+		Short:         "List, create and import rules, and search, install, lock, test, lint and distribute rule packs",
+		Args:          cobra.NoArgs,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+
+	cmd.AddCommand(
+		newRulesListCommand(),
+		newRulesSearchCommand(),
+		newRulesInstallCommand(),
+		newRulesNewCommand(),
+		newRulesImportCommand(),
+		newRulesPushCommand(),
+		newRulesPullCommand(),
+		newRulesLockCommand(),
+		newRulesTestCommand(),
+		newRulesLintCommand(),
+	)
+
+	cmd.SetUsageTemplate(usageTemplate)
+
+	return cmd
+}
+
+func newRulesListCommand() *cobra.Command {
+	var RulesListFlags = flag.Flags{
+		flag.RuleListFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list [query]",
+		Short: "List the available rules",
+		Long: `List the default, built-in and external rules, optionally only those whose
+id or description contains the query. No network access is needed: default
+rules are read from the local rule cache, which is filled by any previous scan.`,
+		Example: `# List every rule
+$ bearer rules list
+
+# List the rules about SQL injection
+$ bearer rules list sql
+
+# List the Rails rules for a CWE, including external rules, as JSON
+$ bearer rules list --framework rails --cwe 89 --external-rule-dir ./rules --format json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesListFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			setLogLevel(cmd)
+
+			options, err := RulesListFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			definitions, err := settings.LoadRuleDocumentation(options.RuleListOptions.RuleListExternalRuleDir)
+			if err != nil {
+				return err
+			}
+
+			filter := rulelist.Filter{
+				Languages:  options.RuleListOptions.RuleListLanguages,
+				Severities: options.RuleListOptions.RuleListSeverities,
+				CWEIDs:     options.RuleListOptions.RuleListCWEIDs,
+				Frameworks: options.RuleListOptions.RuleListFrameworks,
+			}
+			if len(args) == 1 {
+				filter.Query = args[0]
+			}
+
+			rules := rulelist.List(definitions, filter)
+
+			if options.RuleListOptions.RuleListFormat == flag.RuleListFormatJSON {
+				content, err := rulelist.JSON(rules)
+				if err != nil {
+					return err
+				}
+
+				cmd.Print(content)
+				return nil
+			}
+
+			cmd.Print(rulelist.Table(rules))
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesListFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesListFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func newRulesSearchCommand() *cobra.Command {
+	var RulesSearchFlags = flag.Flags{
+		flag.RulePackFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Search the community rule pack index",
+		Example: `# List every rule pack in the index
+$ bearer rules search
+
+# Search for rule packs by name, description, language or tag
+$ bearer rules search django`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesSearchFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			if len(args) > 1 {
+				return cmd.Help()
+			}
+
+			setLogLevel(cmd)
+
+			options, err := RulesSearchFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			index, err := rulepack.FetchIndex(options.RulePackOptions.IndexURL, options.GeneralOptions.Offline)
+			if err != nil {
+				return fmt.Errorf("error loading rule pack index %s: %w", options.RulePackOptions.IndexURL, err)
+			}
+
+			query := ""
+			if len(args) == 1 {
+				query = args[0]
+			}
+
+			packs := index.Search(query)
+			if len(packs) == 0 {
+				cmd.Printf("No rule packs found matching '%s'\n", query)
+				return nil
+			}
+
+			for _, pack := range packs {
+				version := ""
+				if latest := pack.LatestVersion(); latest != nil {
+					version = "@" + latest.Version
+				}
+
+				cmd.Printf("%s%s\n", pack.Name, version)
+				if pack.Description != "" {
+					cmd.Printf("  %s\n", pack.Description)
+				}
+				if len(pack.Languages) != 0 {
+					cmd.Printf("  Languages: %s\n", strings.Join(pack.Languages, ", "))
+				}
+				if pack.Author != "" {
+					cmd.Printf("  Author: %s\n", pack.Author)
+				}
+				cmd.Print("\n")
+			}
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesSearchFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesSearchFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func newRulesInstallCommand() *cobra.Command {
+	var RulesInstallFlags = flag.Flags{
+		flag.RulePackFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "install <pack>[@version]",
+		Short: "Install a community rule pack",
+		Example: `# Install the latest version of a rule pack
+$ bearer rules install <pack>
+
+# Install a specific version, then use it in a scan
+$ bearer rules install <pack>@1.2.0
+$ bearer scan . --external-rule-dir .bearer/rules`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesInstallFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			setLogLevel(cmd)
+
+			options, err := RulesInstallFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			index, err := rulepack.FetchIndex(options.RulePackOptions.IndexURL, options.GeneralOptions.Offline)
+			if err != nil {
+				return fmt.Errorf("error loading rule pack index %s: %w", options.RulePackOptions.IndexURL, err)
+			}
+
+			name, version, _ := strings.Cut(args[0], "@")
+			pack, packVersion, err := index.Find(name, version)
+			if err != nil {
+				return err
+			}
+
+			provenance, err := rulepack.Install(
+				pack,
+				packVersion,
+				options.RulePackOptions.IndexURL,
+				options.RulePackOptions.RulesDir,
+				options.GeneralOptions.Offline,
+			)
+			if err != nil {
+				return fmt.Errorf("error installing rule pack %s@%s: %w", pack.Name, packVersion.Version, err)
+			}
+
+			cmd.Printf(
+				"Installed %s@%s (%d rule files) into %s\n",
+				provenance.Name,
+				provenance.Version,
+				len(provenance.Files),
+				options.RulePackOptions.RulesDir,
+			)
+			cmd.Printf("Use --external-rule-dir %s to include its rules in a scan\n", options.RulePackOptions.RulesDir)
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesInstallFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesInstallFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func newRulesNewCommand() *cobra.Command {
+	var RulesNewFlags = flag.Flags{
+		flag.RuleNewFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "new [rules-dir]",
+		Short: "Create a rule and its test fixture",
+		Long: fmt.Sprintf(`Create a rule file with a pattern stub for the language, and a fixture in
+the testdata directory annotated so that bearer rules test passes. The id,
+language and description are prompted for when not given as flags.
+Supported languages: %s.`, strings.Join(rulenew.Languages(), ", ")),
+		Example: `# Create a rule in the current directory, answering prompts
+$ bearer rules new
+
+# Create a Python rule in a rules directory, then test it
+$ bearer rules new ./rules --id acme:python_insecure_call --language python --severity high --cwe 78
+$ bearer rules test ./rules`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesNewFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			setLogLevel(cmd)
+
+			options, err := RulesNewFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			rulesDir := "."
+			if len(args) == 1 {
+				rulesDir = args[0]
+			}
+
+			newOptions := options.RuleNewOptions
+			reader := bufio.NewReader(cmd.InOrStdin())
+			prompt := func(value *string, label string) {
+				if *value != "" {
+					return
+				}
+
+				cmd.Printf("%s: ", label)
+				input, _ := reader.ReadString('\n')
+				*value = strings.TrimSpace(input)
+			}
+
+			prompt(&newOptions.RuleNewID, "Rule id (eg. acme:ruby_insecure_call)")
+			prompt(&newOptions.RuleNewLanguage, fmt.Sprintf("Language (%s)", strings.Join(rulenew.Languages(), ", ")))
+			prompt(&newOptions.RuleNewDescription, "Description")
+
+			cmd.SilenceUsage = true
+
+			filePaths, err := rulenew.Generate(rulesDir, rulenew.Options{
+				ID:          newOptions.RuleNewID,
+				Language:    newOptions.RuleNewLanguage,
+				Severity:    newOptions.RuleNewSeverity,
+				Description: newOptions.RuleNewDescription,
+				CWEIDs:      newOptions.RuleNewCWEIDs,
+			})
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Created rule %s\n", newOptions.RuleNewID)
+			for _, filePath := range filePaths {
+				cmd.Printf("  %s\n", filePath)
+			}
+			cmd.Printf("Edit the pattern and the fixture, then run bearer rules test %s\n", rulesDir)
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesNewFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesNewFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func newRulesImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "import [subcommand]",
+		Short:         "Convert rules from other tools into Bearer rules",
+		Args:          cobra.NoArgs,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+
+	cmd.AddCommand(newRulesImportSemgrepCommand())
+
+	return cmd
+}
+
+func newRulesImportSemgrepCommand() *cobra.Command {
+	var RulesImportFlags = flag.Flags{
+		flag.RuleImportFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "semgrep <semgrep-rules> [rules-dir]",
+		Short: "Convert Semgrep rules into Bearer rules",
+		Long: `Convert the Semgrep rules of a YAML file, or of the YAML files of a directory,
+into Bearer rules written to the rules directory. Rules using pattern,
+pattern-either, pattern-not and metavariable-regex are converted, along with
+their severity, message and CWE, OWASP and confidence metadata. Rules using
+other constructs are skipped and reported. Existing files are never
+overwritten.`,
+		Example: `# Convert Semgrep rules into the current directory
+$ bearer rules import semgrep ./semgrep-rules
+
+# Convert Semgrep rules into a namespaced rule pack, then test them
+$ bearer rules import semgrep ./semgrep-rules ./rules --namespace acme
+$ bearer rules test ./rules`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesImportFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			setLogLevel(cmd)
+
+			options, err := RulesImportFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			rulesDir := "."
+			if len(args) == 2 {
+				rulesDir = args[1]
+			}
+
+			cmd.SilenceUsage = true
+
+			report, err := ruleimport.ImportSemgrep(args[0], rulesDir, options.RuleImportNamespace)
+			if err != nil {
+				return err
+			}
+
+			cmd.Print(report.String())
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesImportFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesImportFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func newRulesPushCommand() *cobra.Command {
+	var RulesPushFlags = flag.Flags{
+		flag.RulePackFlagGroup,
+		flag.RulePushFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "push <registry/repository:tag>",
+		Short: "Push a rule pack to an OCI registry",
+		Example: `# Push the rules of a directory to a registry
+$ bearer rules push ghcr.io/org/rules:v3 --rules-dir ./rules
+
+# Sign the rule pack with an ed25519 private key
+$ bearer rules push ghcr.io/org/rules:v3 --rules-dir ./rules --signing-key key.pem`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesPushFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			setLogLevel(cmd)
+
+			options, err := RulesPushFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			if options.GeneralOptions.Offline {
+				return errors.New("rule packs can't be pushed to a registry in offline mode")
+			}
+
+			reference, err := rulepack.ParseOCIReference(args[0])
+			if err != nil {
+				return err
+			}
+
+			var signingKey ed25519.PrivateKey
+			if options.RulePushOptions.SigningKey != "" {
+				if signingKey, err = rulepack.LoadSigningKey(options.RulePushOptions.SigningKey); err != nil {
+					return err
+				}
+			}
+
+			digest, err := rulepack.Push(reference, options.RulePackOptions.RulesDir, signingKey)
+			if err != nil {
+				return fmt.Errorf("error pushing rule pack %s: %w", reference, err)
+			}
+
+			cmd.Printf("Pushed %s to %s@%s\n", options.RulePackOptions.RulesDir, reference, digest)
+			if signingKey == nil {
+				cmd.Printf("The rule pack is not signed; use --signing-key to sign it\n")
+			}
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesPushFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesPushFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func newRulesPullCommand() *cobra.Command {
+	var RulesPullFlags = flag.Flags{
+		flag.RulePackFlagGroup,
+		flag.RulePullFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "pull <registry/repository:tag>",
+		Short: "Pull a rule pack from an OCI registry",
+		Example: `# Pull a rule pack, then use it in a scan
+$ bearer rules pull ghcr.io/org/rules:v3
+$ bearer scan . --external-rule-dir .bearer/rules
+
+# Verify the rule pack was signed with the matching private key
+$ bearer rules pull ghcr.io/org/rules:v3 --public-key key.pub.pem`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesPullFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			setLogLevel(cmd)
+
+			options, err := RulesPullFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			reference, err := rulepack.ParseOCIReference(args[0])
+			if err != nil {
+				return err
+			}
+
+			var publicKey ed25519.PublicKey
+			if options.RulePullOptions.PublicKey != "" {
+				if publicKey, err = rulepack.LoadPublicKey(options.RulePullOptions.PublicKey); err != nil {
+					return err
+				}
+			}
+
+			provenance, err := rulepack.Pull(
+				reference,
+				filepath.Join(options.RulePackOptions.RulesDir, reference.Name()),
+				publicKey,
+				options.GeneralOptions.Offline,
+			)
+			if err != nil {
+				return fmt.Errorf("error pulling rule pack %s: %w", reference, err)
+			}
+
+			cmd.Printf(
+				"Pulled %s (%d rule files) into %s\n",
+				reference,
+				len(provenance.Files),
+				options.RulePackOptions.RulesDir,
+			)
+			cmd.Printf("Use --external-rule-dir %s to include its rules in a scan\n", options.RulePackOptions.RulesDir)
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesPullFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesPullFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func newRulesLockCommand() *cobra.Command {
+	var RulesLockFlags = flag.Flags{
+		flag.RuleLockFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Record the digests of external rules in a lockfile",
+		Long: `Record the version and digest of every external rule directory, and of the
+rule packs installed in them, in a lockfile. When the lockfile exists, scans
+fail if their external rules don't match it.`,
+		Example: `# Lock the external rules given in the configuration file
+$ bearer rules lock
+
+# Lock a pinned git repository and an OCI rule pack
+$ bearer rules lock --external-rule-dir git::https://github.com/org/rules?ref=v1 \
+  --external-rule-dir oci://ghcr.io/org/rules:v3`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesLockFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			setLogLevel(cmd)
+
+			options, err := RulesLockFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			lock, err := settings.LockExternalRules(
+				options.RuleLockOptions.RuleLockExternalRuleDir,
+				options.RuleLockOptions.RuleLockPublicKey,
+				options.GeneralOptions.Offline,
+			)
+			if err != nil {
+				return err
+			}
+
+			if err := lock.Write(options.RuleLockOptions.RuleLockLockfile); err != nil {
+				return err
+			}
+
+			sources := "sources"
+			if len(lock.RuleSources) == 1 {
+				sources = "source"
+			}
+
+			cmd.Printf(
+				"Locked %d external rule %s in %s\n",
+				len(lock.RuleSources),
+				sources,
+				options.RuleLockOptions.RuleLockLockfile,
+			)
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesLockFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesLockFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func newRulesTestCommand() *cobra.Command {
+	var RulesTestFlags = flag.Flags{
+		flag.RuleTestFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "test [rules-dir]",
+		Short: "Test rules against their annotated fixtures",
+		Long: `Scan the files within the testdata directories of a rules directory with
+its rules, and report for each rule whether its findings match the annotations
+of the fixtures. A "ruleid: <rule_id>" comment marks the next line as one the
+rule must report a finding on, and an "ok: <rule_id>" comment as one it must not.`,
+		Example: `# Test the rules of the current directory
+$ bearer rules test
+
+# Test the rules of a rule pack
+$ bearer rules test ./rules
+
+# Test the rules again whenever they or their fixtures change
+$ bearer rules test ./rules --watch`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesTestFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			setLogLevel(cmd)
+
+			options, err := RulesTestFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			rulesDir := "."
+			if len(args) == 1 {
+				rulesDir = args[0]
+			}
+
+			if options.RuleTestOptions.Watch {
+				cmd.SilenceUsage = true
+				return watchRuleTests(cmd, rulesDir, options.RuleTestOptions.WatchInterval)
+			}
+
+			config, err := standaloneScanConfig([]string{rulesDir})
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			report, err := ruletest.Run(cmd.Context(), config, rulesDir)
+			if err != nil {
+				return err
+			}
+
+			cmd.Print(report.String())
+
+			if report.Failed() {
+				return ErrRuleTestsFailed
+			}
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesTestFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesTestFlags.Usages(cmd)))
+
+	return cmd
+}
+
+// watchRuleTests runs the rule tests of the directory whenever its rule files
+// or fixtures change, until interrupted
+func watchRuleTests(cmd *cobra.Command, rulesDir string, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	watcher := ruletest.NewWatcher(rulesDir, func(ruleIDs []string) (settings.Config, error) {
+		return standaloneScanConfig([]string{rulesDir}, ruleIDs...)
+	})
+
+	return watcher.Watch(ctx, interval, func(report *ruletest.Report, err error) {
+		if err != nil {
+			cmd.PrintErrf("Error running rule tests: %s\n", err)
+		} else {
+			cmd.Print(report.String())
+		}
+
+		cmd.Printf("\nWatching %s for changes, press Ctrl+C to stop\n\n", rulesDir)
+	})
+}
+
+func newRulesLintCommand() *cobra.Command {
+	var RulesLintFlags = flag.Flags{
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "lint [rules-dir]",
+		Short: "Check rule files for mistakes",
+		Long: `Check the rule files of a directory: validate them against the rule schema,
+compile their patterns for the declared languages, and report unreachable
+patterns and missing metadata. Errors make the command exit with a non-zero
+status; missing documentation is reported as a warning.`,
+		Example: `# Check the rules of the current directory
+$ bearer rules lint
+
+# Check the rules of a rule pack
+$ bearer rules lint ./rules`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := RulesLintFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			setLogLevel(cmd)
+
+			options, err := RulesLintFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			rulesDir := "."
+			if len(args) == 1 {
+				rulesDir = args[0]
+			}
+
+			config, err := standaloneScanConfig(nil)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			schema, err := loadLintSchema(options.GeneralOptions.Offline)
+			if err != nil {
+				cmd.PrintErrf("Schema validation skipped: %s\n", err)
+			}
+
+			report, err := rulelint.Run(config, rulesDir, schema)
+			if err != nil {
+				return err
+			}
+
+			cmd.Print(report.String())
+
+			if report.Failed() {
+				return ErrRuleLintFailed
+			}
+
+			return nil
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	RulesLintFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, RulesLintFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func loadLintSchema(offline bool) (*gojsonschema.Schema, error) {
+	if offline {
+		return nil, errors.New("the schema can't be downloaded in offline mode")
+	}
+
+	schema, err := settings.LoadRuleSchema()
+	if err != nil {
+		return nil, fmt.Errorf("could not load the schema from %s: %w", settings.SCHEMA_URL, err)
+	}
+
+	return schema, nil
+}
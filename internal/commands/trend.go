@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/history"
+	"github.com/bearer/bearer/internal/util/output"
+)
+
+func NewTrendCommand() *cobra.Command {
+	var TrendFlags = flag.Flags{
+		flag.TrendFlagGroup,
+		flag.GeneralFlagGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "trend <history-file>",
+		Short: "Show the trend of findings across recorded scans",
+		Example: `# Record the scan history and show the trend
+$ bearer scan . --history-file bearer-history.jsonl
+$ bearer trend bearer-history.jsonl
+
+# Show the trend of the last 10 scans as Markdown
+$ bearer trend bearer-history.jsonl --last 10 --format markdown`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := TrendFlags.Bind(cmd); err != nil {
+				return fmt.Errorf("flag bind error: %w", err)
+			}
+
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			setLogLevel(cmd)
+
+			options, err := TrendFlags.ToOptions(args)
+			if err != nil {
+				return fmt.Errorf("flag error: %s", err)
+			}
+
+			entries, err := history.Read(args[0], options.GeneralOptions.Offline)
+			if err != nil {
+				return fmt.Errorf("error reading history file %s: %w", args[0], err)
+			}
+
+			if last := options.TrendOptions.TrendLast; last > 0 && len(entries) > last {
+				entries = entries[len(entries)-last:]
+			}
+
+			trend := history.BuildTrend(entries)
+
+			var content string
+			switch options.TrendOptions.TrendFormat {
+			case flag.TrendFormatMarkdown:
+				content = trend.Markdown()
+			default:
+				content, err = output.ReportJSON(trend)
+				if err != nil {
+					return err
+				}
+			}
+
+			writer := cmd.OutOrStdout()
+			if options.TrendOptions.TrendOutput != "" {
+				file, err := os.Create(options.TrendOptions.TrendOutput)
+				if err != nil {
+					return fmt.Errorf("error creating output file %s: %w", options.TrendOptions.TrendOutput, err)
+				}
+				defer file.Close()
+
+				writer = file
+			}
+
+			_, err = fmt.Fprintln(writer, content)
+			return err
+		},
+		SilenceErrors: false,
+		SilenceUsage:  false,
+	}
+	TrendFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(scanTemplate, TrendFlags.Usages(cmd)))
+
+	return cmd
+}
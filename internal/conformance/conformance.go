@@ -0,0 +1,344 @@
+package conformance
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/internal/commands/process/filelist/files"
+	"github.com/bearer/bearer/internal/commands/process/orchestrator/work"
+	"github.com/bearer/bearer/internal/commands/process/orchestrator/worker"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/output"
+	"github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// The suite holds a directory per scenario, with a directory per language
+// inside it. Each of those holds a `rule.yml` matching the scenario in that
+// language, and source files marking the lines the rule must find with a
+// `bearer:expected` comment.
+//
+//go:embed testdata
+var suiteDir embed.FS
+
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusMissing = "missing"
+
+	ruleFilename = "rule.yml"
+)
+
+type Scenario struct {
+	ID          string
+	Description string
+}
+
+// Scenarios are the behaviours every language analyzer should support
+var Scenarios = []Scenario{
+	{ID: "log_leak", Description: "Sensitive data written to a logger"},
+	{ID: "third_party_send", Description: "Sensitive data sent to a third-party library"},
+	{ID: "insecure_transport", Description: "Request made over an insecure connection"},
+	{ID: "secret_literal", Description: "Secret hard-coded as a string literal"},
+}
+
+// Languages are the languages with an analyzer
+var Languages = []string{"c", "go", "java", "javascript", "kotlin", "php", "python", "ruby", "rust", "shell"}
+
+type Report struct {
+	Languages []LanguageResult `json:"languages" yaml:"languages"`
+}
+
+type LanguageResult struct {
+	Language  string           `json:"language" yaml:"language"`
+	Passed    int              `json:"passed" yaml:"passed"`
+	Total     int              `json:"total" yaml:"total"`
+	Scenarios []ScenarioResult `json:"scenarios" yaml:"scenarios"`
+}
+
+type ScenarioResult struct {
+	Scenario string `json:"scenario" yaml:"scenario"`
+	Status   string `json:"status" yaml:"status"`
+	// locations, as `filename:line`, marked as expected but not found
+	MissingFindings []string `json:"missing_findings,omitempty" yaml:"missing_findings,omitempty"`
+	// locations found but not marked as expected
+	UnexpectedFindings []string `json:"unexpected_findings,omitempty" yaml:"unexpected_findings,omitempty"`
+}
+
+// Run scans the fixtures of the given languages, or of every language when
+// none are given, and reports which scenarios each language passes. The
+// rules of the suite replace the rules of the config.
+func Run(ctx context.Context, config settings.Config, languages []string) (*Report, error) {
+	if len(languages) == 0 {
+		languages = Languages
+	}
+
+	suite, err := fs.Sub(suiteDir, "testdata")
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "bearer-conformance")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conformance directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := extract(suite, dir); err != nil {
+		return nil, fmt.Errorf("failed to extract conformance suite: %w", err)
+	}
+
+	config.Rules, err = loadRules(suite, languages)
+	if err != nil {
+		return nil, err
+	}
+	config.Scan.Target = dir
+	config.Scan.Scanner = []string{flag.ScannerSAST}
+	config.Scan.Quiet = true
+	config.Report.Report = flag.ReportSecurity
+	config.IgnoredFingerprints = nil
+
+	scanWorker := worker.Worker{}
+	if _, err := scanWorker.Setup(config); err != nil {
+		return nil, fmt.Errorf("failed to setup scan worker: %w", err)
+	}
+
+	report := &Report{}
+	for _, language := range languages {
+		result := LanguageResult{Language: language, Total: len(Scenarios)}
+
+		for _, scenario := range Scenarios {
+			scenarioResult, err := runScenario(ctx, &scanWorker, config, dir, scenario, language)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", language, scenario.ID, err)
+			}
+
+			if scenarioResult.Status == StatusPassed {
+				result.Passed++
+			}
+
+			result.Scenarios = append(result.Scenarios, scenarioResult)
+		}
+
+		report.Languages = append(report.Languages, result)
+	}
+
+	return report, nil
+}
+
+// Failed tells whether any language doesn't pass every scenario
+func (report *Report) Failed() bool {
+	for _, language := range report.Languages {
+		if language.Passed != language.Total {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (report *Report) String() string {
+	var builder strings.Builder
+	builder.WriteString("Language analyzer conformance\n\n")
+
+	for _, language := range report.Languages {
+		fmt.Fprintf(
+			&builder,
+			"%-12s %d/%d scenarios (%d%%)\n",
+			language.Language,
+			language.Passed,
+			language.Total,
+			language.Passed*100/language.Total,
+		)
+
+		for _, scenario := range language.Scenarios {
+			switch scenario.Status {
+			case StatusMissing:
+				fmt.Fprintf(&builder, "  - %s: no fixtures\n", scenario.Scenario)
+			case StatusFailed:
+				var problems []string
+				if len(scenario.MissingFindings) != 0 {
+					problems = append(problems, "missing findings at "+strings.Join(scenario.MissingFindings, ", "))
+				}
+				if len(scenario.UnexpectedFindings) != 0 {
+					problems = append(problems, "unexpected findings at "+strings.Join(scenario.UnexpectedFindings, ", "))
+				}
+
+				fmt.Fprintf(&builder, "  - %s: %s\n", scenario.Scenario, strings.Join(problems, "; "))
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+func extract(suite fs.FS, dir string) error {
+	return fs.WalkDir(suite, ".", func(filePath string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(filePath))
+		if dirEntry.IsDir() {
+			return os.MkdirAll(target, 0700)
+		}
+
+		content, err := fs.ReadFile(suite, filePath)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(target, content, 0600)
+	})
+}
+
+func loadRules(suite fs.FS, languages []string) (map[string]*settings.Rule, error) {
+	definitions := make(map[string]settings.RuleDefinition)
+	enabledRules := make(map[string]struct{})
+
+	for _, scenario := range Scenarios {
+		for _, language := range languages {
+			content, err := fs.ReadFile(suite, path.Join(scenario.ID, language, ruleFilename))
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			var definition settings.RuleDefinition
+			if err := yaml.Unmarshal(content, &definition); err != nil {
+				return nil, fmt.Errorf("failed to parse %s %s rule: %w", language, scenario.ID, err)
+			}
+
+			if definition.Metadata == nil || definition.Metadata.ID != ruleID(language, scenario) {
+				return nil, fmt.Errorf("%s %s rule must have the id %s", language, scenario.ID, ruleID(language, scenario))
+			}
+
+			definitions[definition.Metadata.ID] = definition
+			enabledRules[definition.Metadata.ID] = struct{}{}
+		}
+	}
+
+	return settings.BuildRules(definitions, enabledRules), nil
+}
+
+func ruleID(language string, scenario Scenario) string {
+	return language + "_conformance_" + scenario.ID
+}
+
+func runScenario(
+	ctx context.Context,
+	scanWorker *worker.Worker,
+	config settings.Config,
+	dir string,
+	scenario Scenario,
+	language string,
+) (ScenarioResult, error) {
+	result := ScenarioResult{Scenario: scenario.ID, Status: StatusMissing}
+
+	caseDir := path.Join(scenario.ID, language)
+	entries, err := os.ReadDir(filepath.Join(dir, filepath.FromSlash(caseDir)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if _, ok := config.Rules[ruleID(language, scenario)]; !ok {
+		return result, nil
+	}
+
+	expected := set.New[string]()
+	found := set.New[string]()
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == ruleFilename {
+			continue
+		}
+
+		if err := scanFile(ctx, scanWorker, config, dir, path.Join(caseDir, entry.Name()), ruleID(language, scenario), expected, found); err != nil {
+			return result, err
+		}
+	}
+
+	for _, location := range expected.Items() {
+		if !found.Has(location) {
+			result.MissingFindings = append(result.MissingFindings, location)
+		}
+	}
+	for _, location := range found.Items() {
+		if !expected.Has(location) {
+			result.UnexpectedFindings = append(result.UnexpectedFindings, location)
+		}
+	}
+	slices.Sort(result.MissingFindings)
+	slices.Sort(result.UnexpectedFindings)
+
+	result.Status = StatusPassed
+	if len(expected) == 0 || len(result.MissingFindings) != 0 || len(result.UnexpectedFindings) != 0 {
+		result.Status = StatusFailed
+	}
+
+	return result, nil
+}
+
+func scanFile(
+	ctx context.Context,
+	scanWorker *worker.Worker,
+	config settings.Config,
+	dir string,
+	filePath string,
+	ruleID string,
+	expected set.Set[string],
+	found set.Set[string],
+) error {
+	reportFile, err := os.CreateTemp("", "conformance-report.jsonl")
+	if err != nil {
+		return err
+	}
+	reportFile.Close()
+	defer os.Remove(reportFile.Name())
+
+	_, err = scanWorker.Scan(ctx, work.ProcessRequest{
+		File:       files.File{FilePath: filePath},
+		ReportPath: reportFile.Name(),
+		Repository: work.Repository{Dir: dir},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", filePath, err)
+	}
+
+	reportData, err := output.GetData(types.Report{Path: reportFile.Name(), HasFiles: true}, config, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get findings for %s: %w", filePath, err)
+	}
+
+	filename := path.Base(filePath)
+	for _, expectedDetection := range reportData.ExpectedDetections {
+		if expectedDetection.RuleID == ruleID {
+			expected.Add(fmt.Sprintf("%s:%d", filename, expectedDetection.Location.Start))
+		}
+	}
+
+	for _, findings := range reportData.FindingsBySeverity {
+		for _, finding := range findings {
+			if finding.Rule != nil && finding.Rule.Id == ruleID {
+				found.Add(fmt.Sprintf("%s:%d", filename, finding.LineNumber))
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package conformance_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/conformance"
+	"github.com/bearer/bearer/internal/version_check"
+)
+
+func TestSuite(t *testing.T) {
+	err := commands.ScanFlags.BindForConfigInit(commands.NewScanCommand())
+	if err != nil {
+		t.Fatalf("failed to bind flags: %s", err)
+	}
+
+	options, err := commands.ScanFlags.ToOptions([]string{})
+	if err != nil {
+		t.Fatalf("failed to generate default flags: %s", err)
+	}
+	options.DisableDefaultRules = true
+
+	config, err := settings.FromOptions(options, &version_check.VersionMeta{
+		Rules: version_check.RuleVersionMeta{
+			Packages: make(map[string]string),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate default scan settings: %s", err)
+	}
+
+	report, err := conformance.Run(context.Background(), config, nil)
+	if err != nil {
+		t.Fatalf("failed to run the suite: %s", err)
+	}
+
+	assert.Len(t, report.Languages, len(conformance.Languages))
+	for _, language := range report.Languages {
+		for _, scenario := range language.Scenarios {
+			assert.Equal(
+				t,
+				conformance.StatusPassed,
+				scenario.Status,
+				"%s %s: missing %v, unexpected %v",
+				language.Language,
+				scenario.Scenario,
+				scenario.MissingFindings,
+				scenario.UnexpectedFindings,
+			)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+void fetch(CURL *curl) {
+  // bearer:expected c_conformance_insecure_transport
+  curl_easy_setopt(curl, CURLOPT_URL, "http://api.example.com/users");
+  curl_easy_setopt(curl, CURLOPT_URL, "https://api.example.com/users");
+  curl_easy_setopt(curl, CURLOPT_URL, "http://localhost:3000/users");
+}
@@ -0,0 +1,10 @@
+package main
+
+import "net/http"
+
+func fetch() {
+	// bearer:expected go_conformance_insecure_transport
+	http.Get("http://api.example.com/users")
+	http.Get("https://api.example.com/users")
+	http.Get("http://localhost:3000/users")
+}
@@ -0,0 +1,10 @@
+import java.net.URL;
+
+public class Main {
+  public void fetch() throws Exception {
+    // bearer:expected java_conformance_insecure_transport
+    URL insecure = new URL("http://api.example.com/users");
+    URL secure = new URL("https://api.example.com/users");
+    URL local = new URL("http://localhost:3000/users");
+  }
+}
@@ -0,0 +1,6 @@
+function load() {
+  // bearer:expected javascript_conformance_insecure_transport
+  fetch("http://api.example.com/users")
+  fetch("https://api.example.com/users")
+  fetch("http://localhost:3000/users")
+}
@@ -0,0 +1,8 @@
+import java.net.URL
+
+fun fetch() {
+    // bearer:expected kotlin_conformance_insecure_transport
+    val insecure = URL("http://api.example.com/users")
+    val secure = URL("https://api.example.com/users")
+    val local = URL("http://localhost:3000/users")
+}
@@ -0,0 +1,8 @@
+<?php
+
+function load() {
+  // bearer:expected php_conformance_insecure_transport
+  curl_init("http://api.example.com/users");
+  curl_init("https://api.example.com/users");
+  curl_init("http://localhost:3000/users");
+}
@@ -0,0 +1,8 @@
+import requests
+
+
+def load():
+    # bearer:expected python_conformance_insecure_transport
+    requests.get("http://api.example.com/users")
+    requests.get("https://api.example.com/users")
+    requests.get("http://localhost:3000/users")
@@ -0,0 +1,6 @@
+def load
+  # bearer:expected ruby_conformance_insecure_transport
+  HTTParty.get("http://api.example.com/users")
+  HTTParty.get("https://api.example.com/users")
+  HTTParty.get("http://localhost:3000/users")
+end
@@ -0,0 +1,6 @@
+async fn fetch(client: &reqwest::Client) {
+    // bearer:expected rust_conformance_insecure_transport
+    let insecure = client.get("http://api.example.com/users");
+    let secure = client.get("https://api.example.com/users");
+    let local = client.get("http://localhost:3000/users");
+}
@@ -0,0 +1,6 @@
+fetch() {
+  # bearer:expected shell_conformance_insecure_transport
+  curl -s http://api.example.com/users
+  curl -s https://api.example.com/users
+  curl -s http://localhost:3000/users
+}
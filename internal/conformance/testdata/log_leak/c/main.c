@@ -0,0 +1,5 @@
+void notify(struct user *user) {
+  // bearer:expected c_conformance_log_leak
+  syslog(LOG_INFO, "sending to %s", user->email);
+  syslog(LOG_INFO, "notification sent");
+}
@@ -0,0 +1,13 @@
+package main
+
+import "log"
+
+type User struct {
+	Email string
+}
+
+func notify(user User) {
+	// bearer:expected go_conformance_log_leak
+	log.Printf("notified %s", user.Email)
+	log.Printf("notification sent")
+}
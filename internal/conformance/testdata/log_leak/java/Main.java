@@ -0,0 +1,7 @@
+public class Main {
+  public void notify(User user) {
+    // bearer:expected java_conformance_log_leak
+    logger.info(user.email);
+    logger.info("notification sent");
+  }
+}
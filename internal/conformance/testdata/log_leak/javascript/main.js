@@ -0,0 +1,5 @@
+function notify(user) {
+  // bearer:expected javascript_conformance_log_leak
+  console.log("notified", user.email)
+  console.log("notification sent")
+}
@@ -0,0 +1,5 @@
+fun notify(user: User) {
+    // bearer:expected kotlin_conformance_log_leak
+    logger.info(user.email)
+    logger.info("notification sent")
+}
@@ -0,0 +1,7 @@
+<?php
+
+function notify($user) {
+  // bearer:expected php_conformance_log_leak
+  error_log($user->email);
+  error_log("notification sent");
+}
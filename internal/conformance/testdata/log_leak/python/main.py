@@ -0,0 +1,7 @@
+import logging
+
+
+def notify(user):
+    # bearer:expected python_conformance_log_leak
+    logging.info(user.email)
+    logging.info("notification sent")
@@ -0,0 +1,5 @@
+def notify(user)
+  # bearer:expected ruby_conformance_log_leak
+  logger.info(user.email)
+  logger.info("notification sent")
+end
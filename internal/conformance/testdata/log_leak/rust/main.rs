@@ -0,0 +1,5 @@
+fn notify(user: User) {
+    // bearer:expected rust_conformance_log_leak
+    info!("sending to {}", user.email);
+    info!("notification sent");
+}
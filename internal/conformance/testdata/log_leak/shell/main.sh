@@ -0,0 +1,5 @@
+notify() {
+  # bearer:expected shell_conformance_log_leak
+  logger "sending to $CUSTOMER_EMAIL"
+  logger "notification sent"
+}
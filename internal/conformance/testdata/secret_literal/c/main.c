@@ -0,0 +1,6 @@
+void connect() {
+  // bearer:expected c_conformance_secret_literal
+  char *password = "hunter2-but-longer";
+  char *token = getenv("TOKEN");
+  char *username = "admin";
+}
@@ -0,0 +1,10 @@
+package main
+
+import "os"
+
+func connect() {
+	// bearer:expected go_conformance_secret_literal
+	password := "hunter2-but-longer"
+	token := os.Getenv("TOKEN")
+	username := "admin"
+}
@@ -0,0 +1,8 @@
+public class Main {
+  public void connect() {
+    // bearer:expected java_conformance_secret_literal
+    String password = "hunter2-but-longer";
+    String token = System.getenv("TOKEN");
+    String username = "admin";
+  }
+}
@@ -0,0 +1,6 @@
+function connect() {
+  // bearer:expected javascript_conformance_secret_literal
+  const password = "hunter2-but-longer"
+  const token = process.env.TOKEN
+  const username = "admin"
+}
@@ -0,0 +1,6 @@
+fun connect() {
+    // bearer:expected kotlin_conformance_secret_literal
+    val password = "hunter2-but-longer"
+    val token = System.getenv("TOKEN")
+    val username = "admin"
+}
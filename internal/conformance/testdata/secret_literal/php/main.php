@@ -0,0 +1,8 @@
+<?php
+
+function connect() {
+  // bearer:expected php_conformance_secret_literal
+  $password = "hunter2-but-longer";
+  $token = getenv("TOKEN");
+  $username = "admin";
+}
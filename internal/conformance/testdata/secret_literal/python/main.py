@@ -0,0 +1,8 @@
+import os
+
+
+def connect():
+    # bearer:expected python_conformance_secret_literal
+    password = "hunter2-but-longer"
+    token = os.environ["TOKEN"]
+    username = "admin"
@@ -0,0 +1,6 @@
+def connect
+  # bearer:expected ruby_conformance_secret_literal
+  password = "hunter2-but-longer"
+  token = ENV["TOKEN"]
+  username = "admin"
+end
@@ -0,0 +1,6 @@
+fn connect() {
+    // bearer:expected rust_conformance_secret_literal
+    let password = "hunter2-but-longer";
+    let token = std::env::var("TOKEN");
+    let username = "admin";
+}
@@ -0,0 +1,6 @@
+connect() {
+  # bearer:expected shell_conformance_secret_literal
+  PASSWORD="hunter2-but-longer"
+  TOKEN="$(cat /run/secrets/token)"
+  USERNAME="admin"
+}
@@ -0,0 +1,5 @@
+void notify(struct user *user) {
+  // bearer:expected c_conformance_third_party_send
+  sentry_capture_event(sentry_value_new_message_event(SENTRY_LEVEL_INFO, NULL, user->email));
+  sentry_capture_event(sentry_value_new_message_event(SENTRY_LEVEL_INFO, NULL, "notification sent"));
+}
@@ -0,0 +1,13 @@
+package main
+
+import "github.com/getsentry/sentry-go"
+
+type User struct {
+	Email string
+}
+
+func notify(user User) {
+	// bearer:expected go_conformance_third_party_send
+	sentry.CaptureMessage(user.Email)
+	sentry.CaptureMessage("notification sent")
+}
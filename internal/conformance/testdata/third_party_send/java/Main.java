@@ -0,0 +1,9 @@
+import io.sentry.Sentry;
+
+public class Main {
+  public void notify(User user) {
+    // bearer:expected java_conformance_third_party_send
+    Sentry.captureMessage(user.email);
+    Sentry.captureMessage("notification sent");
+  }
+}
@@ -0,0 +1,7 @@
+import * as Sentry from "@sentry/node"
+
+function notify(user) {
+  // bearer:expected javascript_conformance_third_party_send
+  Sentry.captureMessage(user.email)
+  Sentry.captureMessage("notification sent")
+}
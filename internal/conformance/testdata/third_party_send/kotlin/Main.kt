@@ -0,0 +1,7 @@
+import io.sentry.Sentry
+
+fun notify(user: User) {
+    // bearer:expected kotlin_conformance_third_party_send
+    Sentry.captureMessage(user.email)
+    Sentry.captureMessage("notification sent")
+}
@@ -0,0 +1,7 @@
+<?php
+
+function notify($user) {
+  // bearer:expected php_conformance_third_party_send
+  \Sentry\captureMessage($user->email);
+  \Sentry\captureMessage("notification sent");
+}
@@ -0,0 +1,7 @@
+import sentry_sdk
+
+
+def notify(user):
+    # bearer:expected python_conformance_third_party_send
+    sentry_sdk.capture_message(user.email)
+    sentry_sdk.capture_message("notification sent")
@@ -0,0 +1,5 @@
+def notify(user)
+  # bearer:expected ruby_conformance_third_party_send
+  Sentry.capture_message(user.email)
+  Sentry.capture_message("notification sent")
+end
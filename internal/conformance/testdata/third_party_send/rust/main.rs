@@ -0,0 +1,5 @@
+fn notify(user: User) {
+    // bearer:expected rust_conformance_third_party_send
+    sentry::capture_message(&user.email, sentry::Level::Info);
+    sentry::capture_message("notification sent", sentry::Level::Info);
+}
@@ -0,0 +1,5 @@
+notify() {
+  # bearer:expected shell_conformance_third_party_send
+  sentry-cli send-event -m "$CUSTOMER_EMAIL"
+  sentry-cli send-event -m "notification sent"
+}
@@ -0,0 +1,228 @@
+package avro
+
+import (
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/javascript"
+
+	"github.com/bearer/bearer/internal/detectors/types"
+	"github.com/bearer/bearer/internal/parser"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/schema"
+	"github.com/bearer/bearer/internal/util/file"
+	"github.com/bearer/bearer/internal/util/pluralize"
+
+	"github.com/bearer/bearer/internal/parser/nodeid"
+	parserschema "github.com/bearer/bearer/internal/parser/schema"
+	reporttypes "github.com/bearer/bearer/internal/report"
+)
+
+var (
+	// Avro schemas are JSON documents
+	language     = javascript.GetLanguage()
+	objectsQuery = parser.QueryMustCompile(language, `(object) @object`)
+
+	simpleTypes = map[string]string{
+		"int":     schema.SimpleTypeNumber,
+		"long":    schema.SimpleTypeNumber,
+		"float":   schema.SimpleTypeNumber,
+		"double":  schema.SimpleTypeNumber,
+		"string":  schema.SimpleTypeString,
+		"enum":    schema.SimpleTypeString,
+		"bytes":   schema.SimpleTypeBinary,
+		"fixed":   schema.SimpleTypeBinary,
+		"boolean": schema.SimpleTypeBool,
+		"null":    schema.SimpleTypeUnknown,
+	}
+)
+
+type detector struct {
+	idGenerator nodeid.Generator
+}
+
+func New(idGenerator nodeid.Generator) types.Detector {
+	return &detector{
+		idGenerator: idGenerator,
+	}
+}
+
+func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, report reporttypes.Report) (bool, error) {
+	if file.Extension != ".avsc" {
+		return false, nil
+	}
+
+	err := detector.ExtractFromSchema(file, report)
+
+	return true, err
+}
+
+// ExtractFromSchema reports the fields of each record of the schema. Records
+// nested in the types of fields are reported as objects of their own.
+func (detector *detector) ExtractFromSchema(
+	file *file.FileInfo,
+	report reporttypes.Report,
+) error {
+	tree, err := parser.ParseFile(file, file.Path, language)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	uuidHolder := parserschema.NewUUIDHolder()
+
+	return tree.Query(objectsQuery, func(captures parser.Captures) error {
+		record := pairs(captures["object"])
+		if stringValue(record["type"]) != "record" || record["name"] == nil || record["fields"] == nil {
+			return nil
+		}
+
+		objectNode := record["name"]
+		objectName := recordName(stringValue(objectNode))
+
+		fields := record["fields"]
+		for i := 0; i < fields.NamedChildCount(); i++ {
+			field := pairs(fields.Child(i))
+			fieldNode := field["name"]
+			if fieldNode == nil {
+				continue
+			}
+
+			fieldName := stringValue(fieldNode)
+			fieldType, simpleType := convertType(field["type"])
+
+			currentSchema := schema.Schema{
+				ObjectName:           objectName,
+				ObjectUUID:           uuidHolder.Assign(objectNode.ID(), detector.idGenerator),
+				FieldName:            fieldName,
+				FieldUUID:            uuidHolder.Assign(fieldNode.ID(), detector.idGenerator),
+				FieldType:            fieldType,
+				SimpleFieldType:      simpleType,
+				NormalizedObjectName: pluralize.Singular(strings.ToLower(objectName)),
+				NormalizedFieldName:  pluralize.Singular(strings.ToLower(fieldName)),
+			}
+
+			if !report.SchemaGroupIsOpen() {
+				source := objectNode.Source(true)
+				report.SchemaGroupBegin(
+					detectors.DetectorAvro,
+					objectNode,
+					currentSchema,
+					&source,
+					nil,
+				)
+			}
+			source := fieldNode.Source(true)
+			report.SchemaGroupAddItem(
+				fieldNode,
+				currentSchema,
+				&source,
+			)
+		}
+
+		report.SchemaGroupEnd(detector.idGenerator)
+
+		return nil
+	})
+}
+
+// convertType returns the type of a field as written in the schema, along
+// with its simple type. Types are either names, unions written as arrays or
+// complex types written as objects.
+func convertType(node *parser.Node) (string, string) {
+	if node == nil {
+		return "", schema.SimpleTypeUnknown
+	}
+
+	switch node.Type() {
+	case "string":
+		name := stringValue(node)
+		if simpleType, isPrimitive := simpleTypes[name]; isPrimitive {
+			return name, simpleType
+		}
+
+		// a reference to a named type
+		return name, schema.SimpleTypeObject
+	case "array":
+		// optional fields are unions with null
+		var members []*parser.Node
+		for i := 0; i < node.NamedChildCount(); i++ {
+			member := node.Child(i)
+			if stringValue(member) != "null" {
+				members = append(members, member)
+			}
+		}
+
+		if len(members) == 1 {
+			return convertType(members[0])
+		}
+
+		return node.Content(), schema.SimpleTypeUnknown
+	case "object":
+		complexType := pairs(node)
+		typeName := stringValue(complexType["type"])
+
+		if logicalType := stringValue(complexType["logicalType"]); logicalType != "" {
+			if logicalType == "date" || strings.Contains(logicalType, "timestamp") {
+				return logicalType, schema.SimpleTypeDate
+			}
+
+			return logicalType, simpleTypes[typeName]
+		}
+
+		switch typeName {
+		case "record", "enum", "fixed":
+			name := stringValue(complexType["name"])
+			if simpleType, ok := simpleTypes[typeName]; ok {
+				return name, simpleType
+			}
+
+			return name, schema.SimpleTypeObject
+		case "array", "map":
+			return typeName, schema.SimpleTypeObject
+		}
+
+		return convertType(complexType["type"])
+	}
+
+	return node.Content(), schema.SimpleTypeUnknown
+}
+
+// pairs returns the values of the object node by key
+func pairs(node *parser.Node) map[string]*parser.Node {
+	result := make(map[string]*parser.Node)
+	if node == nil || node.Type() != "object" {
+		return result
+	}
+
+	for i := 0; i < node.NamedChildCount(); i++ {
+		pair := node.Child(i)
+		if pair.Type() != "pair" {
+			continue
+		}
+
+		key := pair.ChildByFieldName("key")
+		value := pair.ChildByFieldName("value")
+		if key != nil && value != nil {
+			result[stringValue(key)] = value
+		}
+	}
+
+	return result
+}
+
+func stringValue(node *parser.Node) string {
+	if node == nil || node.Type() != "string" {
+		return ""
+	}
+
+	return strings.Trim(node.Content(), `"'`)
+}
+
+// recordName returns the name of a record without its namespace
+func recordName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
@@ -10,11 +10,13 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/detectors/avro"
 	"github.com/bearer/bearer/internal/detectors/beego"
 	"github.com/bearer/bearer/internal/detectors/csharp"
 	"github.com/bearer/bearer/internal/detectors/custom"
 	"github.com/bearer/bearer/internal/detectors/dependencies"
 	"github.com/bearer/bearer/internal/detectors/django"
+	"github.com/bearer/bearer/internal/detectors/dockerfile"
 	"github.com/bearer/bearer/internal/detectors/dotnet"
 	"github.com/bearer/bearer/internal/detectors/envfile"
 	"github.com/bearer/bearer/internal/detectors/gitleaks"
@@ -24,16 +26,21 @@ import (
 	"github.com/bearer/bearer/internal/detectors/ipynb"
 	"github.com/bearer/bearer/internal/detectors/java"
 	"github.com/bearer/bearer/internal/detectors/javascript"
+	"github.com/bearer/bearer/internal/detectors/kotlin"
+	"github.com/bearer/bearer/internal/detectors/kubernetes"
 	"github.com/bearer/bearer/internal/detectors/openapi"
 	"github.com/bearer/bearer/internal/detectors/php"
 	"github.com/bearer/bearer/internal/detectors/proto"
 	"github.com/bearer/bearer/internal/detectors/python"
 	"github.com/bearer/bearer/internal/detectors/rails"
 	"github.com/bearer/bearer/internal/detectors/ruby"
+	"github.com/bearer/bearer/internal/detectors/rust"
+	"github.com/bearer/bearer/internal/detectors/sampledata"
 	"github.com/bearer/bearer/internal/detectors/simple"
 	"github.com/bearer/bearer/internal/detectors/spring"
 	"github.com/bearer/bearer/internal/detectors/sql"
 	"github.com/bearer/bearer/internal/detectors/symfony"
+	"github.com/bearer/bearer/internal/detectors/terraform"
 	"github.com/bearer/bearer/internal/detectors/tsx"
 	"github.com/bearer/bearer/internal/detectors/typescript"
 	"github.com/bearer/bearer/internal/detectors/yamlconfig"
@@ -80,6 +87,15 @@ func Registrations(scanners []string) []InitializedDetector {
 		)
 	}
 
+	if slices.Contains(scanners, "fixtures") {
+		detectors = append(
+			detectors,
+			InitializedDetector{
+				reportdetectors.DetectorSampleData, sampledata.New(),
+			},
+		)
+	}
+
 	if slices.Contains(scanners, "sast") {
 		detectors = append(
 			detectors,
@@ -110,14 +126,20 @@ func Registrations(scanners []string) []InitializedDetector {
 
 				{reportdetectors.DetectorSpring, spring.New()},
 				{reportdetectors.DetectorJava, java.New(&nodeid.UUIDGenerator{})},
+				{reportdetectors.DetectorKotlin, kotlin.New(&nodeid.UUIDGenerator{})},
+				{reportdetectors.DetectorRust, rust.New(&nodeid.UUIDGenerator{})},
 
 				{reportdetectors.DetectorSymfony, symfony.New()},
 				{reportdetectors.DetectorPHP, php.New(&nodeid.UUIDGenerator{})},
 
+				{reportdetectors.DetectorKubernetes, kubernetes.New()},
 				{reportdetectors.DetectorYamlConfig, yamlconfig.New()},
+				{reportdetectors.DetectorTerraform, terraform.New()},
+				{reportdetectors.DetectorDockerfile, dockerfile.New()},
 
 				{reportdetectors.DetectorSQL, sql.New(&nodeid.UUIDGenerator{})},
 				{reportdetectors.DetectorProto, proto.New(&nodeid.UUIDGenerator{})},
+				{reportdetectors.DetectorAvro, avro.New(&nodeid.UUIDGenerator{})},
 				{reportdetectors.DetectorGraphQL, graphql.New(&nodeid.UUIDGenerator{})},
 
 				{reportdetectors.DetectorHTML, html.New(&nodeid.UUIDGenerator{})},
@@ -140,6 +162,7 @@ func Extract(
 	fileStats *stats.FileStats,
 	enabledScanners []string,
 	sastScanner *scanner.Scanner,
+	languageExtensions map[string]string,
 ) error {
 	return ExtractWithDetectors(
 		ctx,
@@ -149,6 +172,7 @@ func Extract(
 		fileStats,
 		Registrations(enabledScanners),
 		sastScanner,
+		languageExtensions,
 	)
 }
 
@@ -160,13 +184,24 @@ func ExtractWithDetectors(
 	fileStats *stats.FileStats,
 	allDetectors []InitializedDetector,
 	sastScanner *scanner.Scanner,
+	languageExtensions map[string]string,
 ) error {
 
 	activeDetectors := make(map[InitializedDetector]activeDetector)
 
+	// fixture files are skipped like other test files, except when looking for
+	// sample data in them
+	includeIgnored := false
+	if file.IsIgnoredFilename(filename) && sampledata.IsFixture(filename) {
+		allDetectors = fixtureDetectors(allDetectors)
+		sastScanner = nil
+		includeIgnored = len(allDetectors) != 0
+	}
+
 	if err := file.IterateFilesList(
 		rootDir,
 		[]string{filename},
+		includeIgnored,
 		func(dir *file.Path) (bool, error) {
 			for _, detector := range allDetectors {
 				active, isActive := activeDetectors[detector]
@@ -200,6 +235,8 @@ func ExtractWithDetectors(
 			}
 			defer recovery()
 
+			file.OverrideLanguage(languageExtensions)
+
 			if err := sastScanner.Scan(ctx, report, fileStats, file); err != nil {
 				log.Debug().Msgf("failed to scan file %s: %s", file.RelativePath, err)
 				report.AddError(file.RelativePath, fmt.Errorf("failed to scan file: %s", err))
@@ -240,6 +277,17 @@ func ExtractWithDetectors(
 	return nil
 }
 
+func fixtureDetectors(allDetectors []InitializedDetector) []InitializedDetector {
+	var result []InitializedDetector
+	for _, detector := range allDetectors {
+		if detector.Type == reportdetectors.DetectorSampleData {
+			result = append(result, detector)
+		}
+	}
+
+	return result
+}
+
 func isParentedBy(rootPath, path string) bool {
 	relativePath, err := filepath.Rel(rootPath, path)
 	if err != nil {
@@ -0,0 +1,416 @@
+package dockerfile
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/bearer/bearer/internal/detectors/internal/credentials"
+	"github.com/bearer/bearer/internal/detectors/types"
+	"github.com/bearer/bearer/internal/parser/interfaces"
+	"github.com/bearer/bearer/internal/report"
+	"github.com/bearer/bearer/internal/report/detections"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
+	"github.com/bearer/bearer/internal/report/infrastructure"
+	reportinterface "github.com/bearer/bearer/internal/report/interfaces"
+	"github.com/bearer/bearer/internal/report/source"
+	"github.com/bearer/bearer/internal/report/values"
+	"github.com/bearer/bearer/internal/util/file"
+)
+
+const (
+	ruleHardcodedSecret = "dockerfile_hardcoded_secret"
+	ruleSensitiveFile   = "dockerfile_sensitive_file_added"
+)
+
+var (
+	// Dockerfile, Dockerfile.prod, api.dockerfile or Containerfile
+	filenamePattern = regexp.MustCompile(`(?i)(^|\.)(docker|container)file($|\.)`)
+
+	// values set from a variable, eg. `$DB_PASSWORD` or `${DB_PASSWORD}`
+	variableReferenceRegex = regexp.MustCompile(`\$[\w{]`)
+
+	// files holding credentials, eg. `.env`, `id_rsa` or `server.key`
+	sensitiveFilenameRegex = regexp.MustCompile(
+		`(?i)^(\.env(\..+)?|id_(rsa|dsa|ecdsa|ed25519)|.*\.(pem|key|p12|pfx|jks|keystore|tfstate)|` +
+			`\.npmrc|\.pypirc|\.netrc|\.pgpass|\.htpasswd|\.git-credentials|\.dockercfg|credentials\.json|` +
+			`\.ssh|\.aws|\.gnupg|\.kube)$`,
+	)
+	sensitivePathRegex = regexp.MustCompile(`(?i)(^|/)(\.aws/credentials|\.docker/config\.json|\.kube/config)$`)
+	// examples of sensitive files, which don't hold real credentials
+	exampleFilenameRegex = regexp.MustCompile(`(?i)\.(example|sample|template|tpl|dist)$`)
+)
+
+type detector struct{}
+
+// instruction is an instruction of a Dockerfile, eg. `ENV RAILS_ENV=production`.
+// The arguments are kept as written, including line continuations.
+type instruction struct {
+	keyword   string
+	arguments string
+	line      int
+	column    int
+}
+
+// word is an argument of an instruction, with quotes and escapes removed
+type word struct {
+	text   string
+	line   int
+	column int
+}
+
+func New() types.Detector {
+	return &detector{}
+}
+
+func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, report report.Report) (bool, error) {
+	if file.Language != "Dockerfile" && !filenamePattern.MatchString(file.Base) {
+		return false, nil
+	}
+
+	content, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return false, err
+	}
+
+	reporter := &reporter{file: file, report: report}
+	for _, instruction := range parseInstructions(string(content)) {
+		reporter.processInstruction(instruction)
+	}
+
+	return true, nil
+}
+
+// parseInstructions splits a Dockerfile into its instructions, joining the
+// lines continued with a backslash. Comments within an instruction are
+// dropped, keeping line numbers.
+func parseInstructions(content string) []instruction {
+	lines := strings.Split(content, "\n")
+
+	var instructions []instruction
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		start := i
+		text := line
+		for continued := isContinued(line); continued && i+1 < len(lines); {
+			i++
+
+			if strings.HasPrefix(strings.TrimSpace(lines[i]), "#") {
+				text += "\n\\"
+				continue
+			}
+
+			text += "\n" + lines[i]
+			continued = isContinued(lines[i])
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		keywordEnd := strings.IndexAny(text[indent:], " \t\r\n")
+		if keywordEnd == -1 {
+			keywordEnd = len(text) - indent
+		}
+		keywordEnd += indent
+
+		instructions = append(instructions, instruction{
+			keyword:   strings.ToUpper(text[indent:keywordEnd]),
+			arguments: text[keywordEnd:],
+			line:      start + 1,
+			column:    keywordEnd + 1,
+		})
+	}
+
+	return instructions
+}
+
+func isContinued(line string) bool {
+	return strings.HasSuffix(strings.TrimRight(line, " \t\r"), `\`)
+}
+
+type reporter struct {
+	file   *file.FileInfo
+	report report.Report
+}
+
+func (reporter *reporter) processInstruction(instruction instruction) {
+	switch instruction.keyword {
+	case "ARG":
+		// ARG VERSION=1.0 TOKEN
+		for _, argument := range instruction.words() {
+			if name, value, hasValue := strings.Cut(argument.text, "="); hasValue {
+				reporter.processSetting(name, value, argument)
+			}
+		}
+	case "ENV":
+		reporter.processEnv(instruction.words())
+	case "ADD", "COPY":
+		reporter.processSources(instruction.words())
+	case "ENTRYPOINT", "CMD":
+		reporter.processCommand(instruction.keyword, instruction.words())
+	}
+}
+
+// processEnv reports the variables of an `ENV` instruction, in either form:
+//
+//	ENV RAILS_ENV=production SECRET_KEY_BASE=abc123
+//	ENV SECRET_KEY_BASE abc123
+func (reporter *reporter) processEnv(arguments []word) {
+	if len(arguments) == 0 {
+		return
+	}
+
+	if !strings.Contains(arguments[0].text, "=") {
+		if len(arguments) < 2 {
+			return
+		}
+
+		value := arguments[1]
+		for _, argument := range arguments[2:] {
+			value.text += " " + argument.text
+		}
+
+		reporter.processSetting(arguments[0].text, value.text, value)
+		return
+	}
+
+	for _, argument := range arguments {
+		if name, value, hasValue := strings.Cut(argument.text, "="); hasValue {
+			reporter.processSetting(name, value, argument)
+		}
+	}
+}
+
+// processSetting reports the value of a build argument or of an environment
+// variable, which is stored in the image
+func (reporter *reporter) processSetting(name string, value string, position word) {
+	if isLiteral(value) && (credentials.IsName(name) || credentials.URLHasPassword(value)) {
+		reporter.reportRisk(ruleHardcodedSecret, name, position)
+	}
+
+	reporter.reportEndpoint(name, value, position)
+}
+
+// processSources reports the sensitive files added to the image by an `ADD` or
+// `COPY` instruction, eg. `COPY --chown=app .env id_rsa /app/`
+func (reporter *reporter) processSources(arguments []word) {
+	var paths []word
+	for _, argument := range arguments {
+		if !strings.HasPrefix(argument.text, "--") {
+			paths = append(paths, argument)
+		}
+	}
+
+	// the last path is the destination
+	if len(paths) < 2 {
+		return
+	}
+
+	for _, sourcePath := range paths[:len(paths)-1] {
+		if isSensitiveFile(sourcePath.text) {
+			reporter.reportRisk(ruleSensitiveFile, sourcePath.text, sourcePath)
+		}
+	}
+}
+
+// processCommand reports the secrets, data stores and services given to the
+// command run by the image, eg.
+//
+//	CMD ["bundle", "exec", "puma", "--redis-url=redis://cache:6379/0"]
+//	ENTRYPOINT redis-cli -u redis://cache:6379/0
+func (reporter *reporter) processCommand(keyword string, arguments []word) {
+	previousFlag := ""
+
+	for _, argument := range arguments {
+		// the arguments of `sh -c "..."` are given as a single string
+		for _, field := range strings.Fields(argument.text) {
+			if strings.HasPrefix(field, "-") {
+				flag, value, hasValue := strings.Cut(field, "=")
+				if !hasValue {
+					previousFlag = strings.TrimLeft(flag, "-")
+					continue
+				}
+
+				reporter.processCommandArgument(keyword, strings.TrimLeft(flag, "-"), value, argument)
+			} else {
+				reporter.processCommandArgument(keyword, previousFlag, field, argument)
+			}
+
+			previousFlag = ""
+		}
+	}
+}
+
+// processCommandArgument reports a value given to a command, along with the
+// flag it is given to if any, eg. `--password=s3cr3t`
+func (reporter *reporter) processCommandArgument(keyword string, flag string, value string, position word) {
+	value = strings.Trim(value, `"'`)
+
+	name := flag
+	if name == "" {
+		name = keyword
+	}
+
+	if isLiteral(value) && ((flag != "" && credentials.IsName(flag)) || credentials.URLHasPassword(value)) {
+		reporter.reportRisk(ruleHardcodedSecret, name, position)
+	}
+
+	if strings.Contains(value, "://") {
+		reporter.reportEndpoint(name, value, position)
+	}
+}
+
+// reportRisk reports a risk at an argument, described by its name so that
+// secret values are not kept in the report
+func (reporter *reporter) reportRisk(ruleID string, name string, position word) {
+	reporter.report.AddDetection(
+		detections.TypeInfrastructureRisk,
+		detectors.DetectorDockerfile,
+		reporter.sourceFor(position, name),
+		infrastructure.Risk{RuleID: ruleID, Description: name},
+	)
+}
+
+// reportEndpoint reports the data store or service a value points to, eg.
+// `postgres://db/app` or `https://api.stripe.com`
+func (reporter *reporter) reportEndpoint(name string, value string, position word) {
+	// connection strings describe a data store rather than an interface
+	if dataStore := connection.Parse(value); dataStore != nil {
+		reporter.report.AddFramework(detectors.DetectorDockerfile, connection.TypeDataStore, connection.Variable{
+			Name:      name,
+			DataStore: *dataStore,
+		}, reporter.sourceFor(position, ""))
+
+		return
+	}
+
+	parsedValue := values.New()
+	parsedValue.AppendString(value)
+
+	interfaceType, isInterface := interfaces.GetTypeWithKey(name, parsedValue)
+	if isInterface {
+		reporter.report.AddInterface(detectors.DetectorDockerfile, reportinterface.Interface{
+			Value:        parsedValue,
+			Type:         interfaceType,
+			VariableName: name,
+		}, reporter.sourceFor(position, value))
+	}
+}
+
+func (reporter *reporter) sourceFor(position word, text string) source.Source {
+	return source.New(
+		reporter.file,
+		reporter.file.Path,
+		position.line,
+		position.column,
+		position.line,
+		position.column+len(position.text),
+		text,
+	)
+}
+
+// words splits the arguments of an instruction, given either as a JSON array,
+// eg. `["npm", "start"]`, or in the shell form, eg. `npm start`
+func (instruction instruction) words() []word {
+	trimmed := strings.TrimLeft(instruction.arguments, " \t")
+	if strings.HasPrefix(trimmed, "[") {
+		var parts []string
+		if err := json.Unmarshal([]byte(strings.ReplaceAll(trimmed, "\\\n", "")), &parts); err == nil {
+			// elements are located at the opening bracket
+			column := instruction.column + len(instruction.arguments) - len(trimmed)
+
+			words := make([]word, len(parts))
+			for i, part := range parts {
+				words[i] = word{text: part, line: instruction.line, column: column}
+			}
+
+			return words
+		}
+	}
+
+	return splitWords(instruction.arguments, instruction.line, instruction.column)
+}
+
+// splitWords splits the shell form arguments of an instruction on whitespace,
+// removing quotes, escapes and line continuations
+func splitWords(text string, line int, column int) []word {
+	var words []word
+	var current *word
+	var quote byte
+
+	start := func() {
+		if current == nil {
+			current = &word{line: line, column: column}
+		}
+	}
+
+	flush := func() {
+		if current != nil {
+			words = append(words, *current)
+			current = nil
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		char := text[i]
+
+		switch {
+		case char == '\n':
+			flush()
+			line++
+			column = 1
+			continue
+		case quote == 0 && (char == ' ' || char == '\t' || char == '\r'):
+			flush()
+		case char == '\\' && i+1 < len(text) && text[i+1] != '\n' && text[i+1] != '\r':
+			start()
+			current.text += string(text[i+1])
+			i++
+			column++
+		case char == '\\':
+			// line continuation
+			flush()
+		case quote == 0 && (char == '"' || char == '\''):
+			start()
+			quote = char
+		case char == quote:
+			quote = 0
+		default:
+			start()
+			current.text += string(char)
+		}
+
+		column++
+	}
+
+	flush()
+
+	return words
+}
+
+// isLiteral tells whether a value is set in the Dockerfile, rather than from
+// a variable
+func isLiteral(value string) bool {
+	return strings.TrimSpace(value) != "" && !variableReferenceRegex.MatchString(value)
+}
+
+func isSensitiveFile(filePath string) bool {
+	filePath = strings.TrimSuffix(filePath, "/")
+	base := path.Base(filePath)
+
+	if exampleFilenameRegex.MatchString(base) {
+		return false
+	}
+
+	return sensitiveFilenameRegex.MatchString(base) || sensitivePathRegex.MatchString(filePath)
+}
@@ -0,0 +1,27 @@
+package dockerfile_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/detectors/internal/testhelper"
+	"github.com/bearer/bearer/internal/report/detectors"
+)
+
+const detectorType = detectors.DetectorDockerfile
+
+var registrations = testhelper.RegistrationFor(detectorType)
+
+func TestDetectorReportRisksAndInterfaces(t *testing.T) {
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "project"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, detectorReport.Detections)
+}
+
+func TestDetectorReportDataStores(t *testing.T) {
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "project"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, detectorReport.Frameworks)
+}
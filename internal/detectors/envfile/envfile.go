@@ -11,6 +11,7 @@ import (
 	"github.com/bearer/bearer/internal/parser/interfaces"
 	"github.com/bearer/bearer/internal/report"
 	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
 	reportinterface "github.com/bearer/bearer/internal/report/interfaces"
 	"github.com/bearer/bearer/internal/report/values"
 	"github.com/bearer/bearer/internal/util/file"
@@ -54,6 +55,16 @@ func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, repor
 		name := captures["name"].Content()
 		value := stripQuotes(captures["value"].Content())
 
+		// connection strings, eg. DATABASE_URL, describe a data store rather than an interface
+		if dataStore := connection.Parse(value); dataStore != nil {
+			report.AddFramework(detectors.DetectorEnvFile, connection.TypeDataStore, connection.Variable{
+				Name:      name,
+				DataStore: *dataStore,
+			}, detectionNode.Source(false))
+
+			return nil
+		}
+
 		parsedValue := values.New()
 		parsedValue.AppendString(value)
 
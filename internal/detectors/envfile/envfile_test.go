@@ -19,3 +19,9 @@ func TestDetectorReportVariables(t *testing.T) {
 
 	cupaloy.SnapshotT(t, detectorReport.Detections)
 }
+
+func TestDetectorReportConnectionStrings(t *testing.T) {
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "variables"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, detectorReport.Frameworks)
+}
@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"log"
 	"strings"
+	"sync"
 
 	"github.com/bearer/bearer/internal/detectors/types"
 	"github.com/bearer/bearer/internal/parser/nodeid"
@@ -19,12 +20,24 @@ import (
 //go:embed gitlab_config.toml
 var RawConfig []byte
 
+var (
+	maskDetector     *detect.Detector
+	maskDetectorOnce sync.Once
+)
+
 type detector struct {
 	gitleaksDetector *detect.Detector
 	idGenerator      nodeid.Generator
 }
 
 func New(idGenerator nodeid.Generator) types.Detector {
+	return &detector{
+		gitleaksDetector: newGitleaksDetector(),
+		idGenerator:      idGenerator,
+	}
+}
+
+func newGitleaksDetector() *detect.Detector {
 	var vc config.ViperConfig
 	toml.Unmarshal(RawConfig, &vc) //nolint:all,errcheck
 	cfg, err := vc.Translate()
@@ -32,12 +45,33 @@ func New(idGenerator nodeid.Generator) types.Detector {
 		log.Fatal(err)
 	}
 
-	gitleaksDetector := detect.NewDetector(cfg)
+	return detect.NewDetector(cfg)
+}
 
-	return &detector{
-		gitleaksDetector: gitleaksDetector,
-		idGenerator:      idGenerator,
+// MaskSecrets replaces the characters of any secrets found in the content
+// with asterisks. Line breaks are kept so that line numbers are unchanged.
+func MaskSecrets(content string) string {
+	maskDetectorOnce.Do(func() {
+		maskDetector = newGitleaksDetector()
+	})
+
+	for _, finding := range maskDetector.DetectString(content) {
+		if finding.Secret == "" {
+			continue
+		}
+
+		mask := strings.Map(func(char rune) rune {
+			if char == '\n' {
+				return char
+			}
+
+			return '*'
+		}, finding.Secret)
+
+		content = strings.ReplaceAll(content, finding.Secret, mask)
 	}
+
+	return content
 }
 
 func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
@@ -1,23 +1,32 @@
 package graphql
 
 import (
+	"regexp"
+	"sort"
 	"strings"
 
+	sitter "github.com/smacker/go-tree-sitter"
+
 	"github.com/bearer/bearer/internal/detectors/types"
 	"github.com/bearer/bearer/internal/parser"
 	"github.com/bearer/bearer/internal/parser/nodeid"
 	parserschema "github.com/bearer/bearer/internal/parser/schema"
 	"github.com/bearer/bearer/internal/parser/sitter/graphql"
+	"github.com/bearer/bearer/internal/report/detections"
 	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/operations"
 	"github.com/bearer/bearer/internal/report/schema"
+	"github.com/bearer/bearer/internal/report/source"
 	"github.com/bearer/bearer/internal/util/file"
 	"github.com/bearer/bearer/internal/util/pluralize"
+	"github.com/bearer/bearer/internal/util/set"
 
 	reporttypes "github.com/bearer/bearer/internal/report"
 )
 
 var (
-	language           = graphql.GetLanguage()
+	language = graphql.GetLanguage()
+
 	graphqlSchemaQuery = parser.QueryMustCompile(language, `
 	(
 		object_type_definition (name) @object_name
@@ -30,12 +39,98 @@ var (
 			)
 	)
 	`)
+
+	// Fields of the interface and input types, and those added by type
+	// extensions
+	otherTypesQuery = parser.QueryMustCompile(language, `
+	[
+		(object_type_extension (name) @object_name
+			(fields_definition
+				(field_definition (name) @field_name (type) @field_type)))
+		(interface_type_definition (name) @object_name
+			(fields_definition
+				(field_definition (name) @field_name (type) @field_type)))
+		(input_object_type_definition (name) @object_name
+			(input_fields_definition
+				(input_value_definition (name) @field_name (type) @field_type)))
+	]
+	`)
+
+	rootOperationTypesQuery = parser.QueryMustCompile(language, `
+	(root_operation_type_definition
+		(operation_type) @operation_type
+		(named_type (name) @type_name))
+	`)
+
+	argumentsQuery = parser.QueryMustCompile(language, `
+	[
+		(object_type_definition (name) @object_name
+			(fields_definition
+				(field_definition (name) @field_name
+					(arguments_definition
+						(input_value_definition (name) @argument_name (type) @argument_type)) @arguments)))
+		(object_type_extension (name) @object_name
+			(fields_definition
+				(field_definition (name) @field_name
+					(arguments_definition
+						(input_value_definition (name) @argument_name (type) @argument_type)) @arguments)))
+	]
+	`)
+
+	// Fields selected by queries, mutations and fragments. Fragments are named
+	// after the type they apply to.
+	selectionsQuery = parser.QueryMustCompile(language, `
+	[
+		(field (name) @object_name
+			(selection_set
+				(selection (field (name) @field_name))))
+		(fragment_definition
+			(type_condition (named_type (name) @object_name))
+			(selection_set
+				(selection (field (name) @field_name))))
+	]
+	`)
+
+	templateTags                = []string{"gql", "graphql"}
+	templateSubstitutionPattern = regexp.MustCompile(`\$\{[^}]*\}`)
+
+	defaultRootOperationTypes = map[string]string{
+		"Query":        "QUERY",
+		"Mutation":     "MUTATION",
+		"Subscription": "SUBSCRIPTION",
+	}
 )
 
 type detector struct {
 	idGenerator nodeid.Generator
 }
 
+// item is a field of any other schema than an object type, kept until all the
+// fields of the tree are known so that they can be grouped by object
+type item struct {
+	objectNode *parser.Node
+	objectName string
+	fieldNode  *parser.Node
+	fieldType  string
+	simpleType string
+}
+
+type fieldDefinition struct {
+	typeName  string
+	name      string
+	namedType string
+	source    source.Source
+}
+
+// document holds the types of one or more GraphQL documents of a file, so that
+// operations can be resolved to the schemas they use
+type document struct {
+	rootOperationTypes map[string]string
+	typeReferences     map[string][]string
+	fields             []fieldDefinition
+	arguments          map[string][]string
+}
+
 func New(idGenerator nodeid.Generator) types.Detector {
 	return &detector{
 		idGenerator: idGenerator,
@@ -66,9 +161,190 @@ func (detector *detector) ExtractFromSchema(
 	}
 	defer tree.Close()
 
+	document := newDocument()
+	if err := detector.extract(tree, document, report); err != nil {
+		return err
+	}
+
+	document.addOperations(report)
+
+	return nil
+}
+
+// ProcessTaggedTemplates reports the GraphQL documents embedded in the gql and
+// graphql tagged templates of a JavaScript or TypeScript tree. The query must
+// capture the tag as @tag and the template string as @template.
+func ProcessTaggedTemplates(
+	tree *parser.Tree,
+	query *sitter.Query,
+	report reporttypes.Report,
+	idGenerator nodeid.Generator,
+) error {
+	detector := &detector{idGenerator: idGenerator}
+	document := newDocument()
+
+	err := tree.Query(query, func(captures parser.Captures) error {
+		if !isTemplateTag(captures["tag"].Content()) {
+			return nil
+		}
+
+		templateNode := captures["template"]
+		content := strings.Trim(templateNode.Content(), "`")
+		// blank out substitutions, keeping line numbers intact
+		content = templateSubstitutionPattern.ReplaceAllStringFunc(content, func(substitution string) string {
+			return strings.Map(func(char rune) rune {
+				if char == '\n' {
+					return char
+				}
+
+				return ' '
+			}, substitution)
+		})
+
+		templateTree, err := parser.ParseBytes(
+			tree.FileInfo(),
+			tree.File(),
+			[]byte(content),
+			language,
+			templateNode.StartLineNumber()-1,
+		)
+		if err != nil {
+			return err
+		}
+		defer templateTree.Close()
+
+		return detector.extract(templateTree, document, report)
+	})
+	if err != nil {
+		return err
+	}
+
+	document.addOperations(report)
+
+	return nil
+}
+
+func isTemplateTag(name string) bool {
+	for _, tag := range templateTags {
+		if name == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newDocument() *document {
+	return &document{
+		rootOperationTypes: make(map[string]string),
+		typeReferences:     make(map[string][]string),
+		arguments:          make(map[string][]string),
+	}
+}
+
+func (detector *detector) extract(tree *parser.Tree, document *document, report reporttypes.Report) error {
+	var items []item
+
+	err := tree.Query(rootOperationTypesQuery, func(captures parser.Captures) error {
+		typeName := captures["type_name"].Content()
+		document.rootOperationTypes[typeName] = strings.ToUpper(captures["operation_type"].Content())
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := detector.addObjectTypes(tree, document, report); err != nil {
+		return err
+	}
+
+	err = tree.Query(otherTypesQuery, func(captures parser.Captures) error {
+		objectNode := captures["object_name"]
+		objectName := stripQuotes(objectNode.Content())
+		fieldNode := captures["field_name"]
+		fieldType := stripQuotes(captures["field_type"].Content())
+
+		items = append(items, item{
+			objectNode: objectNode,
+			objectName: objectName,
+			fieldNode:  fieldNode,
+			fieldType:  fieldType,
+			simpleType: convertType(fieldType),
+		})
+
+		document.addField(objectName, fieldNode, fieldType)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// arguments of root fields are reported as the fields of an object named
+	// after the operation
+	err = tree.Query(argumentsQuery, func(captures parser.Captures) error {
+		objectName := stripQuotes(captures["object_name"].Content())
+		if _, isRoot := document.operationType(objectName); !isRoot {
+			return nil
+		}
+
+		fieldName := stripQuotes(captures["field_name"].Content())
+		argumentType := stripQuotes(captures["argument_type"].Content())
+
+		items = append(items, item{
+			objectNode: captures["arguments"],
+			objectName: fieldName,
+			fieldNode:  captures["argument_name"],
+			fieldType:  argumentType,
+			simpleType: convertType(argumentType),
+		})
+
+		key := argumentsKey(objectName, fieldName)
+		document.arguments[key] = append(document.arguments[key], namedType(argumentType))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tree.Query(selectionsQuery, func(captures parser.Captures) error {
+		fieldNode := captures["field_name"]
+		if strings.HasPrefix(fieldNode.Content(), "__") {
+			return nil
+		}
+
+		simpleType := schema.SimpleTypeUnknown
+		if hasSelectionSet(fieldNode.Parent()) {
+			simpleType = schema.SimpleTypeObject
+		}
+
+		items = append(items, item{
+			objectNode: captures["object_name"],
+			objectName: stripQuotes(captures["object_name"].Content()),
+			fieldNode:  fieldNode,
+			simpleType: simpleType,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	detector.addSchemas(items, report)
+
+	return nil
+}
+
+// addObjectTypes reports the fields of the object types as they are found,
+// before any other schemas of the tree, so that they don't depend on what else
+// the tree holds
+func (detector *detector) addObjectTypes(tree *parser.Tree, document *document, report reporttypes.Report) error {
 	uuidHolder := parserschema.NewUUIDHolder()
 
-	err = tree.Query(graphqlSchemaQuery, func(captures parser.Captures) error {
+	err := tree.Query(graphqlSchemaQuery, func(captures parser.Captures) error {
 		objectNode := captures["object_name"]
 		objectName := stripQuotes(objectNode.Content())
 		fieldType := stripQuotes(captures["field_type"].Content())
@@ -79,11 +355,6 @@ func (detector *detector) ExtractFromSchema(
 		objectUUID := uuidHolder.Assign(objectNode.ID(), detector.idGenerator)
 		fieldUUID := uuidHolder.Assign(fieldNode.ID(), detector.idGenerator)
 
-		normalizedObjectName := ""
-		normalizedFieldName := ""
-		normalizedObjectName = pluralize.Singular(strings.ToLower(objectName))
-		normalizedFieldName = pluralize.Singular(strings.ToLower(fieldName))
-
 		currentSchema := schema.Schema{
 			ObjectName:           objectName,
 			ObjectUUID:           objectUUID,
@@ -91,8 +362,8 @@ func (detector *detector) ExtractFromSchema(
 			FieldType:            fieldType,
 			FieldUUID:            fieldUUID,
 			SimpleFieldType:      convertType(fieldType),
-			NormalizedObjectName: normalizedObjectName,
-			NormalizedFieldName:  normalizedFieldName,
+			NormalizedObjectName: pluralize.Singular(strings.ToLower(objectName)),
+			NormalizedFieldName:  pluralize.Singular(strings.ToLower(fieldName)),
 		}
 
 		if report.SchemaGroupShouldClose(objectName) {
@@ -116,6 +387,8 @@ func (detector *detector) ExtractFromSchema(
 			&source,
 		)
 
+		document.addField(objectName, fieldNode, fieldType)
+
 		return nil
 	})
 
@@ -124,10 +397,153 @@ func (detector *detector) ExtractFromSchema(
 	return err
 }
 
+// addSchemas reports the items grouped by the object they belong to, in the
+// order the objects appear
+func (detector *detector) addSchemas(items []item, report reporttypes.Report) {
+	var objectNodes []*parser.Node
+	itemsByObject := make(map[parser.NodeID][]item)
+	for _, item := range items {
+		objectID := item.objectNode.ID()
+		if _, seen := itemsByObject[objectID]; !seen {
+			objectNodes = append(objectNodes, item.objectNode)
+		}
+
+		itemsByObject[objectID] = append(itemsByObject[objectID], item)
+	}
+
+	uuidHolder := parserschema.NewUUIDHolder()
+
+	for _, objectNode := range objectNodes {
+		for _, item := range itemsByObject[objectNode.ID()] {
+			fieldName := stripQuotes(item.fieldNode.Content())
+
+			currentSchema := schema.Schema{
+				ObjectName:           item.objectName,
+				ObjectUUID:           uuidHolder.Assign(objectNode.ID(), detector.idGenerator),
+				FieldName:            fieldName,
+				FieldType:            item.fieldType,
+				FieldUUID:            uuidHolder.Assign(item.fieldNode.ID(), detector.idGenerator),
+				SimpleFieldType:      item.simpleType,
+				NormalizedObjectName: pluralize.Singular(strings.ToLower(item.objectName)),
+				NormalizedFieldName:  pluralize.Singular(strings.ToLower(fieldName)),
+			}
+
+			if !report.SchemaGroupIsOpen() {
+				source := objectNode.Source(true)
+				report.SchemaGroupBegin(
+					detectors.DetectorGraphQL,
+					objectNode,
+					currentSchema,
+					&source,
+					nil,
+				)
+			}
+			source := item.fieldNode.Source(true)
+			report.SchemaGroupAddItem(
+				item.fieldNode,
+				currentSchema,
+				&source,
+			)
+		}
+
+		report.SchemaGroupEnd(detector.idGenerator)
+	}
+}
+
+func (document *document) addField(typeName string, fieldNode *parser.Node, fieldType string) {
+	document.typeReferences[typeName] = append(document.typeReferences[typeName], namedType(fieldType))
+	document.fields = append(document.fields, fieldDefinition{
+		typeName:  typeName,
+		name:      stripQuotes(fieldNode.Content()),
+		namedType: namedType(fieldType),
+		source:    fieldNode.Source(true),
+	})
+}
+
+// addOperations reports each field of the root operation types, which are
+// implemented by resolvers, with the schemas of its arguments and result
+func (document *document) addOperations(report reporttypes.Report) {
+	for _, field := range document.fields {
+		operationType, isRoot := document.operationType(field.typeName)
+		if !isRoot {
+			continue
+		}
+
+		requestSchemas := set.New[string]()
+		if arguments, ok := document.arguments[argumentsKey(field.typeName, field.name)]; ok {
+			requestSchemas.Add(field.name)
+			for _, argumentType := range arguments {
+				document.collectSchemas(argumentType, requestSchemas)
+			}
+		}
+
+		responseSchemas := set.New[string]()
+		document.collectSchemas(field.namedType, responseSchemas)
+
+		report.AddDetection(detections.TypeOperation, detectors.DetectorGraphQL, field.source, operations.Operation{
+			Path:            field.name,
+			Type:            operationType,
+			RequestSchemas:  sortedItems(requestSchemas),
+			ResponseSchemas: sortedItems(responseSchemas),
+		})
+	}
+}
+
+func (document *document) operationType(typeName string) (string, bool) {
+	rootOperationTypes := document.rootOperationTypes
+	if len(rootOperationTypes) == 0 {
+		rootOperationTypes = defaultRootOperationTypes
+	}
+
+	operationType, ok := rootOperationTypes[typeName]
+	return operationType, ok
+}
+
+// collectSchemas adds the type and the types of its fields, recursively. Only
+// types defined in the document are included.
+func (document *document) collectSchemas(typeName string, found set.Set[string]) {
+	references, defined := document.typeReferences[typeName]
+	if !defined || found.Has(typeName) {
+		return
+	}
+
+	found.Add(typeName)
+	for _, reference := range references {
+		document.collectSchemas(reference, found)
+	}
+}
+
+func argumentsKey(typeName string, fieldName string) string {
+	return typeName + "." + fieldName
+}
+
+func hasSelectionSet(node *parser.Node) bool {
+	for i := 0; i < node.NamedChildCount(); i++ {
+		if node.Child(i).Type() == "selection_set" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedItems(values set.Set[string]) []string {
+	var items []string
+	items = append(items, values.Items()...)
+	sort.Strings(items)
+
+	return items
+}
+
 func stripQuotes(value string) string {
 	return strings.Trim(value, `"'`)
 }
 
+// namedType returns the name of the type, without list and non-null modifiers
+func namedType(value string) string {
+	return strings.Trim(value, "[]! ")
+}
+
 func convertType(value string) string {
 	simplified := strings.TrimSuffix(value, "!")
 	switch simplified {
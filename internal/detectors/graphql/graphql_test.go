@@ -1,24 +1,81 @@
 package graphql_test
 
 import (
+	"os"
 	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/bearer/bearer/internal/detectors"
 	"github.com/bearer/bearer/internal/detectors/graphql"
 	"github.com/bearer/bearer/internal/detectors/internal/testhelper"
+	"github.com/bearer/bearer/internal/detectors/javascript"
 	"github.com/bearer/bearer/internal/parser/nodeid"
+	"github.com/bearer/bearer/internal/report/detections"
 	detectortypes "github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/schema"
+	"github.com/bearer/bearer/internal/util/set"
 	"github.com/bradleyjkemp/cupaloy"
 )
 
 var detectorType = detectortypes.DetectorGraphQL
 var (
 	registrations = []detectors.InitializedDetector{{Type: detectorType, Detector: graphql.New(&nodeid.IntGenerator{Counter: 0})}}
+
+	objectTypePattern = regexp.MustCompile(`(?m)^type (\w+)`)
 )
 
+// The schema is large, so only its object types are snapshotted. The other
+// kinds of types are covered by TestBuildReportTypes.
 func TestBuildReportSchema(t *testing.T) {
 	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "schemas"), registrations, detectorType)
 
+	content, err := os.ReadFile(filepath.Join("testdata", "schemas", "schema.graphql"))
+	if err != nil {
+		t.Fatalf("failed to read schema: %s", err)
+	}
+
+	objectTypes := set.New[string]()
+	for _, match := range objectTypePattern.FindAllStringSubmatch(string(content), -1) {
+		objectTypes.Add(match[1])
+	}
+
+	var objectTypeDetections []*detections.Detection
+	for _, detection := range detectorReport.Detections {
+		value, ok := detection.Value.(schema.Schema)
+		if detection.Type == detections.TypeSchema && ok && objectTypes.Has(value.ObjectName) {
+			objectTypeDetections = append(objectTypeDetections, detection)
+		}
+	}
+
+	cupaloy.SnapshotT(t, objectTypeDetections)
+}
+
+func TestBuildReportTypes(t *testing.T) {
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "types"), registrations, detectorType)
+
 	cupaloy.SnapshotT(t, detectorReport.Detections)
 }
+
+func TestBuildReportOperations(t *testing.T) {
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "operations"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, detectorReport.Detections)
+}
+
+func TestBuildReportTaggedTemplates(t *testing.T) {
+	javascriptRegistrations := []detectors.InitializedDetector{{
+		Type:     detectortypes.DetectorJavascript,
+		Detector: javascript.New(&nodeid.IntGenerator{Counter: 0}),
+	}}
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "templates"), javascriptRegistrations, detectortypes.DetectorJavascript)
+
+	var graphqlDetections []*detections.Detection
+	for _, detection := range detectorReport.Detections {
+		if detection.DetectorType == detectorType {
+			graphqlDetections = append(graphqlDetections, detection)
+		}
+	}
+
+	cupaloy.SnapshotT(t, graphqlDetections)
+}
@@ -0,0 +1,23 @@
+const { gql } = require("apollo-server")
+
+const typeDefs = gql`
+  type Customer {
+    email: String
+    dateOfBirth: String
+  }
+
+  type Query {
+    customer(email: String!): Customer
+  }
+`
+
+const CUSTOMER_QUERY = gql`
+  query Customer($email: String!) {
+    customer(email: $email) {
+      email
+      dateOfBirth
+    }
+  }
+`
+
+const notGraphQL = html`<p>${typeDefs}</p>`
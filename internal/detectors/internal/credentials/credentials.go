@@ -0,0 +1,37 @@
+package credentials
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// the names of settings holding credentials, eg. DATABASE_PASSWORD or apiKey
+	nameRegex = regexp.MustCompile(`(?i)(passw(or)?d|secret|token|api[_-]?key|private[_-]?key|access[_-]?key|credential)`)
+	// the names of settings locating credentials rather than holding them, eg.
+	// SECRET_NAME or TOKEN_URL
+	referenceNameRegex = regexp.MustCompile(`(?i)[_-]?(name|file|path|dir|url|uri|endpoint|host|ref)$`)
+)
+
+// IsName tells whether the name of a setting, such as an environment
+// variable, denotes a credential
+func IsName(name string) bool {
+	return nameRegex.MatchString(name) && !referenceNameRegex.MatchString(name)
+}
+
+// URLHasPassword tells whether a value is a URL including a password, eg.
+// `postgres://app:s3cr3t@db/app`
+func URLHasPassword(value string) bool {
+	if !strings.Contains(value, "://") {
+		return false
+	}
+
+	parsed, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || parsed.User == nil {
+		return false
+	}
+
+	password, set := parsed.User.Password()
+	return set && password != ""
+}
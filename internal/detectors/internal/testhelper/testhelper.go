@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/bearer/bearer/internal/detectors"
 	"github.com/bearer/bearer/internal/parser"
@@ -53,7 +54,7 @@ func Extract(
 	}
 
 	for _, filename := range files {
-		err = detectors.ExtractWithDetectors(context.Background(), path, filename, &report, nil, registrations, nil)
+		err = detectors.ExtractWithDetectors(context.Background(), path, filename, &report, nil, registrations, nil, nil)
 		if !assert.Nil(t, err) {
 			t.Errorf("report has errored %s", err)
 		}
@@ -211,3 +212,12 @@ func (report *InMemoryReport) AddError(filePath string, err error) {
 		File:    filePath,
 	})
 }
+
+func (report *InMemoryReport) AddRuleTimeout(filePath string, ruleID string, timeout time.Duration) {
+	report.Errors = append(report.Errors, &detections.ErrorDetection{
+		Type:    detections.TypeError,
+		Message: fmt.Sprintf("rule %s skipped as it timed out after %s", ruleID, timeout),
+		File:    filePath,
+		RuleID:  ruleID,
+	})
+}
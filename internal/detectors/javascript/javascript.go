@@ -8,6 +8,7 @@ import (
 
 	sitter "github.com/smacker/go-tree-sitter"
 
+	"github.com/bearer/bearer/internal/detectors/graphql"
 	"github.com/bearer/bearer/internal/detectors/javascript/datatype"
 	"github.com/bearer/bearer/internal/detectors/types"
 	"github.com/bearer/bearer/internal/parser"
@@ -34,6 +35,12 @@ var (
 		name: (object_pattern (shorthand_property_identifier_pattern) @key @node)
 			value: (member_expression) @object)
 `)
+
+	graphqlTemplatesQuery = parser.QueryMustCompile(javascript.GetLanguage(), `
+	(call_expression
+		function: (identifier) @tag
+		arguments: (template_string) @template)
+`)
 )
 
 type detector struct {
@@ -95,6 +102,10 @@ func (detector *detector) processTree(tree *parser.Tree, report report.Report) (
 
 	datatype.Discover(report, tree, detector.idGenerator)
 
+	if err := graphql.ProcessTaggedTemplates(tree, graphqlTemplatesQuery, report, detector.idGenerator); err != nil {
+		return false, err
+	}
+
 	if err := interfacedetector.Detect(&interfacedetector.Request{
 		Tree:             tree,
 		Report:           report,
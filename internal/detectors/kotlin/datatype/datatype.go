@@ -0,0 +1,159 @@
+package datatype
+
+import (
+	"strings"
+
+	"github.com/bearer/bearer/internal/parser"
+	"github.com/bearer/bearer/internal/parser/datatype"
+	"github.com/bearer/bearer/internal/parser/nodeid"
+	"github.com/bearer/bearer/internal/report"
+	"github.com/bearer/bearer/internal/report/detections"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/schema"
+	schemadatatype "github.com/bearer/bearer/internal/report/schema/datatype"
+	"github.com/smacker/go-tree-sitter/kotlin"
+)
+
+var classesQuery = parser.QueryMustCompile(kotlin.GetLanguage(),
+	`(class_declaration
+		(type_identifier) @param_name
+	) @param_class`)
+
+// class User(val name: String) { val email: String = "" }
+var classPropertiesQuery = parser.QueryMustCompile(kotlin.GetLanguage(),
+	`(class_declaration
+		[
+			(primary_constructor
+				(class_parameter
+					(simple_identifier) @param_id
+					":"
+					.
+					(_) @param_type
+				) @param_node
+			)
+			(class_body
+				(property_declaration
+					(variable_declaration
+						(simple_identifier) @param_id
+						(_)? @param_type
+					)
+				) @param_node
+			)
+		]
+	) @param_class
+	`)
+
+var classFunctionsQuery = parser.QueryMustCompile(kotlin.GetLanguage(),
+	`(class_declaration
+		(class_body
+			(function_declaration
+				(simple_identifier) @param_id
+			) @param_node
+		)
+	) @param_class`)
+
+func Discover(report report.Report, tree *parser.Tree, idGenerator nodeid.Generator) {
+	datatypes := make(map[parser.NodeID]*schemadatatype.DataType)
+
+	// add classses
+	captures := tree.QueryConventional(classesQuery)
+	for _, capture := range captures {
+		name := capture["param_name"].Content()
+		classNode := capture["param_class"]
+
+		datatypes[classNode.ID()] = &schemadatatype.DataType{
+			Node:       classNode,
+			Name:       name,
+			Type:       schema.SimpleTypeObject,
+			TextType:   "class",
+			Properties: make(map[string]schemadatatype.DataTypable),
+		}
+	}
+
+	discoverProperties(tree, datatypes)
+	discoverFunctions(tree, datatypes)
+
+	datatype.PruneMap(datatypes)
+
+	report.AddDataType(detections.TypeSchema, detectors.DetectorKotlin, idGenerator, datatypes, nil)
+}
+
+func discoverProperties(tree *parser.Tree, datatypes map[parser.NodeID]*schemadatatype.DataType) {
+	// add class properties
+	captures := tree.QueryConventional(classPropertiesQuery)
+	for _, capture := range captures {
+		classNode := capture["param_class"]
+		if datatypes[classNode.ID()] == nil {
+			continue
+		}
+
+		// get node
+		propertyNode := capture["param_node"]
+
+		// get property name
+		propertyName := capture["param_id"].Content()
+
+		// get property type. The type is optional when it's inferred
+		propertyType := schema.SimpleTypeUnknown
+		propertyTextType := ""
+		if propertyTypeNode := capture["param_type"]; propertyTypeNode != nil {
+			propertyType = standardizeDataType(propertyTypeNode, propertyTypeNode.Content())
+			propertyTextType = propertyTypeNode.Content()
+		}
+
+		datatypes[classNode.ID()].Properties[propertyName] = &schemadatatype.DataType{
+			Node:       propertyNode,
+			Name:       propertyName,
+			Type:       propertyType,
+			TextType:   propertyTextType,
+			Properties: make(map[string]schemadatatype.DataTypable),
+		}
+	}
+}
+
+func discoverFunctions(tree *parser.Tree, datatypes map[parser.NodeID]*schemadatatype.DataType) {
+	captures := tree.QueryConventional(classFunctionsQuery)
+	for _, capture := range captures {
+		classNode := capture["param_class"]
+		if datatypes[classNode.ID()] == nil {
+			continue
+		}
+
+		// get node
+		functionNode := capture["param_node"]
+
+		// get method name
+		functionNameNode := capture["param_id"]
+		functionName := functionNameNode.Content()
+
+		datatypes[classNode.ID()].Properties[functionName] = &schemadatatype.DataType{
+			Node:       functionNode,
+			Name:       functionName,
+			Type:       schema.SimpleTypeFunction,
+			TextType:   "",
+			Properties: make(map[string]schemadatatype.DataTypable),
+		}
+	}
+}
+
+func standardizeDataType(node *parser.Node, content string) string {
+	// nullable types, eg. String?
+	content = strings.TrimSuffix(strings.Trim(content, " "), "?")
+
+	switch content {
+	case "String", "Char":
+		return schema.SimpleTypeString
+	case "Int", "Long", "Short", "Float", "Double":
+		return schema.SimpleTypeNumber
+	case "Byte", "ByteArray":
+		return schema.SimpleTypeBinary
+	case "Boolean":
+		return schema.SimpleTypeBool
+	}
+
+	if node.Type() == "user_type" || node.Type() == "nullable_type" {
+		return schema.SimpleTypeObject
+	}
+
+	return schema.SimpleTypeUnknown
+}
@@ -0,0 +1,150 @@
+package kotlin
+
+import (
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/kotlin"
+
+	"github.com/bearer/bearer/internal/detectors/kotlin/datatype"
+	"github.com/bearer/bearer/internal/detectors/types"
+	"github.com/bearer/bearer/internal/parser"
+	"github.com/bearer/bearer/internal/parser/interfacedetector"
+	"github.com/bearer/bearer/internal/parser/nodeid"
+	"github.com/bearer/bearer/internal/report"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/values"
+	"github.com/bearer/bearer/internal/report/variables"
+	"github.com/bearer/bearer/internal/util/file"
+)
+
+var (
+	language = kotlin.GetLanguage()
+
+	environmentVariableQuery = parser.QueryMustCompile(language, `
+		(call_expression
+			(navigation_expression
+				(simple_identifier) @object
+				(navigation_suffix (simple_identifier) @method))
+			(call_suffix
+				(value_arguments . (value_argument . (line_string_literal) @key .)))) @node
+	`)
+)
+
+type detector struct {
+	idGenerator nodeid.Generator
+}
+
+func New(idGenerator nodeid.Generator) types.Detector {
+	return &detector{
+		idGenerator: idGenerator,
+	}
+}
+
+func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, report report.Report) (bool, error) {
+	if file.Language != "Kotlin" {
+		return false, nil
+	}
+
+	tree, err := parser.ParseFile(file, file.Path, language)
+	if err != nil {
+		return false, err
+	}
+	defer tree.Close()
+
+	if err := annotate(tree); err != nil {
+		return false, err
+	}
+
+	datatype.Discover(report, tree, detector.idGenerator)
+
+	if err := interfacedetector.Detect(&interfacedetector.Request{
+		Tree:             tree,
+		Report:           report,
+		DetectorType:     detectors.DetectorKotlin,
+		AcceptExpression: acceptExpression,
+		PathAllowed:      false,
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func annotate(tree *parser.Tree) error {
+	if err := annotateEnvironmentVariables(tree); err != nil {
+		return err
+	}
+
+	return tree.Annotate(func(node *parser.Node, value *values.Value) {
+		switch node.Type() {
+		case "additive_expression":
+			if node.FirstUnnamedChild().Content() == "+" {
+				value.Append(node.Child(0).Value())
+				value.Append(node.Child(node.ChildCount() - 1).Value())
+
+				return
+			}
+		case "simple_identifier", "interpolated_identifier":
+			value.AppendVariableReference(variables.VariableName, node.Content())
+
+			return
+		case "line_string_literal", "multi_line_string_literal":
+			node.EachPart(func(text string) error { //nolint:all,errcheck
+				value.AppendString(text)
+
+				return nil
+			}, func(child *parser.Node) error {
+				value.Append(child.Value())
+
+				return nil
+			})
+
+			return
+		case "character_escape_seq":
+			value.AppendString(strings.TrimPrefix(node.Content(), `\`))
+
+			return
+		case "source_file", "statements", "class_declaration", "class_body", "function_declaration", "function_body",
+			"property_declaration", "variable_declaration", "user_type", "type_identifier", "import_list", "import_header",
+			"package_header":
+			return
+		}
+
+		value.AppendUnknown(node.ChildValueParts())
+	})
+}
+
+func annotateEnvironmentVariables(tree *parser.Tree) error {
+	return tree.Query(environmentVariableQuery, func(captures parser.Captures) error {
+		object := captures["object"].Content()
+		method := captures["method"].Content()
+		if object != "System" || method != "getenv" {
+			return nil
+		}
+
+		node := captures["node"]
+		keyNode := captures["key"]
+		key := strings.Trim(keyNode.Content(), `"`)
+
+		value := values.New()
+		value.AppendVariableReference(variables.VariableEnvironment, key)
+		node.SetValue(value)
+
+		return nil
+	})
+}
+
+func acceptExpression(node *parser.Node) bool {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		// something["ignored.domain"]
+		if parent.Type() == "indexing_suffix" {
+			return false
+		}
+	}
+
+	return true
+}
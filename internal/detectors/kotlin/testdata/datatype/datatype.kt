@@ -0,0 +1,8 @@
+class Main(val assign: Int = 5, val name: String?) {
+    var declare: Long = 0
+    lateinit var imported: User
+
+    fun doMethod(): String {
+        return ""
+    }
+}
@@ -0,0 +1,21 @@
+package test.example
+
+class Delivery(private val client: HttpClient) {
+    private val apiUrl = "https://api.example.com"
+
+    suspend fun getOnlyPath() {
+        client.get("/api/delivery-messages")
+    }
+
+    suspend fun getWithUrlConcatenated(platformId: String, customerId: String) {
+        client.get(apiUrl + "/api/customers/" + customerId + "/transactions/" + platformId)
+    }
+
+    suspend fun getWithUrlInterpolated(platformId: String, customerId: String) {
+        client.get("$apiUrl/api/customers/$customerId/transactions/${platformId}")
+    }
+
+    suspend fun getWithEnvironmentHost() {
+        client.get(System.getenv("CUSTOMERS_HOST") + "/api/delivery-messages?num_page=1")
+    }
+}
@@ -0,0 +1,13 @@
+package test.example
+
+fun main() {
+    val accountId = System.getenv("ACCOUNT_ID")
+    val orderServiceUrl = System.getenv("ORDER_SERVICE_URL") + "/path?x=" + accountId
+
+    // TEST: ignores other methods on System
+    val ignore = System.other("IGNORE_ME_HOST")
+    // TEST: ignores other packages
+    val ignore2 = Other.getenv("IGNORE_ME_URL")
+
+    println(someVar["ignored.domain.com"])
+}
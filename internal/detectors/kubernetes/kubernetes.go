@@ -0,0 +1,299 @@
+package kubernetes
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/internal/detectors/internal/credentials"
+	"github.com/bearer/bearer/internal/detectors/types"
+	"github.com/bearer/bearer/internal/parser/interfaces"
+	"github.com/bearer/bearer/internal/report"
+	"github.com/bearer/bearer/internal/report/detections"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
+	"github.com/bearer/bearer/internal/report/infrastructure"
+	reportinterface "github.com/bearer/bearer/internal/report/interfaces"
+	"github.com/bearer/bearer/internal/report/source"
+	"github.com/bearer/bearer/internal/report/values"
+	"github.com/bearer/bearer/internal/util/file"
+)
+
+const (
+	ruleSecretInEnv           = "kubernetes_hardcoded_secret_in_env"
+	ruleSensitiveConfigMap    = "kubernetes_sensitive_data_in_config_map"
+	templateActionPlaceholder = "${helm}"
+)
+
+var (
+	filenamePattern = regexp.MustCompile(`\.ya?ml$`)
+
+	// a Helm template action, eg. `{{ .Values.image.tag }}`
+	templateActionRegex = regexp.MustCompile(`\{\{.*?\}\}`)
+
+	// values set from another variable, eg. `$(DB_PASSWORD)` or `${DB_PASSWORD}`
+	variableReferenceRegex = regexp.MustCompile(`\$[({]`)
+)
+
+type detector struct{}
+
+func New() types.Detector {
+	return &detector{}
+}
+
+func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+// ProcessFile reads the Kubernetes manifests of a YAML file, including the
+// templates of Helm charts. Other YAML files are left to the YAML config
+// detector.
+func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, report report.Report) (bool, error) {
+	if file.Language != "YAML" && !filenamePattern.MatchString(file.Base) {
+		return false, nil
+	}
+
+	content, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return false, err
+	}
+
+	// files which aren't valid YAML are left to the YAML config detector too,
+	// eg. templates using actions to build keys
+	manifests, err := parseManifests(stripTemplateActions(content))
+	if err != nil || len(manifests) == 0 {
+		return false, nil
+	}
+
+	reporter := &reporter{file: file, report: report}
+	for _, manifest := range manifests {
+		reporter.processManifest(manifest)
+	}
+
+	return true, nil
+}
+
+// stripTemplateActions removes the actions of a Helm template so that it can
+// be read as YAML. Lines holding only actions are emptied, and actions within
+// a value are replaced by a placeholder. Line numbers are kept.
+func stripTemplateActions(content []byte) []byte {
+	if !bytes.Contains(content, []byte("{{")) {
+		return content
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		if !templateActionRegex.MatchString(line) {
+			continue
+		}
+
+		if strings.TrimSpace(templateActionRegex.ReplaceAllString(line, "")) == "" {
+			lines[i] = ""
+			continue
+		}
+
+		lines[i] = templateActionRegex.ReplaceAllString(line, templateActionPlaceholder)
+	}
+
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// parseManifests returns the root of the YAML documents which are Kubernetes
+// manifests, ie. which have an `apiVersion` and a `kind`
+func parseManifests(content []byte) ([]*yaml.Node, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	var manifests []*yaml.Node
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				return manifests, nil
+			}
+
+			return nil, err
+		}
+
+		if len(document.Content) == 0 {
+			continue
+		}
+
+		root := document.Content[0]
+		if mappingValue(root, "apiVersion") != nil && mappingValue(root, "kind") != nil {
+			manifests = append(manifests, root)
+		}
+	}
+}
+
+type reporter struct {
+	file   *file.FileInfo
+	report report.Report
+}
+
+func (reporter *reporter) processManifest(manifest *yaml.Node) {
+	reporter.processEnvironmentVariables(manifest)
+
+	switch mappingValue(manifest, "kind").Value {
+	case "ConfigMap":
+		reporter.processConfigMap(manifest)
+	case "Service":
+		// type: ExternalName services are aliases for an external host
+		if spec := mappingValue(manifest, "spec"); spec != nil {
+			if externalName := mappingValue(spec, "externalName"); externalName != nil {
+				reporter.reportEndpoint("externalName", externalName)
+			}
+		}
+	}
+}
+
+// processEnvironmentVariables reports the environment variables of the
+// containers found anywhere in a manifest, eg. in the template of a
+// Deployment or in the job template of a CronJob
+func (reporter *reporter) processEnvironmentVariables(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			value := node.Content[i+1]
+
+			if key.Value == "env" && value.Kind == yaml.SequenceNode {
+				reporter.processEnv(value)
+				continue
+			}
+
+			reporter.processEnvironmentVariables(value)
+		}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			reporter.processEnvironmentVariables(child)
+		}
+	}
+}
+
+// processEnv reports the variables of a container, eg.
+//
+//	env:
+//	  - name: DATABASE_PASSWORD
+//	    value: s3cr3t
+func (reporter *reporter) processEnv(env *yaml.Node) {
+	for _, variable := range env.Content {
+		name := mappingValue(variable, "name")
+		value := mappingValue(variable, "value")
+		if name == nil || value == nil || value.Kind != yaml.ScalarNode {
+			continue
+		}
+
+		if credentials.IsName(name.Value) && isLiteral(value.Value) {
+			reporter.reportRisk(ruleSecretInEnv, name.Value, value)
+		}
+
+		reporter.reportEndpoint(name.Value, value)
+	}
+}
+
+// processConfigMap reports the entries of a ConfigMap, eg.
+//
+//	data:
+//	  database_url: postgres://app:s3cr3t@db/app
+func (reporter *reporter) processConfigMap(manifest *yaml.Node) {
+	data := mappingValue(manifest, "data")
+	if data == nil || data.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(data.Content); i += 2 {
+		key := data.Content[i]
+		value := data.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			continue
+		}
+
+		if (credentials.IsName(key.Value) && isLiteral(value.Value)) || (isLiteral(value.Value) && credentials.URLHasPassword(value.Value)) {
+			reporter.reportRisk(ruleSensitiveConfigMap, key.Value, value)
+		}
+
+		reporter.reportEndpoint(key.Value, value)
+	}
+}
+
+// reportRisk reports a risk at a value, described by the name holding it so
+// that secret values are not kept in the report
+func (reporter *reporter) reportRisk(ruleID string, name string, value *yaml.Node) {
+	reporter.report.AddDetection(
+		detections.TypeInfrastructureRisk,
+		detectors.DetectorKubernetes,
+		reporter.sourceFor(value, name),
+		infrastructure.Risk{RuleID: ruleID, Description: name},
+	)
+}
+
+// reportEndpoint reports the data store or service a value points to, eg.
+// `postgres://db/app` or `https://api.stripe.com`
+func (reporter *reporter) reportEndpoint(name string, value *yaml.Node) {
+	text := value.Value
+	if strings.Contains(text, templateActionPlaceholder) || strings.Contains(text, "\n") {
+		return
+	}
+
+	// connection strings describe a data store rather than an interface
+	if dataStore := connection.Parse(text); dataStore != nil {
+		reporter.report.AddFramework(detectors.DetectorKubernetes, connection.TypeDataStore, connection.Variable{
+			Name:      name,
+			DataStore: *dataStore,
+		}, reporter.sourceFor(value, ""))
+
+		return
+	}
+
+	parsedValue := values.New()
+	parsedValue.AppendString(text)
+
+	interfaceType, isInterface := interfaces.GetTypeWithKey(name, parsedValue)
+	if isInterface {
+		reporter.report.AddInterface(detectors.DetectorKubernetes, reportinterface.Interface{
+			Value:        parsedValue,
+			Type:         interfaceType,
+			VariableName: name,
+		}, reporter.sourceFor(value, text))
+	}
+}
+
+func (reporter *reporter) sourceFor(node *yaml.Node, text string) source.Source {
+	return source.New(
+		reporter.file,
+		reporter.file.Path,
+		node.Line,
+		node.Column,
+		node.Line,
+		node.Column+len(node.Value),
+		text,
+	)
+}
+
+// isLiteral tells whether a value is set in the manifest, rather than from a
+// variable or by a Helm template
+func isLiteral(value string) bool {
+	return strings.TrimSpace(value) != "" &&
+		!strings.Contains(value, templateActionPlaceholder) &&
+		!variableReferenceRegex.MatchString(value)
+}
+
+// mappingValue returns the value of a key of a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
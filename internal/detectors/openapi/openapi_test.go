@@ -46,3 +46,9 @@ func TestOtherjson(t *testing.T) {
 
 	cupaloy.SnapshotT(t, report.Detections)
 }
+
+func TestDetectorV3Operations(t *testing.T) {
+	report := testhelper.Extract(t, filepath.Join("testdata", "v3servers"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, report.Detections)
+}
@@ -0,0 +1,188 @@
+package queries
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/internal/report/operations"
+	"github.com/bearer/bearer/internal/util/file"
+	"github.com/bearer/bearer/internal/util/maputil"
+)
+
+var httpMethods = map[string]struct{}{
+	"get":     {},
+	"put":     {},
+	"post":    {},
+	"delete":  {},
+	"options": {},
+	"head":    {},
+	"patch":   {},
+	"trace":   {},
+}
+
+type operationsDocument struct {
+	Paths      map[string]map[string]yaml.Node `yaml:"paths"`
+	Components struct {
+		Schemas map[string]schemaObject `yaml:"schemas"`
+	} `yaml:"components"`
+	Definitions map[string]schemaObject `yaml:"definitions"`
+}
+
+type operationObject struct {
+	OperationId string      `yaml:"operationId"`
+	Parameters  []parameter `yaml:"parameters"`
+	RequestBody struct {
+		Content map[string]mediaType `yaml:"content"`
+	} `yaml:"requestBody"`
+	Responses map[string]response `yaml:"responses"`
+}
+
+type parameter struct {
+	Name   string        `yaml:"name"`
+	In     string        `yaml:"in"`
+	Schema *schemaObject `yaml:"schema"`
+}
+
+type response struct {
+	Content map[string]mediaType `yaml:"content"`
+	Schema  *schemaObject        `yaml:"schema"`
+}
+
+type mediaType struct {
+	Schema *schemaObject `yaml:"schema"`
+}
+
+type schemaObject struct {
+	Ref        string                  `yaml:"$ref"`
+	Items      *schemaObject           `yaml:"items"`
+	Properties map[string]schemaObject `yaml:"properties"`
+	AllOf      []schemaObject          `yaml:"allOf"`
+	OneOf      []schemaObject          `yaml:"oneOf"`
+	AnyOf      []schemaObject          `yaml:"anyOf"`
+}
+
+// OperationKey identifies an operation of the document by its path and HTTP
+// method
+func OperationKey(path string, method string) string {
+	return strings.ToLower(method) + " " + path
+}
+
+// IsHTTPMethod tells whether a key of a path item is an operation. Path items
+// can also hold shared parameters, servers and descriptions.
+func IsHTTPMethod(key string) bool {
+	_, ok := httpMethods[strings.ToLower(key)]
+	return ok
+}
+
+// FindOperations returns the operations of the document, keyed by
+// OperationKey, with the names of the schemas used by their requests and
+// responses. Schemas are followed through their references so that nested
+// objects are included.
+func FindOperations(file *file.FileInfo) map[string]operations.Operation {
+	fileBytes, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return nil
+	}
+
+	// JSON documents are valid YAML
+	var document operationsDocument
+	if err := yaml.NewDecoder(bytes.NewBuffer(fileBytes)).Decode(&document); err != nil {
+		return nil
+	}
+
+	definitions := document.Components.Schemas
+	if len(definitions) == 0 {
+		definitions = document.Definitions
+	}
+
+	urls := FindUrls(file)
+
+	result := make(map[string]operations.Operation)
+	for path, pathItem := range document.Paths {
+		for method, node := range pathItem {
+			if !IsHTTPMethod(method) {
+				continue
+			}
+
+			var operation operationObject
+			if err := node.Decode(&operation); err != nil {
+				continue
+			}
+
+			requestSchemas := make(map[string]struct{})
+			responseSchemas := make(map[string]struct{})
+
+			for _, parameter := range operation.Parameters {
+				collectSchemas(parameter.Schema, definitions, requestSchemas)
+			}
+			for _, content := range operation.RequestBody.Content {
+				collectSchemas(content.Schema, definitions, requestSchemas)
+			}
+			for _, response := range operation.Responses {
+				collectSchemas(response.Schema, definitions, responseSchemas)
+				for _, content := range response.Content {
+					collectSchemas(content.Schema, definitions, responseSchemas)
+				}
+			}
+
+			// parameters are reported as the fields of an object named after the
+			// operation
+			if operation.OperationId != "" && len(operation.Parameters) != 0 {
+				requestSchemas[operation.OperationId] = struct{}{}
+			}
+
+			result[OperationKey(path, method)] = operations.Operation{
+				Path:            path,
+				Type:            strings.ToUpper(method),
+				Urls:            urls,
+				OperationId:     operation.OperationId,
+				RequestSchemas:  maputil.SortedStringKeys(requestSchemas),
+				ResponseSchemas: maputil.SortedStringKeys(responseSchemas),
+			}
+		}
+	}
+
+	return result
+}
+
+func collectSchemas(schema *schemaObject, definitions map[string]schemaObject, found map[string]struct{}) {
+	if schema == nil {
+		return
+	}
+
+	if schema.Ref != "" {
+		// references to other documents are not followed
+		if !strings.HasPrefix(schema.Ref, "#/") {
+			return
+		}
+
+		name := schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]
+		if _, seen := found[name]; seen {
+			return
+		}
+
+		found[name] = struct{}{}
+		if definition, ok := definitions[name]; ok {
+			collectSchemas(&definition, definitions, found)
+		}
+
+		return
+	}
+
+	collectSchemas(schema.Items, definitions, found)
+
+	for _, property := range schema.Properties {
+		property := property
+		collectSchemas(&property, definitions, found)
+	}
+
+	for _, group := range [][]schemaObject{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, child := range group {
+			child := child
+			collectSchemas(&child, definitions, found)
+		}
+	}
+}
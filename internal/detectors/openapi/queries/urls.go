@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"os"
+	"strings"
 
 	"github.com/bearer/bearer/internal/report/operations"
 	"github.com/bearer/bearer/internal/util/file"
@@ -12,11 +13,33 @@ import (
 
 type Document struct {
 	Servers []Url `yaml:"servers" json:"servers"`
+	// OpenAPI 2 documents declare a single host
+	Host     string   `yaml:"host" json:"host"`
+	BasePath string   `yaml:"basePath" json:"basePath"`
+	Schemes  []string `yaml:"schemes" json:"schemes"`
 }
 
 type Url struct {
 	Url       string              `yaml:"url" json:"url"`
 	Variables map[string]Variable `yaml:"variables" json:"variables"`
+	Line      int                 `yaml:"-" json:"-"`
+}
+
+// Server is a URL the API is served from, with its variables replaced by
+// their default values
+type Server struct {
+	Url  string
+	Line int
+}
+
+func (url *Url) UnmarshalYAML(node *yaml.Node) error {
+	type plainUrl Url
+	if err := node.Decode((*plainUrl)(url)); err != nil {
+		return err
+	}
+
+	url.Line = node.Line
+	return nil
 }
 
 type Variable struct {
@@ -25,21 +48,54 @@ type Variable struct {
 	Default string   `yaml:"default" json:"default"`
 }
 
-func FindUrls(file *file.FileInfo) (urls []operations.Url) {
+func readDocument(file *file.FileInfo) (document Document) {
 	fileBytes, err := os.ReadFile(file.AbsolutePath)
 	if err != nil {
-		return nil
+		return
 	}
 
-	var document Document
-
 	err = yaml.NewDecoder(bytes.NewBuffer(fileBytes)).Decode(&document)
 
 	if err != nil {
 		json.NewDecoder(bytes.NewBuffer(fileBytes)).Decode(&document) //nolint:all,errcheck
 	}
 
-	for _, url := range document.Servers {
+	if len(document.Servers) == 0 && document.Host != "" {
+		schemes := document.Schemes
+		if len(schemes) == 0 {
+			schemes = []string{"https"}
+		}
+
+		for _, scheme := range schemes {
+			document.Servers = append(document.Servers, Url{Url: scheme + "://" + document.Host + document.BasePath})
+		}
+	}
+
+	return
+}
+
+// FindServers returns the URLs declared by the document, for classification
+// as components
+func FindServers(file *file.FileInfo) (servers []Server) {
+	for _, url := range readDocument(file).Servers {
+		value := url.Url
+		for name, variable := range url.Variables {
+			replacement := variable.Default
+			if replacement == "" && len(variable.Values) != 0 {
+				replacement = variable.Values[0]
+			}
+
+			value = strings.ReplaceAll(value, "{"+name+"}", replacement)
+		}
+
+		servers = append(servers, Server{Url: value, Line: url.Line})
+	}
+
+	return
+}
+
+func FindUrls(file *file.FileInfo) (urls []operations.Url) {
+	for _, url := range readDocument(file).Servers {
 
 		returnedUrl := operations.Url{
 			Url: url.Url,
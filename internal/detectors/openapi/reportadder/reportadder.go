@@ -3,12 +3,18 @@ package reportadder
 import (
 	"sort"
 
+	"github.com/bearer/bearer/internal/detectors/openapi/queries"
 	"github.com/bearer/bearer/internal/parser"
 	"github.com/bearer/bearer/internal/parser/nodeid"
 	reporttypes "github.com/bearer/bearer/internal/report"
+	"github.com/bearer/bearer/internal/report/detections"
 	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/interfaces"
+	"github.com/bearer/bearer/internal/report/operations/operationshelper"
 	"github.com/bearer/bearer/internal/report/schema"
 	"github.com/bearer/bearer/internal/report/schema/schemahelper"
+	"github.com/bearer/bearer/internal/report/source"
+	"github.com/bearer/bearer/internal/report/values"
 	"github.com/bearer/bearer/internal/util/file"
 	"github.com/bearer/bearer/internal/util/stringutil"
 )
@@ -59,17 +65,19 @@ func AddSchema(file *file.FileInfo, report reporttypes.Report, foundValues map[p
 			report.SchemaGroupEnd(idGenerator)
 		}
 
+		fieldNode := node
 		if !report.SchemaGroupIsOpen() {
+			// object names are not kept, so the group is identified by its first
+			// field
 			report.SchemaGroupBegin(
 				detectors.DetectorOpenAPI,
-				nil,
+				&fieldNode,
 				schema.Value,
 				&schema.Source,
 				nil,
 			)
 		}
 
-		fieldNode := node
 		report.SchemaGroupAddItem(
 			&fieldNode,
 			schema.Value,
@@ -80,6 +88,51 @@ func AddSchema(file *file.FileInfo, report reporttypes.Report, foundValues map[p
 	report.SchemaGroupEnd(idGenerator)
 }
 
+// AddOperations reports the endpoints of the document, along with the schemas
+// of their requests and responses
+func AddOperations(file *file.FileInfo, report reporttypes.Report, foundValues map[parser.Node]*operationshelper.Operation) {
+	documentOperations := queries.FindOperations(file)
+
+	sortedOperations := make([]*operationshelper.Operation, 0, len(foundValues))
+	for _, operation := range foundValues {
+		if queries.IsHTTPMethod(operation.Value.Type) {
+			sortedOperations = append(sortedOperations, operation)
+		}
+	}
+	sort.Slice(sortedOperations, func(i, j int) bool {
+		return *sortedOperations[i].Source.StartLineNumber < *sortedOperations[j].Source.StartLineNumber
+	})
+
+	for _, operation := range sortedOperations {
+		value, ok := documentOperations[queries.OperationKey(operation.Value.Path, operation.Value.Type)]
+		if !ok {
+			continue
+		}
+
+		operation.Source.Language = file.Language
+		operation.Source.LanguageType = file.LanguageTypeString()
+		report.AddDetection(detections.TypeOperation, detectors.DetectorOpenAPI, operation.Source, value)
+	}
+}
+
+// AddServers reports the URLs the API is served from so that they are
+// classified as components
+func AddServers(file *file.FileInfo, report reporttypes.Report) {
+	for _, server := range queries.FindServers(file) {
+		value := values.New()
+		value.AppendString(server.Url)
+
+		report.AddInterface(
+			detectors.DetectorOpenAPI,
+			interfaces.Interface{
+				Type:  interfaces.TypeURL,
+				Value: value,
+			},
+			source.New(file, file.Path, server.Line, 0, server.Line, 0, server.Url),
+		)
+	}
+}
+
 func convertSchema(value string) string {
 	switch value {
 	case "string":
@@ -69,6 +69,8 @@ func ProcessFile(idGenerator nodeid.Generator, file *file.FileInfo, report repor
 	}
 
 	reportadder.AddSchema(file, report, foundValues, idGenerator)
+	reportadder.AddOperations(file, report, foundPaths)
+	reportadder.AddServers(file, report)
 
 	return true, err
 }
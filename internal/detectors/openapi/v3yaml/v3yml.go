@@ -67,6 +67,8 @@ func ProcessFile(idGenerator nodeid.Generator, file *file.FileInfo, report repor
 	}
 
 	reportadder.AddSchema(file, report, foundSchemas, idGenerator)
+	reportadder.AddOperations(file, report, foundPaths)
+	reportadder.AddServers(file, report)
 
 	return true, err
 }
@@ -1,16 +1,20 @@
 package proto
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/smacker/go-tree-sitter/protobuf"
 
 	"github.com/bearer/bearer/internal/detectors/types"
 	"github.com/bearer/bearer/internal/parser"
+	"github.com/bearer/bearer/internal/report/detections"
 	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/operations"
 	"github.com/bearer/bearer/internal/report/schema"
 	"github.com/bearer/bearer/internal/util/file"
 	"github.com/bearer/bearer/internal/util/pluralize"
+	"github.com/bearer/bearer/internal/util/set"
 
 	"github.com/bearer/bearer/internal/parser/nodeid"
 	parserschema "github.com/bearer/bearer/internal/parser/schema"
@@ -24,21 +28,39 @@ var (
 		message
 		(message_name (identifier) @object_name)
 			(message_body
-				(
-					field (
-						(type) @field_type
-						(identifier) @field_name
-					)
-				)
+				[
+					(field (type) @field_type (identifier) @field_name)
+					(map_field (key_type) @key_type (type) @field_type (identifier) @field_name)
+					(oneof (oneof_field (type) @field_type (identifier) @field_name))
+				]
 			)
 	)
 	`)
+
+	packageQuery = parser.QueryMustCompile(language, `(package (full_ident) @package)`)
+
+	servicesQuery = parser.QueryMustCompile(language, `
+	(
+		service
+		(service_name (identifier) @service_name)
+		(rpc (rpc_name (identifier) @rpc_name)) @rpc
+	)
+	`)
 )
 
 type detector struct {
 	idGenerator nodeid.Generator
 }
 
+// item is a field of a message, kept until all the fields of the file are
+// known so that nested messages don't split the group of their parent
+type item struct {
+	objectNode *parser.Node
+	fieldNode  *parser.Node
+	fieldType  string
+	simpleType string
+}
+
 func New(idGenerator nodeid.Generator) types.Detector {
 	return &detector{
 		idGenerator: idGenerator,
@@ -69,63 +91,170 @@ func (detector *detector) ExtractFromSchema(
 	}
 	defer tree.Close()
 
-	uuidHolder := parserschema.NewUUIDHolder()
+	var items []item
+	// the types of the fields of each message, to resolve the messages used by
+	// the procedures of services
+	fieldTypes := make(map[string][]string)
 
 	err = tree.Query(protoSchemaQuery, func(captures parser.Captures) error {
 		objectNode := captures["object_name"]
+		fieldType := stripQuotes(captures["field_type"].Content())
+		simpleType := convertToSimpleType(fieldType)
+
 		objectName := stripQuotes(objectNode.Content())
+		fieldTypes[objectName] = append(fieldTypes[objectName], messageName(fieldType))
 
-		columnType := stripQuotes(captures["field_type"].Content())
+		if keyType, isMap := captures["key_type"]; isMap {
+			fieldType = "map<" + keyType.Content() + ", " + fieldType + ">"
+			simpleType = schema.SimpleTypeObject
+		}
 
-		fieldNode := captures["field_name"]
-		fieldName := stripQuotes(fieldNode.Content())
+		items = append(items, item{
+			objectNode: objectNode,
+			fieldNode:  captures["field_name"],
+			fieldType:  fieldType,
+			simpleType: simpleType,
+		})
 
-		objectUUID := uuidHolder.Assign(objectNode.ID(), detector.idGenerator)
-		fieldUUID := uuidHolder.Assign(fieldNode.ID(), detector.idGenerator)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
-		normalizedObjectName := ""
-		normalizedFieldName := ""
-		normalizedObjectName = pluralize.Singular(strings.ToLower(objectName))
-		normalizedFieldName = pluralize.Singular(strings.ToLower(fieldName))
+	detector.addSchemas(items, report)
 
-		currentSchema := schema.Schema{
-			ObjectName:           objectName,
-			ObjectUUID:           objectUUID,
-			FieldName:            fieldName,
-			FieldUUID:            fieldUUID,
-			FieldType:            columnType,
-			SimpleFieldType:      convertToSimpleType(columnType),
-			NormalizedObjectName: normalizedObjectName,
-			NormalizedFieldName:  normalizedFieldName,
-		}
+	return addServices(tree, fieldTypes, report)
+}
 
-		if report.SchemaGroupShouldClose(objectName) {
-			report.SchemaGroupEnd(detector.idGenerator)
+// addSchemas reports the items grouped by the message they belong to, in the
+// order the messages appear
+func (detector *detector) addSchemas(items []item, report reporttypes.Report) {
+	var objectNodes []*parser.Node
+	itemsByObject := make(map[parser.NodeID][]item)
+	for _, item := range items {
+		objectID := item.objectNode.ID()
+		if _, seen := itemsByObject[objectID]; !seen {
+			objectNodes = append(objectNodes, item.objectNode)
 		}
 
-		if !report.SchemaGroupIsOpen() {
-			source := objectNode.Source(true)
-			report.SchemaGroupBegin(
-				detectors.DetectorProto,
-				objectNode,
+		itemsByObject[objectID] = append(itemsByObject[objectID], item)
+	}
+
+	uuidHolder := parserschema.NewUUIDHolder()
+
+	for _, objectNode := range objectNodes {
+		objectName := stripQuotes(objectNode.Content())
+
+		for _, item := range itemsByObject[objectNode.ID()] {
+			fieldName := stripQuotes(item.fieldNode.Content())
+
+			currentSchema := schema.Schema{
+				ObjectName:           objectName,
+				ObjectUUID:           uuidHolder.Assign(objectNode.ID(), detector.idGenerator),
+				FieldName:            fieldName,
+				FieldUUID:            uuidHolder.Assign(item.fieldNode.ID(), detector.idGenerator),
+				FieldType:            item.fieldType,
+				SimpleFieldType:      item.simpleType,
+				NormalizedObjectName: pluralize.Singular(strings.ToLower(objectName)),
+				NormalizedFieldName:  pluralize.Singular(strings.ToLower(fieldName)),
+			}
+
+			if !report.SchemaGroupIsOpen() {
+				source := objectNode.Source(true)
+				report.SchemaGroupBegin(
+					detectors.DetectorProto,
+					objectNode,
+					currentSchema,
+					&source,
+					nil,
+				)
+			}
+			source := item.fieldNode.Source(true)
+			report.SchemaGroupAddItem(
+				item.fieldNode,
 				currentSchema,
 				&source,
-				nil,
 			)
 		}
-		source := fieldNode.Source(true)
-		report.SchemaGroupAddItem(
-			fieldNode,
-			currentSchema,
-			&source,
-		)
 
+		report.SchemaGroupEnd(detector.idGenerator)
+	}
+}
+
+// addServices reports each procedure of a gRPC service as an operation, with
+// the path used by gRPC clients and the messages it receives and returns
+func addServices(tree *parser.Tree, fieldTypes map[string][]string, report reporttypes.Report) error {
+	packageName := ""
+	err := tree.Query(packageQuery, func(captures parser.Captures) error {
+		packageName = strings.ReplaceAll(captures["package"].Content(), " ", "")
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	return tree.Query(servicesQuery, func(captures parser.Captures) error {
+		serviceName := captures["service_name"].Content()
+		if packageName != "" {
+			serviceName = packageName + "." + serviceName
+		}
+
+		rpcNameNode := captures["rpc_name"]
 
-	report.SchemaGroupEnd(detector.idGenerator)
+		var messages []string
+		rpcNode := captures["rpc"]
+		for i := 0; i < rpcNode.NamedChildCount(); i++ {
+			child := rpcNode.Child(i)
+			if child.Type() == "message_or_enum_type" {
+				messages = append(messages, messageName(child.Content()))
+			}
+		}
 
-	return err
+		requestSchemas := set.New[string]()
+		responseSchemas := set.New[string]()
+		if len(messages) == 2 {
+			collectSchemas(messages[0], fieldTypes, requestSchemas)
+			collectSchemas(messages[1], fieldTypes, responseSchemas)
+		}
+
+		report.AddDetection(detections.TypeOperation, detectors.DetectorProto, rpcNameNode.Source(true), operations.Operation{
+			Path:            "/" + serviceName + "/" + rpcNameNode.Content(),
+			Type:            operations.TypeRPC,
+			RequestSchemas:  sortedItems(requestSchemas),
+			ResponseSchemas: sortedItems(responseSchemas),
+		})
+
+		return nil
+	})
+}
+
+// collectSchemas adds the message and the messages of its fields,
+// recursively. Only messages defined in the file are included.
+func collectSchemas(name string, fieldTypes map[string][]string, found set.Set[string]) {
+	references, defined := fieldTypes[name]
+	if !defined || found.Has(name) {
+		return
+	}
+
+	found.Add(name)
+	for _, reference := range references {
+		collectSchemas(reference, fieldTypes, found)
+	}
+}
+
+// messageName returns the name of a message type without its package, as
+// nested and imported messages are referenced by their qualified name
+func messageName(fieldType string) string {
+	return fieldType[strings.LastIndex(fieldType, ".")+1:]
+}
+
+func sortedItems(values set.Set[string]) []string {
+	var items []string
+	items = append(items, values.Items()...)
+	sort.Strings(items)
+
+	return items
 }
 
 func stripQuotes(value string) string {
@@ -133,7 +262,7 @@ func stripQuotes(value string) string {
 }
 
 func convertToSimpleType(value string) string {
-	numberMap := []string{"double", "float", "int32", "in64", "uint32", "uint64", "sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64"}
+	numberMap := []string{"double", "float", "int32", "int64", "uint32", "uint64", "sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64"}
 	for _, typeValue := range numberMap {
 		if typeValue == value {
 			return schema.SimpleTypeNumber
@@ -152,5 +281,9 @@ func convertToSimpleType(value string) string {
 		return schema.SimpleTypeBool
 	}
 
+	if value == "google.protobuf.Timestamp" {
+		return schema.SimpleTypeDate
+	}
+
 	return schema.SimpleTypeObject
 }
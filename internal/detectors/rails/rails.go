@@ -14,6 +14,7 @@ import (
 	"github.com/bearer/bearer/internal/parser/nodeid"
 	"github.com/bearer/bearer/internal/report"
 	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
 	"github.com/bearer/bearer/internal/report/frameworks/rails"
 	"github.com/bearer/bearer/internal/report/source"
 	"github.com/bearer/bearer/internal/util/file"
@@ -144,20 +145,56 @@ func getAdapterDatabase(file *file.FileInfo, node yaml.Node, name string) (*rail
 		var adapter string
 		if adapterNode.Decode(&adapter) == nil {
 			return &rails.Database{
-					Name:    name,
-					Adapter: adapter,
-				}, &source.Source{
-					Language:        file.Language,
-					LanguageType:    file.LanguageTypeString(),
-					Filename:        file.RelativePath,
-					StartLineNumber: &node.Line,
-				}, nil
+				Name:      name,
+				Adapter:   adapter,
+				DataStore: getDataStore(adapter, config),
+			}, &source.Source{
+				Language:        file.Language,
+				LanguageType:    file.LanguageTypeString(),
+				Filename:        file.RelativePath,
+				StartLineNumber: &node.Line,
+			}, nil
 		}
 	}
 
 	return nil, nil, nil
 }
 
+// getDataStore reads the connection details of a database, from its url or
+// from the individual settings, which take precedence in Rails
+func getDataStore(adapter string, config map[string]yaml.Node) *connection.DataStore {
+	host := getString(config, "host")
+	database := getString(config, "database")
+
+	dataStore := connection.Parse(getString(config, "url"))
+	if dataStore == nil {
+		engine, ok := connection.Engine(adapter)
+		if !ok {
+			engine = strings.ToLower(adapter)
+		}
+
+		dataStore = &connection.DataStore{Engine: engine}
+	}
+
+	if host != "" {
+		dataStore.Host = host
+	}
+	if database != "" {
+		dataStore.Database = database
+	}
+
+	return dataStore
+}
+
+func getString(config map[string]yaml.Node, key string) string {
+	node, exists := config[key]
+	if !exists || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return node.Value
+}
+
 func extractStorage(file *file.FileInfo, report report.Report) error {
 	bytes, err := os.ReadFile(file.AbsolutePath)
 	if len(bytes) == 0 || err != nil {
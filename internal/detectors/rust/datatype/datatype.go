@@ -0,0 +1,175 @@
+package datatype
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bearer/bearer/internal/parser"
+	"github.com/bearer/bearer/internal/parser/datatype"
+	"github.com/bearer/bearer/internal/parser/nodeid"
+	"github.com/bearer/bearer/internal/report"
+	"github.com/bearer/bearer/internal/report/detections"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/schema"
+	schemadatatype "github.com/bearer/bearer/internal/report/schema/datatype"
+	"github.com/smacker/go-tree-sitter/rust"
+)
+
+// #[serde(rename = "email_address")]
+var serdeRenameRegex = regexp.MustCompile(`\Aserde\s*\(.*\brename\s*=\s*"([^"]+)"`)
+
+var structsQuery = parser.QueryMustCompile(rust.GetLanguage(),
+	`(struct_item
+		name: (type_identifier) @param_name
+	) @param_struct`)
+
+// struct User { email: String }
+var structFieldsQuery = parser.QueryMustCompile(rust.GetLanguage(),
+	`(struct_item
+		name: (type_identifier) @param_name
+		body: (field_declaration_list
+			(field_declaration
+				name: (field_identifier) @param_id
+				type: (_) @param_type
+			) @param_node
+		)
+	) @param_struct`)
+
+// impl User { fn full_name(&self) {} }
+var implFunctionsQuery = parser.QueryMustCompile(rust.GetLanguage(),
+	`(impl_item
+		type: (type_identifier) @param_name
+		body: (declaration_list
+			(function_item
+				name: (identifier) @param_id
+			) @param_node
+		)
+	)`)
+
+func Discover(report report.Report, tree *parser.Tree, idGenerator nodeid.Generator) {
+	datatypes := make(map[parser.NodeID]*schemadatatype.DataType)
+	structsByName := make(map[string]*schemadatatype.DataType)
+
+	// add structs
+	captures := tree.QueryConventional(structsQuery)
+	for _, capture := range captures {
+		name := capture["param_name"].Content()
+		structNode := capture["param_struct"]
+
+		datatypes[structNode.ID()] = &schemadatatype.DataType{
+			Node:       structNode,
+			Name:       name,
+			Type:       schema.SimpleTypeObject,
+			TextType:   "struct",
+			Properties: make(map[string]schemadatatype.DataTypable),
+		}
+		structsByName[name] = datatypes[structNode.ID()]
+	}
+
+	discoverFields(tree, datatypes)
+	discoverFunctions(tree, structsByName)
+
+	datatype.PruneMap(datatypes)
+
+	report.AddDataType(detections.TypeSchema, detectors.DetectorRust, idGenerator, datatypes, nil)
+}
+
+func discoverFields(tree *parser.Tree, datatypes map[parser.NodeID]*schemadatatype.DataType) {
+	captures := tree.QueryConventional(structFieldsQuery)
+	for _, capture := range captures {
+		structNode := capture["param_struct"]
+		if datatypes[structNode.ID()] == nil {
+			continue
+		}
+
+		// get node
+		fieldNode := capture["param_node"]
+
+		// get field name, which is the serialized name when renamed with serde
+		fieldName := capture["param_id"].Content()
+		if renamed := serdeRename(tree, fieldNode); renamed != "" {
+			fieldName = renamed
+		}
+
+		// get field type
+		fieldTypeNode := capture["param_type"]
+
+		datatypes[structNode.ID()].Properties[fieldName] = &schemadatatype.DataType{
+			Node:       fieldNode,
+			Name:       fieldName,
+			Type:       standardizeDataType(fieldTypeNode),
+			TextType:   fieldTypeNode.Content(),
+			Properties: make(map[string]schemadatatype.DataTypable),
+		}
+	}
+}
+
+// methods are declared separately from the struct, in an impl block of the same
+// file
+func discoverFunctions(tree *parser.Tree, structsByName map[string]*schemadatatype.DataType) {
+	captures := tree.QueryConventional(implFunctionsQuery)
+	for _, capture := range captures {
+		structDataType := structsByName[capture["param_name"].Content()]
+		if structDataType == nil {
+			continue
+		}
+
+		// get node
+		functionNode := capture["param_node"]
+
+		// get method name
+		functionName := capture["param_id"].Content()
+
+		structDataType.Properties[functionName] = &schemadatatype.DataType{
+			Node:       functionNode,
+			Name:       functionName,
+			Type:       schema.SimpleTypeFunction,
+			TextType:   "",
+			Properties: make(map[string]schemadatatype.DataTypable),
+		}
+	}
+}
+
+// serdeRename returns the name given to a field by a serde attribute
+func serdeRename(tree *parser.Tree, fieldNode *parser.Node) string {
+	for sibling := fieldNode.Sitter().PrevNamedSibling(); sibling != nil && sibling.Type() == "attribute_item"; sibling = sibling.PrevNamedSibling() {
+		attribute := tree.Wrap(sibling).Child(0)
+		if attribute == nil {
+			continue
+		}
+
+		if match := serdeRenameRegex.FindStringSubmatch(attribute.Content()); match != nil {
+			return match[1]
+		}
+	}
+
+	return ""
+}
+
+func standardizeDataType(node *parser.Node) string {
+	// optional fields, eg. Option<String>
+	if node.Type() == "generic_type" && node.ChildByFieldName("type").Content() == "Option" {
+		arguments := node.ChildByFieldName("type_arguments")
+		if arguments != nil && arguments.NamedChildCount() == 1 {
+			return standardizeDataType(arguments.Child(0))
+		}
+	}
+
+	content := strings.TrimPrefix(node.Content(), "&")
+	switch content {
+	case "String", "str", "'static str", "char":
+		return schema.SimpleTypeString
+	case "i8", "i16", "i32", "i64", "i128", "isize", "u16", "u32", "u64", "u128", "usize", "f32", "f64":
+		return schema.SimpleTypeNumber
+	case "u8", "Vec<u8>", "[u8]":
+		return schema.SimpleTypeBinary
+	case "bool":
+		return schema.SimpleTypeBool
+	}
+
+	if node.Type() == "type_identifier" || node.Type() == "generic_type" || node.Type() == "scoped_type_identifier" {
+		return schema.SimpleTypeObject
+	}
+
+	return schema.SimpleTypeUnknown
+}
@@ -0,0 +1,289 @@
+package rust
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/rust"
+
+	"github.com/bearer/bearer/internal/detectors/rust/datatype"
+	"github.com/bearer/bearer/internal/detectors/types"
+	"github.com/bearer/bearer/internal/parser"
+	"github.com/bearer/bearer/internal/parser/interfacedetector"
+	"github.com/bearer/bearer/internal/parser/nodeid"
+	"github.com/bearer/bearer/internal/report"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/values"
+	"github.com/bearer/bearer/internal/report/variables"
+	"github.com/bearer/bearer/internal/util/file"
+)
+
+var (
+	language = rust.GetLanguage()
+
+	// env::var("KEY") or std::env::var("KEY")
+	environmentVariableQuery = parser.QueryMustCompile(language, `
+		(call_expression
+			function: (scoped_identifier) @function
+			arguments: (arguments . (string_literal) @key .)) @node
+	`)
+
+	// env!("KEY") or option_env!("KEY")
+	environmentVariableMacroQuery = parser.QueryMustCompile(language, `
+		(macro_invocation
+			macro: (identifier) @macro
+			(token_tree . (string_literal) @key .)) @node
+	`)
+
+	rawStringRegex         = regexp.MustCompile(`\Ab?r(#*)"((?s).*)"#*\z`)
+	formatPlaceholderRegex = regexp.MustCompile(`\{\{|\}\}|\{[^{}]*\}`)
+)
+
+type detector struct {
+	idGenerator nodeid.Generator
+}
+
+func New(idGenerator nodeid.Generator) types.Detector {
+	return &detector{
+		idGenerator: idGenerator,
+	}
+}
+
+func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, report report.Report) (bool, error) {
+	if file.Language != "Rust" {
+		return false, nil
+	}
+
+	tree, err := parser.ParseFile(file, file.Path, language)
+	if err != nil {
+		return false, err
+	}
+	defer tree.Close()
+
+	if err := annotate(tree); err != nil {
+		return false, err
+	}
+
+	datatype.Discover(report, tree, detector.idGenerator)
+
+	if err := interfacedetector.Detect(&interfacedetector.Request{
+		Tree:             tree,
+		Report:           report,
+		DetectorType:     detectors.DetectorRust,
+		AcceptExpression: acceptExpression,
+		PathAllowed:      false,
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func annotate(tree *parser.Tree) error {
+	if err := annotateEnvironmentVariables(tree); err != nil {
+		return err
+	}
+
+	return tree.Annotate(func(node *parser.Node, value *values.Value) {
+		switch node.Type() {
+		case "binary_expression":
+			if node.ChildByFieldName("operator").Content() == "+" {
+				value.Append(node.ChildByFieldName("left").Value())
+				value.Append(node.ChildByFieldName("right").Value())
+
+				return
+			}
+		case "reference_expression":
+			value.Append(node.ChildByFieldName("value").Value())
+
+			return
+		case "macro_invocation":
+			if node.ChildByFieldName("macro").Content() == "format" {
+				annotateFormat(node, value)
+
+				return
+			}
+		case "identifier":
+			value.AppendVariableReference(variables.VariableName, node.Content())
+
+			return
+		case "string_literal":
+			node.EachPart(func(text string) error { //nolint:all,errcheck
+				value.AppendString(text)
+
+				return nil
+			}, func(child *parser.Node) error {
+				value.Append(child.Value())
+
+				return nil
+			})
+
+			return
+		case "raw_string_literal":
+			value.AppendString(rawStringRegex.ReplaceAllString(node.Content(), "$2"))
+
+			return
+		case "escape_sequence":
+			value.AppendString(strings.TrimPrefix(node.Content(), `\`))
+
+			return
+		case "source_file", "function_item", "impl_item", "struct_item", "field_declaration_list", "field_declaration",
+			"declaration_list", "block", "let_declaration", "use_declaration", "mod_item", "attribute_item",
+			"type_identifier", "primitive_type", "scoped_identifier":
+			return
+		}
+
+		value.AppendUnknown(node.ChildValueParts())
+	})
+}
+
+// annotateFormat sets the value of a `format!` call, with the placeholders
+// replaced by the values of the arguments in order
+func annotateFormat(node *parser.Node, value *values.Value) {
+	tokens := node.Child(node.ChildCount() - 1)
+	if tokens.Type() != "token_tree" {
+		value.AppendUnknown(node.ChildValueParts())
+		return
+	}
+
+	arguments := macroArguments(tokens)
+	if len(arguments) == 0 || len(arguments[0]) != 1 || arguments[0][0].Type() != "string_literal" {
+		value.AppendUnknown(node.ChildValueParts())
+		return
+	}
+
+	text := strings.Trim(arguments[0][0].Content(), `"`)
+	nextArgument := 1
+	start := 0
+	appendText := func(text string) {
+		if text != "" {
+			value.AppendString(text)
+		}
+	}
+
+	for _, placeholder := range formatPlaceholderRegex.FindAllStringIndex(text, -1) {
+		appendText(text[start:placeholder[0]])
+		start = placeholder[1]
+
+		switch text[placeholder[0]:placeholder[1]] {
+		case "{{":
+			value.AppendString("{")
+		case "}}":
+			value.AppendString("}")
+		default:
+			if nextArgument < len(arguments) {
+				value.Append(macroArgumentValue(arguments[nextArgument]))
+				nextArgument++
+			} else {
+				value.AppendUnknown(nil)
+			}
+		}
+	}
+
+	appendText(text[start:])
+}
+
+// macroArguments splits the tokens of a macro call into its comma separated
+// arguments. The grammar doesn't parse the arguments into expressions, and
+// doesn't keep the punctuation as nodes
+func macroArguments(tokens *parser.Node) [][]*parser.Node {
+	input := tokens.Tree().Input()
+
+	var arguments [][]*parser.Node
+	var argument []*parser.Node
+	end := tokens.Sitter().StartByte() + 1
+	for i := 0; i < tokens.ChildCount(); i++ {
+		child := tokens.Child(i)
+
+		if strings.Contains(string(input[end:child.Sitter().StartByte()]), ",") && len(argument) != 0 {
+			arguments = append(arguments, argument)
+			argument = nil
+		}
+
+		argument = append(argument, child)
+		end = child.Sitter().EndByte()
+	}
+
+	if len(argument) != 0 {
+		arguments = append(arguments, argument)
+	}
+
+	return arguments
+}
+
+// macroArgumentValue returns the value of a macro argument. Nested
+// environment variable macros are resolved, eg. `env!("KEY")`
+func macroArgumentValue(argument []*parser.Node) *values.Value {
+	if len(argument) == 1 {
+		return argument[0].Value()
+	}
+
+	if len(argument) == 2 &&
+		argument[0].Type() == "identifier" &&
+		(argument[0].Content() == "env" || argument[0].Content() == "option_env") &&
+		argument[1].Type() == "token_tree" &&
+		argument[1].ChildCount() == 1 &&
+		argument[1].Child(0).Type() == "string_literal" {
+		value := values.New()
+		value.AppendVariableReference(variables.VariableEnvironment, strings.Trim(argument[1].Child(0).Content(), `"`))
+		return value
+	}
+
+	value := values.New()
+	value.AppendUnknown(nil)
+	return value
+}
+
+func annotateEnvironmentVariables(tree *parser.Tree) error {
+	if err := tree.Query(environmentVariableQuery, func(captures parser.Captures) error {
+		function := captures["function"].Content()
+		if function != "env::var" && function != "std::env::var" {
+			return nil
+		}
+
+		setEnvironmentVariable(captures["node"], captures["key"])
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return tree.Query(environmentVariableMacroQuery, func(captures parser.Captures) error {
+		macro := captures["macro"].Content()
+		if macro != "env" && macro != "option_env" {
+			return nil
+		}
+
+		setEnvironmentVariable(captures["node"], captures["key"])
+
+		return nil
+	})
+}
+
+func setEnvironmentVariable(node *parser.Node, keyNode *parser.Node) {
+	key := strings.Trim(keyNode.Content(), `"`)
+
+	value := values.New()
+	value.AppendVariableReference(variables.VariableEnvironment, key)
+	node.SetValue(value)
+}
+
+func acceptExpression(node *parser.Node) bool {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		// something["ignored.domain"]
+		if parent.Type() == "index_expression" {
+			return false
+		}
+
+		// something["ignored.domain"] in the arguments of a macro
+		if parent.Type() == "token_tree" && strings.HasPrefix(parent.Content(), "[") {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,15 @@
+#[derive(Serialize, Deserialize)]
+pub struct User {
+    pub name: String,
+    age: Option<u32>,
+    #[serde(rename = "email_address")]
+    email: String,
+    avatar: Vec<u8>,
+    address: Address,
+}
+
+impl User {
+    pub fn full_name(&self) -> String {
+        self.name.clone()
+    }
+}
@@ -0,0 +1,17 @@
+const API_URL: &str = "https://api.example.com";
+
+pub async fn get_only_path(client: &Client) {
+    client.get("/api/delivery-messages").send().await;
+}
+
+pub async fn get_with_url_concatenated(client: &Client, customer_id: &str) {
+    client.get(API_URL.to_owned() + "/api/customers/" + customer_id).send().await;
+}
+
+pub async fn get_with_url_formatted(client: &Client, customer_id: &str) {
+    client.get(format!("{}/api/customers/{}/transactions", API_URL, customer_id)).send().await;
+}
+
+pub async fn get_with_environment_host(client: &Client) {
+    client.get(env::var("CUSTOMERS_HOST").unwrap() + "/api/delivery-messages?num_page=1").send().await;
+}
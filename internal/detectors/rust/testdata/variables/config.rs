@@ -0,0 +1,11 @@
+fn main() {
+    let account_id = std::env::var("ACCOUNT_ID").unwrap();
+    let order_service_url = format!("{}/path?x={}", env!("ORDER_SERVICE_URL"), account_id);
+
+    // TEST: ignores other functions of env
+    let ignore = env::args("IGNORE_ME_HOST");
+    // TEST: ignores other modules
+    let ignore2 = other::var("IGNORE_ME_URL");
+
+    println!("{}", some_var["ignored.domain.com"]);
+}
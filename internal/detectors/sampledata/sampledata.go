@@ -0,0 +1,201 @@
+package sampledata
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/javascript"
+
+	"github.com/bearer/bearer/internal/detectors/types"
+	"github.com/bearer/bearer/internal/parser"
+	"github.com/bearer/bearer/internal/report"
+	"github.com/bearer/bearer/internal/report/detections"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/sampledata"
+	"github.com/bearer/bearer/internal/report/source"
+	"github.com/bearer/bearer/internal/util/file"
+)
+
+var (
+	language    = javascript.GetLanguage()
+	valuesQuery = parser.QueryMustCompile(language, `[(string) (number)] @value`)
+
+	fixtureDirs = []string{
+		"fixtures",
+		"fixture",
+		"__fixtures__",
+		"seeds",
+		"seed",
+		"seeders",
+		"testdata",
+		"test-data",
+		"mocks",
+		"__mocks__",
+	}
+	fixtureWords = []string{"fixture", "seed", "sample", "mock"}
+)
+
+type detector struct{}
+
+func New() types.Detector {
+	return &detector{}
+}
+
+func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+// ProcessFile classifies the values of CSV and JSON fixture and seed files.
+// Each data type is reported once per column or key, at its first value, so
+// that a large seed file doesn't flood the report. Other detectors still
+// process the file.
+func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, report report.Report) (bool, error) {
+	if !IsFixture(file.Path.RelativePath) {
+		return false, nil
+	}
+
+	reporter := &reporter{
+		file:     file,
+		report:   report,
+		reported: make(map[string]struct{}),
+	}
+
+	switch file.Extension {
+	case ".csv":
+		return false, reporter.processCSV()
+	case ".json":
+		return false, reporter.processJSON()
+	}
+
+	return false, nil
+}
+
+// IsFixture tells whether the file at the relative path is a CSV or JSON
+// fixture or seed file
+func IsFixture(relativePath string) bool {
+	path := strings.ToLower(filepath.ToSlash(relativePath))
+	if extension := filepath.Ext(path); extension != ".csv" && extension != ".json" {
+		return false
+	}
+
+	elements := strings.Split(path, "/")
+	for _, dir := range elements[:len(elements)-1] {
+		for _, fixtureDir := range fixtureDirs {
+			if dir == fixtureDir {
+				return true
+			}
+		}
+	}
+
+	name := elements[len(elements)-1]
+	for _, word := range fixtureWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type reporter struct {
+	file     *file.FileInfo
+	report   report.Report
+	reported map[string]struct{}
+}
+
+func (reporter *reporter) processCSV() error {
+	input, err := os.Open(reporter.file.Path.AbsolutePath)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for i, value := range record {
+			column := ""
+			if i < len(header) {
+				column = header[i]
+			}
+
+			line, columnNumber := reader.FieldPos(i)
+			reporter.add(column, value, line, columnNumber, line, columnNumber+len(value))
+		}
+	}
+}
+
+func (reporter *reporter) processJSON() error {
+	tree, err := parser.ParseFile(reporter.file, reporter.file.Path, language)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	return tree.Query(valuesQuery, func(captures parser.Captures) error {
+		node := captures["value"]
+
+		key := ""
+		if parent := node.Parent(); parent != nil && parent.Type() == "pair" {
+			keyNode := parent.ChildByFieldName("key")
+			if keyNode.Equal(node) {
+				return nil
+			}
+
+			key = strings.Trim(keyNode.Content(), `"'`)
+		}
+
+		reporter.add(
+			key,
+			strings.Trim(node.Content(), `"'`),
+			node.StartLineNumber(),
+			node.StartColumnNumber(),
+			node.EndLineNumber(),
+			node.EndColumnNumber(),
+		)
+
+		return nil
+	})
+}
+
+func (reporter *reporter) add(field, value string, startLine, startColumn, endLine, endColumn int) {
+	dataType := classify(value)
+	if dataType == "" {
+		return
+	}
+
+	key := dataType + "\x00" + field
+	if _, reported := reporter.reported[key]; reported {
+		return
+	}
+	reporter.reported[key] = struct{}{}
+
+	reporter.report.AddDetection(
+		detections.TypeSampleData,
+		detectors.DetectorSampleData,
+		source.New(reporter.file, reporter.file.Path, startLine, startColumn, endLine, endColumn, value),
+		sampledata.Sample{Description: dataType},
+	)
+}
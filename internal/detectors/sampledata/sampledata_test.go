@@ -0,0 +1,24 @@
+package sampledata_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/detectors"
+	"github.com/bearer/bearer/internal/detectors/internal/testhelper"
+	"github.com/bearer/bearer/internal/detectors/sampledata"
+	detectortypes "github.com/bearer/bearer/internal/report/detectors"
+)
+
+const detectorType = detectortypes.DetectorSampleData
+
+func TestSampleData(t *testing.T) {
+	var registrations = []detectors.InitializedDetector{{
+		Type:     detectorType,
+		Detector: sampledata.New()}}
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, detectorReport.Detections)
+}
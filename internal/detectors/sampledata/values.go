@@ -0,0 +1,196 @@
+package sampledata
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	dataTypeEmail       = "Email Address"
+	dataTypeCreditCard  = "Credit Card Number"
+	dataTypeBankAccount = "Bank Account"
+	dataTypeIDNumber    = "ID Number"
+	dataTypePhone       = "Telephone Number"
+
+	cardIndustryIdentifiers = "23456"
+)
+
+var (
+	emailRegexp = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@((?:[a-zA-Z0-9-]+\.)+[a-zA-Z]{2,})$`)
+	cardRegexp  = regexp.MustCompile(`^[0-9]{4}(?:[ -]?[0-9]{3,4}){2,4}$`)
+	ibanRegexp  = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+	ssnRegexp   = regexp.MustCompile(`^([0-9]{3})-([0-9]{2})-([0-9]{4})$`)
+	phoneRegexp = regexp.MustCompile(`^(?:\+[0-9]|\(?[0-9]{3}\)?[ .-])[0-9 ().-]{7,}[0-9]$`)
+
+	// domains reserved for documentation and testing (RFC 2606)
+	reservedDomains = []string{"example", "test", "invalid", "localhost"}
+
+	// numbers published for testing or as examples, which aren't real data
+	testCardNumbers = map[string]bool{
+		"4111111111111111": true,
+		"4242424242424242": true,
+		"4012888888881881": true,
+		"4000056655665556": true,
+		"5555555555554444": true,
+		"5105105105105100": true,
+		"378282246310005":  true,
+		"371449635398431":  true,
+		"6011111111111117": true,
+	}
+	exampleIBANs = map[string]bool{
+		"GB82WEST12345698765432": true,
+		"DE89370400440532013000": true,
+	}
+	exampleSSNs = map[string]bool{
+		"123-45-6789": true,
+		"078-05-1120": true,
+	}
+)
+
+// classify returns the name of the data type the value looks like, or an
+// empty string when it doesn't look like real personal data
+func classify(value string) string {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case isEmail(value):
+		return dataTypeEmail
+	case isCardNumber(value):
+		return dataTypeCreditCard
+	case isIBAN(value):
+		return dataTypeBankAccount
+	case isSSN(value):
+		return dataTypeIDNumber
+	case isPhoneNumber(value):
+		return dataTypePhone
+	}
+
+	return ""
+}
+
+func isEmail(value string) bool {
+	match := emailRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return false
+	}
+
+	for _, label := range strings.Split(strings.ToLower(match[1]), ".") {
+		for _, reserved := range reservedDomains {
+			if label == reserved {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isCardNumber(value string) bool {
+	if !cardRegexp.MatchString(value) {
+		return false
+	}
+
+	digits := onlyDigits(value)
+	if len(digits) < 13 || len(digits) > 19 || testCardNumbers[digits] || isRepeated(digits) {
+		return false
+	}
+
+	// card networks use these major industry identifiers, which rules out
+	// most timestamps and identifiers
+	if !strings.ContainsRune(cardIndustryIdentifiers, rune(digits[0])) {
+		return false
+	}
+
+	return luhnValid(digits)
+}
+
+func isIBAN(value string) bool {
+	compact := strings.ReplaceAll(value, " ", "")
+	if !ibanRegexp.MatchString(compact) || exampleIBANs[compact] {
+		return false
+	}
+
+	// the check digits make the rearranged number equal to 1 mod 97
+	rearranged := compact[4:] + compact[:4]
+	var numeric strings.Builder
+	for _, char := range rearranged {
+		if char >= 'A' && char <= 'Z' {
+			numeric.WriteString(strconv.Itoa(int(char-'A') + 10))
+		} else {
+			numeric.WriteRune(char)
+		}
+	}
+
+	number, ok := new(big.Int).SetString(numeric.String(), 10)
+	if !ok {
+		return false
+	}
+
+	return new(big.Int).Mod(number, big.NewInt(97)).Int64() == 1
+}
+
+// isSSN tells whether the value is a US social security number that could
+// have been issued
+func isSSN(value string) bool {
+	match := ssnRegexp.FindStringSubmatch(value)
+	if match == nil || exampleSSNs[value] {
+		return false
+	}
+
+	area, group, serial := match[1], match[2], match[3]
+
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+func isPhoneNumber(value string) bool {
+	if !phoneRegexp.MatchString(value) {
+		return false
+	}
+
+	digits := onlyDigits(value)
+	if len(digits) < 10 || len(digits) > 15 || isRepeated(digits) {
+		return false
+	}
+
+	// 555-0100 to 555-0199 are reserved for fictional use in North America
+	if strings.HasPrefix(digits[len(digits)-7:], "55501") {
+		return false
+	}
+
+	return true
+}
+
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+func onlyDigits(value string) string {
+	return strings.Map(func(char rune) rune {
+		if char >= '0' && char <= '9' {
+			return char
+		}
+
+		return -1
+	}, value)
+}
+
+func isRepeated(digits string) bool {
+	return strings.Count(digits, digits[:1]) == len(digits)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/bearer/bearer/internal/detectors/types"
 	"github.com/bearer/bearer/internal/report"
 	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
 	"github.com/bearer/bearer/internal/report/frameworks/spring"
 	"github.com/bearer/bearer/internal/report/source"
 	"github.com/bearer/bearer/internal/util/file"
@@ -136,7 +137,8 @@ func extractDataStoresFromProperties(file *file.FileInfo, report report.Report)
 	}
 
 	report.AddFramework(detectors.DetectorSpring, spring.TypeDatabase, spring.DataStore{
-		Driver: getDriver(driverClass1.value, driverClass2.value, url.value),
+		Driver:    getDriver(driverClass1.value, driverClass2.value, url.value),
+		DataStore: connection.Parse(url.value),
 	}, source.Source{
 		Language:        file.Language,
 		LanguageType:    file.LanguageTypeString(),
@@ -187,7 +189,8 @@ func extractDataStoresFromYAML(file *file.FileInfo, report report.Report) error
 	}
 
 	report.AddFramework(detectors.DetectorSpring, spring.TypeDatabase, spring.DataStore{
-		Driver: getDriver(dataSource.DriverClass1, dataSource.DriverClass2, dataSource.URL),
+		Driver:    getDriver(dataSource.DriverClass1, dataSource.DriverClass2, dataSource.URL),
+		DataStore: connection.Parse(dataSource.URL),
 	}, source.Source{
 		Language:        file.Language,
 		LanguageType:    file.LanguageTypeString(),
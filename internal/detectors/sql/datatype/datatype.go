@@ -0,0 +1,174 @@
+package datatype
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/bearer/bearer/internal/detectors/sql/util"
+	"github.com/bearer/bearer/internal/parser"
+	"github.com/bearer/bearer/internal/parser/datatype"
+	"github.com/bearer/bearer/internal/parser/nodeid"
+	"github.com/bearer/bearer/internal/parser/sitter/sql"
+	"github.com/bearer/bearer/internal/report"
+	"github.com/bearer/bearer/internal/report/detections"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/schema"
+	schemadatatype "github.com/bearer/bearer/internal/report/schema/datatype"
+)
+
+var (
+	language = sql.GetLanguage()
+
+	// SELECT email, u.name, lower(phone) FROM users u
+	selectColumnsQuery = parser.QueryMustCompile(language, `
+	[
+		(select_clause_body [(identifier) (dotted_name)] @column)
+		(select_clause_body (function_call arguments: [(identifier) (dotted_name)] @column))
+	]
+	`)
+
+	// INSERT INTO users (email, name) ...
+	insertColumnsQuery = parser.QueryMustCompile(language,
+		`(insert_statement . (identifier) @table (identifier) @column)`)
+
+	// UPDATE users SET email = ...
+	updateColumnsQuery = parser.QueryMustCompile(language, `
+	(update_statement
+		. (identifier) @table
+		(set_clause (set_clause_body (assigment_expression . (identifier) @column))))
+	`)
+)
+
+// Discover reports the columns read and written by the queries of a file, eg.
+// an ETL script, so that the data they move is classified
+func Discover(report report.Report, tree *parser.Tree, idGenerator nodeid.Generator) {
+	datatypes := make(map[parser.NodeID]*schemadatatype.DataType)
+
+	for _, capture := range tree.QueryConventional(selectColumnsQuery) {
+		columnNode := capture["column"]
+		if isSelectTarget(columnNode) {
+			continue
+		}
+
+		qualifier, column := splitColumn(columnNode)
+		if tableNode := findTable(columnNode, qualifier); tableNode != nil {
+			addColumn(datatypes, tableNode, columnNode, column)
+		}
+	}
+
+	for _, query := range []*sitter.Query{insertColumnsQuery, updateColumnsQuery} {
+		for _, capture := range tree.QueryConventional(query) {
+			columnNode := capture["column"]
+			addColumn(datatypes, capture["table"], columnNode, util.StripQuotes(columnNode.Content()))
+		}
+	}
+
+	datatype.PruneMap(datatypes)
+
+	report.AddDataType(detections.TypeSchema, detectors.DetectorSQL, idGenerator, datatypes, nil)
+}
+
+func addColumn(
+	datatypes map[parser.NodeID]*schemadatatype.DataType,
+	tableNode *parser.Node,
+	columnNode *parser.Node,
+	column string,
+) {
+	table := datatypes[tableNode.ID()]
+	if table == nil {
+		_, tableName := splitColumn(tableNode)
+
+		table = &schemadatatype.DataType{
+			Node:       tableNode,
+			Name:       tableName,
+			Type:       schema.SimpleTypeObject,
+			TextType:   "table",
+			Properties: make(map[string]schemadatatype.DataTypable),
+		}
+		datatypes[tableNode.ID()] = table
+	}
+
+	table.Properties[column] = &schemadatatype.DataType{
+		Node:       columnNode,
+		Name:       column,
+		Type:       schema.SimpleTypeUnknown,
+		Properties: make(map[string]schemadatatype.DataTypable),
+	}
+}
+
+// isSelectTarget tells whether a node is the table a `SELECT ... INTO` query
+// creates, rather than a column
+func isSelectTarget(node *parser.Node) bool {
+	target := node.Parent().ChildByFieldName("into")
+	return target != nil && target.Equal(node)
+}
+
+// splitColumn returns the qualifier and the name of a column, eg. `u` and
+// `email` for `u.email`. Tables are named the same way, eg. `public.users`.
+func splitColumn(node *parser.Node) (string, string) {
+	if node.Type() != "dotted_name" {
+		return "", util.StripQuotes(node.Content())
+	}
+
+	count := node.NamedChildCount()
+	column := util.StripQuotes(node.Child(count - 1).Content())
+	if count < 2 {
+		return "", column
+	}
+
+	return util.StripQuotes(node.Child(count - 2).Content()), column
+}
+
+// findTable returns the node of the table in the FROM clause which a column of
+// a SELECT query belongs to. The qualifier of the column is the name or alias
+// of the table. Unqualified columns belong to the table when only one is
+// selected from.
+func findTable(columnNode *parser.Node, qualifier string) *parser.Node {
+	statement, err := columnNode.FindParent("select_statement")
+	if err != nil || statement == nil {
+		return nil
+	}
+
+	var tables []*parser.Node
+	aliases := make(map[string]*parser.Node)
+	for i := 0; i < statement.ChildCount(); i++ {
+		if clause := statement.Child(i); clause.Type() == "from_clause" {
+			collectTables(clause, &tables, aliases)
+		}
+	}
+
+	if qualifier != "" {
+		return aliases[strings.ToLower(qualifier)]
+	}
+
+	if len(tables) == 1 {
+		return tables[0]
+	}
+
+	return nil
+}
+
+// collectTables gathers the tables of a FROM clause, and maps their names and
+// aliases to them, eg. `FROM users u JOIN orders o ON ...`
+func collectTables(node *parser.Node, tables *[]*parser.Node, aliases map[string]*parser.Node) {
+	var table *parser.Node
+	for i := 0; i < node.ChildCount(); i++ {
+		child := node.Child(i)
+
+		switch child.Type() {
+		case "identifier", "dotted_name":
+			table = child
+			*tables = append(*tables, table)
+
+			_, name := splitColumn(table)
+			aliases[strings.ToLower(name)] = table
+		case "alias":
+			if table != nil {
+				aliases[strings.ToLower(util.StripQuotes(child.Content()))] = table
+			}
+		case "join_clause":
+			collectTables(child, tables, aliases)
+		}
+	}
+}
@@ -1,15 +1,55 @@
 package sql
 
 import (
+	"strings"
+
+	"github.com/bearer/bearer/internal/detectors/sql/datatype"
+	"github.com/bearer/bearer/internal/detectors/sql/util"
 	"github.com/bearer/bearer/internal/detectors/types"
 	"github.com/bearer/bearer/internal/parser"
 	"github.com/bearer/bearer/internal/parser/nodeid"
+	"github.com/bearer/bearer/internal/parser/sitter/sql"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/schema"
 	"github.com/bearer/bearer/internal/util/file"
+	"github.com/bearer/bearer/internal/util/pluralize"
 
+	parserschema "github.com/bearer/bearer/internal/parser/schema"
 	reporttypes "github.com/bearer/bearer/internal/report"
 	schemadatatype "github.com/bearer/bearer/internal/report/schema/datatype"
 )
 
+var (
+	language = sql.GetLanguage()
+
+	// Columns declared by CREATE TABLE and ALTER TABLE ... ADD COLUMN. For
+	// schema-qualified names (public.users) the last part is the table name.
+	tableColumnsQuery = parser.QueryMustCompile(language, `
+	[
+		(create_table_statement
+			[
+				(identifier) @table_name
+				(dotted_name (identifier) @table_name .)
+			]
+			(table_parameters
+				(table_column
+					name: (identifier) @column_name
+					type: (type) @column_type))) @statement
+		(alter_statement
+			(alter_table
+				[
+					(identifier) @table_name
+					(dotted_name (identifier) @table_name .)
+				]
+				(alter_table_action
+					(alter_table_action_add
+						(table_column
+							name: (identifier) @column_name
+							type: (type) @column_type))))) @statement
+	]
+	`)
+)
+
 type detector struct {
 	idGenerator nodeid.Generator
 }
@@ -34,9 +74,80 @@ func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, repor
 		return false, nil
 	}
 
+	tree, err := parser.ParseFile(file, file.Path, language)
+	if err != nil {
+		return false, err
+	}
+	defer tree.Close()
+
+	if err := detector.ExtractFromSchema(tree, report); err != nil {
+		return true, err
+	}
+
+	datatype.Discover(report, tree, detector.idGenerator)
+
 	return true, nil
 }
 
+// ExtractFromSchema reports the columns of the tables defined in a DDL file,
+// such as a migration or a schema dump, so that they are classified as stored
+// data
+func (detector *detector) ExtractFromSchema(
+	tree *parser.Tree,
+	report reporttypes.Report,
+) error {
+	uuidHolder := parserschema.NewUUIDHolder()
+
+	err := tree.Query(tableColumnsQuery, func(captures parser.Captures) error {
+		tableNode := captures["table_name"]
+		tableName := util.StripQuotes(tableNode.Content())
+		columnNode := captures["column_name"]
+		columnName := util.StripQuotes(columnNode.Content())
+		columnType := strings.ToLower(captures["column_type"].Content())
+
+		objectUUID := uuidHolder.Assign(tableNode.ID(), detector.idGenerator)
+		fieldUUID := uuidHolder.Assign(columnNode.ID(), detector.idGenerator)
+
+		currentSchema := schema.Schema{
+			ObjectName:           tableName,
+			ObjectUUID:           objectUUID,
+			FieldName:            columnName,
+			FieldUUID:            fieldUUID,
+			FieldType:            columnType,
+			SimpleFieldType:      util.ConvertToSimpleType(columnType),
+			NormalizedObjectName: pluralize.Singular(strings.ToLower(tableName)),
+			NormalizedFieldName:  pluralize.Singular(strings.ToLower(columnName)),
+		}
+
+		if report.SchemaGroupShouldClose(tableName) {
+			report.SchemaGroupEnd(detector.idGenerator)
+		}
+
+		if !report.SchemaGroupIsOpen() {
+			source := tableNode.Source(false)
+			report.SchemaGroupBegin(
+				detectors.DetectorSQLCreateTable,
+				tableNode,
+				currentSchema,
+				&source,
+				captures["statement"],
+			)
+		}
+		source := columnNode.Source(false)
+		report.SchemaGroupAddItem(
+			columnNode,
+			currentSchema,
+			&source,
+		)
+
+		return nil
+	})
+
+	report.SchemaGroupEnd(detector.idGenerator)
+
+	return err
+}
+
 func ExtractArguments(node *parser.Node, idGenerator nodeid.Generator) (map[parser.NodeID]*schemadatatype.DataType, error) {
 	return nil, nil
 }
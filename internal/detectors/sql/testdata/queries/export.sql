@@ -0,0 +1,10 @@
+CREATE TABLE marketing_export AS
+SELECT u.email, u.first_name AS name, lower(c."phone_number"), o.total
+FROM public.users u
+JOIN orders o ON o.user_id = u.id;
+
+INSERT INTO crm_contacts (user_id, email) SELECT id, email FROM customers;
+
+UPDATE accounts SET iban = NULL WHERE closed;
+
+SELECT email INTO users_backup FROM users;
@@ -0,0 +1,12 @@
+CREATE TABLE public.users (
+  id bigint NOT NULL,
+  email character varying DEFAULT ''::character varying NOT NULL,
+  date_of_birth date,
+  created_at timestamp(6) without time zone NOT NULL
+);
+
+CREATE TABLE `accounts` (
+  `id` int NOT NULL,
+  `iban` varchar(34),
+  `balance` decimal(10,2)
+);
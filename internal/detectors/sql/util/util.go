@@ -15,7 +15,7 @@ func ConvertToSimpleType(value string) string {
 	simplified := strings.ToLower(value)
 	simplified = strings.Split(simplified, " ")[0]
 
-	numberMap := []string{"bit", `tinyint(\(\d?\))?`, "smallint", "mediumint", "int", "integer", "bigint", `float(\(\d?,\d?\))`, `double(\(\d?,\d?\))?`, `decimal(\(\d?,\d?\))`, `dec`}
+	numberMap := []string{"bit", `tinyint(\(\d?\))?`, "smallint", "mediumint", "int", "integer", "bigint", `float(\(\d?,\d?\))`, `double(\(\d?,\d?\))?`, `decimal(\(\d?,\d?\))`, `dec`, "serial", "numeric", "real"}
 	for _, typeValue := range numberMap {
 		reg := regexp.MustCompile(typeValue)
 		if reg.MatchString(simplified) {
@@ -30,7 +30,7 @@ func ConvertToSimpleType(value string) string {
 		}
 	}
 
-	stringMap := []string{`char(\(\d?\))?`, `varchar(\(\d?\))?`, `character(\(\d?\))?`, "tinytext", "mediumtext", "longtext"}
+	stringMap := []string{`char(\(\d?\))?`, `varchar(\(\d?\))?`, `character(\(\d?\))?`, "text", "uuid"}
 	for _, typeValue := range stringMap {
 		reg := regexp.MustCompile(typeValue)
 		if reg.MatchString(simplified) {
@@ -0,0 +1,461 @@
+package terraform
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/smacker/go-tree-sitter/hcl"
+
+	"github.com/bearer/bearer/internal/detectors/types"
+	"github.com/bearer/bearer/internal/parser"
+	"github.com/bearer/bearer/internal/report"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
+	"github.com/bearer/bearer/internal/report/frameworks/terraform"
+	"github.com/bearer/bearer/internal/util/file"
+	"github.com/bearer/bearer/internal/util/maputil"
+)
+
+var (
+	language = hcl.GetLanguage()
+
+	// aws_s3_bucket.uploads.id
+	bucketReferenceRegex = regexp.MustCompile(`\Aaws_s3_bucket\.([\w-]+)\.`)
+
+	// the attribute holding the engine of database resources supporting
+	// several engines
+	engineAttributes = map[string]string{
+		"aws_db_instance":              "engine",
+		"aws_rds_cluster":              "engine",
+		"aws_elasticache_cluster":      "engine",
+		"aws_mq_broker":                "engine_type",
+		"google_sql_database_instance": "database_version",
+	}
+
+	// database resources dedicated to a single engine
+	resourceEngines = map[string]string{
+		"aws_elasticache_replication_group":  "redis",
+		"google_redis_instance":              "redis",
+		"azurerm_postgresql_server":          "postgresql",
+		"azurerm_postgresql_flexible_server": "postgresql",
+		"azurerm_mysql_server":               "mysql",
+		"azurerm_mysql_flexible_server":      "mysql",
+		"azurerm_mariadb_server":             "mariadb",
+		"azurerm_mssql_server":               "sqlserver",
+		"azurerm_sql_server":                 "sqlserver",
+		"azurerm_redis_cache":                "redis",
+	}
+
+	databaseNameAttributes = []string{"db_name", "database_name"}
+
+	// boolean attributes which enable encryption, eg. `storage_encrypted = true`
+	encryptionAttributes = []string{
+		"storage_encrypted",
+		"encrypted",
+		"at_rest_encryption_enabled",
+	}
+
+	// attributes which enable encryption with a key when set, eg.
+	// `kms_master_key_id = aws_kms_key.queue.arn`
+	encryptionKeyAttributes = []string{
+		"kms_master_key_id",
+		"kms_key_id",
+		"kms_key_arn",
+		"customer_managed_key_id",
+	}
+
+	// blocks which enable encryption when given
+	encryptionBlocks = []string{
+		"server_side_encryption_configuration",
+		"encryption",
+		"default_encryption_configuration",
+		"customer_managed_key",
+	}
+
+	// blocks which enable encryption according to their `enabled` attribute
+	encryptionToggleBlocks = []string{
+		"server_side_encryption",
+		"encrypt_at_rest",
+	}
+
+	// boolean attributes which allow public access, eg.
+	// `publicly_accessible = true`
+	publicAccessAttributes = []string{
+		"publicly_accessible",
+		"public_network_access_enabled",
+		"allow_nested_items_to_be_public",
+		"allow_blob_public_access",
+	}
+
+	publicACLs = []string{"public-read", "public-read-write"}
+)
+
+type detector struct{}
+
+// block is a block of the configuration, eg. `resource "aws_s3_bucket" "uploads" {}`
+type block struct {
+	node       *parser.Node
+	keyword    string
+	labels     []string
+	attributes map[string]*parser.Node
+	blocks     map[string][]*block
+}
+
+type resource struct {
+	node     *parser.Node
+	resource terraform.Resource
+}
+
+func New() types.Detector {
+	return &detector{}
+}
+
+func (detector *detector) AcceptDir(dir *file.Path) (bool, error) {
+	return true, nil
+}
+
+func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, report report.Report) (bool, error) {
+	if file.Extension != ".tf" {
+		return false, nil
+	}
+
+	tree, err := parser.ParseFile(file, file.Path, language)
+	if err != nil {
+		return false, err
+	}
+	defer tree.Close()
+
+	var resources []*resource
+	buckets := make(map[string]*resource)
+	var bucketSettings []*block
+
+	for _, configBlock := range blocksOf(tree.RootNode()) {
+		switch configBlock.keyword {
+		case "terraform":
+			for _, providers := range configBlock.blocks["required_providers"] {
+				for _, name := range maputil.SortedStringKeys(providers.attributes) {
+					reportProvider(report, name, providers.attributes[name].Parent())
+				}
+			}
+		case "provider":
+			if len(configBlock.labels) == 1 {
+				reportProvider(report, configBlock.labels[0], configBlock.node)
+			}
+		case "resource":
+			if len(configBlock.labels) != 2 {
+				continue
+			}
+
+			switch configBlock.labels[0] {
+			case "aws_s3_bucket_server_side_encryption_configuration",
+				"aws_s3_bucket_acl",
+				"aws_s3_bucket_public_access_block":
+				bucketSettings = append(bucketSettings, configBlock)
+				continue
+			}
+
+			newResource := &resource{
+				node:     configBlock.node,
+				resource: resourceFor(configBlock),
+			}
+			if newResource.resource.GetTechnologyKey() == "" {
+				continue
+			}
+
+			resources = append(resources, newResource)
+			if configBlock.labels[0] == "aws_s3_bucket" {
+				buckets[configBlock.labels[1]] = newResource
+			}
+		}
+	}
+
+	for _, settings := range bucketSettings {
+		applyBucketSettings(buckets, settings)
+	}
+
+	for _, resource := range resources {
+		report.AddFramework(detectors.DetectorTerraform, terraform.TypeResource, resource.resource, resource.node.Source(false))
+	}
+
+	return true, nil
+}
+
+func reportProvider(report report.Report, name string, node *parser.Node) {
+	provider := terraform.Provider{Name: name}
+	if provider.GetTechnologyKey() == "" {
+		return
+	}
+
+	report.AddFramework(detectors.DetectorTerraform, terraform.TypeProvider, provider, node.Source(false))
+}
+
+func resourceFor(resourceBlock *block) terraform.Resource {
+	resourceType := resourceBlock.labels[0]
+
+	result := terraform.Resource{
+		Definition: terraform.Definition{
+			Type:         resourceType,
+			Name:         resourceBlock.labels[1],
+			Encrypted:    encrypted(resourceBlock),
+			PublicAccess: publicAccess(resourceBlock),
+		},
+	}
+
+	engine := resourceEngines[resourceType]
+	if attribute, ok := engineAttributes[resourceType]; ok {
+		engine = normalizeEngine(resourceBlock.stringAttribute(attribute))
+	}
+
+	if engine != "" {
+		result.DataStore = &connection.DataStore{Engine: engine}
+
+		for _, attribute := range databaseNameAttributes {
+			if name := resourceBlock.stringAttribute(attribute); name != "" {
+				result.DataStore.Database = name
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// normalizeEngine returns the data store engine for the engine of a managed
+// database, eg. `aurora-postgresql` or `POSTGRES_15` are both `postgresql`
+func normalizeEngine(name string) string {
+	name = strings.ToLower(name)
+	if name == "aurora" {
+		return "mysql"
+	}
+
+	name = strings.TrimPrefix(name, "aurora-")
+	if i := strings.IndexAny(name, "-_"); i != -1 {
+		name = name[:i]
+	}
+
+	engine, _ := connection.Engine(name)
+	return engine
+}
+
+func encrypted(resourceBlock *block) *bool {
+	for _, attribute := range encryptionAttributes {
+		if value, ok := resourceBlock.boolAttribute(attribute); ok {
+			return &value
+		}
+	}
+
+	for _, attribute := range encryptionKeyAttributes {
+		if _, ok := resourceBlock.attributes[attribute]; ok {
+			return boolPointer(true)
+		}
+	}
+
+	for _, name := range encryptionBlocks {
+		if len(resourceBlock.blocks[name]) != 0 {
+			return boolPointer(true)
+		}
+	}
+
+	for _, name := range encryptionToggleBlocks {
+		for _, toggleBlock := range resourceBlock.blocks[name] {
+			if value, ok := toggleBlock.boolAttribute("enabled"); ok {
+				return &value
+			}
+		}
+	}
+
+	// aws_sqs_queue
+	if value, ok := resourceBlock.boolAttribute("sqs_managed_sse_enabled"); ok && value {
+		return &value
+	}
+
+	// aws_kinesis_stream
+	switch resourceBlock.stringAttribute("encryption_type") {
+	case "KMS":
+		return boolPointer(true)
+	case "NONE":
+		return boolPointer(false)
+	}
+
+	return nil
+}
+
+func publicAccess(resourceBlock *block) *bool {
+	for _, attribute := range publicAccessAttributes {
+		if value, ok := resourceBlock.boolAttribute(attribute); ok {
+			return &value
+		}
+	}
+
+	if value := aclPublicAccess(resourceBlock); value != nil {
+		return value
+	}
+
+	// google_storage_bucket
+	if resourceBlock.stringAttribute("public_access_prevention") == "enforced" {
+		return boolPointer(false)
+	}
+
+	return nil
+}
+
+// aclPublicAccess returns whether a canned ACL grants public access, eg.
+// `acl = "public-read"`
+func aclPublicAccess(resourceBlock *block) *bool {
+	acl := resourceBlock.stringAttribute("acl")
+	if acl == "" {
+		return nil
+	}
+
+	for _, publicACL := range publicACLs {
+		if acl == publicACL {
+			return boolPointer(true)
+		}
+	}
+
+	return boolPointer(false)
+}
+
+// applyBucketSettings applies the settings of an S3 bucket declared in their
+// own resource, eg. `resource "aws_s3_bucket_acl" "uploads" {}`, to the bucket
+// they refer to
+func applyBucketSettings(buckets map[string]*resource, settings *block) {
+	reference, ok := settings.attributes["bucket"]
+	if !ok {
+		return
+	}
+
+	match := bucketReferenceRegex.FindStringSubmatch(reference.Content())
+	if match == nil {
+		return
+	}
+
+	bucket, ok := buckets[match[1]]
+	if !ok {
+		return
+	}
+
+	definition := &bucket.resource.Definition
+
+	switch settings.labels[0] {
+	case "aws_s3_bucket_server_side_encryption_configuration":
+		definition.Encrypted = boolPointer(true)
+	case "aws_s3_bucket_acl":
+		if value := aclPublicAccess(settings); value != nil && definition.PublicAccess == nil {
+			definition.PublicAccess = value
+		}
+	case "aws_s3_bucket_public_access_block":
+		// public access is blocked when both ACLs and policies can't grant it
+		blockACLs, _ := settings.boolAttribute("block_public_acls")
+		blockPolicy, _ := settings.boolAttribute("block_public_policy")
+		if blockACLs && blockPolicy {
+			definition.PublicAccess = boolPointer(false)
+		}
+	}
+}
+
+// blocksOf returns the blocks in the body of a configuration file or block
+func blocksOf(node *parser.Node) []*block {
+	body := childOfType(node, "body")
+	if body == nil {
+		return nil
+	}
+
+	var result []*block
+	for i := 0; i < body.ChildCount(); i++ {
+		child := body.Child(i)
+		if child.Type() != "block" {
+			continue
+		}
+
+		result = append(result, newBlock(child))
+	}
+
+	return result
+}
+
+func newBlock(node *parser.Node) *block {
+	result := &block{
+		node:       node,
+		attributes: make(map[string]*parser.Node),
+		blocks:     make(map[string][]*block),
+	}
+
+	for i := 0; i < node.ChildCount(); i++ {
+		child := node.Child(i)
+
+		switch child.Type() {
+		case "identifier":
+			result.keyword = child.Content()
+		case "string_literal":
+			result.labels = append(result.labels, strings.Trim(child.Content(), `"`))
+		}
+	}
+
+	if body := childOfType(node, "body"); body != nil {
+		for i := 0; i < body.ChildCount(); i++ {
+			child := body.Child(i)
+
+			switch child.Type() {
+			case "attribute":
+				name := childOfType(child, "identifier")
+				value := childOfType(child, "expression")
+				if name != nil && value != nil {
+					result.attributes[name.Content()] = value
+				}
+			case "block":
+				nestedBlock := newBlock(child)
+				result.blocks[nestedBlock.keyword] = append(result.blocks[nestedBlock.keyword], nestedBlock)
+			}
+		}
+	}
+
+	return result
+}
+
+// stringAttribute returns the value of an attribute set to a string literal.
+// Templates with interpolations have no known value
+func (block *block) stringAttribute(name string) string {
+	value, ok := block.attributes[name]
+	if !ok {
+		return ""
+	}
+
+	content := value.Content()
+	if len(content) < 2 || !strings.HasPrefix(content, `"`) || !strings.HasSuffix(content, `"`) || strings.Contains(content, "${") {
+		return ""
+	}
+
+	return content[1 : len(content)-1]
+}
+
+// boolAttribute returns the value of an attribute set to a boolean literal
+func (block *block) boolAttribute(name string) (bool, bool) {
+	value, ok := block.attributes[name]
+	if !ok {
+		return false, false
+	}
+
+	switch value.Content() {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+func childOfType(node *parser.Node, nodeType string) *parser.Node {
+	for i := 0; i < node.ChildCount(); i++ {
+		if child := node.Child(i); child.Type() == nodeType {
+			return child
+		}
+	}
+
+	return nil
+}
+
+func boolPointer(value bool) *bool {
+	return &value
+}
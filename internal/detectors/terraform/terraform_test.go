@@ -0,0 +1,21 @@
+package terraform_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/detectors/internal/testhelper"
+	"github.com/bearer/bearer/internal/report/detectors"
+)
+
+const detectorType = detectors.DetectorTerraform
+
+var registrations = testhelper.RegistrationFor(detectorType)
+
+func TestDetectorReportFrameworks(t *testing.T) {
+	detectorReport := testhelper.Extract(t, filepath.Join("testdata", "infra"), registrations, detectorType)
+
+	cupaloy.SnapshotT(t, detectorReport.Frameworks)
+}
@@ -0,0 +1,53 @@
+terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+    datadog = {
+      source = "DataDog/datadog"
+    }
+  }
+}
+
+provider "aws" {
+  region = "eu-west-1"
+}
+
+provider "stripe" {
+  api_key = var.stripe_api_key
+}
+
+resource "aws_db_instance" "main" {
+  engine              = "postgres"
+  engine_version      = "15.4"
+  db_name             = "app"
+  storage_encrypted   = true
+  publicly_accessible = false
+}
+
+resource "aws_rds_cluster" "analytics" {
+  engine            = "aurora-mysql"
+  database_name     = "analytics"
+  storage_encrypted = false
+}
+
+resource "aws_db_instance" "legacy" {
+  engine = var.legacy_engine
+}
+
+resource "aws_sqs_queue" "events" {
+  name              = "events"
+  kms_master_key_id = aws_kms_key.events.arn
+}
+
+resource "aws_dynamodb_table" "sessions" {
+  name = "sessions"
+
+  server_side_encryption {
+    enabled = true
+  }
+}
+
+resource "aws_iam_role" "app" {
+  name = "app"
+}
@@ -0,0 +1,45 @@
+resource "aws_s3_bucket" "uploads" {
+  bucket = "uploads"
+  acl    = "public-read"
+}
+
+resource "aws_s3_bucket" "backups" {
+  bucket = "backups"
+}
+
+resource "aws_s3_bucket_server_side_encryption_configuration" "backups" {
+  bucket = aws_s3_bucket.backups.id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm = "aws:kms"
+    }
+  }
+}
+
+resource "aws_s3_bucket_public_access_block" "backups" {
+  bucket                  = aws_s3_bucket.backups.id
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+resource "google_storage_bucket" "exports" {
+  name                     = "exports"
+  location                 = "EU"
+  public_access_prevention = "enforced"
+}
+
+resource "google_sql_database_instance" "reporting" {
+  database_version = "POSTGRES_15"
+
+  settings {
+    tier = "db-f1-micro"
+  }
+}
+
+resource "azurerm_storage_account" "documents" {
+  name                            = "documents"
+  allow_nested_items_to_be_public = true
+}
@@ -7,6 +7,7 @@ import (
 
 	sitter "github.com/smacker/go-tree-sitter"
 
+	"github.com/bearer/bearer/internal/detectors/graphql"
 	"github.com/bearer/bearer/internal/detectors/types"
 	"github.com/bearer/bearer/internal/detectors/typescript/datatype"
 	"github.com/bearer/bearer/internal/parser"
@@ -33,6 +34,12 @@ var (
 		name: (object_pattern (shorthand_property_identifier_pattern) @key @node)
 			value: (member_expression) @object)
 `)
+
+	graphqlTemplatesQuery = parser.QueryMustCompile(typescript.GetLanguage(), `
+	(call_expression
+		function: (identifier) @tag
+		arguments: (template_string) @template)
+`)
 )
 
 type detector struct {
@@ -62,6 +69,10 @@ func (detector *detector) ProcessFile(file *file.FileInfo, dir *file.Path, repor
 
 	datatype.Discover(report, tree, typescript.GetLanguage(), detector.idGenerator)
 
+	if err := graphql.ProcessTaggedTemplates(tree, graphqlTemplatesQuery, report, detector.idGenerator); err != nil {
+		return false, err
+	}
+
 	if err := annotate(tree, environmentVariablesQuery); err != nil {
 		return false, err
 	}
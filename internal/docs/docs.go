@@ -0,0 +1,241 @@
+package docs
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/bearer/bearer/internal/classification/db"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+)
+
+const (
+	KindRule     = "rule"
+	KindDataType = "data type"
+	KindCommand  = "command"
+)
+
+var markdownPrefixPattern = regexp.MustCompile(`^\s*(#+|[-*>])\s+`)
+
+// Entry is a single searchable piece of documentation
+type Entry struct {
+	Kind  string `json:"kind" yaml:"kind"`
+	ID    string `json:"id" yaml:"id"`
+	Title string `json:"title" yaml:"title"`
+	Body  string `json:"body,omitempty" yaml:"body,omitempty"`
+	URL   string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+type Result struct {
+	Entry
+	Score int `json:"score" yaml:"score"`
+}
+
+type Index struct {
+	entries []Entry
+}
+
+func NewIndex(entries ...[]Entry) *Index {
+	index := &Index{}
+	for _, group := range entries {
+		index.entries = append(index.entries, group...)
+	}
+
+	return index
+}
+
+// Search returns the entries containing every word of the term, the most
+// relevant first. Matches on the id count for more than matches on the
+// title, which count for more than matches in the body.
+func (index *Index) Search(term string) []Result {
+	words := strings.Fields(strings.ToLower(term))
+	if len(words) == 0 {
+		return nil
+	}
+
+	var results []Result
+	for _, entry := range index.entries {
+		if score := score(entry, strings.ToLower(term), words); score > 0 {
+			results = append(results, Result{Entry: entry, Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Kind != results[j].Kind {
+			return results[i].Kind > results[j].Kind
+		}
+
+		return results[i].ID < results[j].ID
+	})
+
+	return results
+}
+
+func score(entry Entry, term string, words []string) int {
+	id := strings.ToLower(entry.ID)
+	title := strings.ToLower(entry.Title)
+	body := strings.ToLower(entry.Body)
+
+	total := 0
+	for _, word := range words {
+		wordScore := 0
+		if strings.Contains(id, word) {
+			wordScore += 20
+		}
+		if strings.Contains(title, word) {
+			wordScore += 10
+		}
+		wordScore += min(strings.Count(body, word), 5)
+
+		if wordScore == 0 {
+			return 0
+		}
+
+		total += wordScore
+	}
+
+	if id == strings.TrimSpace(term) {
+		total += 100
+	}
+
+	return total
+}
+
+// Excerpt returns the first line of the body that contains a word of the
+// term, falling back to the first line of the body
+func (entry Entry) Excerpt(term string) string {
+	var lines []string
+	for _, line := range strings.Split(entry.Body, "\n") {
+		line = strings.TrimSpace(markdownPrefixPattern.ReplaceAllString(line, ""))
+		if line != "" && line != "Description" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	for _, line := range lines {
+		lowerLine := strings.ToLower(line)
+		for _, word := range strings.Fields(strings.ToLower(term)) {
+			if strings.Contains(lowerLine, word) {
+				return line
+			}
+		}
+	}
+
+	return lines[0]
+}
+
+// RuleEntries documents each rule by its description and remediation
+func RuleEntries(definitions map[string]settings.RuleDefinition) []Entry {
+	var entries []Entry
+	for id, definition := range definitions {
+		metadata := definition.Metadata
+		if metadata == nil {
+			continue
+		}
+
+		body := &strings.Builder{}
+		body.WriteString(metadata.RemediationMessage)
+		if len(metadata.CWEIDs) != 0 {
+			body.WriteString("\nCWE-" + strings.Join(metadata.CWEIDs, " CWE-"))
+		}
+		if len(metadata.OWASP) != 0 {
+			body.WriteString("\nOWASP " + strings.Join(metadata.OWASP, " "))
+		}
+		if len(definition.Languages) != 0 {
+			body.WriteString("\nLanguages: " + strings.Join(definition.Languages, ", "))
+		}
+
+		entries = append(entries, Entry{
+			Kind:  KindRule,
+			ID:    id,
+			Title: metadata.Description,
+			Body:  body.String(),
+			URL:   metadata.DocumentationUrl,
+		})
+	}
+
+	return entries
+}
+
+// DataTypeEntries documents each data type by its category and groups
+func DataTypeEntries(defaultDB db.DefaultDB) []Entry {
+	var entries []Entry
+	for _, dataType := range defaultDB.DataTypes {
+		groups := make([]string, 0, len(dataType.Category.Groups))
+		for _, group := range dataType.Category.Groups {
+			groups = append(groups, group.Name)
+		}
+		sort.Strings(groups)
+
+		body := fmt.Sprintf("Category: %s", dataType.Category.Name)
+		if len(groups) != 0 {
+			body += fmt.Sprintf("\nGroups: %s", strings.Join(groups, ", "))
+		}
+
+		entries = append(entries, Entry{
+			Kind:  KindDataType,
+			ID:    dataType.Name,
+			Title: fmt.Sprintf("%s (%s)", dataType.Name, dataType.Category.Name),
+			Body:  body,
+		})
+	}
+
+	return entries
+}
+
+// CommandEntries documents each command reachable from the root command,
+// including its flags
+func CommandEntries(root *cobra.Command) []Entry {
+	var entries []Entry
+
+	var walk func(command *cobra.Command)
+	walk = func(command *cobra.Command) {
+		if command.Hidden {
+			return
+		}
+
+		if command.HasParent() && command.Runnable() {
+			body := &strings.Builder{}
+			if command.Long != "" {
+				body.WriteString(command.Long + "\n")
+			}
+
+			command.Flags().VisitAll(func(flag *pflag.Flag) {
+				if flag.Hidden {
+					return
+				}
+
+				body.WriteString(fmt.Sprintf("--%s: %s\n", flag.Name, flag.Usage))
+			})
+
+			if command.Example != "" {
+				body.WriteString(command.Example + "\n")
+			}
+
+			entries = append(entries, Entry{
+				Kind:  KindCommand,
+				ID:    command.CommandPath(),
+				Title: command.Short,
+				Body:  body.String(),
+			})
+		}
+
+		for _, child := range command.Commands() {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	return entries
+}
@@ -0,0 +1,95 @@
+package docs_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/docs"
+)
+
+func TestSearch(t *testing.T) {
+	index := docs.NewIndex(
+		docs.RuleEntries(map[string]settings.RuleDefinition{
+			"ruby_rails_logger": {
+				Languages: []string{"ruby"},
+				Metadata: &settings.RuleMetadata{
+					Description:        "Sensitive data sent to Rails loggers detected.",
+					RemediationMessage: "## Description\n\nLeaking sensitive data to loggers is a common cause of data leaks.\n",
+					CWEIDs:             []string{"209", "532"},
+				},
+			},
+			"ruby_lang_ssl_verification": {
+				Metadata: &settings.RuleMetadata{
+					Description:        "Missing SSL certificate verification detected.",
+					RemediationMessage: "Applications processing sensitive data should use valid SSL certificates.\n",
+				},
+			},
+		}),
+		[]docs.Entry{{Kind: docs.KindDataType, ID: "Email Address", Title: "Email Address (Contact)", Body: "Category: Contact"}},
+	)
+
+	results := index.Search("sensitive data")
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].ID != "ruby_rails_logger" {
+		t.Errorf("expected the logger rule first, got %s", results[0].ID)
+	}
+
+	results = index.Search("cwe-532")
+	if len(results) != 1 || results[0].ID != "ruby_rails_logger" {
+		t.Errorf("expected only the logger rule to match the CWE, got %v", results)
+	}
+
+	results = index.Search("email address")
+	if len(results) != 1 || results[0].Kind != docs.KindDataType {
+		t.Errorf("expected only the data type to match, got %v", results)
+	}
+
+	if results := index.Search("sensitive kotlin"); len(results) != 0 {
+		t.Errorf("expected every word to be required, got %v", results)
+	}
+
+	if results := index.Search("  "); len(results) != 0 {
+		t.Errorf("expected no results for an empty term, got %v", results)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	entry := docs.Entry{Body: "## Description\n\nLeaking data is bad.\n\n## Remediations\n- Avoid logging emails\n"}
+
+	if excerpt := entry.Excerpt("emails"); excerpt != "Avoid logging emails" {
+		t.Errorf("unexpected excerpt %q", excerpt)
+	}
+
+	if excerpt := entry.Excerpt("unrelated"); excerpt != "Leaking data is bad." {
+		t.Errorf("unexpected fallback excerpt %q", excerpt)
+	}
+}
+
+func TestCommandEntries(t *testing.T) {
+	run := func(cmd *cobra.Command, args []string) {}
+	root := &cobra.Command{Use: "bearer"}
+	scan := &cobra.Command{Use: "scan", Short: "Scan a directory or file", Run: run}
+	scan.Flags().Int("context-lines", 0, "Include surrounding code")
+	group := &cobra.Command{Use: "ignore"}
+	group.AddCommand(&cobra.Command{Use: "show", Short: "Show an ignored fingerprint", Run: run})
+	root.AddCommand(scan, group, &cobra.Command{Use: "hidden", Hidden: true, Run: run})
+
+	entries := docs.CommandEntries(root)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %v", entries)
+	}
+
+	results := docs.NewIndex(entries).Search("context-lines")
+	if len(results) != 1 || results[0].ID != "bearer scan" {
+		t.Errorf("expected the scan command to match its flag, got %v", results)
+	}
+
+	results = docs.NewIndex(entries).Search("bearer ignore show")
+	if len(results) != 1 || results[0].Title != "Show an ignored fingerprint" {
+		t.Errorf("expected the nested command to be documented, got %v", results)
+	}
+}
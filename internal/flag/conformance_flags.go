@@ -0,0 +1,46 @@
+package flag
+
+import "errors"
+
+type conformanceFlagGroup struct{ flagGroupBase }
+
+var ConformanceFlagGroup = &conformanceFlagGroup{flagGroupBase{name: "Conformance"}}
+
+var ErrInvalidFormatConformance = errors.New("invalid format argument for conformance; supported values: json")
+
+var (
+	ConformanceLanguageFlag = ConformanceFlagGroup.add(Flag{
+		Name:       "language",
+		ConfigName: "conformance.language",
+		Value:      []string{},
+		Usage:      "Only check the given languages, e.g. --language=ruby,python. Defaults to every supported language.",
+	})
+	ConformanceFormatFlag = ConformanceFlagGroup.add(Flag{
+		Name:       "format",
+		ConfigName: "conformance.format",
+		Shorthand:  "f",
+		Value:      FormatEmpty,
+		Usage:      "Specify the output format (json).",
+	})
+)
+
+type ConformanceOptions struct {
+	ConformanceLanguages []string `mapstructure:"conformance_languages" json:"conformance_languages" yaml:"conformance_languages"`
+	ConformanceFormat    string   `mapstructure:"conformance_format" json:"conformance_format" yaml:"conformance_format"`
+}
+
+func (conformanceFlagGroup) SetOptions(options *Options, args []string) error {
+	format := getString(ConformanceFormatFlag)
+	switch format {
+	case FormatEmpty, FormatJSON:
+	default:
+		return ErrInvalidFormatConformance
+	}
+
+	options.ConformanceOptions = ConformanceOptions{
+		ConformanceLanguages: getStringSlice(ConformanceLanguageFlag),
+		ConformanceFormat:    format,
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package flag
+
+import "errors"
+
+type diffFlagGroup struct{ flagGroupBase }
+
+var DiffFlagGroup = &diffFlagGroup{flagGroupBase{name: "Diff"}}
+
+const (
+	DiffFormatJSON     = "json"
+	DiffFormatMarkdown = "markdown"
+)
+
+var ErrInvalidFormatDiff = errors.New("invalid format argument for diff; supported values: json, markdown")
+
+var (
+	DiffFormatFlag = DiffFlagGroup.add(Flag{
+		Name:       "format",
+		ConfigName: "diff.format",
+		Shorthand:  "f",
+		Value:      DiffFormatJSON,
+		Usage:      "Specify the output format (json, markdown).",
+	})
+	DiffOutputFlag = DiffFlagGroup.add(Flag{
+		Name:       "output",
+		ConfigName: "diff.output",
+		Value:      "",
+		Usage:      "Specify the output path for the comparison.",
+	})
+	DiffExitCodeFlag = DiffFlagGroup.add(Flag{
+		Name:       "exit-code",
+		ConfigName: "diff.exit-code",
+		Value:      -1,
+		Usage:      "Force a given exit code when new findings are introduced. Set this to 0 (success) to always return a success exit code.",
+	})
+)
+
+type DiffOptions struct {
+	OutputFormat        string `mapstructure:"diff_format" json:"diff_format" yaml:"diff_format"`
+	OutputPath          string `mapstructure:"diff_output" json:"diff_output" yaml:"diff_output"`
+	NewFindingsExitCode int    `mapstructure:"diff_exit_code" json:"diff_exit_code" yaml:"diff_exit_code"`
+}
+
+func (diffFlagGroup) SetOptions(options *Options, args []string) error {
+	format := getString(DiffFormatFlag)
+	switch format {
+	case DiffFormatJSON, DiffFormatMarkdown:
+	default:
+		return ErrInvalidFormatDiff
+	}
+
+	options.DiffOptions = DiffOptions{
+		OutputFormat:        format,
+		OutputPath:          getString(DiffOutputFlag),
+		NewFindingsExitCode: getInteger(DiffExitCodeFlag),
+	}
+
+	return nil
+}
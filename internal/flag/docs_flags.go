@@ -0,0 +1,34 @@
+package flag
+
+type docsFlagGroup struct{ flagGroupBase }
+
+var DocsFlagGroup = &docsFlagGroup{flagGroupBase{name: "Docs"}}
+
+var (
+	DocsExternalRuleDirFlag = DocsFlagGroup.add(Flag{
+		Name:       "external-rule-dir",
+		ConfigName: "scan.external-rule-dir",
+		Value:      []string{},
+		Usage:      "Specify directories paths that contain .yaml files with external rules to include in the search",
+	})
+	DocsLimitFlag = DocsFlagGroup.add(Flag{
+		Name:       "limit",
+		ConfigName: "docs.limit",
+		Value:      10,
+		Usage:      "Specify the maximum number of results to show. Set to 0 to show all results.",
+	})
+)
+
+type DocsOptions struct {
+	DocsExternalRuleDir []string `mapstructure:"docs_external_rule_dir" json:"docs_external_rule_dir" yaml:"docs_external_rule_dir"`
+	DocsLimit           int      `mapstructure:"docs_limit" json:"docs_limit" yaml:"docs_limit"`
+}
+
+func (docsFlagGroup) SetOptions(options *Options, args []string) error {
+	options.DocsOptions = DocsOptions{
+		DocsExternalRuleDir: getStringSlice(DocsExternalRuleDirFlag),
+		DocsLimit:           getInteger(DocsLimitFlag),
+	}
+
+	return nil
+}
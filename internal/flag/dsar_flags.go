@@ -0,0 +1,62 @@
+package flag
+
+import "errors"
+
+type dsarFlagGroup struct{ flagGroupBase }
+
+var DSARFlagGroup = &dsarFlagGroup{flagGroupBase{name: "DSAR"}}
+
+var (
+	ErrInvalidFormatDSAR = errors.New("invalid format argument for dsar; supported values: json, yaml")
+	ErrMissingDataType   = errors.New("missing data type; specify one with --data-type")
+)
+
+var (
+	DSARDataTypeFlag = DSARFlagGroup.add(Flag{
+		Name:       "data-type",
+		ConfigName: "dsar.data-type",
+		Value:      "",
+		Usage:      "Specify the data type to export, matching any data type containing it eg. email.",
+	})
+	DSARFormatFlag = DSARFlagGroup.add(Flag{
+		Name:       "format",
+		ConfigName: "dsar.format",
+		Shorthand:  "f",
+		Value:      FormatJSON,
+		Usage:      "Specify the output format (json, yaml).",
+	})
+	DSAROutputFlag = DSARFlagGroup.add(Flag{
+		Name:       "output",
+		ConfigName: "dsar.output",
+		Value:      "",
+		Usage:      "Specify the output path for the export.",
+	})
+)
+
+type DSAROptions struct {
+	DSARDataType string `mapstructure:"dsar_data_type" json:"dsar_data_type" yaml:"dsar_data_type"`
+	DSARFormat   string `mapstructure:"dsar_format" json:"dsar_format" yaml:"dsar_format"`
+	DSAROutput   string `mapstructure:"dsar_output" json:"dsar_output" yaml:"dsar_output"`
+}
+
+func (dsarFlagGroup) SetOptions(options *Options, args []string) error {
+	format := getString(DSARFormatFlag)
+	switch format {
+	case FormatJSON, FormatYAML:
+	default:
+		return ErrInvalidFormatDSAR
+	}
+
+	dataType := getString(DSARDataTypeFlag)
+	if dataType == "" {
+		return ErrMissingDataType
+	}
+
+	options.DSAROptions = DSAROptions{
+		DSARDataType: dataType,
+		DSARFormat:   format,
+		DSAROutput:   getString(DSAROutputFlag),
+	}
+
+	return nil
+}
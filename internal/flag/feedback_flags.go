@@ -0,0 +1,59 @@
+package flag
+
+import "errors"
+
+type feedbackFlagGroup struct{ flagGroupBase }
+
+var FeedbackFlagGroup = &feedbackFlagGroup{flagGroupBase{name: "Feedback"}}
+
+var (
+	ErrFeedbackReasonRequired     = errors.New("a reason is required; use --reason to explain why the finding is a false positive")
+	ErrFeedbackReportFileRequired = errors.New("a security report is required; use --report-file with a report from bearer scan --format json")
+)
+
+var (
+	FeedbackReasonFlag = FeedbackFlagGroup.add(Flag{
+		Name:       "reason",
+		ConfigName: "feedback.reason",
+		Value:      "",
+		Usage:      "Explain why the finding is a false positive.",
+	})
+	FeedbackReportFileFlag = FeedbackFlagGroup.add(Flag{
+		Name:       "report-file",
+		ConfigName: "feedback.report-file",
+		Value:      "",
+		Usage:      "Specify the path of the security report (json or jsonv2) containing the finding.",
+	})
+	FeedbackOutputFlag = FeedbackFlagGroup.add(Flag{
+		Name:       "output",
+		ConfigName: "feedback.output",
+		Value:      "",
+		Usage:      "Specify the output path for the feedback report.",
+	})
+)
+
+type FeedbackOptions struct {
+	FeedbackReason     string `mapstructure:"feedback_reason" json:"feedback_reason" yaml:"feedback_reason"`
+	FeedbackReportFile string `mapstructure:"feedback_report_file" json:"feedback_report_file" yaml:"feedback_report_file"`
+	FeedbackOutput     string `mapstructure:"feedback_output" json:"feedback_output" yaml:"feedback_output"`
+}
+
+func (feedbackFlagGroup) SetOptions(options *Options, args []string) error {
+	reason := getString(FeedbackReasonFlag)
+	if reason == "" {
+		return ErrFeedbackReasonRequired
+	}
+
+	reportFile := getString(FeedbackReportFileFlag)
+	if reportFile == "" {
+		return ErrFeedbackReportFileRequired
+	}
+
+	options.FeedbackOptions = FeedbackOptions{
+		FeedbackReason:     reason,
+		FeedbackReportFile: reportFile,
+		FeedbackOutput:     getString(FeedbackOutputFlag),
+	}
+
+	return nil
+}
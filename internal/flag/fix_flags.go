@@ -0,0 +1,57 @@
+package flag
+
+import "errors"
+
+type fixFlagGroup struct{ flagGroupBase }
+
+var FixFlagGroup = &fixFlagGroup{flagGroupBase{name: "Fix"}}
+
+var (
+	ErrFixReportFileRequired = errors.New("a security report is required; use --report-file with a report from bearer scan --format json")
+	ErrFixApplyAndDryRun     = errors.New("--apply and --dry-run cannot be used together")
+)
+
+var (
+	FixReportFileFlag = FixFlagGroup.add(Flag{
+		Name:       "report-file",
+		ConfigName: "fix.report-file",
+		Value:      "",
+		Usage:      "Specify the path of the security report (json or jsonv2) containing the findings to fix.",
+	})
+	FixDryRunFlag = FixFlagGroup.add(Flag{
+		Name:       "dry-run",
+		ConfigName: "fix.dry-run",
+		Value:      false,
+		Usage:      "Output the fixes as unified diffs without changing any file. This is the default.",
+	})
+	FixApplyFlag = FixFlagGroup.add(Flag{
+		Name:       "apply",
+		ConfigName: "fix.apply",
+		Value:      false,
+		Usage:      "Apply the fixes to the files.",
+	})
+)
+
+type FixOptions struct {
+	FixReportFile string `mapstructure:"fix_report_file" json:"fix_report_file" yaml:"fix_report_file"`
+	FixApply      bool   `mapstructure:"fix_apply" json:"fix_apply" yaml:"fix_apply"`
+}
+
+func (fixFlagGroup) SetOptions(options *Options, args []string) error {
+	reportFile := getString(FixReportFileFlag)
+	if reportFile == "" {
+		return ErrFixReportFileRequired
+	}
+
+	apply := getBool(FixApplyFlag)
+	if apply && getBool(FixDryRunFlag) {
+		return ErrFixApplyAndDryRun
+	}
+
+	options.FixOptions = FixOptions{
+		FixReportFile: reportFile,
+		FixApply:      apply,
+	}
+
+	return nil
+}
@@ -1,10 +1,12 @@
 package flag
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/bearer/bearer/api"
 	pointer "github.com/bearer/bearer/internal/util/pointers"
+	"github.com/bearer/bearer/internal/util/workdir"
 	"github.com/rs/zerolog/log"
 )
 
@@ -15,6 +17,8 @@ const (
 	TraceLogLevel = "trace"
 )
 
+var ErrOfflineAPIKey = errors.New("--api-key cannot be used with --offline as sending the report to Bearer Cloud requires network access")
+
 type generalFlagGroup struct{ flagGroupBase }
 
 var GeneralFlagGroup = &generalFlagGroup{flagGroupBase{name: "General"}}
@@ -53,6 +57,13 @@ var (
 		Usage:      "Disable Bearer version checking",
 	})
 
+	OfflineFlag = GeneralFlagGroup.add(Flag{
+		Name:       "offline",
+		ConfigName: "offline",
+		Value:      false,
+		Usage:      "Disable all network access, including version checks, rule downloads and Bearer Cloud. Only locally cached rules are used.",
+	})
+
 	NoColorFlag = GeneralFlagGroup.add(Flag{
 		Name:       "no-color",
 		ConfigName: "report.no-color",
@@ -92,6 +103,13 @@ var (
 		DisableInConfig: true,
 	})
 
+	WorkDirFlag = GeneralFlagGroup.add(Flag{
+		Name:       "workdir",
+		ConfigName: "workdir",
+		Value:      "",
+		Usage:      "Specify the directory to write temporary files, caches and downloaded rules to. Defaults to the system temporary directory.",
+	})
+
 	IgnoreGitFlag = GeneralFlagGroup.add(Flag{
 		Name:            "ignore-git",
 		ConfigName:      "ignore-git",
@@ -107,17 +125,23 @@ type GeneralOptions struct {
 	ConfigFile          string `json:"config_file" yaml:"config_file"`
 	Client              *api.API
 	DisableVersionCheck bool
+	Offline             bool   `mapstructure:"offline" json:"offline" yaml:"offline"`
 	NoColor             bool   `mapstructure:"no_color" json:"no_color" yaml:"no_color"`
 	IgnoreFile          string `mapstructure:"ignore_file" json:"ignore_file" yaml:"ignore_file"`
 	Debug               bool   `mapstructure:"debug" json:"debug" yaml:"debug"`
 	LogLevel            string `mapstructure:"log-level" json:"log-level" yaml:"log-level"`
 	DebugProfile        bool
-	IgnoreGit           bool `mapstructure:"ignore-git" json:"ignore-git" yaml:"ignore-git"`
+	WorkDir             string `mapstructure:"workdir" json:"workdir" yaml:"workdir"`
+	IgnoreGit           bool   `mapstructure:"ignore-git" json:"ignore-git" yaml:"ignore-git"`
 }
 
 func (generalFlagGroup) SetOptions(options *Options, args []string) error {
 	var client *api.API
+	offline := getBool(OfflineFlag)
 	apiKey := getString(APIKeyFlag)
+	if apiKey != "" && offline {
+		return ErrOfflineAPIKey
+	}
 	if apiKey != "" {
 		client = api.New(api.API{
 			Host:  getString(HostFlag),
@@ -139,16 +163,23 @@ func (generalFlagGroup) SetOptions(options *Options, args []string) error {
 		logLevel = DebugLogLevel
 	}
 
+	workDir := getString(WorkDirFlag)
+	if err := workdir.Setup(workDir); err != nil {
+		return fmt.Errorf("invalid workdir %s: %w", workDir, err)
+	}
+
 	options.GeneralOptions = GeneralOptions{
 		Client:              client,
 		ConfigFile:          getString(ConfigFileFlag),
 		DisableVersionCheck: getBool(DisableVersionCheckFlag),
+		Offline:             offline,
 		NoColor:             getBool(NoColorFlag),
 		IgnoreFile:          getString(IgnoreFileFlag),
 		Debug:               debug,
 		LogLevel:            logLevel,
 		IgnoreGit:           getBool(IgnoreGitFlag),
 		DebugProfile:        getBool(DebugProfileFlag),
+		WorkDir:             workDir,
 	}
 
 	return nil
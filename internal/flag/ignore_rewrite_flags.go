@@ -0,0 +1,73 @@
+package flag
+
+type ignoreRewriteFlagGroup struct{ flagGroupBase }
+
+var IgnoreRewriteFlagGroup = &ignoreRewriteFlagGroup{flagGroupBase{name: "Ignore Rewrite"}}
+
+var (
+	IgnoreRewritePathPrefixFlag = IgnoreRewriteFlagGroup.add(Flag{
+		Name:       "path-prefix",
+		ConfigName: "ignore_rewrite.path-prefix",
+		Value:      []string{},
+		Usage:      "Specify a moved path as old=new, e.g. --path-prefix=app/=services/app/. Paths ending with / are directories.",
+	})
+	IgnoreRewriteRenameMapFlag = IgnoreRewriteFlagGroup.add(Flag{
+		Name:       "rename-map",
+		ConfigName: "ignore_rewrite.rename-map",
+		Value:      "",
+		Usage:      "Specify a YAML or JSON file mapping old paths to new paths.",
+	})
+	IgnoreRewriteDryRunFlag = IgnoreRewriteFlagGroup.add(Flag{
+		Name:       "dry-run",
+		ConfigName: "ignore_rewrite.dry-run",
+		Value:      false,
+		Usage:      "Show the rewritten fingerprints without updating the ignore file.",
+	})
+	IgnoreRewriteExternalRuleDirFlag = IgnoreRewriteFlagGroup.add(Flag{
+		Name:       "external-rule-dir",
+		ConfigName: "scan.external-rule-dir",
+		Value:      []string{},
+		Usage:      "Specify directories paths that contain .yaml files with external rules to include in the search",
+	})
+	IgnoreRewriteFingerprintHashFlag = IgnoreRewriteFlagGroup.add(Flag{
+		Name:       "fingerprint-hash",
+		ConfigName: "report.fingerprint-hash",
+		Value:      FingerprintHashMD5,
+		Usage:      "Specify the hash function used to generate finding fingerprints (md5, sha256).",
+	})
+	IgnoreRewriteFingerprintSaltFlag = IgnoreRewriteFlagGroup.add(Flag{
+		Name:       "fingerprint-salt",
+		ConfigName: "report.fingerprint-salt",
+		Value:      "",
+		Usage:      "Specify the organization-specific salt used to generate finding fingerprints.",
+	})
+)
+
+type IgnoreRewriteOptions struct {
+	RewritePathPrefixes    []string `mapstructure:"ignore_rewrite_path_prefix" json:"ignore_rewrite_path_prefix" yaml:"ignore_rewrite_path_prefix"`
+	RewriteRenameMap       string   `mapstructure:"ignore_rewrite_rename_map" json:"ignore_rewrite_rename_map" yaml:"ignore_rewrite_rename_map"`
+	RewriteDryRun          bool     `mapstructure:"ignore_rewrite_dry_run" json:"ignore_rewrite_dry_run" yaml:"ignore_rewrite_dry_run"`
+	RewriteExternalRuleDir []string `mapstructure:"ignore_rewrite_external_rule_dir" json:"ignore_rewrite_external_rule_dir" yaml:"ignore_rewrite_external_rule_dir"`
+	RewriteFingerprintHash string   `mapstructure:"ignore_rewrite_fingerprint_hash" json:"ignore_rewrite_fingerprint_hash" yaml:"ignore_rewrite_fingerprint_hash"`
+	RewriteFingerprintSalt string   `mapstructure:"ignore_rewrite_fingerprint_salt" json:"ignore_rewrite_fingerprint_salt" yaml:"ignore_rewrite_fingerprint_salt"`
+}
+
+func (ignoreRewriteFlagGroup) SetOptions(options *Options, args []string) error {
+	fingerprintHash := getString(IgnoreRewriteFingerprintHashFlag)
+	switch fingerprintHash {
+	case FingerprintHashMD5, FingerprintHashSHA256:
+	default:
+		return ErrInvalidFingerprintHash
+	}
+
+	options.IgnoreRewriteOptions = IgnoreRewriteOptions{
+		RewritePathPrefixes:    getStringSlice(IgnoreRewritePathPrefixFlag),
+		RewriteRenameMap:       getString(IgnoreRewriteRenameMapFlag),
+		RewriteDryRun:          getBool(IgnoreRewriteDryRunFlag),
+		RewriteExternalRuleDir: getStringSlice(IgnoreRewriteExternalRuleDirFlag),
+		RewriteFingerprintHash: fingerprintHash,
+		RewriteFingerprintSalt: getString(IgnoreRewriteFingerprintSaltFlag),
+	}
+
+	return nil
+}
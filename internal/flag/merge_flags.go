@@ -0,0 +1,46 @@
+package flag
+
+import "errors"
+
+type mergeFlagGroup struct{ flagGroupBase }
+
+var MergeFlagGroup = &mergeFlagGroup{flagGroupBase{name: "Merge"}}
+
+var ErrInvalidFormatMerge = errors.New("invalid format argument for merge-dataflow; supported values: json, yaml, mermaid, dot")
+
+var (
+	MergeFormatFlag = MergeFlagGroup.add(Flag{
+		Name:       "format",
+		ConfigName: "merge.format",
+		Shorthand:  "f",
+		Value:      FormatJSON,
+		Usage:      "Specify the output format (json, yaml, mermaid, dot).",
+	})
+	MergeOutputFlag = MergeFlagGroup.add(Flag{
+		Name:       "output",
+		ConfigName: "merge.output",
+		Value:      "",
+		Usage:      "Specify the output path for the merged report.",
+	})
+)
+
+type MergeOptions struct {
+	MergeFormat string `mapstructure:"merge_format" json:"merge_format" yaml:"merge_format"`
+	MergeOutput string `mapstructure:"merge_output" json:"merge_output" yaml:"merge_output"`
+}
+
+func (mergeFlagGroup) SetOptions(options *Options, args []string) error {
+	format := getString(MergeFormatFlag)
+	switch format {
+	case FormatJSON, FormatYAML, FormatMermaid, FormatDOT:
+	default:
+		return ErrInvalidFormatMerge
+	}
+
+	options.MergeOptions = MergeOptions{
+		MergeFormat: format,
+		MergeOutput: getString(MergeOutputFlag),
+	}
+
+	return nil
+}
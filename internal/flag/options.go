@@ -15,7 +15,7 @@ import (
 	"github.com/bearer/bearer/internal/util/set"
 )
 
-var ErrInvalidScannerReportCombination = errors.New("invalid scanner argument; privacy report requires sast scanner")
+var ErrInvalidScannerReportCombination = errors.New("invalid scanner argument; privacy, ropa, logs and residency reports require sast scanner")
 
 type Flag struct {
 	// Name is for CLI flag and environment variable.
@@ -70,6 +70,23 @@ type Options struct {
 	IgnoreAddOptions
 	IgnoreShowOptions
 	IgnoreMigrateOptions
+	IgnoreRewriteOptions
+	DiffOptions
+	TrendOptions
+	ConformanceOptions
+	MergeOptions
+	DSAROptions
+	RulePackOptions
+	RulePushOptions
+	RulePullOptions
+	RuleLockOptions
+	RuleNewOptions
+	RuleImportOptions
+	RuleListOptions
+	RuleTestOptions
+	DocsOptions
+	FeedbackOptions
+	FixOptions
 	WorkerOptions
 }
 
@@ -198,6 +215,22 @@ func getSeverities(flag *Flag) set.Set[string] {
 	return result
 }
 
+func getProcessorCategories(flag *Flag) ([]string, error) {
+	var result []string
+
+	for _, value := range getStringSlice(flag) {
+		if !slices.Contains(types.ProcessorCategories, value) {
+			return nil, ErrInvalidProcessorCategory
+		}
+
+		if !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+
+	return result, nil
+}
+
 func (f *flagGroupBase) add(flag Flag) *Flag {
 	f.flags = append(f.flags, &flag)
 	return &flag
@@ -277,7 +310,7 @@ func (f Flags) ToOptions(args []string) (Options, error) {
 		}
 	}
 
-	if options.ReportOptions.Report == "privacy" && !slices.Contains(options.ScanOptions.Scanner, "sast") {
+	if slices.Contains([]string{ReportPrivacy, ReportRoPA, ReportLogs, ReportResidency}, options.ReportOptions.Report) && !slices.Contains(options.ScanOptions.Scanner, "sast") {
 		return Options{}, ErrInvalidScannerReportCombination
 	}
 
@@ -2,7 +2,14 @@ package flag
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/spf13/viper"
 
 	globaltypes "github.com/bearer/bearer/internal/types"
 	"github.com/bearer/bearer/internal/util/set"
@@ -12,29 +19,78 @@ import (
 var (
 	FormatReviewDog  = "rdjson"
 	FormatGitLabSast = "gitlab-sast"
+	FormatSonarQube  = "sonarqube"
+	FormatDefectDojo = "defectdojo"
 	FormatSarif      = "sarif"
 	FormatJSON       = "json"
 	FormatJSONV2     = "jsonv2"
+	FormatJSONL      = "jsonl"
 	FormatYAML       = "yaml"
 	FormatHTML       = "html"
 	FormatCSV        = "csv"
+	FormatTemplate   = "template"
+	FormatBillOfData = "bill-of-data"
+	FormatMermaid    = "mermaid"
+	FormatDOT        = "dot"
 	FormatEmpty      = ""
 
+	GroupByRule      = "rule"
+	GroupByFile      = "file"
+	GroupByDataType  = "datatype"
+	GroupByOwner     = "owner"
+	GroupByWorkspace = "workspace"
+
+	FingerprintHashMD5    = "md5"
+	FingerprintHashSHA256 = "sha256"
+
 	ReportPrivacy   = "privacy"
 	ReportSecurity  = "security"
 	ReportDataFlow  = "dataflow"
+	ReportRoPA      = "ropa"
+	ReportLogs      = "logs"
+	ReportResidency = "residency"
 	ReportDetectors = "detectors" // nodoc: internal report type
 	ReportSaaS      = "saas"      // nodoc: internal report type
 	ReportStats     = "stats"     // nodoc: internal report type
 )
 
 var (
-	ErrInvalidFormatSecurity = errors.New("invalid format argument for security report; supported values: json, yaml, sarif, gitlab-sast, rdjson, html, jsonv2")
-	ErrInvalidFormatPrivacy  = errors.New("invalid format argument for privacy report; supported values: csv, json, yaml, html")
-	ErrInvalidFormatDefault  = errors.New("invalid format argument; supported values: json, yaml")
-	ErrInvalidReport         = errors.New("invalid report argument; supported values: security, privacy")
-	ErrInvalidSeverity       = errors.New("invalid severity argument; supported values: " + strings.Join(globaltypes.Severities, ", "))
-	ErrInvalidFailOnSeverity = errors.New("invalid fail-on-severity argument; supported values: " + strings.Join(globaltypes.Severities, ", "))
+	ErrInvalidFormatSecurity     = errors.New("invalid format argument for security report; supported values: json, yaml, sarif, gitlab-sast, rdjson, sonarqube, defectdojo, html, template, jsonl, jsonv2")
+	ErrInvalidFormatPrivacy      = errors.New("invalid format argument for privacy report; supported values: csv, json, yaml, html, template")
+	ErrInvalidFormatDataFlow     = errors.New("invalid format argument for dataflow report; supported values: json, yaml, bill-of-data, mermaid, dot, template")
+	ErrInvalidFormatRoPA         = errors.New("invalid format argument for ropa report; supported values: json, yaml, csv, template")
+	ErrInvalidFormatLogs         = errors.New("invalid format argument for logs report; supported values: json, yaml, csv, template")
+	ErrInvalidFormatResidency    = errors.New("invalid format argument for residency report; supported values: json, yaml, csv, template")
+	ErrInvalidFormatDefault      = errors.New("invalid format argument; supported values: json, yaml, template")
+	ErrInvalidReport             = errors.New("invalid report argument; supported values: security, privacy, dataflow, ropa, logs, residency")
+	ErrInvalidSeverity           = errors.New("invalid severity argument; supported values: " + strings.Join(globaltypes.Severities, ", "))
+	ErrInvalidSkipSeverity       = errors.New("invalid skip-severity argument; supported values: " + strings.Join(globaltypes.Severities, ", "))
+	ErrInvalidFailOnSeverity     = errors.New("invalid fail-on-severity argument; supported values: " + strings.Join(globaltypes.Severities, ", "))
+	ErrInvalidGatesMinConfidence = errors.New("invalid gates.min-confidence configuration; keys must be one of: " + strings.Join(globaltypes.Severities, ", ") + " and values one of: " + strings.Join(globaltypes.Confidences, ", "))
+	ErrInvalidMinConfidence      = errors.New("invalid min-confidence argument; supported values: " + strings.Join(globaltypes.Confidences, ", "))
+	ErrInvalidGroupBy            = errors.New("invalid group-by argument; supported values: rule, file, datatype, owner, workspace")
+	ErrInvalidGroupByReport      = errors.New("group-by is only supported for the security report")
+	ErrInvalidMeta               = errors.New("invalid meta argument; metadata must be given as key=value pairs")
+	ErrInvalidHistoryFileReport  = errors.New("history-file is only supported for the security report")
+	ErrInvalidRuleCoverageReport = errors.New("rule-coverage is only supported for the security report")
+	ErrInvalidPurposesReport     = errors.New("processing-purposes is only supported for the ropa report")
+	ErrMissingResidencyRegion    = errors.New("the residency report requires the report.residency-region configuration")
+	ErrInvalidProcessorCategory  = errors.New("invalid processor category argument; supported values: " + strings.Join(globaltypes.ProcessorCategories, ", "))
+	ErrInvalidProcessorReport    = errors.New("processor-category is only supported for the privacy and dataflow reports")
+	ErrInvalidFailOnProcessor    = errors.New("fail-on-processor-category is only supported for the security report")
+	ErrInvalidContextLines       = errors.New("invalid context-lines argument; must be zero or a positive number")
+	ErrInvalidContextLinesReport = errors.New("context-lines is only supported for the security report")
+	ErrInvalidAnnotationLimit    = errors.New("invalid annotation-limit argument; must be zero or a positive number")
+	ErrInvalidFingerprintHash    = errors.New("invalid fingerprint-hash argument; supported values: md5, sha256")
+	ErrInvalidStreamToCloud      = errors.New("stream-to-cloud is only supported for the security and saas reports")
+	ErrInvalidPolicyReport       = errors.New("policy is only supported for the security and saas reports")
+	ErrInvalidException          = errors.New("invalid exceptions configuration; each exception must give a fingerprint or a rule, with an owner and a reason")
+	ErrInvalidExceptionExpiry    = errors.New("invalid exceptions configuration; expiry dates must be given as YYYY-MM-DD")
+	ErrInvalidRedactPattern      = errors.New("invalid report.redact-patterns configuration; patterns must be valid regular expressions")
+	ErrOutputDirRequired         = errors.New("multiple formats require an output directory; use --output-dir to specify one")
+	ErrOutputWithOutputDir       = errors.New("output and output-dir cannot be used together")
+	ErrJSONLWithOutputDir        = errors.New("jsonl format cannot be used with output-dir")
+	ErrTemplateRequired          = errors.New("template format requires a template file; use --template to specify one")
 )
 
 type reportFlagGroup struct{ flagGroupBase }
@@ -47,13 +103,13 @@ var (
 		ConfigName: "report.format",
 		Shorthand:  "f",
 		Value:      FormatEmpty,
-		Usage:      "Specify report format (json, yaml, sarif, gitlab-sast, rdjson, html)",
+		Usage:      "Specify report format (json, jsonl, yaml, sarif, gitlab-sast, rdjson, sonarqube, defectdojo, html, template, bill-of-data, mermaid, dot). Separate multiple formats with commas when using --output-dir.",
 	})
 	ReportFlag = ReportFlagGroup.add(Flag{
 		Name:       "report",
 		ConfigName: "report.report",
 		Value:      ReportSecurity,
-		Usage:      "Specify the type of report (security, privacy, dataflow).",
+		Usage:      "Specify the type of report (security, privacy, dataflow, ropa, logs, residency).",
 	})
 	OutputFlag = ReportFlagGroup.add(Flag{
 		Name:       "output",
@@ -61,18 +117,184 @@ var (
 		Value:      "",
 		Usage:      "Specify the output path for the report.",
 	})
+	OutputDirFlag = ReportFlagGroup.add(Flag{
+		Name:       "output-dir",
+		ConfigName: "report.output-dir",
+		Value:      "",
+		Usage:      "Specify a directory to write the report to, with one file for each format.",
+	})
+	TemplateFlag = ReportFlagGroup.add(Flag{
+		Name:       "template",
+		ConfigName: "report.template",
+		Value:      "",
+		Usage:      "Specify the path to a Go template file used to render the report. Works in conjunction with --format=template.",
+	})
+	GroupByFlag = ReportFlagGroup.add(Flag{
+		Name:       "group-by",
+		ConfigName: "report.group-by",
+		Value:      "",
+		Usage:      "Group findings in the security report by rule, file, datatype, owner (from CODEOWNERS) or workspace (Go module or JavaScript workspace package).",
+	})
+	ContextLinesFlag = ReportFlagGroup.add(Flag{
+		Name:       "context-lines",
+		ConfigName: "report.context-lines",
+		Value:      0,
+		Usage:      "Specify the number of lines of surrounding source code to include with each security finding. Secrets in these lines are masked.",
+	})
+	AnnotationLimitFlag = ReportFlagGroup.add(Flag{
+		Name:       "annotation-limit",
+		ConfigName: "report.annotation-limit",
+		Value:      50,
+		Usage:      "Specify the maximum number of findings annotated by the rdjson format. The remaining findings are summarized in a single annotation. Use 0 for no limit.",
+	})
+	AnnotationSummaryURLFlag = ReportFlagGroup.add(Flag{
+		Name:       "annotation-summary-url",
+		ConfigName: "report.annotation-summary-url",
+		Value:      "",
+		Usage:      "Specify the URL of the full report to link from the summary annotation. Defaults to the GitHub Actions run or GitLab CI job.",
+	})
+	MetaFlag = ReportFlagGroup.add(Flag{
+		Name:       "meta",
+		ConfigName: "report.meta",
+		Value:      []string{},
+		Usage:      "Specify comma-separated key=value pairs of metadata to attach to the report, e.g. tier=1,business-unit=payments.",
+	})
+	HistoryFileFlag = ReportFlagGroup.add(Flag{
+		Name:       "history-file",
+		ConfigName: "report.history-file",
+		Value:      "",
+		Usage:      "Specify a local path or http(s) URL to record the finding and data type counts of each scan, for use with the trend command.",
+	})
+	RuleCoverageFlag = ReportFlagGroup.add(Flag{
+		Name:       "rule-coverage",
+		ConfigName: "report.rule-coverage",
+		Value:      "",
+		Usage:      "Specify a path to write a JSON summary of which rules were evaluated in the scan, and why others were skipped.",
+	})
+	ProcessingPurposesFlag = ReportFlagGroup.add(Flag{
+		Name:       "processing-purposes",
+		ConfigName: "report.processing-purposes",
+		Value:      "",
+		Usage:      "Specify the path to a YAML or JSON file describing the controller and processing purposes for the ropa report.",
+	})
+	ResidencyRegionFlag = ReportFlagGroup.add(Flag{
+		ConfigName: "report.residency-region",
+		Value:      "",
+		Usage:      "Specify the region data is declared to reside in, for the residency report.",
+	})
+	ResidencyComponentsFlag = ReportFlagGroup.add(Flag{
+		ConfigName: "report.residency-components",
+		Value:      []ResidencyComponent{},
+		Usage:      "Assign data stores, internal services and third parties to the region they process data in, for the residency report.",
+	})
+	ProcessorCategoryFlag = ReportFlagGroup.add(Flag{
+		Name:       "processor-category",
+		ConfigName: "report.processor-category",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated categories of the third parties listed in the privacy and dataflow reports (" + strings.Join(globaltypes.ProcessorCategories, ", ") + ").",
+	})
+	FailOnProcessorCategoryFlag = ReportFlagGroup.add(Flag{
+		Name:       "fail-on-processor-category",
+		ConfigName: "report.fail-on-processor-category",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated categories of third parties which cause the security report to fail when classified data is found alongside them, e.g. advertising.",
+	})
 	SeverityFlag = ReportFlagGroup.add(Flag{
 		Name:       "severity",
 		ConfigName: "report.severity",
 		Value:      strings.Join(globaltypes.Severities, ","),
 		Usage:      "Specify which severities are included in the report.",
 	})
+	SkipSeverityFlag = ReportFlagGroup.add(Flag{
+		Name:       "skip-severity",
+		ConfigName: "report.skip-severity",
+		Value:      "",
+		Usage:      "Specify which severities are left out of the report.",
+	})
+	OnlyPathFlag = ReportFlagGroup.add(Flag{
+		Name:       "only-path",
+		ConfigName: "report.only-path",
+		Value:      []string{},
+		Usage:      "Specify the comma separated files and directories to restrict the findings of the report to. Supports * syntax, e.g. --only-path users/*.go,users/admin.sql",
+	})
+	OnlyReportRuleFlag = ReportFlagGroup.add(Flag{
+		Name:       "only-report-rule",
+		ConfigName: "report.only-report-rule",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated ids of the rules to restrict the findings of the report to. Unlike --only-rule, all rules are still run.",
+	})
 	FailOnSeverityFlag = ReportFlagGroup.add(Flag{
 		Name:       "fail-on-severity",
 		ConfigName: "report.fail-on-severity",
 		Value:      strings.Join(sliceutil.Except(globaltypes.Severities, globaltypes.LevelWarning), ","),
 		Usage:      "Specify which severities cause the report to fail. Works in conjunction with --exit-code.",
 	})
+	GatesMinConfidenceFlag = ReportFlagGroup.add(Flag{
+		ConfigName: "gates.min-confidence",
+		Value:      map[string]string{},
+		Usage:      "Specify the minimum rule confidence required for findings of each severity to cause the report to fail.",
+	})
+	MinConfidenceFlag = ReportFlagGroup.add(Flag{
+		Name:       "min-confidence",
+		ConfigName: "report.min-confidence",
+		Value:      "",
+		Usage:      "Specify the minimum confidence required for findings to cause the report to fail. Findings below it are still reported.",
+	})
+	PseudonymizersFlag = ReportFlagGroup.add(Flag{
+		ConfigName: "report.pseudonymization-functions",
+		Value:      []string{},
+		Usage:      "Specify the hashing, tokenization and pseudonymization functions which lower the severity of findings when data passes through them.",
+	})
+	FingerprintHashFlag = ReportFlagGroup.add(Flag{
+		Name:       "fingerprint-hash",
+		ConfigName: "report.fingerprint-hash",
+		Value:      FingerprintHashMD5,
+		Usage:      "Specify the hash function used to generate finding fingerprints (md5, sha256).",
+	})
+	FingerprintSaltFlag = ReportFlagGroup.add(Flag{
+		Name:       "fingerprint-salt",
+		ConfigName: "report.fingerprint-salt",
+		Value:      "",
+		Usage:      "Specify an organization-specific salt for finding fingerprints, so they can't be correlated with those of other organizations.",
+	})
+	FingerprintCompatibilityFlag = ReportFlagGroup.add(Flag{
+		Name:       "fingerprint-compatibility",
+		ConfigName: "report.fingerprint-compatibility",
+		Value:      false,
+		Usage:      "Continue to apply ignored fingerprints generated with the default md5 hash and no salt. Works in conjunction with --fingerprint-hash and --fingerprint-salt.",
+	})
+	RedactPatternsFlag = ReportFlagGroup.add(Flag{
+		ConfigName: "report.redact-patterns",
+		Value:      []string{},
+		Usage:      "Specify regular expressions for values to redact from the report before it is sent to Bearer Cloud.",
+	})
+	PostProcessorsFlag = ReportFlagGroup.add(Flag{
+		ConfigName: "report.post-processors",
+		Value:      []PostProcessor{},
+		Usage:      "Specify commands which the finished report is piped through, for all formats or a given format.",
+	})
+	PolicyFlag = ReportFlagGroup.add(Flag{
+		Name:       "policy",
+		ConfigName: "report.policy",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated paths of Rego policies, which can contain wildcards, to drop, change the severity of or annotate findings of the security report.",
+	})
+	StreamToCloudFlag = ReportFlagGroup.add(Flag{
+		Name:       "stream-to-cloud",
+		ConfigName: "report.stream-to-cloud",
+		Value:      false,
+		Usage:      "Send findings to Bearer Cloud in batches as rules are evaluated, ahead of the full report. Requires --api-key.",
+	})
+	EncryptTempFilesFlag = ReportFlagGroup.add(Flag{
+		ConfigName: "report.encrypt-temp-files",
+		Value:      false,
+		Usage:      "Encrypt the report while it is held in a temporary file before it is sent to Bearer Cloud.",
+	})
+	ExceptionsFlag = ReportFlagGroup.add(Flag{
+		ConfigName: "exceptions",
+		Value:      []FindingException{},
+		Usage:      "Accept the risk of findings, recording who accepted it, why and until when. Excepted findings are reported separately and don't fail the report.",
+	})
 	ExcludeFingerprintFlag = ReportFlagGroup.add(Flag{
 		Name:            "exclude-fingerprint",
 		ConfigName:      "report.exclude-fingerprint",
@@ -85,12 +307,68 @@ var (
 )
 
 type ReportOptions struct {
-	Format             string          `mapstructure:"format" json:"format" yaml:"format"`
-	Report             string          `mapstructure:"report" json:"report" yaml:"report"`
-	Output             string          `mapstructure:"output" json:"output" yaml:"output"`
-	Severity           set.Set[string] `mapstructure:"severity" json:"severity" yaml:"severity"`
-	FailOnSeverity     set.Set[string] `mapstructure:"fail-on-severity" json:"fail-on-severity" yaml:"fail-on-severity"`
-	ExcludeFingerprint map[string]bool `mapstructure:"exclude_fingerprints" json:"exclude_fingerprints" yaml:"exclude_fingerprints"`
+	Format                   string               `mapstructure:"format" json:"format" yaml:"format"`
+	Formats                  []string             `mapstructure:"formats" json:"formats" yaml:"formats"`
+	OutputDir                string               `mapstructure:"output-dir" json:"output-dir" yaml:"output-dir"`
+	Report                   string               `mapstructure:"report" json:"report" yaml:"report"`
+	Output                   string               `mapstructure:"output" json:"output" yaml:"output"`
+	Template                 string               `mapstructure:"template" json:"template" yaml:"template"`
+	GroupBy                  string               `mapstructure:"group-by" json:"group-by" yaml:"group-by"`
+	Meta                     map[string]string    `mapstructure:"meta" json:"meta" yaml:"meta"`
+	HistoryFile              string               `mapstructure:"history-file" json:"history-file" yaml:"history-file"`
+	RuleCoverage             string               `mapstructure:"rule-coverage" json:"rule-coverage" yaml:"rule-coverage"`
+	ProcessingPurposes       string               `mapstructure:"processing-purposes" json:"processing-purposes" yaml:"processing-purposes"`
+	ResidencyRegion          string               `mapstructure:"residency-region" json:"residency-region" yaml:"residency-region"`
+	ResidencyComponents      []ResidencyComponent `mapstructure:"residency-components" json:"residency-components" yaml:"residency-components"`
+	ProcessorCategories      []string             `mapstructure:"processor-category" json:"processor-category" yaml:"processor-category"`
+	FailOnProcessors         []string             `mapstructure:"fail-on-processor-category" json:"fail-on-processor-category" yaml:"fail-on-processor-category"`
+	ContextLines             int                  `mapstructure:"context-lines" json:"context-lines" yaml:"context-lines"`
+	Severity                 set.Set[string]      `mapstructure:"severity" json:"severity" yaml:"severity"`
+	FailOnSeverity           set.Set[string]      `mapstructure:"fail-on-severity" json:"fail-on-severity" yaml:"fail-on-severity"`
+	OnlyPath                 []string             `mapstructure:"only-path" json:"only-path" yaml:"only-path"`
+	OnlyReportRule           []string             `mapstructure:"only-report-rule" json:"only-report-rule" yaml:"only-report-rule"`
+	ExcludeFingerprint       map[string]bool      `mapstructure:"exclude_fingerprints" json:"exclude_fingerprints" yaml:"exclude_fingerprints"`
+	Exceptions               []FindingException   `mapstructure:"exceptions" json:"exceptions,omitempty" yaml:"exceptions,omitempty"`
+	GatesMinConfidence       map[string]string    `mapstructure:"gates-min-confidence" json:"gates-min-confidence" yaml:"gates-min-confidence"`
+	Pseudonymizers           []string             `mapstructure:"pseudonymization-functions" json:"pseudonymization-functions" yaml:"pseudonymization-functions"`
+	FingerprintHash          string               `mapstructure:"fingerprint-hash" json:"fingerprint-hash" yaml:"fingerprint-hash"`
+	FingerprintSalt          string               `mapstructure:"fingerprint-salt" json:"-" yaml:"-"`
+	FingerprintCompatibility bool                 `mapstructure:"fingerprint-compatibility" json:"fingerprint-compatibility" yaml:"fingerprint-compatibility"`
+	RedactPatterns           []string             `mapstructure:"redact-patterns" json:"redact-patterns" yaml:"redact-patterns"`
+	PostProcessors           []PostProcessor      `mapstructure:"post-processors" json:"post-processors" yaml:"post-processors"`
+	Policy                   []string             `mapstructure:"policy" json:"policy" yaml:"policy"`
+	StreamToCloud            bool                 `mapstructure:"stream-to-cloud" json:"stream-to-cloud" yaml:"stream-to-cloud"`
+	EncryptTempFiles         bool                 `mapstructure:"encrypt-temp-files" json:"encrypt-temp-files" yaml:"encrypt-temp-files"`
+	AnnotationLimit          int                  `mapstructure:"annotation-limit" json:"annotation-limit" yaml:"annotation-limit"`
+	AnnotationSummaryURL     string               `mapstructure:"annotation-summary-url" json:"annotation-summary-url" yaml:"annotation-summary-url"`
+}
+
+// ResidencyComponent assigns a component, matched by name ignoring case, to the
+// region it processes data in
+type ResidencyComponent struct {
+	Name   string `mapstructure:"name" json:"name" yaml:"name"`
+	Region string `mapstructure:"region" json:"region" yaml:"region"`
+}
+
+// FindingException accepts the risk of a finding, given by its fingerprint, or
+// of the findings of a rule, optionally within some paths which use the same
+// patterns as skip-path. Expires is the last day the exception applies, as
+// YYYY-MM-DD.
+type FindingException struct {
+	Fingerprint string   `mapstructure:"fingerprint" json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+	Rule        string   `mapstructure:"rule" json:"rule,omitempty" yaml:"rule,omitempty"`
+	Paths       []string `mapstructure:"paths" json:"paths,omitempty" yaml:"paths,omitempty"`
+	Owner       string   `mapstructure:"owner" json:"owner" yaml:"owner"`
+	Reason      string   `mapstructure:"reason" json:"reason" yaml:"reason"`
+	Expires     string   `mapstructure:"expires" json:"expires,omitempty" yaml:"expires,omitempty"`
+}
+
+// PostProcessor is a shell command which receives the report of a format on
+// stdin, and whose output replaces it. Commands without a format receive every
+// format.
+type PostProcessor struct {
+	Format  string `mapstructure:"format" json:"format,omitempty" yaml:"format,omitempty"`
+	Command string `mapstructure:"command" json:"command" yaml:"command"`
 }
 
 func (reportFlagGroup) SetOptions(options *Options, args []string) error {
@@ -102,6 +380,13 @@ func (reportFlagGroup) SetOptions(options *Options, args []string) error {
 	case ReportSecurity:
 		invalidFormat = ErrInvalidFormatSecurity
 	case ReportDataFlow:
+		invalidFormat = ErrInvalidFormatDataFlow
+	case ReportRoPA:
+		invalidFormat = ErrInvalidFormatRoPA
+	case ReportLogs:
+		invalidFormat = ErrInvalidFormatLogs
+	case ReportResidency:
+		invalidFormat = ErrInvalidFormatResidency
 	// hidden flags for development use
 	case ReportDetectors:
 	case ReportSaaS:
@@ -110,36 +395,195 @@ func (reportFlagGroup) SetOptions(options *Options, args []string) error {
 		return ErrInvalidReport
 	}
 
-	format := getString(FormatFlag)
-	switch format {
-	case FormatYAML:
-	case FormatJSON:
-	case FormatEmpty:
-	case FormatHTML:
-		if report != ReportPrivacy && report != ReportSecurity {
-			return invalidFormat
+	var formats []string
+	for _, format := range strings.Split(getString(FormatFlag), ",") {
+		format = strings.TrimSpace(format)
+		if err := validateFormat(report, format, invalidFormat); err != nil {
+			return err
 		}
-	case FormatCSV:
-		if report != ReportPrivacy {
-			return invalidFormat
+		if !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+
+	outputDir := getString(OutputDirFlag)
+	if outputDir == "" {
+		if len(formats) > 1 {
+			return ErrOutputDirRequired
+		}
+	} else {
+		if getString(OutputFlag) != "" {
+			return ErrOutputWithOutputDir
+		}
+		if slices.Contains(formats, FormatJSONL) {
+			return ErrJSONLWithOutputDir
 		}
-	case FormatSarif, FormatGitLabSast, FormatReviewDog, FormatJSONV2:
+	}
+
+	groupBy := getString(GroupByFlag)
+	switch groupBy {
+	case "":
+	case GroupByRule, GroupByFile, GroupByDataType, GroupByOwner, GroupByWorkspace:
 		if report != ReportSecurity {
-			return invalidFormat
+			return ErrInvalidGroupByReport
 		}
 	default:
-		return invalidFormat
+		return ErrInvalidGroupBy
 	}
 
 	severity := getSeverities(SeverityFlag)
 	if severity == nil {
 		return ErrInvalidSeverity
 	}
+	skipSeverity := getSeverities(SkipSeverityFlag)
+	if skipSeverity == nil {
+		return ErrInvalidSkipSeverity
+	}
+	for skipped := range skipSeverity {
+		delete(severity, skipped)
+	}
 	failOnSeverity := getSeverities(FailOnSeverityFlag)
 	if failOnSeverity == nil {
 		return ErrInvalidFailOnSeverity
 	}
 
+	gatesMinConfidence := viper.GetStringMapString(GatesMinConfidenceFlag.ConfigName)
+	for severity, confidence := range gatesMinConfidence {
+		if !slices.Contains(globaltypes.Severities, severity) || !slices.Contains(globaltypes.Confidences, confidence) {
+			return ErrInvalidGatesMinConfidence
+		}
+	}
+
+	// gates.min-confidence takes precedence for the severities it configures
+	if minConfidence := getString(MinConfidenceFlag); minConfidence != "" {
+		if !slices.Contains(globaltypes.Confidences, minConfidence) {
+			return ErrInvalidMinConfidence
+		}
+
+		for _, severity := range globaltypes.Severities {
+			if _, exists := gatesMinConfidence[severity]; !exists {
+				gatesMinConfidence[severity] = minConfidence
+			}
+		}
+	}
+
+	historyFile := getString(HistoryFileFlag)
+	if historyFile != "" && report != ReportSecurity {
+		return ErrInvalidHistoryFileReport
+	}
+
+	ruleCoverage := getString(RuleCoverageFlag)
+	if ruleCoverage != "" && report != ReportSecurity {
+		return ErrInvalidRuleCoverageReport
+	}
+
+	processingPurposes := getString(ProcessingPurposesFlag)
+	if processingPurposes != "" && report != ReportRoPA {
+		return ErrInvalidPurposesReport
+	}
+
+	residencyRegion := strings.TrimSpace(getString(ResidencyRegionFlag))
+	if residencyRegion == "" && report == ReportResidency {
+		return ErrMissingResidencyRegion
+	}
+
+	var residencyComponents []ResidencyComponent
+	if err := viper.UnmarshalKey(ResidencyComponentsFlag.ConfigName, &residencyComponents); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", ResidencyComponentsFlag.ConfigName, err)
+	}
+	for i, component := range residencyComponents {
+		if strings.TrimSpace(component.Name) == "" {
+			return fmt.Errorf("invalid %s configuration: component %d has no name", ResidencyComponentsFlag.ConfigName, i+1)
+		}
+		if strings.TrimSpace(component.Region) == "" {
+			return fmt.Errorf("invalid %s configuration: component %q has no region", ResidencyComponentsFlag.ConfigName, component.Name)
+		}
+	}
+
+	processorCategories, err := getProcessorCategories(ProcessorCategoryFlag)
+	if err != nil {
+		return err
+	}
+	if len(processorCategories) != 0 && report != ReportPrivacy && report != ReportDataFlow {
+		return ErrInvalidProcessorReport
+	}
+
+	failOnProcessors, err := getProcessorCategories(FailOnProcessorCategoryFlag)
+	if err != nil {
+		return err
+	}
+	if len(failOnProcessors) != 0 && report != ReportSecurity {
+		return ErrInvalidFailOnProcessor
+	}
+
+	fingerprintHash := getString(FingerprintHashFlag)
+	switch fingerprintHash {
+	case FingerprintHashMD5, FingerprintHashSHA256:
+	default:
+		return ErrInvalidFingerprintHash
+	}
+
+	streamToCloud := getBool(StreamToCloudFlag)
+	if streamToCloud && report != ReportSecurity && report != ReportSaaS {
+		return ErrInvalidStreamToCloud
+	}
+
+	contextLines := getInteger(ContextLinesFlag)
+	if contextLines < 0 {
+		return ErrInvalidContextLines
+	}
+	if contextLines != 0 && report != ReportSecurity {
+		return ErrInvalidContextLinesReport
+	}
+
+	annotationLimit := getInteger(AnnotationLimitFlag)
+	if annotationLimit < 0 {
+		return ErrInvalidAnnotationLimit
+	}
+
+	meta := make(map[string]string)
+	for _, pair := range getStringSlice(MetaFlag) {
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return ErrInvalidMeta
+		}
+		meta[key] = strings.TrimSpace(value)
+	}
+
+	// patterns are not split on commas as they can be part of a regular expression
+	redactPatterns := viper.GetStringSlice(RedactPatternsFlag.ConfigName)
+	for _, pattern := range redactPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return ErrInvalidRedactPattern
+		}
+	}
+
+	var postProcessors []PostProcessor
+	if err := viper.UnmarshalKey(PostProcessorsFlag.ConfigName, &postProcessors); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", PostProcessorsFlag.ConfigName, err)
+	}
+	for i, postProcessor := range postProcessors {
+		if strings.TrimSpace(postProcessor.Command) == "" {
+			return fmt.Errorf("invalid %s configuration: post-processor %d has no command", PostProcessorsFlag.ConfigName, i+1)
+		}
+	}
+
+	policy := getStringSlice(PolicyFlag)
+	if len(policy) != 0 && report != ReportSecurity && report != ReportSaaS {
+		return ErrInvalidPolicyReport
+	}
+
+	var exceptions []FindingException
+	if err := viper.UnmarshalKey(ExceptionsFlag.ConfigName, &exceptions, viper.DecodeHook(dateToString)); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", ExceptionsFlag.ConfigName, err)
+	}
+	for _, exception := range exceptions {
+		if err := validateException(exception); err != nil {
+			return err
+		}
+	}
+
 	// turn string slice into map for ease of access
 	excludeFingerprints := getStringSlice(ExcludeFingerprintFlag)
 	excludeFingerprintsMapping := make(map[string]bool)
@@ -148,12 +592,102 @@ func (reportFlagGroup) SetOptions(options *Options, args []string) error {
 	}
 
 	options.ReportOptions = ReportOptions{
-		Format:             format,
-		Report:             report,
-		Output:             getString(OutputFlag),
-		Severity:           severity,
-		FailOnSeverity:     failOnSeverity,
-		ExcludeFingerprint: excludeFingerprintsMapping,
+		Format:                   formats[0],
+		Formats:                  formats,
+		OutputDir:                outputDir,
+		Report:                   report,
+		Output:                   getString(OutputFlag),
+		Template:                 getString(TemplateFlag),
+		GroupBy:                  groupBy,
+		Meta:                     meta,
+		HistoryFile:              historyFile,
+		RuleCoverage:             ruleCoverage,
+		ProcessingPurposes:       processingPurposes,
+		ResidencyRegion:          residencyRegion,
+		ResidencyComponents:      residencyComponents,
+		ProcessorCategories:      processorCategories,
+		FailOnProcessors:         failOnProcessors,
+		ContextLines:             contextLines,
+		Severity:                 severity,
+		FailOnSeverity:           failOnSeverity,
+		OnlyPath:                 getStringSlice(OnlyPathFlag),
+		OnlyReportRule:           getStringSlice(OnlyReportRuleFlag),
+		ExcludeFingerprint:       excludeFingerprintsMapping,
+		Exceptions:               exceptions,
+		GatesMinConfidence:       gatesMinConfidence,
+		Pseudonymizers:           viper.GetStringSlice(PseudonymizersFlag.ConfigName),
+		FingerprintHash:          fingerprintHash,
+		FingerprintSalt:          getString(FingerprintSaltFlag),
+		FingerprintCompatibility: getBool(FingerprintCompatibilityFlag),
+		PostProcessors:           postProcessors,
+		Policy:                   policy,
+		RedactPatterns:           redactPatterns,
+		StreamToCloud:            streamToCloud,
+		EncryptTempFiles:         getBool(EncryptTempFilesFlag),
+		AnnotationLimit:          annotationLimit,
+		AnnotationSummaryURL:     getString(AnnotationSummaryURLFlag),
+	}
+
+	return nil
+}
+
+// dateToString decodes dates, which YAML parses unquoted YYYY-MM-DD values
+// into, back to YYYY-MM-DD strings
+func dateToString(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if date, ok := data.(time.Time); ok && to.Kind() == reflect.String {
+		return date.Format(time.DateOnly), nil
+	}
+
+	return data, nil
+}
+
+func validateException(exception FindingException) error {
+	hasFingerprint := strings.TrimSpace(exception.Fingerprint) != ""
+	hasRule := strings.TrimSpace(exception.Rule) != ""
+	if hasFingerprint == hasRule || (hasFingerprint && len(exception.Paths) != 0) {
+		return ErrInvalidException
+	}
+
+	if strings.TrimSpace(exception.Owner) == "" || strings.TrimSpace(exception.Reason) == "" {
+		return ErrInvalidException
+	}
+
+	if exception.Expires != "" {
+		if _, err := time.Parse(time.DateOnly, exception.Expires); err != nil {
+			return ErrInvalidExceptionExpiry
+		}
+	}
+
+	return nil
+}
+
+func validateFormat(report string, format string, invalidFormat error) error {
+	switch format {
+	case FormatYAML:
+	case FormatJSON:
+	case FormatEmpty:
+	case FormatTemplate:
+		if getString(TemplateFlag) == "" {
+			return ErrTemplateRequired
+		}
+	case FormatHTML:
+		if report != ReportPrivacy && report != ReportSecurity {
+			return invalidFormat
+		}
+	case FormatBillOfData, FormatMermaid, FormatDOT:
+		if report != ReportDataFlow {
+			return invalidFormat
+		}
+	case FormatCSV:
+		if report != ReportPrivacy && report != ReportRoPA && report != ReportLogs && report != ReportResidency {
+			return invalidFormat
+		}
+	case FormatSarif, FormatGitLabSast, FormatReviewDog, FormatSonarQube, FormatDefectDojo, FormatJSONV2, FormatJSONL:
+		if report != ReportSecurity {
+			return invalidFormat
+		}
+	default:
+		return invalidFormat
 	}
 
 	return nil
@@ -1,9 +1,27 @@
 package flag
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	globaltypes "github.com/bearer/bearer/internal/types"
+)
+
 type ruleFlagGroup struct{ flagGroupBase }
 
 var RuleFlagGroup = &ruleFlagGroup{flagGroupBase{name: "Rule"}}
 
+var ErrInvalidRuleOverrideSeverity = errors.New(
+	"invalid severity in rule overrides; supported values: " + strings.Join(globaltypes.Severities, ", "),
+)
+var ErrMissingPathOverridePaths = errors.New("paths must be given for each rule path override")
+var ErrMissingSanitizerLanguages = errors.New("languages must be given for each rule sanitizer")
+var ErrMissingSanitizerPatterns = errors.New("patterns must be given for each rule sanitizer")
+
 var (
 	DisableDefaultRulesFlag = RuleFlagGroup.add(Flag{
 		Name:       "disable-default-rules",
@@ -23,19 +41,159 @@ var (
 		Value:      []string{},
 		Usage:      "Specify the comma-separated ids of the rules you would like to run. Skips all other rules.",
 	})
+	OnlyCWEFlag = RuleFlagGroup.add(Flag{
+		Name:       "only-cwe",
+		ConfigName: "rule.only-cwe",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated CWE ids of the rules you would like to run, eg. 89 or CWE-89. Skips all other rules.",
+	})
+	OnlyOWASPFlag = RuleFlagGroup.add(Flag{
+		Name:       "only-owasp",
+		ConfigName: "rule.only-owasp",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated OWASP Top 10 categories of the rules you would like to run, eg. A03:2021 or A03. Skips all other rules.",
+	})
+	RuleMappingsFlag = RuleFlagGroup.add(Flag{
+		ConfigName: "rule.mappings",
+		Value:      map[string]RuleMapping{},
+		Usage:      "Override the CWE ids and OWASP categories of rules.",
+	})
+	RuleOverridesFlag = RuleFlagGroup.add(Flag{
+		ConfigName: "rule.overrides",
+		Value:      map[string]RuleOverride{},
+		Usage:      "Override the severity of rules.",
+	})
+	RuleParametersFlag = RuleFlagGroup.add(Flag{
+		ConfigName: "rule.parameters",
+		Value:      map[string]map[string]interface{}{},
+		Usage:      "Set the parameters of rules.",
+	})
+	RulePathOverridesFlag = RuleFlagGroup.add(Flag{
+		ConfigName: "rule.path-overrides",
+		Value:      []PathOverride{},
+		Usage:      "Skip rules, or override their severity, for the files matching the given paths.",
+	})
+	RuleSanitizersFlag = RuleFlagGroup.add(Flag{
+		ConfigName: "rule.sanitizers",
+		Value:      []RuleSanitizer{},
+		Usage:      "Patterns of the code sanitizing values, which rules don't follow data through.",
+	})
 )
 
+// RuleMapping replaces the CWE ids and OWASP categories a rule is mapped to.
+// A nil list keeps the mapping from the rule definition.
+type RuleMapping struct {
+	CWEIDs []string `mapstructure:"cwe_ids" json:"cwe_ids,omitempty" yaml:"cwe_ids,omitempty"`
+	OWASP  []string `mapstructure:"owasp" json:"owasp,omitempty" yaml:"owasp,omitempty"`
+}
+
+// RuleOverride replaces the severity of a rule. An empty severity keeps the
+// one from the rule definition.
+type RuleOverride struct {
+	Severity string `mapstructure:"severity" json:"severity,omitempty" yaml:"severity,omitempty"`
+}
+
+// PathOverride skips or re-tunes rules for the findings in files matching any
+// of the paths, which use the same patterns as skip-path. When several path
+// overrides match a file, the last one takes precedence.
+type PathOverride struct {
+	Paths     []string                `mapstructure:"paths" json:"paths" yaml:"paths"`
+	SkipRule  []string                `mapstructure:"skip-rule" json:"skip-rule,omitempty" yaml:"skip-rule,omitempty"`
+	Overrides map[string]RuleOverride `mapstructure:"overrides" json:"overrides,omitempty" yaml:"overrides,omitempty"`
+}
+
+// RuleSanitizer declares patterns, in the custom rule syntax, matching code
+// which sanitizes the values given to it, eg. `sanitize_sql($<_>)`. Rules
+// don't follow data through the matched code.
+type RuleSanitizer struct {
+	Languages []string `mapstructure:"languages" json:"languages" yaml:"languages"`
+	Patterns  []string `mapstructure:"patterns" json:"patterns" yaml:"patterns"`
+}
+
 type RuleOptions struct {
-	DisableDefaultRules bool            `mapstructure:"disable-default-rules" json:"disable-default-rules" yaml:"disable-default-rules"`
-	SkipRule            map[string]bool `mapstructure:"skip-rule" json:"skip-rule" yaml:"skip-rule"`
-	OnlyRule            map[string]bool `mapstructure:"only-rule" json:"only-rule" yaml:"only-rule"`
+	DisableDefaultRules bool                              `mapstructure:"disable-default-rules" json:"disable-default-rules" yaml:"disable-default-rules"`
+	SkipRule            map[string]bool                   `mapstructure:"skip-rule" json:"skip-rule" yaml:"skip-rule"`
+	OnlyRule            map[string]bool                   `mapstructure:"only-rule" json:"only-rule" yaml:"only-rule"`
+	OnlyCWE             []string                          `mapstructure:"only-cwe" json:"only-cwe,omitempty" yaml:"only-cwe,omitempty"`
+	OnlyOWASP           []string                          `mapstructure:"only-owasp" json:"only-owasp,omitempty" yaml:"only-owasp,omitempty"`
+	Mappings            map[string]RuleMapping            `mapstructure:"mappings" json:"mappings,omitempty" yaml:"mappings,omitempty"`
+	Overrides           map[string]RuleOverride           `mapstructure:"overrides" json:"overrides,omitempty" yaml:"overrides,omitempty"`
+	Parameters          map[string]map[string]interface{} `mapstructure:"parameters" json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	PathOverrides       []PathOverride                    `mapstructure:"path-overrides" json:"path-overrides,omitempty" yaml:"path-overrides,omitempty"`
+	Sanitizers          []RuleSanitizer                   `mapstructure:"sanitizers" json:"sanitizers,omitempty" yaml:"sanitizers,omitempty"`
 }
 
 func (ruleFlagGroup) SetOptions(options *Options, args []string) error {
+	var mappings map[string]RuleMapping
+	if err := viper.UnmarshalKey(RuleMappingsFlag.ConfigName, &mappings); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", RuleMappingsFlag.ConfigName, err)
+	}
+
+	var overrides map[string]RuleOverride
+	if err := viper.UnmarshalKey(RuleOverridesFlag.ConfigName, &overrides); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", RuleOverridesFlag.ConfigName, err)
+	}
+
+	var parameters map[string]map[string]interface{}
+	if err := viper.UnmarshalKey(RuleParametersFlag.ConfigName, &parameters); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", RuleParametersFlag.ConfigName, err)
+	}
+
+	var pathOverrides []PathOverride
+	if err := viper.UnmarshalKey(RulePathOverridesFlag.ConfigName, &pathOverrides); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", RulePathOverridesFlag.ConfigName, err)
+	}
+
+	var sanitizers []RuleSanitizer
+	if err := viper.UnmarshalKey(RuleSanitizersFlag.ConfigName, &sanitizers); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", RuleSanitizersFlag.ConfigName, err)
+	}
+
+	if err := validateRuleOverrides(overrides); err != nil {
+		return err
+	}
+
+	for _, pathOverride := range pathOverrides {
+		if len(pathOverride.Paths) == 0 {
+			return ErrMissingPathOverridePaths
+		}
+
+		if err := validateRuleOverrides(pathOverride.Overrides); err != nil {
+			return err
+		}
+	}
+
+	for _, sanitizer := range sanitizers {
+		if len(sanitizer.Languages) == 0 {
+			return ErrMissingSanitizerLanguages
+		}
+
+		if len(sanitizer.Patterns) == 0 {
+			return ErrMissingSanitizerPatterns
+		}
+	}
+
 	options.RuleOptions = RuleOptions{
 		DisableDefaultRules: getBool(DisableDefaultRulesFlag),
 		SkipRule:            argsToMap(SkipRuleFlag),
 		OnlyRule:            argsToMap(OnlyRuleFlag),
+		OnlyCWE:             getStringSlice(OnlyCWEFlag),
+		OnlyOWASP:           getStringSlice(OnlyOWASPFlag),
+		Mappings:            mappings,
+		Overrides:           overrides,
+		Parameters:          parameters,
+		PathOverrides:       pathOverrides,
+		Sanitizers:          sanitizers,
+	}
+
+	return nil
+}
+
+func validateRuleOverrides(overrides map[string]RuleOverride) error {
+	for _, override := range overrides {
+		if override.Severity != "" && !slices.Contains(globaltypes.Severities, override.Severity) {
+			return ErrInvalidRuleOverrideSeverity
+		}
 	}
 
 	return nil
@@ -0,0 +1,26 @@
+package flag
+
+type ruleImportFlagGroup struct{ flagGroupBase }
+
+var RuleImportFlagGroup = &ruleImportFlagGroup{flagGroupBase{name: "Rule Import"}}
+
+var (
+	RuleImportNamespaceFlag = RuleImportFlagGroup.add(Flag{
+		Name:       "namespace",
+		ConfigName: "rule-import.namespace",
+		Value:      "",
+		Usage:      "Specify the namespace of the imported rule ids, eg. acme.",
+	})
+)
+
+type RuleImportOptions struct {
+	RuleImportNamespace string `mapstructure:"rule_import_namespace" json:"rule_import_namespace" yaml:"rule_import_namespace"`
+}
+
+func (ruleImportFlagGroup) SetOptions(options *Options, args []string) error {
+	options.RuleImportOptions = RuleImportOptions{
+		RuleImportNamespace: getString(RuleImportNamespaceFlag),
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package flag
+
+import "errors"
+
+type ruleListFlagGroup struct{ flagGroupBase }
+
+var RuleListFlagGroup = &ruleListFlagGroup{flagGroupBase{name: "Rule List"}}
+
+const (
+	RuleListFormatTable = "table"
+	RuleListFormatJSON  = "json"
+)
+
+var ErrInvalidFormatRuleList = errors.New("invalid format argument for rules list; supported values: table, json")
+
+var (
+	RuleListExternalRuleDirFlag = RuleListFlagGroup.add(Flag{
+		Name:       "external-rule-dir",
+		ConfigName: "scan.external-rule-dir",
+		Value:      []string{},
+		Usage:      "Specify directories paths that contain .yaml files with external rules to include in the list.",
+	})
+	RuleListLanguageFlag = RuleListFlagGroup.add(Flag{
+		Name:       "language",
+		ConfigName: "rule-list.language",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated languages of the rules to list, eg. ruby,java.",
+	})
+	RuleListSeverityFlag = RuleListFlagGroup.add(Flag{
+		Name:       "severity",
+		ConfigName: "rule-list.severity",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated severities of the rules to list, eg. critical,high.",
+	})
+	RuleListCWEFlag = RuleListFlagGroup.add(Flag{
+		Name:       "cwe",
+		ConfigName: "rule-list.cwe",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated CWE ids of the rules to list, eg. 89 or CWE-89.",
+	})
+	RuleListFrameworkFlag = RuleListFlagGroup.add(Flag{
+		Name:       "framework",
+		ConfigName: "rule-list.framework",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated frameworks of the rules to list, eg. rails,express.",
+	})
+	RuleListFormatFlag = RuleListFlagGroup.add(Flag{
+		Name:       "format",
+		ConfigName: "rule-list.format",
+		Shorthand:  "f",
+		Value:      RuleListFormatTable,
+		Usage:      "Specify the output format (table, json).",
+	})
+)
+
+type RuleListOptions struct {
+	RuleListExternalRuleDir []string `mapstructure:"rule_list_external_rule_dir" json:"rule_list_external_rule_dir" yaml:"rule_list_external_rule_dir"`
+	RuleListLanguages       []string `mapstructure:"rule_list_language" json:"rule_list_language" yaml:"rule_list_language"`
+	RuleListSeverities      []string `mapstructure:"rule_list_severity" json:"rule_list_severity" yaml:"rule_list_severity"`
+	RuleListCWEIDs          []string `mapstructure:"rule_list_cwe" json:"rule_list_cwe" yaml:"rule_list_cwe"`
+	RuleListFrameworks      []string `mapstructure:"rule_list_framework" json:"rule_list_framework" yaml:"rule_list_framework"`
+	RuleListFormat          string   `mapstructure:"rule_list_format" json:"rule_list_format" yaml:"rule_list_format"`
+}
+
+func (ruleListFlagGroup) SetOptions(options *Options, args []string) error {
+	format := getString(RuleListFormatFlag)
+	switch format {
+	case RuleListFormatTable, RuleListFormatJSON:
+	default:
+		return ErrInvalidFormatRuleList
+	}
+
+	options.RuleListOptions = RuleListOptions{
+		RuleListExternalRuleDir: getStringSlice(RuleListExternalRuleDirFlag),
+		RuleListLanguages:       getStringSlice(RuleListLanguageFlag),
+		RuleListSeverities:      getStringSlice(RuleListSeverityFlag),
+		RuleListCWEIDs:          getStringSlice(RuleListCWEFlag),
+		RuleListFrameworks:      getStringSlice(RuleListFrameworkFlag),
+		RuleListFormat:          format,
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package flag
+
+type ruleLockFlagGroup struct{ flagGroupBase }
+
+var RuleLockFlagGroup = &ruleLockFlagGroup{flagGroupBase{name: "Rule Lock"}}
+
+const DefaultRulesLockfile = "bearer_rules.lock"
+
+var (
+	RuleLockExternalRuleDirFlag = RuleLockFlagGroup.add(Flag{
+		Name:       "external-rule-dir",
+		ConfigName: "scan.external-rule-dir",
+		Value:      []string{},
+		Usage:      "Specify directories paths that contain .yaml files with external rules to record in the lockfile.",
+	})
+	RuleLockPublicKeyFlag = RuleLockFlagGroup.add(Flag{
+		Name:       "external-rule-public-key",
+		ConfigName: "scan.external-rule-public-key",
+		Value:      "",
+		Usage:      "Specify the path to a PEM encoded ed25519 public key that external rules pulled from OCI registries must be signed with.",
+	})
+	RuleLockLockfileFlag = RuleLockFlagGroup.add(Flag{
+		Name:       "rules-lockfile",
+		ConfigName: "scan.rules-lockfile",
+		Value:      DefaultRulesLockfile,
+		Usage:      "Specify the path of the lockfile to write.",
+	})
+)
+
+type RuleLockOptions struct {
+	RuleLockExternalRuleDir []string `mapstructure:"rule_lock_external_rule_dir" json:"rule_lock_external_rule_dir" yaml:"rule_lock_external_rule_dir"`
+	RuleLockPublicKey       string   `mapstructure:"rule_lock_external_rule_public_key" json:"rule_lock_external_rule_public_key" yaml:"rule_lock_external_rule_public_key"`
+	RuleLockLockfile        string   `mapstructure:"rule_lock_lockfile" json:"rule_lock_lockfile" yaml:"rule_lock_lockfile"`
+}
+
+func (ruleLockFlagGroup) SetOptions(options *Options, args []string) error {
+	options.RuleLockOptions = RuleLockOptions{
+		RuleLockExternalRuleDir: getStringSlice(RuleLockExternalRuleDirFlag),
+		RuleLockPublicKey:       getString(RuleLockPublicKeyFlag),
+		RuleLockLockfile:        getString(RuleLockLockfileFlag),
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package flag
+
+type ruleNewFlagGroup struct{ flagGroupBase }
+
+var RuleNewFlagGroup = &ruleNewFlagGroup{flagGroupBase{name: "Rule New"}}
+
+var (
+	RuleNewIDFlag = RuleNewFlagGroup.add(Flag{
+		Name:       "id",
+		ConfigName: "rule-new.id",
+		Value:      "",
+		Usage:      "Specify the id of the rule, eg. acme:ruby_insecure_call. Prompted for when not given.",
+	})
+	RuleNewLanguageFlag = RuleNewFlagGroup.add(Flag{
+		Name:       "language",
+		ConfigName: "rule-new.language",
+		Value:      "",
+		Usage:      "Specify the language of the rule (c, go, java, javascript, kotlin, php, python, ruby, rust, shell, sql). Prompted for when not given.",
+	})
+	RuleNewSeverityFlag = RuleNewFlagGroup.add(Flag{
+		Name:       "severity",
+		ConfigName: "rule-new.severity",
+		Value:      "medium",
+		Usage:      "Specify the severity of the rule (critical, high, medium, low, warning).",
+	})
+	RuleNewDescriptionFlag = RuleNewFlagGroup.add(Flag{
+		Name:       "description",
+		ConfigName: "rule-new.description",
+		Value:      "",
+		Usage:      "Specify the description of the rule. Prompted for when not given.",
+	})
+	RuleNewCWEFlag = RuleNewFlagGroup.add(Flag{
+		Name:       "cwe",
+		ConfigName: "rule-new.cwe",
+		Value:      []string{},
+		Usage:      "Specify the comma-separated CWE ids of the rule, eg. 89 or CWE-89.",
+	})
+)
+
+type RuleNewOptions struct {
+	RuleNewID          string   `mapstructure:"rule_new_id" json:"rule_new_id" yaml:"rule_new_id"`
+	RuleNewLanguage    string   `mapstructure:"rule_new_language" json:"rule_new_language" yaml:"rule_new_language"`
+	RuleNewSeverity    string   `mapstructure:"rule_new_severity" json:"rule_new_severity" yaml:"rule_new_severity"`
+	RuleNewDescription string   `mapstructure:"rule_new_description" json:"rule_new_description" yaml:"rule_new_description"`
+	RuleNewCWEIDs      []string `mapstructure:"rule_new_cwe" json:"rule_new_cwe" yaml:"rule_new_cwe"`
+}
+
+func (ruleNewFlagGroup) SetOptions(options *Options, args []string) error {
+	options.RuleNewOptions = RuleNewOptions{
+		RuleNewID:          getString(RuleNewIDFlag),
+		RuleNewLanguage:    getString(RuleNewLanguageFlag),
+		RuleNewSeverity:    getString(RuleNewSeverityFlag),
+		RuleNewDescription: getString(RuleNewDescriptionFlag),
+		RuleNewCWEIDs:      getStringSlice(RuleNewCWEFlag),
+	}
+
+	return nil
+}
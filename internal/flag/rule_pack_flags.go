@@ -0,0 +1,36 @@
+package flag
+
+type rulePackFlagGroup struct{ flagGroupBase }
+
+var RulePackFlagGroup = &rulePackFlagGroup{flagGroupBase{name: "Rule Pack"}}
+
+const DefaultRulePackIndexURL = "https://raw.githubusercontent.com/Bearer/bearer-community-rules/main/index.json"
+
+var (
+	RulePackIndexURLFlag = RulePackFlagGroup.add(Flag{
+		Name:       "index-url",
+		ConfigName: "rule-pack.index-url",
+		Value:      DefaultRulePackIndexURL,
+		Usage:      "Specify the URL or local path of the community rule pack index.",
+	})
+	RulePackRulesDirFlag = RulePackFlagGroup.add(Flag{
+		Name:       "rules-dir",
+		ConfigName: "rule-pack.rules-dir",
+		Value:      ".bearer/rules",
+		Usage:      "Specify the directory rule packs are installed into. Use it with --external-rule-dir when scanning.",
+	})
+)
+
+type RulePackOptions struct {
+	IndexURL string `mapstructure:"index-url" json:"index-url" yaml:"index-url"`
+	RulesDir string `mapstructure:"rules-dir" json:"rules-dir" yaml:"rules-dir"`
+}
+
+func (rulePackFlagGroup) SetOptions(options *Options, args []string) error {
+	options.RulePackOptions = RulePackOptions{
+		IndexURL: getString(RulePackIndexURLFlag),
+		RulesDir: getString(RulePackRulesDirFlag),
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package flag
+
+type rulePushFlagGroup struct{ flagGroupBase }
+
+var RulePushFlagGroup = &rulePushFlagGroup{flagGroupBase{name: "Rule Push"}}
+
+type rulePullFlagGroup struct{ flagGroupBase }
+
+var RulePullFlagGroup = &rulePullFlagGroup{flagGroupBase{name: "Rule Pull"}}
+
+var (
+	RulePushSigningKeyFlag = RulePushFlagGroup.add(Flag{
+		Name:       "signing-key",
+		ConfigName: "rule-pack.signing-key",
+		Value:      "",
+		Usage:      "Specify the path to a PEM encoded ed25519 private key to sign the rule pack with.",
+	})
+	RulePullPublicKeyFlag = RulePullFlagGroup.add(Flag{
+		Name:       "public-key",
+		ConfigName: "scan.external-rule-public-key",
+		Value:      "",
+		Usage:      "Specify the path to a PEM encoded ed25519 public key the rule pack must be signed with.",
+	})
+)
+
+type RulePushOptions struct {
+	SigningKey string `mapstructure:"signing-key" json:"signing-key" yaml:"signing-key"`
+}
+
+type RulePullOptions struct {
+	PublicKey string `mapstructure:"public-key" json:"public-key" yaml:"public-key"`
+}
+
+func (rulePushFlagGroup) SetOptions(options *Options, args []string) error {
+	options.RulePushOptions = RulePushOptions{
+		SigningKey: getString(RulePushSigningKeyFlag),
+	}
+
+	return nil
+}
+
+func (rulePullFlagGroup) SetOptions(options *Options, args []string) error {
+	options.RulePullOptions = RulePullOptions{
+		PublicKey: getString(RulePullPublicKeyFlag),
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package flag
+
+import "time"
+
+type ruleTestFlagGroup struct{ flagGroupBase }
+
+var RuleTestFlagGroup = &ruleTestFlagGroup{flagGroupBase{name: "Rule Test"}}
+
+var (
+	RuleTestWatchFlag = RuleTestFlagGroup.add(Flag{
+		Name:       "watch",
+		ConfigName: "rule-test.watch",
+		Value:      false,
+		Usage:      "Run the tests again whenever rule files or fixtures change, only reloading the affected rules.",
+	})
+	RuleTestWatchIntervalFlag = RuleTestFlagGroup.add(Flag{
+		Name:       "watch-interval",
+		ConfigName: "rule-test.watch-interval",
+		Value:      time.Second,
+		Usage:      "Specify how often to check for changes when watching.",
+	})
+)
+
+type RuleTestOptions struct {
+	Watch         bool          `mapstructure:"watch" json:"watch" yaml:"watch"`
+	WatchInterval time.Duration `mapstructure:"watch-interval" json:"watch-interval" yaml:"watch-interval"`
+}
+
+func (ruleTestFlagGroup) SetOptions(options *Options, args []string) error {
+	options.RuleTestOptions = RuleTestOptions{
+		Watch:         getBool(RuleTestWatchFlag),
+		WatchInterval: getDuration(RuleTestWatchIntervalFlag),
+	}
+
+	return nil
+}
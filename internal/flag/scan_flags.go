@@ -2,11 +2,14 @@ package flag
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/bearer/bearer/internal/util/file"
 )
 
 type Context string
@@ -15,13 +18,18 @@ const (
 	Health Context = "health"
 	Empty  Context = ""
 
-	ScannerSAST    = "sast"
-	ScannerSecrets = "secrets"
+	ScannerSAST     = "sast"
+	ScannerSecrets  = "secrets"
+	ScannerFixtures = "fixtures"
 )
 
 var (
 	ErrInvalidContext = errors.New("invalid context argument; supported values: health")
-	ErrInvalidScanner = errors.New("invalid scanner argument; supported values: sast, secrets")
+	ErrInvalidScanner = errors.New("invalid scanner argument; supported values: sast, secrets, fixtures")
+
+	ErrInvalidInterproceduralDepth = errors.New("invalid interprocedural depth argument; the depth cannot be negative")
+
+	ErrInvalidLanguageExtensions = errors.New("invalid scan.language-extensions configuration; supported languages: " + strings.Join(file.LanguageNames(), ", "))
 )
 
 type scanFlagGroup struct{ flagGroupBase }
@@ -65,6 +73,49 @@ var (
 		Value:      "",
 		Usage:      "Override default data subject mapping by providing a path to a custom mapping JSON file",
 	})
+	DataSubjectsFlag = ScanFlagGroup.add(Flag{
+		ConfigName: "scan.data-subjects",
+		Value:      []DataSubjectDefinition{},
+		Usage:      "Assign the data found in objects, or of data types, to data subjects such as customers, employees or patients.",
+	})
+	DataTypesFlag = ScanFlagGroup.add(Flag{
+		ConfigName: "scan.data-types",
+		Value:      []DataTypeDefinition{},
+		Usage:      "Declare custom data types to classify in addition to the built-in ones.",
+	})
+	DataTypeOverridesFlag = ScanFlagGroup.add(Flag{
+		ConfigName: "scan.data-type-overrides",
+		Value:      []DataTypeOverride{},
+		Usage:      "Override the category or sensitivity of data types.",
+	})
+	DataTypesDirFlag = ScanFlagGroup.add(Flag{
+		Name:       "data-types-dir",
+		ConfigName: "scan.data-types-dir",
+		Value:      []string{},
+		Usage:      "Specify directories paths that contain .yml files with custom data type definitions",
+	})
+	SanitizerAnnotationsFlag = ScanFlagGroup.add(Flag{
+		ConfigName: "scan.sanitizer-annotations",
+		Value:      []string{},
+		Usage:      "Names of the annotations and decorators marking fields and functions as sanitized.",
+	})
+	LanguageExtensionsFlag = ScanFlagGroup.add(Flag{
+		ConfigName: "scan.language-extensions",
+		Value:      map[string]string{},
+		Usage:      "Assign file extensions to the language they are scanned as, e.g. jbuilder: ruby",
+	})
+	InterproceduralDepthFlag = ScanFlagGroup.add(Flag{
+		Name:       "interprocedural-depth",
+		ConfigName: "scan.interprocedural-depth",
+		Value:      0,
+		Usage:      "Follow values passed to functions through up to this many calls, across the files of the project. Disabled when 0.",
+	})
+	RecipesDirFlag = ScanFlagGroup.add(Flag{
+		Name:       "recipes-dir",
+		ConfigName: "scan.recipes-dir",
+		Value:      []string{},
+		Usage:      "Specify directories paths that contain .json or .yml files with custom component recipes",
+	})
 	QuietFlag = ScanFlagGroup.add(Flag{
 		Name:       "quiet",
 		ConfigName: "scan.quiet",
@@ -89,11 +140,23 @@ var (
 		Value:      []string{},
 		Usage:      "Specify directories paths that contain .yaml files with external rules configuration",
 	})
+	ExternalRulePublicKeyFlag = ScanFlagGroup.add(Flag{
+		Name:       "external-rule-public-key",
+		ConfigName: "scan.external-rule-public-key",
+		Value:      "",
+		Usage:      "Specify the path to a PEM encoded ed25519 public key that external rules pulled from OCI registries must be signed with.",
+	})
+	RulesLockfileFlag = ScanFlagGroup.add(Flag{
+		Name:       "rules-lockfile",
+		ConfigName: "scan.rules-lockfile",
+		Value:      DefaultRulesLockfile,
+		Usage:      "Specify the path of the lockfile recording the digests of external rules. When it exists, scans fail if external rules don't match it.",
+	})
 	ScannerFlag = ScanFlagGroup.add(Flag{
 		Name:       "scanner",
 		ConfigName: "scan.scanner",
 		Value:      []string{ScannerSAST},
-		Usage:      "Specify which scanner to use e.g. --scanner=secrets, --scanner=secrets,sast",
+		Usage:      "Specify which scanner to use e.g. --scanner=secrets, --scanner=secrets,sast, --scanner=sast,fixtures",
 	})
 	ParallelFlag = ScanFlagGroup.add(Flag{
 		Name:       "parallel",
@@ -101,6 +164,12 @@ var (
 		Value:      0,
 		Usage:      "Specify the amount of parallelism to use during the scan",
 	})
+	MaxScanDurationFlag = ScanFlagGroup.add(Flag{
+		Name:       "max-scan-duration",
+		ConfigName: "scan.max-scan-duration",
+		Value:      time.Duration(0),
+		Usage:      "Stop scanning new files once the duration is reached, scanning the riskiest files first e.g. --max-scan-duration=10m",
+	})
 	ExitCodeFlag = ScanFlagGroup.add(Flag{
 		Name:       "exit-code",
 		ConfigName: "scan.exit-code",
@@ -111,27 +180,80 @@ var (
 		Name:            "diff",
 		ConfigName:      "scan.diff",
 		Value:           false,
-		Usage:           "Only report differences in findings relative to a base branch.",
+		Usage:           "Only report differences in findings relative to a base branch. Ignores of files renamed since the base branch keep applying.",
+		DisableInConfig: true,
+	})
+	RuleTimeoutFlag = ScanFlagGroup.add(Flag{
+		Name:       "rule-timeout",
+		ConfigName: "scan.rule-timeout",
+		Value:      time.Duration(0),
+		Usage:      "Skip a rule on a file once evaluating it takes longer than the duration, reporting it as an error e.g. --rule-timeout=10s",
+	})
+	RuleTimingsFlag = ScanFlagGroup.add(Flag{
+		Name:            "rule-timings",
+		ConfigName:      "scan.rule-timings",
+		Value:           "",
+		Usage:           "Write the compile and evaluation time, and the number of matches, of each rule as JSON to the given path, and print the slowest rules.",
 		DisableInConfig: true,
 	})
 )
 
 type ScanOptions struct {
-	Target                  string        `mapstructure:"target" json:"target" yaml:"target"`
-	SkipPath                []string      `mapstructure:"skip-path" json:"skip-path" yaml:"skip-path"`
-	DisableDomainResolution bool          `mapstructure:"disable-domain-resolution" json:"disable-domain-resolution" yaml:"disable-domain-resolution"`
-	DomainResolutionTimeout time.Duration `mapstructure:"domain-resolution-timeout" json:"domain-resolution-timeout" yaml:"domain-resolution-timeout"`
-	InternalDomains         []string      `mapstructure:"internal-domains" json:"internal-domains" yaml:"internal-domains"`
-	Context                 Context       `mapstructure:"context" json:"context" yaml:"context"`
-	DataSubjectMapping      string        `mapstructure:"data_subject_mapping" json:"data_subject_mapping" yaml:"data_subject_mapping"`
-	Quiet                   bool          `mapstructure:"quiet" json:"quiet" yaml:"quiet"`
-	HideProgressBar         bool          `mapstructure:"hide_progress_bar" json:"hide_progress_bar" yaml:"hide_progress_bar"`
-	Force                   bool          `mapstructure:"force" json:"force" yaml:"force"`
-	ExternalRuleDir         []string      `mapstructure:"external-rule-dir" json:"external-rule-dir" yaml:"external-rule-dir"`
-	Scanner                 []string      `mapstructure:"scanner" json:"scanner" yaml:"scanner"`
-	Parallel                int           `mapstructure:"parallel" json:"parallel" yaml:"parallel"`
-	ExitCode                int           `mapstructure:"exit-code" json:"exit-code" yaml:"exit-code"`
-	Diff                    bool          `mapstructure:"diff" json:"diff" yaml:"diff"`
+	Target                  string                  `mapstructure:"target" json:"target" yaml:"target"`
+	SkipPath                []string                `mapstructure:"skip-path" json:"skip-path" yaml:"skip-path"`
+	DisableDomainResolution bool                    `mapstructure:"disable-domain-resolution" json:"disable-domain-resolution" yaml:"disable-domain-resolution"`
+	DomainResolutionTimeout time.Duration           `mapstructure:"domain-resolution-timeout" json:"domain-resolution-timeout" yaml:"domain-resolution-timeout"`
+	InternalDomains         []string                `mapstructure:"internal-domains" json:"internal-domains" yaml:"internal-domains"`
+	Context                 Context                 `mapstructure:"context" json:"context" yaml:"context"`
+	DataSubjectMapping      string                  `mapstructure:"data_subject_mapping" json:"data_subject_mapping" yaml:"data_subject_mapping"`
+	DataSubjects            []DataSubjectDefinition `mapstructure:"data-subjects" json:"data-subjects" yaml:"data-subjects"`
+	Quiet                   bool                    `mapstructure:"quiet" json:"quiet" yaml:"quiet"`
+	HideProgressBar         bool                    `mapstructure:"hide_progress_bar" json:"hide_progress_bar" yaml:"hide_progress_bar"`
+	Force                   bool                    `mapstructure:"force" json:"force" yaml:"force"`
+	ExternalRuleDir         []string                `mapstructure:"external-rule-dir" json:"external-rule-dir" yaml:"external-rule-dir"`
+	ExternalRulePublicKey   string                  `mapstructure:"external-rule-public-key" json:"external-rule-public-key" yaml:"external-rule-public-key"`
+	RulesLockfile           string                  `mapstructure:"rules-lockfile" json:"rules-lockfile" yaml:"rules-lockfile"`
+	Scanner                 []string                `mapstructure:"scanner" json:"scanner" yaml:"scanner"`
+	Parallel                int                     `mapstructure:"parallel" json:"parallel" yaml:"parallel"`
+	MaxScanDuration         time.Duration           `mapstructure:"max-scan-duration" json:"max-scan-duration" yaml:"max-scan-duration"`
+	ExitCode                int                     `mapstructure:"exit-code" json:"exit-code" yaml:"exit-code"`
+	Diff                    bool                    `mapstructure:"diff" json:"diff" yaml:"diff"`
+	RuleTimeout             time.Duration           `mapstructure:"rule-timeout" json:"rule-timeout" yaml:"rule-timeout"`
+	RuleTimings             string                  `mapstructure:"rule-timings" json:"rule-timings" yaml:"rule-timings"`
+	DataTypes               []DataTypeDefinition    `mapstructure:"data-types" json:"data-types" yaml:"data-types"`
+	DataTypeOverrides       []DataTypeOverride      `mapstructure:"data-type-overrides" json:"data-type-overrides" yaml:"data-type-overrides"`
+	DataTypesDir            []string                `mapstructure:"data-types-dir" json:"data-types-dir" yaml:"data-types-dir"`
+	RecipesDir              []string                `mapstructure:"recipes-dir" json:"recipes-dir" yaml:"recipes-dir"`
+	SanitizerAnnotations    []string                `mapstructure:"sanitizer-annotations" json:"sanitizer-annotations" yaml:"sanitizer-annotations"`
+	LanguageExtensions      map[string]string       `mapstructure:"language-extensions" json:"language-extensions" yaml:"language-extensions"`
+	InterproceduralDepth    int                     `mapstructure:"interprocedural-depth" json:"interprocedural-depth" yaml:"interprocedural-depth"`
+}
+
+// DataSubjectDefinition assigns data to a data subject declared by the user.
+// Objects take precedence over data types.
+type DataSubjectDefinition struct {
+	Name      string   `mapstructure:"name" json:"name" yaml:"name"`
+	Objects   []string `mapstructure:"objects" json:"objects,omitempty" yaml:"objects,omitempty"`
+	DataTypes []string `mapstructure:"data_types" json:"data_types,omitempty" yaml:"data_types,omitempty"`
+}
+
+// DataTypeDefinition is a custom data type declared by the user
+type DataTypeDefinition struct {
+	Name            string              `mapstructure:"name" json:"name" yaml:"name"`
+	Category        string              `mapstructure:"category" json:"category,omitempty" yaml:"category,omitempty"`
+	Patterns        []string            `mapstructure:"patterns" json:"patterns,omitempty" yaml:"patterns,omitempty"`
+	ExcludePatterns []string            `mapstructure:"exclude_patterns" json:"exclude_patterns,omitempty" yaml:"exclude_patterns,omitempty"`
+	ColumnNames     []string            `mapstructure:"column_names" json:"column_names,omitempty" yaml:"column_names,omitempty"`
+	Locales         map[string][]string `mapstructure:"locales" json:"locales,omitempty" yaml:"locales,omitempty"`
+	ExcludeTypes    []string            `mapstructure:"exclude_types" json:"exclude_types,omitempty" yaml:"exclude_types,omitempty"`
+}
+
+// DataTypeOverride changes the category or sensitivity of a data type. The
+// sensitivity is the name of a category group, or "none".
+type DataTypeOverride struct {
+	Name        string `mapstructure:"name" json:"name" yaml:"name"`
+	Category    string `mapstructure:"category" json:"category,omitempty" yaml:"category,omitempty"`
+	Sensitivity string `mapstructure:"sensitivity" json:"sensitivity,omitempty" yaml:"sensitivity,omitempty"`
 }
 
 func (scanFlagGroup) SetOptions(options *Options, args []string) error {
@@ -152,11 +274,50 @@ func (scanFlagGroup) SetOptions(options *Options, args []string) error {
 		switch scanner {
 		case ScannerSAST:
 		case ScannerSecrets:
+		case ScannerFixtures:
 		default:
 			return ErrInvalidScanner
 		}
 	}
 
+	var dataTypes []DataTypeDefinition
+	if err := viper.UnmarshalKey(DataTypesFlag.ConfigName, &dataTypes); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", DataTypesFlag.ConfigName, err)
+	}
+
+	var dataTypeOverrides []DataTypeOverride
+	if err := viper.UnmarshalKey(DataTypeOverridesFlag.ConfigName, &dataTypeOverrides); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", DataTypeOverridesFlag.ConfigName, err)
+	}
+
+	var dataSubjects []DataSubjectDefinition
+	if err := viper.UnmarshalKey(DataSubjectsFlag.ConfigName, &dataSubjects); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", DataSubjectsFlag.ConfigName, err)
+	}
+	for i, dataSubject := range dataSubjects {
+		if strings.TrimSpace(dataSubject.Name) == "" {
+			return fmt.Errorf("invalid %s configuration: subject %d has no name", DataSubjectsFlag.ConfigName, i+1)
+		}
+		if len(dataSubject.Objects) == 0 && len(dataSubject.DataTypes) == 0 {
+			return fmt.Errorf("invalid %s configuration: subject %q has no objects or data types", DataSubjectsFlag.ConfigName, dataSubject.Name)
+		}
+	}
+
+	// extensions are kept without their leading dot, as viper splits keys on dots
+	languageExtensions := make(map[string]string)
+	for extension, language := range viper.GetStringMapString(LanguageExtensionsFlag.ConfigName) {
+		extension = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(extension), "."))
+		if _, ok := file.LanguageName(language); extension == "" || !ok {
+			return ErrInvalidLanguageExtensions
+		}
+		languageExtensions[extension] = strings.ToLower(language)
+	}
+
+	interproceduralDepth := viper.GetInt(InterproceduralDepthFlag.ConfigName)
+	if interproceduralDepth < 0 {
+		return ErrInvalidInterproceduralDepth
+	}
+
 	// DIFF_BASE_BRANCH is used for backwards compatibilty
 	diff := getBool(DiffFlag) || os.Getenv("DIFF_BASE_BRANCH") != ""
 
@@ -167,15 +328,28 @@ func (scanFlagGroup) SetOptions(options *Options, args []string) error {
 		InternalDomains:         getStringSlice(InternalDomainsFlag),
 		Context:                 context,
 		DataSubjectMapping:      getString(DataSubjectMappingFlag),
+		DataSubjects:            dataSubjects,
 		Quiet:                   getBool(QuietFlag),
 		HideProgressBar:         getBool(HideProgressBarFlag),
 		Force:                   getBool(ForceFlag),
 		Target:                  target,
 		ExternalRuleDir:         getStringSlice(ExternalRuleDirFlag),
+		ExternalRulePublicKey:   getString(ExternalRulePublicKeyFlag),
+		RulesLockfile:           getString(RulesLockfileFlag),
 		Scanner:                 scanners,
 		Parallel:                viper.GetInt(ParallelFlag.ConfigName),
+		MaxScanDuration:         getDuration(MaxScanDurationFlag),
 		ExitCode:                viper.GetInt(ExitCodeFlag.ConfigName),
 		Diff:                    diff,
+		RuleTimeout:             getDuration(RuleTimeoutFlag),
+		RuleTimings:             getString(RuleTimingsFlag),
+		DataTypes:               dataTypes,
+		DataTypeOverrides:       dataTypeOverrides,
+		DataTypesDir:            getStringSlice(DataTypesDirFlag),
+		RecipesDir:              getStringSlice(RecipesDirFlag),
+		SanitizerAnnotations:    getStringSlice(SanitizerAnnotationsFlag),
+		LanguageExtensions:      languageExtensions,
+		InterproceduralDepth:    interproceduralDepth,
 	}
 
 	return nil
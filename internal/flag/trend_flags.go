@@ -0,0 +1,59 @@
+package flag
+
+import "errors"
+
+type trendFlagGroup struct{ flagGroupBase }
+
+var TrendFlagGroup = &trendFlagGroup{flagGroupBase{name: "Trend"}}
+
+const (
+	TrendFormatJSON     = "json"
+	TrendFormatMarkdown = "markdown"
+)
+
+var ErrInvalidFormatTrend = errors.New("invalid format argument for trend; supported values: json, markdown")
+
+var (
+	TrendFormatFlag = TrendFlagGroup.add(Flag{
+		Name:       "format",
+		ConfigName: "trend.format",
+		Shorthand:  "f",
+		Value:      TrendFormatJSON,
+		Usage:      "Specify the output format (json, markdown).",
+	})
+	TrendOutputFlag = TrendFlagGroup.add(Flag{
+		Name:       "output",
+		ConfigName: "trend.output",
+		Value:      "",
+		Usage:      "Specify the output path for the trend report.",
+	})
+	TrendLastFlag = TrendFlagGroup.add(Flag{
+		Name:       "last",
+		ConfigName: "trend.last",
+		Value:      0,
+		Usage:      "Only include the given number of most recent scans. Set to 0 to include all scans.",
+	})
+)
+
+type TrendOptions struct {
+	TrendFormat string `mapstructure:"trend_format" json:"trend_format" yaml:"trend_format"`
+	TrendOutput string `mapstructure:"trend_output" json:"trend_output" yaml:"trend_output"`
+	TrendLast   int    `mapstructure:"trend_last" json:"trend_last" yaml:"trend_last"`
+}
+
+func (trendFlagGroup) SetOptions(options *Options, args []string) error {
+	format := getString(TrendFormatFlag)
+	switch format {
+	case TrendFormatJSON, TrendFormatMarkdown:
+	default:
+		return ErrInvalidFormatTrend
+	}
+
+	options.TrendOptions = TrendOptions{
+		TrendFormat: format,
+		TrendOutput: getString(TrendOutputFlag),
+		TrendLast:   getInteger(TrendLastFlag),
+	}
+
+	return nil
+}
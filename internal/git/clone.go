@@ -0,0 +1,26 @@
+package git
+
+import "context"
+
+// CloneRef makes a shallow clone of the given ref of a repository into dir.
+// The ref can be a branch, a tag or a commit. The default branch is cloned
+// when the ref is empty.
+func CloneRef(ctx context.Context, dir string, url string, ref string) error {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	if err := basicCommand(ctx, "", "init", "--quiet", dir); err != nil {
+		return err
+	}
+
+	if err := basicCommand(ctx, dir, "remote", "add", "origin", url); err != nil {
+		return err
+	}
+
+	if err := FetchRef(ctx, dir, ref); err != nil {
+		return err
+	}
+
+	return basicCommand(ctx, dir, "-c", "advice.detachedHead=false", "checkout", "--quiet", "FETCH_HEAD")
+}
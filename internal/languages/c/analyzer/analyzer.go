@@ -0,0 +1,381 @@
+package analyzer
+
+import (
+	"slices"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/language"
+)
+
+// methods that use the receiver in their result
+var reflexiveMethods = []string{
+	// std::string
+	"c_str",
+	"data",
+	"substr",
+	// std::stringstream
+	"str",
+	// std::optional
+	"value",
+	"value_or",
+}
+
+type analyzer struct {
+	builder *tree.Builder
+	scope   *language.Scope
+}
+
+func New(builder *tree.Builder) language.Analyzer {
+	return &analyzer{
+		builder: builder,
+		scope:   language.NewScope(nil),
+	}
+}
+
+func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
+	switch node.Type() {
+	case "function_definition":
+		return analyzer.analyzeFunction(node, visitChildren)
+	case "namespace_definition",
+		"class_specifier",
+		"struct_specifier",
+		"lambda_expression",
+		"compound_statement",
+		"if_statement",
+		"for_statement",
+		"while_statement",
+		"do_statement",
+		"switch_statement":
+		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+			return visitChildren()
+		})
+	case "declaration":
+		return analyzer.analyzeDeclaration(node, visitChildren)
+	case "assignment_expression":
+		return analyzer.analyzeAssignment(node, visitChildren)
+	case "call_expression":
+		return analyzer.analyzeCall(node, visitChildren)
+	case "field_expression":
+		return analyzer.analyzeFieldExpression(node, visitChildren)
+	case "parameter_declaration", "optional_parameter_declaration":
+		return analyzer.analyzeParameter(node, visitChildren)
+	case "for_range_loop":
+		return analyzer.analyzeForRange(node, visitChildren)
+	case "conditional_expression":
+		return analyzer.analyzeConditional(node, visitChildren)
+	case "parenthesized_expression",
+		"pointer_expression",
+		"cast_expression",
+		"condition_clause":
+		return analyzer.analyzeWrapper(node, visitChildren)
+	case "argument_list",
+		"initializer_list",
+		"concatenated_string",
+		"binary_expression",
+		"unary_expression",
+		"update_expression",
+		"subscript_expression",
+		"comma_expression":
+		return analyzer.analyzeGenericOperation(node, visitChildren)
+	default:
+		analyzer.builder.Dataflow(node, analyzer.builder.ChildrenFor(node)...)
+		return visitChildren()
+	}
+}
+
+// void foo(char *a, int b) {}
+func (analyzer *analyzer) analyzeFunction(node *sitter.Node, visitChildren func() error) error {
+	if declarator := functionDeclarator(node.ChildByFieldName("declarator")); declarator != nil {
+		if name := functionName(declarator.ChildByFieldName("declarator")); name != nil {
+			analyzer.builder.AddFunction(name, analyzer.positionalParameters(declarator.ChildByFieldName("parameters")))
+		}
+	}
+
+	return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+		return visitChildren()
+	})
+}
+
+// char *a = b, c[10];
+// std::string a(b);
+func (analyzer *analyzer) analyzeDeclaration(node *sitter.Node, visitChildren func() error) error {
+	var names []*sitter.Node
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		switch child.Type() {
+		case "init_declarator":
+			name := declaratorIdentifier(child.ChildByFieldName("declarator"))
+			if name == nil {
+				continue
+			}
+
+			value := child.ChildByFieldName("value")
+			analyzer.builder.Alias(name, value)
+			analyzer.lookupVariable(value)
+			names = append(names, name)
+		case "identifier", "pointer_declarator", "reference_declarator", "array_declarator":
+			if name := declaratorIdentifier(child); name != nil {
+				names = append(names, name)
+			}
+		}
+	}
+
+	err := visitChildren()
+
+	for _, name := range names {
+		analyzer.scope.Declare(analyzer.builder.ContentFor(name), name)
+	}
+
+	return err
+}
+
+// foo = a
+// foo += a
+func (analyzer *analyzer) analyzeAssignment(node *sitter.Node, visitChildren func() error) error {
+	left := node.ChildByFieldName("left")
+	right := node.ChildByFieldName("right")
+	analyzer.lookupVariable(right)
+
+	if analyzer.builder.ContentFor(node.ChildByFieldName("operator")) == "=" {
+		analyzer.builder.Alias(node, right)
+	} else {
+		analyzer.builder.Dataflow(node, left, right)
+		analyzer.lookupVariable(left)
+	}
+
+	err := visitChildren()
+
+	if left != nil && left.Type() == "identifier" {
+		analyzer.scope.Assign(analyzer.builder.ContentFor(left), node)
+	}
+
+	return err
+}
+
+// foo(1, 2)
+// std::foo(1, 2)
+// foo.bar(1, 2)
+// foo->bar(1, 2)
+func (analyzer *analyzer) analyzeCall(node *sitter.Node, visitChildren func() error) error {
+	function := node.ChildByFieldName("function")
+	analyzer.lookupVariable(function)
+
+	if function.Type() == "field_expression" {
+		if slices.Contains(reflexiveMethods, analyzer.builder.ContentFor(function.ChildByFieldName("field"))) {
+			analyzer.builder.Dataflow(node, function.ChildByFieldName("argument"))
+		}
+	}
+
+	arguments := node.ChildByFieldName("arguments")
+	if arguments != nil {
+		analyzer.builder.Dataflow(node, arguments)
+	}
+
+	if name := functionName(function); name != nil {
+		analyzer.builder.AddCall(name, analyzer.positionalArguments(arguments))
+	}
+
+	return visitChildren()
+}
+
+// foo.bar
+// foo->bar
+func (analyzer *analyzer) analyzeFieldExpression(node *sitter.Node, visitChildren func() error) error {
+	analyzer.lookupVariable(node.ChildByFieldName("argument"))
+
+	return visitChildren()
+}
+
+// void m(char *foo) {}
+// void m(int foo = 1) {}
+func (analyzer *analyzer) analyzeParameter(node *sitter.Node, visitChildren func() error) error {
+	if name := declaratorIdentifier(node.ChildByFieldName("declarator")); name != nil {
+		analyzer.scope.Declare(analyzer.builder.ContentFor(name), name)
+	}
+
+	return visitChildren()
+}
+
+// for (auto &item : items) {}
+func (analyzer *analyzer) analyzeForRange(node *sitter.Node, visitChildren func() error) error {
+	return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+		right := node.ChildByFieldName("right")
+		analyzer.lookupVariable(right)
+
+		if name := declaratorIdentifier(node.ChildByFieldName("declarator")); name != nil {
+			analyzer.builder.Dataflow(name, right)
+			analyzer.scope.Declare(analyzer.builder.ContentFor(name), name)
+		}
+
+		return visitChildren()
+	})
+}
+
+// x ? a : b
+// x ?: b
+func (analyzer *analyzer) analyzeConditional(node *sitter.Node, visitChildren func() error) error {
+	condition := node.ChildByFieldName("condition")
+	consequence := node.ChildByFieldName("consequence")
+	alternative := node.ChildByFieldName("alternative")
+
+	analyzer.lookupVariable(condition)
+	analyzer.lookupVariable(consequence)
+	analyzer.lookupVariable(alternative)
+
+	if consequence != nil {
+		analyzer.builder.Alias(node, consequence, alternative)
+	} else {
+		analyzer.builder.Alias(node, condition, alternative)
+	}
+
+	return visitChildren()
+}
+
+// nodes which result in the value of their last child, eg.
+//
+//	(a)
+//	*a
+//	&a
+//	(char *)a
+func (analyzer *analyzer) analyzeWrapper(node *sitter.Node, visitChildren func() error) error {
+	if count := int(node.NamedChildCount()); count != 0 {
+		child := node.NamedChild(count - 1)
+		analyzer.builder.Alias(node, child)
+		analyzer.lookupVariable(child)
+	}
+
+	return visitChildren()
+}
+
+// default analysis, where the children are assumed to be data sources
+func (analyzer *analyzer) analyzeGenericOperation(node *sitter.Node, visitChildren func() error) error {
+	children := analyzer.builder.ChildrenFor(node)
+	analyzer.builder.Dataflow(node, children...)
+
+	for _, child := range children {
+		analyzer.lookupVariable(child)
+	}
+
+	return visitChildren()
+}
+
+// the parameters of a function by position
+func (analyzer *analyzer) positionalParameters(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var parameters []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() != "parameter_declaration" && child.Type() != "optional_parameter_declaration" {
+			continue
+		}
+
+		parameters = append(parameters, declaratorIdentifier(child.ChildByFieldName("declarator")))
+	}
+
+	return parameters
+}
+
+// the arguments of a call by position
+func (analyzer *analyzer) positionalArguments(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var arguments []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() == "comment" {
+			continue
+		}
+
+		arguments = append(arguments, child)
+	}
+
+	return arguments
+}
+
+func (analyzer *analyzer) withScope(newScope *language.Scope, body func() error) error {
+	oldScope := analyzer.scope
+
+	analyzer.scope = newScope
+	err := body()
+	analyzer.scope = oldScope
+
+	return err
+}
+
+func (analyzer *analyzer) lookupVariable(node *sitter.Node) {
+	if node == nil || node.Type() != "identifier" {
+		return
+	}
+
+	if pointsToNode := analyzer.scope.Lookup(analyzer.builder.ContentFor(node)); pointsToNode != nil {
+		analyzer.builder.Alias(node, pointsToNode)
+	}
+}
+
+// declaratorIdentifier returns the variable declared by a declarator, eg. `a`
+// in `*a`, `&a` or `a[10]`
+func declaratorIdentifier(node *sitter.Node) *sitter.Node {
+	for node != nil {
+		switch node.Type() {
+		case "identifier":
+			return node
+		case "pointer_declarator", "array_declarator", "init_declarator", "parenthesized_declarator":
+			node = node.ChildByFieldName("declarator")
+			if node == nil {
+				return nil
+			}
+		case "reference_declarator":
+			// the declarator isn't a field of references
+			node = node.NamedChild(int(node.NamedChildCount()) - 1)
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// functionDeclarator returns the declarator holding the name and parameters of
+// a function, eg. `foo(int a)` in `char *foo(int a)`
+func functionDeclarator(node *sitter.Node) *sitter.Node {
+	for node != nil {
+		switch node.Type() {
+		case "function_declarator":
+			return node
+		case "pointer_declarator", "reference_declarator":
+			node = node.NamedChild(int(node.NamedChildCount()) - 1)
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// functionName returns the name of a called or defined function, eg. `foo` in
+// `foo`, `ns::foo`, `obj.foo` or `obj->foo`
+func functionName(node *sitter.Node) *sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	switch node.Type() {
+	case "identifier", "field_identifier":
+		return node
+	case "qualified_identifier":
+		return functionName(node.ChildByFieldName("name"))
+	case "field_expression":
+		return node.ChildByFieldName("field")
+	}
+
+	return nil
+}
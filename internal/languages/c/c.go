@@ -0,0 +1,60 @@
+package c
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/cpp"
+
+	"github.com/bearer/bearer/internal/classification/schema"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	detectortypes "github.com/bearer/bearer/internal/scanner/detectors/types"
+
+	"github.com/bearer/bearer/internal/languages/c/analyzer"
+	"github.com/bearer/bearer/internal/languages/c/detectors/object"
+	stringdetector "github.com/bearer/bearer/internal/languages/c/detectors/string"
+	"github.com/bearer/bearer/internal/languages/c/pattern"
+	"github.com/bearer/bearer/internal/scanner/detectors/datatype"
+	"github.com/bearer/bearer/internal/scanner/detectors/insecureurl"
+	"github.com/bearer/bearer/internal/scanner/detectors/stringliteral"
+	"github.com/bearer/bearer/internal/scanner/language"
+)
+
+type implementation struct {
+	pattern pattern.Pattern
+}
+
+func Get() language.Language {
+	return &implementation{}
+}
+
+func (*implementation) ID() string {
+	return "c"
+}
+
+func (*implementation) EnryLanguages() []string {
+	return []string{"C", "C++"}
+}
+
+func (*implementation) NewBuiltInDetectors(schemaClassifier *schema.Classifier, querySet *query.Set) []detectortypes.Detector {
+	return []detectortypes.Detector{
+		object.New(querySet),
+		datatype.New(detectors.DetectorC, schemaClassifier),
+		stringdetector.New(querySet),
+		stringliteral.New(querySet),
+		insecureurl.New(querySet),
+	}
+}
+
+// C files are parsed with the C++ grammar, which covers the C syntax
+func (*implementation) SitterLanguage() *sitter.Language {
+	return cpp.GetLanguage()
+}
+
+func (language *implementation) Pattern() language.Pattern {
+	return &language.pattern
+}
+
+func (*implementation) NewAnalyzer(builder *tree.Builder) language.Analyzer {
+	return analyzer.New(builder)
+}
@@ -0,0 +1,21 @@
+package detectors_test
+
+import (
+	"testing"
+
+	"github.com/bearer/bearer/internal/languages/c"
+	"github.com/bearer/bearer/internal/scanner/detectors/testhelper"
+)
+
+func TestCObjects(t *testing.T) {
+	runTest(t, "object_class", "object", "testdata/class.c")
+	runTest(t, "object_no_class", "object", "testdata/no_class.c")
+}
+
+func TestCString(t *testing.T) {
+	runTest(t, "string", "string", "testdata/string.c")
+}
+
+func runTest(t *testing.T, name, detectorType, fileName string) {
+	testhelper.RunTest(t, name, c.Get(), detectorType, fileName)
+}
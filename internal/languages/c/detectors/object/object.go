@@ -0,0 +1,172 @@
+package object
+
+import (
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/ruleset"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+type objectDetector struct {
+	types.DetectorBase
+	// Base
+	structQuery *query.Query
+	// Naming
+	assignmentQuery *query.Query
+	// Projection
+	fieldAccessQuery *query.Query
+}
+
+func New(querySet *query.Set) types.Detector {
+	// User *user = <object>;
+	// user = <object>;
+	assignmentQuery := querySet.Add(`[
+		(init_declarator
+			declarator: [
+				(identifier) @name
+				(pointer_declarator declarator: (identifier) @name)
+				(reference_declarator (identifier) @name)
+			]
+			value: (_) @value) @root
+		(assignment_expression left: (identifier) @name operator: "=" right: (_) @value) @root
+	]`)
+
+	// struct user {
+	//   char *name;
+	// };
+	// class User {
+	//   std::string name;
+	// };
+	// typedef struct {
+	//   char *name;
+	// } user;
+	structQuery := querySet.Add(`[
+		(struct_specifier
+			name: (type_identifier) @class_name
+			body: (field_declaration_list
+				(field_declaration
+					declarator: [
+						(field_identifier) @name
+						(pointer_declarator declarator: (field_identifier) @name)
+						(array_declarator declarator: (field_identifier) @name)
+					]))) @root
+		(class_specifier
+			name: (type_identifier) @class_name
+			body: (field_declaration_list
+				(field_declaration
+					declarator: [
+						(field_identifier) @name
+						(pointer_declarator declarator: (field_identifier) @name)
+						(array_declarator declarator: (field_identifier) @name)
+					]))) @root
+		(type_definition
+			type: (struct_specifier
+				!name
+				body: (field_declaration_list
+					(field_declaration
+						declarator: [
+							(field_identifier) @name
+							(pointer_declarator declarator: (field_identifier) @name)
+							(array_declarator declarator: (field_identifier) @name)
+						])))
+			declarator: (type_identifier) @class_name) @root
+	]`)
+
+	// user.name
+	// user->name
+	fieldAccessQuery := querySet.Add(`(field_expression argument: (_) @object field: (field_identifier) @field) @root`)
+
+	return &objectDetector{
+		assignmentQuery:  assignmentQuery,
+		structQuery:      structQuery,
+		fieldAccessQuery: fieldAccessQuery,
+	}
+}
+
+func (detector *objectDetector) Rule() *ruleset.Rule {
+	return ruleset.BuiltinObjectRule
+}
+
+func (detector *objectDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	detections, err := detector.getAssignment(node, detectorContext)
+	if len(detections) != 0 || err != nil {
+		return detections, err
+	}
+
+	detections, err = detector.getStruct(node)
+	if len(detections) != 0 || err != nil {
+		return detections, err
+	}
+
+	return detector.getProjections(node, detectorContext)
+}
+
+func (detector *objectDetector) getAssignment(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	result, err := detector.assignmentQuery.MatchOnceAt(node)
+
+	if result == nil || err != nil {
+		return nil, err
+	}
+
+	rightObjects, err := common.GetNonVirtualObjects(
+		detectorContext,
+		result["value"],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []interface{}
+	for _, object := range rightObjects {
+		objects = append(objects, common.Object{
+			IsVirtual: true,
+			Properties: []common.Property{{
+				Name:   result["name"].Content(),
+				Node:   node,
+				Object: object,
+			}},
+		})
+	}
+
+	return objects, nil
+}
+
+func (detector *objectDetector) getStruct(node *tree.Node) ([]interface{}, error) {
+	results := detector.structQuery.MatchAt(node)
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	className := results[0]["class_name"].Content()
+
+	var properties []common.Property
+	for _, result := range results {
+		nameNode := result["name"]
+
+		properties = append(properties, common.Property{
+			Name: nameNode.Content(),
+			Node: nameNode,
+		})
+	}
+
+	return []interface{}{common.Object{
+		Properties: []common.Property{{
+			Name: className,
+			Object: &types.Detection{
+				RuleID:    ruleset.BuiltinObjectRule.ID(),
+				MatchNode: node,
+				Data: common.Object{
+					Properties: properties,
+				},
+			},
+		}},
+	}}, nil
+}
@@ -0,0 +1,61 @@
+package object
+
+import (
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+func (detector *objectDetector) getProjections(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	// user.save() is a method call, not a field
+	if parent := node.Parent(); parent != nil && parent.Type() == "call_expression" && parent.ChildByFieldName("function") == node {
+		return nil, nil
+	}
+
+	result, err := detector.fieldAccessQuery.MatchOnceAt(node)
+	if result == nil || err != nil {
+		return nil, err
+	}
+
+	objectNode := result["object"]
+
+	return common.ProjectObject(
+		node,
+		detectorContext,
+		objectNode,
+		getObjectName(objectNode),
+		result["field"].Content(),
+		true,
+	)
+}
+
+func getObjectName(objectNode *tree.Node) string {
+	switch objectNode.Type() {
+	// user.name
+	// user->name
+	case "identifier", "this":
+		return objectNode.Content()
+	// address.city.zip
+	case "field_expression":
+		return objectNode.ChildByFieldName("field").Content()
+	// user.address().city
+	case "call_expression":
+		if function := objectNode.ChildByFieldName("function"); function.Type() == "field_expression" {
+			return function.ChildByFieldName("field").Content()
+		}
+	// users[0].name
+	case "subscript_expression":
+		return getObjectName(objectNode.ChildByFieldName("argument"))
+	// (*user).name
+	case "parenthesized_expression", "pointer_expression":
+		if children := objectNode.NamedChildren(); len(children) != 0 {
+			return getObjectName(children[len(children)-1])
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,99 @@
+package string
+
+import (
+	"regexp"
+
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/ruleset"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+// R"(...)" or R"delimiter(...)delimiter", with an optional encoding prefix
+var rawStringRegex = regexp.MustCompile(`\A\w*R"[^(]*\(((?s).*)\)[^)"]*"\z`)
+
+type stringDetector struct {
+	types.DetectorBase
+}
+
+func New(querySet *query.Set) types.Detector {
+	return &stringDetector{}
+}
+
+func (detector *stringDetector) Rule() *ruleset.Rule {
+	return ruleset.BuiltinStringRule
+}
+
+func (detector *stringDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	switch node.Type() {
+	case "string_literal":
+		return handleString(node)
+	case "raw_string_literal":
+		return []interface{}{common.String{
+			Value:     rawStringRegex.ReplaceAllString(node.Content(), "$1"),
+			IsLiteral: true,
+		}}, nil
+	case "concatenated_string":
+		return common.ConcatenateChildStrings(node, detectorContext)
+	case "binary_expression":
+		if node.ChildByFieldName("operator").Content() == "+" {
+			return common.ConcatenateChildStrings(node, detectorContext)
+		}
+	case "assignment_expression":
+		if node.ChildByFieldName("operator").Content() == "+=" {
+			return common.ConcatenateAssignEquals(node, detectorContext)
+		}
+	case "call_expression":
+		return handleConversion(node, detectorContext)
+	}
+
+	return nil, nil
+}
+
+// handleString returns the value of a string literal. The quotes and encoding
+// prefix aren't part of the value as they are anonymous children
+func handleString(node *tree.Node) ([]interface{}, error) {
+	text := ""
+
+	err := node.EachContentPart(func(partText string) error {
+		text += partText
+		return nil
+	}, func(child *tree.Node) error {
+		// escape sequences
+		text += child.Content()
+		return nil
+	})
+
+	return []interface{}{common.String{
+		Value:     text,
+		IsLiteral: true,
+	}}, err
+}
+
+// handleConversion returns the value of the string being converted by a call,
+// eg. `std::string("foo")`
+func handleConversion(node *tree.Node, detectorContext types.Context) ([]interface{}, error) {
+	if node.ChildByFieldName("function").Content() != "std::string" {
+		return nil, nil
+	}
+
+	arguments := node.ChildByFieldName("arguments").NamedChildren()
+	if len(arguments) != 1 {
+		return nil, nil
+	}
+
+	value, isLiteral, err := common.GetStringValue(arguments[0], detectorContext)
+	if err != nil || (value == "" && !isLiteral) {
+		return nil, err
+	}
+
+	return []interface{}{common.String{
+		Value:     value,
+		IsLiteral: isLiteral,
+	}}, nil
+}
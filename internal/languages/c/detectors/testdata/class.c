@@ -0,0 +1,15 @@
+struct user {
+  char *name;
+  char email[255];
+  int age;
+};
+
+class Account {
+ public:
+  std::string name;
+};
+
+void main() {
+  struct user *user = lookup();
+  print(user->name);
+}
@@ -0,0 +1,8 @@
+void main() {
+  const char *a = "hello";
+  std::string b = a + std::string(" world");
+  b += "two";
+  const char *c = "con" "cat" "enated";
+  auto d = R"sql(raw "string")sql";
+  const char *e = "escaped\n";
+}
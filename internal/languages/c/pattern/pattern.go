@@ -0,0 +1,151 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/language"
+	"github.com/bearer/bearer/internal/util/regex"
+)
+
+var (
+	// $<name:type> or $<name:type1|type2> or $<name>
+	queryVariableRegex = regexp.MustCompile(`\$<(?P<name>[^>:!\.]+)(?::(?P<types>[^>]+))?>`)
+	matchNodeRegex     = regexp.MustCompile(`\$<!>`)
+	ellipsisRegex      = regexp.MustCompile(`\$<\.\.\.>`)
+
+	matchNodeContainerTypes = []string{"argument_list", "initializer_list"}
+
+	allowedQueryTypes = []string{
+		"_",
+		"identifier",
+		"field_identifier",
+		"type_identifier",
+		"qualified_identifier",
+		"field_expression",
+		"call_expression",
+		"string_literal",
+		"concatenated_string",
+	}
+
+	// the operators of these expressions are matched, so that eg. `a == b` doesn't
+	// match `a != b`
+	anonymousParentTypes = []string{
+		"assignment_expression",
+		"binary_expression",
+		"pointer_expression",
+		"unary_expression",
+		"update_expression",
+	}
+
+	// the items of a file or namespace, the statements of a block and the
+	// members of a struct or class
+	unanchoredParentTypes = []string{
+		"translation_unit",
+		"declaration_list",
+		"compound_statement",
+		"field_declaration_list",
+	}
+)
+
+type Pattern struct {
+	language.PatternBase
+}
+
+func (*Pattern) ExtractVariables(input string) (string, []language.PatternVariable, error) {
+	nameIndex := queryVariableRegex.SubexpIndex("name")
+	typesIndex := queryVariableRegex.SubexpIndex("types")
+	i := 0
+
+	var params []language.PatternVariable
+
+	replaced, err := regex.ReplaceAllWithSubmatches(queryVariableRegex, input, func(submatches []string) (string, error) {
+		nodeTypes := strings.Split(submatches[typesIndex], "|")
+		if nodeTypes[0] == "" {
+			nodeTypes = []string{"_"}
+		}
+
+		for _, nodeType := range nodeTypes {
+			if !slices.Contains(allowedQueryTypes, nodeType) {
+				return "", fmt.Errorf("invalid node type '%s' in pattern query", nodeType)
+			}
+		}
+
+		dummyValue := produceDummyValue(i)
+
+		params = append(params, language.PatternVariable{
+			Name:       submatches[nameIndex],
+			NodeTypes:  nodeTypes,
+			DummyValue: dummyValue,
+		})
+
+		i += 1
+
+		return dummyValue, nil
+	})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return replaced, params, nil
+}
+
+func produceDummyValue(i int) string {
+	return "BearerVar" + fmt.Sprint(i)
+}
+
+func (*Pattern) FindMatchNode(input []byte) [][]int {
+	return matchNodeRegex.FindAllIndex(input, -1)
+}
+
+func (*Pattern) FindUnanchoredPoints(input []byte) [][]int {
+	return ellipsisRegex.FindAllIndex(input, -1)
+}
+
+func (*Pattern) LeafContentTypes() []string {
+	return []string{
+		// identifiers
+		"identifier", "field_identifier", "type_identifier", "namespace_identifier", "primitive_type", "this",
+		// datatypes/literals
+		"string_literal", "raw_string_literal", "char_literal", "number_literal", "true", "false", "null", "nullptr",
+	}
+}
+
+func (*Pattern) IsAnchored(node *tree.Node) (bool, bool) {
+	parent := node.Parent()
+	if parent == nil {
+		return true, true
+	}
+
+	// the storage class, qualifiers and attributes of a function are optional
+	if parent.Type() == "function_definition" {
+		if node == parent.ChildByFieldName("body") {
+			return false, true
+		}
+
+		return false, false
+	}
+
+	isAnchored := !slices.Contains(unanchoredParentTypes, parent.Type())
+	return isAnchored, isAnchored
+}
+
+func (*Pattern) IsRoot(node *tree.Node) bool {
+	return !slices.Contains([]string{"translation_unit", "expression_statement"}, node.Type()) && !node.IsMissing()
+}
+
+func (*Pattern) AnonymousParentTypes() []string {
+	return anonymousParentTypes
+}
+
+func (*Pattern) NodeTypes(node *tree.Node) []string {
+	return []string{node.Type()}
+}
+
+func (*Pattern) ContainerTypes() []string {
+	return matchNodeContainerTypes
+}
@@ -0,0 +1,16 @@
+int main() {
+  scope_cursor(getenv("INPUT"));
+  scope_cursor(x + getenv("INPUT"));
+  scope_cursor(x ? getenv("INPUT") : y);
+  scope_cursor(getenv("INPUT") ? x : y);
+
+  scope_nested(getenv("INPUT"));
+  scope_nested(x + getenv("INPUT"));
+  scope_nested(x ? getenv("INPUT") : y);
+  scope_nested(getenv("INPUT") ? x : y);
+
+  scope_result(getenv("INPUT"));
+  scope_result(x + getenv("INPUT"));
+  scope_result(x ? getenv("INPUT") : y);
+  scope_result(getenv("INPUT") ? x : y);
+}
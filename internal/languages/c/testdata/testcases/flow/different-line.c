@@ -0,0 +1,4 @@
+void notify(struct user *user) {
+  char *name = user->name;
+  syslog(LOG_INFO, "%s", name);
+}
@@ -0,0 +1,3 @@
+void notify(struct user *user) {
+  syslog(LOG_INFO, "%s", user->name);
+}
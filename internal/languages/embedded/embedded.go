@@ -0,0 +1,26 @@
+package embedded
+
+import (
+	"github.com/bearer/bearer/internal/languages/html"
+	"github.com/bearer/bearer/internal/languages/shell"
+	"github.com/bearer/bearer/internal/languages/sql"
+	"github.com/bearer/bearer/internal/scanner/language"
+)
+
+var languages = []language.Language{
+	html.Get(),
+	shell.Get(),
+	sql.Get(),
+}
+
+// Get returns the language with the given id which rules can match within the
+// string literals of other languages, or nil if there isn't one
+func Get(id string) language.Language {
+	for _, language := range languages {
+		if language.ID() == id {
+			return language
+		}
+	}
+
+	return nil
+}
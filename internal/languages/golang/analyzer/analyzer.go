@@ -25,13 +25,22 @@ func New(builder *tree.Builder) language.Analyzer {
 
 func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
 	switch node.Type() {
-	case "for_statement", "block", "method_declaration", "function_declaration":
+	case "method_declaration", "function_declaration":
+		analyzer.builder.AddFunction(
+			node.ChildByFieldName("name"),
+			analyzer.positionalParameters(node.ChildByFieldName("parameters")),
+		)
+
+		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+			return visitChildren()
+		})
+	case "for_statement", "block":
 		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
 			return visitChildren()
 		})
 	case "short_var_declaration":
 		return analyzer.analyzeShortVarDeclaration(node, visitChildren)
-	case "var_spec":
+	case "var_spec", "const_spec":
 		return analyzer.analyzeVarSpecDeclaration(node, visitChildren)
 	case "assignment_expression":
 		return analyzer.analyzeAssignment(node, visitChildren)
@@ -151,11 +160,28 @@ func (analyzer *analyzer) analyzeShortVarDeclaration(node *sitter.Node, visitChi
 }
 
 // var a, b string
+// const a, b = "x", "y"
 func (analyzer *analyzer) analyzeVarSpecDeclaration(node *sitter.Node, visitChildren func() error) error {
+	var values []*sitter.Node
+	if valueNode := node.ChildByFieldName("value"); valueNode != nil {
+		for i := 0; i < int(valueNode.NamedChildCount()); i++ {
+			values = append(values, valueNode.NamedChild(i))
+		}
+	}
+
+	i := 0
 	for _, child := range analyzer.builder.ChildrenFor(node) {
-		if child.Type() == "identifier" {
-			analyzer.scope.Declare(analyzer.builder.ContentFor(child), child)
+		if child.Type() != "identifier" {
+			continue
 		}
+
+		analyzer.scope.Declare(analyzer.builder.ContentFor(child), child)
+
+		if i < len(values) {
+			analyzer.builder.Alias(child, values[i])
+		}
+
+		i++
 	}
 
 	err := visitChildren()
@@ -165,13 +191,76 @@ func (analyzer *analyzer) analyzeVarSpecDeclaration(node *sitter.Node, visitChil
 
 // foo(1, 2)
 func (analyzer *analyzer) analyzeCallExpression(node *sitter.Node, visitChildren func() error) error {
-	if arguments := node.ChildByFieldName("arguments"); arguments != nil {
+	arguments := node.ChildByFieldName("arguments")
+	if arguments != nil {
 		analyzer.builder.Dataflow(node, arguments)
 	}
 
+	switch function := node.ChildByFieldName("function"); function.Type() {
+	case "identifier":
+		analyzer.builder.AddCall(function, analyzer.positionalArguments(arguments))
+	case "selector_expression":
+		analyzer.builder.AddCall(function.ChildByFieldName("field"), analyzer.positionalArguments(arguments))
+	}
+
 	return visitChildren()
 }
 
+// the names of the parameters of a function by position. Only the first name
+// of a declaration is declared, eg. `a` in `a, b string`, so the others can't
+// be followed.
+func (analyzer *analyzer) positionalParameters(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var parameters []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		switch child.Type() {
+		case "comment":
+		case "parameter_declaration":
+			name := child.ChildByFieldName("name")
+			parameters = append(parameters, name)
+
+			for j := 1; j < int(child.NamedChildCount()); j++ {
+				if child.NamedChild(j).Type() == "identifier" {
+					parameters = append(parameters, nil)
+				}
+			}
+		default:
+			return parameters
+		}
+	}
+
+	return parameters
+}
+
+// the arguments of a call by position. Variadic arguments can't be matched to
+// parameters by position.
+func (analyzer *analyzer) positionalArguments(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var arguments []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		switch child.Type() {
+		case "comment":
+			continue
+		case "variadic_argument":
+			return arguments
+		}
+
+		arguments = append(arguments, child)
+	}
+
+	return arguments
+}
+
 // foo.bar
 func (analyzer *analyzer) analyzeSelectorExpression(node *sitter.Node, visitChildren func() error) error {
 	analyzer.lookupVariable(node.ChildByFieldName("operand"))
@@ -0,0 +1,55 @@
+package html
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/html"
+
+	"github.com/bearer/bearer/internal/classification/schema"
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	detectortypes "github.com/bearer/bearer/internal/scanner/detectors/types"
+
+	"github.com/bearer/bearer/internal/languages/html/pattern"
+	"github.com/bearer/bearer/internal/scanner/language"
+)
+
+// implementation of HTML as a language embedded in the string literals of
+// other languages, so it doesn't scan files on its own
+type implementation struct {
+	pattern pattern.Pattern
+}
+
+type analyzer struct{}
+
+func Get() language.Language {
+	return &implementation{}
+}
+
+func (*implementation) ID() string {
+	return "html"
+}
+
+func (*implementation) EnryLanguages() []string {
+	return nil
+}
+
+func (*implementation) NewBuiltInDetectors(schemaClassifier *schema.Classifier, querySet *query.Set) []detectortypes.Detector {
+	return nil
+}
+
+func (*implementation) SitterLanguage() *sitter.Language {
+	return html.GetLanguage()
+}
+
+func (language *implementation) Pattern() language.Pattern {
+	return &language.pattern
+}
+
+func (*implementation) NewAnalyzer(builder *tree.Builder) language.Analyzer {
+	return &analyzer{}
+}
+
+// Analyze does nothing as markup has no variables to follow
+func (*analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
+	return visitChildren()
+}
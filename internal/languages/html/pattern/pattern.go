@@ -0,0 +1,108 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/language"
+	"github.com/bearer/bearer/internal/util/regex"
+)
+
+var (
+	// $<name:type> or $<name:type1|type2> or $<name>
+	patternQueryVariableRegex = regexp.MustCompile(`\$<(?P<name>[^>:!\.]+)(?::(?P<types>[^>]+))?>`)
+	matchNodeRegex            = regexp.MustCompile(`\$<!>`)
+	ellipsisRegex             = regexp.MustCompile(`\$<\.\.\.>`)
+
+	allowedPatternQueryTypes = []string{"_", "text", "attribute_value", "quoted_attribute_value"}
+)
+
+type Pattern struct {
+	language.PatternBase
+}
+
+func (*Pattern) ExtractVariables(input string) (string, []language.PatternVariable, error) {
+	nameIndex := patternQueryVariableRegex.SubexpIndex("name")
+	typesIndex := patternQueryVariableRegex.SubexpIndex("types")
+	i := 0
+
+	var params []language.PatternVariable
+
+	replaced, err := regex.ReplaceAllWithSubmatches(patternQueryVariableRegex, input, func(submatches []string) (string, error) {
+		nodeTypes := strings.Split(submatches[typesIndex], "|")
+		if nodeTypes[0] == "" {
+			nodeTypes = []string{"_"}
+		}
+
+		for _, nodeType := range nodeTypes {
+			if !slices.Contains(allowedPatternQueryTypes, nodeType) {
+				return "", fmt.Errorf("invalid node type '%s' in pattern query", nodeType)
+			}
+		}
+
+		dummyValue := "BearerVar" + fmt.Sprint(i)
+
+		params = append(params, language.PatternVariable{
+			Name:       submatches[nameIndex],
+			NodeTypes:  nodeTypes,
+			DummyValue: dummyValue,
+		})
+
+		i += 1
+
+		return dummyValue, nil
+	})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return replaced, params, nil
+}
+
+func (*Pattern) FindMatchNode(input []byte) [][]int {
+	return matchNodeRegex.FindAllIndex(input, -1)
+}
+
+func (*Pattern) FindUnanchoredPoints(input []byte) [][]int {
+	return ellipsisRegex.FindAllIndex(input, -1)
+}
+
+func (*Pattern) LeafContentTypes() []string {
+	return []string{
+		"tag_name",
+		"attribute_name",
+		"attribute_value",
+		"text",
+		"raw_text",
+	}
+}
+
+func (*Pattern) IsAnchored(node *tree.Node) (bool, bool) {
+	parent := node.Parent()
+	if parent == nil {
+		return true, true
+	}
+
+	switch parent.Type() {
+	// attributes can be in any order, and tags can have other attributes
+	case "start_tag", "self_closing_tag":
+		return false, false
+	// a pattern with only a start tag still matches elements with content
+	case "element", "script_element", "style_element":
+		return true, false
+	}
+
+	return true, true
+}
+
+func (*Pattern) IsRoot(node *tree.Node) bool {
+	return node.Type() != "fragment" && !node.IsMissing()
+}
+
+func (*Pattern) NodeTypes(node *tree.Node) []string {
+	return []string{node.Type()}
+}
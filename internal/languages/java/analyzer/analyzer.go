@@ -38,6 +38,13 @@ func New(builder *tree.Builder) language.Analyzer {
 }
 
 func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
+	if node.Type() == "method_declaration" {
+		analyzer.builder.AddFunction(
+			node.ChildByFieldName("name"),
+			analyzer.positionalParameters(node.ChildByFieldName("parameters")),
+		)
+	}
+
 	switch node.Type() {
 	case "class_body",
 		"method_declaration",
@@ -167,13 +174,62 @@ func (analyzer *analyzer) analyzeMethodInvocation(node *sitter.Node, visitChildr
 		}
 	}
 
-	if arguments := node.ChildByFieldName("arguments"); arguments != nil {
+	arguments := node.ChildByFieldName("arguments")
+	if arguments != nil {
 		analyzer.builder.Dataflow(node, arguments)
 	}
 
+	analyzer.builder.AddCall(node.ChildByFieldName("name"), analyzer.positionalArguments(arguments))
+
 	return visitChildren()
 }
 
+// the names of the parameters of a method by position. Values can't be
+// followed into varargs by position.
+func (analyzer *analyzer) positionalParameters(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var parameters []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		switch child.Type() {
+		case "line_comment", "block_comment", "receiver_parameter":
+		case "formal_parameter":
+			name := child.ChildByFieldName("name")
+			if name.Type() != "identifier" {
+				name = nil
+			}
+
+			parameters = append(parameters, name)
+		default:
+			return parameters
+		}
+	}
+
+	return parameters
+}
+
+// the arguments of a call by position
+func (analyzer *analyzer) positionalArguments(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var arguments []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		if child.Type() != "line_comment" && child.Type() != "block_comment" {
+			arguments = append(arguments, child)
+		}
+	}
+
+	return arguments
+}
+
 // foo.bar
 func (analyzer *analyzer) analyzeFieldAccess(node *sitter.Node, visitChildren func() error) error {
 	analyzer.lookupVariable(node.ChildByFieldName("object"))
@@ -1,6 +1,8 @@
 package string
 
 import (
+	"strings"
+
 	"github.com/bearer/bearer/internal/scanner/ast/query"
 	"github.com/bearer/bearer/internal/scanner/ast/tree"
 	"github.com/bearer/bearer/internal/scanner/ruleset"
@@ -32,6 +34,14 @@ func (detector *stringDetector) DetectAt(
 			Value:     stringutil.StripQuotes(node.Content()),
 			IsLiteral: true,
 		}}, nil
+	// """
+	//   text
+	//   """
+	case "text_block":
+		return []interface{}{common.String{
+			Value:     strings.TrimPrefix(stringutil.StripQuotes(node.Content()), "\n"),
+			IsLiteral: true,
+		}}, nil
 	case "binary_expression":
 		if node.Children()[1].Content() == "+" {
 			return common.ConcatenateChildStrings(node, detectorContext)
@@ -35,6 +35,10 @@ func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error)
 	// () => {}
 	// function getName() {}
 	case "function", "arrow_function", "method_definition":
+		if node.Type() == "method_definition" {
+			analyzer.addFunction(node.ChildByFieldName("name"), node)
+		}
+
 		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
 			return visitChildren()
 		})
@@ -79,6 +83,11 @@ func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error)
 		"template_substitution",
 		"unary_expression":
 		return analyzer.analyzeGenericOperation(node, visitChildren)
+	case "function_declaration", "generator_function_declaration":
+		analyzer.addFunction(node.ChildByFieldName("name"), node)
+		analyzer.builder.Dataflow(node, analyzer.builder.ChildrenFor(node)...)
+
+		return visitChildren()
 	default:
 		// statements don't have results
 		if !strings.HasSuffix(node.Type(), "_statement") {
@@ -139,6 +148,10 @@ func (analyzer *analyzer) analyzeVariableDeclarator(node *sitter.Node, visitChil
 	analyzer.builder.Alias(node, value)
 	analyzer.lookupVariable(value)
 
+	if value != nil && (value.Type() == "function" || value.Type() == "arrow_function") {
+		analyzer.addFunction(name, value)
+	}
+
 	err := visitChildren()
 
 	if name.Type() == "identifier" {
@@ -200,13 +213,75 @@ func (analyzer *analyzer) analyzeCall(node *sitter.Node, visitChildren func() er
 		}
 	}
 
-	if arguments := node.ChildByFieldName("arguments"); arguments != nil {
+	arguments := node.ChildByFieldName("arguments")
+	if arguments != nil {
 		analyzer.builder.Dataflow(node, arguments)
 	}
 
+	switch function.Type() {
+	case "identifier":
+		analyzer.builder.AddCall(function, analyzer.positionalArguments(arguments))
+	case "member_expression":
+		analyzer.builder.AddCall(function.ChildByFieldName("property"), analyzer.positionalArguments(arguments))
+	}
+
 	return visitChildren()
 }
 
+// function foo(a, b = 1) {}
+// const foo = (a, b = 1) => {}
+func (analyzer *analyzer) addFunction(nameNode *sitter.Node, node *sitter.Node) {
+	if nameNode == nil || nameNode.Type() == "computed_property_name" {
+		return
+	}
+
+	var parameters []*sitter.Node
+	if parametersNode := node.ChildByFieldName("parameters"); parametersNode != nil {
+		for i := 0; i < int(parametersNode.NamedChildCount()); i++ {
+			child := parametersNode.NamedChild(i)
+
+			switch child.Type() {
+			case "comment":
+			case "required_parameter", "optional_parameter":
+				// parameters are declared as the parameter node
+				if pattern := child.ChildByFieldName("pattern"); pattern != nil && pattern.Type() == "identifier" {
+					parameters = append(parameters, child)
+				} else {
+					parameters = append(parameters, nil)
+				}
+			default:
+				parameters = append(parameters, nil)
+			}
+		}
+	}
+
+	analyzer.builder.AddFunction(nameNode, parameters)
+}
+
+// the arguments of a call which are passed by position. Arguments after a
+// spread can't be matched to parameters by position.
+func (analyzer *analyzer) positionalArguments(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var arguments []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		switch child.Type() {
+		case "comment":
+			continue
+		case "spread_element":
+			return arguments
+		}
+
+		arguments = append(arguments, child)
+	}
+
+	return arguments
+}
+
 // parameter definition
 // foo(a, b = 1)
 func (analyzer *analyzer) analyzeParameter(node *sitter.Node, visitChildren func() error) error {
@@ -0,0 +1,427 @@
+package analyzer
+
+import (
+	"slices"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/language"
+)
+
+// methods that use the receiver in their result
+var reflexiveMethods = []string{
+	// Any
+	"toString",
+	// String
+	"format",
+	"lowercase",
+	"orEmpty",
+	"replace",
+	"split",
+	"substring",
+	"toByteArray",
+	"toCharArray",
+	"toLowerCase",
+	"toUpperCase",
+	"trim",
+	"trimIndent",
+	"trimMargin",
+	"uppercase",
+	// StringBuilder
+	"append",
+}
+
+type analyzer struct {
+	builder *tree.Builder
+	scope   *language.Scope
+}
+
+func New(builder *tree.Builder) language.Analyzer {
+	return &analyzer{
+		builder: builder,
+		scope:   language.NewScope(nil),
+	}
+}
+
+func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
+	switch node.Type() {
+	case "function_declaration":
+		return analyzer.analyzeFunction(node, visitChildren)
+	case "class_declaration",
+		"object_declaration",
+		"class_body",
+		"lambda_literal",
+		"anonymous_function",
+		"catch_block":
+		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+			return visitChildren()
+		})
+	case "property_declaration":
+		return analyzer.analyzePropertyDeclaration(node, visitChildren)
+	case "assignment":
+		return analyzer.analyzeAssignment(node, visitChildren)
+	case "call_expression":
+		return analyzer.analyzeCall(node, visitChildren)
+	case "navigation_expression":
+		return analyzer.analyzeNavigation(node, visitChildren)
+	case "parameter", "class_parameter":
+		return analyzer.analyzeParameter(node, visitChildren)
+	case "lambda_parameters":
+		return analyzer.analyzeLambdaParameters(node, visitChildren)
+	case "for_statement":
+		return analyzer.analyzeFor(node, visitChildren)
+	case "value_argument", "parenthesized_expression", "interpolated_expression":
+		return analyzer.analyzeWrapper(node, visitChildren)
+	case "control_structure_body":
+		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+			return analyzer.analyzeWrapper(node, visitChildren)
+		})
+	case "if_expression":
+		return analyzer.analyzeIf(node, visitChildren)
+	case "when_expression", "when_entry":
+		return analyzer.analyzeWhen(node, visitChildren)
+	case "elvis_expression":
+		return analyzer.analyzeElvis(node, visitChildren)
+	case "value_arguments",
+		"additive_expression",
+		"multiplicative_expression",
+		"infix_expression",
+		"indexing_expression",
+		"indexing_suffix",
+		"collection_literal",
+		"prefix_expression",
+		"postfix_expression",
+		"as_expression",
+		"line_string_literal",
+		"multi_line_string_literal":
+		return analyzer.analyzeGenericOperation(node, visitChildren)
+	case "while_statement", "do_while_statement": // statements don't have results
+		return visitChildren()
+	default:
+		analyzer.builder.Dataflow(node, analyzer.builder.ChildrenFor(node)...)
+		return visitChildren()
+	}
+}
+
+// fun foo(a: String, b: Int = 1) {}
+func (analyzer *analyzer) analyzeFunction(node *sitter.Node, visitChildren func() error) error {
+	if name := analyzer.firstChildOfType(node, "simple_identifier"); name != nil {
+		analyzer.builder.AddFunction(name, analyzer.positionalParameters(node))
+	}
+
+	return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+		return visitChildren()
+	})
+}
+
+// val foo = a
+// var foo: String = a
+func (analyzer *analyzer) analyzePropertyDeclaration(node *sitter.Node, visitChildren func() error) error {
+	var name *sitter.Node
+	if declaration := analyzer.firstChildOfType(node, "variable_declaration"); declaration != nil {
+		name = analyzer.firstChildOfType(declaration, "simple_identifier")
+	}
+
+	value := analyzer.valueAfter(node, "=")
+	if name != nil && value != nil {
+		analyzer.builder.Alias(name, value)
+	}
+	analyzer.lookupVariable(value)
+
+	err := visitChildren()
+
+	if name != nil {
+		analyzer.scope.Declare(analyzer.builder.ContentFor(name), name)
+	}
+
+	return err
+}
+
+// foo = a
+// foo += a
+func (analyzer *analyzer) analyzeAssignment(node *sitter.Node, visitChildren func() error) error {
+	var left *sitter.Node
+	if target := analyzer.firstChildOfType(node, "directly_assignable_expression"); target != nil &&
+		target.NamedChildCount() == 1 &&
+		target.NamedChild(0).Type() == "simple_identifier" {
+		left = target.NamedChild(0)
+	}
+
+	right := node.NamedChild(int(node.NamedChildCount()) - 1)
+
+	if analyzer.builder.ContentFor(node.Child(1)) == "=" {
+		analyzer.builder.Alias(node, right)
+	} else {
+		analyzer.lookupVariable(left)
+		analyzer.builder.Dataflow(node, left, right)
+	}
+
+	analyzer.lookupVariable(right)
+
+	err := visitChildren()
+
+	if left != nil {
+		analyzer.scope.Assign(analyzer.builder.ContentFor(left), node)
+	}
+
+	return err
+}
+
+// foo(1, 2)
+// foo.bar(1, 2)
+// foo.bar { ... }
+func (analyzer *analyzer) analyzeCall(node *sitter.Node, visitChildren func() error) error {
+	callee := node.NamedChild(0)
+	analyzer.lookupVariable(callee)
+
+	var name *sitter.Node
+	switch callee.Type() {
+	case "simple_identifier":
+		name = callee
+	case "navigation_expression":
+		if suffix := analyzer.lastChildOfType(callee, "navigation_suffix"); suffix != nil {
+			name = analyzer.firstChildOfType(suffix, "simple_identifier")
+		}
+
+		if name != nil && slices.Contains(reflexiveMethods, analyzer.builder.ContentFor(name)) {
+			analyzer.builder.Dataflow(node, callee.NamedChild(0))
+		}
+	}
+
+	var arguments *sitter.Node
+	if suffix := analyzer.firstChildOfType(node, "call_suffix"); suffix != nil {
+		arguments = analyzer.firstChildOfType(suffix, "value_arguments")
+	}
+
+	if arguments != nil {
+		analyzer.builder.Dataflow(node, arguments)
+	}
+
+	if name != nil {
+		analyzer.builder.AddCall(name, analyzer.positionalArguments(arguments))
+	}
+
+	return visitChildren()
+}
+
+// the parameters of a function by position. Values can't be followed into
+// varargs by position.
+func (analyzer *analyzer) positionalParameters(node *sitter.Node) []*sitter.Node {
+	var parameters []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() != "parameter" {
+			continue
+		}
+
+		if modifiers := child.PrevNamedSibling(); modifiers != nil &&
+			modifiers.Type() == "parameter_modifiers" &&
+			analyzer.builder.ContentFor(modifiers) == "vararg" {
+			return parameters
+		}
+
+		parameters = append(parameters, analyzer.firstChildOfType(child, "simple_identifier"))
+	}
+
+	return parameters
+}
+
+// the arguments of a call by position. Arguments after named and spread
+// arguments can't be matched to parameters by position.
+func (analyzer *analyzer) positionalArguments(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var arguments []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() != "value_argument" {
+			continue
+		}
+
+		if child.NamedChildCount() != 1 || !child.Child(0).IsNamed() {
+			return arguments
+		}
+
+		arguments = append(arguments, child)
+	}
+
+	return arguments
+}
+
+// foo.bar
+func (analyzer *analyzer) analyzeNavigation(node *sitter.Node, visitChildren func() error) error {
+	analyzer.lookupVariable(node.NamedChild(0))
+
+	return visitChildren()
+}
+
+// fun m(foo: String) {}
+// class User(val foo: String)
+func (analyzer *analyzer) analyzeParameter(node *sitter.Node, visitChildren func() error) error {
+	if name := analyzer.firstChildOfType(node, "simple_identifier"); name != nil {
+		analyzer.builder.Alias(node, name)
+		analyzer.scope.Declare(analyzer.builder.ContentFor(name), name)
+	}
+
+	return visitChildren()
+}
+
+// { a, b -> ... }
+func (analyzer *analyzer) analyzeLambdaParameters(node *sitter.Node, visitChildren func() error) error {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if name := analyzer.firstChildOfType(node.NamedChild(i), "simple_identifier"); name != nil {
+			analyzer.scope.Declare(analyzer.builder.ContentFor(name), name)
+		}
+	}
+
+	return visitChildren()
+}
+
+// for (item in items) {}
+func (analyzer *analyzer) analyzeFor(node *sitter.Node, visitChildren func() error) error {
+	return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+		value := analyzer.valueAfter(node, "in")
+		analyzer.lookupVariable(value)
+
+		if declaration := analyzer.firstChildOfType(node, "variable_declaration"); declaration != nil {
+			if name := analyzer.firstChildOfType(declaration, "simple_identifier"); name != nil {
+				analyzer.builder.Dataflow(name, value)
+				analyzer.scope.Declare(analyzer.builder.ContentFor(name), name)
+			}
+		}
+
+		return visitChildren()
+	})
+}
+
+// nodes which result in the value of their last child, eg.
+//
+//	foo(a)
+//	(a)
+//	"${a}"
+//	if (x) a else b
+func (analyzer *analyzer) analyzeWrapper(node *sitter.Node, visitChildren func() error) error {
+	if count := int(node.NamedChildCount()); count != 0 {
+		child := node.NamedChild(count - 1)
+		analyzer.builder.Alias(node, child)
+		analyzer.lookupVariable(child)
+	}
+
+	return visitChildren()
+}
+
+// if (x) a else b
+func (analyzer *analyzer) analyzeIf(node *sitter.Node, visitChildren func() error) error {
+	var bodies []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() == "control_structure_body" {
+			bodies = append(bodies, child)
+		} else {
+			analyzer.lookupVariable(child)
+		}
+	}
+
+	analyzer.builder.Alias(node, bodies...)
+
+	return visitChildren()
+}
+
+//	when (x) {
+//	  1 -> a
+//	  else -> b
+//	}
+func (analyzer *analyzer) analyzeWhen(node *sitter.Node, visitChildren func() error) error {
+	var results []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() == "when_entry" || child.Type() == "control_structure_body" {
+			results = append(results, child)
+		}
+	}
+
+	analyzer.builder.Alias(node, results...)
+
+	return visitChildren()
+}
+
+// a ?: b
+func (analyzer *analyzer) analyzeElvis(node *sitter.Node, visitChildren func() error) error {
+	var operands []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		operand := node.NamedChild(i)
+		analyzer.lookupVariable(operand)
+		operands = append(operands, operand)
+	}
+
+	analyzer.builder.Alias(node, operands...)
+
+	return visitChildren()
+}
+
+// default analysis, where the children are assumed to be data sources
+func (analyzer *analyzer) analyzeGenericOperation(node *sitter.Node, visitChildren func() error) error {
+	children := analyzer.builder.ChildrenFor(node)
+	analyzer.builder.Dataflow(node, children...)
+
+	for _, child := range children {
+		analyzer.lookupVariable(child)
+	}
+
+	return visitChildren()
+}
+
+// the named node following the given token, eg. the value after `=`
+func (analyzer *analyzer) valueAfter(node *sitter.Node, token string) *sitter.Node {
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		if !child.IsNamed() && analyzer.builder.ContentFor(child) == token {
+			return child.NextNamedSibling()
+		}
+	}
+
+	return nil
+}
+
+func (analyzer *analyzer) firstChildOfType(node *sitter.Node, nodeType string) *sitter.Node {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if child := node.NamedChild(i); child.Type() == nodeType {
+			return child
+		}
+	}
+
+	return nil
+}
+
+func (analyzer *analyzer) lastChildOfType(node *sitter.Node, nodeType string) *sitter.Node {
+	for i := int(node.NamedChildCount()) - 1; i >= 0; i-- {
+		if child := node.NamedChild(i); child.Type() == nodeType {
+			return child
+		}
+	}
+
+	return nil
+}
+
+func (analyzer *analyzer) withScope(newScope *language.Scope, body func() error) error {
+	oldScope := analyzer.scope
+
+	analyzer.scope = newScope
+	err := body()
+	analyzer.scope = oldScope
+
+	return err
+}
+
+func (analyzer *analyzer) lookupVariable(node *sitter.Node) {
+	if node == nil || (node.Type() != "simple_identifier" && node.Type() != "interpolated_identifier") {
+		return
+	}
+
+	if pointsToNode := analyzer.scope.Lookup(analyzer.builder.ContentFor(node)); pointsToNode != nil {
+		analyzer.builder.Alias(node, pointsToNode)
+	}
+}
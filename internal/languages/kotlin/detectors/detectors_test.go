@@ -0,0 +1,21 @@
+package detectors_test
+
+import (
+	"testing"
+
+	"github.com/bearer/bearer/internal/languages/kotlin"
+	"github.com/bearer/bearer/internal/scanner/detectors/testhelper"
+)
+
+func TestKotlinObjects(t *testing.T) {
+	runTest(t, "object_class", "object", "testdata/class.kt")
+	runTest(t, "object_no_class", "object", "testdata/no_class.kt")
+}
+
+func TestKotlinString(t *testing.T) {
+	runTest(t, "string", "string", "testdata/string.kt")
+}
+
+func runTest(t *testing.T, name, detectorType, fileName string) {
+	testhelper.RunTest(t, name, kotlin.Get(), detectorType, fileName)
+}
@@ -0,0 +1,141 @@
+package object
+
+import (
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/ruleset"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+type objectDetector struct {
+	types.DetectorBase
+	// Base
+	classQuery *query.Query
+	// Naming
+	assignmentQuery *query.Query
+	// Projection
+	navigationQuery *query.Query
+}
+
+func New(querySet *query.Set) types.Detector {
+	// user = <object>
+	// val user = User(...)
+	assignmentQuery := querySet.Add(`[
+		(assignment (directly_assignable_expression . (simple_identifier) @name .) "=" (_) @value) @root
+		(property_declaration (variable_declaration (simple_identifier) @name) "=" (call_expression) @value) @root
+	]`)
+
+	// class User(val name: String) {
+	//   val email: String
+	//   fun getLevel() {}
+	// }
+	classQuery := querySet.Add(`
+		(class_declaration (type_identifier) @class_name
+			[
+				(primary_constructor (class_parameter (simple_identifier) @name))
+				(class_body
+					[
+						(property_declaration (variable_declaration (simple_identifier) @name))
+						(function_declaration (simple_identifier) @name)
+					]
+				)
+			]
+		) @root`)
+
+	// user.name
+	navigationQuery := querySet.Add(`(navigation_expression (_) @object (navigation_suffix (simple_identifier) @field)) @root`)
+
+	return &objectDetector{
+		assignmentQuery: assignmentQuery,
+		classQuery:      classQuery,
+		navigationQuery: navigationQuery,
+	}
+}
+
+func (detector *objectDetector) Rule() *ruleset.Rule {
+	return ruleset.BuiltinObjectRule
+}
+
+func (detector *objectDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	detections, err := detector.getAssignment(node, detectorContext)
+	if len(detections) != 0 || err != nil {
+		return detections, err
+	}
+
+	detections, err = detector.getClass(node)
+	if len(detections) != 0 || err != nil {
+		return detections, err
+	}
+
+	return detector.getProjections(node, detectorContext)
+}
+
+func (detector *objectDetector) getAssignment(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	result, err := detector.assignmentQuery.MatchOnceAt(node)
+
+	if result == nil || err != nil {
+		return nil, err
+	}
+
+	rightObjects, err := common.GetNonVirtualObjects(
+		detectorContext,
+		result["value"],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []interface{}
+	for _, object := range rightObjects {
+		objects = append(objects, common.Object{
+			IsVirtual: true,
+			Properties: []common.Property{{
+				Name:   result["name"].Content(),
+				Node:   node,
+				Object: object,
+			}},
+		})
+	}
+
+	return objects, nil
+}
+
+func (detector *objectDetector) getClass(node *tree.Node) ([]interface{}, error) {
+	results := detector.classQuery.MatchAt(node)
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	className := results[0]["class_name"].Content()
+
+	var properties []common.Property
+	for _, result := range results {
+		nameNode := result["name"]
+
+		properties = append(properties, common.Property{
+			Name: nameNode.Content(),
+			Node: nameNode,
+		})
+	}
+
+	return []interface{}{common.Object{
+		Properties: []common.Property{{
+			Name: className,
+			Object: &types.Detection{
+				RuleID:    ruleset.BuiltinObjectRule.ID(),
+				MatchNode: node,
+				Data: common.Object{
+					Properties: properties,
+				},
+			},
+		}},
+	}}, nil
+}
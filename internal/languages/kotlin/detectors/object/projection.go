@@ -0,0 +1,77 @@
+package object
+
+import (
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+func (detector *objectDetector) getProjections(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	// user.save() is a method call, not a property
+	if parent := node.Parent(); parent != nil && parent.Type() == "call_expression" {
+		return nil, nil
+	}
+
+	result, err := detector.navigationQuery.MatchOnceAt(node)
+	if err != nil {
+		return nil, err
+	}
+
+	if result != nil {
+		objectNode := result["object"]
+
+		objects, err := common.ProjectObject(
+			node,
+			detectorContext,
+			objectNode,
+			getObjectName(objectNode),
+			result["field"].Content(),
+			true,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return objects, nil
+	}
+
+	return nil, nil
+}
+
+func getObjectName(objectNode *tree.Node) string {
+	switch objectNode.Type() {
+	// user.name
+	case "simple_identifier":
+		return objectNode.Content()
+	// address.city.zip
+	case "navigation_expression":
+		return getNavigationName(objectNode)
+	// user.getAddress().city
+	case "call_expression":
+		if callee := objectNode.NamedChildren()[0]; callee.Type() == "navigation_expression" {
+			return getNavigationName(callee)
+		}
+	}
+
+	return ""
+}
+
+func getNavigationName(node *tree.Node) string {
+	children := node.NamedChildren()
+	suffix := children[len(children)-1]
+	if suffix.Type() != "navigation_suffix" {
+		return ""
+	}
+
+	for _, child := range suffix.NamedChildren() {
+		if child.Type() == "simple_identifier" {
+			return child.Content()
+		}
+	}
+
+	return ""
+}
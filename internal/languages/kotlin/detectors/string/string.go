@@ -0,0 +1,120 @@
+package string
+
+import (
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/ruleset"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+type stringDetector struct {
+	types.DetectorBase
+}
+
+func New(querySet *query.Set) types.Detector {
+	return &stringDetector{}
+}
+
+func (detector *stringDetector) Rule() *ruleset.Rule {
+	return ruleset.BuiltinStringRule
+}
+
+func (detector *stringDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	switch node.Type() {
+	case "line_string_literal", "multi_line_string_literal":
+		return handleTemplateString(node, detectorContext)
+	case "additive_expression":
+		if node.Children()[1].Content() == "+" {
+			return common.ConcatenateChildStrings(node, detectorContext)
+		}
+	case "assignment":
+		if node.Children()[1].Content() == "+=" {
+			return concatenateAssignEquals(node, detectorContext)
+		}
+	}
+
+	return nil, nil
+}
+
+// handleTemplateString returns the value of a string, including any
+// interpolated identifiers and expressions. The quotes aren't part of the
+// value as they are anonymous children
+func handleTemplateString(node *tree.Node, detectorContext types.Context) ([]interface{}, error) {
+	text := ""
+	isLiteral := true
+
+	err := node.EachContentPart(func(partText string) error {
+		text += partText
+		return nil
+	}, func(child *tree.Node) error {
+		if child.Type() == "character_escape_seq" {
+			text += child.Content()
+			return nil
+		}
+
+		childValue, childIsLiteral, err := common.GetStringValue(child, detectorContext)
+		if err != nil {
+			return err
+		}
+
+		if childValue == "" && !childIsLiteral {
+			childValue = common.NonLiteralValue
+		}
+
+		text += childValue
+
+		if !childIsLiteral {
+			isLiteral = false
+		}
+
+		return nil
+	})
+
+	return []interface{}{common.String{
+		Value:     text,
+		IsLiteral: isLiteral,
+	}}, err
+}
+
+// concatenateAssignEquals is the equivalent of common.ConcatenateAssignEquals,
+// as the Kotlin grammar doesn't have field names for the assignment operands
+func concatenateAssignEquals(node *tree.Node, detectorContext types.Context) ([]interface{}, error) {
+	namedChildren := node.NamedChildren()
+	target := namedChildren[0].NamedChildren()
+	if len(target) != 1 {
+		return nil, nil
+	}
+
+	left, leftIsLiteral, err := common.GetStringValue(target[0], detectorContext)
+	if err != nil {
+		return nil, err
+	}
+
+	right, rightIsLiteral, err := common.GetStringValue(namedChildren[len(namedChildren)-1], detectorContext)
+	if err != nil {
+		return nil, err
+	}
+
+	if left == "" && !leftIsLiteral {
+		left = common.NonLiteralValue
+
+		// No detection when neither parts are a string
+		if right == "" && !rightIsLiteral {
+			return nil, nil
+		}
+	}
+
+	if right == "" && !rightIsLiteral {
+		right = common.NonLiteralValue
+	}
+
+	return []interface{}{common.String{
+		Value:     left + right,
+		IsLiteral: leftIsLiteral && rightIsLiteral,
+	}}, nil
+}
@@ -0,0 +1,7 @@
+data class User(val name: String) {
+    val email: String = ""
+
+    fun lowercaseName(): String {
+        return name.lowercase()
+    }
+}
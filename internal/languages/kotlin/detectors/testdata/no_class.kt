@@ -0,0 +1 @@
+user.name
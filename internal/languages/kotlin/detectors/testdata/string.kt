@@ -0,0 +1,12 @@
+const val GREETING = "Hello World"
+
+fun main(args: Array<String>) {
+    var s = GREETING + "!"
+    s += "!!"
+
+    var s2 = "hey "
+    s2 += args[0]
+    s2 += " there"
+
+    val s3 = "$GREETING, ${args[0]}\n"
+}
@@ -0,0 +1,43 @@
+package kotlin_test
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/languages/kotlin"
+	"github.com/bearer/bearer/internal/languages/testhelper"
+	patternquerybuilder "github.com/bearer/bearer/internal/scanner/detectors/customrule/patternquery/builder"
+)
+
+//go:embed testdata/logger.yml
+var loggerRule []byte
+
+//go:embed testdata/scope_rule.yml
+var scopeRule []byte
+
+func TestFlow(t *testing.T) {
+	testhelper.GetRunner(t, loggerRule, "Kotlin").RunTest(t, "./testdata/testcases/flow", ".snapshots/flow/")
+}
+
+func TestScope(t *testing.T) {
+	testhelper.GetRunner(t, scopeRule, "Kotlin").RunTest(t, "./testdata/scope", ".snapshots/")
+}
+
+func TestPattern(t *testing.T) {
+	for _, test := range []struct{ name, pattern string }{
+		{"call arguments is a container type", `
+				foo($<!>$<_>)
+		`},
+	} {
+		t.Run(test.name, func(tt *testing.T) {
+			result, err := patternquerybuilder.Build(kotlin.Get(), test.pattern, "")
+			if err != nil {
+				tt.Fatalf("failed to build pattern: %s", err)
+			}
+
+			cupaloy.SnapshotT(tt, result)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/language"
+	"github.com/bearer/bearer/internal/util/regex"
+)
+
+var (
+	// $<name:type> or $<name:type1|type2> or $<name>
+	queryVariableRegex = regexp.MustCompile(`\$<(?P<name>[^>:!\.]+)(?::(?P<types>[^>]+))?>`)
+	matchNodeRegex     = regexp.MustCompile(`\$<!>`)
+	ellipsisRegex      = regexp.MustCompile(`\$<\.\.\.>`)
+
+	matchNodeContainerTypes = []string{"value_arguments"}
+
+	allowedQueryTypes = []string{
+		"_",
+		"simple_identifier",
+		"type_identifier",
+		"navigation_expression",
+		"call_expression",
+		"line_string_literal",
+	}
+
+	// the operators of these expressions are matched, so that eg. `a == b` doesn't
+	// match `a != b`
+	anonymousParentTypes = []string{
+		"additive_expression",
+		"assignment",
+		"comparison_expression",
+		"conjunction_expression",
+		"disjunction_expression",
+		"equality_expression",
+		"multiplicative_expression",
+		"prefix_expression",
+	}
+)
+
+type Pattern struct {
+	language.PatternBase
+}
+
+func (*Pattern) ExtractVariables(input string) (string, []language.PatternVariable, error) {
+	nameIndex := queryVariableRegex.SubexpIndex("name")
+	typesIndex := queryVariableRegex.SubexpIndex("types")
+	i := 0
+
+	var params []language.PatternVariable
+
+	replaced, err := regex.ReplaceAllWithSubmatches(queryVariableRegex, input, func(submatches []string) (string, error) {
+		nodeTypes := strings.Split(submatches[typesIndex], "|")
+		if nodeTypes[0] == "" {
+			nodeTypes = []string{"_"}
+		}
+
+		for _, nodeType := range nodeTypes {
+			if !slices.Contains(allowedQueryTypes, nodeType) {
+				return "", fmt.Errorf("invalid node type '%s' in pattern query", nodeType)
+			}
+		}
+
+		dummyValue := produceDummyValue(i)
+
+		params = append(params, language.PatternVariable{
+			Name:       submatches[nameIndex],
+			NodeTypes:  nodeTypes,
+			DummyValue: dummyValue,
+		})
+
+		i += 1
+
+		return dummyValue, nil
+	})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return replaced, params, nil
+}
+
+func produceDummyValue(i int) string {
+	return "BearerVar" + fmt.Sprint(i)
+}
+
+func (*Pattern) FindMatchNode(input []byte) [][]int {
+	return matchNodeRegex.FindAllIndex(input, -1)
+}
+
+func (*Pattern) FindUnanchoredPoints(input []byte) [][]int {
+	return ellipsisRegex.FindAllIndex(input, -1)
+}
+
+func (*Pattern) LeafContentTypes() []string {
+	return []string{
+		// identifiers
+		"simple_identifier", "type_identifier",
+		// modifiers
+		"visibility_modifier", "inheritance_modifier", "member_modifier", "function_modifier", "property_modifier",
+		// datatypes/literals
+		"line_string_literal", "multi_line_string_literal", "character_literal", "integer_literal", "long_literal",
+		"hex_literal", "bin_literal", "real_literal", "boolean_literal", "null_literal",
+	}
+}
+
+func (*Pattern) IsAnchored(node *tree.Node) (bool, bool) {
+	parent := node.Parent()
+	if parent == nil {
+		return true, true
+	}
+
+	// statements of a file, function or lambda
+	// class body
+	// modifiers and annotations
+	unAnchored := []string{"statements", "source_file", "class_body", "function_body", "lambda_literal", "modifiers"}
+
+	isAnchored := !slices.Contains(unAnchored, parent.Type())
+	return isAnchored, isAnchored
+}
+
+func (*Pattern) IsRoot(node *tree.Node) bool {
+	return !slices.Contains([]string{"source_file", "statements"}, node.Type()) && !node.IsMissing()
+}
+
+func (*Pattern) AnonymousParentTypes() []string {
+	return anonymousParentTypes
+}
+
+func (*Pattern) NodeTypes(node *tree.Node) []string {
+	return []string{node.Type()}
+}
+
+func (*Pattern) ContainerTypes() []string {
+	return matchNodeContainerTypes
+}
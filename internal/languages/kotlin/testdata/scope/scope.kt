@@ -0,0 +1,14 @@
+scopeCursor(call.receiveParameters())
+scopeCursor(x + call.receiveParameters())
+scopeCursor(if (x) call.receiveParameters() else y)
+scopeCursor(if (call.receiveParameters()) x else y)
+
+scopeNested(call.receiveParameters())
+scopeNested(x + call.receiveParameters())
+scopeNested(if (x) call.receiveParameters() else y)
+scopeNested(if (call.receiveParameters()) x else y)
+
+scopeResult(call.receiveParameters())
+scopeResult(x + call.receiveParameters())
+scopeResult(if (x) call.receiveParameters() else y)
+scopeResult(if (call.receiveParameters()) x else y)
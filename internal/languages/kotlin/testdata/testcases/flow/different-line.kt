@@ -0,0 +1,4 @@
+fun main(user: User) {
+    val name = user.name
+    logger.error(name)
+}
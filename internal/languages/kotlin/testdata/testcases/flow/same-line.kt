@@ -0,0 +1,3 @@
+fun main(user: User) {
+    logger.error(user.name)
+}
@@ -20,6 +20,19 @@ func New(builder *tree.Builder) language.Analyzer {
 }
 
 func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
+	switch node.Type() {
+	case "function_definition", "method_declaration":
+		analyzer.builder.AddFunction(
+			node.ChildByFieldName("name"),
+			analyzer.positionalParameters(node.ChildByFieldName("parameters")),
+		)
+	case "scoped_call_expression":
+		analyzer.builder.AddCall(
+			node.ChildByFieldName("name"),
+			analyzer.positionalArguments(node.ChildByFieldName("arguments")),
+		)
+	}
+
 	switch node.Type() {
 	case "declaration_list", "class_declaration", "anonymous_function_creation_expression", "for_statement", "block", "method_declaration":
 		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
@@ -29,6 +42,8 @@ func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error)
 		return analyzer.analyzeAugmentedAssignment(node, visitChildren)
 	case "assignment_expression":
 		return analyzer.analyzeAssignment(node, visitChildren)
+	case "const_element":
+		return analyzer.analyzeConstElement(node, visitChildren)
 	case "parenthesized_expression":
 		return analyzer.analyzeParentheses(node, visitChildren)
 	case "conditional_expression":
@@ -96,6 +111,24 @@ func (analyzer *analyzer) analyzeAssignment(node *sitter.Node, visitChildren fun
 	return err
 }
 
+// const FOO = a;
+func (analyzer *analyzer) analyzeConstElement(node *sitter.Node, visitChildren func() error) error {
+	name := node.NamedChild(0)
+	value := node.NamedChild(1)
+	if value != nil {
+		analyzer.builder.Alias(node, value)
+		analyzer.lookupVariable(value)
+	}
+
+	err := visitChildren()
+
+	if name != nil && name.Type() == "name" {
+		analyzer.scope.Declare(analyzer.builder.ContentFor(name), node)
+	}
+
+	return err
+}
+
 // $foo .= a
 func (analyzer *analyzer) analyzeAugmentedAssignment(node *sitter.Node, visitChildren func() error) error {
 	left := node.ChildByFieldName("left")
@@ -147,13 +180,74 @@ func (analyzer *analyzer) analyzeMethodInvocation(node *sitter.Node, visitChildr
 	analyzer.lookupVariable(node.ChildByFieldName("object"))   // method
 	analyzer.lookupVariable(node.ChildByFieldName("function")) // function
 
-	if arguments := node.ChildByFieldName("arguments"); arguments != nil {
+	arguments := node.ChildByFieldName("arguments")
+	if arguments != nil {
 		analyzer.builder.Dataflow(node, arguments)
 	}
 
+	nameNode := node.ChildByFieldName("name")
+	if nameNode == nil {
+		nameNode = node.ChildByFieldName("function")
+	}
+
+	if nameNode != nil && nameNode.Type() == "name" {
+		analyzer.builder.AddCall(nameNode, analyzer.positionalArguments(arguments))
+	}
+
 	return visitChildren()
 }
 
+// the names of the parameters of a function which are passed by position.
+// Variadic parameters can't be matched to arguments by position.
+func (analyzer *analyzer) positionalParameters(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var parameters []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		switch child.Type() {
+		case "comment":
+		case "simple_parameter", "property_promotion_parameter":
+			parameters = append(parameters, child.ChildByFieldName("name"))
+		default:
+			return parameters
+		}
+	}
+
+	return parameters
+}
+
+// the values of the arguments of a call which are passed by position.
+// Arguments after named or unpacked ones can't be matched to parameters by
+// position.
+func (analyzer *analyzer) positionalArguments(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var arguments []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() == "comment" {
+			continue
+		}
+
+		if child.Type() != "argument" ||
+			child.ChildByFieldName("name") != nil ||
+			child.Child(0).Type() == "..." ||
+			child.NamedChildCount() != 1 {
+			return arguments
+		}
+
+		arguments = append(arguments, child.NamedChild(0))
+	}
+
+	return arguments
+}
+
 // foo->bar
 func (analyzer *analyzer) analyzeFieldAccess(node *sitter.Node, visitChildren func() error) error {
 	analyzer.lookupVariable(node.ChildByFieldName("object"))
@@ -257,12 +351,29 @@ func (analyzer *analyzer) withScope(newScope *language.Scope, body func() error)
 	return err
 }
 
+// lookupVariable aliases variables, and constants, to their values. Constant
+// names don't start with `$`, so they can't clash with variables.
 func (analyzer *analyzer) lookupVariable(node *sitter.Node) {
-	if node == nil || node.Type() != "variable_name" {
+	if node == nil {
+		return
+	}
+
+	var name string
+	switch node.Type() {
+	case "variable_name", "name":
+		name = analyzer.builder.ContentFor(node)
+	case "class_constant_access_expression": // self::FOO
+		scope := node.NamedChild(0)
+		if scope == nil || scope.Type() != "relative_scope" || analyzer.builder.ContentFor(scope) == "parent" {
+			return
+		}
+
+		name = analyzer.builder.ContentFor(node.NamedChild(1))
+	default:
 		return
 	}
 
-	if pointsToNode := analyzer.scope.Lookup(analyzer.builder.ContentFor(node)); pointsToNode != nil {
+	if pointsToNode := analyzer.scope.Lookup(name); pointsToNode != nil {
 		analyzer.builder.Alias(node, pointsToNode)
 	}
 }
@@ -58,7 +58,7 @@ func TestAnalyzer(t *testing.T) {
 				tt.Fatalf("failed to compile query set: %s", err)
 			}
 
-			result, err := ast.ParseAndAnalyze(context.Background(), language, ruleSet, querySet, []byte(test.code))
+			result, err := ast.ParseAndAnalyze(context.Background(), language, ruleSet, querySet, nil, nil, []byte(test.code))
 			if err != nil {
 				tt.Fatalf("failed to parse example: %s", err)
 			}
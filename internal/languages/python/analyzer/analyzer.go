@@ -21,7 +21,9 @@ func New(builder *tree.Builder) language.Analyzer {
 
 func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
 	switch node.Type() {
-	case "class_definition", "block", "function_definition":
+	case "function_definition":
+		return analyzer.analyzeFunction(node, visitChildren)
+	case "class_definition", "block":
 		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
 			return visitChildren()
 		})
@@ -37,7 +39,7 @@ func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error)
 		return analyzer.analyzeCall(node, visitChildren)
 	case "argument_list":
 		return analyzer.analyzeGenericOperation(node, visitChildren)
-	case "expression_statement":
+	case "expression_statement", "binary_operator":
 		return analyzer.analyzeGenericOperation(node, visitChildren)
 	case "while_statement", "try_statement", "if_statement": // statements don't have results
 		return visitChildren()
@@ -94,13 +96,101 @@ func (analyzer *analyzer) analyzeCall(node *sitter.Node, visitChildren func() er
 		analyzer.builder.Dataflow(node, receiver)
 	}
 
-	if argumentsNode := node.ChildByFieldName("arguments"); argumentsNode != nil {
+	argumentsNode := node.ChildByFieldName("arguments")
+	if argumentsNode != nil {
 		analyzer.builder.Dataflow(node, argumentsNode)
 	}
 
+	switch function := node.ChildByFieldName("function"); function.Type() {
+	case "identifier":
+		analyzer.builder.AddCall(function, analyzer.positionalArguments(argumentsNode))
+	case "attribute":
+		analyzer.builder.AddCall(function.ChildByFieldName("attribute"), analyzer.positionalArguments(argumentsNode))
+	}
+
 	return visitChildren()
 }
 
+// def foo(a, b=1):
+func (analyzer *analyzer) analyzeFunction(node *sitter.Node, visitChildren func() error) error {
+	parameters := analyzer.positionalParameters(node.ChildByFieldName("parameters"))
+	analyzer.builder.AddFunction(node.ChildByFieldName("name"), parameters)
+
+	return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+		for _, parameter := range parameters {
+			analyzer.scope.Declare(analyzer.builder.ContentFor(parameter), parameter)
+		}
+
+		return visitChildren()
+	})
+}
+
+// the names of the parameters of a function which are passed by position.
+// Parameters after splats can't be matched to arguments by position, and
+// the `self` or `cls` parameter of methods isn't passed explicitly.
+func (analyzer *analyzer) positionalParameters(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var parameters []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		var name *sitter.Node
+		switch child.Type() {
+		case "comment":
+			continue
+		case "identifier":
+			name = child
+		case "default_parameter", "typed_default_parameter":
+			name = child.ChildByFieldName("name")
+		case "typed_parameter":
+			name = child.NamedChild(0)
+		default:
+			return parameters
+		}
+
+		if name == nil || name.Type() != "identifier" {
+			return parameters
+		}
+
+		if i == 0 {
+			if content := analyzer.builder.ContentFor(name); content == "self" || content == "cls" {
+				continue
+			}
+		}
+
+		parameters = append(parameters, name)
+	}
+
+	return parameters
+}
+
+// the arguments of a call which are passed by position. Arguments after
+// keywords and splats can't be matched to parameters by position.
+func (analyzer *analyzer) positionalArguments(node *sitter.Node) []*sitter.Node {
+	if node == nil || node.Type() != "argument_list" {
+		return nil
+	}
+
+	var arguments []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		switch child.Type() {
+		case "comment":
+			continue
+		case "keyword_argument", "list_splat", "dictionary_splat":
+			return arguments
+		}
+
+		arguments = append(arguments, child)
+	}
+
+	return arguments
+}
+
 // foo.bar
 func (analyzer *analyzer) analyzeAttribute(node *sitter.Node, visitChildren func() error) error {
 	if receiver := node.ChildByFieldName("object"); receiver != nil {
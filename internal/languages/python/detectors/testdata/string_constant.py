@@ -0,0 +1,6 @@
+PREFIX = "Hello"
+GREETING = PREFIX + " World"
+
+
+def main():
+    return GREETING + "!"
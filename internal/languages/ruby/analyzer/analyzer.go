@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"slices"
+	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
 
@@ -76,25 +77,42 @@ var reflexiveMethods = []string{
 type analyzer struct {
 	builder *tree.Builder
 	scope   *language.Scope
+	// constants are visible in the methods of the classes and modules they are
+	// assigned in, unlike local variables
+	constants *language.Scope
 }
 
 func New(builder *tree.Builder) language.Analyzer {
 	return &analyzer{
-		builder: builder,
-		scope:   language.NewScope(nil),
+		builder:   builder,
+		scope:     language.NewScope(nil),
+		constants: language.NewScope(nil),
 	}
 }
 
 func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
 	switch node.Type() {
 	case "method":
+		analyzer.addFunction(node)
+
 		return analyzer.withScope(language.NewScope(nil), func() error {
 			return visitChildren()
 		})
+	case "singleton_method":
+		analyzer.addFunction(node)
+		analyzer.builder.Dataflow(node, analyzer.builder.ChildrenFor(node)...)
+
+		return visitChildren()
 	case "block", "do_block":
 		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
 			return visitChildren()
 		})
+	case "class", "module":
+		analyzer.builder.Dataflow(node, analyzer.builder.ChildrenFor(node)...)
+
+		return analyzer.withConstantScope(func() error {
+			return visitChildren()
+		})
 	case "assignment":
 		return analyzer.analyzeAssignment(node, visitChildren)
 	case "operator_assignment":
@@ -117,6 +135,12 @@ func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error)
 		return analyzer.analyzeParentheses(node, visitChildren)
 	case "conditional":
 		return analyzer.analyzeConditional(node, visitChildren)
+	case "heredoc_beginning":
+		if body := analyzer.heredocBody(node); body != nil {
+			analyzer.builder.Alias(node, body)
+		}
+
+		return visitChildren()
 	case "pair", "argument_list", "interpolation", "array", "binary", "unary":
 		return analyzer.analyzeGenericOperation(node, visitChildren)
 	default:
@@ -134,8 +158,11 @@ func (analyzer *analyzer) analyzeAssignment(node *sitter.Node, visitChildren fun
 
 	err := visitChildren()
 
-	if left.Type() == "identifier" {
+	switch left.Type() {
+	case "identifier":
 		analyzer.scope.Assign(analyzer.builder.ContentFor(left), node)
+	case "constant":
+		analyzer.constants.Declare(analyzer.builder.ContentFor(left), node)
 	}
 
 	return err
@@ -168,13 +195,75 @@ func (analyzer *analyzer) analyzeCall(node *sitter.Node, visitChildren func() er
 		}
 	}
 
-	if argumentsNode := node.ChildByFieldName("arguments"); argumentsNode != nil {
+	argumentsNode := node.ChildByFieldName("arguments")
+	if argumentsNode != nil {
 		analyzer.builder.Dataflow(node, argumentsNode)
 	}
 
+	if methodNode := node.ChildByFieldName("method"); methodNode != nil {
+		analyzer.builder.AddCall(methodNode, analyzer.positionalArguments(argumentsNode))
+	}
+
 	return visitChildren()
 }
 
+// def m(a, b = 1)
+// def self.m(a)
+func (analyzer *analyzer) addFunction(node *sitter.Node) {
+	analyzer.builder.AddFunction(
+		node.ChildByFieldName("name"),
+		analyzer.positionalParameters(node.ChildByFieldName("parameters")),
+	)
+}
+
+// the parameters of a method which are passed by position. Parameters after
+// splats and keywords can't be matched to arguments by position.
+func (analyzer *analyzer) positionalParameters(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var parameters []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		switch child.Type() {
+		case "comment":
+		case "identifier":
+			parameters = append(parameters, child)
+		case "optional_parameter":
+			parameters = append(parameters, child.ChildByFieldName("name"))
+		default:
+			return parameters
+		}
+	}
+
+	return parameters
+}
+
+// the arguments of a call which are passed by position
+func (analyzer *analyzer) positionalArguments(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var arguments []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		switch child.Type() {
+		case "comment":
+			continue
+		case "pair", "splat_argument", "hash_splat_argument", "block_argument":
+			return arguments
+		}
+
+		arguments = append(arguments, child)
+	}
+
+	return arguments
+}
+
 // foo["bar"]
 func (analyzer *analyzer) analyzeElementReference(node *sitter.Node, visitChildren func() error) error {
 	objectNode := node.ChildByFieldName("object")
@@ -270,6 +359,27 @@ func (analyzer *analyzer) analyzeParentheses(node *sitter.Node, visitChildren fu
 	return visitChildren()
 }
 
+// the body of a heredoc follows the statement it begins in, and is the first
+// one ending with its delimiter
+//
+//	query = <<~SQL
+//	  SELECT 1
+//	SQL
+func (analyzer *analyzer) heredocBody(beginning *sitter.Node) *sitter.Node {
+	delimiter := strings.Trim(strings.TrimLeft(analyzer.builder.ContentFor(beginning), "<~-"), `"'`+"`")
+
+	for node := beginning; node != nil; node = node.Parent() {
+		for body := node.NextNamedSibling(); body != nil && body.Type() == "heredoc_body"; body = body.NextNamedSibling() {
+			end := body.NamedChild(int(body.NamedChildCount()) - 1)
+			if end != nil && end.Type() == "heredoc_end" && strings.TrimSpace(analyzer.builder.ContentFor(end)) == delimiter {
+				return body
+			}
+		}
+	}
+
+	return nil
+}
+
 // foo ? x : y
 func (analyzer *analyzer) analyzeConditional(node *sitter.Node, visitChildren func() error) error {
 	condition := node.ChildByFieldName("condition")
@@ -307,12 +417,30 @@ func (analyzer *analyzer) withScope(newScope *language.Scope, body func() error)
 	return err
 }
 
+func (analyzer *analyzer) withConstantScope(body func() error) error {
+	oldConstants := analyzer.constants
+
+	analyzer.constants = language.NewScope(oldConstants)
+	err := body()
+	analyzer.constants = oldConstants
+
+	return err
+}
+
 func (analyzer *analyzer) lookupVariable(node *sitter.Node) {
-	if node == nil || node.Type() != "identifier" {
+	if node == nil {
 		return
 	}
 
-	if pointsToNode := analyzer.scope.Lookup(analyzer.builder.ContentFor(node)); pointsToNode != nil {
+	var pointsToNode *sitter.Node
+	switch node.Type() {
+	case "identifier":
+		pointsToNode = analyzer.scope.Lookup(analyzer.builder.ContentFor(node))
+	case "constant":
+		pointsToNode = analyzer.constants.Lookup(analyzer.builder.ContentFor(node))
+	}
+
+	if pointsToNode != nil {
 		analyzer.builder.Alias(node, pointsToNode)
 	}
 }
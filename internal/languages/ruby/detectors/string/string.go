@@ -33,6 +33,13 @@ func (detector *stringDetector) DetectAt(
 		}}, nil
 	case "interpolation", "string":
 		return common.ConcatenateChildStrings(node, detectorContext)
+	case "heredoc_content":
+		return []interface{}{common.String{
+			Value:     node.Content(),
+			IsLiteral: true,
+		}}, nil
+	case "heredoc_body":
+		return concatenateHeredocBody(node, detectorContext)
 	case "binary":
 		if node.Children()[1].Content() == "+" {
 			return common.ConcatenateChildStrings(node, detectorContext)
@@ -45,3 +52,36 @@ func (detector *stringDetector) DetectAt(
 
 	return nil, nil
 }
+
+// concatenateHeredocBody returns the string of a heredoc's content, leaving
+// out the delimiter ending it
+func concatenateHeredocBody(node *tree.Node, detectorContext types.Context) ([]interface{}, error) {
+	value := ""
+	isLiteral := true
+
+	for _, child := range node.NamedChildren() {
+		if child.Type() == "heredoc_end" {
+			continue
+		}
+
+		childValue, childIsLiteral, err := common.GetStringValue(child, detectorContext)
+		if err != nil {
+			return nil, err
+		}
+
+		if childValue == "" && !childIsLiteral {
+			childValue = common.NonLiteralValue
+		}
+
+		value += childValue
+
+		if !childIsLiteral {
+			isLiteral = false
+		}
+	}
+
+	return []interface{}{common.String{
+		Value:     value,
+		IsLiteral: isLiteral,
+	}}, nil
+}
@@ -0,0 +1,9 @@
+PREFIX = "Hello"
+
+class Greet
+  GREETING = PREFIX + " World"
+
+  def main
+    GREETING + "!"
+  end
+end
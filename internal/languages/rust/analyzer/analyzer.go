@@ -0,0 +1,521 @@
+package analyzer
+
+import (
+	"slices"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/language"
+)
+
+// methods that use the receiver in their result
+var reflexiveMethods = []string{
+	// Clone/ToString/Into
+	"clone",
+	"into",
+	"to_owned",
+	"to_string",
+	// Option/Result
+	"expect",
+	"unwrap",
+	"unwrap_or",
+	"unwrap_or_default",
+	// str/String
+	"as_bytes",
+	"as_str",
+	"replace",
+	"split",
+	"to_lowercase",
+	"to_uppercase",
+	"trim",
+	"trim_end",
+	"trim_start",
+	// AsRef/Borrow
+	"as_ref",
+	"borrow",
+}
+
+type analyzer struct {
+	builder *tree.Builder
+	scope   *language.Scope
+}
+
+func New(builder *tree.Builder) language.Analyzer {
+	return &analyzer{
+		builder: builder,
+		scope:   language.NewScope(nil),
+	}
+}
+
+func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
+	switch node.Type() {
+	case "function_item":
+		return analyzer.analyzeFunction(node, visitChildren)
+	case "impl_item", "trait_item", "mod_item", "closure_expression":
+		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+			return visitChildren()
+		})
+	case "block":
+		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+			return analyzer.analyzeBlock(node, visitChildren)
+		})
+	case "let_declaration", "let_condition":
+		return analyzer.analyzeLet(node, visitChildren)
+	case "assignment_expression":
+		return analyzer.analyzeAssignment(node, visitChildren)
+	case "compound_assignment_expr":
+		return analyzer.analyzeCompoundAssignment(node, visitChildren)
+	case "call_expression":
+		return analyzer.analyzeCall(node, visitChildren)
+	case "macro_invocation":
+		return analyzer.analyzeMacro(node, visitChildren)
+	case "field_expression":
+		return analyzer.analyzeFieldExpression(node, visitChildren)
+	case "parameter":
+		return analyzer.analyzeParameter(node, visitChildren)
+	case "closure_parameters":
+		return analyzer.analyzeClosureParameters(node, visitChildren)
+	case "for_expression":
+		return analyzer.analyzeFor(node, visitChildren)
+	case "if_expression":
+		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+			return analyzer.analyzeIf(node, visitChildren)
+		})
+	case "match_expression":
+		return analyzer.analyzeMatch(node, visitChildren)
+	case "match_arm":
+		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+			return analyzer.analyzeMatchArm(node, visitChildren)
+		})
+	case "parenthesized_expression",
+		"reference_expression",
+		"try_expression",
+		"await_expression",
+		"else_clause",
+		"async_block",
+		"unsafe_block",
+		"field_initializer",
+		"shorthand_field_initializer":
+		return analyzer.analyzeWrapper(node, visitChildren)
+	case "arguments",
+		"token_tree",
+		"binary_expression",
+		"unary_expression",
+		"index_expression",
+		"array_expression",
+		"tuple_expression",
+		"type_cast_expression",
+		"range_expression":
+		return analyzer.analyzeGenericOperation(node, visitChildren)
+	case "while_expression": // statements don't have results
+		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+			return visitChildren()
+		})
+	case "loop_expression":
+		return visitChildren()
+	default:
+		analyzer.builder.Dataflow(node, analyzer.builder.ChildrenFor(node)...)
+		return visitChildren()
+	}
+}
+
+// fn foo(a: String, b: i32) {}
+func (analyzer *analyzer) analyzeFunction(node *sitter.Node, visitChildren func() error) error {
+	if name := node.ChildByFieldName("name"); name != nil {
+		analyzer.builder.AddFunction(name, analyzer.positionalParameters(node.ChildByFieldName("parameters")))
+	}
+
+	return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+		return visitChildren()
+	})
+}
+
+// { let a = 1; a }
+func (analyzer *analyzer) analyzeBlock(node *sitter.Node, visitChildren func() error) error {
+	if result := analyzer.blockResult(node); result != nil {
+		analyzer.builder.Alias(node, result)
+		analyzer.lookupVariable(result)
+	}
+
+	return visitChildren()
+}
+
+// let foo = a;
+// let (a, b) = foo;
+// if let Some(foo) = a {}
+func (analyzer *analyzer) analyzeLet(node *sitter.Node, visitChildren func() error) error {
+	pattern := node.ChildByFieldName("pattern")
+	value := node.ChildByFieldName("value")
+	analyzer.lookupVariable(value)
+
+	if pattern != nil && value != nil {
+		if pattern.Type() == "identifier" {
+			analyzer.builder.Alias(pattern, value)
+		} else {
+			for _, name := range analyzer.patternIdentifiers(pattern) {
+				analyzer.builder.Dataflow(name, value)
+			}
+		}
+	}
+
+	err := visitChildren()
+
+	if pattern != nil {
+		analyzer.declarePattern(pattern)
+	}
+
+	return err
+}
+
+// foo = a
+func (analyzer *analyzer) analyzeAssignment(node *sitter.Node, visitChildren func() error) error {
+	left := node.ChildByFieldName("left")
+	right := node.ChildByFieldName("right")
+	analyzer.builder.Alias(node, right)
+	analyzer.lookupVariable(right)
+
+	err := visitChildren()
+
+	if left != nil && left.Type() == "identifier" {
+		analyzer.scope.Assign(analyzer.builder.ContentFor(left), node)
+	}
+
+	return err
+}
+
+// foo += a
+func (analyzer *analyzer) analyzeCompoundAssignment(node *sitter.Node, visitChildren func() error) error {
+	left := node.ChildByFieldName("left")
+	right := node.ChildByFieldName("right")
+	analyzer.builder.Dataflow(node, left, right)
+	analyzer.lookupVariable(left)
+	analyzer.lookupVariable(right)
+
+	err := visitChildren()
+
+	if left != nil && left.Type() == "identifier" {
+		analyzer.scope.Assign(analyzer.builder.ContentFor(left), node)
+	}
+
+	return err
+}
+
+// foo(1, 2)
+// foo::bar(1, 2)
+// foo.bar(1, 2)
+func (analyzer *analyzer) analyzeCall(node *sitter.Node, visitChildren func() error) error {
+	function := node.ChildByFieldName("function")
+	analyzer.lookupVariable(function)
+
+	var name *sitter.Node
+	switch function.Type() {
+	case "identifier":
+		name = function
+	case "scoped_identifier":
+		name = function.ChildByFieldName("name")
+	case "field_expression":
+		name = function.ChildByFieldName("field")
+
+		if slices.Contains(reflexiveMethods, analyzer.builder.ContentFor(name)) {
+			analyzer.builder.Dataflow(node, function.ChildByFieldName("value"))
+		}
+	}
+
+	arguments := node.ChildByFieldName("arguments")
+	if arguments != nil {
+		analyzer.builder.Dataflow(node, arguments)
+	}
+
+	if name != nil {
+		analyzer.builder.AddCall(name, analyzer.positionalArguments(arguments))
+	}
+
+	return visitChildren()
+}
+
+// format!("{}", a)
+func (analyzer *analyzer) analyzeMacro(node *sitter.Node, visitChildren func() error) error {
+	if tokens := analyzer.lastChildOfType(node, "token_tree"); tokens != nil {
+		analyzer.builder.Dataflow(node, tokens)
+	}
+
+	return visitChildren()
+}
+
+// foo.bar
+func (analyzer *analyzer) analyzeFieldExpression(node *sitter.Node, visitChildren func() error) error {
+	analyzer.lookupVariable(node.ChildByFieldName("value"))
+
+	return visitChildren()
+}
+
+// fn m(foo: String) {}
+// fn m(Query(foo): Query<Params>) {}
+// |foo: String| {}
+func (analyzer *analyzer) analyzeParameter(node *sitter.Node, visitChildren func() error) error {
+	pattern := node.ChildByFieldName("pattern")
+	if pattern == nil {
+		return visitChildren()
+	}
+
+	// rules match the parameter as a whole, eg. `query: web::Query<Params>`
+	for _, name := range analyzer.patternIdentifiers(pattern) {
+		analyzer.builder.Alias(name, node)
+		analyzer.scope.Declare(analyzer.builder.ContentFor(name), name)
+	}
+
+	return visitChildren()
+}
+
+// |a, b| {}
+func (analyzer *analyzer) analyzeClosureParameters(node *sitter.Node, visitChildren func() error) error {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if child := node.NamedChild(i); child.Type() != "parameter" {
+			analyzer.declarePattern(child)
+		}
+	}
+
+	return visitChildren()
+}
+
+// for item in items {}
+func (analyzer *analyzer) analyzeFor(node *sitter.Node, visitChildren func() error) error {
+	return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+		value := node.ChildByFieldName("value")
+		analyzer.lookupVariable(value)
+
+		if pattern := node.ChildByFieldName("pattern"); pattern != nil {
+			for _, name := range analyzer.patternIdentifiers(pattern) {
+				analyzer.builder.Dataflow(name, value)
+			}
+
+			analyzer.declarePattern(pattern)
+		}
+
+		return visitChildren()
+	})
+}
+
+// if x { a } else { b }
+func (analyzer *analyzer) analyzeIf(node *sitter.Node, visitChildren func() error) error {
+	analyzer.lookupVariable(node.ChildByFieldName("condition"))
+
+	analyzer.builder.Alias(
+		node,
+		node.ChildByFieldName("consequence"),
+		node.ChildByFieldName("alternative"),
+	)
+
+	return visitChildren()
+}
+
+//	match x {
+//	  Some(a) => a,
+//	  _ => b,
+//	}
+func (analyzer *analyzer) analyzeMatch(node *sitter.Node, visitChildren func() error) error {
+	analyzer.lookupVariable(node.ChildByFieldName("value"))
+
+	var arms []*sitter.Node
+	if body := node.ChildByFieldName("body"); body != nil {
+		for i := 0; i < int(body.NamedChildCount()); i++ {
+			if child := body.NamedChild(i); child.Type() == "match_arm" {
+				arms = append(arms, child)
+			}
+		}
+	}
+
+	analyzer.builder.Alias(node, arms...)
+
+	return visitChildren()
+}
+
+// Some(a) => a
+func (analyzer *analyzer) analyzeMatchArm(node *sitter.Node, visitChildren func() error) error {
+	value := node.ChildByFieldName("value")
+	analyzer.builder.Alias(node, value)
+
+	if pattern := node.ChildByFieldName("pattern"); pattern != nil {
+		var matchValue *sitter.Node
+		if body := node.Parent(); body != nil && body.Parent() != nil {
+			matchValue = body.Parent().ChildByFieldName("value")
+		}
+
+		for _, name := range analyzer.patternIdentifiers(pattern) {
+			analyzer.builder.Dataflow(name, matchValue)
+		}
+
+		analyzer.declarePattern(pattern)
+	}
+
+	analyzer.lookupVariable(value)
+
+	return visitChildren()
+}
+
+// nodes which result in the value of their last child, eg.
+//
+//	(a)
+//	&a
+//	a?
+//	a.await
+//	else { a }
+func (analyzer *analyzer) analyzeWrapper(node *sitter.Node, visitChildren func() error) error {
+	if count := int(node.NamedChildCount()); count != 0 {
+		child := node.NamedChild(count - 1)
+		analyzer.builder.Alias(node, child)
+		analyzer.lookupVariable(child)
+	}
+
+	return visitChildren()
+}
+
+// default analysis, where the children are assumed to be data sources
+func (analyzer *analyzer) analyzeGenericOperation(node *sitter.Node, visitChildren func() error) error {
+	children := analyzer.builder.ChildrenFor(node)
+	analyzer.builder.Dataflow(node, children...)
+
+	for _, child := range children {
+		analyzer.lookupVariable(child)
+	}
+
+	return visitChildren()
+}
+
+// the parameters of a function by position. The receiver (self) isn't a
+// positional parameter
+func (analyzer *analyzer) positionalParameters(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var parameters []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() != "parameter" {
+			continue
+		}
+
+		parameters = append(parameters, child.ChildByFieldName("pattern"))
+	}
+
+	return parameters
+}
+
+// the arguments of a call by position
+func (analyzer *analyzer) positionalArguments(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	var arguments []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if isComment(child) {
+			continue
+		}
+
+		arguments = append(arguments, child)
+	}
+
+	return arguments
+}
+
+// the expression a block results in, which is its last expression when it
+// isn't terminated by a semicolon
+func (analyzer *analyzer) blockResult(node *sitter.Node) *sitter.Node {
+	for i := int(node.NamedChildCount()) - 1; i >= 0; i-- {
+		child := node.NamedChild(i)
+		if isComment(child) {
+			continue
+		}
+
+		switch child.Type() {
+		case "let_declaration", "empty_statement":
+			return nil
+		case "expression_statement":
+			if last := child.Child(int(child.ChildCount()) - 1); last != nil && !last.IsNamed() {
+				return nil
+			}
+		}
+
+		if strings.HasSuffix(child.Type(), "_item") {
+			return nil
+		}
+
+		return child
+	}
+
+	return nil
+}
+
+// the variables bound by a pattern, eg. `a` and `b` in `(a, Some(b))`
+func (analyzer *analyzer) patternIdentifiers(node *sitter.Node) []*sitter.Node {
+	if node.Type() == "identifier" {
+		return []*sitter.Node{node}
+	}
+
+	// paths don't bind variables, eg. `Role::Admin`
+	if node.Type() == "scoped_identifier" {
+		return nil
+	}
+
+	var result []*sitter.Node
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		if !child.IsNamed() {
+			continue
+		}
+
+		// the struct/variant name, eg. `Some`, and match guards
+		if field := node.FieldNameForChild(i); field == "type" || field == "condition" {
+			continue
+		}
+
+		result = append(result, analyzer.patternIdentifiers(child)...)
+	}
+
+	return result
+}
+
+func (analyzer *analyzer) declarePattern(node *sitter.Node) {
+	for _, name := range analyzer.patternIdentifiers(node) {
+		analyzer.scope.Declare(analyzer.builder.ContentFor(name), name)
+	}
+}
+
+func (analyzer *analyzer) lastChildOfType(node *sitter.Node, nodeType string) *sitter.Node {
+	for i := int(node.NamedChildCount()) - 1; i >= 0; i-- {
+		if child := node.NamedChild(i); child.Type() == nodeType {
+			return child
+		}
+	}
+
+	return nil
+}
+
+func (analyzer *analyzer) withScope(newScope *language.Scope, body func() error) error {
+	oldScope := analyzer.scope
+
+	analyzer.scope = newScope
+	err := body()
+	analyzer.scope = oldScope
+
+	return err
+}
+
+func (analyzer *analyzer) lookupVariable(node *sitter.Node) {
+	if node == nil || node.Type() != "identifier" {
+		return
+	}
+
+	if pointsToNode := analyzer.scope.Lookup(analyzer.builder.ContentFor(node)); pointsToNode != nil {
+		analyzer.builder.Alias(node, pointsToNode)
+	}
+}
+
+func isComment(node *sitter.Node) bool {
+	return node.Type() == "line_comment" || node.Type() == "block_comment"
+}
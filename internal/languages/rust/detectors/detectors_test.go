@@ -0,0 +1,21 @@
+package detectors_test
+
+import (
+	"testing"
+
+	"github.com/bearer/bearer/internal/languages/rust"
+	"github.com/bearer/bearer/internal/scanner/detectors/testhelper"
+)
+
+func TestRustObjects(t *testing.T) {
+	runTest(t, "object_class", "object", "testdata/class.rs")
+	runTest(t, "object_no_class", "object", "testdata/no_class.rs")
+}
+
+func TestRustString(t *testing.T) {
+	runTest(t, "string", "string", "testdata/string.rs")
+}
+
+func runTest(t *testing.T, name, detectorType, fileName string) {
+	testhelper.RunTest(t, name, rust.Get(), detectorType, fileName)
+}
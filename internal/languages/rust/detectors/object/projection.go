@@ -0,0 +1,108 @@
+package object
+
+import (
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+func (detector *objectDetector) getProjections(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	if node.Type() == "identifier" {
+		return detector.getMacroProjections(node, detectorContext)
+	}
+
+	// user.save() is a method call, not a field
+	if parent := node.Parent(); parent != nil && parent.Type() == "call_expression" && parent.ChildByFieldName("function") == node {
+		return nil, nil
+	}
+
+	result, err := detector.fieldAccessQuery.MatchOnceAt(node)
+	if result == nil || err != nil {
+		return nil, err
+	}
+
+	objectNode := result["object"]
+
+	return common.ProjectObject(
+		node,
+		detectorContext,
+		objectNode,
+		getObjectName(objectNode),
+		result["field"].Content(),
+		true,
+	)
+}
+
+// getMacroProjections handles field access in the arguments of a macro, eg.
+// `info!("{}", user.name)`. The grammar doesn't parse macro arguments into
+// expressions, so the field is the identifier directly after `<object>.`
+func (detector *objectDetector) getMacroProjections(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	parent := node.Parent()
+	if parent == nil || parent.Type() != "token_tree" {
+		return nil, nil
+	}
+
+	var objectNode, nextNode *tree.Node
+	children := parent.Children()
+	for i, child := range children {
+		if child != node {
+			continue
+		}
+
+		if i > 0 {
+			objectNode = children[i-1]
+		}
+
+		if i < len(children)-1 {
+			nextNode = children[i+1]
+		}
+	}
+
+	if objectNode == nil || (objectNode.Type() != "identifier" && objectNode.Type() != "self") {
+		return nil, nil
+	}
+
+	separator := node.Tree().ContentBytes()[objectNode.ContentEnd.Byte:node.ContentStart.Byte]
+	if string(separator) != "." {
+		return nil, nil
+	}
+
+	// user.save() is a method call, not a field
+	if nextNode != nil && nextNode.Type() == "token_tree" && nextNode.ContentStart.Byte == node.ContentEnd.Byte {
+		return nil, nil
+	}
+
+	return common.ProjectObject(
+		node,
+		detectorContext,
+		objectNode,
+		objectNode.Content(),
+		node.Content(),
+		true,
+	)
+}
+
+func getObjectName(objectNode *tree.Node) string {
+	switch objectNode.Type() {
+	// user.name
+	case "identifier", "self":
+		return objectNode.Content()
+	// address.city.zip
+	case "field_expression":
+		return objectNode.ChildByFieldName("field").Content()
+	// user.address().city
+	case "call_expression":
+		if function := objectNode.ChildByFieldName("function"); function.Type() == "field_expression" {
+			return function.ChildByFieldName("field").Content()
+		}
+	}
+
+	return ""
+}
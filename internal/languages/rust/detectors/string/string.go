@@ -0,0 +1,157 @@
+package string
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/ruleset"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+var (
+	// r"..." or r#"..."#
+	rawStringRegex = regexp.MustCompile(`\Ab?r(#*)"((?s).*)"#*\z`)
+	// {}, {0}, {name}, {:?} etc. placeholders and the {{ and }} escapes
+	formatPlaceholderRegex = regexp.MustCompile(`\{\{|\}\}|\{[^{}]*\}`)
+
+	// methods converting a &str into a String
+	conversionMethods = []string{"to_string", "to_owned", "into"}
+)
+
+type stringDetector struct {
+	types.DetectorBase
+}
+
+func New(querySet *query.Set) types.Detector {
+	return &stringDetector{}
+}
+
+func (detector *stringDetector) Rule() *ruleset.Rule {
+	return ruleset.BuiltinStringRule
+}
+
+func (detector *stringDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	switch node.Type() {
+	case "string_literal":
+		return handleString(node)
+	case "raw_string_literal":
+		return []interface{}{common.String{
+			Value:     rawStringRegex.ReplaceAllString(node.Content(), "$2"),
+			IsLiteral: true,
+		}}, nil
+	case "binary_expression":
+		if node.ChildByFieldName("operator").Content() == "+" {
+			return common.ConcatenateChildStrings(node, detectorContext)
+		}
+	case "compound_assignment_expr":
+		if node.ChildByFieldName("operator").Content() == "+=" {
+			return common.ConcatenateAssignEquals(node, detectorContext)
+		}
+	case "call_expression":
+		return handleConversion(node, detectorContext)
+	case "macro_invocation":
+		if node.ChildByFieldName("macro").Content() == "format" {
+			return handleFormat(node, detectorContext)
+		}
+	}
+
+	return nil, nil
+}
+
+// handleString returns the value of a string literal. The quotes aren't part
+// of the value as they are anonymous children
+func handleString(node *tree.Node) ([]interface{}, error) {
+	text := ""
+
+	err := node.EachContentPart(func(partText string) error {
+		text += partText
+		return nil
+	}, func(child *tree.Node) error {
+		// escape sequences
+		text += child.Content()
+		return nil
+	})
+
+	return []interface{}{common.String{
+		Value:     text,
+		IsLiteral: true,
+	}}, err
+}
+
+// handleConversion returns the value of the string being converted by a call,
+// eg. `String::from("foo")` or `"foo".to_string()`
+func handleConversion(node *tree.Node, detectorContext types.Context) ([]interface{}, error) {
+	var source *tree.Node
+
+	function := node.ChildByFieldName("function")
+	switch function.Type() {
+	case "scoped_identifier":
+		arguments := node.ChildByFieldName("arguments").NamedChildren()
+		if function.Content() == "String::from" && len(arguments) == 1 {
+			source = arguments[0]
+		}
+	case "field_expression":
+		if slices.Contains(conversionMethods, function.ChildByFieldName("field").Content()) {
+			source = function.ChildByFieldName("value")
+		}
+	}
+
+	if source == nil {
+		return nil, nil
+	}
+
+	value, isLiteral, err := common.GetStringValue(source, detectorContext)
+	if err != nil || (value == "" && !isLiteral) {
+		return nil, err
+	}
+
+	return []interface{}{common.String{
+		Value:     value,
+		IsLiteral: isLiteral,
+	}}, nil
+}
+
+// handleFormat returns the value of a `format!` call, with the placeholders
+// replaced by the non-literal value
+func handleFormat(node *tree.Node, detectorContext types.Context) ([]interface{}, error) {
+	children := node.NamedChildren()
+	tokens := children[len(children)-1]
+	if tokens.Type() != "token_tree" {
+		return nil, nil
+	}
+
+	arguments := tokens.NamedChildren()
+	if len(arguments) == 0 || arguments[0].Type() != "string_literal" {
+		return nil, nil
+	}
+
+	format, _, err := common.GetStringValue(arguments[0], detectorContext)
+	if err != nil {
+		return nil, err
+	}
+
+	isLiteral := true
+	value := formatPlaceholderRegex.ReplaceAllStringFunc(format, func(placeholder string) string {
+		switch placeholder {
+		case "{{":
+			return "{"
+		case "}}":
+			return "}"
+		}
+
+		isLiteral = false
+		return common.NonLiteralValue
+	})
+
+	return []interface{}{common.String{
+		Value:     value,
+		IsLiteral: isLiteral,
+	}}, nil
+}
@@ -0,0 +1,9 @@
+#[derive(Serialize, Deserialize)]
+pub struct User {
+    pub name: String,
+    email: Option<String>,
+}
+
+fn main() {
+    let user = User { name: String::from("x"), email };
+}
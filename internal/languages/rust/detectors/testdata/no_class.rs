@@ -0,0 +1,3 @@
+fn main() {
+    let user = User { name: String::from("x") };
+}
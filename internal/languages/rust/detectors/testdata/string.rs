@@ -0,0 +1,10 @@
+fn main() {
+    let a = "hello";
+    let b = a + " world";
+    let mut c = String::from("one");
+    c += "two";
+    c += &b;
+    let d = r#"raw "string""#;
+    let e = format!("{} {{literal}} {:?}", a, b);
+    let f = "escaped\n";
+}
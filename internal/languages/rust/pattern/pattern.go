@@ -0,0 +1,154 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/language"
+	"github.com/bearer/bearer/internal/util/regex"
+)
+
+var (
+	// $<name:type> or $<name:type1|type2> or $<name>
+	queryVariableRegex = regexp.MustCompile(`\$<(?P<name>[^>:!\.]+)(?::(?P<types>[^>]+))?>`)
+	matchNodeRegex     = regexp.MustCompile(`\$<!>`)
+	ellipsisRegex      = regexp.MustCompile(`\$<\.\.\.>`)
+
+	matchNodeContainerTypes = []string{"arguments", "token_tree"}
+
+	allowedQueryTypes = []string{
+		"_",
+		"identifier",
+		"field_identifier",
+		"type_identifier",
+		"scoped_identifier",
+		"field_expression",
+		"call_expression",
+		"macro_invocation",
+		"string_literal",
+	}
+
+	// the operators of these expressions are matched, so that eg. `a == b` doesn't
+	// match `a != b`
+	anonymousParentTypes = []string{
+		"binary_expression",
+		"compound_assignment_expr",
+		"unary_expression",
+	}
+
+	// the statements of a file, block or impl, the fields of a struct and the
+	// tokens of a macro call. Macro arguments are unanchored as they are not
+	// parsed into expressions by the grammar
+	unanchoredParentTypes = []string{
+		"source_file",
+		"block",
+		"declaration_list",
+		"field_declaration_list",
+		"field_initializer_list",
+		"token_tree",
+	}
+)
+
+type Pattern struct {
+	language.PatternBase
+}
+
+func (*Pattern) ExtractVariables(input string) (string, []language.PatternVariable, error) {
+	nameIndex := queryVariableRegex.SubexpIndex("name")
+	typesIndex := queryVariableRegex.SubexpIndex("types")
+	i := 0
+
+	var params []language.PatternVariable
+
+	replaced, err := regex.ReplaceAllWithSubmatches(queryVariableRegex, input, func(submatches []string) (string, error) {
+		nodeTypes := strings.Split(submatches[typesIndex], "|")
+		if nodeTypes[0] == "" {
+			nodeTypes = []string{"_"}
+		}
+
+		for _, nodeType := range nodeTypes {
+			if !slices.Contains(allowedQueryTypes, nodeType) {
+				return "", fmt.Errorf("invalid node type '%s' in pattern query", nodeType)
+			}
+		}
+
+		dummyValue := produceDummyValue(i)
+
+		params = append(params, language.PatternVariable{
+			Name:       submatches[nameIndex],
+			NodeTypes:  nodeTypes,
+			DummyValue: dummyValue,
+		})
+
+		i += 1
+
+		return dummyValue, nil
+	})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return replaced, params, nil
+}
+
+func produceDummyValue(i int) string {
+	return "BearerVar" + fmt.Sprint(i)
+}
+
+func (*Pattern) FindMatchNode(input []byte) [][]int {
+	return matchNodeRegex.FindAllIndex(input, -1)
+}
+
+func (*Pattern) FindUnanchoredPoints(input []byte) [][]int {
+	return ellipsisRegex.FindAllIndex(input, -1)
+}
+
+func (*Pattern) LeafContentTypes() []string {
+	return []string{
+		// identifiers
+		"identifier", "field_identifier", "type_identifier", "shorthand_field_identifier", "primitive_type", "self",
+		// modifiers
+		"visibility_modifier", "mutable_specifier",
+		// datatypes/literals
+		"string_literal", "raw_string_literal", "char_literal", "integer_literal", "float_literal", "boolean_literal",
+	}
+}
+
+func (*Pattern) IsAnchored(node *tree.Node) (bool, bool) {
+	parent := node.Parent()
+	if parent == nil {
+		return true, true
+	}
+
+	// the return type and where clause are optional
+	if parent.Type() == "function_item" {
+		if node == parent.ChildByFieldName("parameters") {
+			return true, false
+		}
+
+		return false, false
+	}
+
+	isAnchored := !slices.Contains(unanchoredParentTypes, parent.Type())
+	return isAnchored, isAnchored
+}
+
+func (*Pattern) IsRoot(node *tree.Node) bool {
+	return !slices.Contains([]string{"source_file", "expression_statement"}, node.Type()) && !node.IsMissing()
+}
+
+func (*Pattern) AnonymousParentTypes() []string {
+	return anonymousParentTypes
+}
+
+func (*Pattern) NodeTypes(node *tree.Node) []string {
+	return []string{node.Type()}
+}
+
+func (*Pattern) ContainerTypes() []string {
+	return matchNodeContainerTypes
+}
@@ -0,0 +1,43 @@
+package rust_test
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/languages/rust"
+	"github.com/bearer/bearer/internal/languages/testhelper"
+	patternquerybuilder "github.com/bearer/bearer/internal/scanner/detectors/customrule/patternquery/builder"
+)
+
+//go:embed testdata/logger.yml
+var loggerRule []byte
+
+//go:embed testdata/scope_rule.yml
+var scopeRule []byte
+
+func TestFlow(t *testing.T) {
+	testhelper.GetRunner(t, loggerRule, "Rust").RunTest(t, "./testdata/testcases/flow", ".snapshots/flow/")
+}
+
+func TestScope(t *testing.T) {
+	testhelper.GetRunner(t, scopeRule, "Rust").RunTest(t, "./testdata/scope", ".snapshots/")
+}
+
+func TestPattern(t *testing.T) {
+	for _, test := range []struct{ name, pattern string }{
+		{"call arguments is a container type", `
+				foo($<!>$<_>)
+		`},
+	} {
+		t.Run(test.name, func(tt *testing.T) {
+			result, err := patternquerybuilder.Build(rust.Get(), test.pattern, "")
+			if err != nil {
+				tt.Fatalf("failed to build pattern: %s", err)
+			}
+
+			cupaloy.SnapshotT(tt, result)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+fn main() {
+    scope_cursor(query.into_inner());
+    scope_cursor(x + query.into_inner());
+    scope_cursor(if x { query.into_inner() } else { y });
+    scope_cursor(if query.into_inner() { x } else { y });
+
+    scope_nested(query.into_inner());
+    scope_nested(x + query.into_inner());
+    scope_nested(if x { query.into_inner() } else { y });
+    scope_nested(if query.into_inner() { x } else { y });
+
+    scope_result(query.into_inner());
+    scope_result(x + query.into_inner());
+    scope_result(if x { query.into_inner() } else { y });
+    scope_result(if query.into_inner() { x } else { y });
+}
@@ -0,0 +1,4 @@
+fn main(user: User) {
+    let name = user.name;
+    log::error!("{}", name);
+}
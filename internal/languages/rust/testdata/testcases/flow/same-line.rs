@@ -0,0 +1,3 @@
+fn main(user: User) {
+    log::error!("{}", user.name);
+}
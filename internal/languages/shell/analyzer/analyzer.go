@@ -0,0 +1,171 @@
+package analyzer
+
+import (
+	"slices"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/language"
+)
+
+// builtins declaring variables local to a function
+var localDeclarations = []string{"local", "declare", "typeset"}
+
+type analyzer struct {
+	builder *tree.Builder
+	scope   *language.Scope
+}
+
+func New(builder *tree.Builder) language.Analyzer {
+	return &analyzer{
+		builder: builder,
+		scope:   language.NewScope(nil),
+	}
+}
+
+func (analyzer *analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
+	switch node.Type() {
+	case "function_definition":
+		return analyzer.withScope(language.NewScope(analyzer.scope), func() error {
+			return visitChildren()
+		})
+	case "declaration_command":
+		return analyzer.analyzeDeclaration(node, visitChildren)
+	case "variable_assignment":
+		return analyzer.analyzeAssignment(node, visitChildren)
+	case "for_statement":
+		return analyzer.analyzeFor(node, visitChildren)
+	case "simple_expansion", "expansion":
+		return analyzer.analyzeExpansion(node, visitChildren)
+	case "string", "command_substitution":
+		return analyzer.analyzeWrapper(node, visitChildren)
+	case "variable_name":
+		return visitChildren()
+	default:
+		analyzer.builder.Dataflow(node, analyzer.builder.ChildrenFor(node)...)
+		return visitChildren()
+	}
+}
+
+// local foo
+// export FOO=a
+func (analyzer *analyzer) analyzeDeclaration(node *sitter.Node, visitChildren func() error) error {
+	analyzer.builder.Dataflow(node, analyzer.builder.ChildrenFor(node)...)
+
+	err := visitChildren()
+
+	if isLocalDeclaration(node) {
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			if child := node.NamedChild(i); child.Type() == "variable_name" {
+				analyzer.scope.Declare(analyzer.builder.ContentFor(child), child)
+			}
+		}
+	}
+
+	return err
+}
+
+// foo=a
+// local foo=a
+func (analyzer *analyzer) analyzeAssignment(node *sitter.Node, visitChildren func() error) error {
+	name := node.ChildByFieldName("name")
+	value := node.ChildByFieldName("value")
+	if value != nil {
+		analyzer.builder.Alias(node, value)
+	}
+
+	err := visitChildren()
+
+	if name != nil && name.Type() == "variable_name" {
+		if isLocalDeclaration(node.Parent()) {
+			analyzer.scope.Declare(analyzer.builder.ContentFor(name), node)
+		} else {
+			analyzer.scope.Assign(analyzer.builder.ContentFor(name), node)
+		}
+	}
+
+	return err
+}
+
+// for item in $items; do ... done
+func (analyzer *analyzer) analyzeFor(node *sitter.Node, visitChildren func() error) error {
+	if variable := node.ChildByFieldName("variable"); variable != nil {
+		var values []*sitter.Node
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if node.FieldNameForChild(i) == "value" {
+				values = append(values, node.Child(i))
+			}
+		}
+
+		analyzer.builder.Dataflow(variable, values...)
+		analyzer.scope.Assign(analyzer.builder.ContentFor(variable), variable)
+	}
+
+	return visitChildren()
+}
+
+// $foo
+// ${foo}
+// ${foo:-default}
+func (analyzer *analyzer) analyzeExpansion(node *sitter.Node, visitChildren func() error) error {
+	if name := variableName(node); name != nil {
+		if pointsToNode := analyzer.scope.Lookup(analyzer.builder.ContentFor(name)); pointsToNode != nil {
+			analyzer.builder.Alias(node, pointsToNode)
+		}
+	}
+
+	return visitChildren()
+}
+
+// nodes which result in the value of their only child, eg.
+//
+//	"$foo"
+//	$(foo)
+func (analyzer *analyzer) analyzeWrapper(node *sitter.Node, visitChildren func() error) error {
+	if node.NamedChildCount() == 1 && isWrapped(node, node.NamedChild(0)) {
+		analyzer.builder.Alias(node, node.NamedChild(0))
+	} else {
+		analyzer.builder.Dataflow(node, analyzer.builder.ChildrenFor(node)...)
+	}
+
+	return visitChildren()
+}
+
+func (analyzer *analyzer) withScope(newScope *language.Scope, body func() error) error {
+	oldScope := analyzer.scope
+
+	analyzer.scope = newScope
+	err := body()
+	analyzer.scope = oldScope
+
+	return err
+}
+
+// variableName returns the name of the variable an expansion refers to
+func variableName(node *sitter.Node) *sitter.Node {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if child := node.NamedChild(i); child.Type() == "variable_name" {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// isLocalDeclaration tells whether a node declares variables local to the
+// function, eg. `local foo=a`, rather than setting global variables
+func isLocalDeclaration(node *sitter.Node) bool {
+	if node == nil || node.Type() != "declaration_command" || node.ChildCount() == 0 {
+		return false
+	}
+
+	return slices.Contains(localDeclarations, node.Child(0).Type())
+}
+
+// isWrapped tells whether a child makes up the whole content of a node, apart
+// from its delimiters, eg. `"$foo"` but not `"foo $bar"`
+func isWrapped(node, child *sitter.Node) bool {
+	return child.StartByte() == node.Child(0).EndByte() &&
+		child.EndByte() == node.Child(int(node.ChildCount())-1).StartByte()
+}
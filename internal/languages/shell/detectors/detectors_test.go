@@ -0,0 +1,20 @@
+package detectors_test
+
+import (
+	"testing"
+
+	"github.com/bearer/bearer/internal/languages/shell"
+	"github.com/bearer/bearer/internal/scanner/detectors/testhelper"
+)
+
+func TestShellObjects(t *testing.T) {
+	runTest(t, "object", "object", "testdata/object.sh")
+}
+
+func TestShellString(t *testing.T) {
+	runTest(t, "string", "string", "testdata/string.sh")
+}
+
+func runTest(t *testing.T, name, detectorType, fileName string) {
+	testhelper.RunTest(t, name, shell.Get(), detectorType, fileName)
+}
@@ -0,0 +1,76 @@
+package object
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/ruleset"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+// the positional and special parameters, eg. `$1` or `$@`, which don't name
+// the data they hold
+var specialParameterRegex = regexp.MustCompile(`\A([0-9]+|[@*#?$!-])\z`)
+
+type objectDetector struct {
+	types.DetectorBase
+	expansionQuery *query.Query
+}
+
+func New(querySet *query.Set) types.Detector {
+	// $user_email
+	// ${USER_EMAIL}
+	expansionQuery := querySet.Add(`[
+		(simple_expansion (variable_name) @name)
+		(expansion (variable_name) @name)
+	] @root`)
+
+	return &objectDetector{expansionQuery: expansionQuery}
+}
+
+func (detector *objectDetector) Rule() *ruleset.Rule {
+	return ruleset.BuiltinObjectRule
+}
+
+// DetectAt reports the variables expanded in a script. Shell variables hold
+// strings, so their name is all that describes the data. Names commonly join
+// the object and the field they hold, eg. `CUSTOMER_EMAIL`, and are reported as
+// such so that they are classified.
+func (detector *objectDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	result, err := detector.expansionQuery.MatchOnceAt(node)
+	if result == nil || err != nil {
+		return nil, err
+	}
+
+	name := result["name"].Content()
+	if specialParameterRegex.MatchString(name) {
+		return nil, nil
+	}
+
+	objectName, propertyName, isField := strings.Cut(strings.Trim(name, "_"), "_")
+	if !isField {
+		return []interface{}{common.Object{
+			Properties: []common.Property{{Name: name, Node: node}},
+		}}, nil
+	}
+
+	return []interface{}{common.Object{
+		Properties: []common.Property{{
+			Name: objectName,
+			Object: &types.Detection{
+				RuleID:    ruleset.BuiltinObjectRule.ID(),
+				MatchNode: node,
+				Data: common.Object{
+					Properties: []common.Property{{Name: propertyName, Node: node}},
+				},
+			},
+		}},
+	}}, nil
+}
@@ -0,0 +1,89 @@
+package string
+
+import (
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/ruleset"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+type stringDetector struct {
+	types.DetectorBase
+}
+
+func New(querySet *query.Set) types.Detector {
+	return &stringDetector{}
+}
+
+func (detector *stringDetector) Rule() *ruleset.Rule {
+	return ruleset.BuiltinStringRule
+}
+
+func (detector *stringDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	switch node.Type() {
+	case "word":
+		// the name of a command isn't a value
+		if parent := node.Parent(); parent != nil && parent.Type() == "command_name" {
+			return nil, nil
+		}
+
+		return []interface{}{common.String{
+			Value:     node.Content(),
+			IsLiteral: true,
+		}}, nil
+	case "raw_string":
+		content := node.Content()
+
+		return []interface{}{common.String{
+			Value:     content[1 : len(content)-1],
+			IsLiteral: true,
+		}}, nil
+	case "string":
+		return handleString(node, detectorContext)
+	case "concatenation":
+		return common.ConcatenateChildStrings(node, detectorContext)
+	}
+
+	return nil, nil
+}
+
+// handleString returns the value of a double quoted string, eg.
+// "Bearer $TOKEN". The quotes aren't part of the value as they are anonymous
+// children, and expansions are included when the value of their variable is
+// known.
+func handleString(node *tree.Node, detectorContext types.Context) ([]interface{}, error) {
+	value := ""
+	isLiteral := true
+
+	err := node.EachContentPart(func(text string) error {
+		value += text
+		return nil
+	}, func(child *tree.Node) error {
+		childValue, childIsLiteral, err := common.GetStringValue(child, detectorContext)
+		if err != nil {
+			return err
+		}
+
+		if childValue == "" && !childIsLiteral {
+			childValue = common.NonLiteralValue
+		}
+
+		value += childValue
+
+		if !childIsLiteral {
+			isLiteral = false
+		}
+
+		return nil
+	})
+
+	return []interface{}{common.String{
+		Value:     value,
+		IsLiteral: isLiteral,
+	}}, err
+}
@@ -0,0 +1,3 @@
+USER_EMAIL="$1"
+curl -d "email=$USER_EMAIL" https://example.com
+echo "${first_name:-unknown}" "$@"
@@ -0,0 +1,2 @@
+TOKEN=abc
+echo plain 'single $quoted' "double $TOKEN" "with $UNKNOWN" prefix$TOKEN "$(date)"
@@ -0,0 +1,61 @@
+package shell
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/bash"
+
+	"github.com/bearer/bearer/internal/classification/schema"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	detectortypes "github.com/bearer/bearer/internal/scanner/detectors/types"
+
+	"github.com/bearer/bearer/internal/languages/shell/analyzer"
+	"github.com/bearer/bearer/internal/languages/shell/detectors/object"
+	stringdetector "github.com/bearer/bearer/internal/languages/shell/detectors/string"
+	"github.com/bearer/bearer/internal/languages/shell/pattern"
+	"github.com/bearer/bearer/internal/scanner/detectors/datatype"
+	"github.com/bearer/bearer/internal/scanner/detectors/insecureurl"
+	"github.com/bearer/bearer/internal/scanner/detectors/stringliteral"
+	"github.com/bearer/bearer/internal/scanner/language"
+)
+
+// implementation of shell, used both to scan shell scripts and as a language
+// embedded in the string literals of other languages
+type implementation struct {
+	pattern pattern.Pattern
+}
+
+func Get() language.Language {
+	return &implementation{}
+}
+
+func (*implementation) ID() string {
+	return "shell"
+}
+
+func (*implementation) EnryLanguages() []string {
+	return []string{"Shell"}
+}
+
+func (*implementation) NewBuiltInDetectors(schemaClassifier *schema.Classifier, querySet *query.Set) []detectortypes.Detector {
+	return []detectortypes.Detector{
+		object.New(querySet),
+		datatype.New(detectors.DetectorShell, schemaClassifier),
+		stringdetector.New(querySet),
+		stringliteral.New(querySet),
+		insecureurl.New(querySet),
+	}
+}
+
+func (*implementation) SitterLanguage() *sitter.Language {
+	return bash.GetLanguage()
+}
+
+func (language *implementation) Pattern() language.Pattern {
+	return &language.pattern
+}
+
+func (*implementation) NewAnalyzer(builder *tree.Builder) language.Analyzer {
+	return analyzer.New(builder)
+}
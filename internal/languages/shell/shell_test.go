@@ -0,0 +1,42 @@
+package shell_test
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/languages/shell"
+	"github.com/bearer/bearer/internal/languages/testhelper"
+	patternquerybuilder "github.com/bearer/bearer/internal/scanner/detectors/customrule/patternquery/builder"
+)
+
+//go:embed testdata/logger.yml
+var loggerRule []byte
+
+//go:embed testdata/scope_rule.yml
+var scopeRule []byte
+
+func TestFlow(t *testing.T) {
+	testhelper.GetRunner(t, loggerRule, "Shell").RunTest(t, "./testdata/testcases/flow", ".snapshots/flow/")
+}
+
+func TestScope(t *testing.T) {
+	testhelper.GetRunner(t, scopeRule, "Shell").RunTest(t, "./testdata/scope", ".snapshots/")
+}
+
+func TestPattern(t *testing.T) {
+	for _, test := range []struct{ name, pattern string }{
+		{"command in a pipeline is the match node", `$<!>$<_> | $<_>`},
+		{"command in a redirection is the match node", `$<!>echo $<_> > $<_>`},
+	} {
+		t.Run(test.name, func(tt *testing.T) {
+			result, err := patternquerybuilder.Build(shell.Get(), test.pattern, "")
+			if err != nil {
+				tt.Fatalf("failed to build pattern: %s", err)
+			}
+
+			cupaloy.SnapshotT(tt, result)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+scope_cursor "$(read_input)"
+scope_cursor "prefix $(read_input)"
+INPUT=$(read_input)
+scope_cursor "$INPUT"
+
+scope_nested "$(read_input)"
+scope_nested "prefix $(read_input)"
+scope_nested "$INPUT"
+
+scope_result "$(read_input)"
+scope_result "prefix $(read_input)"
+scope_result "$INPUT"
@@ -0,0 +1,2 @@
+RECIPIENT="$CUSTOMER_EMAIL"
+logger "sending to $RECIPIENT"
@@ -0,0 +1,6 @@
+RECIPIENT="$CUSTOMER_EMAIL"
+notify() {
+  local RECIPIENT=ops
+  logger "sending to $RECIPIENT"
+}
+logger "sending to $RECIPIENT"
@@ -0,0 +1,17 @@
+package detectors_test
+
+import (
+	"testing"
+
+	"github.com/bearer/bearer/internal/languages/sql"
+	"github.com/bearer/bearer/internal/scanner/detectors/testhelper"
+)
+
+func TestSQLObjects(t *testing.T) {
+	runTest(t, "object_class", "object", "testdata/class.sql")
+	runTest(t, "object_no_class", "object", "testdata/no_class.sql")
+}
+
+func runTest(t *testing.T, name, detectorType, fileName string) {
+	testhelper.RunTest(t, name, sql.Get(), detectorType, fileName)
+}
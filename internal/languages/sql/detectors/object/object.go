@@ -0,0 +1,214 @@
+package object
+
+import (
+	"strings"
+
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/ruleset"
+
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/types"
+)
+
+type objectDetector struct {
+	types.DetectorBase
+	// Columns read by a query
+	selectQuery *query.Query
+	// Columns written by a query
+	insertQuery *query.Query
+	updateQuery *query.Query
+}
+
+func New(querySet *query.Set) types.Detector {
+	// SELECT email, users.name, lower(phone) FROM users
+	selectQuery := querySet.Add(`[
+		(select_clause_body [(identifier) (dotted_name)] @root)
+		(function_call arguments: [(identifier) (dotted_name)] @root)
+	]`)
+
+	// INSERT INTO users (email, name) VALUES (...)
+	insertQuery := querySet.Add(`(insert_statement . (identifier) @table (identifier) @root)`)
+
+	// UPDATE users SET email = ...
+	updateQuery := querySet.Add(`(update_statement
+		. (identifier) @table
+		(set_clause (set_clause_body (assigment_expression . [(identifier) (dotted_name)] @root))))`)
+
+	return &objectDetector{
+		selectQuery: selectQuery,
+		insertQuery: insertQuery,
+		updateQuery: updateQuery,
+	}
+}
+
+func (detector *objectDetector) Rule() *ruleset.Rule {
+	return ruleset.BuiltinObjectRule
+}
+
+func (detector *objectDetector) DetectAt(
+	node *tree.Node,
+	detectorContext types.Context,
+) ([]interface{}, error) {
+	detections, err := detector.getInsertColumn(node)
+	if len(detections) != 0 || err != nil {
+		return detections, err
+	}
+
+	detections, err = detector.getUpdateColumn(node)
+	if len(detections) != 0 || err != nil {
+		return detections, err
+	}
+
+	return detector.getSelectColumn(node), nil
+}
+
+func (detector *objectDetector) getInsertColumn(node *tree.Node) ([]interface{}, error) {
+	result, err := detector.insertQuery.MatchOnceAt(node)
+	if result == nil || err != nil {
+		return nil, err
+	}
+
+	return newColumnObject(node, unquote(result["table"].Content()), unquote(node.Content())), nil
+}
+
+func (detector *objectDetector) getUpdateColumn(node *tree.Node) ([]interface{}, error) {
+	result, err := detector.updateQuery.MatchOnceAt(node)
+	if result == nil || err != nil {
+		return nil, err
+	}
+
+	table, column := splitColumn(node)
+	if table == "" {
+		table = unquote(result["table"].Content())
+	}
+
+	return newColumnObject(node, table, column), nil
+}
+
+func (detector *objectDetector) getSelectColumn(node *tree.Node) []interface{} {
+	if len(detector.selectQuery.MatchAt(node)) == 0 || isSelectTarget(node) {
+		return nil
+	}
+
+	table, column := splitColumn(node)
+	return newColumnObject(node, resolveTable(node, table), column)
+}
+
+// newColumnObject returns an object for a column, within an object for its
+// table when the table is known
+func newColumnObject(node *tree.Node, table, column string) []interface{} {
+	property := common.Property{Name: column, Node: node}
+	if table == "" {
+		return []interface{}{common.Object{Properties: []common.Property{property}}}
+	}
+
+	return []interface{}{common.Object{
+		Properties: []common.Property{{
+			Name: table,
+			Object: &types.Detection{
+				RuleID:    ruleset.BuiltinObjectRule.ID(),
+				MatchNode: node,
+				Data: common.Object{
+					Properties: []common.Property{property},
+				},
+			},
+		}},
+	}}
+}
+
+// isSelectTarget tells whether a node is the table a `SELECT ... INTO` query
+// creates, rather than a column
+func isSelectTarget(node *tree.Node) bool {
+	parent := node.Parent()
+	return parent.Type() == "select_clause_body" && parent.ChildByFieldName("into") == node
+}
+
+// splitColumn returns the qualifier and the name of a column, eg. `u` and
+// `email` for `u.email`
+func splitColumn(node *tree.Node) (string, string) {
+	if node.Type() != "dotted_name" {
+		return "", unquote(node.Content())
+	}
+
+	parts := node.NamedChildren()
+	column := unquote(parts[len(parts)-1].Content())
+	if len(parts) < 2 {
+		return "", column
+	}
+
+	return unquote(parts[len(parts)-2].Content()), column
+}
+
+// resolveTable returns the table a column of a SELECT query belongs to, given
+// the qualifier of the column. The qualifier can be an alias of a table in the
+// FROM clause. Unqualified columns belong to the table when only one is
+// selected from.
+func resolveTable(node *tree.Node, qualifier string) string {
+	statement := node.Parent()
+	for statement != nil && statement.Type() != "select_statement" {
+		statement = statement.Parent()
+	}
+
+	if statement == nil {
+		return qualifier
+	}
+
+	var tables []string
+	aliases := make(map[string]string)
+	for _, clause := range statement.NamedChildren() {
+		if clause.Type() == "from_clause" {
+			collectTables(clause, &tables, aliases)
+		}
+	}
+
+	if qualifier != "" {
+		if table, isAlias := aliases[strings.ToLower(qualifier)]; isAlias {
+			return table
+		}
+
+		return qualifier
+	}
+
+	if len(tables) == 1 {
+		return tables[0]
+	}
+
+	return ""
+}
+
+// collectTables gathers the tables of a FROM clause, and maps their aliases to
+// them, eg. `FROM users u JOIN orders o ON ...`
+func collectTables(node *tree.Node, tables *[]string, aliases map[string]string) {
+	table := ""
+	for _, child := range node.NamedChildren() {
+		switch child.Type() {
+		case "identifier", "dotted_name":
+			_, table = splitColumn(child)
+			*tables = append(*tables, table)
+		case "alias":
+			if table != "" {
+				aliases[strings.ToLower(unquote(child.Content()))] = table
+			}
+		case "join_clause":
+			collectTables(child, tables, aliases)
+		}
+	}
+}
+
+// unquote removes the quotes of a quoted identifier, eg. `"email"`, `email`
+// or [email]
+func unquote(name string) string {
+	if len(name) < 2 {
+		return name
+	}
+
+	switch {
+	case name[0] == '"' && name[len(name)-1] == '"',
+		name[0] == '`' && name[len(name)-1] == '`',
+		name[0] == '[' && name[len(name)-1] == ']':
+		return name[1 : len(name)-1]
+	}
+
+	return name
+}
@@ -0,0 +1,3 @@
+SELECT u.email, "first_name", lower(phone) FROM users u;
+INSERT INTO contacts (email) VALUES ('x');
+UPDATE accounts SET iban = NULL;
@@ -0,0 +1 @@
+SELECT email FROM users JOIN orders ON orders.user_id = users.id;
@@ -0,0 +1,116 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/language"
+	"github.com/bearer/bearer/internal/util/regex"
+)
+
+var (
+	// $<name:type> or $<name:type1|type2> or $<name>
+	patternQueryVariableRegex = regexp.MustCompile(`\$<(?P<name>[^>:!\.]+)(?::(?P<types>[^>]+))?>`)
+	matchNodeRegex            = regexp.MustCompile(`\$<!>`)
+	ellipsisRegex             = regexp.MustCompile(`\$<\.\.\.>`)
+
+	// a variable for the only column of a query matches the column, rather
+	// than the list of columns
+	matchNodeContainerTypes = []string{"select_clause_body"}
+
+	allowedPatternQueryTypes = []string{"_", "identifier", "string", "number"}
+)
+
+type Pattern struct {
+	language.PatternBase
+}
+
+func (*Pattern) ExtractVariables(input string) (string, []language.PatternVariable, error) {
+	nameIndex := patternQueryVariableRegex.SubexpIndex("name")
+	typesIndex := patternQueryVariableRegex.SubexpIndex("types")
+	i := 0
+
+	var params []language.PatternVariable
+
+	replaced, err := regex.ReplaceAllWithSubmatches(patternQueryVariableRegex, input, func(submatches []string) (string, error) {
+		nodeTypes := strings.Split(submatches[typesIndex], "|")
+		if nodeTypes[0] == "" {
+			nodeTypes = []string{"_"}
+		}
+
+		for _, nodeType := range nodeTypes {
+			if !slices.Contains(allowedPatternQueryTypes, nodeType) {
+				return "", fmt.Errorf("invalid node type '%s' in pattern query", nodeType)
+			}
+		}
+
+		dummyValue := "BearerVar" + fmt.Sprint(i)
+
+		params = append(params, language.PatternVariable{
+			Name:       submatches[nameIndex],
+			NodeTypes:  nodeTypes,
+			DummyValue: dummyValue,
+		})
+
+		i += 1
+
+		return dummyValue, nil
+	})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return replaced, params, nil
+}
+
+func (*Pattern) FindMatchNode(input []byte) [][]int {
+	return matchNodeRegex.FindAllIndex(input, -1)
+}
+
+func (*Pattern) FindUnanchoredPoints(input []byte) [][]int {
+	return ellipsisRegex.FindAllIndex(input, -1)
+}
+
+func (*Pattern) ContainerTypes() []string {
+	return matchNodeContainerTypes
+}
+
+func (*Pattern) AnonymousParentTypes() []string {
+	return []string{"binary_expression", "grant_statement"}
+}
+
+func (*Pattern) LeafContentTypes() []string {
+	return []string{
+		"identifier",
+		"number",
+		"content",
+	}
+}
+
+func (*Pattern) IsAnchored(node *tree.Node) (bool, bool) {
+	parent := node.Parent()
+	if parent == nil {
+		return true, true
+	}
+
+	// the clauses of a statement are optional, eg. a pattern without a `WHERE`
+	// clause still matches queries with one, and a pattern selecting one column
+	// matches queries selecting others too
+	if strings.HasSuffix(parent.Type(), "_statement") || parent.Type() == "select_clause_body" {
+		return false, false
+	}
+
+	return true, true
+}
+
+func (*Pattern) IsRoot(node *tree.Node) bool {
+	return node.Type() != "source_file" && !node.IsMissing()
+}
+
+func (*Pattern) NodeTypes(node *tree.Node) []string {
+	return []string{node.Type()}
+}
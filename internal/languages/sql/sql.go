@@ -0,0 +1,61 @@
+package sql
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/bearer/bearer/internal/classification/schema"
+	"github.com/bearer/bearer/internal/parser/sitter/sql"
+	"github.com/bearer/bearer/internal/report/detectors"
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	detectortypes "github.com/bearer/bearer/internal/scanner/detectors/types"
+
+	"github.com/bearer/bearer/internal/languages/sql/detectors/object"
+	"github.com/bearer/bearer/internal/languages/sql/pattern"
+	"github.com/bearer/bearer/internal/scanner/detectors/datatype"
+	"github.com/bearer/bearer/internal/scanner/language"
+)
+
+// implementation of SQL, used both to scan SQL files and as a language
+// embedded in the string literals of other languages
+type implementation struct {
+	pattern pattern.Pattern
+}
+
+type analyzer struct{}
+
+func Get() language.Language {
+	return &implementation{}
+}
+
+func (*implementation) ID() string {
+	return "sql"
+}
+
+func (*implementation) EnryLanguages() []string {
+	return []string{"SQL", "PLpgSQL", "PLSQL", "SQLPL", "TSQL"}
+}
+
+func (*implementation) NewBuiltInDetectors(schemaClassifier *schema.Classifier, querySet *query.Set) []detectortypes.Detector {
+	return []detectortypes.Detector{
+		object.New(querySet),
+		datatype.New(detectors.DetectorSQL, schemaClassifier),
+	}
+}
+
+func (*implementation) SitterLanguage() *sitter.Language {
+	return sql.GetLanguage()
+}
+
+func (language *implementation) Pattern() language.Pattern {
+	return &language.pattern
+}
+
+func (*implementation) NewAnalyzer(builder *tree.Builder) language.Analyzer {
+	return &analyzer{}
+}
+
+// Analyze does nothing as queries have no variables to follow
+func (*analyzer) Analyze(node *sitter.Node, visitChildren func() error) error {
+	return visitChildren()
+}
@@ -63,7 +63,7 @@ func GetRunner(t *testing.T, ruleBytes []byte, lang string) *Runner {
 	config.Rules = getRulesFromYaml(t, ruleBytes)
 
 	worker := worker.Worker{}
-	err = worker.Setup(config)
+	_, err = worker.Setup(config)
 	if err != nil {
 		t.Fatalf("failed to setup scan worker: %s", err)
 	}
@@ -161,6 +161,7 @@ func (runner *Runner) scanSingleFile(t *testing.T, testDataPath string, fileRela
 		runner.config,
 		nil,
 		nil,
+		nil,
 	)
 	if err != nil {
 		t.Fatalf("failed to get output: %s", err)
@@ -75,6 +75,14 @@ func (tree *Tree) File() *file.Path {
 	return tree.file
 }
 
+func (tree *Tree) FileInfo() *file.FileInfo {
+	return tree.fileInfo
+}
+
+func (tree *Tree) Input() []byte {
+	return tree.input
+}
+
 func (tree *Tree) Close() {
 	tree.sitter.Close()
 }
@@ -13,16 +13,23 @@ type key struct {
 }
 
 type Findings struct {
-	fileList *files.List
-	chunks   map[string]git.Chunks
-	items    map[key][]git.ChunkRange
+	fileList          *files.List
+	chunks            map[string]git.Chunks
+	items             map[key][]git.ChunkRange
+	previousFilenames map[string]string
 }
 
 func New(fileList *files.List) *Findings {
+	previousFilenames := make(map[string]string)
+	for baseFilename, filename := range fileList.Renames {
+		previousFilenames[filename] = baseFilename
+	}
+
 	return &Findings{
-		fileList: fileList,
-		chunks:   make(map[string]git.Chunks),
-		items:    make(map[key][]git.ChunkRange),
+		fileList:          fileList,
+		chunks:            make(map[string]git.Chunks),
+		items:             make(map[key][]git.ChunkRange),
+		previousFilenames: previousFilenames,
 	}
 }
 
@@ -54,7 +61,7 @@ func (findings Findings) Consume(ruleID string, filename string, startLine, endL
 
 	for i, findingLineRange := range findings.items[key] {
 		if findingLineRange.Overlap(lineRange) {
-			slices.Delete(findings.items[key], i, i+1)
+			findings.items[key] = slices.Delete(findings.items[key], i, i+1)
 			return true
 		}
 	}
@@ -62,6 +69,12 @@ func (findings Findings) Consume(ruleID string, filename string, startLine, endL
 	return false
 }
 
+// PreviousFilename returns the base branch name of a file that git detected
+// as renamed or moved, or an empty string if the file was not renamed
+func (findings Findings) PreviousFilename(filename string) string {
+	return findings.previousFilenames[filename]
+}
+
 func newRange(startLine, endLine int) git.ChunkRange {
 	return git.ChunkRange{LineNumber: startLine, LineCount: endLine - startLine + 1}
 }
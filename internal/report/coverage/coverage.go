@@ -0,0 +1,244 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hhatto/gocloc"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/facts"
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+	"github.com/bearer/bearer/internal/report/output/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+const (
+	StatusEvaluated = "evaluated"
+	StatusSkipped   = "skipped"
+	StatusDisabled  = "disabled"
+)
+
+// Reasons a loaded rule isn't evaluated in a scan
+const (
+	ReasonLanguageAbsent     = "language_absent"
+	ReasonScannerDisabled    = "scanner_disabled"
+	ReasonRequirementsNotMet = "requirements_not_met"
+	ReasonTimeout            = "timeout"
+)
+
+// Report tells which rules were evaluated in a scan, and why the others
+// weren't
+type Report struct {
+	Summary   Summary  `json:"summary" yaml:"summary"`
+	Languages []string `json:"languages" yaml:"languages"`
+	Rules     []Rule   `json:"rules" yaml:"rules"`
+}
+
+type Summary struct {
+	Loaded    int `json:"loaded" yaml:"loaded"`
+	Evaluated int `json:"evaluated" yaml:"evaluated"`
+	Skipped   int `json:"skipped" yaml:"skipped"`
+	Disabled  int `json:"disabled" yaml:"disabled"`
+}
+
+// Rule is the coverage of a single rule. A rule is evaluated for the scanned
+// languages it applies to, except in the files it timed out on.
+type Rule struct {
+	ID                 string   `json:"id" yaml:"id"`
+	Languages          []string `json:"languages,omitempty" yaml:"languages,omitempty"`
+	Status             string   `json:"status" yaml:"status"`
+	Reason             string   `json:"reason,omitempty" yaml:"reason,omitempty"`
+	EvaluatedLanguages []string `json:"evaluated_languages,omitempty" yaml:"evaluated_languages,omitempty"`
+	TimedOutFiles      []string `json:"timed_out_files,omitempty" yaml:"timed_out_files,omitempty"`
+}
+
+// New builds the coverage of the rules of the config over a scan. Rules which
+// were disabled when loading are listed, but don't count as loaded.
+func New(config settings.Config, dataflow *types.DataFlow, inputgocloc *gocloc.Result) Report {
+	scannedFiles := scannedFilesByLanguage(inputgocloc)
+
+	timedOutFiles := make(map[string]set.Set[string])
+	var dependencies []dataflowtypes.Dependency
+	if dataflow != nil {
+		for _, fileError := range dataflow.Errors {
+			if fileError.RuleID == "" {
+				continue
+			}
+
+			if timedOutFiles[fileError.RuleID] == nil {
+				timedOutFiles[fileError.RuleID] = set.New[string]()
+			}
+			timedOutFiles[fileError.RuleID].Add(fileError.Filename)
+		}
+
+		dependencies = dataflow.Dependencies
+	}
+	projectFacts := facts.New(dependencies)
+
+	report := Report{
+		Languages: maputil.SortedStringKeys(scannedFiles),
+		Rules:     []Rule{},
+	}
+
+	for _, rules := range []map[string]*settings.Rule{config.Rules, config.BuiltInRules} {
+		for _, rule := range rules {
+			if !rule.PolicyType() {
+				continue
+			}
+
+			ruleCoverage := newRule(config, rule, scannedFiles, inputgocloc != nil, projectFacts)
+			if ruleFiles, timedOut := timedOutFiles[rule.Id]; timedOut && ruleCoverage.Status == StatusEvaluated {
+				ruleCoverage.TimedOutFiles = ruleFiles.Items()
+				slices.Sort(ruleCoverage.TimedOutFiles)
+
+				// a rule which timed out on every file it applies to wasn't
+				// evaluated at all
+				if len(ruleCoverage.TimedOutFiles) >= countFiles(scannedFiles, ruleCoverage.EvaluatedLanguages) {
+					ruleCoverage.Status = StatusSkipped
+					ruleCoverage.Reason = ReasonTimeout
+				}
+			}
+
+			report.Summary.Loaded++
+			if ruleCoverage.Status == StatusEvaluated {
+				report.Summary.Evaluated++
+			} else {
+				report.Summary.Skipped++
+			}
+
+			report.Rules = append(report.Rules, ruleCoverage)
+		}
+	}
+
+	for id, disabledRule := range config.DisabledRules {
+		report.Summary.Disabled++
+		report.Rules = append(report.Rules, Rule{
+			ID:        id,
+			Languages: disabledRule.Languages,
+			Status:    StatusDisabled,
+			Reason:    disabledRule.Reason,
+		})
+	}
+
+	slices.SortFunc(report.Rules, func(a, b Rule) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return report
+}
+
+// Write writes the coverage as JSON to the given path
+func Write(path string, report Report) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode rule coverage: %w", err)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write rule coverage: %w", err)
+	}
+
+	return nil
+}
+
+func newRule(
+	config settings.Config,
+	rule *settings.Rule,
+	scannedFiles map[string][]string,
+	languagesKnown bool,
+	projectFacts *facts.Facts,
+) Rule {
+	ruleCoverage := Rule{ID: rule.Id, Languages: rule.Languages}
+
+	scanner := flag.ScannerSAST
+	if rule.Id == "sample_data" {
+		scanner = flag.ScannerFixtures
+	} else if rule.Languages == nil && !rule.IsDetectorRule() {
+		scanner = flag.ScannerSecrets
+	}
+
+	if !slices.Contains(config.Scan.Scanner, scanner) {
+		ruleCoverage.Status = StatusSkipped
+		ruleCoverage.Reason = ReasonScannerDisabled
+		return ruleCoverage
+	}
+
+	if scanner == flag.ScannerSAST && !rule.IsDetectorRule() {
+		for _, language := range rule.Languages {
+			if !languagesKnown || len(filesFor(scannedFiles, language)) != 0 {
+				ruleCoverage.EvaluatedLanguages = append(ruleCoverage.EvaluatedLanguages, language)
+			}
+		}
+
+		if len(ruleCoverage.EvaluatedLanguages) == 0 {
+			ruleCoverage.Status = StatusSkipped
+			ruleCoverage.Reason = ReasonLanguageAbsent
+			return ruleCoverage
+		}
+	}
+
+	if !projectFacts.Satisfy(rule.Preconditions()) {
+		ruleCoverage.Status = StatusSkipped
+		ruleCoverage.Reason = ReasonRequirementsNotMet
+		return ruleCoverage
+	}
+
+	ruleCoverage.Status = StatusEvaluated
+	return ruleCoverage
+}
+
+// goclocRuleLanguages maps the gocloc languages scanned by the analyzer of
+// another language onto the language of its rules
+var goclocRuleLanguages = map[string]string{
+	"c header":   "c",
+	"c++":        "c",
+	"c++ header": "c",
+}
+
+// scannedFilesByLanguage returns the files found for each of the languages
+// rules can apply to
+func scannedFilesByLanguage(inputgocloc *gocloc.Result) map[string][]string {
+	scannedFiles := make(map[string][]string)
+	if inputgocloc == nil {
+		return scannedFiles
+	}
+
+	supportedLanguages := settings.GetSupportedRuleLanguages()
+	for _, language := range inputgocloc.Languages {
+		id := strings.ToLower(language.Name)
+		if ruleLanguage, ok := goclocRuleLanguages[id]; ok {
+			id = ruleLanguage
+		}
+
+		if supportedLanguages[id] {
+			scannedFiles[id] = append(scannedFiles[id], language.Files...)
+		}
+	}
+
+	return scannedFiles
+}
+
+// filesFor returns the scanned files a rule language applies to. JavaScript
+// rules also apply to TypeScript files.
+func filesFor(scannedFiles map[string][]string, language string) []string {
+	if language == "javascript" {
+		return append(slices.Clone(scannedFiles[language]), scannedFiles["typescript"]...)
+	}
+
+	return scannedFiles[language]
+}
+
+func countFiles(scannedFiles map[string][]string, languages []string) int {
+	count := 0
+	for _, language := range languages {
+		count += len(filesFor(scannedFiles, language))
+	}
+
+	return count
+}
@@ -0,0 +1,101 @@
+package coverage_test
+
+import (
+	"testing"
+
+	"github.com/hhatto/gocloc"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/coverage"
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+	"github.com/bearer/bearer/internal/report/output/types"
+)
+
+func TestNew(t *testing.T) {
+	config := settings.Config{
+		Scan: flag.ScanOptions{Scanner: []string{flag.ScannerSAST}},
+		Rules: map[string]*settings.Rule{
+			"ruby_logger":       {Id: "ruby_logger", Type: "risk", Languages: []string{"ruby"}},
+			"ruby_slow":         {Id: "ruby_slow", Type: "risk", Languages: []string{"ruby"}},
+			"ruby_rails_csrf":   {Id: "ruby_rails_csrf", Type: "risk", Languages: []string{"ruby"}, Requires: []string{"rails < 7.1"}},
+			"java_logger":       {Id: "java_logger", Type: "risk", Languages: []string{"java"}},
+			"javascript_logger": {Id: "javascript_logger", Type: "risk", Languages: []string{"javascript", "php"}},
+			"ruby_user_input":   {Id: "ruby_user_input", Type: "shared", Languages: []string{"ruby"}},
+			"gitleaks":          {Id: "gitleaks", Type: "risk"},
+		},
+		DisabledRules: map[string]settings.DisabledRule{
+			"ruby_old_logger": {Languages: []string{"ruby"}, Reason: settings.DisabledReasonDeprecated},
+		},
+	}
+
+	dataflow := &types.DataFlow{
+		Errors: []dataflowtypes.Error{
+			{Type: "error", Filename: "app/user.rb", Error: "failed to parse"},
+			{Type: "error", Filename: "app/user.rb", Error: "timed out", RuleID: "ruby_logger"},
+			{Type: "error", Filename: "app/user.rb", Error: "timed out", RuleID: "ruby_slow"},
+			{Type: "error", Filename: "app/account.rb", Error: "timed out", RuleID: "ruby_slow"},
+		},
+	}
+
+	inputgocloc := &gocloc.Result{
+		Languages: map[string]*gocloc.Language{
+			"Ruby":       {Name: "Ruby", Files: []string{"app/user.rb", "app/account.rb"}},
+			"TypeScript": {Name: "TypeScript", Files: []string{"web/index.ts"}},
+			"Markdown":   {Name: "Markdown", Files: []string{"README.md"}},
+		},
+	}
+
+	report := coverage.New(config, dataflow, inputgocloc)
+
+	assert.Equal(t, coverage.Summary{Loaded: 6, Evaluated: 2, Skipped: 4, Disabled: 1}, report.Summary)
+	assert.Equal(t, []string{"ruby", "typescript"}, report.Languages)
+	assert.Equal(t, []coverage.Rule{
+		{
+			ID:     "gitleaks",
+			Status: coverage.StatusSkipped,
+			Reason: coverage.ReasonScannerDisabled,
+		},
+		{
+			ID:        "java_logger",
+			Languages: []string{"java"},
+			Status:    coverage.StatusSkipped,
+			Reason:    coverage.ReasonLanguageAbsent,
+		},
+		{
+			ID:                 "javascript_logger",
+			Languages:          []string{"javascript", "php"},
+			Status:             coverage.StatusEvaluated,
+			EvaluatedLanguages: []string{"javascript"},
+		},
+		{
+			ID:                 "ruby_logger",
+			Languages:          []string{"ruby"},
+			Status:             coverage.StatusEvaluated,
+			EvaluatedLanguages: []string{"ruby"},
+			TimedOutFiles:      []string{"app/user.rb"},
+		},
+		{
+			ID:        "ruby_old_logger",
+			Languages: []string{"ruby"},
+			Status:    coverage.StatusDisabled,
+			Reason:    settings.DisabledReasonDeprecated,
+		},
+		{
+			ID:                 "ruby_rails_csrf",
+			Languages:          []string{"ruby"},
+			Status:             coverage.StatusSkipped,
+			Reason:             coverage.ReasonRequirementsNotMet,
+			EvaluatedLanguages: []string{"ruby"},
+		},
+		{
+			ID:                 "ruby_slow",
+			Languages:          []string{"ruby"},
+			Status:             coverage.StatusSkipped,
+			Reason:             coverage.ReasonTimeout,
+			EvaluatedLanguages: []string{"ruby"},
+			TimedOutFiles:      []string{"app/account.rb", "app/user.rb"},
+		},
+	}, report.Rules)
+}
@@ -4,3 +4,4 @@ const TypeRisk = "risk"
 const TypeDatatype = "data_type"
 const TypeVerifier = "verifier"
 const TypeShared = "shared"
+const TypeSanitizer = "sanitizer"
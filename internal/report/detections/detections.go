@@ -24,10 +24,15 @@ var TypeError DetectionType = "error"
 var TypeFileList DetectionType = "file_list"
 var TypeFileFailed DetectionType = "file_error"
 var TypeSecretleak DetectionType = "secret_leak"
+var TypeSampleData DetectionType = "sample_data"
+var TypeInfrastructureRisk DetectionType = "infrastructure_risk"
 var TypeCustom DetectionType = "custom"
 var TypeCustomClassified DetectionType = "custom_classified"
 var TypeCustomRisk DetectionType = "custom_risk"
 var TypeExpectedDetection DetectionType = "expected_detection"
+var TypeSuppressionWarning DetectionType = "suppression_warning"
+var TypeOperation DetectionType = "operation"
+var TypePurpose DetectionType = "purpose"
 
 type ReportDetection interface {
 	AddDetection(detectionType DetectionType, detectorType detectors.Type, source source.Source, value interface{})
@@ -51,6 +56,8 @@ type ErrorDetection struct {
 	Type    DetectionType `json:"type" yaml:"type"`
 	Message string        `json:"message" yaml:"message"`
 	File    string        `json:"file" yaml:"file"`
+	// RuleID is set when the error is a rule being skipped on the file
+	RuleID string `json:"rule_id,omitempty" yaml:"rule_id,omitempty"`
 }
 
 type FrameworkDetection struct {
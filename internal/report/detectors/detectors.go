@@ -13,6 +13,10 @@ const (
 	DetectorGo           Type = "golang"
 	DetectorJava         Type = "java"
 	DetectorJavascript   Type = "javascript"
+	DetectorKotlin       Type = "kotlin"
+	DetectorRust         Type = "rust"
+	DetectorC            Type = "c"
+	DetectorShell        Type = "shell"
 	DetectorTypescript   Type = "typescript"
 	DetectorTsx          Type = "tsx"
 	DetectorOpenAPI      Type = "openapi"
@@ -24,12 +28,20 @@ const (
 	DetectorSpring       Type = "spring"
 	DetectorSymfony      Type = "symfony"
 	DetectorYamlConfig   Type = "yaml_config"
+	DetectorTerraform    Type = "terraform"
+	DetectorKubernetes   Type = "kubernetes"
+	DetectorDockerfile   Type = "dockerfile"
 	DetectorSQL          Type = "sql"
 	DetectorProto        Type = "proto"
+	DetectorAvro         Type = "avro"
 	DetectorGraphQL      Type = "graphql"
 	DetectorHTML         Type = "html"
 	DetectorIPYNB        Type = "ipynb"
 	DetectorGitleaks     Type = "gitleaks"
+	DetectorSampleData   Type = "sample_data"
 	DetectorCustom       Type = "custom"
 	DetectorSchemaRb     Type = "schema_rb"
+	// Tables defined in SQL files. The name is that of the rule which
+	// previously detected them, so that rules listing it keep working
+	DetectorSQLCreateTable Type = "sql_lang_create_table"
 )
@@ -0,0 +1,194 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
+)
+
+var ErrUnknownReport = errors.New("unrecognised report; expected a security report in json or jsonv2 format")
+
+type Report struct {
+	New        []securitytypes.RawFinding `json:"new" yaml:"new"`
+	Fixed      []securitytypes.RawFinding `json:"fixed" yaml:"fixed"`
+	Persisting []securitytypes.RawFinding `json:"persisting" yaml:"persisting"`
+}
+
+// ReadFindings reads the findings from a saved security report. Reports in
+// the json format (keyed by severity), the jsonv2 format and the Bearer Cloud
+// format are supported.
+func ReadFindings(reader io.Reader) ([]securitytypes.RawFinding, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var report map[string]json.RawMessage
+	if err := json.Unmarshal(content, &report); err != nil {
+		return nil, ErrUnknownReport
+	}
+
+	if findings, ok := report["findings"]; ok {
+		if bytes.HasPrefix(bytes.TrimSpace(findings), []byte("[")) {
+			var rawFindings []securitytypes.RawFinding
+			if err := json.Unmarshal(findings, &rawFindings); err != nil {
+				return nil, fmt.Errorf("failed to read findings: %w", err)
+			}
+
+			return slices.DeleteFunc(rawFindings, func(finding securitytypes.RawFinding) bool {
+				return finding.Finding == nil
+			}), nil
+		}
+
+		return readFindingsBySeverity(findings)
+	}
+
+	return readFindingsBySeverity(content)
+}
+
+func readFindingsBySeverity(content []byte) ([]securitytypes.RawFinding, error) {
+	var findingsBySeverity map[string][]securitytypes.Finding
+	if err := json.Unmarshal(content, &findingsBySeverity); err != nil {
+		return nil, ErrUnknownReport
+	}
+
+	var rawFindings []securitytypes.RawFinding
+	for severity, findings := range findingsBySeverity {
+		if !slices.Contains(globaltypes.Severities, severity) {
+			return nil, ErrUnknownReport
+		}
+
+		for i := range findings {
+			rawFindings = append(rawFindings, securitytypes.RawFinding{Finding: &findings[i], Severity: severity})
+		}
+	}
+
+	return rawFindings, nil
+}
+
+// Compare matches findings between the baseline and current reports by
+// fingerprint. Findings also match by content fingerprint, so that findings
+// in moved files or shifted lines are not reported as new.
+func Compare(baseline, current []securitytypes.RawFinding) Report {
+	baselineFingerprints := fingerprints(baseline)
+	currentFingerprints := fingerprints(current)
+
+	report := Report{
+		New:        []securitytypes.RawFinding{},
+		Fixed:      []securitytypes.RawFinding{},
+		Persisting: []securitytypes.RawFinding{},
+	}
+
+	for _, finding := range current {
+		if matches(baselineFingerprints, finding) {
+			report.Persisting = append(report.Persisting, finding)
+		} else {
+			report.New = append(report.New, finding)
+		}
+	}
+
+	for _, finding := range baseline {
+		if !matches(currentFingerprints, finding) {
+			report.Fixed = append(report.Fixed, finding)
+		}
+	}
+
+	sortFindings(report.New)
+	sortFindings(report.Fixed)
+	sortFindings(report.Persisting)
+
+	return report
+}
+
+func fingerprints(findings []securitytypes.RawFinding) map[string]bool {
+	result := make(map[string]bool)
+	for _, finding := range findings {
+		result[finding.Fingerprint] = true
+		if finding.ContentFingerprint != "" {
+			result[finding.ContentFingerprint] = true
+		}
+	}
+
+	return result
+}
+
+func matches(fingerprints map[string]bool, finding securitytypes.RawFinding) bool {
+	return fingerprints[finding.Fingerprint] ||
+		(finding.ContentFingerprint != "" && fingerprints[finding.ContentFingerprint])
+}
+
+func sortFindings(findings []securitytypes.RawFinding) {
+	sort.Slice(findings, func(i, j int) bool {
+		severityI := slices.Index(globaltypes.Severities, findings[i].Severity)
+		severityJ := slices.Index(globaltypes.Severities, findings[j].Severity)
+		if severityI != severityJ {
+			return severityI < severityJ
+		}
+
+		if findings[i].Filename != findings[j].Filename {
+			return findings[i].Filename < findings[j].Filename
+		}
+
+		if findings[i].LineNumber != findings[j].LineNumber {
+			return findings[i].LineNumber < findings[j].LineNumber
+		}
+
+		return findings[i].Fingerprint < findings[j].Fingerprint
+	})
+}
+
+func (report Report) Markdown() string {
+	builder := &strings.Builder{}
+
+	builder.WriteString("## Bearer findings diff\n\n")
+	builder.WriteString(fmt.Sprintf(
+		"**%d new**, %d fixed, %d persisting\n",
+		len(report.New),
+		len(report.Fixed),
+		len(report.Persisting),
+	))
+
+	writeMarkdownSection(builder, "New findings", report.New)
+	writeMarkdownSection(builder, "Fixed findings", report.Fixed)
+	writeMarkdownSection(builder, "Persisting findings", report.Persisting)
+
+	return builder.String()
+}
+
+func writeMarkdownSection(builder *strings.Builder, title string, findings []securitytypes.RawFinding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	builder.WriteString(fmt.Sprintf("\n### %s\n\n", title))
+	builder.WriteString("| Severity | Rule | Location | Fingerprint |\n")
+	builder.WriteString("| --- | --- | --- | --- |\n")
+
+	for _, finding := range findings {
+		title := ""
+		if finding.Rule != nil {
+			title = finding.Title
+		}
+
+		builder.WriteString(fmt.Sprintf(
+			"| %s | %s | `%s:%d` | `%s` |\n",
+			finding.Severity,
+			escapeMarkdownCell(title),
+			finding.Filename,
+			finding.LineNumber,
+			finding.Fingerprint,
+		))
+	}
+}
+
+func escapeMarkdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
@@ -0,0 +1,38 @@
+package diff_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/report/diff"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+)
+
+func TestCompare(t *testing.T) {
+	report := diff.Compare(readFindings(t, "testdata/baseline.json"), readFindings(t, "testdata/current.json"))
+
+	cupaloy.SnapshotT(t, report)
+}
+
+func TestMarkdown(t *testing.T) {
+	report := diff.Compare(readFindings(t, "testdata/baseline.json"), readFindings(t, "testdata/current.json"))
+
+	cupaloy.SnapshotT(t, report.Markdown())
+}
+
+func readFindings(t *testing.T, path string) []securitytypes.RawFinding {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open file, err: %s", err)
+	}
+	defer file.Close()
+
+	findings, err := diff.ReadFindings(file)
+	if err != nil {
+		t.Fatalf("failed to read findings, err: %s", err)
+	}
+
+	return findings
+}
@@ -0,0 +1,162 @@
+package dsar
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bearer/bearer/internal/report/output/billofdata"
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+const componentTypeThirdParty = "external_service"
+
+// Export maps the data types matching a query to every place they are
+// processed, to be used as a starting point for data subject access and
+// deletion requests
+type Export struct {
+	Query     string     `json:"query" yaml:"query"`
+	DataTypes []DataType `json:"data_types" yaml:"data_types"`
+}
+
+type DataType struct {
+	Name           string      `json:"name" yaml:"name"`
+	CategoryName   string      `json:"category_name,omitempty" yaml:"category_name,omitempty"`
+	CategoryGroups []string    `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
+	SubjectNames   []string    `json:"subject_names" yaml:"subject_names"`
+	Locations      []Location  `json:"locations" yaml:"locations"`
+	Components     []Component `json:"components" yaml:"components"`
+	ThirdParties   []Component `json:"third_parties" yaml:"third_parties"`
+}
+
+type Location struct {
+	Filename    string `json:"filename" yaml:"filename"`
+	LineNumber  int    `json:"line_number" yaml:"line_number"`
+	SubjectName string `json:"subject_name,omitempty" yaml:"subject_name,omitempty"`
+	ObjectName  string `json:"object_name,omitempty" yaml:"object_name,omitempty"`
+	FieldName   string `json:"field_name,omitempty" yaml:"field_name,omitempty"`
+	Stored      bool   `json:"stored" yaml:"stored"`
+	Encrypted   *bool  `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+	Purpose     string `json:"purpose,omitempty" yaml:"purpose,omitempty"`
+	Retention   string `json:"retention,omitempty" yaml:"retention,omitempty"`
+}
+
+// Component is a data store, internal service or third party found in the
+// same files as the data type
+type Component struct {
+	Name     string   `json:"name" yaml:"name"`
+	Type     string   `json:"type" yaml:"type"`
+	SubType  string   `json:"sub_type" yaml:"sub_type"`
+	Category string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Files    []string `json:"files" yaml:"files"`
+}
+
+// Build exports the data types whose name contains the query, ignoring case,
+// eg. `email` matches `Email Address`
+func Build(dataflow *outputtypes.DataFlow, query string) (Export, error) {
+	export := Export{Query: query, DataTypes: []DataType{}}
+	if dataflow == nil {
+		return export, nil
+	}
+
+	billOfData, err := billofdata.ReportBillOfData(dataflow)
+	if err != nil {
+		return export, err
+	}
+
+	needle := strings.ToLower(strings.TrimSpace(query))
+	for _, dataType := range dataflow.Datatypes {
+		if !strings.Contains(strings.ToLower(dataType.Name), needle) {
+			continue
+		}
+
+		exported, files := buildDataType(dataType)
+		for _, component := range billOfData.Components {
+			var sharedFiles []string
+			for _, filename := range component.Files {
+				if files.Has(filename) {
+					sharedFiles = append(sharedFiles, filename)
+				}
+			}
+
+			if len(sharedFiles) == 0 {
+				continue
+			}
+
+			dsarComponent := Component{
+				Name:     component.Name,
+				Type:     component.Type,
+				SubType:  component.SubType,
+				Category: component.Category,
+				Files:    sharedFiles,
+			}
+
+			if component.Type == componentTypeThirdParty {
+				exported.ThirdParties = append(exported.ThirdParties, dsarComponent)
+			} else {
+				exported.Components = append(exported.Components, dsarComponent)
+			}
+		}
+
+		export.DataTypes = append(export.DataTypes, exported)
+	}
+
+	sort.Slice(export.DataTypes, func(i, j int) bool {
+		return export.DataTypes[i].Name < export.DataTypes[j].Name
+	})
+
+	return export, nil
+}
+
+func buildDataType(dataType dataflowtypes.Datatype) (DataType, set.Set[string]) {
+	exported := DataType{
+		Name:           dataType.Name,
+		CategoryName:   dataType.CategoryName,
+		CategoryGroups: dataType.CategoryGroups,
+		Locations:      []Location{},
+		Components:     []Component{},
+		ThirdParties:   []Component{},
+	}
+
+	files := set.New[string]()
+	subjects := make(map[string]struct{})
+	for _, detector := range dataType.Detectors {
+		for _, location := range detector.Locations {
+			subjectName := ""
+			if location.SubjectName != nil {
+				subjectName = *location.SubjectName
+			}
+			if subjectName != "" {
+				subjects[subjectName] = struct{}{}
+			}
+
+			exported.Locations = append(exported.Locations, Location{
+				Filename:    location.Filename,
+				LineNumber:  location.StartLineNumber,
+				SubjectName: subjectName,
+				ObjectName:  location.ObjectName,
+				FieldName:   location.FieldName,
+				Stored:      location.Stored != nil && *location.Stored,
+				Encrypted:   location.Encrypted,
+				Purpose:     location.Purpose,
+				Retention:   location.Retention,
+			})
+
+			files.Add(location.Filename)
+		}
+	}
+
+	sort.SliceStable(exported.Locations, func(i, j int) bool {
+		if exported.Locations[i].Filename != exported.Locations[j].Filename {
+			return exported.Locations[i].Filename < exported.Locations[j].Filename
+		}
+
+		return exported.Locations[i].LineNumber < exported.Locations[j].LineNumber
+	})
+
+	exported.SubjectNames = maputil.SortedStringKeys(subjects)
+
+	return exported, files
+}
@@ -0,0 +1,46 @@
+package dsar_test
+
+import (
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/report/dsar"
+	"github.com/bearer/bearer/internal/report/merge"
+	"github.com/bearer/bearer/internal/util/output"
+)
+
+func TestBuild(t *testing.T) {
+	service, err := merge.ReadService("testdata/dataflow.json")
+	if err != nil {
+		t.Fatalf("failed to read report, err: %s", err)
+	}
+
+	export, err := dsar.Build(service.Dataflow, "EMAIL")
+	if err != nil {
+		t.Fatalf("failed to build export, err: %s", err)
+	}
+
+	content, err := output.ReportYAML(export)
+	if err != nil {
+		t.Fatalf("failed to generate YAML output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, content)
+}
+
+func TestBuildNoMatch(t *testing.T) {
+	service, err := merge.ReadService("testdata/dataflow.json")
+	if err != nil {
+		t.Fatalf("failed to read report, err: %s", err)
+	}
+
+	export, err := dsar.Build(service.Dataflow, "passport")
+	if err != nil {
+		t.Fatalf("failed to build export, err: %s", err)
+	}
+
+	if len(export.DataTypes) != 0 {
+		t.Errorf("expected no data types, got %d", len(export.DataTypes))
+	}
+}
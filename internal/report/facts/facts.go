@@ -0,0 +1,271 @@
+package facts
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+)
+
+var operators = []string{"<=", ">=", "!=", "==", "<", ">", "="}
+
+// lockfileDetectors are the dependency detectors reading resolved versions,
+// rather than the version ranges of a manifest
+var lockfileDetectors = []string{
+	"composerlock",
+	"gemfile-lock",
+	"gosum",
+	"ivy",
+	"mvnplugin",
+	"npm",
+	"nuget",
+	"pipdeptree",
+	"piplock",
+	"poetry",
+	"yarn.lock",
+}
+
+// Precondition is a requirement of a rule on the project, eg. `rails < 7.1`
+// or `jwt`. A precondition without a version only requires the dependency to
+// be present.
+type Precondition struct {
+	Name     string
+	Operator string
+	Version  string
+}
+
+// Facts are the dependencies of the project, along with their versions, as
+// resolved from the lockfiles and manifests found during the scan
+type Facts struct {
+	// versions of each dependency by the directory of the files declaring it
+	directories map[string]map[string][]string
+}
+
+// ParsePrecondition reads a precondition of the form `<name> [<op> <version>]`
+func ParsePrecondition(value string) (Precondition, error) {
+	value = strings.TrimSpace(value)
+
+	end := strings.IndexFunc(value, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune("<>=!", r)
+	})
+	if end == -1 {
+		end = len(value)
+	}
+
+	precondition := Precondition{Name: value[:end]}
+	if precondition.Name == "" {
+		return Precondition{}, fmt.Errorf("precondition '%s' is missing a dependency name", value)
+	}
+
+	rest := strings.TrimSpace(value[end:])
+	if rest == "" {
+		return precondition, nil
+	}
+
+	for _, operator := range operators {
+		if strings.HasPrefix(rest, operator) {
+			precondition.Operator = operator
+			precondition.Version = strings.TrimSpace(rest[len(operator):])
+			break
+		}
+	}
+
+	if precondition.Operator == "" {
+		return Precondition{}, fmt.Errorf("precondition '%s' has an unknown operator", value)
+	}
+
+	if _, ok := parseVersion(precondition.Version); !ok {
+		return Precondition{}, fmt.Errorf("precondition '%s' has an invalid version", value)
+	}
+
+	return precondition, nil
+}
+
+// New collects the facts of a project from its dependencies. When a lockfile
+// resolves a dependency, the version ranges of the manifests next to it are
+// ignored.
+func New(dependencies []dataflowtypes.Dependency) *Facts {
+	locked := make(map[string]map[string][]string)
+	declared := make(map[string]map[string][]string)
+
+	for _, dependency := range dependencies {
+		versions := declared
+		if slices.Contains(lockfileDetectors, dependency.Detector) {
+			versions = locked
+		}
+
+		directory := path.Dir(dependency.Filename)
+		if versions[directory] == nil {
+			versions[directory] = make(map[string][]string)
+		}
+
+		name := strings.ToLower(dependency.Name)
+		versions[directory][name] = append(versions[directory][name], dependency.Version)
+	}
+
+	for directory, lockedVersions := range locked {
+		if declared[directory] == nil {
+			declared[directory] = make(map[string][]string)
+		}
+
+		for name, versions := range lockedVersions {
+			declared[directory][name] = versions
+		}
+	}
+
+	return &Facts{directories: declared}
+}
+
+// Satisfy reports whether the project meets all of the preconditions. A
+// precondition on a version is met when any version of the dependency in use
+// matches it. Versions which cannot be resolved, such as git references, never
+// match.
+func (facts *Facts) Satisfy(preconditions []Precondition) bool {
+	for _, precondition := range preconditions {
+		if !facts.satisfies(precondition, facts.allVersions(precondition.Name)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SatisfyFor reports whether the project of a file meets all of the
+// preconditions. Each precondition is checked against the dependency files
+// closest to the file which declare the dependency, so that findings in a
+// project of a monorepo don't match on the versions of other projects. When
+// no directory containing the file declares the dependency, every version in
+// the project is considered.
+func (facts *Facts) SatisfyFor(filename string, preconditions []Precondition) bool {
+	for _, precondition := range preconditions {
+		versions, ok := facts.closestVersions(filename, precondition.Name)
+		if !ok {
+			versions = facts.allVersions(precondition.Name)
+		}
+
+		if !facts.satisfies(precondition, versions) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (facts *Facts) closestVersions(filename string, name string) ([]string, bool) {
+	name = strings.ToLower(name)
+
+	for directory := path.Dir(filename); ; directory = path.Dir(directory) {
+		if versions, ok := facts.directories[directory][name]; ok {
+			return versions, true
+		}
+
+		if directory == "." || directory == "/" {
+			return nil, false
+		}
+	}
+}
+
+func (facts *Facts) allVersions(name string) []string {
+	name = strings.ToLower(name)
+
+	var result []string
+	for _, versions := range facts.directories {
+		result = append(result, versions[name]...)
+	}
+
+	return result
+}
+
+func (facts *Facts) satisfies(precondition Precondition, versions []string) bool {
+	if len(versions) == 0 {
+		return false
+	}
+
+	if precondition.Operator == "" {
+		return true
+	}
+
+	expected, _ := parseVersion(precondition.Version)
+	for _, version := range versions {
+		actual, ok := parseVersion(version)
+		if !ok {
+			continue
+		}
+
+		if compare(precondition.Operator, compareVersions(actual, expected)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func compare(operator string, comparison int) bool {
+	switch operator {
+	case "<":
+		return comparison < 0
+	case "<=":
+		return comparison <= 0
+	case ">":
+		return comparison > 0
+	case ">=":
+		return comparison >= 0
+	case "!=":
+		return comparison != 0
+	default:
+		return comparison == 0
+	}
+}
+
+// parseVersion reads the numeric segments of a version, ignoring any range
+// prefix (eg. `^1.2.0`, `~> 7.0`) and pre-release or build suffix
+func parseVersion(version string) ([]int, bool) {
+	version = strings.TrimLeft(version, "^~>=<v ")
+
+	var segments []int
+	for _, segment := range strings.Split(version, ".") {
+		end := strings.IndexFunc(segment, func(r rune) bool { return !unicode.IsDigit(r) })
+		if end == -1 {
+			end = len(segment)
+		}
+
+		number, err := strconv.Atoi(segment[:end])
+		if err != nil {
+			break
+		}
+
+		segments = append(segments, number)
+		if end != len(segment) {
+			break
+		}
+	}
+
+	return segments, len(segments) != 0
+}
+
+// compareVersions compares versions segment by segment, treating missing
+// segments as zero so that `7.1` and `7.1.0` are equal
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,168 @@
+package facts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/report/facts"
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+)
+
+func TestParsePrecondition(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  facts.Precondition
+		err   bool
+	}{
+		{
+			name:  "presence",
+			value: "jwt",
+			want:  facts.Precondition{Name: "jwt"},
+		},
+		{
+			name:  "version",
+			value: "rails < 7.1",
+			want:  facts.Precondition{Name: "rails", Operator: "<", Version: "7.1"},
+		},
+		{
+			name:  "no spaces",
+			value: "express>=4.17.1",
+			want:  facts.Precondition{Name: "express", Operator: ">=", Version: "4.17.1"},
+		},
+		{
+			name:  "scoped package",
+			value: "@nestjs/core != 10.0.0",
+			want:  facts.Precondition{Name: "@nestjs/core", Operator: "!=", Version: "10.0.0"},
+		},
+		{
+			name:  "missing name",
+			value: "< 7.1",
+			err:   true,
+		},
+		{
+			name:  "unknown operator",
+			value: "rails ~= 7.1",
+			err:   true,
+		},
+		{
+			name:  "invalid version",
+			value: "rails < latest",
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := facts.ParsePrecondition(test.value)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSatisfy(t *testing.T) {
+	projectFacts := facts.New([]dataflowtypes.Dependency{
+		{Name: "rails", Version: "7.0.4.3"},
+		{Name: "jwt", Version: "2.7.1"},
+		{Name: "express", Version: "^4.18.2"},
+		{Name: "internal-gem", Version: "git"},
+	})
+
+	tests := []struct {
+		name          string
+		preconditions []string
+		want          bool
+	}{
+		{name: "no preconditions", want: true},
+		{name: "present", preconditions: []string{"jwt"}, want: true},
+		{name: "absent", preconditions: []string{"devise"}, want: false},
+		{name: "case insensitive", preconditions: []string{"Rails"}, want: true},
+		{name: "older version", preconditions: []string{"rails < 7.1"}, want: true},
+		{name: "newer version", preconditions: []string{"rails >= 7.1"}, want: false},
+		{name: "missing segments", preconditions: []string{"rails = 7.0.4.3.0"}, want: true},
+		{name: "range prefix", preconditions: []string{"express > 4.17"}, want: true},
+		{name: "unresolved version", preconditions: []string{"internal-gem > 1"}, want: false},
+		{name: "all preconditions", preconditions: []string{"rails < 7.1", "devise"}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var preconditions []facts.Precondition
+			for _, value := range test.preconditions {
+				precondition, err := facts.ParsePrecondition(value)
+				if err != nil {
+					t.Fatalf("failed to parse precondition: %s", err)
+				}
+
+				preconditions = append(preconditions, precondition)
+			}
+
+			assert.Equal(t, test.want, projectFacts.Satisfy(preconditions))
+		})
+	}
+}
+
+func TestSatisfyPrefersLockfiles(t *testing.T) {
+	projectFacts := facts.New([]dataflowtypes.Dependency{
+		{Name: "jsonwebtoken", Version: "8.5.1", Filename: "package.json", Detector: "package-json"},
+		{Name: "jsonwebtoken", Version: "9.0.2", Filename: "package-lock.json", Detector: "npm"},
+		{Name: "express", Version: "4.18.2", Filename: "package.json", Detector: "package-json"},
+	})
+
+	satisfy := func(value string) bool {
+		precondition, err := facts.ParsePrecondition(value)
+		if err != nil {
+			t.Fatalf("failed to parse precondition: %s", err)
+		}
+
+		return projectFacts.Satisfy([]facts.Precondition{precondition})
+	}
+
+	assert.False(t, satisfy("jsonwebtoken < 9"), "manifest range of a locked dependency")
+	assert.True(t, satisfy("jsonwebtoken >= 9"), "locked version")
+	assert.True(t, satisfy("express < 5"), "dependency missing from the lockfile")
+}
+
+func TestSatisfyFor(t *testing.T) {
+	projectFacts := facts.New([]dataflowtypes.Dependency{
+		{Name: "rails", Version: "6.1.7", Filename: "legacy/Gemfile.lock", Detector: "gemfile-lock"},
+		{Name: "rails", Version: "7.1.2", Filename: "app/Gemfile.lock", Detector: "gemfile-lock"},
+		{Name: "jwt", Version: "2.7.1", Filename: "Gemfile.lock", Detector: "gemfile-lock"},
+	})
+
+	precondition, err := facts.ParsePrecondition("rails < 7.0")
+	if err != nil {
+		t.Fatalf("failed to parse precondition: %s", err)
+	}
+	preconditions := []facts.Precondition{precondition}
+
+	tests := []struct {
+		name     string
+		filename string
+		want     bool
+	}{
+		{name: "affected project", filename: "legacy/app/controllers/users_controller.rb", want: true},
+		{name: "patched project", filename: "app/app/controllers/users_controller.rb", want: false},
+		{name: "outside of the projects", filename: "scripts/seed.rb", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, projectFacts.SatisfyFor(test.filename, preconditions))
+		})
+	}
+
+	jwtPrecondition, err := facts.ParsePrecondition("jwt")
+	if err != nil {
+		t.Fatalf("failed to parse precondition: %s", err)
+	}
+
+	assert.True(t, projectFacts.SatisfyFor("app/app/models/user.rb", []facts.Precondition{jwtPrecondition}))
+}
@@ -0,0 +1,149 @@
+package feedback
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/bearer/bearer/cmd/bearer/build"
+	"github.com/bearer/bearer/internal/languages/c"
+	"github.com/bearer/bearer/internal/languages/golang"
+	"github.com/bearer/bearer/internal/languages/java"
+	"github.com/bearer/bearer/internal/languages/javascript"
+	"github.com/bearer/bearer/internal/languages/kotlin"
+	"github.com/bearer/bearer/internal/languages/php"
+	"github.com/bearer/bearer/internal/languages/python"
+	"github.com/bearer/bearer/internal/languages/ruby"
+	"github.com/bearer/bearer/internal/languages/rust"
+	"github.com/bearer/bearer/internal/languages/shell"
+	"github.com/bearer/bearer/internal/languages/sql"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/scanner/language"
+	"github.com/bearer/bearer/internal/util/file"
+)
+
+var ErrUnsupportedLanguage = errors.New("the language of the file is not supported")
+
+// Report is the anonymized context of a false positive finding. It contains
+// no source code, filenames or fingerprints, only what is needed to identify
+// the rule and the structure of the code it matched.
+type Report struct {
+	BearerVersion string   `json:"bearer_version" yaml:"bearer_version"`
+	RuleID        string   `json:"rule_id" yaml:"rule_id"`
+	CWEIDs        []string `json:"cwe_ids,omitempty" yaml:"cwe_ids,omitempty"`
+	OWASP         []string `json:"owasp,omitempty" yaml:"owasp,omitempty"`
+	Severity      string   `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Language      string   `json:"language,omitempty" yaml:"language,omitempty"`
+	NodeType      string   `json:"node_type,omitempty" yaml:"node_type,omitempty"`
+	ShapeHash     string   `json:"shape_hash,omitempty" yaml:"shape_hash,omitempty"`
+	Reason        string   `json:"reason" yaml:"reason"`
+	CreatedAt     string   `json:"created_at" yaml:"created_at"`
+}
+
+// FindFinding returns the finding matching any of its fingerprints
+func FindFinding(findings []securitytypes.RawFinding, fingerprint string) *securitytypes.RawFinding {
+	for i, finding := range findings {
+		if finding.Fingerprint == fingerprint ||
+			finding.ContentFingerprint == fingerprint ||
+			finding.OldFingerprint == fingerprint ||
+			finding.PreviousFingerprint == fingerprint {
+			return &findings[i]
+		}
+	}
+
+	return nil
+}
+
+func New(finding securitytypes.RawFinding, reason string, createdAt time.Time) *Report {
+	report := &Report{
+		BearerVersion: build.Version,
+		Severity:      finding.Severity,
+		Reason:        reason,
+		CreatedAt:     createdAt.UTC().Format(time.RFC3339),
+	}
+
+	if finding.Rule != nil {
+		report.RuleID = finding.Id
+		report.CWEIDs = finding.CWEIDs
+		report.OWASP = finding.OWASP
+	}
+
+	return report
+}
+
+// AddShape parses the source file of the finding and records the type of the
+// matched syntax node and a hash of the shape of its syntax tree. The shape
+// is made up of the node types and field names only, so two matches with the
+// same structure but different identifiers or values have the same hash.
+func (report *Report) AddShape(filename string, sink securitytypes.Sink) error {
+	fileInfo, err := file.FileInfoFromPath(filename)
+	if err != nil {
+		return err
+	}
+
+	fileLanguage := findLanguage(fileInfo.Language)
+	if fileLanguage == nil {
+		return ErrUnsupportedLanguage
+	}
+	report.Language = fileLanguage.ID()
+
+	if sink.Location == nil {
+		return errors.New("the finding has no location")
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	parser := sitter.NewParser()
+	defer parser.Close()
+	parser.SetLanguage(fileLanguage.SitterLanguage())
+
+	tree, err := parser.ParseCtx(context.Background(), nil, content)
+	if err != nil {
+		return err
+	}
+	defer tree.Close()
+
+	node := tree.RootNode().NamedDescendantForPointRange(
+		sitter.Point{Row: uint32(max(sink.Start-1, 0)), Column: uint32(max(sink.Column.Start-1, 0))},
+		sitter.Point{Row: uint32(max(sink.End-1, 0)), Column: uint32(max(sink.Column.End-1, 0))},
+	)
+	if node == nil {
+		return errors.New("no syntax node found at the finding location")
+	}
+
+	report.NodeType = node.Type()
+	report.ShapeHash = fmt.Sprintf("%x", sha256.Sum256([]byte(node.String())))
+
+	return nil
+}
+
+func findLanguage(enryLanguage string) language.Language {
+	for _, candidate := range []language.Language{
+		java.Get(),
+		javascript.Get(),
+		ruby.Get(),
+		php.Get(),
+		golang.Get(),
+		python.Get(),
+		kotlin.Get(),
+		rust.Get(),
+		c.Get(),
+		sql.Get(),
+		shell.Get(),
+	} {
+		if slices.Contains(candidate.EnryLanguages(), enryLanguage) {
+			return candidate
+		}
+	}
+
+	return nil
+}
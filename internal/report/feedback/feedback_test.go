@@ -0,0 +1,77 @@
+package feedback_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/report/feedback"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+)
+
+func sinkAt(line int, startColumn int, endColumn int) securitytypes.Sink {
+	return securitytypes.Sink{
+		Location: &securitytypes.Location{
+			Start:  line,
+			End:    line,
+			Column: securitytypes.Column{Start: startColumn, End: endColumn},
+		},
+	}
+}
+
+func TestFindFinding(t *testing.T) {
+	findings := []securitytypes.RawFinding{
+		{Finding: &securitytypes.Finding{Fingerprint: "a_0", ContentFingerprint: "b_0"}},
+		{Finding: &securitytypes.Finding{Fingerprint: "c_0", PreviousFingerprint: "d_0"}},
+	}
+
+	assert.Equal(t, "a_0", feedback.FindFinding(findings, "b_0").Fingerprint)
+	assert.Equal(t, "c_0", feedback.FindFinding(findings, "d_0").Fingerprint)
+	assert.Nil(t, feedback.FindFinding(findings, "e_0"))
+}
+
+func TestNew(t *testing.T) {
+	finding := securitytypes.RawFinding{
+		Finding: &securitytypes.Finding{
+			Rule:         &securitytypes.Rule{Id: "ruby_lang_logger", CWEIDs: []string{"532"}},
+			FullFilename: "testdata/original.rb",
+			Fingerprint:  "a_0",
+			Sink:         sinkAt(1, 1, 37),
+		},
+		Severity: "high",
+	}
+
+	report := feedback.New(finding, "sanitized upstream", time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	assert.NoError(t, report.AddShape(finding.FullFilename, finding.Sink))
+
+	assert.Equal(t, "ruby_lang_logger", report.RuleID)
+	assert.Equal(t, []string{"532"}, report.CWEIDs)
+	assert.Equal(t, "high", report.Severity)
+	assert.Equal(t, "ruby", report.Language)
+	assert.Equal(t, "call", report.NodeType)
+	assert.Len(t, report.ShapeHash, 64)
+	assert.Equal(t, "sanitized upstream", report.Reason)
+	assert.Equal(t, "2026-03-01T09:00:00Z", report.CreatedAt)
+}
+
+func TestAddShape(t *testing.T) {
+	shapeOf := func(filename string, sink securitytypes.Sink) string {
+		report := &feedback.Report{}
+		if err := report.AddShape(filename, sink); err != nil {
+			t.Fatalf("failed to add shape, err: %s", err)
+		}
+
+		return report.ShapeHash
+	}
+
+	original := shapeOf("testdata/original.rb", sinkAt(1, 1, 37))
+	similar := shapeOf("testdata/similar.rb", sinkAt(2, 1, 33))
+	different := shapeOf("testdata/different.rb", sinkAt(1, 1, 37))
+
+	assert.Equal(t, original, similar, "identifiers and values should not affect the shape")
+	assert.NotEqual(t, original, different)
+
+	report := &feedback.Report{}
+	assert.ErrorIs(t, report.AddShape("testdata/notes.txt", sinkAt(1, 1, 2)), feedback.ErrUnsupportedLanguage)
+}
@@ -0,0 +1 @@
+logger.info("user info" + user.email)
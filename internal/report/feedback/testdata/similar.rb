@@ -0,0 +1,2 @@
+# another file
+log.warn("account", account.name)
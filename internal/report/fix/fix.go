@@ -0,0 +1,237 @@
+package fix
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+)
+
+const (
+	reasonNoLocation   = "the finding has no location"
+	reasonChanged      = "the code has changed since the report"
+	reasonNoChange     = "the fix doesn't change the code"
+	reasonOverlapping  = "the code overlaps the fix of another finding"
+	reasonInvalidRegex = "the fix of the rule is invalid"
+)
+
+// File is the fixed content of a source file
+type File struct {
+	Filename string
+	Original string
+	Fixed    string
+	// Fingerprints are those of the findings fixed in the file
+	Fingerprints []string
+}
+
+// Skipped is a finding whose rule has a fix which couldn't be applied
+type Skipped struct {
+	Fingerprint string
+	RuleID      string
+	Reason      string
+}
+
+type edit struct {
+	findings    []securitytypes.RawFinding
+	start       int
+	end         int
+	replacement string
+}
+
+// Plan computes the fixes of the findings whose rule has one, by file. The
+// code matched by each finding is read from the file rather than the report,
+// so findings are skipped when the file has changed since the scan.
+func Plan(findings []securitytypes.RawFinding) ([]*File, []Skipped) {
+	var skipped []Skipped
+	findingsByFile := make(map[string][]securitytypes.RawFinding)
+
+	for _, finding := range findings {
+		if finding.Finding == nil || finding.Rule == nil || finding.Rule.Fix == nil {
+			continue
+		}
+
+		findingsByFile[finding.FullFilename] = append(findingsByFile[finding.FullFilename], finding)
+	}
+
+	var files []*File
+	for _, filename := range maputil.SortedStringKeys(findingsByFile) {
+		file, fileSkipped := planFile(filename, findingsByFile[filename])
+		skipped = append(skipped, fileSkipped...)
+
+		if file != nil {
+			files = append(files, file)
+		}
+	}
+
+	return files, skipped
+}
+
+// Diff returns the changes to the file as a unified diff
+func (file *File) Diff() (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(file.Original),
+		B:        splitLines(file.Fixed),
+		FromFile: "a/" + file.Filename,
+		ToFile:   "b/" + file.Filename,
+		Context:  3,
+	})
+}
+
+// Apply writes the fixed content to the file
+func (file *File) Apply() error {
+	info, err := os.Stat(file.Filename)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file.Filename, []byte(file.Fixed), info.Mode().Perm())
+}
+
+func planFile(filename string, findings []securitytypes.RawFinding) (*File, []Skipped) {
+	var skipped []Skipped
+	skip := func(finding securitytypes.RawFinding, reason string) {
+		skipped = append(skipped, Skipped{Fingerprint: finding.Fingerprint, RuleID: finding.Rule.Id, Reason: reason})
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		for _, finding := range findings {
+			skip(finding, fmt.Sprintf("failed to read %s: %s", filename, err))
+		}
+
+		return nil, skipped
+	}
+
+	original := string(content)
+	lineStarts := getLineStarts(original)
+
+	var edits []*edit
+	for _, finding := range findings {
+		if finding.Sink.Location == nil {
+			skip(finding, reasonNoLocation)
+			continue
+		}
+
+		start, startOk := offsetOf(original, lineStarts, finding.Sink.Start, finding.Sink.Column.Start)
+		end, endOk := offsetOf(original, lineStarts, finding.Sink.End, finding.Sink.Column.End)
+		if !startOk || !endOk || start > end || original[start:end] != finding.Sink.Content {
+			skip(finding, reasonChanged)
+			continue
+		}
+
+		pattern, err := regexp.Compile(finding.Rule.Fix.Replace)
+		if err != nil {
+			skip(finding, reasonInvalidRegex)
+			continue
+		}
+
+		replacement := pattern.ReplaceAllString(original[start:end], finding.Rule.Fix.With)
+		if replacement == original[start:end] {
+			skip(finding, reasonNoChange)
+			continue
+		}
+
+		edits = append(edits, &edit{
+			findings:    []securitytypes.RawFinding{finding},
+			start:       start,
+			end:         end,
+			replacement: replacement,
+		})
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var kept []*edit
+	for _, edit := range edits {
+		if len(kept) != 0 {
+			previous := kept[len(kept)-1]
+
+			// findings for several data types match the same code
+			if edit.start == previous.start && edit.end == previous.end && edit.replacement == previous.replacement {
+				previous.findings = append(previous.findings, edit.findings...)
+				continue
+			}
+
+			if edit.start < previous.end {
+				skip(edit.findings[0], reasonOverlapping)
+				continue
+			}
+		}
+
+		kept = append(kept, edit)
+	}
+
+	if len(kept) == 0 {
+		return nil, skipped
+	}
+
+	var fixed strings.Builder
+	var fingerprints []string
+	position := 0
+	for _, edit := range kept {
+		fixed.WriteString(original[position:edit.start])
+		fixed.WriteString(edit.replacement)
+		position = edit.end
+
+		for _, finding := range edit.findings {
+			fingerprints = append(fingerprints, finding.Fingerprint)
+		}
+	}
+	fixed.WriteString(original[position:])
+
+	return &File{
+		Filename:     filename,
+		Original:     original,
+		Fixed:        fixed.String(),
+		Fingerprints: fingerprints,
+	}, skipped
+}
+
+// splitLines splits the content into lines ending with a newline, unlike
+// difflib.SplitLines it doesn't add an empty line after a final newline
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n"
+	return lines
+}
+
+func getLineStarts(content string) []int {
+	lineStarts := []int{0}
+	for i, char := range content {
+		if char == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+
+	return lineStarts
+}
+
+// offsetOf returns the byte offset of a 1-based line and column, where
+// columns count bytes as in the report locations
+func offsetOf(content string, lineStarts []int, line int, column int) (int, bool) {
+	if line < 1 || line > len(lineStarts) || column < 1 {
+		return 0, false
+	}
+
+	lineEnd := len(content)
+	if line < len(lineStarts) {
+		lineEnd = lineStarts[line] - 1
+	}
+
+	offset := lineStarts[line-1] + column - 1
+	if offset > lineEnd {
+		return 0, false
+	}
+
+	return offset, true
+}
@@ -0,0 +1,87 @@
+package fix_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/report/fix"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+)
+
+var weakHashRule = &securitytypes.Rule{
+	Id:  "ruby_lang_weak_hash",
+	Fix: &securitytypes.Fix{Replace: `Digest::MD5`, With: "Digest::SHA256"},
+}
+
+func finding(fingerprint string, rule *securitytypes.Rule, line int, startColumn int, content string) securitytypes.RawFinding {
+	return securitytypes.RawFinding{
+		Finding: &securitytypes.Finding{
+			Rule:         rule,
+			FullFilename: "testdata/app.rb",
+			Fingerprint:  fingerprint,
+			Sink: securitytypes.Sink{
+				Location: &securitytypes.Location{
+					Start:  line,
+					End:    line,
+					Column: securitytypes.Column{Start: startColumn, End: startColumn + len(content)},
+				},
+				Content: content,
+			},
+		},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	files, skipped := fix.Plan([]securitytypes.RawFinding{
+		finding("a_0", weakHashRule, 1, 10, "Digest::MD5.hexdigest(user.password)"),
+		// another data type of the same match
+		finding("a_1", weakHashRule, 1, 10, "Digest::MD5.hexdigest(user.password)"),
+		finding("b_0", weakHashRule, 2, 9, "Digest::MD5.hexdigest(token)"),
+		finding("c_0", &securitytypes.Rule{Id: "ruby_lang_logger"}, 2, 9, "Digest::MD5.hexdigest(token)"),
+	})
+
+	assert.Empty(t, skipped)
+	if assert.Len(t, files, 1) {
+		assert.Equal(t, []string{"a_0", "a_1", "b_0"}, files[0].Fingerprints)
+		assert.Equal(t, "digest = Digest::SHA256.hexdigest(user.password)\nother = Digest::SHA256.hexdigest(token)\n", files[0].Fixed)
+
+		diff, err := files[0].Diff()
+		assert.NoError(t, err)
+		assert.Equal(t, `--- a/testdata/app.rb
++++ b/testdata/app.rb
+@@ -1,2 +1,2 @@
+-digest = Digest::MD5.hexdigest(user.password)
+-other = Digest::MD5.hexdigest(token)
++digest = Digest::SHA256.hexdigest(user.password)
++other = Digest::SHA256.hexdigest(token)
+`, diff)
+	}
+}
+
+func TestPlanSkipped(t *testing.T) {
+	nestedRule := &securitytypes.Rule{
+		Id:  "ruby_lang_weak_hash_call",
+		Fix: &securitytypes.Fix{Replace: `hexdigest`, With: "base64digest"},
+	}
+	sha1Rule := &securitytypes.Rule{
+		Id:  "ruby_lang_weak_hash_sha1",
+		Fix: &securitytypes.Fix{Replace: `Digest::SHA1`, With: "Digest::SHA256"},
+	}
+
+	files, skipped := fix.Plan([]securitytypes.RawFinding{
+		finding("a_0", weakHashRule, 1, 10, "Digest::MD5.hexdigest(user.password)"),
+		finding("b_0", nestedRule, 1, 10, "Digest::MD5.hexdigest(user.password)"),
+		finding("c_0", weakHashRule, 2, 9, "Digest::SHA1.hexdigest(token)"),
+		finding("d_0", sha1Rule, 2, 9, "Digest::MD5.hexdigest(token)"),
+	})
+
+	assert.Equal(t, []fix.Skipped{
+		{Fingerprint: "c_0", RuleID: "ruby_lang_weak_hash", Reason: "the code has changed since the report"},
+		{Fingerprint: "d_0", RuleID: "ruby_lang_weak_hash_sha1", Reason: "the fix doesn't change the code"},
+		{Fingerprint: "b_0", RuleID: "ruby_lang_weak_hash_call", Reason: "the code overlaps the fix of another finding"},
+	}, skipped)
+	if assert.Len(t, files, 1) {
+		assert.Equal(t, []string{"a_0"}, files[0].Fingerprints)
+	}
+}
@@ -0,0 +1,2 @@
+digest = Digest::MD5.hexdigest(user.password)
+other = Digest::MD5.hexdigest(token)
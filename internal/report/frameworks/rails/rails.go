@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/bearer/bearer/internal/report/frameworks"
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
 )
 
 const TypeCache frameworks.Type = "cache"
@@ -15,8 +16,9 @@ type Cache struct {
 }
 
 type Database struct {
-	Name    string `json:"name" yaml:"name"`
-	Adapter string `json:"adapter" yaml:"adapter"`
+	Name      string                `json:"name" yaml:"name"`
+	Adapter   string                `json:"adapter" yaml:"adapter"`
+	DataStore *connection.DataStore `json:"data_store,omitempty" yaml:"data_store,omitempty"`
 }
 
 type Storage struct {
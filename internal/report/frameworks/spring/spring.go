@@ -2,12 +2,14 @@ package spring
 
 import (
 	"github.com/bearer/bearer/internal/report/frameworks"
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
 )
 
 const TypeDatabase frameworks.Type = "database"
 
 type DataStore struct {
-	Driver string `json:"driver" yaml:"driver"`
+	Driver    string                `json:"driver" yaml:"driver"`
+	DataStore *connection.DataStore `json:"data_store,omitempty" yaml:"data_store,omitempty"`
 }
 
 func (value DataStore) GetTechnologyKey() string {
@@ -0,0 +1,121 @@
+package terraform
+
+import (
+	"github.com/bearer/bearer/internal/report/frameworks"
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
+)
+
+const TypeResource frameworks.Type = "resource"
+const TypeProvider frameworks.Type = "provider"
+
+const unidentifiedDataStore = "unidentified_data_store"
+
+// resourceTechnologies maps the types of resources holding or carrying data to
+// their recipes. Databases which engine isn't known are unidentified data
+// stores
+var resourceTechnologies = map[string]string{
+	// AWS
+	"aws_s3_bucket":                     "4e5a3a3a-47cd-4b0e-b0a6-fa30a0a62499",
+	"aws_sqs_queue":                     "27c833e8-298b-4c71-a7cf-c1943779f485",
+	"aws_dynamodb_table":                "11f8b440-d1ca-40d7-8a2d-2b8caa7c7fad",
+	"aws_kinesis_stream":                "8feff8fb-432e-4213-b5dd-9358ebcfb5b5",
+	"aws_redshift_cluster":              "3a49be11-d8a7-4f63-ba4d-542320f3c580",
+	"aws_msk_cluster":                   "749d874f-c6ad-478a-b7b2-bd28f48da6ad",
+	"aws_elasticsearch_domain":          "911eed55-46f6-4324-ab55-ca11acfe562e",
+	"aws_opensearch_domain":             "911eed55-46f6-4324-ab55-ca11acfe562e",
+	"aws_db_instance":                   unidentifiedDataStore,
+	"aws_rds_cluster":                   unidentifiedDataStore,
+	"aws_elasticache_cluster":           unidentifiedDataStore,
+	"aws_elasticache_replication_group": unidentifiedDataStore,
+	"aws_mq_broker":                     unidentifiedDataStore,
+	// Google Cloud
+	"google_storage_bucket":        "3a154582-174f-4ef7-90a2-f654435c23cb",
+	"google_pubsub_topic":          "4f9955fb-23b7-4f3e-ab5f-134afa22940b",
+	"google_bigquery_dataset":      "2c6da556-2622-471b-80cd-0ecefa73db61",
+	"google_bigtable_instance":     "0b6a3389-d3f1-46a2-b99c-af06343d7aa9",
+	"google_spanner_instance":      "4f76d9ef-77e1-42f7-ac04-11147c151a82",
+	"google_firestore_database":    "63202bac-af18-4c67-92f9-a6b4a8bb8211",
+	"google_sql_database_instance": unidentifiedDataStore,
+	"google_redis_instance":        unidentifiedDataStore,
+	// Azure
+	"azurerm_storage_account":            "f0f43ee7-7f6b-4572-aaa0-6b207146912b",
+	"azurerm_cosmosdb_account":           "610197d6-7d20-41b4-9e45-dda645165b0b",
+	"azurerm_servicebus_namespace":       "235e9ec8-0884-4bfc-8238-f8047b4f8663",
+	"azurerm_postgresql_server":          unidentifiedDataStore,
+	"azurerm_postgresql_flexible_server": unidentifiedDataStore,
+	"azurerm_mysql_server":               unidentifiedDataStore,
+	"azurerm_mysql_flexible_server":      unidentifiedDataStore,
+	"azurerm_mariadb_server":             unidentifiedDataStore,
+	"azurerm_mssql_server":               unidentifiedDataStore,
+	"azurerm_sql_server":                 unidentifiedDataStore,
+	"azurerm_redis_cache":                unidentifiedDataStore,
+	// SaaS
+	"mongodbatlas_cluster":          "be62dea4-2b19-4e33-8092-6751aaa6430b",
+	"mongodbatlas_advanced_cluster": "be62dea4-2b19-4e33-8092-6751aaa6430b",
+	"snowflake_database":            "1b018aaf-fa0e-4981-a417-f3bb03bd7a7f",
+}
+
+// providerTechnologies maps the providers of third party services to their
+// recipes
+var providerTechnologies = map[string]string{
+	"auth0":        "2a8392d5-7013-41ab-833d-64fd2cffcba6",
+	"cloudflare":   "dd77bb6c-c4f2-4128-baca-b9ec77ca4481",
+	"datadog":      "1fc5f10d-490f-48b9-b901-e0813804c781",
+	"ec":           "e56e4e80-adab-4afb-915a-f1760b8f9631",
+	"elasticstack": "e56e4e80-adab-4afb-915a-f1760b8f9631",
+	"github":       "f92a8cf0-3524-4319-9dec-3406066c0119",
+	"heroku":       "6cabd765-14ac-428b-b8ee-98b91e385a08",
+	"mongodbatlas": "be62dea4-2b19-4e33-8092-6751aaa6430b",
+	"newrelic":     "895291fe-f457-4326-8113-c0cf1426084b",
+	"okta":         "807f7e5f-63ac-426d-9b6e-fcd3b4325f66",
+	"pagerduty":    "125900d0-de02-4839-baf2-eeac1a94fd96",
+	"sendgrid":     "6d201c8d-31cb-4f37-b2e1-aa9941d14ca8",
+	"sentry":       "f1ed601f-601a-4fd7-9b82-da8fbbe06c62",
+	"snowflake":    "1b018aaf-fa0e-4981-a417-f3bb03bd7a7f",
+	"stripe":       "c24b836a-d035-49dc-808f-1912f16f690d",
+	"twilio":       "233c67d0-98a3-48ae-8a3e-451b1da7e192",
+}
+
+// Resource is a data store, queue or service declared in a Terraform
+// configuration, eg. `resource "aws_s3_bucket" "uploads" {}`
+type Resource struct {
+	Definition Definition            `json:"resource" yaml:"resource"`
+	DataStore  *connection.DataStore `json:"data_store,omitempty" yaml:"data_store,omitempty"`
+}
+
+// Definition identifies a resource, along with the settings protecting its
+// data. Settings are nil when they aren't set, or are set from a value which
+// isn't known statically such as a variable.
+type Definition struct {
+	Type         string `json:"type" yaml:"type"`
+	Name         string `json:"name" yaml:"name"`
+	Encrypted    *bool  `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+	PublicAccess *bool  `json:"public_access,omitempty" yaml:"public_access,omitempty"`
+}
+
+// Provider is the provider of a third party service, eg. `provider "datadog" {}`
+type Provider struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+func (value Resource) GetTechnologyKey() string {
+	if value.DataStore != nil {
+		return connection.TechnologyKey(value.DataStore.Engine)
+	}
+
+	return resourceTechnologies[value.Definition.Type]
+}
+
+// IsDefinite reports that a resource declares its data store precisely
+// enough to be classified
+func (value Resource) IsDefinite() bool {
+	return true
+}
+
+func (value Provider) GetTechnologyKey() string {
+	return providerTechnologies[value.Name]
+}
+
+func (value Provider) IsDefinite() bool {
+	return true
+}
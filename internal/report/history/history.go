@@ -0,0 +1,185 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bearer/bearer/internal/commands/process/gitrepository"
+	"github.com/bearer/bearer/internal/report/output/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
+)
+
+var ErrRemoteOffline = errors.New("remote history files are not available in offline mode")
+
+// Entry is the summary of a single scan recorded in a history file
+type Entry struct {
+	Timestamp time.Time      `json:"timestamp" yaml:"timestamp"`
+	Branch    string         `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Commit    string         `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Findings  map[string]int `json:"findings" yaml:"findings"`
+	DataTypes map[string]int `json:"data_types" yaml:"data_types"`
+	// counts for each file, used to prioritize files in time-bounded scans
+	Files map[string]FileEntry `json:"files,omitempty" yaml:"files,omitempty"`
+}
+
+type FileEntry struct {
+	Findings  int `json:"findings,omitempty" yaml:"findings,omitempty"`
+	DataTypes int `json:"data_types,omitempty" yaml:"data_types,omitempty"`
+}
+
+// NewEntry summarises the report into the finding counts by severity and the
+// number of detections of each data type, in total and for each file
+func NewEntry(reportData *types.ReportData, gitContext *gitrepository.Context, timestamp time.Time) Entry {
+	entry := Entry{
+		Timestamp: timestamp.UTC(),
+		Findings:  make(map[string]int),
+		DataTypes: make(map[string]int),
+		Files:     make(map[string]FileEntry),
+	}
+
+	if gitContext != nil {
+		entry.Branch = gitContext.Branch
+		entry.Commit = gitContext.CommitHash
+	}
+
+	for _, severity := range globaltypes.Severities {
+		entry.Findings[severity] = len(reportData.FindingsBySeverity[severity])
+
+		for _, finding := range reportData.FindingsBySeverity[severity] {
+			fileEntry := entry.Files[finding.Filename]
+			fileEntry.Findings++
+			entry.Files[finding.Filename] = fileEntry
+		}
+	}
+
+	if reportData.Dataflow != nil {
+		for _, datatype := range reportData.Dataflow.Datatypes {
+			for _, detector := range datatype.Detectors {
+				entry.DataTypes[datatype.Name] += len(detector.Locations)
+
+				for _, location := range detector.Locations {
+					fileEntry := entry.Files[location.Filename]
+					fileEntry.DataTypes++
+					entry.Files[location.Filename] = fileEntry
+				}
+			}
+		}
+	}
+
+	return entry
+}
+
+func isRemote(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// Append adds the entry to the history file. Local files have the entry
+// appended as a line of JSON, while remote (http or https) locations have
+// it sent in a POST request.
+func Append(location string, entry Entry, offline bool) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if isRemote(location) {
+		if offline {
+			return ErrRemoteOffline
+		}
+
+		response, err := http.Post(location, "application/json", bytes.NewReader(content))
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode >= 300 {
+			return fmt.Errorf("unexpected response status %s", response.Status)
+		}
+
+		return nil
+	}
+
+	file, err := os.OpenFile(location, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(content, '\n'))
+	return err
+}
+
+// Read loads the entries from a local or remote history file, ordered by
+// timestamp
+func Read(location string, offline bool) ([]Entry, error) {
+	if isRemote(location) {
+		if offline {
+			return nil, ErrRemoteOffline
+		}
+
+		response, err := http.Get(location)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode >= 300 {
+			return nil, fmt.Errorf("unexpected response status %s", response.Status)
+		}
+
+		return ReadEntries(response.Body)
+	}
+
+	file, err := os.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadEntries(file)
+}
+
+// ReadEntries parses entries given one JSON object per line
+func ReadEntries(reader io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("invalid history entry on line %d: %w", lineNumber, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+
+	return entries, nil
+}
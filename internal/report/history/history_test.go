@@ -0,0 +1,89 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/report/history"
+)
+
+func TestAppend(t *testing.T) {
+	location := filepath.Join(t.TempDir(), "history.jsonl")
+
+	first := history.Entry{
+		Timestamp: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		Commit:    "a1b2c3d",
+		Findings:  map[string]int{"critical": 1},
+		DataTypes: map[string]int{"Email Address": 2},
+	}
+	second := history.Entry{
+		Timestamp: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		Commit:    "b2c3d4e",
+		Findings:  map[string]int{"critical": 0},
+		DataTypes: map[string]int{"Email Address": 3},
+	}
+
+	for _, entry := range []history.Entry{first, second} {
+		if err := history.Append(location, entry, false); err != nil {
+			t.Fatalf("failed to append entry, err: %s", err)
+		}
+	}
+
+	entries, err := history.Read(location, false)
+	if err != nil {
+		t.Fatalf("failed to read history, err: %s", err)
+	}
+
+	if len(entries) != 2 || entries[0].Commit != first.Commit || entries[1].Commit != second.Commit {
+		t.Fatalf("unexpected entries read back: %+v", entries)
+	}
+}
+
+func TestAppendRemoteOffline(t *testing.T) {
+	err := history.Append("https://example.com/history", history.Entry{}, true)
+	if err != history.ErrRemoteOffline {
+		t.Fatalf("expected offline error, got: %v", err)
+	}
+}
+
+func TestBuildTrend(t *testing.T) {
+	cupaloy.SnapshotT(t, history.BuildTrend(readEntries(t)))
+}
+
+func TestMarkdown(t *testing.T) {
+	cupaloy.SnapshotT(t, history.BuildTrend(readEntries(t)).Markdown())
+}
+
+func TestSparkline(t *testing.T) {
+	for name, test := range map[string]struct {
+		values   []int
+		expected string
+	}{
+		"increasing": {[]int{0, 1, 2, 3, 4, 5, 6, 7}, "▁▂▃▄▅▆▇█"},
+		"zero":       {[]int{0, 0, 0}, "▁▁▁"},
+		"flat":       {[]int{3, 3}, "██"},
+	} {
+		if sparkline := history.Sparkline(test.values); sparkline != test.expected {
+			t.Errorf("%s: expected %s, got %s", name, test.expected, sparkline)
+		}
+	}
+}
+
+func readEntries(t *testing.T) []history.Entry {
+	file, err := os.Open("testdata/history.jsonl")
+	if err != nil {
+		t.Fatalf("failed to open file, err: %s", err)
+	}
+	defer file.Close()
+
+	entries, err := history.ReadEntries(file)
+	if err != nil {
+		t.Fatalf("failed to read entries, err: %s", err)
+	}
+
+	return entries
+}
@@ -0,0 +1,162 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	globaltypes "github.com/bearer/bearer/internal/types"
+)
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Series is the count for a single severity or data type across scans
+type Series struct {
+	Name   string `json:"name" yaml:"name"`
+	First  int    `json:"first" yaml:"first"`
+	Latest int    `json:"latest" yaml:"latest"`
+	Change int    `json:"change" yaml:"change"`
+	Values []int  `json:"values" yaml:"values"`
+}
+
+type Trend struct {
+	Scans     int       `json:"scans" yaml:"scans"`
+	From      time.Time `json:"from,omitempty" yaml:"from,omitempty"`
+	To        time.Time `json:"to,omitempty" yaml:"to,omitempty"`
+	Findings  []Series  `json:"findings" yaml:"findings"`
+	DataTypes []Series  `json:"data_types" yaml:"data_types"`
+}
+
+// BuildTrend collects the counts from each entry into a series per severity
+// and data type. Entries are expected to be ordered oldest first.
+func BuildTrend(entries []Entry) Trend {
+	trend := Trend{
+		Scans:     len(entries),
+		Findings:  []Series{},
+		DataTypes: []Series{},
+	}
+
+	if len(entries) == 0 {
+		return trend
+	}
+
+	trend.From = entries[0].Timestamp
+	trend.To = entries[len(entries)-1].Timestamp
+
+	for _, severity := range globaltypes.Severities {
+		trend.Findings = append(trend.Findings, buildSeries(severity, entries, func(entry Entry) map[string]int {
+			return entry.Findings
+		}))
+	}
+
+	dataTypeNames := make(map[string]bool)
+	for _, entry := range entries {
+		for name := range entry.DataTypes {
+			dataTypeNames[name] = true
+		}
+	}
+
+	sortedNames := make([]string, 0, len(dataTypeNames))
+	for name := range dataTypeNames {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	for _, name := range sortedNames {
+		trend.DataTypes = append(trend.DataTypes, buildSeries(name, entries, func(entry Entry) map[string]int {
+			return entry.DataTypes
+		}))
+	}
+
+	return trend
+}
+
+func buildSeries(name string, entries []Entry, counts func(entry Entry) map[string]int) Series {
+	values := make([]int, len(entries))
+	for i, entry := range entries {
+		values[i] = counts(entry)[name]
+	}
+
+	return Series{
+		Name:   name,
+		First:  values[0],
+		Latest: values[len(values)-1],
+		Change: values[len(values)-1] - values[0],
+		Values: values,
+	}
+}
+
+func (trend Trend) Markdown() string {
+	builder := &strings.Builder{}
+
+	builder.WriteString("## Bearer risk trend\n\n")
+	if trend.Scans == 0 {
+		builder.WriteString("No scans recorded\n")
+		return builder.String()
+	}
+
+	builder.WriteString(fmt.Sprintf(
+		"%d scans from %s to %s\n",
+		trend.Scans,
+		trend.From.Format(time.RFC3339),
+		trend.To.Format(time.RFC3339),
+	))
+
+	writeMarkdownSection(builder, "Findings by severity", "Severity", trend.Findings)
+	writeMarkdownSection(builder, "Data types", "Data type", trend.DataTypes)
+
+	return builder.String()
+}
+
+func writeMarkdownSection(builder *strings.Builder, title string, nameHeader string, series []Series) {
+	if len(series) == 0 {
+		return
+	}
+
+	builder.WriteString(fmt.Sprintf("\n### %s\n\n", title))
+	builder.WriteString(fmt.Sprintf("| %s | Trend | First | Latest | Change |\n", nameHeader))
+	builder.WriteString("| --- | --- | --- | --- | --- |\n")
+
+	for _, item := range series {
+		builder.WriteString(fmt.Sprintf(
+			"| %s | %s | %d | %d | %s |\n",
+			strings.ReplaceAll(item.Name, "|", "\\|"),
+			Sparkline(item.Values),
+			item.First,
+			item.Latest,
+			formatChange(item.Change),
+		))
+	}
+}
+
+// Sparkline renders the values as block characters, scaled so that the
+// highest value is a full block
+func Sparkline(values []int) string {
+	max := 0
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+
+	builder := &strings.Builder{}
+	for _, value := range values {
+		index := 0
+		if max != 0 && value > 0 {
+			index = value * (len(sparkTicks) - 1) / max
+		}
+
+		builder.WriteRune(sparkTicks[index])
+	}
+
+	return builder.String()
+}
+
+func formatChange(change int) string {
+	if change > 0 {
+		return fmt.Sprintf("+%d", change)
+	}
+
+	return fmt.Sprintf("%d", change)
+}
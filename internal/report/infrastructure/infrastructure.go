@@ -0,0 +1,11 @@
+package infrastructure
+
+// Risk is a weakness in the configuration of the infrastructure running an
+// application, such as a secret hard-coded in a Kubernetes manifest
+type Risk struct {
+	// the id of the built-in rule reporting the risk
+	RuleID string `json:"rule_id" yaml:"rule_id"`
+	// what holds the risk, eg. the name of an environment variable. Secret
+	// values are never kept
+	Description string `json:"description" yaml:"description"`
+}
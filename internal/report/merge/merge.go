@@ -0,0 +1,262 @@
+package merge
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+var ErrUnknownReport = errors.New("unrecognised report; expected a dataflow report in json or yaml format")
+
+var dataflowKeys = []string{
+	"data_types",
+	"expected_detections",
+	"risks",
+	"components",
+	"dependencies",
+	"endpoints",
+	"errors",
+	"suppression_warnings",
+	"metadata",
+}
+
+// Service is the dataflow report of a single repository or service
+type Service struct {
+	Name     string
+	Dataflow *outputtypes.DataFlow
+}
+
+// ReadService reads a dataflow report. The argument is either the path of the
+// report, in which case the service is named after the file, or
+// `<name>=<path>`.
+func ReadService(argument string) (Service, error) {
+	name, reportPath, named := strings.Cut(argument, "=")
+	if !named {
+		reportPath = argument
+		name = strings.TrimSuffix(filepath.Base(reportPath), filepath.Ext(reportPath))
+	}
+
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		return Service{}, err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return Service{}, ErrUnknownReport
+	}
+
+	for key := range raw {
+		if !slices.Contains(dataflowKeys, key) {
+			return Service{}, ErrUnknownReport
+		}
+	}
+
+	var dataflow outputtypes.DataFlow
+	if err := yaml.Unmarshal(content, &dataflow); err != nil {
+		return Service{}, fmt.Errorf("failed to read dataflow: %w", err)
+	}
+
+	return Service{Name: name, Dataflow: &dataflow}, nil
+}
+
+// Merge joins the dataflow reports of several services into a single report.
+// Filenames are prefixed with the name of their service. Components sharing a
+// name and type, such as a third party or a database used by several
+// services, are merged into one.
+func Merge(services []Service) (*outputtypes.DataFlow, error) {
+	names := make(map[string]struct{})
+	for _, service := range services {
+		if _, duplicate := names[service.Name]; duplicate {
+			return nil, fmt.Errorf("duplicate service name %s; use <name>=<path> to name it", service.Name)
+		}
+		names[service.Name] = struct{}{}
+	}
+
+	result := &outputtypes.DataFlow{}
+	dataTypes := make(map[string]*dataflowtypes.Datatype)
+	risks := make(map[string]*dataflowtypes.RiskDetector)
+	expectedDetections := make(map[string]*dataflowtypes.RiskDetector)
+	components := make(map[string]*dataflowtypes.Component)
+	var componentKeys []string
+
+	for _, service := range services {
+		if service.Dataflow == nil {
+			continue
+		}
+
+		for _, dataType := range service.Dataflow.Datatypes {
+			mergeDataType(dataTypes, service.Name, dataType)
+		}
+
+		for _, risk := range service.Dataflow.Risks {
+			mergeRiskDetector(risks, service.Name, risk)
+		}
+
+		for _, expectedDetection := range service.Dataflow.ExpectedDetections {
+			mergeRiskDetector(expectedDetections, service.Name, expectedDetection)
+		}
+
+		for _, component := range service.Dataflow.Components {
+			key := strings.ToLower(component.Name) + "\x00" + component.Type + "\x00" + component.SubType
+
+			merged, ok := components[key]
+			if !ok {
+				merged = &dataflowtypes.Component{
+					Name:     component.Name,
+					Type:     component.Type,
+					SubType:  component.SubType,
+					Category: component.Category,
+					UUID:     component.UUID,
+				}
+				components[key] = merged
+				componentKeys = append(componentKeys, key)
+			}
+
+			for _, location := range component.Locations {
+				location.Filename = prefix(service.Name, location.Filename)
+				merged.Locations = append(merged.Locations, location)
+			}
+		}
+
+		for _, dependency := range service.Dataflow.Dependencies {
+			dependency.Filename = prefix(service.Name, dependency.Filename)
+			result.Dependencies = append(result.Dependencies, dependency)
+		}
+
+		for _, endpoint := range service.Dataflow.Endpoints {
+			endpoint.Filename = prefix(service.Name, endpoint.Filename)
+			result.Endpoints = append(result.Endpoints, endpoint)
+		}
+
+		for _, fileError := range service.Dataflow.Errors {
+			fileError.Filename = prefix(service.Name, fileError.Filename)
+			result.Errors = append(result.Errors, fileError)
+		}
+
+		for _, warning := range service.Dataflow.SuppressionWarnings {
+			warning.Filename = prefix(service.Name, warning.Filename)
+			result.SuppressionWarnings = append(result.SuppressionWarnings, warning)
+		}
+	}
+
+	for _, dataType := range maputil.ToSortedSlice(dataTypes) {
+		result.Datatypes = append(result.Datatypes, *dataType)
+	}
+
+	for _, risk := range maputil.ToSortedSlice(risks) {
+		result.Risks = append(result.Risks, *risk)
+	}
+
+	for _, expectedDetection := range maputil.ToSortedSlice(expectedDetections) {
+		result.ExpectedDetections = append(result.ExpectedDetections, *expectedDetection)
+	}
+
+	for _, key := range componentKeys {
+		result.Components = append(result.Components, *components[key])
+	}
+
+	return result, nil
+}
+
+func mergeDataType(dataTypes map[string]*dataflowtypes.Datatype, serviceName string, dataType dataflowtypes.Datatype) {
+	merged, ok := dataTypes[dataType.Name]
+	if !ok {
+		merged = &dataflowtypes.Datatype{
+			UUID:           dataType.UUID,
+			CategoryUUID:   dataType.CategoryUUID,
+			CategoryName:   dataType.CategoryName,
+			CategoryGroups: dataType.CategoryGroups,
+			Name:           dataType.Name,
+		}
+		dataTypes[dataType.Name] = merged
+	}
+
+	merged.SubjectNames = mergeStrings(merged.SubjectNames, dataType.SubjectNames)
+
+	for _, detector := range dataType.Detectors {
+		index := -1
+		for i, mergedDetector := range merged.Detectors {
+			if mergedDetector.Name == detector.Name {
+				index = i
+				break
+			}
+		}
+
+		if index == -1 {
+			merged.Detectors = append(merged.Detectors, dataflowtypes.DatatypeDetector{Name: detector.Name, Source: detector.Source})
+			index = len(merged.Detectors) - 1
+		}
+
+		for _, location := range detector.Locations {
+			location.Filename = prefix(serviceName, location.Filename)
+
+			if len(location.VerifiedBy) != 0 {
+				verifiedBy := make([]dataflowtypes.DatatypeVerifiedBy, len(location.VerifiedBy))
+				for i, verification := range location.VerifiedBy {
+					if verification.Filename != nil {
+						filename := prefix(serviceName, *verification.Filename)
+						verification.Filename = &filename
+					}
+
+					verifiedBy[i] = verification
+				}
+				location.VerifiedBy = verifiedBy
+			}
+
+			merged.Detectors[index].Locations = append(merged.Detectors[index].Locations, location)
+		}
+	}
+
+	sort.SliceStable(merged.Detectors, func(i, j int) bool {
+		return merged.Detectors[i].Name < merged.Detectors[j].Name
+	})
+}
+
+func mergeRiskDetector(risks map[string]*dataflowtypes.RiskDetector, serviceName string, risk dataflowtypes.RiskDetector) {
+	merged, ok := risks[risk.DetectorID]
+	if !ok {
+		merged = &dataflowtypes.RiskDetector{DetectorID: risk.DetectorID}
+		risks[risk.DetectorID] = merged
+	}
+
+	for _, location := range risk.Locations {
+		location.Filename = prefix(serviceName, location.Filename)
+		merged.Locations = append(merged.Locations, location)
+	}
+}
+
+func mergeStrings(values, others []string) []string {
+	if len(others) == 0 {
+		return values
+	}
+
+	merged := set.New[string]()
+	merged.AddAll(values)
+	merged.AddAll(others)
+
+	result := merged.Items()
+	sort.Strings(result)
+
+	return result
+}
+
+func prefix(serviceName, filename string) string {
+	if filename == "" {
+		return filename
+	}
+
+	return path.Join(serviceName, filename)
+}
@@ -0,0 +1,53 @@
+package merge_test
+
+import (
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/report/merge"
+	"github.com/bearer/bearer/internal/util/output"
+)
+
+func TestMerge(t *testing.T) {
+	api, err := merge.ReadService("testdata/api.json")
+	if err != nil {
+		t.Fatalf("failed to read report, err: %s", err)
+	}
+
+	web, err := merge.ReadService("storefront=testdata/web.json")
+	if err != nil {
+		t.Fatalf("failed to read report, err: %s", err)
+	}
+
+	if web.Name != "storefront" {
+		t.Errorf("expected the service to be named storefront, got %s", web.Name)
+	}
+
+	dataflow, err := merge.Merge([]merge.Service{api, web})
+	if err != nil {
+		t.Fatalf("failed to merge reports, err: %s", err)
+	}
+
+	content, err := output.ReportYAML(dataflow)
+	if err != nil {
+		t.Fatalf("failed to generate YAML output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, content)
+}
+
+func TestMergeErrors(t *testing.T) {
+	if _, err := merge.ReadService("testdata/security.json"); err != merge.ErrUnknownReport {
+		t.Errorf("expected an unknown report error, got %v", err)
+	}
+
+	api, err := merge.ReadService("testdata/api.json")
+	if err != nil {
+		t.Fatalf("failed to read report, err: %s", err)
+	}
+
+	if _, err := merge.Merge([]merge.Service{api, api}); err == nil {
+		t.Error("expected an error for duplicate service names")
+	}
+}
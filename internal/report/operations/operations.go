@@ -6,12 +6,17 @@ const (
 	TypePut    = "PUT"
 	TypeDelete = "DELETE"
 	TypeOther  = "OTHER"
+	TypeRPC    = "RPC"
 )
 
 type Operation struct {
-	Path string `json:"path" yaml:"path"`
-	Type string `json:"type" yaml:"type"`
-	Urls []Url  `json:"url" yaml:"url"`
+	Path        string `json:"path" yaml:"path"`
+	Type        string `json:"type" yaml:"type"`
+	Urls        []Url  `json:"url" yaml:"url"`
+	OperationId string `json:"operation_id,omitempty" yaml:"operation_id,omitempty"`
+	// Names of the schemas sent and returned by the operation
+	RequestSchemas  []string `json:"request_schemas,omitempty" yaml:"request_schemas,omitempty"`
+	ResponseSchemas []string `json:"response_schemas,omitempty" yaml:"response_schemas,omitempty"`
 }
 
 type Url struct {
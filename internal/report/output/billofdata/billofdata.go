@@ -0,0 +1,139 @@
+package billofdata
+
+import (
+	"sort"
+
+	"github.com/bearer/bearer/internal/report/output/billofdata/types"
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// UnknownDataSubject groups data types that could not be linked to a subject
+const UnknownDataSubject = "Unknown"
+
+type dataSubjectHolder struct {
+	dataTypes map[string]*types.DataType
+	files     set.Set[string]
+}
+
+// ReportBillOfData maps each data subject to the data types processed for it,
+// where that data is stored and processed, and the components found in the
+// same files as that data
+func ReportBillOfData(dataflow *outputtypes.DataFlow) (types.BillOfData, error) {
+	billOfData := types.BillOfData{
+		DataSubjects: []types.DataSubject{},
+		Components:   []types.Component{},
+	}
+	if dataflow == nil {
+		return billOfData, nil
+	}
+
+	for _, component := range dataflow.Components {
+		billOfData.Components = append(billOfData.Components, buildComponent(component.Name, component.Type, component.SubType, component.Category, componentFiles(component)))
+	}
+
+	subjects := make(map[string]*dataSubjectHolder)
+	for _, dataType := range dataflow.Datatypes {
+		for _, detector := range dataType.Detectors {
+			for _, location := range detector.Locations {
+				subjectName := UnknownDataSubject
+				if location.SubjectName != nil && *location.SubjectName != "" {
+					subjectName = *location.SubjectName
+				}
+
+				subject, ok := subjects[subjectName]
+				if !ok {
+					subject = &dataSubjectHolder{
+						dataTypes: make(map[string]*types.DataType),
+						files:     set.New[string](),
+					}
+					subjects[subjectName] = subject
+				}
+
+				subjectDataType, ok := subject.dataTypes[dataType.Name]
+				if !ok {
+					subjectDataType = &types.DataType{
+						Name:                dataType.Name,
+						CategoryName:        dataType.CategoryName,
+						CategoryGroups:      dataType.CategoryGroups,
+						StorageLocations:    []types.Location{},
+						ProcessingLocations: []types.Location{},
+					}
+					subject.dataTypes[dataType.Name] = subjectDataType
+				}
+
+				bodLocation := types.Location{
+					Filename:   location.Filename,
+					LineNumber: location.StartLineNumber,
+					ObjectName: location.ObjectName,
+					FieldName:  location.FieldName,
+					Encrypted:  location.Encrypted,
+					Purpose:    location.Purpose,
+					Retention:  location.Retention,
+				}
+
+				if location.Stored != nil && *location.Stored {
+					subjectDataType.StorageLocations = append(subjectDataType.StorageLocations, bodLocation)
+				} else {
+					subjectDataType.ProcessingLocations = append(subjectDataType.ProcessingLocations, bodLocation)
+				}
+
+				subject.files.Add(location.Filename)
+			}
+		}
+	}
+
+	for _, subjectName := range maputil.SortedStringKeys(subjects) {
+		subject := subjects[subjectName]
+
+		dataSubject := types.DataSubject{
+			Name:       subjectName,
+			DataTypes:  []types.DataType{},
+			Components: []types.Component{},
+		}
+
+		for _, dataTypeName := range maputil.SortedStringKeys(subject.dataTypes) {
+			dataSubject.DataTypes = append(dataSubject.DataTypes, *subject.dataTypes[dataTypeName])
+		}
+
+		for _, component := range billOfData.Components {
+			var sharedFiles []string
+			for _, filename := range component.Files {
+				if subject.files.Has(filename) {
+					sharedFiles = append(sharedFiles, filename)
+				}
+			}
+
+			if len(sharedFiles) != 0 {
+				dataSubject.Components = append(dataSubject.Components, buildComponent(component.Name, component.Type, component.SubType, component.Category, sharedFiles))
+			}
+		}
+
+		billOfData.DataSubjects = append(billOfData.DataSubjects, dataSubject)
+	}
+
+	return billOfData, nil
+}
+
+func componentFiles(component dataflowtypes.Component) []string {
+	files := set.New[string]()
+	for _, location := range component.Locations {
+		files.Add(location.Filename)
+	}
+
+	return files.Items()
+}
+
+func buildComponent(name, componentType, subType, category string, files []string) types.Component {
+	sort.Strings(files)
+
+	return types.Component{
+		Name:     name,
+		Type:     componentType,
+		SubType:  subType,
+		Category: category,
+		Files:    files,
+	}
+}
@@ -0,0 +1,44 @@
+package billofdata_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/report/output/billofdata"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	util "github.com/bearer/bearer/internal/util/output"
+)
+
+func TestBillOfData(t *testing.T) {
+	dataflowOutput, err := os.ReadFile("testdata/dataflow.json")
+	if err != nil {
+		t.Fatalf("failed to read file, err: %s", err)
+	}
+
+	var dataflow outputtypes.DataFlow
+	err = json.Unmarshal(dataflowOutput, &dataflow)
+	if err != nil {
+		t.Fatalf("couldn't unmarshal file output: %s", err)
+	}
+
+	res, err := billofdata.ReportBillOfData(&dataflow)
+	if err != nil {
+		t.Fatalf("failed to generate bill of data output, err: %s", err)
+	}
+
+	output, err := util.ReportJSON(res)
+	if err != nil {
+		t.Fatalf("failed to generate JSON output, err: %s", err)
+	}
+
+	var prettyJSON bytes.Buffer
+	err = json.Indent(&prettyJSON, []byte(output), "", "\t")
+	if err != nil {
+		t.Fatalf("error indenting output, err: %s", err)
+	}
+	cupaloy.SnapshotT(t, prettyJSON.String())
+}
@@ -0,0 +1,38 @@
+package types
+
+type Location struct {
+	Filename   string `json:"filename" yaml:"filename"`
+	LineNumber int    `json:"line_number" yaml:"line_number"`
+	ObjectName string `json:"object_name,omitempty" yaml:"object_name,omitempty"`
+	FieldName  string `json:"field_name,omitempty" yaml:"field_name,omitempty"`
+	Encrypted  *bool  `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+	Purpose    string `json:"purpose,omitempty" yaml:"purpose,omitempty"`
+	Retention  string `json:"retention,omitempty" yaml:"retention,omitempty"`
+}
+
+type DataType struct {
+	Name                string     `json:"name" yaml:"name"`
+	CategoryName        string     `json:"category_name,omitempty" yaml:"category_name,omitempty"`
+	CategoryGroups      []string   `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
+	StorageLocations    []Location `json:"storage_locations" yaml:"storage_locations"`
+	ProcessingLocations []Location `json:"processing_locations" yaml:"processing_locations"`
+}
+
+type Component struct {
+	Name     string   `json:"name" yaml:"name"`
+	Type     string   `json:"type" yaml:"type"`
+	SubType  string   `json:"sub_type" yaml:"sub_type"`
+	Category string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Files    []string `json:"files" yaml:"files"`
+}
+
+type DataSubject struct {
+	Name       string      `json:"name" yaml:"name"`
+	DataTypes  []DataType  `json:"data_types" yaml:"data_types"`
+	Components []Component `json:"components" yaml:"components"`
+}
+
+type BillOfData struct {
+	DataSubjects []DataSubject `json:"data_subjects" yaml:"data_subjects"`
+	Components   []Component   `json:"components" yaml:"components"`
+}
@@ -0,0 +1,129 @@
+package cooccurrence
+
+import (
+	"sort"
+
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// CoOccurrence is a record or structure holding several data types. Combined
+// identifiers, such as a name with a date of birth, carry a higher risk of
+// re-identifying a person than each of them on its own.
+type CoOccurrence struct {
+	ObjectName     string     `json:"object_name" yaml:"object_name"`
+	SubjectName    string     `json:"subject_name,omitempty" yaml:"subject_name,omitempty"`
+	Filename       string     `json:"filename" yaml:"filename"`
+	LineNumber     int        `json:"line_number" yaml:"line_number"`
+	CategoryGroups []string   `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
+	DataTypes      []DataType `json:"data_types" yaml:"data_types"`
+}
+
+type DataType struct {
+	Name         string   `json:"name" yaml:"name"`
+	CategoryName string   `json:"category_name,omitempty" yaml:"category_name,omitempty"`
+	FieldNames   []string `json:"field_names" yaml:"field_names"`
+}
+
+type recordHolder struct {
+	objectName     string
+	subjectName    string
+	filename       string
+	lineNumber     int
+	categoryGroups set.Set[string]
+	dataTypes      map[string]*dataTypeHolder
+}
+
+type dataTypeHolder struct {
+	categoryName string
+	fieldNames   set.Set[string]
+}
+
+// Find returns the records holding more than one data type, with those
+// holding the most data types first. Records are identified by their object
+// name within a file.
+func Find(dataTypes []dataflowtypes.Datatype) []CoOccurrence {
+	records := make(map[string]*recordHolder)
+	for _, dataType := range dataTypes {
+		for _, detector := range dataType.Detectors {
+			for _, location := range detector.Locations {
+				if location.ObjectName == "" {
+					continue
+				}
+
+				key := location.Filename + "\x00" + location.ObjectName
+				record, ok := records[key]
+				if !ok {
+					record = &recordHolder{
+						objectName:     location.ObjectName,
+						filename:       location.Filename,
+						lineNumber:     location.StartLineNumber,
+						categoryGroups: set.New[string](),
+						dataTypes:      make(map[string]*dataTypeHolder),
+					}
+					records[key] = record
+				}
+
+				if location.StartLineNumber < record.lineNumber {
+					record.lineNumber = location.StartLineNumber
+				}
+				if record.subjectName == "" && location.SubjectName != nil {
+					record.subjectName = *location.SubjectName
+				}
+				record.categoryGroups.AddAll(dataType.CategoryGroups)
+
+				recordDataType, ok := record.dataTypes[dataType.Name]
+				if !ok {
+					recordDataType = &dataTypeHolder{
+						categoryName: dataType.CategoryName,
+						fieldNames:   set.New[string](),
+					}
+					record.dataTypes[dataType.Name] = recordDataType
+				}
+				if location.FieldName != "" {
+					recordDataType.fieldNames.Add(location.FieldName)
+				}
+			}
+		}
+	}
+
+	var result []CoOccurrence
+	for _, key := range maputil.SortedStringKeys(records) {
+		record := records[key]
+		if len(record.dataTypes) < 2 {
+			continue
+		}
+
+		categoryGroups := record.categoryGroups.Items()
+		sort.Strings(categoryGroups)
+
+		coOccurrence := CoOccurrence{
+			ObjectName:     record.objectName,
+			SubjectName:    record.subjectName,
+			Filename:       record.filename,
+			LineNumber:     record.lineNumber,
+			CategoryGroups: categoryGroups,
+		}
+
+		for _, name := range maputil.SortedStringKeys(record.dataTypes) {
+			dataType := record.dataTypes[name]
+			fieldNames := dataType.fieldNames.Items()
+			sort.Strings(fieldNames)
+
+			coOccurrence.DataTypes = append(coOccurrence.DataTypes, DataType{
+				Name:         name,
+				CategoryName: dataType.categoryName,
+				FieldNames:   fieldNames,
+			})
+		}
+
+		result = append(result, coOccurrence)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return len(result[i].DataTypes) > len(result[j].DataTypes)
+	})
+
+	return result
+}
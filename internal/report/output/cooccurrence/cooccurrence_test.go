@@ -0,0 +1,26 @@
+package cooccurrence_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/report/output/cooccurrence"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+)
+
+func TestFind(t *testing.T) {
+	dataflowOutput, err := os.ReadFile("testdata/dataflow.json")
+	if err != nil {
+		t.Fatalf("failed to read file, err: %s", err)
+	}
+
+	var dataflow outputtypes.DataFlow
+	if err := json.Unmarshal(dataflowOutput, &dataflow); err != nil {
+		t.Fatalf("couldn't unmarshal file output: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, cooccurrence.Find(dataflow.Datatypes))
+}
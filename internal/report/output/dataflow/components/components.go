@@ -1,9 +1,19 @@
 package components
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
 
+	"golang.org/x/exp/slices"
+
+	"github.com/bearer/bearer/internal/report/detections"
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
+	"github.com/bearer/bearer/internal/report/frameworks/terraform"
+	"github.com/bearer/bearer/internal/report/operations"
+	"github.com/bearer/bearer/internal/report/output/dataflow/detectiondecoder"
 	"github.com/bearer/bearer/internal/report/output/dataflow/types"
 
 	dependenciesclassification "github.com/bearer/bearer/internal/classification/dependencies"
@@ -30,6 +40,7 @@ type component struct {
 	name               string
 	component_type     string
 	component_sub_type string
+	category           string
 	uuid               string
 	detectors          map[string]*detector // group detectors by detectorName
 }
@@ -43,8 +54,18 @@ type fileHolder struct {
 	name        string
 	fullName    string
 	lineNumbers map[int]int //group lines by linenumber
+	dataStores  map[int]*connection.DataStore
+	resources   map[int]*terraform.Definition
 }
 
+const (
+	componentTypeDataStore        = "data_store"
+	componentSubTypeDatabaseTable = "database_table"
+	componentTypeInternalService  = "internal_service"
+	componentSubTypeGRPC          = "grpc"
+	componentSubTypeThirdParty    = "third_party"
+)
+
 var (
 	unwantedVersionCharRegex = regexp.MustCompile(`[^0-9.]+`)
 )
@@ -57,13 +78,24 @@ func New(isInternal bool) *Holder {
 	}
 }
 
+// InProcessorCategories reports whether a component is kept when third parties
+// are filtered by processor category. Components other than third parties,
+// and all components when no categories are given, are always kept.
+func InProcessorCategories(component types.Component, categories []string) bool {
+	if len(categories) == 0 || component.SubType != componentSubTypeThirdParty {
+		return true
+	}
+
+	return slices.Contains(categories, component.Category)
+}
+
 func getComponentType(recipeType string, reason string) string {
 	if recipeType != "" {
 		return recipeType
 	}
 
 	if strings.HasPrefix(reason, "internal") {
-		return "internal_service"
+		return componentTypeInternalService
 	}
 	return "external_service"
 }
@@ -85,6 +117,7 @@ func (holder *Holder) AddInterface(classifiedDetection interfaceclassification.C
 			classifiedDetection.Classification.Name(),
 			componentType,
 			componentSubType,
+			classifiedDetection.Classification.RecipeCategory,
 			componentUUID,
 			string(classifiedDetection.DetectorType),
 			classifiedDetection.Source.Filename,
@@ -123,6 +156,7 @@ func (holder *Holder) AddDependency(classifiedDetection dependenciesclassificati
 			classifiedDetection.Classification.RecipeName,
 			componentType,
 			componentSubType,
+			classifiedDetection.Classification.RecipeCategory,
 			classifiedDetection.Classification.RecipeUUID,
 			string(classifiedDetection.DetectorType),
 			classifiedDetection.Source.Filename,
@@ -143,7 +177,7 @@ func (holder *Holder) AddFramework(classifiedDetection frameworkclassification.C
 		return nil
 	}
 
-	componentType := getComponentType(classifiedDetection.Classification.Decision.Reason, classifiedDetection.Classification.Decision.Reason)
+	componentType := getComponentType(classifiedDetection.Classification.RecipeType, classifiedDetection.Classification.Decision.Reason)
 	componentSubType := classifiedDetection.Classification.RecipeSubType
 
 	if classifiedDetection.Classification.Decision.State == classify.Valid {
@@ -151,17 +185,137 @@ func (holder *Holder) AddFramework(classifiedDetection frameworkclassification.C
 			classifiedDetection.Classification.RecipeName,
 			componentType,
 			componentSubType,
+			classifiedDetection.Classification.RecipeCategory,
 			classifiedDetection.Classification.RecipeUUID,
 			string(classifiedDetection.DetectorType),
 			classifiedDetection.Source.Filename,
 			classifiedDetection.Source.FullFilename,
 			*classifiedDetection.Source.StartLineNumber,
 		)
+
+		framework := decodeFramework(classifiedDetection.Value)
+
+		if framework.DataStore != nil {
+			holder.addDataStore(
+				classifiedDetection.Classification.RecipeUUID,
+				string(classifiedDetection.DetectorType),
+				classifiedDetection.Source.Filename,
+				*classifiedDetection.Source.StartLineNumber,
+				framework.DataStore,
+			)
+		}
+
+		if framework.Resource != nil {
+			holder.addResource(
+				classifiedDetection.Classification.RecipeUUID,
+				string(classifiedDetection.DetectorType),
+				classifiedDetection.Source.Filename,
+				*classifiedDetection.Source.StartLineNumber,
+				framework.Resource,
+			)
+		}
 	}
 
 	return nil
 }
 
+// frameworkDetails are the details of a component held by a framework
+// detection
+type frameworkDetails struct {
+	// the connection details of a data store, such as those of a database
+	// configured in Rails or Spring
+	DataStore *connection.DataStore `json:"data_store"`
+	// the infrastructure resource declaring the component, such as a bucket
+	// declared in Terraform
+	Resource *terraform.Definition `json:"resource"`
+}
+
+func decodeFramework(value interface{}) frameworkDetails {
+	var framework frameworkDetails
+
+	buf := bytes.NewBuffer(nil)
+	if err := json.NewEncoder(buf).Encode(value); err != nil {
+		return framework
+	}
+	if err := json.NewDecoder(buf).Decode(&framework); err != nil {
+		return frameworkDetails{}
+	}
+
+	return framework
+}
+
+// AddTable adds the database table holding a classified column, as found in
+// a SQL table definition
+func (holder *Holder) AddTable(detection detections.Detection) error {
+	schema, err := detectiondecoder.GetSchema(detection)
+	if err != nil {
+		return err
+	}
+
+	classification, err := detectiondecoder.GetSchemaClassification(schema)
+	if err != nil {
+		return err
+	}
+
+	if classification.Decision.State != classify.Valid || schema.ObjectName == "" {
+		return nil
+	}
+
+	// point to the table definition rather than to each of its columns
+	lineNumber := *detection.Source.StartLineNumber
+	if schema.Source != nil && schema.Source.StartLineNumber != 0 {
+		lineNumber = schema.Source.StartLineNumber
+	}
+
+	holder.addComponent(
+		schema.ObjectName,
+		componentTypeDataStore,
+		componentSubTypeDatabaseTable,
+		"",
+		"table:"+schema.ObjectName,
+		string(detection.DetectorType),
+		detection.Source.Filename,
+		detection.Source.FullFilename,
+		lineNumber,
+	)
+
+	return nil
+}
+
+// AddService adds the gRPC service implementing an operation, as found in a
+// Protocol Buffers service definition
+func (holder *Holder) AddService(detection detections.Detection) error {
+	var operation operations.Operation
+	buf := bytes.NewBuffer(nil)
+	if err := json.NewEncoder(buf).Encode(detection.Value); err != nil {
+		return fmt.Errorf("expect detection to have value of type operation %#v", detection.Value)
+	}
+	if err := json.NewDecoder(buf).Decode(&operation); err != nil {
+		return fmt.Errorf("expect detection to have value of type operation %#v", detection.Value)
+	}
+
+	// gRPC paths are of the form /package.Service/Method
+	separator := strings.LastIndex(operation.Path, "/")
+	if separator <= 0 {
+		return nil
+	}
+	serviceName := operation.Path[1:separator]
+
+	holder.addComponent(
+		serviceName,
+		componentTypeInternalService,
+		componentSubTypeGRPC,
+		"",
+		"grpc:"+serviceName,
+		string(detection.DetectorType),
+		detection.Source.Filename,
+		detection.Source.FullFilename,
+		*detection.Source.StartLineNumber,
+	)
+
+	return nil
+}
+
 // addComponent adds component to hash list and at the same time blocks duplicates
 func (holder *Holder) addDependency(
 	detectorName string,
@@ -190,6 +344,7 @@ func (holder *Holder) addComponent(
 	componentName string,
 	componentType string,
 	componentSubType string,
+	componentCategory string,
 	componentUUID string,
 	detectorName string,
 	fileName string,
@@ -206,6 +361,7 @@ func (holder *Holder) addComponent(
 			name:               componentName,
 			component_type:     componentType,
 			component_sub_type: componentSubType,
+			category:           componentCategory,
 			uuid:               uuid,
 			detectors:          make(map[string]*detector),
 		}
@@ -233,6 +389,40 @@ func (holder *Holder) addComponent(
 	targetDetector.files[fileName].lineNumbers[lineNumber] = lineNumber
 }
 
+// addDataStore records the connection details of a data store at one of the
+// locations of its component
+func (holder *Holder) addDataStore(
+	componentUUID string,
+	detectorName string,
+	fileName string,
+	lineNumber int,
+	dataStore *connection.DataStore,
+) {
+	targetFile := holder.components[componentUUID].detectors[detectorName].files[fileName]
+	if targetFile.dataStores == nil {
+		targetFile.dataStores = make(map[int]*connection.DataStore)
+	}
+
+	targetFile.dataStores[lineNumber] = dataStore
+}
+
+// addResource records the infrastructure resource declaring a component at one
+// of its locations
+func (holder *Holder) addResource(
+	componentUUID string,
+	detectorName string,
+	fileName string,
+	lineNumber int,
+	resource *terraform.Definition,
+) {
+	targetFile := holder.components[componentUUID].detectors[detectorName].files[fileName]
+	if targetFile.resources == nil {
+		targetFile.resources = make(map[int]*terraform.Definition)
+	}
+
+	targetFile.resources[lineNumber] = resource
+}
+
 func (holder *Holder) ToDataFlowForDependencies() []types.Dependency {
 	data := make([]types.Dependency, 0)
 
@@ -261,6 +451,7 @@ func (holder *Holder) ToDataFlow() []types.Component {
 			Name:      targetComponent.name,
 			Type:      targetComponent.component_type,
 			SubType:   targetComponent.component_sub_type,
+			Category:  targetComponent.category,
 			UUID:      targetComponent.uuid,
 			Locations: make([]types.ComponentLocation, 0),
 		}
@@ -273,6 +464,8 @@ func (holder *Holder) ToDataFlow() []types.Component {
 						FullFilename: targetFile.fullName,
 						LineNumber:   targetLineNumber,
 						Detector:     targetDetector.name,
+						DataStore:    targetFile.dataStores[targetLineNumber],
+						Resource:     targetFile.resources[targetLineNumber],
 					})
 				}
 			}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/report/frameworks/terraform"
 	"github.com/bearer/bearer/internal/report/output/dataflow"
 	"github.com/bearer/bearer/internal/report/output/dataflow/types"
 	"github.com/bearer/bearer/internal/report/output/detectors"
@@ -114,37 +115,110 @@ func TestDataflowComponents(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:        "single detection - terraform resource",
+			FileContent: `{"detector_type": "terraform", "type": "framework_classified", "source": {"filename": "main.tf", "full_filename": "main.tf", "start_line_number": 3}, "value": {"resource": {"type": "aws_s3_bucket", "name": "uploads", "encrypted": true, "public_access": false}}, "classification": {"Decision": {"state": "valid", "reason": "recipe_match"}, "recipe_name": "AWS S3", "recipe_match": true, "recipe_type": "data_store", "recipe_sub_type": "object_storage", "recipe_uuid": "4e5a3a3a-47cd-4b0e-b0a6-fa30a0a62499"}}`,
+			Want: []types.Component{
+				{
+					Name:    "AWS S3",
+					Type:    "data_store",
+					SubType: "object_storage",
+					Locations: []types.ComponentLocation{
+						{
+							Detector:     "terraform",
+							FullFilename: "main.tf",
+							Filename:     "main.tf",
+							LineNumber:   3,
+							Resource: &terraform.Definition{
+								Type:         "aws_s3_bucket",
+								Name:         "uploads",
+								Encrypted:    boolPointer(true),
+								PublicAccess: boolPointer(false),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "single detection - grpc service",
+			FileContent: `{"detector_type": "proto", "type": "operation", "source": {"filename": "orders.proto", "start_line_number": 8}, "value": {"path": "/shop.orders.v1.OrderService/PlaceOrder", "type": "RPC"}}
+{"detector_type": "proto", "type": "operation", "source": {"filename": "orders.proto", "start_line_number": 9}, "value": {"path": "/shop.orders.v1.OrderService/ListOrders", "type": "RPC"}}`,
+			Want: []types.Component{
+				{
+					Name:    "shop.orders.v1.OrderService",
+					Type:    "internal_service",
+					SubType: "grpc",
+					Locations: []types.ComponentLocation{
+						{
+							Detector:     "proto",
+							FullFilename: "orders.proto",
+							Filename:     "orders.proto",
+							LineNumber:   8,
+						},
+						{
+							Detector:     "proto",
+							FullFilename: "orders.proto",
+							Filename:     "orders.proto",
+							LineNumber:   9,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.Name, func(t *testing.T) {
-			file, err := os.CreateTemp("", "*test.jsonlines")
-			if err != nil {
-				t.Fatalf("failed to create tmp file for report %s", err)
-				return
-			}
-			defer os.Remove(file.Name())
-			_, err = file.Write([]byte(test.FileContent))
-			if err != nil {
-				t.Fatalf("failed to write to tmp file %s", err)
-				return
-			}
-			file.Close()
+			assert.Equal(t, test.Want, dataflowComponents(t, test.FileContent, settings.Config{}))
+		})
+	}
+}
 
-			output := &outputtypes.ReportData{}
-			if err = detectors.AddReportData(output, globaltypes.Report{
-				Path: file.Name(),
-			}, settings.Config{}); err != nil {
-				t.Fatalf("failed to get detectors output %s", err)
-				return
-			}
+func TestDataflowComponentsWithProcessorCategories(t *testing.T) {
+	fileContent := `{	"detector_type": "ruby", "type": "interface_classified", "source": {"filename": "billing.rb", "line_number": 2, "start_line_number": 2}, "classification": { "Decision": { "state": "valid" }, "recipe_name": "Stripe", "recipe_match": true, "recipe_type": "external_service", "recipe_sub_type": "third_party", "recipe_category": "payment"}}
+{	"detector_type": "ruby", "type": "interface_classified", "source": {"filename": "tracking.rb", "line_number": 5, "start_line_number": 5}, "classification": { "Decision": { "state": "valid" }, "recipe_name": "Google Ads", "recipe_match": true, "recipe_type": "external_service", "recipe_sub_type": "third_party", "recipe_category": "advertising"}}
+{	"detector_type": "ruby", "type": "interface_classified", "source": {"filename": "config.rb", "line_number": 1, "start_line_number": 1}, "classification": { "Decision": { "state": "valid" }, "recipe_name": "PostgreSQL", "recipe_match": true, "recipe_type": "data_store", "recipe_sub_type": "database"}}`
 
-			if err = dataflow.AddReportData(output, settings.Config{}, false, true); err != nil {
-				t.Fatalf("failed to get dataflow output %s", err)
-				return
-			}
+	config := settings.Config{}
+	config.Report.ProcessorCategories = []string{"advertising"}
 
-			assert.Equal(t, test.Want, output.Dataflow.Components)
-		})
+	var names, categories []string
+	for _, component := range dataflowComponents(t, fileContent, config) {
+		names = append(names, component.Name)
+		categories = append(categories, component.Category)
 	}
+
+	assert.Equal(t, []string{"Google Ads", "PostgreSQL"}, names, "only third parties are filtered")
+	assert.Equal(t, []string{"advertising", ""}, categories)
+}
+
+func dataflowComponents(t *testing.T, fileContent string, config settings.Config) []types.Component {
+	file, err := os.CreateTemp("", "*test.jsonlines")
+	if err != nil {
+		t.Fatalf("failed to create tmp file for report %s", err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write([]byte(fileContent))
+	if err != nil {
+		t.Fatalf("failed to write to tmp file %s", err)
+	}
+	file.Close()
+
+	output := &outputtypes.ReportData{}
+	if err = detectors.AddReportData(output, globaltypes.Report{
+		Path: file.Name(),
+	}, config); err != nil {
+		t.Fatalf("failed to get detectors output %s", err)
+	}
+
+	if err = dataflow.AddReportData(output, config, false, true); err != nil {
+		t.Fatalf("failed to get dataflow output %s", err)
+	}
+
+	return output.Dataflow.Components
+}
+
+func boolPointer(value bool) *bool {
+	return &value
 }
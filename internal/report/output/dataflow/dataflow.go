@@ -12,8 +12,10 @@ import (
 	"github.com/bearer/bearer/internal/report/output/dataflow/components"
 	"github.com/bearer/bearer/internal/report/output/dataflow/datatypes"
 	"github.com/bearer/bearer/internal/report/output/dataflow/detectiondecoder"
+	"github.com/bearer/bearer/internal/report/output/dataflow/endpoints"
 	fileerrors "github.com/bearer/bearer/internal/report/output/dataflow/file_errors"
 	"github.com/bearer/bearer/internal/report/output/dataflow/risks"
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
 	"github.com/bearer/bearer/internal/report/output/types"
 	"github.com/bearer/bearer/internal/util/file"
 	"github.com/bearer/bearer/internal/util/output"
@@ -27,10 +29,15 @@ var allowedDetections []detections.DetectionType = []detections.DetectionType{
 	detections.TypeFrameworkClassified,
 	detections.TypeCustomRisk,
 	detections.TypeSecretleak,
+	detections.TypeSampleData,
+	detections.TypeInfrastructureRisk,
 	detections.TypeError,
 	detections.TypeFileList,
 	detections.TypeFileFailed,
 	detections.TypeExpectedDetection,
+	detections.TypeSuppressionWarning,
+	detections.TypeOperation,
+	detections.TypePurpose,
 }
 
 func contains(detections []detections.DetectionType, detection detections.DetectionType) bool {
@@ -45,7 +52,7 @@ func contains(detections []detections.DetectionType, detection detections.Detect
 
 func AddReportData(reportData *types.ReportData, config settings.Config, isInternal, hasFiles bool) error {
 	if !hasFiles {
-		reportData.Dataflow = &types.DataFlow{}
+		reportData.Dataflow = &types.DataFlow{Metadata: config.Report.Meta}
 		return nil
 	}
 
@@ -53,6 +60,7 @@ func AddReportData(reportData *types.ReportData, config settings.Config, isInter
 	dataTypesHolder := datatypes.New(config, isInternal)
 	risksHolder := risks.New(config, isInternal)
 	componentsHolder := components.New(isInternal)
+	endpointsHolder := endpoints.New()
 	errorsHolder := fileerrors.New()
 
 	extras, err := datatypes.NewExtras(reportData.Detectors, config)
@@ -66,6 +74,7 @@ func AddReportData(reportData *types.ReportData, config settings.Config, isInter
 	}
 
 	var files []string
+	var suppressionWarnings []dataflowtypes.SuppressionWarning
 	for _, detection := range reportData.Detectors {
 		detectionMap, ok := detection.(map[string]interface{})
 		if !ok {
@@ -137,15 +146,44 @@ func AddReportData(reportData *types.ReportData, config settings.Config, isInter
 			switch detectionType {
 			case detections.TypeSchemaClassified:
 				var detectionExtras *datatypes.ExtraFields
-				if castDetection.DetectorType == reportdetectors.DetectorSchemaRb {
+				if castDetection.DetectorType == reportdetectors.DetectorSchemaRb ||
+					castDetection.DetectorType == reportdetectors.DetectorSQLCreateTable {
 					detectionExtras = customExtras.Get(detection)
 				}
 
 				if err = dataTypesHolder.AddSchema(castDetection, detectionExtras); err != nil {
 					return err
 				}
+
+				if castDetection.DetectorType == reportdetectors.DetectorSQLCreateTable {
+					if err = componentsHolder.AddTable(castDetection); err != nil {
+						return err
+					}
+				}
+			case detections.TypePurpose:
+				if err = dataTypesHolder.AddPurpose(castDetection); err != nil {
+					return err
+				}
 			case detections.TypeExpectedDetection:
 				expectedHolder.AddRiskPresence(castDetection)
+			case detections.TypeSuppressionWarning:
+				suppressionWarnings = append(suppressionWarnings, dataflowtypes.SuppressionWarning{
+					RuleID:       string(castDetection.DetectorType),
+					Filename:     castDetection.Source.Filename,
+					FullFilename: castDetection.Source.FullFilename,
+					LineNumber:   *castDetection.Source.StartLineNumber,
+					Message:      *castDetection.Source.Text,
+				})
+			case detections.TypeOperation:
+				if err = endpointsHolder.AddOperation(castDetection); err != nil {
+					return err
+				}
+
+				if castDetection.DetectorType == reportdetectors.DetectorProto {
+					if err = componentsHolder.AddService(castDetection); err != nil {
+						return err
+					}
+				}
 			case detections.TypeCustomRisk:
 				ruleName := string(castDetection.DetectorType)
 				customDetector, ok := config.Rules[ruleName]
@@ -186,7 +224,7 @@ func AddReportData(reportData *types.ReportData, config settings.Config, isInter
 						return err
 					}
 				}
-			case detections.TypeSecretleak:
+			case detections.TypeSecretleak, detections.TypeSampleData, detections.TypeInfrastructureRisk:
 				risksHolder.AddRiskPresence(castDetection)
 			case detections.TypeDependencyClassified:
 				classifiedDetection, err := detectiondecoder.GetClassifiedDependency(detection)
@@ -226,15 +264,35 @@ func AddReportData(reportData *types.ReportData, config settings.Config, isInter
 		output.StdErrLog("Generating dataflow")
 	}
 
+	dataflowDatatypes := dataTypesHolder.ToDataFlow()
+	dataflowComponents := componentsHolder.ToDataFlow()
+	if !isInternal {
+		dataflowComponents = filterComponents(dataflowComponents, config.Report.ProcessorCategories)
+	}
+
 	reportData.Files = files
 	reportData.Dataflow = &types.DataFlow{
-		Datatypes:          dataTypesHolder.ToDataFlow(),
-		ExpectedDetections: expectedHolder.ToDataFlow(),
-		Risks:              risksHolder.ToDataFlow(),
-		Components:         componentsHolder.ToDataFlow(),
-		Dependencies:       componentsHolder.ToDataFlowForDependencies(),
-		Errors:             errorsHolder.ToDataFlow(),
+		Datatypes:           dataflowDatatypes,
+		ExpectedDetections:  expectedHolder.ToDataFlow(),
+		Risks:               risksHolder.ToDataFlow(),
+		Components:          dataflowComponents,
+		Endpoints:           endpointsHolder.ToDataFlow(dataflowDatatypes, dataflowComponents),
+		Dependencies:        componentsHolder.ToDataFlowForDependencies(),
+		Errors:              errorsHolder.ToDataFlow(),
+		SuppressionWarnings: suppressionWarnings,
+		Metadata:            config.Report.Meta,
 	}
 
 	return nil
 }
+
+func filterComponents(dataflowComponents []dataflowtypes.Component, categories []string) []dataflowtypes.Component {
+	result := make([]dataflowtypes.Component, 0, len(dataflowComponents))
+	for _, component := range dataflowComponents {
+		if components.InProcessorCategories(component, categories) {
+			result = append(result, component)
+		}
+	}
+
+	return result
+}
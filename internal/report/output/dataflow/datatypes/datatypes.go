@@ -13,14 +13,23 @@ import (
 	"github.com/bearer/bearer/internal/report/detections"
 	"github.com/bearer/bearer/internal/util/classify"
 	"github.com/bearer/bearer/internal/util/maputil"
+	"github.com/bearer/bearer/internal/util/purpose"
 )
 
 type Holder struct {
 	datatypes  map[string]datatypeHolder // group datatypeHolders by name
+	purposes   map[purposeKey]purpose.Annotation
 	config     settings.Config
 	isInternal bool
 }
 
+// purposeKey is the location of a data type annotated with a processing purpose
+type purposeKey struct {
+	detectorName string
+	fileName     string
+	lineNumber   int
+}
+
 type datatypeHolder struct {
 	name           string
 	uuid           string
@@ -57,6 +66,7 @@ type lineNumberHolder struct {
 func New(config settings.Config, isInternal bool) *Holder {
 	return &Holder{
 		datatypes:  make(map[string]datatypeHolder),
+		purposes:   make(map[purposeKey]purpose.Annotation),
 		config:     config,
 		isInternal: isInternal,
 	}
@@ -91,6 +101,23 @@ func (holder *Holder) AddSchema(detection detections.Detection, extras *ExtraFie
 	return nil
 }
 
+// AddPurpose records the processing purpose annotated on the data types found
+// at the location of the detection
+func (holder *Holder) AddPurpose(detection detections.Detection) error {
+	annotation, err := detectiondecoder.GetPurpose(detection)
+	if err != nil {
+		return err
+	}
+
+	holder.purposes[purposeKey{
+		detectorName: string(detection.DetectorType),
+		fileName:     detection.Source.Filename,
+		lineNumber:   *detection.Source.StartLineNumber,
+	}] = annotation
+
+	return nil
+}
+
 // addDatatype adds datatype to hash list and at the same time blocks duplicates
 func (holder *Holder) addDatatype(
 	classification *db.DataType,
@@ -168,7 +195,7 @@ func (holder *Holder) addDatatype(
 		lineEntry.verifiedBy = extras.verifiedBy
 	}
 
-	if detectorName == string(detectors.DetectorSchemaRb) {
+	if detectorName == string(detectors.DetectorSchemaRb) || detectorName == string(detectors.DetectorSQLCreateTable) {
 		storedFlag := true
 		lineEntry.stored = &storedFlag
 	} else if customDetector, isCustomDetector := holder.config.Rules[detectorName]; isCustomDetector {
@@ -198,6 +225,7 @@ func (holder *Holder) ToDataFlow() []types.Datatype {
 			CategoryGroups: datatype.categoryGroups,
 		}
 
+		subjectNames := make(map[string]struct{})
 		detectors := maputil.ToSortedSlice(datatype.detectors)
 
 		for _, detectorHolder := range detectors {
@@ -222,12 +250,30 @@ func (holder *Holder) ToDataFlow() []types.Datatype {
 						ObjectName:        lineNumber.objectName,
 						SubjectName:       lineNumber.subjectName,
 					}
+
+					if annotation, ok := holder.purposes[purposeKey{
+						detectorName: detectorHolder.name,
+						fileName:     fileHolder.name,
+						lineNumber:   lineNumber.startLineNumber,
+					}]; ok {
+						location.Purpose = annotation.Purpose
+						location.Retention = annotation.Retention
+					}
+
 					constructedDetector.Locations = append(constructedDetector.Locations, location)
+
+					if lineNumber.subjectName != nil && *lineNumber.subjectName != "" {
+						subjectNames[*lineNumber.subjectName] = struct{}{}
+					}
 				}
 			}
 			constructedDatatype.Detectors = append(constructedDatatype.Detectors, constructedDetector)
 		}
 
+		if len(subjectNames) != 0 {
+			constructedDatatype.SubjectNames = maputil.SortedStringKeys(subjectNames)
+		}
+
 		data = append(data, constructedDatatype)
 	}
 
@@ -195,6 +195,43 @@ func TestDataflowDataType(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:   "single detection - with purpose",
+			Config: config,
+			FileContent: `{"type": "purpose", "detector_type":"ruby", "source": {"filename": "./users.rb", "line_number": 25, "start_line_number": 25, "end_line_number": 25, "end_column_number": 30, "start_column_number": 20}, "value": {"purpose": "billing", "retention": "7y"}}
+{"type": "schema_classified", "detector_type":"ruby", "source": {"filename": "./users.rb", "line_number": 25, "start_line_number": 25, "end_line_number": 25, "end_column_number": 30, "start_column_number": 20}, "value": {"field_name": "User_name", "classification": {"data_type": {"name": "Username"} ,"decision":{"state": "valid"}}}}
+{"type": "schema_classified", "detector_type":"ruby", "source": {"filename": "./users.rb", "line_number": 26, "start_line_number": 26, "end_line_number": 26, "end_column_number": 30, "start_column_number": 20}, "value": {"field_name": "User_name", "classification": {"data_type": {"name": "Username"} ,"decision":{"state": "valid"}}}}`,
+			Want: []types.Datatype{
+				{
+					Name: "Username",
+					Detectors: []types.DatatypeDetector{
+						{
+							Name: "ruby",
+							Locations: []types.DatatypeLocation{
+								{
+									Filename:          "./users.rb",
+									FullFilename:      "./users.rb",
+									StartLineNumber:   25,
+									StartColumnNumber: 20,
+									EndColumnNumber:   30,
+									FieldName:         "User_name",
+									Purpose:           "billing",
+									Retention:         "7y",
+								},
+								{
+									Filename:          "./users.rb",
+									FullFilename:      "./users.rb",
+									StartLineNumber:   26,
+									StartColumnNumber: 20,
+									EndColumnNumber:   30,
+									FieldName:         "User_name",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -47,7 +47,8 @@ func getCustomTargetDetections(allDetections []interface{}) ([]interface{}, erro
 			continue
 		}
 
-		if detectors.Type(detectorType) != detectors.DetectorSchemaRb {
+		if detectors.Type(detectorType) != detectors.DetectorSchemaRb &&
+			detectors.Type(detectorType) != detectors.DetectorSQLCreateTable {
 			continue
 		}
 
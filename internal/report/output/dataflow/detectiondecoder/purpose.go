@@ -0,0 +1,25 @@
+package detectiondecoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bearer/bearer/internal/report/detections"
+	"github.com/bearer/bearer/internal/util/purpose"
+)
+
+func GetPurpose(detection detections.Detection) (purpose.Annotation, error) {
+	var value purpose.Annotation
+	buf := bytes.NewBuffer(nil)
+	err := json.NewEncoder(buf).Encode(detection.Value)
+	if err != nil {
+		return purpose.Annotation{}, fmt.Errorf("expect detection to have value of type purpose %#v", detection.Value)
+	}
+	err = json.NewDecoder(buf).Decode(&value)
+	if err != nil {
+		return purpose.Annotation{}, fmt.Errorf("expect detection to have value of type purpose %#v", detection.Value)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,129 @@
+package endpoints
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bearer/bearer/internal/report/detections"
+	"github.com/bearer/bearer/internal/report/operations"
+	"github.com/bearer/bearer/internal/report/output/dataflow/types"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+type Holder struct {
+	endpoints []endpoint
+}
+
+type endpoint struct {
+	detectorName string
+	fileName     string
+	fullFilename string
+	lineNumber   int
+	operation    operations.Operation
+}
+
+func New() *Holder {
+	return &Holder{}
+}
+
+func (holder *Holder) AddOperation(detection detections.Detection) error {
+	var operation operations.Operation
+	buf := bytes.NewBuffer(nil)
+	if err := json.NewEncoder(buf).Encode(detection.Value); err != nil {
+		return fmt.Errorf("expect detection to have value of type operation %#v", detection.Value)
+	}
+	if err := json.NewDecoder(buf).Decode(&operation); err != nil {
+		return fmt.Errorf("expect detection to have value of type operation %#v", detection.Value)
+	}
+
+	lineNumber := 0
+	if detection.Source.StartLineNumber != nil {
+		lineNumber = *detection.Source.StartLineNumber
+	}
+
+	holder.endpoints = append(holder.endpoints, endpoint{
+		detectorName: string(detection.DetectorType),
+		fileName:     detection.Source.Filename,
+		fullFilename: detection.Source.FullFilename,
+		lineNumber:   lineNumber,
+		operation:    operation,
+	})
+
+	return nil
+}
+
+// ToDataFlow links each endpoint to the data types of the schemas it sends and
+// returns, and to the components declared in the same specification
+func (holder *Holder) ToDataFlow(datatypes []types.Datatype, components []types.Component) []types.Endpoint {
+	data := make([]types.Endpoint, 0)
+
+	for _, endpoint := range holder.endpoints {
+		data = append(data, types.Endpoint{
+			Method:       endpoint.operation.Type,
+			Path:         endpoint.operation.Path,
+			OperationId:  endpoint.operation.OperationId,
+			Detector:     endpoint.detectorName,
+			FullFilename: endpoint.fullFilename,
+			Filename:     endpoint.fileName,
+			LineNumber:   endpoint.lineNumber,
+			Request:      endpoint.payload(endpoint.operation.RequestSchemas, datatypes),
+			Response:     endpoint.payload(endpoint.operation.ResponseSchemas, datatypes),
+			Components:   endpoint.componentNames(components),
+		})
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		if data[i].Filename != data[j].Filename {
+			return data[i].Filename < data[j].Filename
+		}
+
+		return data[i].LineNumber < data[j].LineNumber
+	})
+
+	return data
+}
+
+func (endpoint endpoint) payload(schemas []string, datatypes []types.Datatype) types.EndpointPayload {
+	schemaNames := set.New[string]()
+	schemaNames.AddAll(schemas)
+
+	dataTypeNames := set.New[string]()
+	for _, datatype := range datatypes {
+		for _, detector := range datatype.Detectors {
+			if detector.Name != endpoint.detectorName {
+				continue
+			}
+
+			for _, location := range detector.Locations {
+				if location.Filename == endpoint.fileName && schemaNames.Has(location.ObjectName) {
+					dataTypeNames.Add(datatype.Name)
+				}
+			}
+		}
+	}
+
+	var names []string
+	names = append(names, dataTypeNames.Items()...)
+	sort.Strings(names)
+
+	return types.EndpointPayload{
+		Schemas:   schemas,
+		DataTypes: names,
+	}
+}
+
+func (endpoint endpoint) componentNames(components []types.Component) []string {
+	var names []string
+	for _, component := range components {
+		for _, location := range component.Locations {
+			if location.Detector == endpoint.detectorName && location.Filename == endpoint.fileName {
+				names = append(names, component.Name)
+				break
+			}
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,96 @@
+package endpoints_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/report/output/dataflow"
+	"github.com/bearer/bearer/internal/report/output/dataflow/types"
+	"github.com/bearer/bearer/internal/report/output/detectors"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
+)
+
+func TestDataflowEndpoints(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		FileContent string
+		Want        []types.Endpoint
+	}{
+		{
+			Name:        "operation without data types",
+			FileContent: `{"type": "operation", "detector_type": "openapi", "source": {"filename": "openapi.yaml", "start_line_number": 9}, "value": {"path": "/health", "type": "GET"}}`,
+			Want: []types.Endpoint{
+				{
+					Method:       "GET",
+					Path:         "/health",
+					Detector:     "openapi",
+					FullFilename: "openapi.yaml",
+					Filename:     "openapi.yaml",
+					LineNumber:   9,
+				},
+			},
+		},
+		{
+			Name: "operation linked to data types and components",
+			FileContent: `{"type": "operation", "detector_type": "openapi", "source": {"filename": "openapi.yaml", "start_line_number": 9}, "value": {"path": "/users", "type": "POST", "operation_id": "createUser", "request_schemas": ["User"], "response_schemas": ["Receipt"]}}
+{"type": "schema_classified", "detector_type": "openapi", "source": {"filename": "openapi.yaml", "start_line_number": 30, "start_column_number": 9, "end_column_number": 14}, "value": {"object_name": "User", "field_name": "email", "classification": {"data_type": {"name": "Email Address"}, "decision": {"state": "valid"}}}}
+{"type": "schema_classified", "detector_type": "openapi", "source": {"filename": "other.yaml", "start_line_number": 12, "start_column_number": 9, "end_column_number": 14}, "value": {"object_name": "Receipt", "field_name": "iban", "classification": {"data_type": {"name": "Bank Account"}, "decision": {"state": "valid"}}}}
+{"type": "interface_classified", "detector_type": "openapi", "source": {"filename": "openapi.yaml", "start_line_number": 3}, "classification": {"Decision": {"state": "valid"}, "recipe_name": "Stripe", "recipe_match": true, "recipe_type": "external_service", "recipe_sub_type": "third_party"}}`,
+			Want: []types.Endpoint{
+				{
+					Method:       "POST",
+					Path:         "/users",
+					OperationId:  "createUser",
+					Detector:     "openapi",
+					FullFilename: "openapi.yaml",
+					Filename:     "openapi.yaml",
+					LineNumber:   9,
+					Request: types.EndpointPayload{
+						Schemas:   []string{"User"},
+						DataTypes: []string{"Email Address"},
+					},
+					Response: types.EndpointPayload{
+						Schemas: []string{"Receipt"},
+					},
+					Components: []string{"Stripe"},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.Name, func(t *testing.T) {
+			file, err := os.CreateTemp("", "*test.jsonlines")
+			if err != nil {
+				t.Fatalf("failed to create tmp file for report %s", err)
+				return
+			}
+			defer os.Remove(file.Name())
+			_, err = file.Write([]byte(test.FileContent))
+			if err != nil {
+				t.Fatalf("failed to write to tmp file %s", err)
+				return
+			}
+			file.Close()
+
+			output := &outputtypes.ReportData{}
+			if err = detectors.AddReportData(output, globaltypes.Report{
+				Path: file.Name(),
+			}, settings.Config{}); err != nil {
+				t.Fatalf("failed to get detectors output %s", err)
+				return
+			}
+
+			if err = dataflow.AddReportData(output, settings.Config{}, false, true); err != nil {
+				t.Fatalf("failed to get dataflow output %s", err)
+				return
+			}
+
+			assert.Equal(t, test.Want, output.Dataflow.Endpoints)
+		})
+	}
+}
@@ -28,6 +28,7 @@ func (holder *Holder) AddError(detection detections.ErrorDetection) {
 		Type:     string(detection.Type),
 		Filename: detection.File,
 		Error:    detection.Message,
+		RuleID:   detection.RuleID,
 	})
 }
 
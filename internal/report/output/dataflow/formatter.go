@@ -3,6 +3,8 @@ package dataflow
 import (
 	"github.com/bearer/bearer/internal/commands/process/settings"
 	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/output/billofdata"
+	"github.com/bearer/bearer/internal/report/output/diagram"
 	outputtypes "github.com/bearer/bearer/internal/report/output/types"
 	outputhandler "github.com/bearer/bearer/internal/util/output"
 )
@@ -25,6 +27,16 @@ func (f Formatter) Format(format string) (output string, err error) {
 		return outputhandler.ReportJSON(f.ReportData.Dataflow)
 	case flag.FormatYAML:
 		return outputhandler.ReportYAML(f.ReportData.Dataflow)
+	case flag.FormatBillOfData:
+		billOfData, err := billofdata.ReportBillOfData(f.ReportData.Dataflow)
+		if err != nil {
+			return output, err
+		}
+		return outputhandler.ReportJSON(billOfData)
+	case flag.FormatMermaid:
+		return diagram.ReportMermaid(f.ReportData.Dataflow)
+	case flag.FormatDOT:
+		return diagram.ReportDOT(f.ReportData.Dataflow)
 	}
 
 	return output, err
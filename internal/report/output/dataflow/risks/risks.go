@@ -77,9 +77,15 @@ func (holder *Holder) AddRiskPresence(detection detections.Detection) {
 	var source *schema.Source
 	var content string
 
-	if detection.DetectorType == detectors.DetectorGitleaks {
-		value := detection.Value.(map[string]interface{})["description"]
-		content = value.(string)
+	if detection.DetectorType == detectors.DetectorGitleaks ||
+		detection.DetectorType == detectors.DetectorSampleData ||
+		detection.Type == detections.TypeInfrastructureRisk {
+		value := detection.Value.(map[string]interface{})
+		content = value["description"].(string)
+		// detectors of infrastructure files report the risks of several rules
+		if ruleID, ok := value["rule_id"].(string); ok {
+			ruleName = ruleID
+		}
 		source = &schema.Source{
 			StartLineNumber:   *detection.Source.StartLineNumber,
 			StartColumnNumber: *detection.Source.StartColumnNumber,
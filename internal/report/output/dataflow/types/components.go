@@ -1,9 +1,15 @@
 package types
 
+import (
+	"github.com/bearer/bearer/internal/report/frameworks/connection"
+	"github.com/bearer/bearer/internal/report/frameworks/terraform"
+)
+
 type Component struct {
 	Name      string              `json:"name" yaml:"name"`
 	Type      string              `json:"type" yaml:"type"`
 	SubType   string              `json:"sub_type" yaml:"sub_type"`
+	Category  string              `json:"category,omitempty" yaml:"category,omitempty"`
 	UUID      string              `json:"uuid,omitempty" yaml:"uuid,omitempty"`
 	Locations []ComponentLocation `json:"locations" yaml:"locations"`
 }
@@ -17,8 +23,10 @@ type Dependency struct {
 }
 
 type ComponentLocation struct {
-	Detector     string `json:"detector" yaml:"detector"`
-	FullFilename string `json:"full_filename" yaml:"full_filename"`
-	Filename     string `json:"filename" yaml:"filename"`
-	LineNumber   int    `json:"line_number" yaml:"line_number"`
+	Detector     string                `json:"detector" yaml:"detector"`
+	FullFilename string                `json:"full_filename" yaml:"full_filename"`
+	Filename     string                `json:"filename" yaml:"filename"`
+	LineNumber   int                   `json:"line_number" yaml:"line_number"`
+	DataStore    *connection.DataStore `json:"data_store,omitempty" yaml:"data_store,omitempty"`
+	Resource     *terraform.Definition `json:"resource,omitempty" yaml:"resource,omitempty"`
 }
@@ -10,6 +10,7 @@ type Datatype struct {
 	CategoryName   string             `json:"category_name,omitempty" yaml:"category_name,omitempty"`
 	CategoryGroups []string           `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
 	Name           string             `json:"name" yaml:"name"`
+	SubjectNames   []string           `json:"subject_names,omitempty" yaml:"subject_names,omitempty"`
 	Detectors      []DatatypeDetector `json:"detectors" yaml:"detectors"`
 }
 
@@ -32,6 +33,8 @@ type DatatypeLocation struct {
 	FieldName         string               `json:"field_name,omitempty" yaml:"field_name,omitempty"`
 	ObjectName        string               `json:"object_name,omitempty" yaml:"object_name,omitempty"`
 	SubjectName       *string              `json:"subject_name,omitempty" yaml:"subject_name,omitempty"`
+	Purpose           string               `json:"purpose,omitempty" yaml:"purpose,omitempty"`
+	Retention         string               `json:"retention,omitempty" yaml:"retention,omitempty"`
 }
 
 type DatatypeVerifiedBy struct {
@@ -0,0 +1,19 @@
+package types
+
+type Endpoint struct {
+	Method       string          `json:"method" yaml:"method"`
+	Path         string          `json:"path" yaml:"path"`
+	OperationId  string          `json:"operation_id,omitempty" yaml:"operation_id,omitempty"`
+	Detector     string          `json:"detector" yaml:"detector"`
+	FullFilename string          `json:"full_filename" yaml:"full_filename"`
+	Filename     string          `json:"filename" yaml:"filename"`
+	LineNumber   int             `json:"line_number" yaml:"line_number"`
+	Request      EndpointPayload `json:"request" yaml:"request"`
+	Response     EndpointPayload `json:"response" yaml:"response"`
+	Components   []string        `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+type EndpointPayload struct {
+	Schemas   []string `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	DataTypes []string `json:"data_types,omitempty" yaml:"data_types,omitempty"`
+}
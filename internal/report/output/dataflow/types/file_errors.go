@@ -3,5 +3,6 @@ package types
 type Error struct {
 	Type     string `json:"type" yaml:"type"`
 	Filename string `json:"filename" yaml:"filename"`
-	Error    string `json:"error" yaml:"filename"`
+	Error    string `json:"error" yaml:"error"`
+	RuleID   string `json:"rule_id,omitempty" yaml:"rule_id,omitempty"`
 }
@@ -0,0 +1,11 @@
+package types
+
+// SuppressionWarning is a `bearer:disable` comment needing review, because it
+// has expired or gives no reason
+type SuppressionWarning struct {
+	RuleID       string `json:"rule_id" yaml:"rule_id"`
+	Filename     string `json:"filename" yaml:"filename"`
+	FullFilename string `json:"full_filename" yaml:"full_filename"`
+	LineNumber   int    `json:"line_number" yaml:"line_number"`
+	Message      string `json:"message" yaml:"message"`
+}
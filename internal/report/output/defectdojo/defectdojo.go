@@ -0,0 +1,73 @@
+package defectdojo
+
+import (
+	"strconv"
+	"strings"
+
+	defectdojo "github.com/bearer/bearer/internal/report/output/defectdojo/types"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+)
+
+func ReportDefectDojo(outputDetections map[string][]securitytypes.Finding) (defectdojo.DefectDojoOutput, error) {
+	findings := []defectdojo.Finding{}
+
+	for _, level := range []string{"critical", "high", "medium", "low", "warning"} {
+		if levelFindings, ok := outputDetections[level]; ok {
+			for _, finding := range levelFindings {
+				findings = append(findings, defectdojo.Finding{
+					Title:            finding.Rule.Title,
+					Description:      extractDescription(finding.Rule.Description),
+					Severity:         formatSeverity(level),
+					Mitigation:       extractMitigation(finding.Rule.Description),
+					References:       finding.Rule.DocumentationUrl,
+					CWE:              firstCWE(finding.Rule.CWEIDs),
+					Tags:             finding.Rule.Mappings(),
+					UniqueIdFromTool: finding.Fingerprint,
+					VulnIdFromTool:   finding.Rule.Id,
+					FilePath:         finding.Filename,
+					Line:             finding.Sink.Start,
+					StaticFinding:    true,
+					DynamicFinding:   false,
+				})
+			}
+		}
+	}
+
+	return defectdojo.DefectDojoOutput{Findings: findings}, nil
+}
+
+func formatSeverity(level string) string {
+	switch level {
+	case "warning":
+		return "Info"
+	default:
+		return strings.ToUpper(level[:1]) + level[1:]
+	}
+}
+
+// DefectDojo only supports a single CWE per finding
+func firstCWE(cweIDs []string) int {
+	for _, cweID := range cweIDs {
+		if value, err := strconv.Atoi(cweID); err == nil {
+			return value
+		}
+	}
+
+	return 0
+}
+
+func extractDescription(body string) string {
+	split := strings.Split(body, "## Remediations")
+	if len(split) < 2 {
+		return strings.ReplaceAll(body, "## ", "")
+	}
+	return strings.Replace(split[0], "## Description\n", "", 1)
+}
+
+func extractMitigation(body string) string {
+	split := strings.Split(body, "## Remediations")
+	if len(split) < 2 {
+		return ""
+	}
+	return strings.Replace(split[len(split)-1], "## Resources\n", "Resources:\n", 1)
+}
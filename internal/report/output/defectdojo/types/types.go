@@ -0,0 +1,25 @@
+package types
+
+// Based on the generic findings import format described here:
+// https://documentation.defectdojo.com/integrations/parsers/file/generic/
+// Not all keys are implemented as not all are relevant to Bearer
+
+type Finding struct {
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	Severity         string   `json:"severity"` // Info, Low, Medium, High or Critical
+	Mitigation       string   `json:"mitigation,omitempty"`
+	References       string   `json:"references,omitempty"`
+	CWE              int      `json:"cwe,omitempty"`
+	Tags             []string `json:"tags,omitempty"`      // CWE ids and OWASP categories
+	UniqueIdFromTool string   `json:"unique_id_from_tool"` // fingerprint
+	VulnIdFromTool   string   `json:"vuln_id_from_tool"`   // rule id
+	FilePath         string   `json:"file_path"`
+	Line             int      `json:"line"`
+	StaticFinding    bool     `json:"static_finding"`
+	DynamicFinding   bool     `json:"dynamic_finding"`
+}
+
+type DefectDojoOutput struct {
+	Findings []Finding `json:"findings"`
+}
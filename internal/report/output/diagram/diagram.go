@@ -0,0 +1,186 @@
+package diagram
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+const componentTypeDataStore = "data_store"
+
+type node struct {
+	id    string
+	label string
+	// data stores are drawn as cylinders
+	dataStore bool
+}
+
+type edge struct {
+	from string
+	to   string
+}
+
+// diagram links data types to the files processing them, and those files to
+// the data stores and third parties found in them. Files storing data, such as
+// database schemas, are linked to every data store.
+type diagram struct {
+	dataTypes  []node
+	processors []node
+	components []node
+	edges      []edge
+}
+
+func build(dataflow *outputtypes.DataFlow) diagram {
+	var result diagram
+	if dataflow == nil {
+		return result
+	}
+
+	dataTypeFiles := make(map[string]set.Set[string])
+	processorIDs := make(map[string]string)
+	storingFiles := set.New[string]()
+	for _, dataType := range dataflow.Datatypes {
+		files := set.New[string]()
+		for _, detector := range dataType.Detectors {
+			for _, location := range detector.Locations {
+				files.Add(location.Filename)
+				processorIDs[location.Filename] = ""
+
+				if location.Stored != nil && *location.Stored {
+					storingFiles.Add(location.Filename)
+				}
+			}
+		}
+
+		dataTypeFiles[dataType.Name] = files
+	}
+
+	for i, filename := range maputil.SortedStringKeys(processorIDs) {
+		id := fmt.Sprintf("p%d", i)
+		processorIDs[filename] = id
+		result.processors = append(result.processors, node{id: id, label: filename})
+	}
+
+	for i, name := range maputil.SortedStringKeys(dataTypeFiles) {
+		id := fmt.Sprintf("d%d", i)
+		result.dataTypes = append(result.dataTypes, node{id: id, label: name})
+
+		files := dataTypeFiles[name].Items()
+		sort.Strings(files)
+		for _, filename := range files {
+			result.edges = append(result.edges, edge{from: id, to: processorIDs[filename]})
+		}
+	}
+
+	for i, component := range dataflow.Components {
+		id := fmt.Sprintf("c%d", i)
+		result.components = append(result.components, node{
+			id:        id,
+			label:     component.Name,
+			dataStore: component.Type == componentTypeDataStore,
+		})
+
+		files := set.New[string]()
+		for _, location := range component.Locations {
+			if processorID, processor := processorIDs[location.Filename]; processor {
+				files.Add(processorID)
+			}
+		}
+
+		if component.Type == componentTypeDataStore {
+			for _, filename := range storingFiles.Items() {
+				files.Add(processorIDs[filename])
+			}
+		}
+
+		processors := files.Items()
+		sort.Strings(processors)
+		for _, processorID := range processors {
+			result.edges = append(result.edges, edge{from: processorID, to: id})
+		}
+	}
+
+	return result
+}
+
+// ReportMermaid renders the data flow as a Mermaid flowchart
+func ReportMermaid(dataflow *outputtypes.DataFlow) (string, error) {
+	diagram := build(dataflow)
+
+	var builder strings.Builder
+	builder.WriteString("flowchart LR\n")
+
+	writeMermaidGroup(&builder, "data_types", "Data types", diagram.dataTypes)
+	writeMermaidGroup(&builder, "processors", "Processors", diagram.processors)
+	writeMermaidGroup(&builder, "components", "Data stores and third parties", diagram.components)
+
+	for _, edge := range diagram.edges {
+		fmt.Fprintf(&builder, "  %s --> %s\n", edge.from, edge.to)
+	}
+
+	return builder.String(), nil
+}
+
+func writeMermaidGroup(builder *strings.Builder, id, label string, nodes []node) {
+	if len(nodes) == 0 {
+		return
+	}
+
+	fmt.Fprintf(builder, "  subgraph %s [%s]\n", id, label)
+	for _, node := range nodes {
+		label := strings.ReplaceAll(node.label, `"`, "#quot;")
+		if node.dataStore {
+			fmt.Fprintf(builder, "    %s[(\"%s\")]\n", node.id, label)
+		} else {
+			fmt.Fprintf(builder, "    %s[\"%s\"]\n", node.id, label)
+		}
+	}
+	builder.WriteString("  end\n")
+}
+
+// ReportDOT renders the data flow as a Graphviz DOT graph
+func ReportDOT(dataflow *outputtypes.DataFlow) (string, error) {
+	diagram := build(dataflow)
+
+	var builder strings.Builder
+	builder.WriteString("digraph dataflow {\n")
+	builder.WriteString("  rankdir=LR;\n")
+	builder.WriteString("  node [shape=box];\n")
+
+	writeDOTGroup(&builder, "data_types", "Data types", diagram.dataTypes)
+	writeDOTGroup(&builder, "processors", "Processors", diagram.processors)
+	writeDOTGroup(&builder, "components", "Data stores and third parties", diagram.components)
+
+	for _, edge := range diagram.edges {
+		fmt.Fprintf(&builder, "  %s -> %s;\n", edge.from, edge.to)
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String(), nil
+}
+
+func writeDOTGroup(builder *strings.Builder, id, label string, nodes []node) {
+	if len(nodes) == 0 {
+		return
+	}
+
+	fmt.Fprintf(builder, "  subgraph cluster_%s {\n", id)
+	fmt.Fprintf(builder, "    label=%s;\n", dotString(label))
+	for _, node := range nodes {
+		if node.dataStore {
+			fmt.Fprintf(builder, "    %s [label=%s, shape=cylinder];\n", node.id, dotString(node.label))
+		} else {
+			fmt.Fprintf(builder, "    %s [label=%s];\n", node.id, dotString(node.label))
+		}
+	}
+	builder.WriteString("  }\n")
+}
+
+func dotString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
@@ -0,0 +1,44 @@
+package diagram_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/report/output/diagram"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+)
+
+func readDataflow(t *testing.T) *outputtypes.DataFlow {
+	dataflowOutput, err := os.ReadFile("testdata/dataflow.json")
+	if err != nil {
+		t.Fatalf("failed to read file, err: %s", err)
+	}
+
+	var dataflow outputtypes.DataFlow
+	if err := json.Unmarshal(dataflowOutput, &dataflow); err != nil {
+		t.Fatalf("couldn't unmarshal file output: %s", err)
+	}
+
+	return &dataflow
+}
+
+func TestMermaid(t *testing.T) {
+	output, err := diagram.ReportMermaid(readDataflow(t))
+	if err != nil {
+		t.Fatalf("failed to generate mermaid output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, output)
+}
+
+func TestDOT(t *testing.T) {
+	output, err := diagram.ReportDOT(readDataflow(t))
+	if err != nil {
+		t.Fatalf("failed to generate dot output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, output)
+}
@@ -0,0 +1,72 @@
+package gate
+
+import (
+	"fmt"
+	"strings"
+
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+)
+
+// maxFindings limits the findings listed in an explanation, so that it stays
+// short enough to read in a CI log or a pull request comment
+const maxFindings = 10
+
+// Explain describes which findings failed the scan, the thresholds that
+// applied and the commands to ignore or baseline them
+func Explain(gateFailure *securitytypes.GateFailure) string {
+	builder := &strings.Builder{}
+
+	kind := "findings"
+	if gateFailure.DiffScan {
+		kind = "new findings"
+	}
+	fmt.Fprintf(builder, "The scan failed because of %d %s:\n", len(gateFailure.Findings), kind)
+
+	for i, finding := range gateFailure.Findings {
+		if i == maxFindings {
+			fmt.Fprintf(builder, "- and %d more\n", len(gateFailure.Findings)-maxFindings)
+			break
+		}
+
+		fmt.Fprintf(
+			builder,
+			"- %s: %s (%s) at %s:%d\n  `bearer ignore add %s --comment \"<reason>\"`\n",
+			strings.ToUpper(finding.Severity),
+			finding.Title,
+			finding.RuleID,
+			finding.Filename,
+			finding.LineNumber,
+			finding.Fingerprint,
+		)
+	}
+
+	fmt.Fprintf(builder, "\nFailing severities (--fail-on-severity): %s\n", strings.Join(gateFailure.FailOnSeverity, ", "))
+	if confidences := minimumConfidences(gateFailure); len(confidences) != 0 {
+		fmt.Fprintf(builder, "Minimum confidence (gates.min-confidence): %s\n", strings.Join(confidences, ", "))
+	}
+
+	builder.WriteString("\nTo ignore a finding, run the command shown under it.")
+	if !gateFailure.DiffScan {
+		builder.WriteString(
+			"\nTo only fail on the findings introduced by a change, run a differential scan, eg. `DIFF_BASE_BRANCH=main bearer scan . --diff`.",
+		)
+	}
+	builder.WriteString(
+		"\nTo baseline the current findings, save them with `bearer scan . --format jsonv2 --output baseline.json` and compare later scans with `bearer diff baseline.json current.json --exit-code 1`.",
+	)
+
+	return builder.String()
+}
+
+// minimumConfidences lists the minimum confidence of each failing severity,
+// most severe first
+func minimumConfidences(gateFailure *securitytypes.GateFailure) []string {
+	var result []string
+	for _, severity := range gateFailure.FailOnSeverity {
+		if confidence, ok := gateFailure.MinimumConfidence[severity]; ok {
+			result = append(result, fmt.Sprintf("%s for %s", confidence, severity))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,41 @@
+package gate_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/report/output/gate"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+)
+
+func TestExplain(t *testing.T) {
+	cupaloy.SnapshotT(t, gate.Explain(testGateFailure(false, 2)))
+}
+
+func TestExplainDiffScan(t *testing.T) {
+	cupaloy.SnapshotT(t, gate.Explain(testGateFailure(true, 12)))
+}
+
+func testGateFailure(diffScan bool, count int) *securitytypes.GateFailure {
+	gateFailure := &securitytypes.GateFailure{
+		DiffScan:          diffScan,
+		FailOnSeverity:    []string{"critical", "high"},
+		MinimumConfidence: map[string]string{"high": "medium"},
+	}
+
+	for i := 0; i < count; i++ {
+		gateFailure.Findings = append(gateFailure.Findings, securitytypes.GateFinding{
+			Severity:    "high",
+			Confidence:  "high",
+			RuleID:      "ruby_lang_logger",
+			Title:       "Leakage of sensitive information in logger message",
+			Filename:    "app/models/user.rb",
+			LineNumber:  i + 1,
+			Fingerprint: fmt.Sprintf("4b0883d52334dfd9a4acce2fcf810121_%d", i),
+		})
+	}
+
+	return gateFailure
+}
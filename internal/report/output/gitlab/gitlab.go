@@ -35,6 +35,13 @@ func ReportGitLab(
 						Url:   fmt.Sprintf("https://cwe.mitre.org/data/definitions/%s.html", cwe),
 					})
 				}
+				for _, owasp := range finding.OWASP {
+					identifiers = append(identifiers, gitlab.Identifier{
+						Type:  "owasp",
+						Name:  owasp,
+						Value: owasp,
+					})
+				}
 
 				vulnerabilities = append(vulnerabilities, gitlab.Vulnerability{
 					Id:                   finding.Fingerprint,
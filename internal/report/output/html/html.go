@@ -19,6 +19,9 @@ import (
 //go:embed security.tmpl
 var securityTemplate string
 
+//go:embed security_grouped.tmpl
+var securityGroupedTemplate string
+
 //go:embed privacy.tmpl
 var privacyTemplate string
 
@@ -28,7 +31,7 @@ var wrapperTemplate string
 //go:embed styles.css
 var siteCss string
 
-func ReportHTMLWrapper(title string, body *string) (string, error) {
+func ReportHTMLWrapper(title string, body *string, metadata map[string]string) (string, error) {
 	htmlContent := &strings.Builder{}
 
 	t := time.Now()
@@ -39,6 +42,7 @@ func ReportHTMLWrapper(title string, body *string) (string, error) {
 		Title:     title,
 		TimeStamp: t.Format(timeLayout),
 		Style:     strings.Trim(siteCss, ""),
+		Metadata:  metadata,
 	}
 	pageTemplate, err := template.New("pageTemplate").Parse(wrapperTemplate)
 	if err != nil {
@@ -61,6 +65,7 @@ func ReportSecurityHTML(detections map[string][]securitytypes.Finding) (*string,
 		"kebabCase":      kebabCase,
 		"markdownToHtml": markdownToHtml,
 		"joinCwe":        joinCwe,
+		"joinOwasp":      joinOwasp,
 		"count":          countItems,
 		"displayExtract": displayExtract,
 	}).Parse(securityTemplate)
@@ -76,12 +81,37 @@ func ReportSecurityHTML(detections map[string][]securitytypes.Finding) (*string,
 	return &content, nil
 }
 
+func ReportSecurityGroupedHTML(groups []securitytypes.FindingGroup) (*string, error) {
+	htmlContent := &strings.Builder{}
+
+	groupsTemplate, err := template.New("groupsTemplate").Funcs(template.FuncMap{
+		"markdownToHtml": markdownToHtml,
+		"joinCwe":        joinCwe,
+		"joinOwasp":      joinOwasp,
+		"count":          countItems,
+		"displayExtract": displayExtract,
+	}).Parse(securityGroupedTemplate)
+	if err != nil {
+		return nil, err
+	}
+	err = groupsTemplate.Execute(htmlContent, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	content := htmlContent.String()
+	return &content, nil
+}
+
 func ReportPrivacyHTML(privacyReport *privacytypes.Report) (*string, error) {
 	htmlContent := &strings.Builder{}
 
 	privacyPage := html.PrivacyHTMLBody{
 		GroupedDataSubject: make([]html.GroupedDataSubject, 0),
 		GroupedThirdParty:  make([]html.GroupedThirdParty, 0),
+		UnusedDataTypes:    privacyReport.UnusedDataTypes,
+		CoOccurrences:      privacyReport.CoOccurrences,
+		Purposes:           privacyReport.Purposes,
 	}
 
 	subjectGroups := make(map[string][]privacytypes.Subject)
@@ -143,10 +173,16 @@ func joinCwe(data []string) string {
 	return strings.Join(out, ", ")
 }
 
+func joinOwasp(data []string) string {
+	return strings.Join(data, ", ")
+}
+
 func countItems(arr interface{}) string {
 	switch v := arr.(type) {
 	case []securitytypes.Finding:
 		return fmt.Sprint(len(v))
+	case []securitytypes.RawFinding:
+		return fmt.Sprint(len(v))
 	default:
 		return "0"
 	}
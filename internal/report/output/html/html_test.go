@@ -31,6 +31,39 @@ func TestJuiceShopSecurityHtml(t *testing.T) {
 	snapshotter := cupaloy.New(cupaloy.SnapshotFileExtension(".html"))
 	snapshotter.SnapshotT(t, []byte(*output))
 }
+
+func TestSecurityGroupedHtml(t *testing.T) {
+	groups := []securitytypes.FindingGroup{
+		{
+			Name: "javascript_lang_logger",
+			Findings: []securitytypes.RawFinding{
+				{
+					Finding: &securitytypes.Finding{
+						Rule: &securitytypes.Rule{
+							Id:          "javascript_lang_logger",
+							Title:       "Leakage of information in logger message",
+							Description: "## Description\nInformation leakage",
+							CWEIDs:      []string{"532"},
+						},
+						Fingerprint: "d1e1f4e4a5a14b0da0e3b1ded4d8e6c2_0",
+						Filename:    "lib/logger.ts",
+						LineNumber:  12,
+					},
+					Severity: "medium",
+				},
+			},
+		},
+	}
+
+	output, err := ReportSecurityGroupedHTML(groups)
+	if err != nil {
+		t.Fatalf("failed to generate security output, err: %s", err)
+	}
+
+	snapshotter := cupaloy.New(cupaloy.SnapshotFileExtension(".html"))
+	snapshotter.SnapshotT(t, []byte(*output))
+}
+
 func TestBearPublishingPrivacyHtml(t *testing.T) {
 	privacyOutput, err := os.ReadFile("testdata/bear-publishing-privacy-report.json")
 	if err != nil {
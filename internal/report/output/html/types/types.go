@@ -1,7 +1,9 @@
 package types
 
 import (
+	"github.com/bearer/bearer/internal/report/output/cooccurrence"
 	privacytypes "github.com/bearer/bearer/internal/report/output/privacy/types"
+	"github.com/bearer/bearer/internal/report/output/purposes"
 )
 
 type GroupedThirdParty struct {
@@ -16,6 +18,9 @@ type GroupedDataSubject struct {
 type PrivacyHTMLBody = struct {
 	GroupedDataSubject []GroupedDataSubject
 	GroupedThirdParty  []GroupedThirdParty
+	UnusedDataTypes    []privacytypes.UnusedDataType
+	CoOccurrences      []cooccurrence.CoOccurrence
+	Purposes           []purposes.Purpose
 }
 
 type WrapperHTMLPage = struct {
@@ -23,4 +28,5 @@ type WrapperHTMLPage = struct {
 	Title     string
 	TimeStamp string
 	Style     string
+	Metadata  map[string]string
 }
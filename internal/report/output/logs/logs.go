@@ -0,0 +1,212 @@
+package logs
+
+import (
+	"encoding/csv"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/report/basebranchfindings"
+	"github.com/bearer/bearer/internal/report/output/logs/types"
+	"github.com/bearer/bearer/internal/report/output/security"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+)
+
+// LoggerCWE is the weakness reported by rules detecting sensitive data
+// written to logs (Insertion of Sensitive Information into Log File)
+const LoggerCWE = "532"
+
+const (
+	noDataType      = "(no data type)"
+	rootServiceArea = "(root)"
+)
+
+func AddReportData(
+	reportData *outputtypes.ReportData,
+	config settings.Config,
+	baseBranchFindings *basebranchfindings.Findings,
+	hasFiles bool,
+) error {
+	// only the logger rules are evaluated, so that the exit code reflects
+	// this report alone
+	logsConfig := config
+	logsConfig.BuiltInRules = nil
+	logsConfig.Rules = LoggerRules(config.Rules)
+
+	if err := security.AddReportData(reportData, logsConfig, baseBranchFindings, hasFiles); err != nil {
+		return err
+	}
+
+	report := BuildReport(reportData.FindingsBySeverity, config.Rules)
+	report.Metadata = config.Report.Meta
+	reportData.LogsReport = &report
+
+	return nil
+}
+
+// LoggerRules returns the rules which detect sensitive data written to logs
+func LoggerRules(rules map[string]*settings.Rule) map[string]*settings.Rule {
+	result := make(map[string]*settings.Rule)
+	for id, rule := range rules {
+		if slices.Contains(rule.CWEIDs, LoggerCWE) {
+			result[id] = rule
+		}
+	}
+
+	return result
+}
+
+type groupKey struct {
+	dataType string
+	logger   string
+}
+
+// BuildReport groups the logger findings by data type and logger, counting
+// the findings in each service area
+func BuildReport(findingsBySeverity map[string][]securitytypes.Finding, rules map[string]*settings.Rule) types.Report {
+	report := types.Report{
+		ServiceAreas: []types.ServiceAreaCount{},
+		Groups:       []types.Group{},
+	}
+
+	groups := make(map[groupKey]*types.Group)
+	groupServiceAreas := make(map[groupKey]map[string]int)
+	serviceAreas := make(map[string]int)
+
+	for _, severity := range globaltypes.Severities {
+		for _, finding := range findingsBySeverity[severity] {
+			if finding.Rule == nil || !slices.Contains(finding.Rule.CWEIDs, LoggerCWE) {
+				continue
+			}
+
+			key := groupKey{dataType: dataTypeName(finding), logger: loggerName(finding.Rule.Id, rules[finding.Rule.Id])}
+			group, ok := groups[key]
+			if !ok {
+				group = &types.Group{DataType: key.dataType, Logger: key.logger}
+				groups[key] = group
+				groupServiceAreas[key] = make(map[string]int)
+			}
+
+			serviceArea := serviceAreaName(finding)
+			group.Count++
+			group.Findings = append(group.Findings, types.Finding{
+				RuleID:      finding.Rule.Id,
+				Severity:    severity,
+				Filename:    finding.Filename,
+				LineNumber:  finding.LineNumber,
+				ServiceArea: serviceArea,
+				Fingerprint: finding.Fingerprint,
+			})
+			groupServiceAreas[key][serviceArea]++
+			serviceAreas[serviceArea]++
+			report.Total++
+		}
+	}
+
+	for key, group := range groups {
+		group.ServiceAreas = sortedCounts(groupServiceAreas[key])
+		report.Groups = append(report.Groups, *group)
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Count != report.Groups[j].Count {
+			return report.Groups[i].Count > report.Groups[j].Count
+		}
+		if report.Groups[i].DataType != report.Groups[j].DataType {
+			return report.Groups[i].DataType < report.Groups[j].DataType
+		}
+
+		return report.Groups[i].Logger < report.Groups[j].Logger
+	})
+
+	report.ServiceAreas = sortedCounts(serviceAreas)
+
+	return report
+}
+
+// BuildCsvString renders one row for each data type, logger and service area
+func BuildCsvString(report *types.Report) (string, error) {
+	builder := &strings.Builder{}
+	writer := csv.NewWriter(builder)
+
+	records := [][]string{{"Data Type", "Logger", "Service Area", "Findings"}}
+	for _, group := range report.Groups {
+		for _, serviceArea := range group.ServiceAreas {
+			records = append(records, []string{
+				group.DataType,
+				group.Logger,
+				serviceArea.Name,
+				strconv.Itoa(serviceArea.Count),
+			})
+		}
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+func dataTypeName(finding securitytypes.Finding) string {
+	if finding.DataType == nil || finding.DataType.Name == "" {
+		return noDataType
+	}
+
+	return finding.DataType.Name
+}
+
+// loggerName names the logger from the recipe associated with the rule, or
+// else from the framework part of the rule id, eg. `ruby_rails_logger` is
+// reported as `rails`
+func loggerName(ruleID string, rule *settings.Rule) string {
+	if rule != nil && rule.AssociatedRecipe != "" {
+		return rule.AssociatedRecipe
+	}
+
+	_, name := settings.SplitRuleID(ruleID)
+	parts := strings.SplitN(name, "_", 3)
+	if len(parts) != 3 {
+		return ruleID
+	}
+
+	if parts[1] == "lang" {
+		language := parts[0]
+		if rule != nil {
+			language = rule.Language()
+		}
+
+		return language + " standard library"
+	}
+
+	return parts[1]
+}
+
+func serviceAreaName(finding securitytypes.Finding) string {
+	if finding.Workspace != "" {
+		return finding.Workspace
+	}
+
+	directory, _, found := strings.Cut(filepath.ToSlash(finding.Filename), "/")
+	if !found || directory == "" || directory == "." {
+		return rootServiceArea
+	}
+
+	return directory
+}
+
+func sortedCounts(counts map[string]int) []types.ServiceAreaCount {
+	result := make([]types.ServiceAreaCount, 0, len(counts))
+	for _, name := range maputil.SortedStringKeys(counts) {
+		result = append(result, types.ServiceAreaCount{Name: name, Count: counts[name]})
+	}
+
+	return result
+}
@@ -0,0 +1,98 @@
+package logs_test
+
+import (
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/report/output/logs"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/report/output/testhelper"
+	globaltypes "github.com/bearer/bearer/internal/types"
+	util "github.com/bearer/bearer/internal/util/output"
+)
+
+func TestBuildReport(t *testing.T) {
+	rules := testRules()
+	report := logs.BuildReport(testFindings(rules), rules)
+
+	output, err := util.ReportYAML(report)
+	if err != nil {
+		t.Fatalf("failed to generate YAML output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, output)
+}
+
+func TestBuildCsvString(t *testing.T) {
+	rules := testRules()
+	report := logs.BuildReport(testFindings(rules), rules)
+
+	output, err := logs.BuildCsvString(&report)
+	if err != nil {
+		t.Fatalf("failed to generate CSV output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, output)
+}
+
+func TestLoggerRules(t *testing.T) {
+	rules := testRules()
+	rules["ruby_third_parties_sentry"] = testhelper.RubyThirdPartiesSentryRule()
+
+	assert.ElementsMatch(
+		t,
+		[]string{"ruby_rails_logger", "ruby_lang_logger"},
+		keys(logs.LoggerRules(rules)),
+	)
+}
+
+func testRules() map[string]*settings.Rule {
+	return map[string]*settings.Rule{
+		"ruby_rails_logger": testhelper.RubyRailsLoggerRule(),
+		"ruby_lang_logger": {
+			Id:        "ruby_lang_logger",
+			CWEIDs:    []string{"532"},
+			Languages: []string{"ruby"},
+		},
+	}
+}
+
+func testFindings(rules map[string]*settings.Rule) map[string][]securitytypes.Finding {
+	finding := func(ruleID, filename, workspace, dataType string) securitytypes.Finding {
+		return securitytypes.Finding{
+			Rule:       &securitytypes.Rule{Id: ruleID, CWEIDs: rules[ruleID].CWEIDs},
+			Filename:   filename,
+			Workspace:  workspace,
+			LineNumber: 1,
+			DataType:   &securitytypes.DataType{Name: dataType},
+		}
+	}
+
+	return map[string][]securitytypes.Finding{
+		globaltypes.LevelHigh: {
+			finding("ruby_rails_logger", "billing/app/models/user.rb", "", "Email Address"),
+			finding("ruby_rails_logger", "accounts/app/models/user.rb", "", "Email Address"),
+			finding("ruby_rails_logger", "accounts/app/models/session.rb", "", "Email Address"),
+		},
+		globaltypes.LevelMedium: {
+			finding("ruby_lang_logger", "lib/tasks/import.rb", "tools", "Physical Address"),
+			finding("ruby_lang_logger", "script.rb", "", "Email Address"),
+			{
+				Rule:     &securitytypes.Rule{Id: "ruby_lang_ssl_verification", CWEIDs: []string{"295"}},
+				Filename: "billing/app/clients/bank.rb",
+			},
+		},
+	}
+}
+
+func keys(rules map[string]*settings.Rule) []string {
+	var result []string
+	for id := range rules {
+		result = append(result, id)
+	}
+
+	return result
+}
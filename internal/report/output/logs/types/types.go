@@ -0,0 +1,34 @@
+package types
+
+// Report lists the sensitive data written to loggers, grouped by data type
+// and logger
+type Report struct {
+	Total        int                `json:"total" yaml:"total"`
+	ServiceAreas []ServiceAreaCount `json:"service_areas" yaml:"service_areas"`
+	Groups       []Group            `json:"groups" yaml:"groups"`
+	Metadata     map[string]string  `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+// ServiceAreaCount is the number of findings within a service area, which is
+// the workspace of the finding or else the top-level directory of its file
+type ServiceAreaCount struct {
+	Name  string `json:"name" yaml:"name"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+type Group struct {
+	DataType     string             `json:"data_type" yaml:"data_type"`
+	Logger       string             `json:"logger" yaml:"logger"`
+	Count        int                `json:"count" yaml:"count"`
+	ServiceAreas []ServiceAreaCount `json:"service_areas" yaml:"service_areas"`
+	Findings     []Finding          `json:"findings" yaml:"findings"`
+}
+
+type Finding struct {
+	RuleID      string `json:"rule_id" yaml:"rule_id"`
+	Severity    string `json:"severity" yaml:"severity"`
+	Filename    string `json:"filename" yaml:"filename"`
+	LineNumber  int    `json:"line_number" yaml:"line_number"`
+	ServiceArea string `json:"service_area" yaml:"service_area"`
+	Fingerprint string `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+}
@@ -3,6 +3,8 @@ package output
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,12 +15,19 @@ import (
 	"github.com/bearer/bearer/internal/commands/process/settings"
 	"github.com/bearer/bearer/internal/flag"
 	"github.com/bearer/bearer/internal/report/basebranchfindings"
+	"github.com/bearer/bearer/internal/report/coverage"
+	"github.com/bearer/bearer/internal/report/history"
 	"github.com/bearer/bearer/internal/report/output/dataflow"
 	"github.com/bearer/bearer/internal/report/output/detectors"
+	"github.com/bearer/bearer/internal/report/output/logs"
+	"github.com/bearer/bearer/internal/report/output/postprocess"
 	"github.com/bearer/bearer/internal/report/output/privacy"
+	"github.com/bearer/bearer/internal/report/output/residency"
+	"github.com/bearer/bearer/internal/report/output/ropa"
 	"github.com/bearer/bearer/internal/report/output/saas"
 	"github.com/bearer/bearer/internal/report/output/security"
 	"github.com/bearer/bearer/internal/report/output/stats"
+	"github.com/bearer/bearer/internal/report/output/template"
 	"github.com/bearer/bearer/internal/report/output/types"
 	globaltypes "github.com/bearer/bearer/internal/types"
 )
@@ -30,8 +39,21 @@ func GetData(
 	config settings.Config,
 	gitContext *gitrepository.Context,
 	baseBranchFindings *basebranchfindings.Findings,
+	findingStream types.FindingStream,
 ) (*types.ReportData, error) {
-	data := &types.ReportData{}
+	data := &types.ReportData{FindingStream: findingStream}
+
+	// findings are also sent to the cloud as they are found, ahead of the report
+	if config.Report.StreamToCloud && config.Client != nil && config.Client.Error == nil {
+		cloudStream, err := saas.NewStream(config)
+		if err != nil {
+			return nil, err
+		}
+		defer cloudStream.Close()
+
+		data.FindingStream = cloudStream.Tee(findingStream)
+		data.CloudStreamID = cloudStream.ID
+	}
 
 	// add languages
 	languages := make(map[string]int32)
@@ -66,6 +88,12 @@ func GetData(
 		err = saas.GetReport(data, config, gitContext, false)
 	case flag.ReportPrivacy:
 		err = privacy.AddReportData(data, config)
+	case flag.ReportRoPA:
+		err = ropa.AddReportData(data, config)
+	case flag.ReportLogs:
+		err = logs.AddReportData(data, config, baseBranchFindings, report.HasFiles)
+	case flag.ReportResidency:
+		err = residency.AddReportData(data, config)
 	case flag.ReportStats:
 		err = stats.AddReportData(data, report.Inputgocloc, config)
 	default:
@@ -83,6 +111,31 @@ func UploadReportToCloud(report *types.ReportData, config settings.Config, gitCo
 	}
 }
 
+// AppendHistory records the summary of the report in the configured history
+// file, if any
+func AppendHistory(report *types.ReportData, config settings.Config, gitContext *gitrepository.Context) error {
+	if config.Report.HistoryFile == "" {
+		return nil
+	}
+
+	entry := history.NewEntry(report, gitContext, time.Now())
+	if err := history.Append(config.Report.HistoryFile, entry, config.Offline); err != nil {
+		return fmt.Errorf("error recording scan history in %s: %w", config.Report.HistoryFile, err)
+	}
+
+	return nil
+}
+
+// WriteRuleCoverage writes which rules were evaluated in the scan to the
+// configured file, if any
+func WriteRuleCoverage(report *types.ReportData, config settings.Config, inputgocloc *gocloc.Result) error {
+	if config.Report.RuleCoverage == "" {
+		return nil
+	}
+
+	return coverage.Write(config.Report.RuleCoverage, coverage.New(config, report.Dataflow, inputgocloc))
+}
+
 func GetDataflow(
 	reportData *types.ReportData,
 	report globaltypes.Report,
@@ -100,6 +153,71 @@ func GetDataflow(
 	return dataflow.AddReportData(reportData, config, isInternal, report.HasFiles)
 }
 
+var outputExtensions = map[string]string{
+	flag.FormatEmpty:      "txt",
+	flag.FormatJSON:       "json",
+	flag.FormatJSONV2:     "v2.json",
+	flag.FormatYAML:       "yaml",
+	flag.FormatSarif:      "sarif",
+	flag.FormatGitLabSast: "gitlab-sast.json",
+	flag.FormatReviewDog:  "rdjson.json",
+	flag.FormatSonarQube:  "sonarqube.json",
+	flag.FormatDefectDojo: "defectdojo.json",
+	flag.FormatHTML:       "html",
+	flag.FormatCSV:        "csv",
+	flag.FormatBillOfData: "bill-of-data.json",
+	flag.FormatMermaid:    "mmd",
+	flag.FormatDOT:        "dot",
+	flag.FormatTemplate:   "txt",
+}
+
+// OutputFilename is the name of the file a report format is written to
+// within the output directory, e.g. security.sarif
+func OutputFilename(report string, format string) string {
+	return fmt.Sprintf("%s.%s", report, outputExtensions[format])
+}
+
+// WriteOutputDir renders each of the configured formats from the same report
+// data and writes them to the output directory, returning the paths written
+func WriteOutputDir(
+	reportData *types.ReportData,
+	config settings.Config,
+	goclocResult *gocloc.Result,
+	startTime time.Time,
+	endTime time.Time,
+) ([]string, error) {
+	if err := os.MkdirAll(config.Report.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating output directory %w", err)
+	}
+
+	formats := config.Report.Formats
+	if len(formats) == 0 {
+		formats = []string{config.Report.Format}
+	}
+
+	var paths []string
+	for _, format := range formats {
+		formatConfig := config
+		formatConfig.Report.Format = format
+
+		formatStr, err := FormatOutput(reportData, formatConfig, goclocResult, startTime, endTime)
+		if err != nil {
+			return paths, err
+		}
+
+		path := filepath.Join(config.Report.OutputDir, OutputFilename(config.Report.Report, format))
+		if err := os.WriteFile(path, []byte(formatStr+"\n"), 0644); err != nil {
+			return paths, fmt.Errorf("error writing output file %w", err)
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// FormatOutput renders the report in the configured format, and pipes it
+// through the post-processors for that format
 func FormatOutput(
 	reportData *types.ReportData,
 	config settings.Config,
@@ -107,6 +225,25 @@ func FormatOutput(
 	startTime time.Time,
 	endTime time.Time,
 ) (string, error) {
+	formatStr, err := formatOutput(reportData, config, goclocResult, startTime, endTime)
+	if err != nil {
+		return formatStr, err
+	}
+
+	return postprocess.Run(config.Report.PostProcessors, config.Report.Report, config.Report.Format, formatStr)
+}
+
+func formatOutput(
+	reportData *types.ReportData,
+	config settings.Config,
+	goclocResult *gocloc.Result,
+	startTime time.Time,
+	endTime time.Time,
+) (string, error) {
+	if config.Report.Format == flag.FormatTemplate {
+		return template.ReportTemplate(reportData, config)
+	}
+
 	var formatter types.GenericFormatter
 	switch config.Report.Report {
 	case flag.ReportDetectors:
@@ -117,6 +254,12 @@ func FormatOutput(
 		formatter = security.NewFormatter(reportData, config, goclocResult, startTime, endTime)
 	case flag.ReportPrivacy:
 		formatter = privacy.NewFormatter(reportData, config)
+	case flag.ReportRoPA:
+		formatter = ropa.NewFormatter(reportData, config)
+	case flag.ReportLogs:
+		formatter = logs.NewFormatter(reportData, config)
+	case flag.ReportResidency:
+		formatter = residency.NewFormatter(reportData, config)
 	case flag.ReportSaaS:
 		formatter = saas.NewFormatter(reportData, config)
 	case flag.ReportStats:
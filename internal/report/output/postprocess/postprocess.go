@@ -0,0 +1,42 @@
+package postprocess
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/internal/flag"
+)
+
+// Run pipes the report of a format through each of the post-processors for
+// that format, in order. Each command receives the report on stdin, with the
+// report type and format in the BEARER_REPORT and BEARER_FORMAT environment
+// variables, and its output is the report given to the next one.
+func Run(postProcessors []flag.PostProcessor, report string, format string, output string) (string, error) {
+	for _, postProcessor := range postProcessors {
+		if postProcessor.Format != "" && postProcessor.Format != format {
+			continue
+		}
+
+		log.Debug().Msgf("running post-processor %q for format %s", postProcessor.Command, format)
+
+		var stdout bytes.Buffer
+		command := exec.Command("sh", "-c", postProcessor.Command)
+		command.Env = append(os.Environ(), "BEARER_REPORT="+report, "BEARER_FORMAT="+format)
+		command.Stdin = strings.NewReader(output + "\n")
+		command.Stdout = &stdout
+		command.Stderr = os.Stderr
+
+		if err := command.Run(); err != nil {
+			return "", fmt.Errorf("post-processor %q failed: %w", postProcessor.Command, err)
+		}
+
+		output = strings.TrimSuffix(stdout.String(), "\n")
+	}
+
+	return output, nil
+}
@@ -0,0 +1,48 @@
+package postprocess_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/output/postprocess"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("pipes the report through the commands of its format in order", func(t *testing.T) {
+		output, err := postprocess.Run([]flag.PostProcessor{
+			{Command: "tr a-z A-Z"},
+			{Format: "sarif", Command: "echo skipped"},
+			{Format: "json", Command: "sed 's/FINDINGS/findings/'"},
+		}, "security", "json", `{"findings": []}`)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, `{"findings": []}`, output)
+		}
+	})
+
+	t.Run("sets the report and format", func(t *testing.T) {
+		output, err := postprocess.Run([]flag.PostProcessor{
+			{Command: `cat > /dev/null; echo "$BEARER_REPORT $BEARER_FORMAT"`},
+		}, "privacy", "csv", "Data Type\n")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "privacy csv", output)
+		}
+	})
+
+	t.Run("keeps the report without post-processors", func(t *testing.T) {
+		output, err := postprocess.Run(nil, "security", "json", "{}")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "{}", output)
+		}
+	})
+
+	t.Run("fails when a command fails", func(t *testing.T) {
+		_, err := postprocess.Run([]flag.PostProcessor{{Command: "exit 3"}}, "security", "json", "{}")
+
+		assert.ErrorContains(t, err, `post-processor "exit 3" failed`)
+	})
+}
@@ -15,7 +15,10 @@ import (
 	"github.com/bearer/bearer/internal/util/progressbar"
 	"github.com/bearer/bearer/internal/util/rego"
 
+	"github.com/bearer/bearer/internal/report/output/cooccurrence"
+	"github.com/bearer/bearer/internal/report/output/dataflow/components"
 	"github.com/bearer/bearer/internal/report/output/privacy/types"
+	"github.com/bearer/bearer/internal/report/output/purposes"
 	"github.com/bearer/bearer/internal/report/output/security"
 	outputtypes "github.com/bearer/bearer/internal/report/output/types"
 )
@@ -108,6 +111,11 @@ func AddReportData(reportData *outputtypes.ReportData, config settings.Config) e
 		output.StdErrLog("Evaluating rules")
 	}
 
+	scanDB, err := db.DefaultForScan(config.Scan)
+	if err != nil {
+		return err
+	}
+
 	bar := progressbar.GetProgressBar(len(config.Rules), config)
 
 	subjectRuleFailures := make(map[string]RuleFailureSummary)
@@ -153,7 +161,7 @@ func AddReportData(reportData *outputtypes.ReportData, config settings.Config) e
 				RuleId:         rule.Id,
 				Rule:           rule,
 				Dataflow:       reportData.Dataflow,
-				DataCategories: db.DefaultWithContext(config.Scan.Context).DataCategories,
+				DataCategories: scanDB.DataCategories,
 			},
 			policy.Modules.ToRegoModules())
 		if err != nil {
@@ -266,7 +274,7 @@ func AddReportData(reportData *outputtypes.ReportData, config settings.Config) e
 	rs, err := rego.RunQuery(privacyReportPolicy.Query,
 		Input{
 			Dataflow:       reportData.Dataflow,
-			DataCategories: db.DefaultWithContext(config.Scan.Context).DataCategories,
+			DataCategories: scanDB.DataCategories,
 		},
 		privacyReportPolicy.Modules.ToRegoModules(),
 	)
@@ -313,7 +321,7 @@ func AddReportData(reportData *outputtypes.ReportData, config settings.Config) e
 
 	var thirdPartyInventory []types.ThirdParty
 	for _, component := range reportData.Dataflow.Components {
-		if component.SubType != "third_party" {
+		if component.SubType != "third_party" || !components.InProcessorCategories(component, config.Report.ProcessorCategories) {
 			continue
 		}
 
@@ -322,6 +330,7 @@ func AddReportData(reportData *outputtypes.ReportData, config settings.Config) e
 			// no failures, therefore no associated data subjects
 			thirdPartyInventory = append(thirdPartyInventory, types.ThirdParty{
 				ThirdParty:               component.Name,
+				Category:                 component.Category,
 				DataSubject:              PLACEHOLDER_VALUE,
 				DataTypes:                []string{PLACEHOLDER_VALUE},
 				CriticalRiskFindingCount: 0,
@@ -335,6 +344,7 @@ func AddReportData(reportData *outputtypes.ReportData, config settings.Config) e
 		for _, ruleFailure := range thirdPartyFailure {
 			thirdPartyInventory = append(thirdPartyInventory, types.ThirdParty{
 				ThirdParty:               component.Name,
+				Category:                 component.Category,
 				DataSubject:              ruleFailure.DataSubject,
 				DataTypes:                maps.Keys(ruleFailure.DataTypes),
 				CriticalRiskFindingCount: ruleFailure.CriticalRiskFindingCount,
@@ -350,8 +360,12 @@ func AddReportData(reportData *outputtypes.ReportData, config settings.Config) e
 	sortInventory(subjects, thirdPartyInventory)
 
 	reportData.PrivacyReport = &types.Report{
-		Subjects:   subjects,
-		ThirdParty: thirdPartyInventory,
+		Subjects:        subjects,
+		ThirdParty:      thirdPartyInventory,
+		UnusedDataTypes: unusedDataTypes(reportData.Dataflow),
+		CoOccurrences:   cooccurrence.Find(reportData.Dataflow.Datatypes),
+		Purposes:        purposes.Find(reportData.Dataflow.Datatypes),
+		Metadata:        config.Report.Meta,
 	}
 	return nil
 }
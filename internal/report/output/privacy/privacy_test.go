@@ -56,6 +56,56 @@ func TestAddReportData(t *testing.T) {
 	cupaloy.SnapshotT(t, output.PrivacyReport)
 }
 
+func TestAddReportDataWithUnusedDataTypes(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "privacy"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	stored := true
+	output := &outputtypes.ReportData{
+		Dataflow: &outputtypes.DataFlow{
+			Datatypes: []types.Datatype{
+				{
+					Name:         "Email Address",
+					CategoryName: "Contact",
+					Detectors: []types.DatatypeDetector{
+						{
+							Name: "ruby",
+							Locations: []types.DatatypeLocation{
+								{Filename: "app/controllers/users_controller.rb", StartLineNumber: 5, FieldName: "email", ObjectName: "user"},
+							},
+						},
+						{
+							Name: "schema_rb",
+							Locations: []types.DatatypeLocation{
+								{Filename: "db/schema.rb", StartLineNumber: 3, FieldName: "email", ObjectName: "users", Stored: &stored},
+							},
+						},
+					},
+				},
+				{
+					Name:         "Date of birth",
+					CategoryName: "Demographic",
+					Detectors: []types.DatatypeDetector{
+						{
+							Name: "schema_rb",
+							Locations: []types.DatatypeLocation{
+								{Filename: "db/schema.rb", StartLineNumber: 5, FieldName: "date_of_birth", ObjectName: "users", Stored: &stored},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err = privacy.AddReportData(output, config); err != nil {
+		t.Fatalf("failed to generate privacy output err:%s", err)
+	}
+
+	cupaloy.SnapshotT(t, output.PrivacyReport.UnusedDataTypes)
+}
+
 func generateConfig(reportOptions flag.ReportOptions) (settings.Config, error) {
 	opts := flag.Options{
 		ScanOptions: flag.ScanOptions{
@@ -1,12 +1,34 @@
 package types
 
+import (
+	"github.com/bearer/bearer/internal/report/output/cooccurrence"
+	"github.com/bearer/bearer/internal/report/output/purposes"
+)
+
 type Report struct {
-	Subjects   []Subject    `json:"subjects,omitempty" yaml:"subjects"`
-	ThirdParty []ThirdParty `json:"third_party,omitempty" yaml:"third_party"`
+	Subjects        []Subject                   `json:"subjects,omitempty" yaml:"subjects"`
+	ThirdParty      []ThirdParty                `json:"third_party,omitempty" yaml:"third_party"`
+	UnusedDataTypes []UnusedDataType            `json:"unused_data_types,omitempty" yaml:"unused_data_types,omitempty"`
+	CoOccurrences   []cooccurrence.CoOccurrence `json:"co_occurrences,omitempty" yaml:"co_occurrences,omitempty"`
+	Purposes        []purposes.Purpose          `json:"purposes,omitempty" yaml:"purposes,omitempty"`
+	Metadata        map[string]string           `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+// UnusedDataType is a stored field holding sensitive data that is never
+// referenced elsewhere in the codebase
+type UnusedDataType struct {
+	DataType     string `json:"name" yaml:"name"`
+	CategoryName string `json:"category_name,omitempty" yaml:"category_name,omitempty"`
+	ObjectName   string `json:"object_name,omitempty" yaml:"object_name,omitempty"`
+	FieldName    string `json:"field_name" yaml:"field_name"`
+	Detector     string `json:"detector" yaml:"detector"`
+	Filename     string `json:"filename" yaml:"filename"`
+	LineNumber   int    `json:"line_number" yaml:"line_number"`
 }
 
 type ThirdParty struct {
 	ThirdParty               string   `json:"third_party,omitempty" yaml:"third_party"`
+	Category                 string   `json:"category,omitempty" yaml:"category,omitempty"`
 	DataSubject              string   `json:"subject_name,omitempty" yaml:"subject_name"`
 	DataTypes                []string `json:"data_types,omitempty" yaml:"data_types"`
 	CriticalRiskFindingCount int      `json:"critical_risk_failure_count" yaml:"critical_risk_failure_count"`
@@ -0,0 +1,64 @@
+package privacy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bearer/bearer/internal/report/output/privacy/types"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+)
+
+// unusedDataTypes finds fields holding sensitive data that are stored (for
+// example as a database column) but never referenced by the application
+// code. Such write-only fields are candidates for removal under data
+// minimization.
+func unusedDataTypes(dataflow *outputtypes.DataFlow) []types.UnusedDataType {
+	if dataflow == nil {
+		return nil
+	}
+
+	var result []types.UnusedDataType
+
+	for _, datatype := range dataflow.Datatypes {
+		usedFields := make(map[string]bool)
+		for _, detector := range datatype.Detectors {
+			for _, location := range detector.Locations {
+				if location.Stored == nil || !*location.Stored {
+					usedFields[strings.ToLower(location.FieldName)] = true
+				}
+			}
+		}
+
+		for _, detector := range datatype.Detectors {
+			for _, location := range detector.Locations {
+				if location.Stored == nil || !*location.Stored || location.FieldName == "" {
+					continue
+				}
+
+				if usedFields[strings.ToLower(location.FieldName)] {
+					continue
+				}
+
+				result = append(result, types.UnusedDataType{
+					DataType:     datatype.Name,
+					CategoryName: datatype.CategoryName,
+					ObjectName:   location.ObjectName,
+					FieldName:    location.FieldName,
+					Detector:     detector.Name,
+					Filename:     location.Filename,
+					LineNumber:   location.StartLineNumber,
+				})
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Filename != result[j].Filename {
+			return result[i].Filename < result[j].Filename
+		}
+
+		return result[i].LineNumber < result[j].LineNumber
+	})
+
+	return result
+}
@@ -0,0 +1,93 @@
+package purposes
+
+import (
+	"sort"
+
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// UnknownDataSubject groups data types that could not be linked to a subject
+const UnknownDataSubject = "Unknown"
+
+// Purpose is a processing purpose annotated in the code with a
+// `bearer:purpose` comment, along with the data processed for it
+type Purpose struct {
+	Name         string   `json:"name" yaml:"name"`
+	Retention    []string `json:"retention,omitempty" yaml:"retention,omitempty"`
+	DataSubjects []string `json:"data_subjects" yaml:"data_subjects"`
+	DataTypes    []string `json:"data_types" yaml:"data_types"`
+	Files        []string `json:"files" yaml:"files"`
+}
+
+type purposeHolder struct {
+	retention    set.Set[string]
+	dataSubjects set.Set[string]
+	dataTypes    set.Set[string]
+	files        set.Set[string]
+}
+
+// Find returns the annotated purposes, ordered by name
+func Find(dataTypes []dataflowtypes.Datatype) []Purpose {
+	holders := make(map[string]*purposeHolder)
+	for _, dataType := range dataTypes {
+		for _, detector := range dataType.Detectors {
+			for _, location := range detector.Locations {
+				if location.Purpose == "" {
+					continue
+				}
+
+				holder, ok := holders[location.Purpose]
+				if !ok {
+					holder = &purposeHolder{
+						retention:    set.New[string](),
+						dataSubjects: set.New[string](),
+						dataTypes:    set.New[string](),
+						files:        set.New[string](),
+					}
+					holders[location.Purpose] = holder
+				}
+
+				if location.Retention != "" {
+					holder.retention.Add(location.Retention)
+				}
+
+				subjectName := UnknownDataSubject
+				if location.SubjectName != nil && *location.SubjectName != "" {
+					subjectName = *location.SubjectName
+				}
+				holder.dataSubjects.Add(subjectName)
+				holder.dataTypes.Add(dataType.Name)
+				holder.files.Add(location.Filename)
+			}
+		}
+	}
+
+	var result []Purpose
+	for _, name := range maputil.SortedStringKeys(holders) {
+		holder := holders[name]
+
+		var retention []string
+		if len(holder.retention) != 0 {
+			retention = sortedItems(holder.retention)
+		}
+
+		result = append(result, Purpose{
+			Name:         name,
+			Retention:    retention,
+			DataSubjects: sortedItems(holder.dataSubjects),
+			DataTypes:    sortedItems(holder.dataTypes),
+			Files:        sortedItems(holder.files),
+		})
+	}
+
+	return result
+}
+
+func sortedItems(values set.Set[string]) []string {
+	items := values.Items()
+	sort.Strings(items)
+
+	return items
+}
@@ -0,0 +1,171 @@
+package residency
+
+import (
+	"encoding/csv"
+	"sort"
+	"strings"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/output/billofdata"
+	"github.com/bearer/bearer/internal/report/output/residency/types"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// UnknownRegion is the region of the components which are not assigned to
+// any, so that their data can't be assessed
+const UnknownRegion = "unknown"
+
+func AddReportData(reportData *outputtypes.ReportData, config settings.Config) error {
+	report, err := BuildReport(reportData.Dataflow, config.Report.ResidencyRegion, config.Report.ResidencyComponents)
+	if err != nil {
+		return err
+	}
+
+	report.Metadata = config.Report.Meta
+	reportData.ResidencyReport = &report
+
+	return nil
+}
+
+type transferHolder struct {
+	transfer     types.Transfer
+	destinations []types.Destination
+}
+
+// BuildReport assigns the components of the dataflow to their region, and
+// reports the data types found in the same files as components outside of the
+// declared region
+func BuildReport(
+	dataflow *outputtypes.DataFlow,
+	region string,
+	residencyComponents []flag.ResidencyComponent,
+) (types.Report, error) {
+	report := types.Report{
+		Region:     region,
+		Transfers:  []types.Transfer{},
+		Components: []types.Component{},
+	}
+	if dataflow == nil {
+		return report, nil
+	}
+
+	billOfData, err := billofdata.ReportBillOfData(dataflow)
+	if err != nil {
+		return report, err
+	}
+
+	regions := make(map[string]string)
+	for _, component := range residencyComponents {
+		regions[strings.ToLower(strings.TrimSpace(component.Name))] = strings.TrimSpace(component.Region)
+	}
+
+	transfers := make(map[string]*transferHolder)
+	for _, component := range billOfData.Components {
+		componentRegion, ok := regions[strings.ToLower(component.Name)]
+		if !ok {
+			componentRegion = UnknownRegion
+		}
+		transfer := ok && !strings.EqualFold(componentRegion, region)
+
+		componentFiles := set.New[string]()
+		componentFiles.AddAll(component.Files)
+
+		reportComponent := types.Component{
+			Name:      component.Name,
+			Type:      component.Type,
+			SubType:   component.SubType,
+			Category:  component.Category,
+			Region:    componentRegion,
+			Transfer:  transfer,
+			DataTypes: []string{},
+		}
+
+		for _, dataType := range dataflow.Datatypes {
+			sharedFiles := set.New[string]()
+			for _, detector := range dataType.Detectors {
+				for _, location := range detector.Locations {
+					if componentFiles.Has(location.Filename) {
+						sharedFiles.Add(location.Filename)
+					}
+				}
+			}
+
+			if len(sharedFiles) == 0 {
+				continue
+			}
+
+			reportComponent.DataTypes = append(reportComponent.DataTypes, dataType.Name)
+			if !transfer {
+				continue
+			}
+
+			holder, ok := transfers[dataType.Name]
+			if !ok {
+				holder = &transferHolder{
+					transfer: types.Transfer{DataType: dataType.Name, CategoryName: dataType.CategoryName},
+				}
+				transfers[dataType.Name] = holder
+			}
+
+			files := sharedFiles.Items()
+			sort.Strings(files)
+			holder.destinations = append(holder.destinations, types.Destination{
+				Component: component.Name,
+				Type:      component.Type,
+				SubType:   component.SubType,
+				Category:  component.Category,
+				Region:    componentRegion,
+				Files:     files,
+			})
+		}
+
+		sort.Strings(reportComponent.DataTypes)
+		report.Components = append(report.Components, reportComponent)
+	}
+
+	for _, holder := range transfers {
+		sort.Slice(holder.destinations, func(i, j int) bool {
+			return holder.destinations[i].Component < holder.destinations[j].Component
+		})
+
+		holder.transfer.Destinations = holder.destinations
+		report.Transfers = append(report.Transfers, holder.transfer)
+	}
+
+	sort.Slice(report.Transfers, func(i, j int) bool {
+		return report.Transfers[i].DataType < report.Transfers[j].DataType
+	})
+	sort.SliceStable(report.Components, func(i, j int) bool {
+		return report.Components[i].Name < report.Components[j].Name
+	})
+
+	return report, nil
+}
+
+// BuildCsvString renders one row for each data type and the component outside
+// of the declared region it is sent to
+func BuildCsvString(report *types.Report) (string, error) {
+	builder := &strings.Builder{}
+	writer := csv.NewWriter(builder)
+
+	records := [][]string{{"Data Type", "Category", "Component", "Component Type", "Region"}}
+	for _, transfer := range report.Transfers {
+		for _, destination := range transfer.Destinations {
+			records = append(records, []string{
+				transfer.DataType,
+				transfer.CategoryName,
+				destination.Component,
+				destination.Type,
+				destination.Region,
+			})
+		}
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
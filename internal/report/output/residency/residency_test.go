@@ -0,0 +1,78 @@
+package residency_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/output/residency"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	util "github.com/bearer/bearer/internal/util/output"
+)
+
+var components = []flag.ResidencyComponent{
+	{Name: "postgresql", Region: "EU"},
+	{Name: "Stripe", Region: "us"},
+}
+
+func TestBuildReport(t *testing.T) {
+	report, err := residency.BuildReport(readDataflow(t, "testdata/dataflow.json"), "eu", components)
+	if err != nil {
+		t.Fatalf("failed to build report, err: %s", err)
+	}
+
+	output, err := util.ReportYAML(report)
+	if err != nil {
+		t.Fatalf("failed to generate YAML output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, output)
+}
+
+func TestBuildReportWithoutComponents(t *testing.T) {
+	report, err := residency.BuildReport(readDataflow(t, "testdata/dataflow.json"), "eu", nil)
+	if err != nil {
+		t.Fatalf("failed to build report, err: %s", err)
+	}
+
+	if len(report.Transfers) != 0 {
+		t.Errorf("expected no transfers without assigned components, got %v", report.Transfers)
+	}
+
+	for _, component := range report.Components {
+		if component.Region != residency.UnknownRegion {
+			t.Errorf("expected component %s to be in an unknown region, got %s", component.Name, component.Region)
+		}
+	}
+}
+
+func TestBuildCsvString(t *testing.T) {
+	report, err := residency.BuildReport(readDataflow(t, "testdata/dataflow.json"), "eu", components)
+	if err != nil {
+		t.Fatalf("failed to build report, err: %s", err)
+	}
+
+	output, err := residency.BuildCsvString(&report)
+	if err != nil {
+		t.Fatalf("failed to generate CSV output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, output)
+}
+
+func readDataflow(t *testing.T, path string) *outputtypes.DataFlow {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read dataflow, err: %s", err)
+	}
+
+	var dataflow outputtypes.DataFlow
+	if err := json.Unmarshal(content, &dataflow); err != nil {
+		t.Fatalf("failed to parse dataflow, err: %s", err)
+	}
+
+	return &dataflow
+}
@@ -0,0 +1,38 @@
+package types
+
+// Report lists the data types sent to components outside of the declared
+// region, for transfer impact assessments
+type Report struct {
+	Region     string            `json:"region" yaml:"region"`
+	Transfers  []Transfer        `json:"transfers" yaml:"transfers"`
+	Components []Component       `json:"components" yaml:"components"`
+	Metadata   map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+// Transfer is a data type leaving the declared region
+type Transfer struct {
+	DataType     string        `json:"data_type" yaml:"data_type"`
+	CategoryName string        `json:"category_name,omitempty" yaml:"category_name,omitempty"`
+	Destinations []Destination `json:"destinations" yaml:"destinations"`
+}
+
+type Destination struct {
+	Component string   `json:"component" yaml:"component"`
+	Type      string   `json:"type" yaml:"type"`
+	SubType   string   `json:"sub_type" yaml:"sub_type"`
+	Category  string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Region    string   `json:"region" yaml:"region"`
+	Files     []string `json:"files" yaml:"files"`
+}
+
+// Component is a data store, internal service or third party with the region
+// it is assigned to, and the data types found in the same files
+type Component struct {
+	Name      string   `json:"name" yaml:"name"`
+	Type      string   `json:"type" yaml:"type"`
+	SubType   string   `json:"sub_type" yaml:"sub_type"`
+	Category  string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Region    string   `json:"region" yaml:"region"`
+	Transfer  bool     `json:"transfer" yaml:"transfer"`
+	DataTypes []string `json:"data_types" yaml:"data_types"`
+}
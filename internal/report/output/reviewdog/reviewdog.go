@@ -1,14 +1,34 @@
 package reviewdog
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bearer/bearer/internal/report/output/gate"
 	reviewdog "github.com/bearer/bearer/internal/report/output/reviewdog/types"
 	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
 )
 
-func ReportReviewdog(outputDetections map[string][]securitytypes.Finding) (reviewdog.ReviewdogOutput, error) {
+var levels = []string{"critical", "high", "medium", "low", "warning"}
+
+// ReportReviewdog returns a diagnostic for each finding, most severe first.
+// When there are more findings than the annotation limit, the remaining ones
+// are collapsed into a single summary diagnostic so that pull requests don't
+// receive a comment for every one of them. A limit of zero disables this.
+// When the findings failed the scan, a diagnostic explains why.
+func ReportReviewdog(
+	outputDetections map[string][]securitytypes.Finding,
+	annotationLimit int,
+	summaryURL string,
+	gateFailure *securitytypes.GateFailure,
+) (reviewdog.ReviewdogOutput, error) {
 	var reviewdogDiagnostics []reviewdog.Diagnostic
+	omittedCounts := make(map[string]int)
+	omittedTotal := 0
+	var omittedLocation *reviewdog.Location
 
-	for _, level := range []string{"critical", "high", "medium", "low", "warning"} {
+	for _, level := range levels {
 		if findings, ok := outputDetections[level]; ok {
 			for _, finding := range findings {
 				var severity string
@@ -18,24 +38,35 @@ func ReportReviewdog(outputDetections map[string][]securitytypes.Finding) (revie
 					severity = "ERROR"
 				}
 
-				message := "\n# " + finding.Rule.Title + "\n" + finding.Rule.Description
+				location := reviewdog.Location{
+					Path: finding.Filename,
+					Range: reviewdog.LocationRange{
+						Start: reviewdog.LocationPosition{
+							Line:   finding.Sink.Start,
+							Column: finding.Sink.Column.Start,
+						},
+						End: reviewdog.LocationPosition{
+							Line:   finding.Sink.End,
+							Column: finding.Sink.Column.End,
+						},
+					},
+				}
+
+				if annotationLimit != 0 && len(reviewdogDiagnostics) >= annotationLimit {
+					if omittedLocation == nil {
+						omittedLocation = &location
+					}
+					omittedCounts[level]++
+					omittedTotal++
+					continue
+				}
+
+				message := "\n# " + finding.Rule.MappedTitle() + "\n" + finding.Rule.Description
 
 				reviewdogDiagnostics = append(reviewdogDiagnostics, reviewdog.Diagnostic{
 					Message:  message,
 					Severity: severity,
-					Location: reviewdog.Location{
-						Path: finding.Filename,
-						Range: reviewdog.LocationRange{
-							Start: reviewdog.LocationPosition{
-								Line:   finding.Sink.Start,
-								Column: finding.Sink.Column.Start,
-							},
-							End: reviewdog.LocationPosition{
-								Line:   finding.Sink.End,
-								Column: finding.Sink.Column.End,
-							},
-						},
-					},
+					Location: location,
 					Code: reviewdog.Code{
 						RuleId:           finding.Rule.Id,
 						DocumentationUrl: finding.Rule.DocumentationUrl,
@@ -46,6 +77,20 @@ func ReportReviewdog(outputDetections map[string][]securitytypes.Finding) (revie
 		}
 	}
 
+	if omittedTotal != 0 {
+		reviewdogDiagnostics = append(reviewdogDiagnostics, summaryDiagnostic(
+			annotationLimit,
+			omittedTotal,
+			omittedCounts,
+			*omittedLocation,
+			summaryURL,
+		))
+	}
+
+	if gateFailure != nil && len(gateFailure.Findings) != 0 {
+		reviewdogDiagnostics = append(reviewdogDiagnostics, gateDiagnostic(gateFailure))
+	}
+
 	output := reviewdog.ReviewdogOutput{
 		Source: reviewdog.Source{
 			Name: "Bearer",
@@ -56,3 +101,83 @@ func ReportReviewdog(outputDetections map[string][]securitytypes.Finding) (revie
 
 	return output, nil
 }
+
+// summaryDiagnostic describes the findings that were not annotated. It is
+// placed on the first of them, and its message only depends on the findings
+// so that it is not posted again when the results don't change.
+func summaryDiagnostic(
+	annotationLimit int,
+	omittedTotal int,
+	omittedCounts map[string]int,
+	location reviewdog.Location,
+	summaryURL string,
+) reviewdog.Diagnostic {
+	var counts []string
+	for _, level := range levels {
+		if count := omittedCounts[level]; count != 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count, level))
+		}
+	}
+
+	message := fmt.Sprintf(
+		"\n# %d more findings\nOnly the first %d of %d findings are annotated. Not annotated: %s.",
+		omittedTotal,
+		annotationLimit,
+		annotationLimit+omittedTotal,
+		strings.Join(counts, ", "),
+	)
+	if summaryURL != "" {
+		message += "\n\nSee the full report: " + summaryURL
+	}
+
+	return reviewdog.Diagnostic{
+		Message:     message,
+		Severity:    "INFO",
+		Location:    location,
+		Suggestions: []reviewdog.Suggestion{},
+		Code: reviewdog.Code{
+			RuleId:           "bearer_summary",
+			DocumentationUrl: summaryURL,
+		},
+	}
+}
+
+// gateDiagnostic explains why the scan failed. It is placed on the first
+// failing finding.
+func gateDiagnostic(gateFailure *securitytypes.GateFailure) reviewdog.Diagnostic {
+	first := gateFailure.Findings[0]
+
+	return reviewdog.Diagnostic{
+		Message:  "\n# Why this scan failed\n" + gate.Explain(gateFailure),
+		Severity: "INFO",
+		Location: reviewdog.Location{
+			Path: first.Filename,
+			Range: reviewdog.LocationRange{
+				Start: reviewdog.LocationPosition{Line: first.LineNumber},
+				End:   reviewdog.LocationPosition{Line: first.LineNumber},
+			},
+		},
+		Suggestions: []reviewdog.Suggestion{},
+		Code: reviewdog.Code{
+			RuleId:           "bearer_gate",
+			DocumentationUrl: "https://docs.bearer.com/explanations/reports/",
+		},
+	}
+}
+
+// CIRunURL returns the URL of the current GitHub Actions run or GitLab CI
+// job, where the full report is usually uploaded as an artifact
+func CIRunURL() string {
+	if jobURL := os.Getenv("CI_JOB_URL"); jobURL != "" {
+		return jobURL
+	}
+
+	serverURL := os.Getenv("GITHUB_SERVER_URL")
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	runID := os.Getenv("GITHUB_RUN_ID")
+	if serverURL == "" || repository == "" || runID == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s/actions/runs/%s", serverURL, repository, runID)
+}
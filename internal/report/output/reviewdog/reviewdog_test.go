@@ -25,7 +25,7 @@ func TestRailsGoatReviewdog(t *testing.T) {
 		t.Fatalf("couldn't unmarshal file output: %s", err)
 	}
 
-	res, err := reviewdog.ReportReviewdog(securityFindings)
+	res, err := reviewdog.ReportReviewdog(securityFindings, 0, "", nil)
 	if err != nil {
 		t.Fatalf("failed to generate security output, err: %s", err)
 	}
@@ -42,3 +42,62 @@ func TestRailsGoatReviewdog(t *testing.T) {
 	}
 	cupaloy.SnapshotT(t, prettyJSON.String())
 }
+
+func TestReviewdogAnnotationLimit(t *testing.T) {
+	securityOutput, err := os.ReadFile("testdata/rails-goat-security-report.json")
+	if err != nil {
+		t.Fatalf("failed to read file, err: %s", err)
+	}
+
+	var securityFindings map[string][]securitytypes.Finding
+	err = json.Unmarshal(securityOutput, &securityFindings)
+	if err != nil {
+		t.Fatalf("couldn't unmarshal file output: %s", err)
+	}
+
+	res, err := reviewdog.ReportReviewdog(securityFindings, 3, "https://github.com/acme/app/actions/runs/1", nil)
+	if err != nil {
+		t.Fatalf("failed to generate security output, err: %s", err)
+	}
+
+	if len(res.Diagnostics) != 4 {
+		t.Fatalf("expected 3 annotations and a summary, got %d", len(res.Diagnostics))
+	}
+
+	cupaloy.SnapshotT(t, res.Diagnostics[3])
+}
+
+func TestReviewdogGateFailure(t *testing.T) {
+	securityOutput, err := os.ReadFile("testdata/rails-goat-security-report.json")
+	if err != nil {
+		t.Fatalf("failed to read file, err: %s", err)
+	}
+
+	var securityFindings map[string][]securitytypes.Finding
+	err = json.Unmarshal(securityOutput, &securityFindings)
+	if err != nil {
+		t.Fatalf("couldn't unmarshal file output: %s", err)
+	}
+
+	gateFailure := &securitytypes.GateFailure{
+		FailOnSeverity: []string{"critical"},
+		Findings: []securitytypes.GateFinding{
+			{
+				Severity:    "critical",
+				Confidence:  "high",
+				RuleID:      "ruby_rails_logger",
+				Title:       "Leakage of sensitive data in Rails logger",
+				Filename:    "app/controllers/password_resets_controller.rb",
+				LineNumber:  6,
+				Fingerprint: "f2e5b8e2d5d6a4a9cb4b1eb8a7d3e1c3_0",
+			},
+		},
+	}
+
+	res, err := reviewdog.ReportReviewdog(securityFindings, 3, "", gateFailure)
+	if err != nil {
+		t.Fatalf("failed to generate security output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, res.Diagnostics[len(res.Diagnostics)-1])
+}
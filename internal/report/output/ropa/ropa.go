@@ -0,0 +1,347 @@
+package ropa
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/report/output/billofdata"
+	billofdatatypes "github.com/bearer/bearer/internal/report/output/billofdata/types"
+	"github.com/bearer/bearer/internal/report/output/ropa/types"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// UnassignedPurpose groups the detected data that is not covered by any of
+// the declared purposes, so that gaps in the record are visible
+const UnassignedPurpose = "Unassigned"
+
+const (
+	componentTypeExternalService = "external_service"
+	componentTypeDataStore       = "data_store"
+)
+
+// LoadConfig reads the processing purposes file. YAML and JSON are supported.
+func LoadConfig(path string) (types.Config, error) {
+	var config types.Config
+	if path == "" {
+		return config, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("error reading processing purposes file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return config, fmt.Errorf("error parsing processing purposes file %s: %w", path, err)
+	}
+
+	for i, purpose := range config.Purposes {
+		if strings.TrimSpace(purpose.Name) == "" {
+			return config, fmt.Errorf("error parsing processing purposes file %s: purpose %d has no name", path, i+1)
+		}
+	}
+
+	return config, nil
+}
+
+func AddReportData(reportData *outputtypes.ReportData, config settings.Config) error {
+	ropaConfig, err := LoadConfig(config.Report.ProcessingPurposes)
+	if err != nil {
+		return err
+	}
+
+	report, err := BuildReport(reportData.Dataflow, ropaConfig)
+	if err != nil {
+		return err
+	}
+
+	report.Metadata = config.Report.Meta
+	reportData.RoPAReport = &report
+
+	return nil
+}
+
+type activityHolder struct {
+	purpose types.Purpose
+	// whether the purpose is declared in the processing purposes file, rather
+	// than only annotated in the code
+	declared     bool
+	retention    set.Set[string]
+	dataSubjects set.Set[string]
+	categories   map[string]*types.DataCategory
+	dataTypes    map[string]set.Set[string]
+	recipients   map[string]types.Recipient
+	dataStores   map[string]types.Recipient
+}
+
+// BuildReport assigns the data types detected for each data subject to the
+// declared purposes, along with the third parties and data stores found in the
+// same files as that data. Data processed in code annotated with a
+// `bearer:purpose` comment is assigned to that purpose instead, which is added
+// to the report when it isn't declared.
+func BuildReport(dataflow *outputtypes.DataFlow, config types.Config) (types.Report, error) {
+	billOfData, err := billofdata.ReportBillOfData(dataflow)
+	if err != nil {
+		return types.Report{}, err
+	}
+
+	holders := make([]*activityHolder, len(config.Purposes))
+	for i, purpose := range config.Purposes {
+		holders[i] = newActivityHolder(purpose)
+		holders[i].declared = true
+	}
+	unassigned := newActivityHolder(types.Purpose{Name: UnassignedPurpose})
+
+	for _, subject := range billOfData.DataSubjects {
+		for _, dataType := range subject.DataTypes {
+			annotated, hasUnannotated := annotatedPurposes(dataType)
+
+			// data annotated in the code is assigned to that purpose only
+			for _, annotation := range annotated {
+				holder := findHolder(holders, annotation.Purpose)
+				if holder == nil {
+					holder = newActivityHolder(types.Purpose{Name: annotation.Purpose})
+					holders = append(holders, holder)
+				}
+
+				holder.add(subject, dataType)
+				if annotation.Retention != "" {
+					holder.retention.Add(annotation.Retention)
+				}
+			}
+
+			if !hasUnannotated {
+				continue
+			}
+
+			assigned := false
+			for _, holder := range holders {
+				if holder.declared && matches(holder.purpose.DataSubjects, subject.Name) && matches(holder.purpose.DataTypes, dataType.Name) {
+					holder.add(subject, dataType)
+					assigned = true
+				}
+			}
+
+			if !assigned {
+				unassigned.add(subject, dataType)
+			}
+		}
+	}
+
+	if len(unassigned.categories) != 0 {
+		holders = append(holders, unassigned)
+	}
+
+	report := types.Report{
+		Controller:           config.Controller,
+		ProcessingActivities: []types.ProcessingActivity{},
+	}
+	for _, holder := range holders {
+		report.ProcessingActivities = append(report.ProcessingActivities, holder.activity())
+	}
+
+	return report, nil
+}
+
+func newActivityHolder(purpose types.Purpose) *activityHolder {
+	holder := &activityHolder{
+		purpose:      purpose,
+		retention:    set.New[string](),
+		dataSubjects: set.New[string](),
+		categories:   make(map[string]*types.DataCategory),
+		dataTypes:    make(map[string]set.Set[string]),
+		recipients:   make(map[string]types.Recipient),
+		dataStores:   make(map[string]types.Recipient),
+	}
+
+	for _, name := range purpose.Recipients {
+		holder.recipients[name] = types.Recipient{Name: name}
+	}
+
+	return holder
+}
+
+// annotatedPurposes returns the purposes annotated on the locations of the
+// data type, and whether any of its locations has no annotation
+func annotatedPurposes(dataType billofdatatypes.DataType) ([]billofdatatypes.Location, bool) {
+	var annotated []billofdatatypes.Location
+	hasUnannotated := false
+
+	for _, locations := range [][]billofdatatypes.Location{dataType.StorageLocations, dataType.ProcessingLocations} {
+		for _, location := range locations {
+			if location.Purpose == "" {
+				hasUnannotated = true
+				continue
+			}
+
+			annotated = append(annotated, location)
+		}
+	}
+
+	return annotated, hasUnannotated
+}
+
+// findHolder returns the activity for the purpose, declared or annotated. The
+// names are compared ignoring case.
+func findHolder(holders []*activityHolder, name string) *activityHolder {
+	for _, holder := range holders {
+		if strings.EqualFold(holder.purpose.Name, name) {
+			return holder
+		}
+	}
+
+	return nil
+}
+
+func (holder *activityHolder) add(subject billofdatatypes.DataSubject, dataType billofdatatypes.DataType) {
+	holder.dataSubjects.Add(subject.Name)
+
+	categoryName := dataType.CategoryName
+	if _, ok := holder.categories[categoryName]; !ok {
+		holder.categories[categoryName] = &types.DataCategory{
+			Name:           categoryName,
+			CategoryGroups: dataType.CategoryGroups,
+		}
+		holder.dataTypes[categoryName] = set.New[string]()
+	}
+	holder.dataTypes[categoryName].Add(dataType.Name)
+
+	for _, component := range subject.Components {
+		recipient := types.Recipient{Name: component.Name, Type: component.Type, SubType: component.SubType, Category: component.Category}
+
+		switch component.Type {
+		case componentTypeExternalService:
+			holder.recipients[component.Name] = recipient
+		case componentTypeDataStore:
+			holder.dataStores[component.Name] = recipient
+		}
+	}
+}
+
+func (holder *activityHolder) activity() types.ProcessingActivity {
+	activity := types.ProcessingActivity{
+		Purpose:                holder.purpose.Name,
+		Description:            holder.purpose.Description,
+		LegalBasis:             holder.purpose.LegalBasis,
+		DataSubjects:           sortedItems(holder.dataSubjects),
+		PersonalDataCategories: []types.DataCategory{},
+		Recipients:             []types.Recipient{},
+		DataStores:             []types.Recipient{},
+		InternationalTransfers: holder.purpose.InternationalTransfers,
+		Retention:              holder.purpose.Retention,
+		SecurityMeasures:       holder.purpose.SecurityMeasures,
+	}
+
+	// retention declared in the processing purposes file takes precedence
+	if activity.Retention == "" && len(holder.retention) != 0 {
+		activity.Retention = strings.Join(sortedItems(holder.retention), "; ")
+	}
+
+	for _, categoryName := range maputil.SortedStringKeys(holder.categories) {
+		category := *holder.categories[categoryName]
+		category.DataTypes = sortedItems(holder.dataTypes[categoryName])
+		activity.PersonalDataCategories = append(activity.PersonalDataCategories, category)
+	}
+
+	for _, name := range maputil.SortedStringKeys(holder.recipients) {
+		activity.Recipients = append(activity.Recipients, holder.recipients[name])
+	}
+
+	for _, name := range maputil.SortedStringKeys(holder.dataStores) {
+		activity.DataStores = append(activity.DataStores, holder.dataStores[name])
+	}
+
+	return activity
+}
+
+func matches(filter []string, name string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, value := range filter {
+		if strings.EqualFold(value, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedItems(values set.Set[string]) []string {
+	items := values.Items()
+	sort.Strings(items)
+
+	return items
+}
+
+// BuildCsvString renders the controller and one row for each processing
+// activity. Lists are separated by semicolons.
+func BuildCsvString(report *types.Report) (string, error) {
+	builder := &strings.Builder{}
+	writer := csv.NewWriter(builder)
+
+	records := [][]string{
+		{"Controller", "Contact", "Representative", "Data Protection Officer"},
+		{report.Controller.Name, report.Controller.Contact, report.Controller.Representative, report.Controller.DataProtectionOfficer},
+		{},
+		{
+			"Purpose",
+			"Description",
+			"Legal Basis",
+			"Data Subjects",
+			"Personal Data Categories",
+			"Data Types",
+			"Recipients",
+			"Data Stores",
+			"International Transfers",
+			"Retention",
+			"Security Measures",
+		},
+	}
+
+	for _, activity := range report.ProcessingActivities {
+		var categories, dataTypes []string
+		for _, category := range activity.PersonalDataCategories {
+			categories = append(categories, category.Name)
+			dataTypes = append(dataTypes, category.DataTypes...)
+		}
+
+		records = append(records, []string{
+			activity.Purpose,
+			activity.Description,
+			activity.LegalBasis,
+			strings.Join(activity.DataSubjects, "; "),
+			strings.Join(categories, "; "),
+			strings.Join(dataTypes, "; "),
+			joinRecipients(activity.Recipients),
+			joinRecipients(activity.DataStores),
+			activity.InternationalTransfers,
+			activity.Retention,
+			activity.SecurityMeasures,
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+func joinRecipients(recipients []types.Recipient) string {
+	names := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		names[i] = recipient.Name
+	}
+
+	return strings.Join(names, "; ")
+}
@@ -0,0 +1,115 @@
+package ropa_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/report/output/ropa"
+	"github.com/bearer/bearer/internal/report/output/ropa/types"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	util "github.com/bearer/bearer/internal/util/output"
+)
+
+func TestBuildReport(t *testing.T) {
+	config, err := ropa.LoadConfig("testdata/purposes.yml")
+	if err != nil {
+		t.Fatalf("failed to load config, err: %s", err)
+	}
+
+	report, err := ropa.BuildReport(readDataflow(t, "testdata/dataflow.json"), config)
+	if err != nil {
+		t.Fatalf("failed to build report, err: %s", err)
+	}
+
+	output, err := util.ReportYAML(report)
+	if err != nil {
+		t.Fatalf("failed to generate YAML output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, output)
+}
+
+func TestBuildReportWithAnnotations(t *testing.T) {
+	config, err := ropa.LoadConfig("testdata/purposes.yml")
+	if err != nil {
+		t.Fatalf("failed to load config, err: %s", err)
+	}
+
+	report, err := ropa.BuildReport(readDataflow(t, "testdata/annotated_dataflow.json"), config)
+	if err != nil {
+		t.Fatalf("failed to build report, err: %s", err)
+	}
+
+	output, err := util.ReportYAML(report)
+	if err != nil {
+		t.Fatalf("failed to generate YAML output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, output)
+}
+
+func TestBuildReportWithoutPurposes(t *testing.T) {
+	report, err := ropa.BuildReport(readDataflow(t, "testdata/dataflow.json"), types.Config{})
+	if err != nil {
+		t.Fatalf("failed to build report, err: %s", err)
+	}
+
+	if len(report.ProcessingActivities) != 1 || report.ProcessingActivities[0].Purpose != ropa.UnassignedPurpose {
+		t.Fatalf("expected all data to be unassigned, got %v", report.ProcessingActivities)
+	}
+
+	report, err = ropa.BuildReport(nil, types.Config{})
+	if err != nil {
+		t.Fatalf("failed to build report, err: %s", err)
+	}
+
+	if len(report.ProcessingActivities) != 0 {
+		t.Errorf("expected no activities without a dataflow, got %v", report.ProcessingActivities)
+	}
+}
+
+func TestBuildCsvString(t *testing.T) {
+	config, err := ropa.LoadConfig("testdata/purposes.yml")
+	if err != nil {
+		t.Fatalf("failed to load config, err: %s", err)
+	}
+
+	report, err := ropa.BuildReport(readDataflow(t, "testdata/dataflow.json"), config)
+	if err != nil {
+		t.Fatalf("failed to build report, err: %s", err)
+	}
+
+	output, err := ropa.BuildCsvString(&report)
+	if err != nil {
+		t.Fatalf("failed to generate CSV output, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, output)
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	if _, err := ropa.LoadConfig("testdata/missing.yml"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+
+	if _, err := ropa.LoadConfig("testdata/invalid.yml"); err == nil {
+		t.Error("expected an error for a purpose without a name")
+	}
+}
+
+func readDataflow(t *testing.T, path string) *outputtypes.DataFlow {
+	dataflowOutput, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file, err: %s", err)
+	}
+
+	var dataflow outputtypes.DataFlow
+	if err := json.Unmarshal(dataflowOutput, &dataflow); err != nil {
+		t.Fatalf("couldn't unmarshal file output: %s", err)
+	}
+
+	return &dataflow
+}
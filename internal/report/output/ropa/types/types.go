@@ -0,0 +1,65 @@
+package types
+
+// Config describes the controller and the purposes of processing, as declared
+// by the user in the processing purposes file
+type Config struct {
+	Controller Controller `json:"controller" yaml:"controller"`
+	Purposes   []Purpose  `json:"purposes" yaml:"purposes"`
+}
+
+type Controller struct {
+	Name                  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Contact               string `json:"contact,omitempty" yaml:"contact,omitempty"`
+	Representative        string `json:"representative,omitempty" yaml:"representative,omitempty"`
+	DataProtectionOfficer string `json:"data_protection_officer,omitempty" yaml:"data_protection_officer,omitempty"`
+}
+
+// Purpose is a purpose of processing. DataTypes and DataSubjects select the
+// detected data it applies to; when empty, all data types or subjects match.
+type Purpose struct {
+	Name                   string   `json:"name" yaml:"name"`
+	Description            string   `json:"description,omitempty" yaml:"description,omitempty"`
+	LegalBasis             string   `json:"legal_basis,omitempty" yaml:"legal_basis,omitempty"`
+	DataTypes              []string `json:"data_types,omitempty" yaml:"data_types,omitempty"`
+	DataSubjects           []string `json:"data_subjects,omitempty" yaml:"data_subjects,omitempty"`
+	Recipients             []string `json:"recipients,omitempty" yaml:"recipients,omitempty"`
+	InternationalTransfers string   `json:"international_transfers,omitempty" yaml:"international_transfers,omitempty"`
+	Retention              string   `json:"retention,omitempty" yaml:"retention,omitempty"`
+	SecurityMeasures       string   `json:"security_measures,omitempty" yaml:"security_measures,omitempty"`
+}
+
+// Report is a record of processing activities, shaped after Article 30 of
+// the GDPR
+type Report struct {
+	Controller           Controller           `json:"controller" yaml:"controller"`
+	ProcessingActivities []ProcessingActivity `json:"processing_activities" yaml:"processing_activities"`
+	Metadata             map[string]string    `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+type ProcessingActivity struct {
+	Purpose                string         `json:"purpose" yaml:"purpose"`
+	Description            string         `json:"description,omitempty" yaml:"description,omitempty"`
+	LegalBasis             string         `json:"legal_basis,omitempty" yaml:"legal_basis,omitempty"`
+	DataSubjects           []string       `json:"data_subjects" yaml:"data_subjects"`
+	PersonalDataCategories []DataCategory `json:"personal_data_categories" yaml:"personal_data_categories"`
+	Recipients             []Recipient    `json:"recipients" yaml:"recipients"`
+	DataStores             []Recipient    `json:"data_stores" yaml:"data_stores"`
+	InternationalTransfers string         `json:"international_transfers,omitempty" yaml:"international_transfers,omitempty"`
+	Retention              string         `json:"retention,omitempty" yaml:"retention,omitempty"`
+	SecurityMeasures       string         `json:"security_measures,omitempty" yaml:"security_measures,omitempty"`
+}
+
+type DataCategory struct {
+	Name           string   `json:"name" yaml:"name"`
+	CategoryGroups []string `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
+	DataTypes      []string `json:"data_types" yaml:"data_types"`
+}
+
+// Recipient is a third party or data store the data is disclosed to. Detected
+// recipients have a type; recipients declared by the user do not.
+type Recipient struct {
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type,omitempty" yaml:"type,omitempty"`
+	SubType  string `json:"sub_type,omitempty" yaml:"sub_type,omitempty"`
+	Category string `json:"category,omitempty" yaml:"category,omitempty"`
+}
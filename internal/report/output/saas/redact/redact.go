@@ -0,0 +1,152 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/bearer/bearer/internal/detectors/gitleaks"
+)
+
+const replacement = "[REDACTED]"
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+
+// Redactor removes sensitive values from a piece of text
+type Redactor interface {
+	Name() string
+	Redact(value string) string
+}
+
+type secretsRedactor struct{}
+
+func (secretsRedactor) Name() string {
+	return "secrets"
+}
+
+func (secretsRedactor) Redact(value string) string {
+	return gitleaks.MaskSecrets(value)
+}
+
+type regexRedactor struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+func (redactor regexRedactor) Name() string {
+	return redactor.name
+}
+
+func (redactor regexRedactor) Redact(value string) string {
+	return redactor.pattern.ReplaceAllLiteralString(value, replacement)
+}
+
+// NewRegexRedactor returns a redactor replacing the matches of the given
+// regular expression
+func NewRegexRedactor(pattern string) (Redactor, error) {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redact pattern %s: %w", pattern, err)
+	}
+
+	return regexRedactor{name: pattern, pattern: compiled}, nil
+}
+
+// Pipeline applies the built-in redactors for secrets and email addresses,
+// followed by any custom ones
+type Pipeline struct {
+	redactors []Redactor
+}
+
+func New(patterns []string) (*Pipeline, error) {
+	pipeline := &Pipeline{
+		redactors: []Redactor{
+			secretsRedactor{},
+			regexRedactor{name: "emails", pattern: emailPattern},
+		},
+	}
+
+	for _, pattern := range patterns {
+		redactor, err := NewRegexRedactor(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		pipeline.redactors = append(pipeline.redactors, redactor)
+	}
+
+	return pipeline, nil
+}
+
+// RedactJSON redacts every string value of a JSON report, apart from its
+// metadata which identifies the repository. An error is returned if a value
+// would still match one of the redactors, so that it is never sent.
+func (pipeline *Pipeline) RedactJSON(content []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var report interface{}
+	if err := decoder.Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode report: %w", err)
+	}
+
+	if fields, ok := report.(map[string]interface{}); ok {
+		for key, value := range fields {
+			if key == "meta" {
+				continue
+			}
+
+			redacted, err := pipeline.redactValue(value)
+			if err != nil {
+				return nil, err
+			}
+
+			fields[key] = redacted
+		}
+	}
+
+	return json.Marshal(report)
+}
+
+func (pipeline *Pipeline) redactValue(value interface{}) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return pipeline.Redact(typedValue)
+	case []interface{}:
+		for i, item := range typedValue {
+			redacted, err := pipeline.redactValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[i] = redacted
+		}
+	case map[string]interface{}:
+		for key, item := range typedValue {
+			redacted, err := pipeline.redactValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[key] = redacted
+		}
+	}
+
+	return value, nil
+}
+
+// Redact applies each redactor in turn to the value
+func (pipeline *Pipeline) Redact(value string) (string, error) {
+	for _, redactor := range pipeline.redactors {
+		value = redactor.Redact(value)
+	}
+
+	for _, redactor := range pipeline.redactors {
+		if redactor.Redact(value) != value {
+			return "", fmt.Errorf("value still matches the %s redactor after redaction", redactor.Name())
+		}
+	}
+
+	return value, nil
+}
@@ -1,7 +1,11 @@
 package saas
 
 import (
+	"bytes"
 	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"os"
@@ -15,12 +19,16 @@ import (
 	"github.com/bearer/bearer/cmd/bearer/build"
 	"github.com/bearer/bearer/internal/commands/process/gitrepository"
 	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/report/output/cooccurrence"
+	"github.com/bearer/bearer/internal/report/output/saas/redact"
 	saas "github.com/bearer/bearer/internal/report/output/saas/types"
 	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
 	"github.com/bearer/bearer/internal/report/output/types"
 	"github.com/bearer/bearer/internal/util/file"
 	util "github.com/bearer/bearer/internal/util/output"
 	pointer "github.com/bearer/bearer/internal/util/pointers"
+	"github.com/bearer/bearer/internal/util/tmpfile"
+	"github.com/bearer/bearer/internal/util/workdir"
 )
 
 func GetReport(
@@ -42,17 +50,23 @@ func GetReport(
 		}
 	}
 
+	meta.Metadata = config.Report.Meta
+	meta.StreamID = reportData.CloudStreamID
+
 	saasFindingsBySeverity := translateFindingsBySeverity(reportData.FindingsBySeverity)
 	saasIgnoredFindingsBySeverity := translateFindingsBySeverity(reportData.IgnoredFindingsBySeverity)
+	saasExceptedFindingsBySeverity := translateFindingsBySeverity(reportData.ExceptedFindingsBySeverity)
 
 	reportData.SaasReport = &saas.BearerReport{
-		Meta:            *meta,
-		Findings:        saasFindingsBySeverity,
-		IgnoredFindings: saasIgnoredFindingsBySeverity,
-		DataTypes:       reportData.Dataflow.Datatypes,
-		Components:      reportData.Dataflow.Components,
-		Errors:          reportData.Dataflow.Errors,
-		Files:           getDiscoveredFiles(config, reportData.Files),
+		Meta:                 *meta,
+		Findings:             saasFindingsBySeverity,
+		IgnoredFindings:      saasIgnoredFindingsBySeverity,
+		RiskAcceptedFindings: saasExceptedFindingsBySeverity,
+		DataTypes:            reportData.Dataflow.Datatypes,
+		Components:           reportData.Dataflow.Components,
+		CoOccurrences:        cooccurrence.Find(reportData.Dataflow.Datatypes),
+		Errors:               reportData.Dataflow.Errors,
+		Files:                getDiscoveredFiles(config, reportData.Files),
 	}
 
 	return nil
@@ -69,15 +83,22 @@ func SendReport(config settings.Config, reportData *types.ReportData, gitContext
 		}
 	}
 
-	tmpDir, filename, err := createBearerGzipFileReport(config, reportData)
+	content, err := redactReport(config, reportData)
+	if err != nil {
+		config.Client.Error = pointer.String("Could not redact report. Nothing was sent.")
+		log.Debug().Msgf("error redacting report %s", err)
+		return
+	}
+
+	report, err := createBearerGzipFileReport(content, config.Report.EncryptTempFiles)
 	if err != nil {
 		config.Client.Error = pointer.String("Could not compress report.")
 		log.Debug().Msgf("error creating report %s", err)
+		return
 	}
+	defer report.remove()
 
-	defer os.RemoveAll(*tmpDir)
-
-	err = sendReportToBearer(config.Client, &reportData.SaasReport.Meta, filename)
+	err = sendReportToBearer(config.Client, &reportData.SaasReport.Meta, report)
 	if err != nil {
 		config.Client.Error = pointer.String("Report upload failed.")
 		log.Debug().Msgf("error sending report to Bearer cloud: %s", err)
@@ -89,24 +110,38 @@ func translateFindingsBySeverity[F securitytypes.GenericFinding](someFindingsByS
 	for _, severity := range maps.Keys(someFindingsBySeverity) {
 		for _, someFinding := range someFindingsBySeverity[severity] {
 			finding := someFinding.GetFinding()
+			exception := someFinding.GetException()
 			saasFindingsBySeverity[severity] = append(saasFindingsBySeverity[severity], saas.SaasFinding{
 				Finding:      finding,
 				SeverityMeta: finding.SeverityMeta,
 				IgnoreMeta:   someFinding.GetIgnoreMeta(),
+				RiskAccepted: exception != nil,
+				Exception:    exception,
 			})
 		}
 	}
 	return saasFindingsBySeverity
 }
 
-func sendReportToBearer(client *api.API, meta *saas.Meta, filename *string) error {
-	fileUploadOffer, err := s3.UploadS3(&s3.UploadRequestS3{
+func sendReportToBearer(client *api.API, meta *saas.Meta, report *reportFile) error {
+	request := &s3.UploadRequestS3{
 		Api:             client,
-		FilePath:        *filename,
+		FilePath:        report.filename,
 		FilePrefix:      "bearer_security_report",
 		ContentType:     "application/json",
 		ContentEncoding: "gzip",
-	})
+	}
+
+	if report.key != nil {
+		content, err := report.read()
+		if err != nil {
+			return err
+		}
+
+		request.Content = content
+	}
+
+	fileUploadOffer, err := s3.UploadS3(request)
 	if err != nil {
 		return err
 	}
@@ -131,31 +166,145 @@ func getDiscoveredFiles(config settings.Config, files []string) []string {
 	return filenames
 }
 
-func createBearerGzipFileReport(
-	config settings.Config,
-	reportData *types.ReportData,
-) (*string, *string, error) {
-	tempDir, err := os.MkdirTemp("", "reports")
+// redactReport removes secrets, email addresses and any values matching the
+// configured patterns from the report before it leaves the machine
+func redactReport(config settings.Config, reportData *types.ReportData) ([]byte, error) {
+	pipeline, err := redact.New(config.Report.RedactPatterns)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+
+	content, err := util.ReportJSON(reportData.SaasReport)
+	if err != nil {
+		return nil, err
+	}
+
+	return pipeline.RedactJSON([]byte(content))
+}
+
+// reportFile is the compressed report, held in a temporary directory until it
+// is sent. When the report is encrypted, the key is only held in memory, so
+// the file can't be read if it is left behind by a process that was killed.
+type reportFile struct {
+	dir      string
+	filename string
+	key      []byte
+}
+
+func createBearerGzipFileReport(content []byte, encrypt bool) (*reportFile, error) {
+	tempDir, err := os.MkdirTemp(workdir.Dir(), "reports")
+	if err != nil {
+		return nil, err
+	}
+
+	report := &reportFile{dir: tempDir}
+
+	// remove the partial report on failure, including when panicking
+	created := false
+	defer func() {
+		if !created {
+			report.remove()
+		}
+	}()
+
+	var compressed bytes.Buffer
+	gzWriter := gzip.NewWriter(&compressed)
+	if _, err := gzWriter.Write(content); err != nil {
+		return nil, err
+	}
+	if err := gzWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	data := compressed.Bytes()
+	if encrypt {
+		report.key = make([]byte, 32)
+		if _, err := rand.Read(report.key); err != nil {
+			return nil, err
+		}
+
+		if data, err = encryptReport(report.key, data); err != nil {
+			return nil, err
+		}
 	}
 
 	file, err := os.CreateTemp(tempDir, "security-*.json.gz")
 	if err != nil {
-		return &tempDir, nil, err
+		return nil, err
 	}
+	defer file.Close()
 
-	content, _ := util.ReportJSON(reportData.SaasReport)
-	gzWriter := gzip.NewWriter(file)
-	_, err = gzWriter.Write([]byte(content))
+	report.filename = file.Name()
+
+	// the report can contain code extracts, so it must only be readable by
+	// the current user whatever the platform defaults
+	if err := file.Chmod(0600); err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		return nil, err
+	}
+
+	created = true
+	return report, nil
+}
+
+// read returns the compressed report, decrypting it if needed
+func (report *reportFile) read() ([]byte, error) {
+	data, err := os.ReadFile(report.filename)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+
+	if report.key == nil {
+		return data, nil
+	}
+
+	return decryptReport(report.key, data)
+}
+
+func (report *reportFile) remove() {
+	if err := tmpfile.RemoveAllSecurely(report.dir); err != nil {
+		log.Debug().Msgf("error removing report %s", err)
 	}
-	gzWriter.Close()
+}
 
-	filename := file.Name()
+func encryptReport(key []byte, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+func decryptReport(key []byte, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("encrypted report is truncated")
+	}
+
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
 
-	return &tempDir, &filename, nil
+	return cipher.NewGCM(block)
 }
 
 func getMeta(
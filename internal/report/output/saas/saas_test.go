@@ -0,0 +1,74 @@
+package saas
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/util/workdir"
+)
+
+func TestCreateBearerGzipFileReport(t *testing.T) {
+	content := []byte(`{"findings":{"critical":[{"code_extract":"password = \"hunter2\""}]}}`)
+
+	for _, test := range []struct {
+		Name    string
+		Encrypt bool
+	}{
+		{Name: "plain", Encrypt: false},
+		{Name: "encrypted", Encrypt: true},
+	} {
+		t.Run(test.Name, func(tt *testing.T) {
+			defer workdir.Setup("")
+			if err := workdir.Setup(tt.TempDir()); err != nil {
+				tt.Fatalf("failed to set up workdir: %s", err)
+			}
+
+			report, err := createBearerGzipFileReport(content, test.Encrypt)
+			if err != nil {
+				tt.Fatalf("failed to create report: %s", err)
+			}
+
+			if runtime.GOOS != "windows" {
+				info, err := os.Stat(report.filename)
+				if assert.NoError(tt, err) {
+					assert.Equal(tt, os.FileMode(0600), info.Mode().Perm())
+				}
+			}
+
+			onDisk, err := os.ReadFile(report.filename)
+			if err != nil {
+				tt.Fatalf("failed to read report file: %s", err)
+			}
+			_, err = gzip.NewReader(bytes.NewReader(onDisk))
+			assert.Equal(tt, test.Encrypt, err != nil, "only the plain report should be readable on disk")
+
+			compressed, err := report.read()
+			if assert.NoError(tt, err) {
+				assert.Equal(tt, content, decompress(tt, compressed))
+			}
+
+			report.remove()
+			assert.NoDirExists(tt, report.dir)
+		})
+	}
+}
+
+func decompress(t *testing.T, compressed []byte) []byte {
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("failed to decompress report: %s", err)
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to decompress report: %s", err)
+	}
+
+	return content
+}
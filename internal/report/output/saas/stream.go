@@ -0,0 +1,120 @@
+package saas
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/api"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/report/output/saas/redact"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/report/output/types"
+)
+
+const (
+	streamBatchSize     = 100
+	streamFlushInterval = 5 * time.Second
+)
+
+// Stream sends findings to Bearer Cloud in batches as the rules producing
+// them are evaluated, ahead of the report. Streaming is best effort: the
+// complete report is still sent once the scan finishes, so a failed batch
+// stops the stream without failing the scan.
+type Stream struct {
+	ID        string
+	client    *api.API
+	pipeline  *redact.Pipeline
+	pending   []securitytypes.RawFinding
+	lastFlush time.Time
+	failed    bool
+}
+
+func NewStream(config settings.Config) (*Stream, error) {
+	pipeline, err := redact.New(config.Report.RedactPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stream{
+		ID:        uuid.NewString(),
+		client:    config.Client,
+		pipeline:  pipeline,
+		lastFlush: time.Now(),
+	}, nil
+}
+
+// Tee returns a finding stream sending each finding to the cloud as well as
+// to the given stream, if any
+func (stream *Stream) Tee(findingStream types.FindingStream) types.FindingStream {
+	return func(finding securitytypes.RawFinding) error {
+		stream.add(finding)
+
+		if findingStream == nil {
+			return nil
+		}
+
+		return findingStream(finding)
+	}
+}
+
+// Close sends any remaining findings
+func (stream *Stream) Close() {
+	stream.flush()
+}
+
+func (stream *Stream) add(finding securitytypes.RawFinding) {
+	if stream.failed {
+		return
+	}
+
+	stream.pending = append(stream.pending, finding)
+
+	if len(stream.pending) >= streamBatchSize || time.Since(stream.lastFlush) >= streamFlushInterval {
+		stream.flush()
+	}
+}
+
+func (stream *Stream) flush() {
+	if stream.failed || len(stream.pending) == 0 {
+		return
+	}
+
+	findings := stream.pending
+	stream.pending = nil
+	stream.lastFlush = time.Now()
+
+	if err := stream.send(findings); err != nil {
+		log.Debug().Msgf("error streaming findings to Bearer cloud, remaining findings will be sent with the report: %s", err)
+		stream.failed = true
+	}
+}
+
+func (stream *Stream) send(findings []securitytypes.RawFinding) error {
+	content, err := json.Marshal(struct {
+		Findings []securitytypes.RawFinding `json:"findings"`
+	}{Findings: findings})
+	if err != nil {
+		return err
+	}
+
+	redactedContent, err := stream.pipeline.RedactJSON(content)
+	if err != nil {
+		return err
+	}
+
+	var redacted struct {
+		Findings json.RawMessage `json:"findings"`
+	}
+	if err := json.Unmarshal(redactedContent, &redacted); err != nil {
+		return err
+	}
+
+	log.Debug().Msgf("streaming %d findings to Bearer cloud", len(findings))
+	return stream.client.ScanFindings(api.ScanFindingsBatch{
+		StreamID: stream.ID,
+		Findings: redacted.Findings,
+	})
+}
@@ -0,0 +1,173 @@
+package saas
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/api"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+)
+
+// streamServer records the batches of findings it receives, failing them
+// with the given status when not zero
+type streamServer struct {
+	*httptest.Server
+	mutex   sync.Mutex
+	status  int
+	batches []api.ScanFindingsBatch
+}
+
+func newStreamServer(t *testing.T, status int) *streamServer {
+	server := &streamServer{status: status}
+	server.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, api.Endpoints.ScanFindings.Route, r.URL.Path)
+		assert.Equal(t, api.Endpoints.ScanFindings.HttpMethod, r.Method)
+
+		var message struct {
+			Type api.MessageType       `json:"type"`
+			Data api.ScanFindingsBatch `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("failed to decode batch: %s", err)
+		}
+		assert.Equal(t, api.MessageTypeSuccess, message.Type)
+
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		server.batches = append(server.batches, message.Data)
+
+		if server.status != 0 {
+			w.WriteHeader(server.status)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (server *streamServer) newStream(t *testing.T, redactPatterns []string) *Stream {
+	client := api.NewWithHTTPClient(
+		api.API{Host: strings.TrimPrefix(server.URL, "https://"), Token: "token"},
+		server.Client(),
+	)
+
+	stream, err := NewStream(settings.Config{
+		Client: client,
+		Report: flag.ReportOptions{RedactPatterns: redactPatterns},
+	})
+	if err != nil {
+		t.Fatalf("failed to create stream: %s", err)
+	}
+
+	return stream
+}
+
+// findingCounts returns the number of findings of each batch
+func (server *streamServer) findingCounts(t *testing.T) []int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var counts []int
+	for _, batch := range server.batches {
+		var findings []json.RawMessage
+		if err := json.Unmarshal(batch.Findings, &findings); err != nil {
+			t.Fatalf("failed to decode findings: %s", err)
+		}
+		counts = append(counts, len(findings))
+	}
+
+	return counts
+}
+
+func streamFinding(i int) securitytypes.RawFinding {
+	return securitytypes.RawFinding{
+		Finding: &securitytypes.Finding{
+			Filename:    fmt.Sprintf("app/file_%d.rb", i),
+			CodeExtract: fmt.Sprintf("logger.info(user.email) # %d", i),
+		},
+		Severity: "high",
+	}
+}
+
+func TestStreamSendsFullBatches(t *testing.T) {
+	server := newStreamServer(t, 0)
+	stream := server.newStream(t, nil)
+	findingStream := stream.Tee(nil)
+
+	for i := 0; i < 2*streamBatchSize+1; i++ {
+		if err := findingStream(streamFinding(i)); err != nil {
+			t.Fatalf("failed to stream finding: %s", err)
+		}
+	}
+
+	assert.Equal(t, []int{streamBatchSize, streamBatchSize}, server.findingCounts(t))
+
+	stream.Close()
+	assert.Equal(t, []int{streamBatchSize, streamBatchSize, 1}, server.findingCounts(t))
+
+	for _, batch := range server.batches {
+		assert.Equal(t, stream.ID, batch.StreamID)
+	}
+}
+
+func TestStreamSendsBatchesAfterFlushInterval(t *testing.T) {
+	server := newStreamServer(t, 0)
+	stream := server.newStream(t, nil)
+	findingStream := stream.Tee(nil)
+
+	assert.NoError(t, findingStream(streamFinding(0)))
+	assert.Empty(t, server.findingCounts(t))
+
+	stream.lastFlush = time.Now().Add(-streamFlushInterval)
+	assert.NoError(t, findingStream(streamFinding(1)))
+	assert.Equal(t, []int{2}, server.findingCounts(t))
+
+	stream.Close()
+	assert.Equal(t, []int{2}, server.findingCounts(t), "nothing is left to send")
+}
+
+func TestStreamRedactsFindings(t *testing.T) {
+	server := newStreamServer(t, 0)
+	stream := server.newStream(t, []string{`# \d+`})
+	findingStream := stream.Tee(nil)
+
+	assert.NoError(t, findingStream(streamFinding(1)))
+	stream.Close()
+
+	if assert.Len(t, server.batches, 1) {
+		findings := string(server.batches[0].Findings)
+		assert.Contains(t, findings, "logger.info(user.email) [REDACTED]")
+		assert.Contains(t, findings, "app/file_1.rb")
+	}
+}
+
+func TestStreamStopsAfterFailedBatch(t *testing.T) {
+	server := newStreamServer(t, http.StatusInternalServerError)
+	stream := server.newStream(t, nil)
+
+	var received int
+	findingStream := stream.Tee(func(finding securitytypes.RawFinding) error {
+		received++
+		return nil
+	})
+
+	for i := 0; i < 2*streamBatchSize; i++ {
+		assert.NoError(t, findingStream(streamFinding(i)), "a failed batch doesn't fail the scan")
+	}
+	stream.Close()
+
+	assert.Equal(t, []int{streamBatchSize}, server.findingCounts(t))
+	assert.Equal(t, 2*streamBatchSize, received, "findings are still passed on")
+}
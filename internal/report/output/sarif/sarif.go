@@ -1,19 +1,31 @@
 package sarif
 
 import (
+	"fmt"
+
 	"github.com/bearer/bearer/internal/commands/process/settings"
 	sarif "github.com/bearer/bearer/internal/report/output/sarif/types"
 	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/util/maputil"
 )
 
-func ReportSarif(outputDetections map[string][]securitytypes.Finding, rules map[string]*settings.Rule) (sarif.SarifOutput, error) {
+func ReportSarif(outputDetections map[string][]securitytypes.Finding, rules map[string]*settings.Rule, metadata map[string]string) (sarif.SarifOutput, error) {
 	var sarifRules []sarif.Rule
+	cweIDs := make(map[string]bool)
+	owaspIDs := make(map[string]bool)
 
 	for _, rule := range rules {
 		if !rule.PolicyType() {
 			continue
 		}
 
+		for _, cweID := range rule.CWEIDs {
+			cweIDs[cweID] = true
+		}
+		for _, owaspID := range rule.OWASP {
+			owaspIDs[owaspID] = true
+		}
+
 		sarifRules = append(sarifRules, sarif.Rule{
 			Id:   rule.Id,
 			Name: rule.Id,
@@ -30,10 +42,8 @@ func ReportSarif(outputDetections map[string][]securitytypes.Finding, rules map[
 			DefaultConfiguration: sarif.Configuration{
 				Level: "error", // rule.Severity, accepted values are ("none", "note", "warning", "error")
 			},
-			// Properties: sarif.Properties{
-			// 		Tags:      []string{"maintainability"},
-			// 		Precision: "very-high",
-			// },
+			Properties:    ruleProperties(rule),
+			Relationships: ruleRelationships(rule),
 		})
 	}
 
@@ -42,6 +52,15 @@ func ReportSarif(outputDetections map[string][]securitytypes.Finding, rules map[
 	for _, level := range []string{"critical", "high", "medium", "low", "warning"} {
 		if findings, ok := outputDetections[level]; ok {
 			for _, finding := range findings {
+				var contextRegion *sarif.ContextRegion
+				if finding.CodeContext != nil {
+					contextRegion = &sarif.ContextRegion{
+						StartLine: finding.CodeContext.StartLine,
+						EndLine:   finding.CodeContext.EndLine,
+						Snippet:   sarif.Snippet{Text: finding.CodeContext.Text()},
+					}
+				}
+
 				results = append(results, sarif.Result{
 					RuleId: finding.Rule.Id,
 					Message: sarif.Message{
@@ -59,11 +78,13 @@ func ReportSarif(outputDetections map[string][]securitytypes.Finding, rules map[
 									StartColumn: finding.Sink.Column.Start,
 									EndColumn:   finding.Sink.Column.End,
 								},
+								ContextRegion: contextRegion,
 							},
 						},
 					},
 					PartialFingerprints: &sarif.PartialFingerprints{
 						PrimaryLocationLineHash: finding.Fingerprint,
+						ContentFingerprint:      finding.ContentFingerprint,
 					},
 				})
 			}
@@ -81,10 +102,94 @@ func ReportSarif(outputDetections map[string][]securitytypes.Finding, rules map[
 						Rules: sarifRules,
 					},
 				},
-				Results: results,
+				Results:    results,
+				Taxonomies: taxonomies(cweIDs, owaspIDs),
+				Properties: metadata,
 			},
 		},
 	}
 
 	return output, nil
 }
+
+// ruleProperties tags rules with their CWE ids and OWASP categories, using the
+// tag format recognised by GitHub code scanning
+func ruleProperties(rule *settings.Rule) *sarif.Properties {
+	if len(rule.CWEIDs) == 0 && len(rule.OWASP) == 0 {
+		return nil
+	}
+
+	tags := []string{"security"}
+	for _, cweID := range rule.CWEIDs {
+		tags = append(tags, "external/cwe/cwe-"+cweID)
+	}
+	for _, owaspID := range rule.OWASP {
+		tags = append(tags, "external/owasp/"+owaspID)
+	}
+
+	return &sarif.Properties{Tags: tags}
+}
+
+func ruleRelationships(rule *settings.Rule) []sarif.Relationship {
+	var relationships []sarif.Relationship
+
+	for _, cweID := range rule.CWEIDs {
+		relationships = append(relationships, taxonRelationship(cweTaxonomyName, cweID))
+	}
+	for _, owaspID := range rule.OWASP {
+		relationships = append(relationships, taxonRelationship(owaspTaxonomyName, owaspID))
+	}
+
+	return relationships
+}
+
+func taxonRelationship(taxonomyName string, id string) sarif.Relationship {
+	return sarif.Relationship{
+		Target: sarif.DescriptorReference{
+			Id:            id,
+			ToolComponent: sarif.ToolComponentReference{Name: taxonomyName},
+		},
+		Kinds: []string{"superset"},
+	}
+}
+
+const (
+	cweTaxonomyName   = "CWE"
+	owaspTaxonomyName = "OWASP"
+)
+
+// taxonomies lists the CWE and OWASP entries referenced by the rules
+func taxonomies(cweIDs map[string]bool, owaspIDs map[string]bool) []sarif.Taxonomy {
+	var result []sarif.Taxonomy
+
+	if len(cweIDs) != 0 {
+		taxonomy := sarif.Taxonomy{
+			Name:           cweTaxonomyName,
+			Organization:   "MITRE",
+			InformationUri: "https://cwe.mitre.org/",
+		}
+		for _, cweID := range maputil.SortedStringKeys(cweIDs) {
+			taxonomy.Taxa = append(taxonomy.Taxa, sarif.Taxon{
+				Id:      cweID,
+				HelpUri: fmt.Sprintf("https://cwe.mitre.org/data/definitions/%s.html", cweID),
+			})
+		}
+
+		result = append(result, taxonomy)
+	}
+
+	if len(owaspIDs) != 0 {
+		taxonomy := sarif.Taxonomy{
+			Name:           owaspTaxonomyName,
+			Organization:   "OWASP",
+			InformationUri: "https://owasp.org/Top10/",
+		}
+		for _, owaspID := range maputil.SortedStringKeys(owaspIDs) {
+			taxonomy.Taxa = append(taxonomy.Taxa, sarif.Taxon{Id: owaspID})
+		}
+
+		result = append(result, taxonomy)
+	}
+
+	return result
+}
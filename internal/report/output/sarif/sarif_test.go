@@ -42,7 +42,8 @@ func TestJuiceShopSarif(t *testing.T) {
 		Severity:           "high",
 		Description:        "rule 1",
 		RemediationMessage: "## Rule 1\nremediation message",
-		CWEIDs:             []string{"cwe-10"},
+		CWEIDs:             []string{"10", "20"},
+		OWASP:              []string{"A03:2021"},
 		Languages:          []string{"ruby"},
 		Patterns:           []settings.RulePattern{},
 		SanitizerRuleID:    "",
@@ -54,7 +55,7 @@ func TestJuiceShopSarif(t *testing.T) {
 		OmitParent:         false,
 	}
 
-	res, err := sarif.ReportSarif(securityResults, rules)
+	res, err := sarif.ReportSarif(securityResults, rules, nil)
 	if err != nil {
 		t.Fatalf("failed to generate security output, err: %s", err)
 	}
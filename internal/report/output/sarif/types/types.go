@@ -10,7 +10,7 @@ type Configuration struct {
 
 type Properties struct {
 	Tags      []string `json:"tags"` // Could add Data Types as Tags!
-	Precision string   `json:"precision"`
+	Precision string   `json:"precision,omitempty"`
 }
 
 type Help struct {
@@ -19,14 +19,41 @@ type Help struct {
 }
 
 type Rule struct {
-	Id                   string        `json:"id"`
-	Name                 string        `json:"name"`
-	Kind                 string        `json:"kind,omitempty"`
-	ShortDescription     Description   `json:"shortDescription"`
-	FullDescription      Description   `json:"fullDescription"`
-	DefaultConfiguration Configuration `json:"defaultConfiguration"`
-	Properties           *Properties   `json:"properties,omitempty"`
-	Help                 Help          `json:"help"`
+	Id                   string         `json:"id"`
+	Name                 string         `json:"name"`
+	Kind                 string         `json:"kind,omitempty"`
+	ShortDescription     Description    `json:"shortDescription"`
+	FullDescription      Description    `json:"fullDescription"`
+	DefaultConfiguration Configuration  `json:"defaultConfiguration"`
+	Properties           *Properties    `json:"properties,omitempty"`
+	Help                 Help           `json:"help"`
+	Relationships        []Relationship `json:"relationships,omitempty"`
+}
+
+type ToolComponentReference struct {
+	Name string `json:"name"`
+}
+
+type DescriptorReference struct {
+	Id            string                 `json:"id"`
+	ToolComponent ToolComponentReference `json:"toolComponent"`
+}
+
+type Relationship struct {
+	Target DescriptorReference `json:"target"`
+	Kinds  []string            `json:"kinds"`
+}
+
+type Taxon struct {
+	Id      string `json:"id"`
+	HelpUri string `json:"helpUri,omitempty"`
+}
+
+type Taxonomy struct {
+	Name           string  `json:"name"`
+	Organization   string  `json:"organization"`
+	InformationUri string  `json:"informationUri"`
+	Taxa           []Taxon `json:"taxa"`
 }
 
 type Driver struct {
@@ -49,6 +76,7 @@ type ArtifactLocation struct {
 type PhysicalLocation struct {
 	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
 	Region           Region           `json:"region"`
+	ContextRegion    *ContextRegion   `json:"contextRegion,omitempty"`
 }
 
 type Region struct {
@@ -58,6 +86,16 @@ type Region struct {
 	EndLine     int `json:"endLine"`
 }
 
+type Snippet struct {
+	Text string `json:"text"`
+}
+
+type ContextRegion struct {
+	StartLine int     `json:"startLine"`
+	EndLine   int     `json:"endLine"`
+	Snippet   Snippet `json:"snippet"`
+}
+
 type Location struct {
 	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
 }
@@ -65,6 +103,7 @@ type Location struct {
 type PartialFingerprints struct {
 	PrimaryLocationLineHash               string `json:"primaryLocationLineHash,omitempty"`
 	PrimaryLocationStartColumnFingerprint string `json:"primaryLocationStartColumnFingerprint,omitempty"`
+	ContentFingerprint                    string `json:"bearerContentFingerprint/v1,omitempty"`
 }
 
 type Result struct {
@@ -76,8 +115,10 @@ type Result struct {
 }
 
 type Run struct {
-	Tool    Tool     `json:"tool"`
-	Results []Result `json:"results"`
+	Tool       Tool              `json:"tool"`
+	Results    []Result          `json:"results"`
+	Taxonomies []Taxonomy        `json:"taxonomies,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
 }
 
 type SarifOutput struct {
@@ -0,0 +1,94 @@
+package security
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fatih/color"
+	ignore "github.com/sabhiram/go-gitignore"
+
+	"github.com/bearer/bearer/internal/flag"
+	types "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/util/output"
+)
+
+type findingException struct {
+	flag.FindingException
+	paths *ignore.GitIgnore
+}
+
+// findingExceptions accept the risk of findings, which are then reported
+// separately and don't fail the report. Exceptions past their expiry date no
+// longer apply.
+type findingExceptions struct {
+	active  []findingException
+	expired []flag.FindingException
+}
+
+func newFindingExceptions(exceptions []flag.FindingException, now time.Time) findingExceptions {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var result findingExceptions
+	for _, exception := range exceptions {
+		if exception.Expires != "" {
+			expires, err := time.Parse(time.DateOnly, exception.Expires)
+			if err != nil || today.After(expires) {
+				result.expired = append(result.expired, exception)
+				continue
+			}
+		}
+
+		active := findingException{FindingException: exception}
+		if len(exception.Paths) != 0 {
+			active.paths = ignore.CompileIgnoreLines(exception.Paths...)
+		}
+
+		result.active = append(result.active, active)
+	}
+
+	return result
+}
+
+// match returns the exception applying to a finding of the rule in the file,
+// which is relative to the project root, given the fingerprints the finding
+// can be recorded under. The first matching exception is used.
+func (exceptions findingExceptions) match(ruleID string, filename string, fingerprints []string) *types.Exception {
+	for _, exception := range exceptions.active {
+		if exception.Fingerprint != "" {
+			if !slices.Contains(fingerprints, exception.Fingerprint) {
+				continue
+			}
+		} else if exception.Rule != ruleID || (exception.paths != nil && !exception.paths.MatchesPath(filename)) {
+			continue
+		}
+
+		return &types.Exception{
+			Owner:   exception.Owner,
+			Reason:  exception.Reason,
+			Expires: exception.Expires,
+		}
+	}
+
+	return nil
+}
+
+// expiredExceptionOutput lists the exceptions which are no longer applied, so
+// that they get reviewed
+func expiredExceptionOutput(exceptions findingExceptions) {
+	if len(exceptions.expired) == 0 {
+		return
+	}
+
+	output.StdErrLog(fmt.Sprintf("%d exceptions have expired and no longer apply:", len(exceptions.expired)))
+	for _, exception := range exceptions.expired {
+		target := exception.Rule
+		if exception.Fingerprint != "" {
+			target = exception.Fingerprint
+		}
+
+		output.StdErrLog(color.HiBlackString(
+			fmt.Sprintf("\t- %s (owner: %s, expired on %s)", target, exception.Owner, exception.Expires),
+		))
+	}
+}
@@ -0,0 +1,49 @@
+package security
+
+import (
+	ignore "github.com/sabhiram/go-gitignore"
+
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// onlyPaths restricts the findings of the report to some paths of the project.
+// No paths means findings in any file are reported.
+type onlyPaths struct {
+	paths *ignore.GitIgnore
+}
+
+func newOnlyPaths(paths []string) onlyPaths {
+	if len(paths) == 0 {
+		return onlyPaths{}
+	}
+
+	return onlyPaths{paths: ignore.CompileIgnoreLines(paths...)}
+}
+
+// includes tells whether findings in the file, which is relative to the
+// project root, are reported
+func (only onlyPaths) includes(filename string) bool {
+	return only.paths == nil || only.paths.MatchesPath(filename)
+}
+
+// onlyRules restricts the findings of the report to some rules. Unlike the
+// --only-rule scan flag, the other rules are still run. No rules means the
+// findings of every rule are reported.
+type onlyRules struct {
+	ids set.Set[string]
+}
+
+func newOnlyRules(ids []string) onlyRules {
+	if len(ids) == 0 {
+		return onlyRules{}
+	}
+
+	only := onlyRules{ids: set.New[string]()}
+	only.ids.AddAll(ids)
+
+	return only
+}
+
+func (only onlyRules) includes(ruleID string) bool {
+	return only.ids == nil || only.ids.Has(ruleID)
+}
@@ -0,0 +1,74 @@
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	types "github.com/bearer/bearer/internal/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/rego"
+)
+
+const findingPolicyQuery = "decision = data.bearer.findings"
+
+// findingPolicy runs the Rego policies of the configuration on each finding,
+// so that they can drop it, change its severity or annotate it
+type findingPolicy struct {
+	query *rego.Query
+}
+
+type findingPolicyDecision struct {
+	Drop        bool              `json:"drop"`
+	Severity    string            `json:"severity"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// newFindingPolicy prepares the policies, returning nil when there are none
+func newFindingPolicy(modules settings.Modules) (*findingPolicy, error) {
+	if len(modules) == 0 {
+		return nil, nil
+	}
+
+	query, err := rego.PrepareQuery(findingPolicyQuery, modules.ToRegoModules())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load finding policies: %w", err)
+	}
+
+	return &findingPolicy{query: query}, nil
+}
+
+// decide evaluates the policies for the finding, given its current severity
+func (policy *findingPolicy) decide(finding types.Finding, severity string) (*findingPolicyDecision, error) {
+	results, err := policy.query.Eval(types.RawFinding{Finding: &finding, Severity: severity})
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate finding policies: %w", err)
+	}
+
+	decision := &findingPolicyDecision{}
+	if len(results) == 0 {
+		return decision, nil
+	}
+
+	jsonDecision, err := json.Marshal(results[0]["decision"])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(jsonDecision, decision); err != nil {
+		return nil, fmt.Errorf("invalid finding policy decision for %s: %w", finding.Fingerprint, err)
+	}
+
+	if decision.Severity != "" && !slices.Contains(globaltypes.Severities, decision.Severity) {
+		return nil, fmt.Errorf(
+			"invalid severity '%s' from finding policy for %s, expected one of %s",
+			decision.Severity,
+			finding.Fingerprint,
+			strings.Join(globaltypes.Severities, ", "),
+		)
+	}
+
+	return decision, nil
+}
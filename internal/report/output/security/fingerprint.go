@@ -0,0 +1,109 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"regexp"
+	"strings"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/util/file"
+)
+
+type fingerprinter struct {
+	newHash func() hash.Hash
+	salt    []byte
+}
+
+func newFingerprinter(config settings.Config) fingerprinter {
+	newHash := md5.New
+	if config.Report.FingerprintHash == flag.FingerprintHashSHA256 {
+		newHash = sha256.New
+	}
+
+	return fingerprinter{newHash: newHash, salt: []byte(config.Report.FingerprintSalt)}
+}
+
+// isLegacy is true when fingerprints match those from before the hash was
+// configurable
+func (fingerprinter fingerprinter) isLegacy() bool {
+	return len(fingerprinter.salt) == 0 && fingerprinter.newHash().Size() == md5.Size
+}
+
+// fingerprint hashes the id with the configured hash function. When a salt
+// is set, a keyed (HMAC) hash is used so that fingerprints can't be
+// correlated with those of other organizations.
+func (fingerprinter fingerprinter) fingerprint(id string, index int) string {
+	var hasher hash.Hash
+	if len(fingerprinter.salt) == 0 {
+		hasher = fingerprinter.newHash()
+	} else {
+		hasher = hmac.New(fingerprinter.newHash, fingerprinter.salt)
+	}
+
+	hasher.Write([]byte(id))
+	return fmt.Sprintf("%x_%d", hasher.Sum(nil), index)
+}
+
+// Fingerprint returns the fingerprint of the given instance of a rule's
+// finding in a file, as it appears in the security report
+func Fingerprint(config settings.Config, ruleId string, filename string, index int) string {
+	return newFingerprinter(config).fingerprint(fmt.Sprintf("%s_%s", ruleId, filename), index)
+}
+
+func legacyFingerprint(id string, index int) string {
+	return fmt.Sprintf("%x_%d", md5.Sum([]byte(id)), index)
+}
+
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+// contentFingerprintId identifies a finding by the rule, the enclosing
+// structure and the matched code rather than by its filename, so that it is
+// unaffected by moving the file or by lines shifting above it
+func contentFingerprintId(ruleId string, filename string, sink types.Sink) string {
+	return fmt.Sprintf("%s_%s_%s", ruleId, structuralPath(filename, sink.Start), normalizeSnippet(sink.Content))
+}
+
+func normalizeSnippet(snippet string) string {
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(snippet, " "))
+}
+
+// structuralPath is the chain of lines enclosing the given line, found by
+// indentation. For example, the class and method declarations of a finding
+// within a method.
+func structuralPath(filename string, lineNumber int) string {
+	if lineNumber <= 1 {
+		return ""
+	}
+
+	lines, err := file.ReadFileLineRange(filename, 1, lineNumber)
+	if err != nil || len(lines) == 0 {
+		return ""
+	}
+
+	indent := indentation(lines[len(lines)-1].Extract)
+
+	var path []string
+	for i := len(lines) - 2; i >= 0 && indent > 0; i-- {
+		line := lines[i].Extract
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if lineIndent := indentation(line); lineIndent < indent {
+			path = append([]string{normalizeSnippet(line)}, path...)
+			indent = lineIndent
+		}
+	}
+
+	return strings.Join(path, " > ")
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
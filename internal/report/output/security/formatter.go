@@ -9,11 +9,16 @@ import (
 	"github.com/bearer/bearer/cmd/bearer/build"
 	"github.com/bearer/bearer/internal/commands/process/settings"
 	"github.com/bearer/bearer/internal/flag"
+	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+	"github.com/bearer/bearer/internal/report/output/defectdojo"
 	"github.com/bearer/bearer/internal/report/output/gitlab"
 	"github.com/bearer/bearer/internal/report/output/html"
 	"github.com/bearer/bearer/internal/report/output/reviewdog"
 	"github.com/bearer/bearer/internal/report/output/sarif"
+	types "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/report/output/sonarqube"
 	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
 	outputhandler "github.com/bearer/bearer/internal/util/output"
 )
 
@@ -26,10 +31,13 @@ type Formatter struct {
 }
 
 type JsonV2Output struct {
-	Source   string             `json:"source" yaml:"source"`
-	Version  string             `json:"version" yaml:"version"`
-	Findings RawFindings        `json:"findings" yaml:"findings"`
-	Expected ExpectedDetections `json:"expected_findings,omitempty" yaml:"expected_findings,omitempty"`
+	Source              string                             `json:"source" yaml:"source"`
+	Version             string                             `json:"version" yaml:"version"`
+	Findings            RawFindings                        `json:"findings" yaml:"findings"`
+	Expected            ExpectedDetections                 `json:"expected_findings,omitempty" yaml:"expected_findings,omitempty"`
+	RiskAccepted        []types.RawExceptedFinding         `json:"risk_accepted_findings,omitempty" yaml:"risk_accepted_findings,omitempty"`
+	SuppressionWarnings []dataflowtypes.SuppressionWarning `json:"suppression_warnings,omitempty" yaml:"suppression_warnings,omitempty"`
+	Metadata            map[string]string                  `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
 func NewFormatter(reportData *outputtypes.ReportData, config settings.Config, goclocResult *gocloc.Result, startTime time.Time, endTime time.Time) *Formatter {
@@ -47,13 +55,23 @@ func (f Formatter) Format(format string) (output string, err error) {
 	case flag.FormatEmpty:
 		output = BuildReportString(f.ReportData, f.Config, f.GoclocResult).String()
 	case flag.FormatSarif:
-		sarifContent, sarifErr := sarif.ReportSarif(f.ReportData.FindingsBySeverity, f.Config.Rules)
+		sarifContent, sarifErr := sarif.ReportSarif(f.ReportData.FindingsBySeverity, f.Config.Rules, f.Config.Report.Meta)
 		if sarifErr != nil {
 			return output, fmt.Errorf("error generating sarif report %s", sarifErr)
 		}
 		return outputhandler.ReportJSON(sarifContent)
 	case flag.FormatReviewDog:
-		sastContent, reviewdogErr := reviewdog.ReportReviewdog(f.ReportData.FindingsBySeverity)
+		summaryURL := f.Config.Report.AnnotationSummaryURL
+		if summaryURL == "" {
+			summaryURL = reviewdog.CIRunURL()
+		}
+
+		sastContent, reviewdogErr := reviewdog.ReportReviewdog(
+			f.ReportData.FindingsBySeverity,
+			f.Config.Report.AnnotationLimit,
+			summaryURL,
+			f.ReportData.GateFailure,
+		)
 		if reviewdogErr != nil {
 			return output, fmt.Errorf("error generating reviewdog report %s", reviewdogErr)
 		}
@@ -64,25 +82,57 @@ func (f Formatter) Format(format string) (output string, err error) {
 			return output, fmt.Errorf("error generating gitlab-sast report %s", sastErr)
 		}
 		return outputhandler.ReportJSON(sastContent)
+	case flag.FormatSonarQube:
+		sonarQubeContent, sonarQubeErr := sonarqube.ReportSonarQube(f.ReportData.FindingsBySeverity)
+		if sonarQubeErr != nil {
+			return output, fmt.Errorf("error generating sonarqube report %s", sonarQubeErr)
+		}
+		return outputhandler.ReportJSON(sonarQubeContent)
+	case flag.FormatDefectDojo:
+		defectDojoContent, defectDojoErr := defectdojo.ReportDefectDojo(f.ReportData.FindingsBySeverity)
+		if defectDojoErr != nil {
+			return output, fmt.Errorf("error generating defectdojo report %s", defectDojoErr)
+		}
+		return outputhandler.ReportJSON(defectDojoContent)
 	case flag.FormatJSON:
+		if f.Config.Report.GroupBy != "" {
+			return outputhandler.ReportJSON(groupReportFindings(f.ReportData, f.Config))
+		}
 		return outputhandler.ReportJSON(f.ReportData.FindingsBySeverity)
 	case flag.FormatJSONV2:
+		var suppressionWarnings []dataflowtypes.SuppressionWarning
+		if f.ReportData.Dataflow != nil {
+			suppressionWarnings = f.ReportData.Dataflow.SuppressionWarnings
+		}
+
 		return outputhandler.ReportJSON(JsonV2Output{
-			Source:   "Bearer",
-			Version:  build.Version,
-			Findings: f.ReportData.RawFindings,
-			Expected: f.ReportData.ExpectedDetections,
+			Source:              "Bearer",
+			Version:             build.Version,
+			Findings:            f.ReportData.RawFindings,
+			Expected:            f.ReportData.ExpectedDetections,
+			RiskAccepted:        rawExceptedFindings(f.ReportData.ExceptedFindingsBySeverity),
+			SuppressionWarnings: suppressionWarnings,
+			Metadata:            f.Config.Report.Meta,
 		})
 	case flag.FormatYAML:
+		if f.Config.Report.GroupBy != "" {
+			return outputhandler.ReportYAML(groupReportFindings(f.ReportData, f.Config))
+		}
 		return outputhandler.ReportYAML(f.ReportData.FindingsBySeverity)
 	case flag.FormatHTML:
 		title := "Security Report"
-		body, securityErr := html.ReportSecurityHTML(f.ReportData.FindingsBySeverity)
+		var body *string
+		var securityErr error
+		if f.Config.Report.GroupBy != "" {
+			body, securityErr = html.ReportSecurityGroupedHTML(groupReportFindings(f.ReportData, f.Config))
+		} else {
+			body, securityErr = html.ReportSecurityHTML(f.ReportData.FindingsBySeverity)
+		}
 		if securityErr != nil {
 			return output, securityErr
 		}
 
-		output, err = html.ReportHTMLWrapper(title, body)
+		output, err = html.ReportHTMLWrapper(title, body, f.Config.Report.Meta)
 		if err != nil {
 			err = fmt.Errorf("could not generate html page %s", err)
 		}
@@ -90,3 +140,14 @@ func (f Formatter) Format(format string) (output string, err error) {
 
 	return output, err
 }
+
+func rawExceptedFindings(exceptedFindings ExceptedFindings) []types.RawExceptedFinding {
+	var result []types.RawExceptedFinding
+	for _, severity := range globaltypes.Severities {
+		for _, finding := range exceptedFindings[severity] {
+			result = append(result, finding.ToRawExceptedFinding(severity))
+		}
+	}
+
+	return result
+}
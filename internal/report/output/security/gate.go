@@ -0,0 +1,55 @@
+package security
+
+import (
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	types "github.com/bearer/bearer/internal/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
+)
+
+// BuildGateFailure lists the findings which fail the scan, most severe first
+func BuildGateFailure(findingsBySeverity map[string][]types.Finding, config settings.Config) *types.GateFailure {
+	gateFailure := &types.GateFailure{
+		DiffScan: config.Scan.Diff,
+		Findings: []types.GateFinding{},
+	}
+
+	for _, severity := range globaltypes.Severities {
+		if config.Report.FailOnSeverity.Has(severity) {
+			gateFailure.FailOnSeverity = append(gateFailure.FailOnSeverity, severity)
+		}
+	}
+
+	if len(config.Report.GatesMinConfidence) != 0 {
+		gateFailure.MinimumConfidence = config.Report.GatesMinConfidence
+	}
+
+	for _, severity := range globaltypes.Severities {
+		for _, finding := range findingsBySeverity[severity] {
+			confidence := findingConfidence(finding)
+			if !failsGate(config, severity, confidence) {
+				continue
+			}
+
+			gateFailure.Findings = append(gateFailure.Findings, types.GateFinding{
+				Severity:    severity,
+				Confidence:  confidence,
+				RuleID:      finding.Rule.Id,
+				Title:       finding.Rule.Title,
+				Filename:    finding.Filename,
+				LineNumber:  finding.LineNumber,
+				Fingerprint: ignoreFingerprint(finding),
+			})
+		}
+	}
+
+	return gateFailure
+}
+
+// ignoreFingerprint is the fingerprint suggested when ignoring a finding
+func ignoreFingerprint(finding types.Finding) string {
+	if finding.ContentFingerprint != "" {
+		return finding.ContentFingerprint
+	}
+
+	return finding.Fingerprint
+}
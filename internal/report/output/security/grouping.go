@@ -0,0 +1,117 @@
+package security
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/output/security/types"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/codeowners"
+	"github.com/bearer/bearer/internal/util/workspaces"
+)
+
+const (
+	noDataTypeGroup = "(no data type)"
+	unownedGroup    = "(unowned)"
+	noWorkspace     = "(no workspace)"
+)
+
+func groupReportFindings(reportData *outputtypes.ReportData, config settings.Config) []types.FindingGroup {
+	codeOwners := &codeowners.CodeOwners{}
+	if config.Report.GroupBy == flag.GroupByOwner {
+		var err error
+		codeOwners, err = codeowners.Load(config.Scan.Target)
+		if err != nil {
+			log.Debug().Msgf("failed to load CODEOWNERS: %s", err)
+			codeOwners = &codeowners.CodeOwners{}
+		}
+	}
+
+	return GroupFindings(reportData.FindingsBySeverity, config.Report.GroupBy, codeOwners)
+}
+
+// loadWorkspaces finds the Go modules and JavaScript workspaces of the project,
+// so that each finding can be attributed to the service that owns it
+func loadWorkspaces(config settings.Config) *workspaces.Workspaces {
+	result, err := workspaces.Load(config.Scan.Target)
+	if err != nil {
+		log.Debug().Msgf("failed to load workspaces: %s", err)
+		return nil
+	}
+
+	return result
+}
+
+// GroupFindings restructures findings into groups by the given key. Groups
+// are ordered by size, largest first, so that the rules or files accounting
+// for most findings are seen first. Within each group, findings keep their
+// severity order.
+func GroupFindings(
+	findingsBySeverity map[string][]types.Finding,
+	groupBy string,
+	codeOwners *codeowners.CodeOwners,
+) []types.FindingGroup {
+	groups := make(map[string]*types.FindingGroup)
+
+	for _, severity := range globaltypes.Severities {
+		for i := range findingsBySeverity[severity] {
+			finding := findingsBySeverity[severity][i]
+			name := groupName(finding, groupBy, codeOwners)
+
+			group, ok := groups[name]
+			if !ok {
+				group = &types.FindingGroup{Name: name}
+				groups[name] = group
+			}
+
+			group.Findings = append(group.Findings, types.RawFinding{Finding: &finding, Severity: severity})
+		}
+	}
+
+	result := make([]types.FindingGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Findings) != len(result[j].Findings) {
+			return len(result[i].Findings) > len(result[j].Findings)
+		}
+
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+func groupName(finding types.Finding, groupBy string, codeOwners *codeowners.CodeOwners) string {
+	switch groupBy {
+	case flag.GroupByRule:
+		return finding.Rule.Id
+	case flag.GroupByFile:
+		return finding.Filename
+	case flag.GroupByDataType:
+		if finding.DataType == nil || finding.DataType.Name == "" {
+			return noDataTypeGroup
+		}
+		return finding.DataType.Name
+	case flag.GroupByOwner:
+		owners := codeOwners.Owners(finding.Filename)
+		if len(owners) == 0 {
+			return unownedGroup
+		}
+		return strings.Join(owners, " ")
+	case flag.GroupByWorkspace:
+		if finding.Workspace == "" {
+			return noWorkspace
+		}
+		return finding.Workspace
+	}
+
+	return ""
+}
@@ -0,0 +1,93 @@
+package security
+
+import (
+	"slices"
+
+	ignore "github.com/sabhiram/go-gitignore"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+)
+
+type pathOverride struct {
+	paths     *ignore.GitIgnore
+	skipRule  []string
+	overrides map[string]flag.RuleOverride
+}
+
+// pathOverrides skips or re-tunes rules for the findings within some paths of
+// the project, eg. tests or generated code
+type pathOverrides []pathOverride
+
+func newPathOverrides(overrides []flag.PathOverride) pathOverrides {
+	result := make(pathOverrides, len(overrides))
+	for i, override := range overrides {
+		result[i] = pathOverride{
+			paths:     ignore.CompileIgnoreLines(override.Paths...),
+			skipRule:  override.SkipRule,
+			overrides: override.Overrides,
+		}
+	}
+
+	return result
+}
+
+// skips tells whether findings of the rule are skipped in the file, which is
+// relative to the project root
+func (overrides pathOverrides) skips(ruleID string, filename string) bool {
+	for _, override := range overrides {
+		if slices.Contains(override.skipRule, ruleID) && override.paths.MatchesPath(filename) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// severity returns the severity of the rule for findings in the file, which is
+// the given one unless overridden for the file. Later overrides take
+// precedence.
+func (overrides pathOverrides) severity(ruleID string, filename string, severity string) string {
+	for _, override := range overrides {
+		ruleOverride, ok := override.overrides[ruleID]
+		if ok && ruleOverride.Severity != "" && override.paths.MatchesPath(filename) {
+			severity = ruleOverride.Severity
+		}
+	}
+
+	return severity
+}
+
+// rulePaths restricts the findings of a rule to the files given in its
+// definition
+type rulePaths struct {
+	include *ignore.GitIgnore
+	exclude *ignore.GitIgnore
+}
+
+func newRulePaths(paths *settings.RulePaths) rulePaths {
+	var result rulePaths
+	if paths == nil {
+		return result
+	}
+
+	if len(paths.Include) != 0 {
+		result.include = ignore.CompileIgnoreLines(paths.Include...)
+	}
+
+	if len(paths.Exclude) != 0 {
+		result.exclude = ignore.CompileIgnoreLines(paths.Exclude...)
+	}
+
+	return result
+}
+
+// includes tells whether findings of the rule in the file, which is relative to
+// the project root, are reported
+func (paths rulePaths) includes(filename string) bool {
+	if paths.include != nil && !paths.include.MatchesPath(filename) {
+		return false
+	}
+
+	return paths.exclude == nil || !paths.exclude.MatchesPath(filename)
+}
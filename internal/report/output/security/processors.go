@@ -0,0 +1,79 @@
+package security
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bearer/bearer/internal/report/output/billofdata"
+	types "github.com/bearer/bearer/internal/report/output/security/types"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+	"github.com/bearer/bearer/internal/util/output"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// FindProcessorFlows returns the third parties of the given categories which
+// are found in the same files as classified data, along with that data
+func FindProcessorFlows(dataflow *outputtypes.DataFlow, categories []string) ([]types.ProcessorFlow, error) {
+	if len(categories) == 0 {
+		return nil, nil
+	}
+
+	billOfData, err := billofdata.ReportBillOfData(dataflow)
+	if err != nil {
+		return nil, err
+	}
+
+	dataTypes := make(map[string]set.Set[string])
+	files := make(map[string]set.Set[string])
+	flows := make(map[string]types.ProcessorFlow)
+
+	for _, subject := range billOfData.DataSubjects {
+		for _, component := range subject.Components {
+			if !slices.Contains(categories, component.Category) {
+				continue
+			}
+
+			if _, ok := flows[component.Name]; !ok {
+				flows[component.Name] = types.ProcessorFlow{ThirdParty: component.Name, Category: component.Category}
+				dataTypes[component.Name] = set.New[string]()
+				files[component.Name] = set.New[string]()
+			}
+
+			for _, dataType := range subject.DataTypes {
+				dataTypes[component.Name].Add(dataType.Name)
+			}
+			files[component.Name].AddAll(component.Files)
+		}
+	}
+
+	result := make([]types.ProcessorFlow, 0, len(flows))
+	for _, name := range maputil.SortedStringKeys(flows) {
+		flow := flows[name]
+		flow.DataTypes = sortedItems(dataTypes[name])
+		flow.Files = sortedItems(files[name])
+		result = append(result, flow)
+	}
+
+	return result, nil
+}
+
+func processorFlowOutput(flows []types.ProcessorFlow) {
+	output.StdErrLog("\nClassified data was found alongside third parties in a failing processor category:")
+	for _, flow := range flows {
+		output.StdErrLog(fmt.Sprintf(
+			"  - %s (%s): %s in %s",
+			flow.ThirdParty,
+			flow.Category,
+			strings.Join(flow.DataTypes, ", "),
+			strings.Join(flow.Files, ", "),
+		))
+	}
+}
+
+func sortedItems(values set.Set[string]) []string {
+	items := values.Items()
+	slices.Sort(items)
+	return items
+}
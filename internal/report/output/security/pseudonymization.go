@@ -0,0 +1,114 @@
+package security
+
+import (
+	"strings"
+
+	types "github.com/bearer/bearer/internal/report/output/security/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/file"
+)
+
+// pseudonymizedBy returns the pseudonymization function the data of a finding
+// is passed to before it reaches the sink, eg. `hexdigest` in
+// `Rails.logger.info(Digest::SHA256.hexdigest(user.email))`. The calls open at
+// the position of the data are checked, from the start of the sink.
+func pseudonymizedBy(filename string, source types.Source, sink types.Sink, functions []string) string {
+	if len(functions) == 0 || source.Location == nil || source.Start == 0 || source.Column.Start == 0 {
+		return ""
+	}
+
+	startLine := source.Start
+	if sink.Location != nil && sink.Start > 0 && sink.Start < startLine {
+		startLine = sink.Start
+	}
+
+	lines, err := file.ReadFileLineRange(filename, startLine, source.Start)
+	if err != nil {
+		return ""
+	}
+
+	code := &strings.Builder{}
+	for _, line := range lines {
+		if line.LineNumber != source.Start {
+			code.WriteString(line.Extract + "\n")
+			continue
+		}
+
+		code.WriteString(line.Extract[:min(source.Column.Start-1, len(line.Extract))])
+	}
+
+	return enclosingFunction(code.String(), functions)
+}
+
+// enclosingFunction returns the first of the functions called by a call which
+// is still open at the end of the code, innermost first
+func enclosingFunction(code string, functions []string) string {
+	depth := 0
+	for i := len(code) - 1; i >= 0; i-- {
+		switch code[i] {
+		case ')':
+			depth++
+		case '(':
+			if depth != 0 {
+				depth--
+				continue
+			}
+
+			callee := calleeBefore(code[:i])
+			for _, function := range functions {
+				if matchesFunction(callee, function) {
+					return function
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+func calleeBefore(code string) string {
+	end := len(strings.TrimRight(code, " \t"))
+	start := end
+	for start > 0 && isCalleeChar(code[start-1]) {
+		start--
+	}
+
+	return code[start:end]
+}
+
+func isCalleeChar(char byte) bool {
+	return char >= 'a' && char <= 'z' ||
+		char >= 'A' && char <= 'Z' ||
+		char >= '0' && char <= '9' ||
+		strings.IndexByte("_.:$\\->", char) != -1
+}
+
+// matchesFunction reports whether a callee is the function, either by its
+// full name or by its name within a receiver, module or namespace
+func matchesFunction(callee string, function string) bool {
+	if callee == function {
+		return true
+	}
+
+	for _, separator := range []string{".", "::", "->", "\\"} {
+		if strings.HasSuffix(callee, separator+function) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pseudonymizedSeverity no longer weights the severity of a finding for the
+// sensitivity of its data, as that data was pseudonymized
+func pseudonymizedSeverity(severityMeta types.SeverityMeta) types.SeverityMeta {
+	if severityMeta.DisplaySeverity == globaltypes.LevelWarning {
+		return severityMeta
+	}
+
+	severityMeta.SensitiveDataCategoryWeighting = 0
+	severityMeta.FinalWeighting = severityMeta.RuleSeverityWeighting
+	severityMeta.DisplaySeverity = weightedSeverity(severityMeta.FinalWeighting)
+
+	return severityMeta
+}
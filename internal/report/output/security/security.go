@@ -1,12 +1,12 @@
 package security
 
 import (
-	"crypto/md5"
 	"encoding/json"
 	"fmt"
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"golang.org/x/exp/maps"
 
@@ -17,7 +17,9 @@ import (
 
 	"github.com/bearer/bearer/internal/classification/db"
 	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/detectors/gitleaks"
 	"github.com/bearer/bearer/internal/report/basebranchfindings"
+	"github.com/bearer/bearer/internal/report/facts"
 	globaltypes "github.com/bearer/bearer/internal/types"
 	"github.com/bearer/bearer/internal/util/file"
 	ignoretypes "github.com/bearer/bearer/internal/util/ignore/types"
@@ -46,6 +48,7 @@ type ExpectedDetections = []types.ExpectedDetection
 type RawFindings = []types.RawFinding
 type Findings = map[string][]types.Finding
 type IgnoredFindings = map[string][]types.IgnoredFinding
+type ExceptedFindings = map[string][]types.ExceptedFinding
 
 type Input struct {
 	RuleId         string                `json:"rule_id" yaml:"rule_id"`
@@ -70,6 +73,7 @@ type Output struct {
 	DataType        *types.DataType `json:"data_type,omitempty" yaml:"data_type,omitempty"`
 	Severity        string          `json:"severity,omitempty" yaml:"severity,omitempty"`
 	DetailedContext string          `json:"detailed_context,omitempty" yaml:"detailed_context,omitempty"`
+	Confidence      string          `json:"confidence,omitempty" yaml:"confidence,omitempty"`
 }
 
 func AddReportData(
@@ -80,8 +84,10 @@ func AddReportData(
 ) error {
 	summaryFindings := make(Findings)
 	ignoredSummaryFindings := make(IgnoredFindings)
+	exceptedSummaryFindings := make(ExceptedFindings)
 	reportData.FindingsBySeverity = summaryFindings
 	reportData.IgnoredFindingsBySeverity = ignoredSummaryFindings
+	reportData.ExceptedFindingsBySeverity = exceptedSummaryFindings
 
 	if !hasFiles {
 		return nil
@@ -92,11 +98,13 @@ func AddReportData(
 		output.StdErrLog("Evaluating rules")
 	}
 
-	builtInFingerprints, builtInFailed, err := evaluateRules(summaryFindings, ignoredSummaryFindings, config.BuiltInRules, config, dataflow, baseBranchFindings, true)
+	exceptions := newFindingExceptions(config.Report.Exceptions, time.Now())
+
+	builtInFingerprints, builtInFailed, err := evaluateRules(summaryFindings, ignoredSummaryFindings, exceptedSummaryFindings, config.BuiltInRules, config, exceptions, dataflow, baseBranchFindings, reportData.FindingStream, true)
 	if err != nil {
 		return err
 	}
-	fingerprints, failed, err := evaluateRules(summaryFindings, ignoredSummaryFindings, config.Rules, config, dataflow, baseBranchFindings, false)
+	fingerprints, failed, err := evaluateRules(summaryFindings, ignoredSummaryFindings, exceptedSummaryFindings, config.Rules, config, exceptions, dataflow, baseBranchFindings, reportData.FindingStream, false)
 	if err != nil {
 		return err
 	}
@@ -132,23 +140,40 @@ func AddReportData(
 			config.StaleIgnoredFingerprintIds,
 			config.Scan.Diff,
 		)
+		expiredExceptionOutput(exceptions)
 	}
 
-	reportData.ReportFailed = builtInFailed || failed
+	processorFlows, err := FindProcessorFlows(dataflow, config.Report.FailOnProcessors)
+	if err != nil {
+		return err
+	}
+	if len(processorFlows) != 0 {
+		processorFlowOutput(processorFlows)
+	}
+
+	if builtInFailed || failed {
+		reportData.GateFailure = BuildGateFailure(summaryFindings, config)
+	}
+
+	reportData.ReportFailed = builtInFailed || failed || len(processorFlows) != 0
 	return nil
 }
 
 func evaluateRules(
 	summaryFindings Findings,
 	ignoredSummaryFindings IgnoredFindings,
+	exceptedSummaryFindings ExceptedFindings,
 	rules map[string]*settings.Rule,
 	config settings.Config,
+	exceptions findingExceptions,
 	dataflow *outputtypes.DataFlow,
 	baseBranchFindings *basebranchfindings.Findings,
+	findingStream outputtypes.FindingStream,
 	builtIn bool,
 ) ([]string, bool, error) {
 	outputFindings := map[string][]types.Finding{}
 	ignoredOutputFindings := map[string][]types.IgnoredFinding{}
+	exceptedOutputFindings := map[string][]types.ExceptedFinding{}
 
 	var bar *progressbar.ProgressBar
 	if !builtIn {
@@ -157,6 +182,21 @@ func evaluateRules(
 
 	var fingerprints []string
 	failed := false
+	onlyPaths := newOnlyPaths(config.Report.OnlyPath)
+	onlyRules := newOnlyRules(config.Report.OnlyReportRule)
+	fingerprinter := newFingerprinter(config)
+	pathOverrides := newPathOverrides(config.PathOverrides)
+	findingPolicy, err := newFindingPolicy(config.FindingPolicies)
+	if err != nil {
+		return fingerprints, false, err
+	}
+
+	scanDB, err := db.DefaultForScan(config.Scan)
+	if err != nil {
+		return fingerprints, false, err
+	}
+	workspaces := loadWorkspaces(config)
+	projectFacts := facts.New(dataflow.Dependencies)
 
 	for _, rule := range maputil.ToSortedSlice(rules) {
 		if !builtIn {
@@ -170,6 +210,11 @@ func evaluateRules(
 			continue
 		}
 
+		// rules are run even when the project doesn't meet their requirements,
+		// so that the ignores of their findings are still recognised
+		preconditions := rule.Preconditions()
+		rulePaths := newRulePaths(rule.Paths)
+
 		policy := config.Policies[rule.Type]
 		// Create a prepared query that can be evaluated.
 		rs, err := rego.RunQuery(policy.Query,
@@ -177,7 +222,7 @@ func evaluateRules(
 				RuleId:         rule.Id,
 				Rule:           rule,
 				Dataflow:       dataflow,
-				DataCategories: db.DefaultWithContext(config.Scan.Context).DataCategories,
+				DataCategories: scanDB.DataCategories,
 			},
 			// TODO: perf question: can we do this once?
 			policy.Modules.ToRegoModules())
@@ -202,10 +247,22 @@ func evaluateRules(
 				Description:      rule.RemediationMessage,
 				Id:               rule.Id,
 				CWEIDs:           rule.CWEIDs,
+				OWASP:            rule.OWASP,
 				DocumentationUrl: rule.DocumentationUrl,
+				Confidence:       rule.Confidence,
+				Version:          rule.Version,
+			}
+			if rule.Fix != nil {
+				ruleSummary.Fix = &types.Fix{
+					Description: rule.Fix.Description,
+					Replace:     rule.Fix.Replace,
+					With:        rule.Fix.With,
+				}
 			}
 
+			ruleFindings := map[string][]types.Finding{}
 			instanceCount := make(map[string]int)
+			contentInstanceCount := make(map[string]int)
 			policyFailures := results["policy_failure"]
 			sortByLineNumber(policyFailures)
 
@@ -220,62 +277,226 @@ func evaluateRules(
 
 				fingerprintId := fmt.Sprintf("%s_%s", rule.Id, output.Filename)
 				oldFingerprintId := fmt.Sprintf("%s_%s", rule.Id, output.FullFilename)
-				fingerprint := fmt.Sprintf("%x_%d", md5.Sum([]byte(fingerprintId)), instanceID)
-				oldFingerprint := fmt.Sprintf("%x_%d", md5.Sum([]byte(oldFingerprintId)), i)
+				fingerprint := fingerprinter.fingerprint(fingerprintId, instanceID)
+				oldFingerprint := fingerprinter.fingerprint(oldFingerprintId, i)
+
+				contentId := contentFingerprintId(rule.Id, output.FullFilename, output.Sink)
+				contentIndex := contentInstanceCount[contentId]
+				contentFingerprint := fingerprinter.fingerprint(contentId, contentIndex)
+				contentInstanceCount[contentId]++
+
+				fingerprints = append(fingerprints, fingerprint, contentFingerprint)
+
+				// ignores recorded against deprecated rules apply to the rule replacing them
+				var replacedFingerprints []string
+				for _, replacedID := range rule.Replaces {
+					replacedFingerprints = append(
+						replacedFingerprints,
+						fingerprinter.fingerprint(fmt.Sprintf("%s_%s", replacedID, output.Filename), instanceID),
+						fingerprinter.fingerprint(contentFingerprintId(replacedID, output.FullFilename, output.Sink), contentIndex),
+					)
+				}
+				fingerprints = append(fingerprints, replacedFingerprints...)
+
+				// allow existing ignores to keep matching after changing the fingerprint hash
+				var compatibleFingerprint string
+				if config.Report.FingerprintCompatibility && !fingerprinter.isLegacy() {
+					compatibleFingerprint = legacyFingerprint(fingerprintId, instanceID)
+					fingerprints = append(fingerprints, compatibleFingerprint)
+				}
+
+				// findings in renamed files keep matching ignores recorded against the previous filename
+				var previousFingerprint string
+				if baseBranchFindings != nil {
+					if previousFilename := baseBranchFindings.PreviousFilename(output.Filename); previousFilename != "" {
+						previousFingerprintId := fmt.Sprintf("%s_%s", rule.Id, previousFilename)
+						previousFingerprint = fingerprinter.fingerprint(previousFingerprintId, instanceID)
+						fingerprints = append(fingerprints, previousFingerprint)
+					}
+				}
+
+				// filtered out findings keep their fingerprints, so that their ignores
+				// aren't reported as no longer detected
+				if !onlyRules.includes(rule.Id) || !onlyPaths.includes(output.Filename) {
+					continue
+				}
+
+				if !projectFacts.SatisfyFor(output.Filename, preconditions) {
+					continue
+				}
+
+				if pathOverrides.skips(rule.Id, output.Filename) || !rulePaths.includes(output.Filename) {
+					continue
+				}
 
-				fingerprints = append(fingerprints, fingerprint)
 				rawCodeExtract := codeExtract(output.FullFilename, output.Source, output.Sink)
 				codeExtract := getExtract(rawCodeExtract)
 
+				findingRule := ruleSummary
+				// the confidence of a finding can differ from the one of its rule
+				if output.Confidence != "" {
+					findingRuleCopy := *ruleSummary
+					findingRuleCopy.Confidence = output.Confidence
+					findingRule = &findingRuleCopy
+				}
+
 				finding := types.Finding{
-					Rule:             ruleSummary,
-					FullFilename:     output.FullFilename,
-					Filename:         output.Filename,
-					LineNumber:       output.LineNumber,
-					CategoryGroups:   output.CategoryGroups,
-					DataType:         output.DataType,
-					Source:           output.Source,
-					Sink:             output.Sink,
-					ParentLineNumber: output.Sink.Start,
-					ParentContent:    output.Sink.Content,
-					DetailedContext:  output.DetailedContext,
-					CodeExtract:      codeExtract,
-					RawCodeExtract:   rawCodeExtract,
-					Fingerprint:      fingerprint,
-					OldFingerprint:   oldFingerprint,
+					Rule:                findingRule,
+					FullFilename:        output.FullFilename,
+					Filename:            output.Filename,
+					Workspace:           workspaces.Workspace(output.Filename),
+					LineNumber:          output.LineNumber,
+					CategoryGroups:      output.CategoryGroups,
+					DataType:            output.DataType,
+					Source:              output.Source,
+					Sink:                output.Sink,
+					ParentLineNumber:    output.Sink.Start,
+					ParentContent:       output.Sink.Content,
+					DetailedContext:     output.DetailedContext,
+					CodeExtract:         codeExtract,
+					RawCodeExtract:      rawCodeExtract,
+					CodeContext:         codeContext(output.FullFilename, output.Sink, config.Report.ContextLines),
+					Fingerprint:         fingerprint,
+					OldFingerprint:      oldFingerprint,
+					PreviousFingerprint: previousFingerprint,
+					ContentFingerprint:  contentFingerprint,
 				}
 
 				ignoredFingerprint, ignored := config.IgnoredFingerprints[fingerprint]
+				if !ignored {
+					ignoredFingerprint, ignored = config.IgnoredFingerprints[contentFingerprint]
+				}
+				if !ignored && previousFingerprint != "" {
+					ignoredFingerprint, ignored = config.IgnoredFingerprints[previousFingerprint]
+				}
+				if !ignored && compatibleFingerprint != "" {
+					ignoredFingerprint, ignored = config.IgnoredFingerprints[compatibleFingerprint]
+				}
+				for _, replacedFingerprint := range replacedFingerprints {
+					if ignored {
+						break
+					}
+					ignoredFingerprint, ignored = config.IgnoredFingerprints[replacedFingerprint]
+				}
 				if !ignored && !config.CloudIgnoresUsed {
 					// check for legacy excluded fingerprint
 					ignored = config.Report.ExcludeFingerprint[fingerprint]
 				}
 
-				severityMeta := CalculateSeverity(finding.CategoryGroups, rule.GetSeverity(), output.IsLocal != nil && *output.IsLocal)
+				var exception *types.Exception
+				if !ignored {
+					exceptionFingerprints := append(
+						[]string{fingerprint, contentFingerprint, previousFingerprint, compatibleFingerprint},
+						replacedFingerprints...,
+					)
+					exception = exceptions.match(rule.Id, output.Filename, exceptionFingerprints)
+				}
+
+				severityMeta := CalculateSeverity(
+					finding.CategoryGroups,
+					pathOverrides.severity(rule.Id, output.Filename, rule.GetSeverity()),
+					output.IsLocal != nil && *output.IsLocal,
+				)
+				if function := pseudonymizedBy(output.FullFilename, output.Source, output.Sink, config.Report.Pseudonymizers); function != "" {
+					finding.Mitigation = &types.Mitigation{Type: types.MitigationPseudonymized, Function: function}
+					severityMeta = pseudonymizedSeverity(severityMeta)
+				}
 				severity := severityMeta.DisplaySeverity
 
+				if findingPolicy != nil {
+					decision, err := findingPolicy.decide(finding, severity)
+					if err != nil {
+						return fingerprints, false, err
+					}
+
+					if decision.Drop {
+						continue
+					}
+
+					if decision.Severity != "" {
+						severityMeta.DisplaySeverity = decision.Severity
+						severity = decision.Severity
+					}
+					finding.Annotations = decision.Annotations
+				}
+
 				if config.Report.Severity.Has(severity) {
 					finding.SeverityMeta = severityMeta
 					if ignored {
 						ignoredOutputFindings[severity] = append(ignoredOutputFindings[severity], types.IgnoredFinding{Finding: finding, IgnoreMeta: ignoredFingerprint})
+					} else if exception != nil {
+						exceptedOutputFindings[severity] = append(exceptedOutputFindings[severity], types.ExceptedFinding{Finding: finding, Exception: *exception})
 					} else {
-						outputFindings[severity] = append(outputFindings[severity], finding)
+						ruleFindings[severity] = append(ruleFindings[severity], finding)
 
-						if config.Report.FailOnSeverity.Has(severity) {
+						if failsGate(config, severity, findingConfidence(finding)) {
 							failed = true
 						}
 					}
 				}
 			}
+
+			if findingStream != nil {
+				if err := streamFindings(findingStream, ruleFindings); err != nil {
+					return fingerprints, false, err
+				}
+			}
+
+			for severity, findings := range ruleFindings {
+				outputFindings[severity] = append(outputFindings[severity], findings...)
+			}
 		}
 	}
 
 	sortFindingsBySeverity(summaryFindings, outputFindings)
 	sortFindingsBySeverity(ignoredSummaryFindings, ignoredOutputFindings)
+	sortFindingsBySeverity(exceptedSummaryFindings, exceptedOutputFindings)
 
 	return fingerprints, failed, nil
 }
 
+// failsGate reports whether a finding should fail the report, taking into
+// account any minimum confidence configured for its severity
+func failsGate(config settings.Config, severity string, confidence string) bool {
+	if !config.Report.FailOnSeverity.Has(severity) {
+		return false
+	}
+
+	minimumConfidence, hasMinimum := config.Report.GatesMinConfidence[severity]
+	if !hasMinimum {
+		return true
+	}
+
+	return globaltypes.ConfidenceAtLeast(confidence, minimumConfidence)
+}
+
+// findingConfidence is the confidence of a finding, high unless set by the
+// rule or the match
+func findingConfidence(finding types.Finding) string {
+	if finding.Rule.Confidence != "" {
+		return finding.Rule.Confidence
+	}
+
+	return globaltypes.ConfidenceHigh
+}
+
+func streamFindings(findingStream outputtypes.FindingStream, ruleFindings map[string][]types.Finding) error {
+	ruleFindings = removeDuplicates(ruleFindings)
+
+	for _, severity := range globaltypes.Severities {
+		findings := ruleFindings[severity]
+		sortFindings(findings)
+
+		for _, finding := range findings {
+			if err := findingStream(finding.ToRawFinding(severity)); err != nil {
+				return fmt.Errorf("error streaming finding %s", err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func sortFindingsBySeverity[F types.GenericFinding](findingsBySeverity map[string][]F, outputFindings map[string][]F) {
 	outputFindings = removeDuplicates(outputFindings)
 
@@ -405,6 +626,10 @@ func BuildReportString(reportData *outputtypes.ReportData, config settings.Confi
 	reportStr.WriteString("\n\nSecurity Report\n")
 	reportStr.WriteString("\n=====================================")
 
+	for _, key := range maputil.SortedStringKeys(config.Report.Meta) {
+		reportStr.WriteString(fmt.Sprintf("\n%s: %s", key, config.Report.Meta[key]))
+	}
+
 	initialColorSetting := color.NoColor
 	if config.NoColor && !initialColorSetting {
 		color.NoColor = true
@@ -433,13 +658,28 @@ func BuildReportString(reportData *outputtypes.ReportData, config settings.Confi
 
 	for _, severityLevel := range globaltypes.Severities {
 		for _, failure := range reportData.FindingsBySeverity[severityLevel] {
-			for i := 0; i < len(failure.CWEIDs); i++ {
-				failures[severityLevel]["CWE-"+failure.CWEIDs[i]] = true
+			for _, mapping := range failure.Rule.Mappings() {
+				failures[severityLevel][mapping] = true
+			}
+			if config.Report.GroupBy == "" {
+				writeFailureToString(reportStr, failure, severityLevel)
+			}
+		}
+	}
+
+	if config.Report.GroupBy != "" {
+		for _, group := range groupReportFindings(reportData, config) {
+			reportStr.WriteString(color.New(color.Bold).Sprintf("\n\n%s (%d findings)", group.Name, len(group.Findings)))
+			reportStr.WriteString("\n-------------------------------------")
+			for _, finding := range group.Findings {
+				writeFailureToString(reportStr, *finding.Finding, finding.Severity)
 			}
-			writeFailureToString(reportStr, failure, severityLevel)
 		}
 	}
 
+	writeSuppressionWarningsToString(reportStr, reportData.Dataflow.SuppressionWarnings)
+	writeExceptedFindingsToString(reportStr, reportData.ExceptedFindingsBySeverity)
+
 	if !reportData.ReportFailed {
 		reportStr.WriteString("\nNeed to add your own custom rule? Check out the guide: https://docs.bearer.com/guides/custom-rule\n")
 	}
@@ -499,18 +739,7 @@ func CalculateSeverity(groups []string, severity string, hasLocalDataTypes bool)
 		triggerWeighting = 2
 	}
 
-	var displaySeverity string
 	finalWeighting := ruleSeverityWeighting + (sensitiveDataCategoryWeighting * triggerWeighting)
-	switch {
-	case finalWeighting >= 8:
-		displaySeverity = globaltypes.LevelCritical
-	case finalWeighting >= 5:
-		displaySeverity = globaltypes.LevelHigh
-	case finalWeighting >= 3:
-		displaySeverity = globaltypes.LevelMedium
-	default:
-		displaySeverity = globaltypes.LevelLow
-	}
 
 	return types.SeverityMeta{
 		RuleSeverity:                   severity,
@@ -519,7 +748,20 @@ func CalculateSeverity(groups []string, severity string, hasLocalDataTypes bool)
 		RuleSeverityWeighting:          ruleSeverityWeighting,
 		SensitiveDataCategoryWeighting: sensitiveDataCategoryWeighting,
 		FinalWeighting:                 finalWeighting,
-		DisplaySeverity:                displaySeverity,
+		DisplaySeverity:                weightedSeverity(finalWeighting),
+	}
+}
+
+func weightedSeverity(weighting int) string {
+	switch {
+	case weighting >= 8:
+		return globaltypes.LevelCritical
+	case weighting >= 5:
+		return globaltypes.LevelHigh
+	case weighting >= 3:
+		return globaltypes.LevelMedium
+	default:
+		return globaltypes.LevelLow
 	}
 }
 
@@ -631,7 +873,9 @@ func countRules(
 
 		var shouldCount bool
 
-		if rule.Language() == "secret" {
+		if rule.Id == "sample_data" {
+			shouldCount = slices.Contains(config.Scan.Scanner, "fixtures")
+		} else if rule.Language() == "secret" {
 			shouldCount = slices.Contains(config.Scan.Scanner, "secrets")
 		} else if slices.Contains(config.Scan.Scanner, "sast") {
 			if rule.Language() == "JavaScript" {
@@ -740,32 +984,73 @@ func checkAndWriteFailureSummaryToString(
 func writeFailureToString(reportStr *strings.Builder, finding types.Finding, severity string) {
 	reportStr.WriteString("\n\n")
 	reportStr.WriteString(formatSeverity(severity))
-	reportStr.WriteString(finding.Title)
-	cweCount := len(finding.CWEIDs)
-	if cweCount > 0 {
-		var displayCWEList = []string{}
-		for i := 0; i < cweCount; i++ {
-			displayCWEList = append(displayCWEList, "CWE-"+finding.CWEIDs[i])
-		}
-		reportStr.WriteString(" [" + strings.Join(displayCWEList, ", ") + "]")
-	}
-	reportStr.WriteString("\n")
+	reportStr.WriteString(finding.Rule.MappedTitle() + "\n")
 
 	if finding.DocumentationUrl != "" {
 		reportStr.WriteString(color.HiBlackString(finding.DocumentationUrl + "\n"))
 	}
 
-	reportStr.WriteString(color.HiBlackString("To ignore this finding, run: bearer ignore add " + finding.Fingerprint + "\n"))
+	reportStr.WriteString(color.HiBlackString("To ignore this finding, run: bearer ignore add " + ignoreFingerprint(finding) + "\n"))
 	reportStr.WriteString("\n")
 	if finding.DetailedContext != "" {
 		reportStr.WriteString("Detected: " + finding.DetailedContext + "\n\n")
 	}
+	if finding.Mitigation != nil {
+		reportStr.WriteString("Mitigated: " + finding.Mitigation.Type + " by " + finding.Mitigation.Function + "\n\n")
+	}
 	reportStr.WriteString(color.HiBlueString("File: " + underline(finding.FullFilename+":"+fmt.Sprint(finding.LineNumber)) + "\n"))
 
 	reportStr.WriteString("\n")
 	reportStr.WriteString(finding.HighlightCodeExtract())
 }
 
+// writeSuppressionWarningsToString lists the `bearer:disable` comments that
+// have expired or give no reason, so that they get reviewed
+func writeSuppressionWarningsToString(reportStr *strings.Builder, warnings []dataflowtypes.SuppressionWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	reportStr.WriteString(color.New(color.Bold).Sprintf("\n\nSuppression warnings (%d)", len(warnings)))
+	reportStr.WriteString("\n-------------------------------------\n")
+	for _, warning := range warnings {
+		reportStr.WriteString(color.YellowString(
+			"%s:%d %s: %s\n",
+			warning.FullFilename,
+			warning.LineNumber,
+			warning.RuleID,
+			warning.Message,
+		))
+	}
+}
+
+// writeExceptedFindingsToString lists the findings whose risk was accepted,
+// along with who accepted it and why
+func writeExceptedFindingsToString(reportStr *strings.Builder, exceptedFindings ExceptedFindings) {
+	count := 0
+	for _, findings := range exceptedFindings {
+		count += len(findings)
+	}
+	if count == 0 {
+		return
+	}
+
+	reportStr.WriteString(color.New(color.Bold).Sprintf("\n\nRisk-accepted findings (%d)", count))
+	reportStr.WriteString("\n-------------------------------------\n")
+	for _, severity := range globaltypes.Severities {
+		for _, finding := range exceptedFindings[severity] {
+			reportStr.WriteString(formatSeverity(severity) + finding.Rule.MappedTitle() + "\n")
+			reportStr.WriteString(color.HiBlueString("File: " + finding.FullFilename + ":" + fmt.Sprint(finding.LineNumber) + "\n"))
+
+			accepted := "Accepted by " + finding.Exception.Owner
+			if finding.Exception.Expires != "" {
+				accepted += " until " + finding.Exception.Expires
+			}
+			reportStr.WriteString(color.HiBlackString(accepted+": "+finding.Exception.Reason) + "\n")
+		}
+	}
+}
+
 func formatSeverity(severity string) string {
 	severityColorFn, ok := severityColorFns[severity]
 	if !ok {
@@ -856,6 +1141,38 @@ func sortByLineNumber(outputs []Output) {
 	})
 }
 
+func codeContext(filename string, sink types.Sink, contextLines int) *types.CodeContext {
+	if contextLines == 0 {
+		return nil
+	}
+
+	lines, err := file.ReadFileLineRange(filename, max(1, sink.Start-contextLines), sink.End+contextLines)
+	if err != nil || len(lines) == 0 {
+		return nil
+	}
+
+	content := make([]string, len(lines))
+	for i, line := range lines {
+		content[i] = line.Extract
+	}
+
+	// mask the lines together so that secrets spanning lines are found
+	maskedContent := strings.Split(gitleaks.MaskSecrets(strings.Join(content, "\n")), "\n")
+
+	context := &types.CodeContext{
+		StartLine: lines[0].LineNumber,
+		EndLine:   lines[len(lines)-1].LineNumber,
+	}
+	for i, line := range lines {
+		context.Lines = append(context.Lines, types.ContextLine{
+			LineNumber: line.LineNumber,
+			Content:    maskedContent[i],
+		})
+	}
+
+	return context
+}
+
 func codeExtract(filename string, Source types.Source, Sink types.Sink) []file.Line {
 	code, err := file.ReadFileSinkLines(
 		filename,
@@ -1,10 +1,16 @@
 package security_test
 
 import (
+	"bufio"
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/bradleyjkemp/cupaloy"
 	"github.com/hhatto/gocloc"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bearer/bearer/internal/commands/process/filelist/files"
@@ -14,6 +20,9 @@ import (
 	"github.com/bearer/bearer/internal/report/basebranchfindings"
 	"github.com/bearer/bearer/internal/report/schema"
 	globaltypes "github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/codeowners"
+	ignoretypes "github.com/bearer/bearer/internal/util/ignore/types"
+	"github.com/bearer/bearer/internal/util/output"
 	"github.com/bearer/bearer/internal/util/set"
 	"github.com/bearer/bearer/internal/version_check"
 
@@ -58,6 +67,36 @@ func TestBuildReportString(t *testing.T) {
 	cupaloy.SnapshotT(t, stringBuilder.String())
 }
 
+func TestBuildReportStringWithOWASPMappings(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	rubyRailsLoggerRule := testhelper.RubyRailsLoggerRule()
+	rubyRailsLoggerRule.OWASP = []string{"A09:2021"}
+	config.Rules = map[string]*settings.Rule{
+		"ruby_rails_logger": rubyRailsLoggerRule,
+	}
+
+	data := dummyDataflowData()
+	if err := security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	dummyGoclocLanguage := gocloc.Language{}
+	dummyGoclocResult := gocloc.Result{
+		Total:     &dummyGoclocLanguage,
+		Files:     map[string]*gocloc.ClocFile{},
+		Languages: map[string]*gocloc.Language{"Ruby": {}},
+	}
+
+	reportString := security.BuildReportString(data, config, &dummyGoclocResult).String()
+	assert.Contains(t, reportString, "[CWE-209, CWE-532, A09:2021]")
+	assert.Contains(t, reportString, "(A09:2021, CWE-209, CWE-532)")
+	assert.Equal(t, []string{"A09:2021"}, data.FindingsBySeverity[globaltypes.LevelCritical][0].OWASP)
+}
+
 func TestNoRulesBuildReportString(t *testing.T) {
 	config, err := generateConfig(flag.ReportOptions{Report: "security"})
 	// set rule version
@@ -109,6 +148,32 @@ func TestAddReportData(t *testing.T) {
 	cupaloy.SnapshotT(t, output.FindingsBySeverity)
 }
 
+func TestAddReportDataWithFindingStream(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security", Format: flag.FormatJSONL})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+	}
+
+	var streamedFindings []securitytypes.RawFinding
+	output := dummyDataflowData()
+	output.FindingStream = func(finding securitytypes.RawFinding) error {
+		streamedFindings = append(streamedFindings, finding)
+		return nil
+	}
+
+	if err = security.AddReportData(output, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	assert.NotEmpty(t, streamedFindings)
+	assert.ElementsMatch(t, output.RawFindings, streamedFindings)
+}
+
 func TestAddReportDataWithSeverity(t *testing.T) {
 	severity := set.New[string]()
 	severity.Add(globaltypes.LevelCritical)
@@ -157,31 +222,535 @@ func TestAddReportDataWithFailOnSeverity(t *testing.T) {
 				severity.Add(test.Severity)
 			}
 
-			config, err := generateConfig(flag.ReportOptions{
-				Report:         "security",
-				Severity:       severity,
-				FailOnSeverity: failOnSeverity,
-			})
+			config, err := generateConfig(flag.ReportOptions{
+				Report:         "security",
+				Severity:       severity,
+				FailOnSeverity: failOnSeverity,
+			})
+
+			if err != nil {
+				tt.Fatalf("failed to generate config:%s", err)
+			}
+
+			config.Rules = map[string]*settings.Rule{
+				"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+				"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+			}
+
+			data := dummyDataflowData()
+			if err = security.AddReportData(data, config, nil, true); err != nil {
+				tt.Fatalf("failed to generate security output err:%s", err)
+			}
+
+			assert.Equal(tt, test.Expected, data.ReportFailed)
+		})
+	}
+}
+
+func TestAddReportDataWithGatesMinConfidence(t *testing.T) {
+	for _, test := range []struct {
+		Name,
+		Confidence string
+		Expected bool
+	}{
+		{Name: "default confidence", Expected: true},
+		{Name: "below minimum", Confidence: globaltypes.ConfidenceLow, Expected: false},
+		{Name: "at minimum", Confidence: globaltypes.ConfidenceMedium, Expected: true},
+		{Name: "above minimum", Confidence: globaltypes.ConfidenceHigh, Expected: true},
+	} {
+		t.Run(test.Name, func(tt *testing.T) {
+			config, err := generateConfig(flag.ReportOptions{
+				Report: "security",
+				GatesMinConfidence: map[string]string{
+					globaltypes.LevelCritical: globaltypes.ConfidenceMedium,
+					globaltypes.LevelHigh:     globaltypes.ConfidenceMedium,
+				},
+			})
+
+			if err != nil {
+				tt.Fatalf("failed to generate config:%s", err)
+			}
+
+			rubyRailsLoggerRule := testhelper.RubyRailsLoggerRule()
+			rubyRailsLoggerRule.Confidence = test.Confidence
+			rubyLangSSLVerificationRule := testhelper.RubyLangSSLVerificationRule()
+			rubyLangSSLVerificationRule.Confidence = test.Confidence
+
+			config.Rules = map[string]*settings.Rule{
+				"ruby_rails_logger":          rubyRailsLoggerRule,
+				"ruby_lang_ssl_verification": rubyLangSSLVerificationRule,
+			}
+
+			data := dummyDataflowData()
+			if err = security.AddReportData(data, config, nil, true); err != nil {
+				tt.Fatalf("failed to generate security output err:%s", err)
+			}
+
+			assert.Equal(tt, test.Expected, data.ReportFailed)
+		})
+	}
+}
+
+func TestAddReportDataWithFindingConfidence(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{
+		Report: "security",
+		GatesMinConfidence: map[string]string{
+			globaltypes.LevelCritical: globaltypes.ConfidenceMedium,
+			globaltypes.LevelHigh:     globaltypes.ConfidenceMedium,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+	}
+
+	data := dummyDataflowData()
+	for _, risk := range data.Dataflow.Risks {
+		for _, location := range risk.Locations {
+			location.Source.Confidence = globaltypes.ConfidenceLow
+		}
+	}
+
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	assert.False(t, data.ReportFailed)
+	for _, findings := range data.FindingsBySeverity {
+		for _, finding := range findings {
+			assert.Equal(t, globaltypes.ConfidenceLow, finding.Rule.Confidence)
+		}
+	}
+}
+
+func TestAddReportDataWithGateFailure(t *testing.T) {
+	failOnSeverity := set.New[string]()
+	failOnSeverity.Add(globaltypes.LevelCritical)
+	failOnSeverity.Add(globaltypes.LevelHigh)
+
+	config, err := generateConfig(flag.ReportOptions{
+		Report:             "security",
+		FailOnSeverity:     failOnSeverity,
+		GatesMinConfidence: map[string]string{globaltypes.LevelHigh: globaltypes.ConfidenceMedium},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+	}
+
+	data := dummyDataflowData()
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	if data.GateFailure == nil {
+		t.Fatal("expected the gate failure to be explained")
+	}
+
+	cupaloy.SnapshotT(t, data.GateFailure)
+}
+
+func TestAddReportDataWithoutGateFailure(t *testing.T) {
+	failOnSeverity := set.New[string]()
+	failOnSeverity.Add(globaltypes.LevelWarning)
+
+	config, err := generateConfig(flag.ReportOptions{Report: "security", FailOnSeverity: failOnSeverity})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_rails_logger": testhelper.RubyRailsLoggerRule(),
+	}
+
+	data := dummyDataflowData()
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	assert.Nil(t, data.GateFailure)
+}
+
+func TestAddReportDataWithPseudonymizers(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{
+		Report:         "security",
+		Pseudonymizers: []string{"hexdigest"},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_rails_logger": testhelper.RubyRailsLoggerRule(),
+	}
+
+	location := func(lineNumber int, columnNumber int, content string) dataflowtypes.RiskLocation {
+		return dataflowtypes.RiskLocation{
+			FullFilename:      "testdata/pseudonymization/user.rb",
+			Filename:          "user.rb",
+			StartLineNumber:   lineNumber,
+			StartColumnNumber: columnNumber,
+			EndLineNumber:     lineNumber,
+			EndColumnNumber:   columnNumber + 10,
+			Source: &schema.Source{
+				StartLineNumber:   lineNumber,
+				StartColumnNumber: 5,
+				EndLineNumber:     lineNumber,
+				EndColumnNumber:   5 + len(content),
+				Content:           content,
+			},
+			DataTypes: []dataflowtypes.RiskDatatype{
+				{
+					Name:         "Email Address",
+					CategoryUUID: "dd88aee5-9d40-4ad2-8983-0c791ddec47c",
+				},
+			},
+		}
+	}
+
+	data := &outputtypes.ReportData{
+		Dataflow: &outputtypes.DataFlow{
+			Risks: []dataflowtypes.RiskDetector{
+				{
+					DetectorID: "ruby_rails_logger",
+					Locations: []dataflowtypes.RiskLocation{
+						location(3, 48, "Rails.logger.info(Digest::SHA256.hexdigest(user.email))"),
+						location(4, 23, "Rails.logger.info(user.email)"),
+					},
+				},
+			},
+		},
+		Files: []string{"user.rb"},
+	}
+
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	mitigations := make(map[int]string)
+	for severity, findings := range data.FindingsBySeverity {
+		for _, finding := range findings {
+			mitigation := severity
+			if finding.Mitigation != nil {
+				mitigation += " " + finding.Mitigation.Type + " by " + finding.Mitigation.Function
+			}
+			mitigations[finding.LineNumber] = mitigation
+		}
+	}
+
+	assert.Equal(t, map[int]string{3: "low pseudonymized by hexdigest", 4: "high"}, mitigations)
+}
+
+func TestAddReportDataWithPathOverrides(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+	}
+	config.PathOverrides = []flag.PathOverride{
+		{Paths: []string{"pkg/"}, SkipRule: []string{"ruby_rails_logger"}},
+		{Paths: []string{"config/*.rb"}, Overrides: map[string]flag.RuleOverride{"ruby_lang_ssl_verification": {Severity: "medium"}}},
+		{Paths: []string{"config/"}, Overrides: map[string]flag.RuleOverride{"ruby_lang_ssl_verification": {Severity: "high"}}},
+	}
+
+	data := dummyDataflowData()
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	ruleIDs := make(map[string][]string)
+	for severity, findings := range data.FindingsBySeverity {
+		for _, finding := range findings {
+			ruleIDs[severity] = append(ruleIDs[severity], finding.Rule.Id)
+		}
+	}
+
+	assert.Equal(t, map[string][]string{globaltypes.LevelHigh: {"ruby_lang_ssl_verification"}}, ruleIDs)
+}
+
+func TestAddReportDataWithPathOverridesKeepsIgnores(t *testing.T) {
+	rules := map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+	}
+
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = rules
+	config.PathOverrides = []flag.PathOverride{
+		{Paths: []string{"pkg/"}, SkipRule: []string{"ruby_rails_logger"}},
+	}
+	config.IgnoredFingerprints = ignoreAllFindings(t, rules)
+
+	assert.NotContains(t, addReportDataStdErr(t, config), "no longer detected")
+}
+
+func TestAddReportDataWithRulePaths(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	sslRule := testhelper.RubyLangSSLVerificationRule()
+	sslRule.Paths = &settings.RulePaths{Include: []string{"config/**"}, Exclude: []string{"application.rb"}}
+	loggerRule := testhelper.RubyRailsLoggerRule()
+	loggerRule.Paths = &settings.RulePaths{Include: []string{"*_leak.rb"}}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": sslRule,
+		"ruby_rails_logger":          loggerRule,
+	}
+
+	data := dummyDataflowData()
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	var filenames []string
+	for _, findings := range data.FindingsBySeverity {
+		for _, finding := range findings {
+			filenames = append(filenames, finding.Filename)
+		}
+	}
+
+	assert.Equal(t, []string{"pkg/datatype_leak.rb"}, filenames)
+}
+
+func TestAddReportDataWithRulePathsKeepsIgnores(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	loggerRule := testhelper.RubyRailsLoggerRule()
+	loggerRule.Paths = &settings.RulePaths{Exclude: []string{"pkg/**"}}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_rails_logger": loggerRule,
+	}
+	config.IgnoredFingerprints = ignoreAllFindings(t, map[string]*settings.Rule{
+		"ruby_rails_logger": testhelper.RubyRailsLoggerRule(),
+	})
+
+	assert.NotContains(t, addReportDataStdErr(t, config), "no longer detected")
+}
+
+func TestAddReportDataWithExceptions(t *testing.T) {
+	failOnSeverity := set.New[string]()
+	failOnSeverity.Add(globaltypes.LevelCritical)
+
+	config, err := generateConfig(flag.ReportOptions{
+		Report:         "security",
+		FailOnSeverity: failOnSeverity,
+		Exceptions: []flag.FindingException{
+			{Rule: "ruby_lang_ssl_verification", Owner: "security", Reason: "expired", Expires: "2020-01-01"},
+			{Rule: "ruby_rails_logger", Paths: []string{"app/"}, Owner: "security", Reason: "other paths"},
+			{Rule: "ruby_rails_logger", Paths: []string{"pkg/"}, Owner: "payments", Reason: "logs are scrubbed", Expires: "2999-12-31"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+	}
+
+	data := dummyDataflowData()
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	ruleIDs := make(map[string][]string)
+	for severity, findings := range data.FindingsBySeverity {
+		for _, finding := range findings {
+			ruleIDs[severity] = append(ruleIDs[severity], finding.Rule.Id)
+		}
+	}
+	assert.Equal(t, map[string][]string{globaltypes.LevelHigh: {"ruby_lang_ssl_verification"}}, ruleIDs)
+
+	exceptedFindings := data.ExceptedFindingsBySeverity[globaltypes.LevelCritical]
+	if assert.Len(t, exceptedFindings, 1) {
+		assert.Equal(t, "ruby_rails_logger", exceptedFindings[0].Rule.Id)
+		assert.Equal(t, securitytypes.Exception{
+			Owner:   "payments",
+			Reason:  "logs are scrubbed",
+			Expires: "2999-12-31",
+		}, exceptedFindings[0].Exception)
+	}
+
+	assert.False(t, data.ReportFailed, "excepted findings should not fail the report")
+
+	config.NoColor = true
+	report := security.BuildReportString(data, config, &gocloc.Result{
+		Total:     &gocloc.Language{},
+		Files:     map[string]*gocloc.ClocFile{},
+		Languages: map[string]*gocloc.Language{"Ruby": {}},
+	}).String()
+	assert.Contains(t, report, "Risk-accepted findings (1)")
+	assert.Contains(t, report, "Accepted by payments until 2999-12-31: logs are scrubbed")
+}
+
+func TestAddReportDataWithFindingPolicies(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+	}
+	config.FindingPolicies = settings.Modules{
+		{
+			Name: "triage.rego",
+			Content: `package bearer.findings
+
+import future.keywords
+
+drop if startswith(input.filename, "pkg/")
+
+severity := "low" if input.id == "ruby_lang_ssl_verification"
+
+annotations["team"] := "platform"`,
+		},
+	}
+
+	data := dummyDataflowData()
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	ruleIDs := make(map[string][]string)
+	for severity, findings := range data.FindingsBySeverity {
+		for _, finding := range findings {
+			ruleIDs[severity] = append(ruleIDs[severity], finding.Rule.Id)
+			assert.Equal(t, map[string]string{"team": "platform"}, finding.Annotations)
+		}
+	}
+
+	assert.Equal(t, map[string][]string{globaltypes.LevelLow: {"ruby_lang_ssl_verification"}}, ruleIDs)
+}
+
+func TestAddReportDataWithInvalidFindingPolicySeverity(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+	}
+	config.FindingPolicies = settings.Modules{
+		{Name: "triage.rego", Content: "package bearer.findings\n\nseverity := \"urgent\""},
+	}
+
+	err = security.AddReportData(dummyDataflowData(), config, nil, true)
+	assert.ErrorContains(t, err, "invalid severity 'urgent' from finding policy")
+}
 
+func TestAddReportDataWithRequires(t *testing.T) {
+	for _, test := range []struct {
+		Name     string
+		Requires []string
+		Expected bool
+	}{
+		{Name: "no requirements", Expected: true},
+		{Name: "requirements met", Requires: []string{"rails < 7.1"}, Expected: true},
+		{Name: "version not matched", Requires: []string{"rails >= 7.1"}, Expected: false},
+		{Name: "dependency missing", Requires: []string{"lograge"}, Expected: false},
+	} {
+		t.Run(test.Name, func(tt *testing.T) {
+			config, err := generateConfig(flag.ReportOptions{Report: "security"})
 			if err != nil {
 				tt.Fatalf("failed to generate config:%s", err)
 			}
 
+			rubyRailsLoggerRule := testhelper.RubyRailsLoggerRule()
+			rubyRailsLoggerRule.Requires = test.Requires
+
 			config.Rules = map[string]*settings.Rule{
-				"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
-				"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+				"ruby_rails_logger": rubyRailsLoggerRule,
 			}
 
 			data := dummyDataflowData()
+			data.Dataflow.Dependencies = []dataflowtypes.Dependency{
+				{Name: "rails", Version: "7.0.8", Filename: "Gemfile.lock", Detector: "gemfile-lock"},
+			}
+
 			if err = security.AddReportData(data, config, nil, true); err != nil {
 				tt.Fatalf("failed to generate security output err:%s", err)
 			}
 
-			assert.Equal(tt, test.Expected, data.ReportFailed)
+			assert.Equal(tt, test.Expected, len(data.RawFindings) != 0)
 		})
 	}
 }
 
+func TestAddReportDataWithRequiresKeepsIgnores(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	rubyRailsLoggerRule := testhelper.RubyRailsLoggerRule()
+	rubyRailsLoggerRule.Requires = []string{"lograge"}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_rails_logger": rubyRailsLoggerRule,
+	}
+	config.IgnoredFingerprints = ignoreAllFindings(t, map[string]*settings.Rule{
+		"ruby_rails_logger": testhelper.RubyRailsLoggerRule(),
+	})
+
+	assert.NotContains(t, addReportDataStdErr(t, config), "no longer detected")
+}
+
+func TestGroupFindings(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+	}
+
+	data := dummyDataflowData()
+	if err := security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	codeOwners, err := codeowners.Parse(bufio.NewScanner(strings.NewReader("config/ @security-team\n")))
+	if err != nil {
+		t.Fatalf("failed to parse CODEOWNERS:%s", err)
+	}
+
+	res := make(map[string][]securitytypes.FindingGroup)
+	for _, groupBy := range []string{flag.GroupByRule, flag.GroupByFile, flag.GroupByDataType, flag.GroupByOwner, flag.GroupByWorkspace} {
+		res[groupBy] = security.GroupFindings(data.FindingsBySeverity, groupBy, codeOwners)
+	}
+
+	cupaloy.SnapshotT(t, res)
+}
+
 func TestCalculateSeverity(t *testing.T) {
 	res := []securitytypes.SeverityMeta{
 		security.CalculateSeverity([]string{"PHI", "Personal Data"}, "low", true),
@@ -287,6 +856,333 @@ func TestFingerprintIsStableWithBaseBranchFindings(t *testing.T) {
 	assert.Equal(t, fullScanFinding.Fingerprint, diffFinding.Fingerprint)
 }
 
+func TestRenamedFileMatchesPreviousFingerprint(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+	}
+
+	previousFilename := "config/application.rb"
+	filename := "config/environments/application.rb"
+
+	dataFor := func(filename string) *outputtypes.ReportData {
+		return &outputtypes.ReportData{
+			Dataflow: &outputtypes.DataFlow{
+				Risks: []dataflowtypes.RiskDetector{
+					{
+						DetectorID: "ruby_lang_ssl_verification",
+						Locations: []dataflowtypes.RiskLocation{
+							{
+								Filename:        filename,
+								StartLineNumber: 1,
+								Source: &schema.Source{
+									StartLineNumber:   1,
+									StartColumnNumber: 1,
+									EndLineNumber:     1,
+									EndColumnNumber:   44,
+									Content:           "http.verify_mode = OpenSSL::SSL::VERIFY_NONE",
+								},
+								PresenceMatches: []dataflowtypes.RiskPresence{
+									{
+										Name: "http.verify_mode = OpenSSL::SSL::VERIFY_NONE",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Files: []string{filename},
+		}
+	}
+
+	previousData := dataFor(previousFilename)
+	if err = security.AddReportData(previousData, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	previousFingerprint := previousData.FindingsBySeverity[globaltypes.LevelMedium][0].Fingerprint
+	config.IgnoredFingerprints = map[string]ignoretypes.IgnoredFingerprint{
+		previousFingerprint: {IgnoredAt: "2023-01-01T00:00:00Z"},
+	}
+
+	fileList := &files.List{
+		Files:     []files.File{{FilePath: filename}},
+		BaseFiles: []files.File{{FilePath: previousFilename}},
+		Renames:   map[string]string{previousFilename: filename},
+		Chunks:    map[string]git.Chunks{},
+	}
+
+	data := dataFor(filename)
+	if err = security.AddReportData(data, config, basebranchfindings.New(fileList), true); err != nil {
+		t.Fatalf("failed to generate security output with base branch findings err:%s", err)
+	}
+
+	assert.Empty(t, data.FindingsBySeverity[globaltypes.LevelMedium])
+
+	ignoredFinding := data.IgnoredFindingsBySeverity[globaltypes.LevelMedium][0]
+	assert.Equal(t, previousFingerprint, ignoredFinding.PreviousFingerprint)
+	assert.NotEqual(t, previousFingerprint, ignoredFinding.Fingerprint)
+}
+
+func TestFingerprintHashAndSalt(t *testing.T) {
+	rules := map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+	}
+
+	findingFor := func(reportOptions flag.ReportOptions, ignoredFingerprints map[string]ignoretypes.IgnoredFingerprint) (*securitytypes.Finding, bool) {
+		config, err := generateConfig(reportOptions)
+		if err != nil {
+			t.Fatalf("failed to generate config:%s", err)
+		}
+		config.Rules = rules
+		config.IgnoredFingerprints = ignoredFingerprints
+
+		data := dummyDataflowData()
+		if err = security.AddReportData(data, config, nil, true); err != nil {
+			t.Fatalf("failed to generate security output err:%s", err)
+		}
+
+		for _, severity := range globaltypes.Severities {
+			if ignored := data.IgnoredFindingsBySeverity[severity]; len(ignored) != 0 {
+				return &ignored[0].Finding, true
+			}
+
+			if findings := data.FindingsBySeverity[severity]; len(findings) != 0 {
+				return &findings[0], false
+			}
+		}
+
+		t.Fatal("expected a finding")
+		return nil, false
+	}
+
+	legacyFinding, _ := findingFor(flag.ReportOptions{Report: "security", FingerprintHash: flag.FingerprintHashMD5}, nil)
+
+	saltedOptions := flag.ReportOptions{Report: "security", FingerprintHash: flag.FingerprintHashSHA256, FingerprintSalt: "acme"}
+	saltedFinding, _ := findingFor(saltedOptions, nil)
+	assert.NotEqual(t, legacyFinding.Fingerprint, saltedFinding.Fingerprint)
+	assert.Len(t, strings.Split(saltedFinding.Fingerprint, "_")[0], 64)
+
+	otherSaltOptions := saltedOptions
+	otherSaltOptions.FingerprintSalt = "globex"
+	otherSaltFinding, _ := findingFor(otherSaltOptions, nil)
+	assert.NotEqual(t, saltedFinding.Fingerprint, otherSaltFinding.Fingerprint)
+
+	legacyIgnores := map[string]ignoretypes.IgnoredFingerprint{
+		legacyFinding.Fingerprint: {IgnoredAt: "2023-01-01T00:00:00Z"},
+	}
+
+	_, ignored := findingFor(saltedOptions, legacyIgnores)
+	assert.False(t, ignored)
+
+	compatibleOptions := saltedOptions
+	compatibleOptions.FingerprintCompatibility = true
+	compatibleFinding, ignored := findingFor(compatibleOptions, legacyIgnores)
+	assert.True(t, ignored)
+	assert.Equal(t, saltedFinding.Fingerprint, compatibleFinding.Fingerprint)
+}
+
+func TestContentFingerprint(t *testing.T) {
+	findingAt := func(
+		fullFilename string,
+		lineNumber int,
+		content string,
+		ignoredFingerprints map[string]ignoretypes.IgnoredFingerprint,
+	) (*securitytypes.Finding, bool) {
+		config, err := generateConfig(flag.ReportOptions{Report: "security"})
+		if err != nil {
+			t.Fatalf("failed to generate config:%s", err)
+		}
+		config.Rules = map[string]*settings.Rule{
+			"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		}
+		config.IgnoredFingerprints = ignoredFingerprints
+
+		data := &outputtypes.ReportData{
+			Dataflow: &outputtypes.DataFlow{
+				Risks: []dataflowtypes.RiskDetector{
+					{
+						DetectorID: "ruby_lang_ssl_verification",
+						Locations: []dataflowtypes.RiskLocation{
+							{
+								FullFilename:    fullFilename,
+								Filename:        filepath.Base(fullFilename),
+								StartLineNumber: lineNumber,
+								Source: &schema.Source{
+									StartLineNumber:   lineNumber,
+									StartColumnNumber: 5,
+									EndLineNumber:     lineNumber,
+									EndColumnNumber:   5 + len(content),
+									Content:           content,
+								},
+								PresenceMatches: []dataflowtypes.RiskPresence{{Name: content}},
+							},
+						},
+					},
+				},
+			},
+			Files: []string{filepath.Base(fullFilename)},
+		}
+
+		if err = security.AddReportData(data, config, nil, true); err != nil {
+			t.Fatalf("failed to generate security output err:%s", err)
+		}
+
+		if ignored := data.IgnoredFindingsBySeverity[globaltypes.LevelMedium]; len(ignored) != 0 {
+			return &ignored[0].Finding, true
+		}
+
+		return &data.FindingsBySeverity[globaltypes.LevelMedium][0], false
+	}
+
+	original, _ := findingAt("testdata/fingerprint/original/user.rb", 4, "http.verify_mode = OpenSSL::SSL::VERIFY_NONE", nil)
+	moved, _ := findingAt("testdata/fingerprint/moved/account.rb", 8, "http.verify_mode  =  OpenSSL::SSL::VERIFY_NONE", nil)
+	otherMethod, _ := findingAt("testdata/fingerprint/original/user.rb", 8, "http.verify_mode = OpenSSL::SSL::VERIFY_NONE", nil)
+
+	assert.NotEqual(t, original.Fingerprint, moved.Fingerprint)
+	assert.Equal(t, original.ContentFingerprint, moved.ContentFingerprint)
+	assert.NotEqual(t, original.ContentFingerprint, otherMethod.ContentFingerprint)
+
+	_, ignored := findingAt(
+		"testdata/fingerprint/moved/account.rb",
+		8,
+		"http.verify_mode  =  OpenSSL::SSL::VERIFY_NONE",
+		map[string]ignoretypes.IgnoredFingerprint{original.ContentFingerprint: {IgnoredAt: "2023-01-01T00:00:00Z"}},
+	)
+	assert.True(t, ignored)
+}
+
+func TestAddReportDataWithContextLines(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{Report: "security", ContextLines: 2})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+	}
+
+	data := &outputtypes.ReportData{
+		Dataflow: &outputtypes.DataFlow{
+			Risks: []dataflowtypes.RiskDetector{
+				{
+					DetectorID: "ruby_lang_ssl_verification",
+					Locations: []dataflowtypes.RiskLocation{
+						{
+							FullFilename:    "testdata/context/application.rb",
+							Filename:        "application.rb",
+							StartLineNumber: 4,
+							Source: &schema.Source{
+								StartLineNumber:   4,
+								StartColumnNumber: 1,
+								EndLineNumber:     4,
+								EndColumnNumber:   45,
+								Content:           "http.verify_mode = OpenSSL::SSL::VERIFY_NONE",
+							},
+							PresenceMatches: []dataflowtypes.RiskPresence{
+								{
+									Name: "http.verify_mode = OpenSSL::SSL::VERIFY_NONE",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Files: []string{"application.rb"},
+	}
+
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	cupaloy.SnapshotT(t, data.FindingsBySeverity[globaltypes.LevelMedium][0].CodeContext)
+}
+
+func TestAddReportDataWithOnlyPath(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{
+		Report:   "security",
+		OnlyPath: []string{"config/"},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+	}
+
+	data := dummyDataflowData()
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	var filenames []string
+	for _, findings := range data.FindingsBySeverity {
+		for _, finding := range findings {
+			filenames = append(filenames, finding.Filename)
+		}
+	}
+
+	assert.Equal(t, []string{"config/application.rb"}, filenames)
+}
+
+func TestAddReportDataWithOnlyRule(t *testing.T) {
+	config, err := generateConfig(flag.ReportOptions{
+		Report:         "security",
+		OnlyReportRule: []string{"ruby_rails_logger"},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+	}
+
+	data := dummyDataflowData()
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	var ruleIDs []string
+	for _, findings := range data.FindingsBySeverity {
+		for _, finding := range findings {
+			ruleIDs = append(ruleIDs, finding.Rule.Id)
+		}
+	}
+
+	assert.Equal(t, []string{"ruby_rails_logger"}, ruleIDs)
+}
+
+func TestAddReportDataWithOnlyPathKeepsIgnores(t *testing.T) {
+	rules := map[string]*settings.Rule{
+		"ruby_lang_ssl_verification": testhelper.RubyLangSSLVerificationRule(),
+		"ruby_rails_logger":          testhelper.RubyRailsLoggerRule(),
+	}
+
+	config, err := generateConfig(flag.ReportOptions{
+		Report:         "security",
+		OnlyPath:       []string{"config/"},
+		OnlyReportRule: []string{"ruby_lang_ssl_verification"},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+
+	config.Rules = rules
+	config.IgnoredFingerprints = ignoreAllFindings(t, rules)
+
+	assert.NotContains(t, addReportDataStdErr(t, config), "no longer detected")
+}
+
 func generateConfig(reportOptions flag.ReportOptions) (settings.Config, error) {
 	if reportOptions.Severity == nil {
 		reportOptions.Severity = set.New[string]()
@@ -322,6 +1218,52 @@ func generateConfig(reportOptions flag.ReportOptions) (settings.Config, error) {
 	return settings.FromOptions(opts, meta)
 }
 
+// ignoreAllFindings returns ignores for every finding the rules report
+// without any filter
+func ignoreAllFindings(t *testing.T, rules map[string]*settings.Rule) map[string]ignoretypes.IgnoredFingerprint {
+	config, err := generateConfig(flag.ReportOptions{Report: "security"})
+	if err != nil {
+		t.Fatalf("failed to generate config:%s", err)
+	}
+	config.Rules = rules
+
+	data := dummyDataflowData()
+	if err = security.AddReportData(data, config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	ignoredFingerprints := make(map[string]ignoretypes.IgnoredFingerprint)
+	for _, findings := range data.FindingsBySeverity {
+		for _, finding := range findings {
+			ignoredFingerprints[finding.Fingerprint] = ignoretypes.IgnoredFingerprint{}
+		}
+	}
+
+	if len(ignoredFingerprints) == 0 {
+		t.Fatal("expected findings to ignore")
+	}
+
+	return ignoredFingerprints
+}
+
+// addReportDataStdErr returns what adding the report data logs to stderr
+func addReportDataStdErr(t *testing.T, config settings.Config) string {
+	var stdErr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&stdErr)
+	output.Setup(cmd, output.SetupRequest{})
+	t.Cleanup(func() {
+		cmd.SetErr(io.Discard)
+		output.Setup(cmd, output.SetupRequest{})
+	})
+
+	if err := security.AddReportData(dummyDataflowData(), config, nil, true); err != nil {
+		t.Fatalf("failed to generate security output err:%s", err)
+	}
+
+	return stdErr.String()
+}
+
 func dummyDataflowData() *outputtypes.ReportData {
 	subject := "User"
 	lowRisk := dataflowtypes.RiskDetector{
@@ -414,3 +1356,48 @@ func dummyDataflowData() *outputtypes.ReportData {
 		Files:    []string{"config/application.rb", "pkg/datatype_leak.rb", "app/model/user.rb"},
 	}
 }
+
+func TestFindProcessorFlows(t *testing.T) {
+	data := dummyDataflowData()
+	data.Dataflow.Components = []dataflowtypes.Component{
+		{
+			Name:      "Google Analytics",
+			Type:      "external_service",
+			SubType:   "third_party",
+			Category:  "analytics",
+			Locations: []dataflowtypes.ComponentLocation{{Filename: "app/model/user.rb", LineNumber: 4}},
+		},
+		{
+			Name:      "Google Ads",
+			Type:      "external_service",
+			SubType:   "third_party",
+			Category:  "advertising",
+			Locations: []dataflowtypes.ComponentLocation{{Filename: "app/model/user.rb", LineNumber: 8}},
+		},
+		{
+			Name:      "AdRoll",
+			Type:      "external_service",
+			SubType:   "third_party",
+			Category:  "advertising",
+			Locations: []dataflowtypes.ComponentLocation{{Filename: "app/assets/tracking.rb", LineNumber: 1}},
+		},
+	}
+
+	flows, err := security.FindProcessorFlows(data.Dataflow, []string{"advertising"})
+	if err != nil {
+		t.Fatalf("failed to find processor flows: %s", err)
+	}
+
+	assert.Equal(t, []securitytypes.ProcessorFlow{
+		{
+			ThirdParty: "Google Ads",
+			Category:   "advertising",
+			DataTypes:  []string{"Email Address"},
+			Files:      []string{"app/model/user.rb"},
+		},
+	}, flows)
+
+	flows, err = security.FindProcessorFlows(data.Dataflow, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, flows)
+}
@@ -0,0 +1,10 @@
+require "net/http"
+
+# Connects to the example API
+class User
+  def connect
+    http = Net::HTTP.new("example.com", 443)
+
+    http.verify_mode  =  OpenSSL::SSL::VERIFY_NONE
+  end
+end
@@ -0,0 +1,10 @@
+class User
+  def connect
+    http = Net::HTTP.new("example.com", 443)
+    http.verify_mode = OpenSSL::SSL::VERIFY_NONE
+  end
+
+  def reconnect
+    http.verify_mode = OpenSSL::SSL::VERIFY_NONE
+  end
+end
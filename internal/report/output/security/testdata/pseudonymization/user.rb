@@ -0,0 +1,6 @@
+class User
+  def log_signup(user)
+    Rails.logger.info(Digest::SHA256.hexdigest(user.email))
+    Rails.logger.info(user.email)
+  end
+end
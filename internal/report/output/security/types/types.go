@@ -11,6 +11,11 @@ import (
 	ignoretypes "github.com/bearer/bearer/internal/util/ignore/types"
 )
 
+type FindingGroup struct {
+	Name     string       `json:"name" yaml:"name"`
+	Findings []RawFinding `json:"findings" yaml:"findings"`
+}
+
 type ExpectedDetection struct {
 	RuleID   string   `json:"rule_id"`
 	Location Location `json:"location"`
@@ -23,21 +28,60 @@ type RawFinding struct {
 
 type Finding struct {
 	*Rule
-	LineNumber       int          `json:"line_number,omitempty" yaml:"line_number,omitempty"`
-	FullFilename     string       `json:"full_filename,omitempty" yaml:"full_filename,omitempty"`
-	Filename         string       `json:"filename,omitempty" yaml:"filename,omitempty"`
-	DataType         *DataType    `json:"data_type,omitempty" yaml:"data_type,omitempty"`
-	CategoryGroups   []string     `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
-	Source           Source       `json:"source,omitempty" yaml:"source,omitempty"`
-	Sink             Sink         `json:"sink,omitempty" yaml:"sink,omitempty"`
-	ParentLineNumber int          `json:"parent_line_number,omitempty" yaml:"parent_line_number,omitempty"`
-	ParentContent    string       `json:"snippet,omitempty" yaml:"snippet,omitempty"`
-	Fingerprint      string       `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
-	OldFingerprint   string       `json:"old_fingerprint,omitempty" yaml:"old_fingerprint,omitempty"`
-	DetailedContext  string       `json:"detailed_context,omitempty" yaml:"detailed_context,omitempty"`
-	CodeExtract      string       `json:"code_extract,omitempty" yaml:"code_extract,omitempty"`
-	RawCodeExtract   []file.Line  `json:"-" yaml:"-"`
-	SeverityMeta     SeverityMeta `json:"-" yaml:"-"`
+	LineNumber          int          `json:"line_number,omitempty" yaml:"line_number,omitempty"`
+	FullFilename        string       `json:"full_filename,omitempty" yaml:"full_filename,omitempty"`
+	Filename            string       `json:"filename,omitempty" yaml:"filename,omitempty"`
+	Workspace           string       `json:"workspace,omitempty" yaml:"workspace,omitempty"`
+	DataType            *DataType    `json:"data_type,omitempty" yaml:"data_type,omitempty"`
+	CategoryGroups      []string     `json:"category_groups,omitempty" yaml:"category_groups,omitempty"`
+	Source              Source       `json:"source,omitempty" yaml:"source,omitempty"`
+	Sink                Sink         `json:"sink,omitempty" yaml:"sink,omitempty"`
+	ParentLineNumber    int          `json:"parent_line_number,omitempty" yaml:"parent_line_number,omitempty"`
+	ParentContent       string       `json:"snippet,omitempty" yaml:"snippet,omitempty"`
+	Fingerprint         string       `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+	OldFingerprint      string       `json:"old_fingerprint,omitempty" yaml:"old_fingerprint,omitempty"`
+	PreviousFingerprint string       `json:"previous_fingerprint,omitempty" yaml:"previous_fingerprint,omitempty"`
+	ContentFingerprint  string       `json:"content_fingerprint,omitempty" yaml:"content_fingerprint,omitempty"`
+	DetailedContext     string       `json:"detailed_context,omitempty" yaml:"detailed_context,omitempty"`
+	CodeExtract         string       `json:"code_extract,omitempty" yaml:"code_extract,omitempty"`
+	RawCodeExtract      []file.Line  `json:"-" yaml:"-"`
+	CodeContext         *CodeContext `json:"code_context,omitempty" yaml:"code_context,omitempty"`
+	Mitigation          *Mitigation  `json:"mitigation,omitempty" yaml:"mitigation,omitempty"`
+	// Annotations are added to the finding by the finding policies
+	Annotations  map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	SeverityMeta SeverityMeta      `json:"-" yaml:"-"`
+}
+
+const MitigationPseudonymized = "pseudonymized"
+
+// Mitigation describes how the data of a finding was protected before it
+// reached the sink, which lowers the severity of the finding
+type Mitigation struct {
+	Type     string `json:"type" yaml:"type"`
+	Function string `json:"function" yaml:"function"`
+}
+
+// CodeContext holds the source lines around a finding, with any secrets
+// masked
+type CodeContext struct {
+	StartLine int           `json:"start_line" yaml:"start_line"`
+	EndLine   int           `json:"end_line" yaml:"end_line"`
+	Lines     []ContextLine `json:"lines" yaml:"lines"`
+}
+
+type ContextLine struct {
+	LineNumber int    `json:"line_number" yaml:"line_number"`
+	Content    string `json:"content" yaml:"content"`
+}
+
+// Text returns the lines of the context joined by newlines
+func (context *CodeContext) Text() string {
+	lines := make([]string, len(context.Lines))
+	for i, line := range context.Lines {
+		lines[i] = line.Content
+	}
+
+	return strings.Join(lines, "\n")
 }
 
 type IgnoredFinding struct {
@@ -45,10 +89,31 @@ type IgnoredFinding struct {
 	IgnoreMeta ignoretypes.IgnoredFingerprint
 }
 
+// Exception records who accepted the risk of a finding, why, and until when
+type Exception struct {
+	Owner   string `json:"owner" yaml:"owner"`
+	Reason  string `json:"reason" yaml:"reason"`
+	Expires string `json:"expires,omitempty" yaml:"expires,omitempty"`
+}
+
+// ExceptedFinding is a finding whose risk was accepted in the configuration.
+// It is still reported, but doesn't fail the report.
+type ExceptedFinding struct {
+	Finding
+	Exception Exception
+}
+
+// RawExceptedFinding is an excepted finding as written to the report
+type RawExceptedFinding struct {
+	RawFinding
+	Exception Exception `json:"exception" yaml:"exception"`
+}
+
 type GenericFinding interface {
 	GetFinding() Finding
 	ToRawFinding(severity string) RawFinding
 	GetIgnoreMeta() *ignoretypes.IgnoredFingerprint
+	GetException() *Exception
 }
 
 func (f Finding) ToRawFinding(severity string) RawFinding {
@@ -71,6 +136,10 @@ func (f Finding) GetIgnoreMeta() *ignoretypes.IgnoredFingerprint {
 	return nil
 }
 
+func (f Finding) GetException() *Exception {
+	return nil
+}
+
 func (i IgnoredFinding) GetFinding() Finding {
 	return i.Finding
 }
@@ -79,17 +148,95 @@ func (i IgnoredFinding) GetIgnoreMeta() *ignoretypes.IgnoredFingerprint {
 	return &i.IgnoreMeta
 }
 
+func (e ExceptedFinding) GetFinding() Finding {
+	return e.Finding
+}
+
+func (e ExceptedFinding) GetException() *Exception {
+	return &e.Exception
+}
+
+// ToRawExceptedFinding returns the excepted finding as written to the report
+func (e ExceptedFinding) ToRawExceptedFinding(severity string) RawExceptedFinding {
+	return RawExceptedFinding{RawFinding: e.ToRawFinding(severity), Exception: e.Exception}
+}
+
 type DataType struct {
 	CategoryUUID string `json:"category_uuid,omitempty" yaml:"category_uuid,omitempty"`
 	Name         string `json:"name,omitempty" yaml:"name,omitempty"`
 }
 
+// ProcessorFlow is a third party of a category the report fails on, found in
+// the same files as classified data
+type ProcessorFlow struct {
+	ThirdParty string   `json:"third_party" yaml:"third_party"`
+	Category   string   `json:"category" yaml:"category"`
+	DataTypes  []string `json:"data_types" yaml:"data_types"`
+	Files      []string `json:"files" yaml:"files"`
+}
+
+// GateFailure explains which findings failed the scan, and the thresholds
+// they were checked against
+type GateFailure struct {
+	DiffScan          bool              `json:"diff_scan" yaml:"diff_scan"`
+	FailOnSeverity    []string          `json:"fail_on_severity" yaml:"fail_on_severity"`
+	MinimumConfidence map[string]string `json:"minimum_confidence,omitempty" yaml:"minimum_confidence,omitempty"`
+	Findings          []GateFinding     `json:"findings" yaml:"findings"`
+}
+
+type GateFinding struct {
+	Severity    string `json:"severity" yaml:"severity"`
+	Confidence  string `json:"confidence" yaml:"confidence"`
+	RuleID      string `json:"rule_id" yaml:"rule_id"`
+	Title       string `json:"title" yaml:"title"`
+	Filename    string `json:"filename" yaml:"filename"`
+	LineNumber  int    `json:"line_number" yaml:"line_number"`
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+}
+
 type Rule struct {
 	CWEIDs           []string `json:"cwe_ids" yaml:"cwe_ids"`
+	OWASP            []string `json:"owasp,omitempty" yaml:"owasp,omitempty"`
 	Id               string   `json:"id" yaml:"id"`
 	Title            string   `json:"title" yaml:"title"`
 	Description      string   `json:"description" yaml:"description"`
 	DocumentationUrl string   `json:"documentation_url" yaml:"documentation_url"`
+	Confidence       string   `json:"confidence,omitempty" yaml:"confidence,omitempty"`
+	Version          string   `json:"version,omitempty" yaml:"version,omitempty"`
+	Fix              *Fix     `json:"fix,omitempty" yaml:"fix,omitempty"`
+}
+
+// Mappings lists the CWE ids, as CWE-<id>, followed by the OWASP categories
+// of the rule
+func (rule *Rule) Mappings() []string {
+	var mappings []string
+	if rule == nil {
+		return mappings
+	}
+
+	for _, cweID := range rule.CWEIDs {
+		mappings = append(mappings, "CWE-"+cweID)
+	}
+
+	return append(mappings, rule.OWASP...)
+}
+
+// MappedTitle is the title of the rule followed by its mappings, eg.
+// "Leakage of information in logger message [CWE-532, A09:2021]"
+func (rule *Rule) MappedTitle() string {
+	if mappings := rule.Mappings(); len(mappings) != 0 {
+		return rule.Title + " [" + strings.Join(mappings, ", ") + "]"
+	}
+
+	return rule.Title
+}
+
+// Fix is the rewrite of the matched code suggested by the rule, applied by the
+// fix command
+type Fix struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Replace     string `json:"replace" yaml:"replace"`
+	With        string `json:"with" yaml:"with"`
 }
 
 type Location struct {
@@ -124,6 +271,14 @@ type SeverityMeta struct {
 
 func (f Finding) HighlightCodeExtract() string {
 	result := ""
+	if f.CodeContext != nil {
+		for _, line := range f.CodeContext.Lines {
+			if line.LineNumber < f.Sink.Start {
+				result += color.HiBlackString(fmt.Sprintf(" %d %s", line.LineNumber, line.Content)) + "\n"
+			}
+		}
+	}
+
 	for _, line := range f.RawCodeExtract {
 		if line.Strip {
 			result += color.HiBlackString(
@@ -167,6 +322,14 @@ func (f Finding) HighlightCodeExtract() string {
 		}
 	}
 
+	if f.CodeContext != nil {
+		for _, line := range f.CodeContext.Lines {
+			if line.LineNumber > f.Sink.End {
+				result += "\n" + color.HiBlackString(fmt.Sprintf(" %d %s", line.LineNumber, line.Content))
+			}
+		}
+	}
+
 	return result
 }
 
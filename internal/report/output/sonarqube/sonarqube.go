@@ -0,0 +1,83 @@
+package sonarqube
+
+import (
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	sonarqube "github.com/bearer/bearer/internal/report/output/sonarqube/types"
+)
+
+func ReportSonarQube(outputDetections map[string][]securitytypes.Finding) (sonarqube.SonarQubeOutput, error) {
+	rules := []sonarqube.Rule{}
+	issues := []sonarqube.Issue{}
+	seenRules := make(map[string]bool)
+
+	// severities are ordered highest first, so a rule takes the highest
+	// severity found for any of its findings
+	for _, level := range []string{"critical", "high", "medium", "low", "warning"} {
+		if findings, ok := outputDetections[level]; ok {
+			for _, finding := range findings {
+				if !seenRules[finding.Rule.Id] {
+					seenRules[finding.Rule.Id] = true
+
+					rules = append(rules, sonarqube.Rule{
+						Id:                 finding.Rule.Id,
+						Name:               finding.Rule.Title,
+						Description:        finding.Rule.Description,
+						EngineId:           "bearer",
+						CleanCodeAttribute: "TRUSTWORTHY",
+						Type:               "VULNERABILITY",
+						Severity:           formatSeverity(level),
+						Impacts: []sonarqube.Impact{
+							{
+								SoftwareQuality: "SECURITY",
+								Severity:        formatImpactSeverity(level),
+							},
+						},
+					})
+				}
+
+				issues = append(issues, sonarqube.Issue{
+					RuleId: finding.Rule.Id,
+					PrimaryLocation: sonarqube.Location{
+						Message:  finding.Rule.MappedTitle(),
+						FilePath: finding.Filename,
+						TextRange: sonarqube.TextRange{
+							StartLine: finding.Sink.Start,
+							EndLine:   finding.Sink.End,
+						},
+					},
+				})
+			}
+		}
+	}
+
+	return sonarqube.SonarQubeOutput{
+		Rules:  rules,
+		Issues: issues,
+	}, nil
+}
+
+func formatSeverity(level string) string {
+	switch level {
+	case "critical":
+		return "BLOCKER"
+	case "high":
+		return "CRITICAL"
+	case "medium":
+		return "MAJOR"
+	case "low":
+		return "MINOR"
+	default:
+		return "INFO"
+	}
+}
+
+func formatImpactSeverity(level string) string {
+	switch level {
+	case "critical", "high":
+		return "HIGH"
+	case "medium":
+		return "MEDIUM"
+	default:
+		return "LOW"
+	}
+}
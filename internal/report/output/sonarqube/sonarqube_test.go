@@ -0,0 +1,44 @@
+package sonarqube_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/report/output/sonarqube"
+	util "github.com/bearer/bearer/internal/util/output"
+)
+
+func TestJuiceShopSonarQube(t *testing.T) {
+	securityOutput, err := os.ReadFile("testdata/juice-shop-security-report.json")
+	if err != nil {
+		t.Fatalf("failed to read file, err: %s", err)
+	}
+
+	var securityFindings map[string][]securitytypes.Finding
+	err = json.Unmarshal(securityOutput, &securityFindings)
+	if err != nil {
+		t.Fatalf("couldn't unmarshal file output: %s", err)
+	}
+
+	res, err := sonarqube.ReportSonarQube(securityFindings)
+	if err != nil {
+		t.Fatalf("failed to generate security output, err: %s", err)
+	}
+
+	output, err := util.ReportJSON(res)
+	if err != nil {
+		t.Fatalf("failed to generate JSON output, err: %s", err)
+	}
+
+	var prettyJSON bytes.Buffer
+	err = json.Indent(&prettyJSON, []byte(output), "", "\t")
+	if err != nil {
+		t.Fatalf("error indenting output, err: %s", err)
+	}
+	cupaloy.SnapshotT(t, prettyJSON.String())
+}
@@ -0,0 +1,42 @@
+package types
+
+// Based on the generic external issue import format described here:
+// https://docs.sonarsource.com/sonarqube/latest/analyzing-source-code/importing-external-issues/generic-issue-import-format/
+
+type Impact struct {
+	SoftwareQuality string `json:"softwareQuality"` // SECURITY
+	Severity        string `json:"severity"`        // HIGH, MEDIUM or LOW
+}
+
+type Rule struct {
+	Id                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Description        string   `json:"description"`
+	EngineId           string   `json:"engineId"`           // bearer
+	CleanCodeAttribute string   `json:"cleanCodeAttribute"` // TRUSTWORTHY
+	Type               string   `json:"type"`               // VULNERABILITY
+	Severity           string   `json:"severity"`           // BLOCKER, CRITICAL, MAJOR, MINOR or INFO
+	Impacts            []Impact `json:"impacts"`
+}
+
+type TextRange struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+type Location struct {
+	Message   string    `json:"message"`
+	FilePath  string    `json:"filePath"`
+	TextRange TextRange `json:"textRange"`
+}
+
+type Issue struct {
+	RuleId          string   `json:"ruleId"`
+	EffortMinutes   int      `json:"effortMinutes,omitempty"`
+	PrimaryLocation Location `json:"primaryLocation"`
+}
+
+type SonarQubeOutput struct {
+	Rules  []Rule  `json:"rules"`
+	Issues []Issue `json:"issues"`
+}
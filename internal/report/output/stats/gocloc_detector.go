@@ -4,12 +4,19 @@ import (
 	"time"
 
 	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/util/file"
 	"github.com/bearer/bearer/internal/util/output"
 
 	"github.com/hhatto/gocloc"
 	"github.com/schollz/progressbar/v3"
 )
 
+// shellLanguage is the name of shell scripts in linguist, which the scanner
+// and the rules use. gocloc names them BASH, Bourne Shell or Zsh instead.
+const shellLanguage = "Shell"
+
+var shellExtensions = []string{"bash", "sh", "zsh"}
+
 func GoclocDetectorOutput(path string, opts flag.Options) (*gocloc.Result, error) {
 	clocOpts := gocloc.NewClocOptions()
 	clocOpts.SkipDuplicated = true
@@ -24,6 +31,17 @@ func GoclocDetectorOutput(path string, opts flag.Options) (*gocloc.Result, error
 	}
 
 	languages := gocloc.NewDefinedLanguages()
+	languages.Langs[shellLanguage] = gocloc.NewLanguage(shellLanguage, []string{"#"}, [][]string{{"", ""}})
+	for _, extension := range shellExtensions {
+		gocloc.Exts[extension] = shellLanguage
+	}
+
+	for extension, language := range opts.ScanOptions.LanguageExtensions {
+		if name, ok := file.LanguageName(language); ok {
+			gocloc.Exts[extension] = name
+		}
+	}
+
 	processor := gocloc.NewProcessor(languages, clocOpts)
 
 	return processor.Analyze([]string{path})
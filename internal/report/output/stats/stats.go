@@ -47,7 +47,10 @@ func AddReportData(
 		})
 	}
 
-	dataGroupNames := getDataGroupNames(config, datatypes)
+	dataGroupNames, err := getDataGroupNames(config, datatypes)
+	if err != nil {
+		return err
+	}
 
 	numberOfDatabases := 0
 	numberOfExternalAPIs := 0
@@ -84,8 +87,13 @@ func AddReportData(
 	return nil
 }
 
-func getDataGroupNames(config settings.Config, dataTypes []types.DataType) []string {
-	dataCategories := db.DefaultWithContext(config.Scan.Context).DataCategories
+func getDataGroupNames(config settings.Config, dataTypes []types.DataType) ([]string, error) {
+	scanDB, err := db.DefaultForScan(config.Scan)
+	if err != nil {
+		return nil, err
+	}
+
+	dataCategories := scanDB.DataCategories
 	dataGroups := make(map[string]bool)
 	for _, dataType := range dataTypes {
 		for _, category := range dataCategories {
@@ -98,7 +106,7 @@ func getDataGroupNames(config settings.Config, dataTypes []types.DataType) []str
 		}
 	}
 
-	return maputil.SortedStringKeys(dataGroups)
+	return maputil.SortedStringKeys(dataGroups), nil
 }
 
 func AnythingFoundFor(statistics *types.Stats) bool {
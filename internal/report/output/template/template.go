@@ -0,0 +1,84 @@
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	gotemplate "text/template"
+
+	"github.com/fatih/color"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
+)
+
+var severityColorFns = map[string]func(x ...interface{}) string{
+	globaltypes.LevelCritical: color.New(color.FgRed).SprintFunc(),
+	globaltypes.LevelHigh:     color.New(color.FgHiRed).SprintFunc(),
+	globaltypes.LevelMedium:   color.New(color.FgYellow).SprintFunc(),
+	globaltypes.LevelLow:      color.New(color.FgBlue).SprintFunc(),
+	globaltypes.LevelWarning:  color.New(color.FgCyan).SprintFunc(),
+}
+
+// ReportTemplate renders the report data through the user supplied Go template
+func ReportTemplate(reportData *outputtypes.ReportData, config settings.Config) (string, error) {
+	content, err := os.ReadFile(config.Report.Template)
+	if err != nil {
+		return "", fmt.Errorf("error reading template file %s", err)
+	}
+
+	return Render(filepath.Base(config.Report.Template), string(content), reportData, config)
+}
+
+func Render(name string, content string, reportData *outputtypes.ReportData, config settings.Config) (string, error) {
+	tmpl, err := gotemplate.New(name).Funcs(funcMap(config)).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("error parsing template %s", err)
+	}
+
+	initialColorSetting := color.NoColor
+	if config.NoColor && !initialColorSetting {
+		color.NoColor = true
+	}
+	defer func() { color.NoColor = initialColorSetting }()
+
+	var output strings.Builder
+	if err := tmpl.Execute(&output, reportData); err != nil {
+		return "", fmt.Errorf("error executing template %s", err)
+	}
+
+	return output.String(), nil
+}
+
+func funcMap(config settings.Config) gotemplate.FuncMap {
+	return gotemplate.FuncMap{
+		// severityColor colors the text using the color of the given severity level
+		"severityColor": func(severity string, text string) string {
+			severityColorFn, ok := severityColorFns[severity]
+			if !ok {
+				return text
+			}
+			return severityColorFn(text)
+		},
+		// relativePath returns the path relative to the scan target
+		"relativePath": func(path string) string {
+			relativePath, err := filepath.Rel(config.Scan.Target, path)
+			if err != nil || strings.HasPrefix(relativePath, "..") {
+				return path
+			}
+			return relativePath
+		},
+		// truncate shortens the text to the given number of characters
+		"truncate": func(length int, text string) string {
+			runes := []rune(text)
+			if length < 0 || len(runes) <= length {
+				return text
+			}
+			return string(runes[:length]) + "…"
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}
+}
@@ -0,0 +1,60 @@
+package template_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
+	"github.com/bearer/bearer/internal/report/output/template"
+	outputtypes "github.com/bearer/bearer/internal/report/output/types"
+)
+
+const juiceShopTemplate = `{{- range $severity, $findings := .FindingsBySeverity }}
+{{- range $findings }}
+{{ severityColor $severity (upper $severity) }} {{ .Id }}: {{ .Title | truncate 30 }}
+  {{ relativePath .FullFilename }}:{{ .LineNumber }}
+{{- end }}
+{{- end }}
+`
+
+func TestJuiceShopTemplate(t *testing.T) {
+	securityOutput, err := os.ReadFile("testdata/juice-shop-security-report.json")
+	if err != nil {
+		t.Fatalf("failed to read file, err: %s", err)
+	}
+
+	var securityFindings map[string][]securitytypes.Finding
+	err = json.Unmarshal(securityOutput, &securityFindings)
+	if err != nil {
+		t.Fatalf("couldn't unmarshal file output: %s", err)
+	}
+
+	config := settings.Config{
+		NoColor: true,
+		Scan:    flag.ScanOptions{Target: "../../OWASP/juice-shop"},
+	}
+
+	output, err := template.Render(
+		"juice-shop.tmpl",
+		juiceShopTemplate,
+		&outputtypes.ReportData{FindingsBySeverity: securityFindings},
+		config,
+	)
+	if err != nil {
+		t.Fatalf("failed to render template, err: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, output)
+}
+
+func TestInvalidTemplate(t *testing.T) {
+	_, err := template.Render("invalid.tmpl", "{{ .Unknown }", &outputtypes.ReportData{}, settings.Config{})
+	if err == nil {
+		t.Fatal("expected an error for an invalid template")
+	}
+}
@@ -2,7 +2,10 @@ package types
 
 import (
 	dataflowtypes "github.com/bearer/bearer/internal/report/output/dataflow/types"
+	logstypes "github.com/bearer/bearer/internal/report/output/logs/types"
 	privacytypes "github.com/bearer/bearer/internal/report/output/privacy/types"
+	residencytypes "github.com/bearer/bearer/internal/report/output/residency/types"
+	ropatypes "github.com/bearer/bearer/internal/report/output/ropa/types"
 	saastypes "github.com/bearer/bearer/internal/report/output/saas/types"
 	securitytypes "github.com/bearer/bearer/internal/report/output/security/types"
 	statstypes "github.com/bearer/bearer/internal/report/output/stats/types"
@@ -17,19 +20,35 @@ type ReportData struct {
 	RawFindings               []securitytypes.RawFinding `json:"findings"`
 	FindingsBySeverity        map[string][]securitytypes.Finding
 	IgnoredFindingsBySeverity map[string][]securitytypes.IgnoredFinding
-	PrivacyReport             *privacytypes.Report
-	Stats                     *statstypes.Stats
-	SaasReport                *saastypes.BearerReport
-	ExpectedDetections        []securitytypes.ExpectedDetection
+	// ExceptedFindingsBySeverity are the findings whose risk was accepted
+	// in the configuration
+	ExceptedFindingsBySeverity map[string][]securitytypes.ExceptedFinding
+	PrivacyReport              *privacytypes.Report
+	RoPAReport                 *ropatypes.Report
+	LogsReport                 *logstypes.Report
+	ResidencyReport            *residencytypes.Report
+	Stats                      *statstypes.Stats
+	SaasReport                 *saastypes.BearerReport
+	ExpectedDetections         []securitytypes.ExpectedDetection
+	GateFailure                *securitytypes.GateFailure
+	FindingStream              FindingStream `json:"-" yaml:"-"`
+	CloudStreamID              string        `json:"-" yaml:"-"`
 }
 
+// FindingStream receives each finding as soon as the rule that produced it
+// has been evaluated, ahead of the report being formatted
+type FindingStream func(finding securitytypes.RawFinding) error
+
 type DataFlow struct {
-	Datatypes          []dataflowtypes.Datatype     `json:"data_types,omitempty" yaml:"data_types,omitempty"`
-	ExpectedDetections []dataflowtypes.RiskDetector `json:"expected_detections,omitempty" yaml:"expected_detections,omitempty"`
-	Risks              []dataflowtypes.RiskDetector `json:"risks,omitempty" yaml:"risks,omitempty"`
-	Components         []dataflowtypes.Component    `json:"components,omitempty" yaml:"components,omitempty"`
-	Dependencies       []dataflowtypes.Dependency   `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
-	Errors             []dataflowtypes.Error        `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Datatypes           []dataflowtypes.Datatype           `json:"data_types,omitempty" yaml:"data_types,omitempty"`
+	ExpectedDetections  []dataflowtypes.RiskDetector       `json:"expected_detections,omitempty" yaml:"expected_detections,omitempty"`
+	Risks               []dataflowtypes.RiskDetector       `json:"risks,omitempty" yaml:"risks,omitempty"`
+	Components          []dataflowtypes.Component          `json:"components,omitempty" yaml:"components,omitempty"`
+	Dependencies        []dataflowtypes.Dependency         `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Endpoints           []dataflowtypes.Endpoint           `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	Errors              []dataflowtypes.Error              `json:"errors,omitempty" yaml:"errors,omitempty"`
+	SuppressionWarnings []dataflowtypes.SuppressionWarning `json:"suppression_warnings,omitempty" yaml:"suppression_warnings,omitempty"`
+	Metadata            map[string]string                  `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
 type GenericFormatter interface {
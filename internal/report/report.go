@@ -1,6 +1,8 @@
 package report
 
 import (
+	"time"
+
 	"github.com/bearer/bearer/internal/report/dependencies"
 	"github.com/bearer/bearer/internal/report/detections"
 	"github.com/bearer/bearer/internal/report/detectors"
@@ -22,4 +24,5 @@ type Report interface {
 	AddDependency(detectorType detectors.Type, detectorLanguage detectors.Language, dependency dependencies.Dependency, source source.Source)
 	AddSecretLeak(secret secret.Secret, source source.Source)
 	AddError(filePath string, err error)
+	AddRuleTimeout(filePath string, ruleID string, timeout time.Duration)
 }
@@ -0,0 +1,6 @@
+package sampledata
+
+type Sample struct {
+	// the name of the data type the value looks like
+	Description string `json:"description" yaml:"description"`
+}
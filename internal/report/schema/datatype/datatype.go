@@ -12,12 +12,19 @@ import (
 	"github.com/bearer/bearer/internal/report/schema"
 	"github.com/bearer/bearer/internal/util/normalize_key"
 	"github.com/bearer/bearer/internal/util/pluralize"
+	"github.com/bearer/bearer/internal/util/purpose"
 )
 
 type ReportDataType interface {
 	AddDataType(detectionType detections.DetectionType, detectorType detectors.Type, generator nodeid.Generator, values map[parser.NodeID]*DataType, parent *parser.Node)
 }
 
+// purposeAnnotator is implemented by reports knowing the processing purpose
+// annotated on the code
+type purposeAnnotator interface {
+	PurposeFor(node *parser.Node) *purpose.Annotation
+}
+
 type DataType struct {
 	Node       *parser.Node
 	Name       string
@@ -236,6 +243,12 @@ func dataTypeToSchema[D DataTypable](report detections.ReportDetection, detectio
 				NormalizedFieldName:  normalizedFieldName,
 			},
 		)
+
+		if annotator, ok := report.(purposeAnnotator); ok {
+			if annotation := annotator.PurposeFor(dataType.GetNode()); annotation != nil {
+				report.AddDetection(detections.TypePurpose, detectorType, dataType.GetNode().Source(false), *annotation)
+			}
+		}
 	}
 
 	sortedProperties := SortStringMap(dataType.GetProperties())
@@ -38,6 +38,9 @@ type Source struct {
 	EndLineNumber     int    `json:"end_line_number,omitempty" yaml:"end_line_number,omitempty"`
 	EndColumnNumber   int    `json:"end_column_number,omitempty" yaml:"end_column_number,omitempty"`
 	Content           string `json:"content,omitempty" yaml:"content,omitempty"`
+	// Confidence is the confidence of the detection, when it differs from the
+	// confidence of its rule
+	Confidence string `json:"confidence,omitempty" yaml:"confidence,omitempty"`
 }
 
 type ReportSchema interface {
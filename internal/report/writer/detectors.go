@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"time"
 
 	classification "github.com/bearer/bearer/internal/classification"
 	classificationschema "github.com/bearer/bearer/internal/classification/schema"
 	zerolog "github.com/rs/zerolog/log"
+	sitter "github.com/smacker/go-tree-sitter"
 
 	"github.com/bearer/bearer/internal/parser"
 	"github.com/bearer/bearer/internal/parser/nodeid"
@@ -23,6 +25,8 @@ import (
 	"github.com/bearer/bearer/internal/report/source"
 
 	"github.com/bearer/bearer/internal/util/jsonlines"
+	"github.com/bearer/bearer/internal/util/purpose"
+	"github.com/bearer/bearer/internal/util/sanitization"
 )
 
 type StoredSchema struct {
@@ -41,9 +45,16 @@ type SchemaGroup struct {
 }
 
 type Detectors struct {
-	Classifier    *classification.Classifier
-	File          io.Writer
-	StoredSchemas *SchemaGroup
+	Classifier           *classification.Classifier
+	File                 io.Writer
+	StoredSchemas        *SchemaGroup
+	SanitizerAnnotations []string
+	// nodes covered by a sanitization contract in the last tree seen
+	sanitizedTree  *parser.Tree
+	sanitizedNodes []*sitter.Node
+	// purpose annotations in the last tree seen
+	purposeTree    *parser.Tree
+	purposeMatches []purpose.Match
 }
 
 func (report *Detectors) AddInterface(
@@ -65,6 +76,11 @@ func (report *Detectors) AddInterface(
 func (report *Detectors) AddDataType(detectionType detections.DetectionType, detectorType detectors.Type, idGenerator nodeid.Generator, values map[parser.NodeID]*datatype.DataType, parent *parser.Node) {
 	classifiedDatatypes := make(map[parser.NodeID]*datatype.ClassifiedDatatype, 0)
 	for nodeID, target := range values {
+		if report.isSanitized(target) {
+			continue
+		}
+		report.removeSanitizedProperties(target)
+
 		classification := report.Classifier.Schema.Classify(classificationschema.ClassificationRequest{
 			Value:        target.ToClassificationRequestDetection(),
 			Filename:     target.GetNode().Source(false).Filename,
@@ -81,6 +97,50 @@ func (report *Detectors) AddDataType(detectionType detections.DetectionType, det
 	}
 }
 
+// removeSanitizedProperties removes the properties covered by a sanitization
+// contract, such as the fields with a sanitizer annotation
+func (report *Detectors) removeSanitizedProperties(target datatype.DataTypable) {
+	for name, property := range target.GetProperties() {
+		if report.isSanitized(property) {
+			target.DeleteProperty(name)
+			continue
+		}
+
+		report.removeSanitizedProperties(property)
+	}
+}
+
+func (report *Detectors) isSanitized(target datatype.DataTypable) bool {
+	node := target.GetNode()
+	if node == nil {
+		return false
+	}
+
+	tree := node.Tree()
+	if tree != report.sanitizedTree {
+		report.sanitizedTree = tree
+		report.sanitizedNodes = sanitization.Find(tree.Sitter().RootNode(), tree.Input(), report.SanitizerAnnotations)
+	}
+
+	return sanitization.Covers(report.sanitizedNodes, node.Sitter())
+}
+
+// PurposeFor returns the processing purpose annotated on the code containing
+// the node, if any
+func (report *Detectors) PurposeFor(node *parser.Node) *purpose.Annotation {
+	if node == nil {
+		return nil
+	}
+
+	tree := node.Tree()
+	if tree != report.purposeTree {
+		report.purposeTree = tree
+		report.purposeMatches = purpose.Find(tree.Sitter().RootNode(), tree.Input())
+	}
+
+	return purpose.For(report.purposeMatches, node.Sitter())
+}
+
 func (report *Detectors) SchemaGroupBegin(detectorType detectors.Type, node *parser.Node, schema schema.Schema, source *source.Source, parent *parser.Node) {
 	if report.SchemaGroupIsOpen() {
 		zerolog.Warn().Msg("schema group already open")
@@ -223,6 +283,15 @@ func (report *Detectors) AddError(filePath string, err error) {
 	})
 }
 
+func (report *Detectors) AddRuleTimeout(filePath string, ruleID string, timeout time.Duration) {
+	report.Add(&detections.ErrorDetection{
+		Type:    detections.TypeError,
+		Message: fmt.Sprintf("rule %s skipped as it timed out after %s", ruleID, timeout),
+		File:    filePath,
+		RuleID:  ruleID,
+	})
+}
+
 func (report *Detectors) Add(data interface{}) {
 	detectionsToAdd := []interface{}{data}
 
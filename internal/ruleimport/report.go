@@ -0,0 +1,87 @@
+package ruleimport
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// LevelSkipped is a rule which couldn't be converted, and wasn't written
+	LevelSkipped = "skipped"
+	// LevelWarning is a part of a rule which was left out of the converted rule
+	LevelWarning = "warning"
+)
+
+type Report struct {
+	Files  []string `json:"files" yaml:"files"`
+	Issues []Issue  `json:"issues" yaml:"issues"`
+}
+
+type Issue struct {
+	File    string `json:"file" yaml:"file"`
+	Rule    string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	Level   string `json:"level" yaml:"level"`
+	Message string `json:"message" yaml:"message"`
+}
+
+func (report *Report) String() string {
+	var builder strings.Builder
+	builder.WriteString("Rule import\n\n")
+
+	file := ""
+	for _, issue := range report.Issues {
+		if issue.File != file {
+			if file != "" {
+				builder.WriteString("\n")
+			}
+			file = issue.File
+			fmt.Fprintf(&builder, "%s\n", file)
+		}
+
+		message := issue.Message
+		if issue.Rule != "" {
+			message = issue.Rule + ": " + message
+		}
+		fmt.Fprintf(&builder, "  %-8s %s\n", issue.Level, message)
+	}
+
+	if len(report.Issues) != 0 {
+		builder.WriteString("\n")
+	}
+
+	for _, file := range report.Files {
+		fmt.Fprintf(&builder, "Created %s\n", file)
+	}
+
+	fmt.Fprintf(
+		&builder,
+		"%s imported, %s skipped\n",
+		countOf(len(report.Files), "rule"),
+		countOf(report.count(LevelSkipped), "rule"),
+	)
+
+	return builder.String()
+}
+
+func countOf(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+func (report *Report) add(file, rule, level, message string) {
+	report.Issues = append(report.Issues, Issue{File: file, Rule: rule, Level: level, Message: message})
+}
+
+func (report *Report) count(level string) int {
+	count := 0
+	for _, issue := range report.Issues {
+		if issue.Level == level {
+			count++
+		}
+	}
+
+	return count
+}
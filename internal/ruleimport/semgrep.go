@@ -0,0 +1,689 @@
+package ruleimport
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+)
+
+// stringLiteralRegex matches the content of the string literals of the
+// supported languages, with their optional prefix such as f"" or r”
+const stringLiteralRegex = `(?s)\A[a-zA-Z]*(".*"|'.*'|` + "`.*`" + `)\z`
+
+var (
+	semgrepLanguages = map[string]string{
+		"c":          "c",
+		"cpp":        "c",
+		"c++":        "c",
+		"go":         "go",
+		"golang":     "go",
+		"java":       "java",
+		"javascript": "javascript",
+		"js":         "javascript",
+		"typescript": "javascript",
+		"ts":         "javascript",
+		"kotlin":     "kotlin",
+		"kt":         "kotlin",
+		"php":        "php",
+		"python":     "python",
+		"python3":    "python",
+		"py":         "python",
+		"ruby":       "ruby",
+		"rb":         "ruby",
+		"rust":       "rust",
+		"rs":         "rust",
+	}
+
+	semgrepSeverities = map[string]string{
+		"ERROR":    types.LevelHigh,
+		"WARNING":  types.LevelMedium,
+		"INFO":     types.LevelLow,
+		"CRITICAL": types.LevelCritical,
+		"HIGH":     types.LevelHigh,
+		"MEDIUM":   types.LevelMedium,
+		"LOW":      types.LevelLow,
+	}
+
+	// keys of a Semgrep rule which don't affect what it matches, and aren't
+	// converted
+	semgrepIgnoredKeys = []string{"fix", "fix-regex", "paths", "options", "min-version", "max-version"}
+
+	semgrepTokenPattern        = regexp.MustCompile(`\$\.\.\.[A-Z_][A-Z0-9_]*|"\.\.\."|'\.\.\.'|\.\.\.|\$[A-Z_][A-Z0-9_]*`)
+	semgrepMetavariablePattern = regexp.MustCompile(`\$(?:\.\.\.)?[A-Z_][A-Z0-9_]*`)
+	whitespacePattern          = regexp.MustCompile(`\s+`)
+	invalidNameCharPattern     = regexp.MustCompile(`[^a-z0-9_]+`)
+	cwePattern                 = regexp.MustCompile(`(?i)CWE-(\d+)`)
+	owaspPattern               = regexp.MustCompile(`A\d{2}:\d{4}`)
+)
+
+// errUnsupported is a Semgrep construct which can't be converted, so the rule
+// using it is skipped
+type errUnsupported struct {
+	message string
+}
+
+func (err errUnsupported) Error() string {
+	return err.message
+}
+
+func unsupported(format string, args ...any) error {
+	return errUnsupported{message: fmt.Sprintf(format, args...)}
+}
+
+type rule struct {
+	Patterns   []pattern   `yaml:"patterns"`
+	Languages  []string    `yaml:"languages"`
+	Severity   string      `yaml:"severity"`
+	Confidence string      `yaml:"confidence,omitempty"`
+	Auxiliary  []auxiliary `yaml:"auxiliary,omitempty"`
+	Metadata   metadata    `yaml:"metadata"`
+}
+
+type auxiliary struct {
+	ID       string    `yaml:"id"`
+	Patterns []pattern `yaml:"patterns"`
+}
+
+type pattern struct {
+	Pattern string   `yaml:"pattern"`
+	Filters []filter `yaml:"filters,omitempty"`
+}
+
+type filter struct {
+	Not       *filter `yaml:"not,omitempty"`
+	Variable  string  `yaml:"variable,omitempty"`
+	Detection string  `yaml:"detection,omitempty"`
+	Scope     string  `yaml:"scope,omitempty"`
+	Regex     string  `yaml:"regex,omitempty"`
+}
+
+type metadata struct {
+	Description        string   `yaml:"description"`
+	RemediationMessage string   `yaml:"remediation_message"`
+	CWEIDs             []string `yaml:"cwe_id,omitempty"`
+	OWASP              []string `yaml:"owasp,omitempty"`
+	DocumentationUrl   string   `yaml:"documentation_url,omitempty"`
+	ID                 string   `yaml:"id"`
+}
+
+// semgrepMatch is what a Semgrep rule matches: any of the positive patterns,
+// except where a negative pattern matches, with the metavariables matching
+// their regular expressions
+type semgrepMatch struct {
+	positives []string
+	negatives []string
+	regexes   map[string]string
+}
+
+// ImportSemgrep converts the Semgrep rules of a YAML file, or of the YAML
+// files of a directory, into Bearer rules written to the rules directory.
+// Rules using constructs which can't be converted are skipped and reported.
+// Existing files are never overwritten.
+func ImportSemgrep(source string, rulesDir string, namespace string) (*Report, error) {
+	if namespace != "" {
+		if problem := settings.RuleIDProblem(namespace + settings.RuleNamespaceSeparator + "rule"); problem != "" {
+			return nil, errors.New(problem)
+		}
+	}
+
+	var sourceFiles []string
+	err := filepath.WalkDir(source, func(filePath string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if dirEntry.IsDir() {
+			if filePath != source && strings.HasPrefix(dirEntry.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if extension := filepath.Ext(filePath); extension == ".yml" || extension == ".yaml" {
+			sourceFiles = append(sourceFiles, filePath)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read semgrep rules: %w", err)
+	}
+
+	if err := os.MkdirAll(rulesDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("could not create rules directory: %w", err)
+	}
+
+	report := &Report{}
+	for _, sourceFile := range sourceFiles {
+		if err := report.importSemgrepFile(sourceFile, rulesDir, namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+func (report *Report) importSemgrepFile(sourceFile string, rulesDir string, namespace string) error {
+	content, err := os.ReadFile(sourceFile)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", sourceFile, err)
+	}
+
+	var file struct {
+		Rules []map[string]any `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		report.add(sourceFile, "", LevelSkipped, fmt.Sprintf("not a valid YAML file: %s", err))
+		return nil
+	}
+
+	for _, semgrepRule := range file.Rules {
+		semgrepID, _ := semgrepRule["id"].(string)
+		if semgrepID == "" {
+			report.add(sourceFile, "", LevelSkipped, "rule without an id")
+			continue
+		}
+
+		rules, warnings, err := convertSemgrepRule(semgrepRule, namespace)
+		if err != nil {
+			var unsupportedErr errUnsupported
+			if !errors.As(err, &unsupportedErr) {
+				return err
+			}
+
+			report.add(sourceFile, semgrepID, LevelSkipped, err.Error())
+			continue
+		}
+
+		for _, warning := range warnings {
+			report.add(sourceFile, semgrepID, LevelWarning, warning)
+		}
+
+		for _, rule := range rules {
+			if err := report.writeRule(sourceFile, semgrepID, rulesDir, rule); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (report *Report) writeRule(sourceFile string, semgrepID string, rulesDir string, rule rule) error {
+	_, name := settings.SplitRuleID(rule.Metadata.ID)
+	rulePath := filepath.Join(rulesDir, name+".yml")
+
+	if _, err := os.Stat(rulePath); err == nil {
+		report.add(sourceFile, semgrepID, LevelSkipped, fmt.Sprintf("%s already exists", rulePath))
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	content, err := yaml.Marshal(rule)
+	if err != nil {
+		return fmt.Errorf("could not encode rule %s: %w", rule.Metadata.ID, err)
+	}
+
+	header := fmt.Sprintf("# Imported from Semgrep rule %s (%s)\n", semgrepID, sourceFile)
+	if err := os.WriteFile(rulePath, append([]byte(header), content...), 0644); err != nil {
+		return fmt.Errorf("could not write rule file: %w", err)
+	}
+
+	report.Files = append(report.Files, rulePath)
+	return nil
+}
+
+// convertSemgrepRule converts a Semgrep rule into one Bearer rule for each of
+// its languages, returning warnings for the parts which were left out
+func convertSemgrepRule(semgrepRule map[string]any, namespace string) ([]rule, []string, error) {
+	var warnings []string
+
+	if mode, _ := semgrepRule["mode"].(string); mode != "" && mode != "search" {
+		return nil, nil, unsupported("%s mode is not supported", mode)
+	}
+
+	for key := range semgrepRule {
+		if slices.Contains(semgrepIgnoredKeys, key) {
+			warnings = append(warnings, fmt.Sprintf("%s is not converted", key))
+		}
+	}
+	slices.Sort(warnings)
+
+	var languages []string
+	for _, semgrepLanguage := range stringList(semgrepRule["languages"]) {
+		language, supported := semgrepLanguages[strings.ToLower(semgrepLanguage)]
+		if !supported {
+			warnings = append(warnings, fmt.Sprintf("language %s is not supported", semgrepLanguage))
+			continue
+		}
+
+		if !slices.Contains(languages, language) {
+			languages = append(languages, language)
+		}
+	}
+	if len(languages) == 0 {
+		return nil, nil, unsupported("none of its languages are supported")
+	}
+
+	match, err := semgrepMatchOf(semgrepRule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	semgrepSeverity, _ := semgrepRule["severity"].(string)
+	severity, found := semgrepSeverities[strings.ToUpper(semgrepSeverity)]
+	if !found {
+		warnings = append(warnings, fmt.Sprintf("severity %s is not supported, using %s", semgrepSeverity, types.LevelMedium))
+		severity = types.LevelMedium
+	}
+
+	semgrepID, _ := semgrepRule["id"].(string)
+	message, _ := semgrepRule["message"].(string)
+	semgrepMetadata, _ := semgrepRule["metadata"].(map[string]any)
+
+	var rules []rule
+	for _, language := range languages {
+		name := ruleName(semgrepID)
+		if len(languages) > 1 {
+			name += "_" + language
+		}
+
+		id := name
+		if namespace != "" {
+			id = namespace + settings.RuleNamespaceSeparator + name
+		}
+
+		patterns, auxiliaries, patternWarnings, err := convertMatch(match, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, warning := range patternWarnings {
+			if !slices.Contains(warnings, warning) {
+				warnings = append(warnings, warning)
+			}
+		}
+
+		rules = append(rules, rule{
+			Patterns:   patterns,
+			Languages:  []string{language},
+			Severity:   severity,
+			Confidence: confidenceOf(semgrepMetadata),
+			Auxiliary:  auxiliaries,
+			Metadata:   metadataOf(id, message, semgrepMetadata),
+		})
+	}
+
+	return rules, warnings, nil
+}
+
+func semgrepMatchOf(semgrepRule map[string]any) (semgrepMatch, error) {
+	match := semgrepMatch{regexes: make(map[string]string)}
+
+	for _, key := range []string{"pattern-regex", "pattern-not-regex", "pattern-inside", "pattern-not-inside", "join", "r2c-internal-project-depends-on"} {
+		if _, found := semgrepRule[key]; found {
+			return match, unsupported("%s is not supported", key)
+		}
+	}
+
+	if pattern, found := semgrepRule["pattern"]; found {
+		text, ok := pattern.(string)
+		if !ok {
+			return match, unsupported("pattern must be a string")
+		}
+
+		match.positives = append(match.positives, text)
+	}
+
+	if either, found := semgrepRule["pattern-either"]; found {
+		positives, err := eitherPatterns(either)
+		if err != nil {
+			return match, err
+		}
+
+		match.positives = append(match.positives, positives...)
+	}
+
+	if patterns, found := semgrepRule["patterns"]; found {
+		items, ok := patterns.([]any)
+		if !ok {
+			return match, unsupported("patterns must be a list")
+		}
+
+		hasPositive := false
+		for _, item := range items {
+			operator, value, err := singleKey(item)
+			if err != nil {
+				return match, err
+			}
+
+			switch operator {
+			case "pattern", "pattern-either":
+				if hasPositive {
+					return match, unsupported("combining several patterns within patterns is not supported")
+				}
+				hasPositive = true
+
+				if operator == "pattern" {
+					text, ok := value.(string)
+					if !ok {
+						return match, unsupported("pattern must be a string")
+					}
+
+					match.positives = append(match.positives, text)
+					continue
+				}
+
+				positives, err := eitherPatterns(value)
+				if err != nil {
+					return match, err
+				}
+
+				match.positives = append(match.positives, positives...)
+			case "pattern-not":
+				text, ok := value.(string)
+				if !ok {
+					return match, unsupported("pattern-not must be a string")
+				}
+
+				match.negatives = append(match.negatives, text)
+			case "metavariable-regex":
+				options, _ := value.(map[string]any)
+				metavariable, _ := options["metavariable"].(string)
+				regex, _ := options["regex"].(string)
+				if metavariable == "" || regex == "" {
+					return match, unsupported("metavariable-regex needs a metavariable and a regex")
+				}
+
+				if _, err := regexp.Compile(regex); err != nil {
+					return match, unsupported("the regex of %s is not supported: %s", metavariable, err)
+				}
+
+				match.regexes[metavariable] = regex
+			default:
+				return match, unsupported("%s is not supported", operator)
+			}
+		}
+	}
+
+	if len(match.positives) == 0 {
+		return match, unsupported("no pattern, pattern-either or patterns")
+	}
+
+	return match, nil
+}
+
+func eitherPatterns(value any) ([]string, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, unsupported("pattern-either must be a list")
+	}
+
+	var result []string
+	for _, item := range items {
+		operator, value, err := singleKey(item)
+		if err != nil {
+			return nil, err
+		}
+
+		text, ok := value.(string)
+		if operator != "pattern" || !ok {
+			return nil, unsupported("%s within pattern-either is not supported", operator)
+		}
+
+		result = append(result, text)
+	}
+
+	return result, nil
+}
+
+func singleKey(item any) (string, any, error) {
+	values, ok := item.(map[string]any)
+	if !ok || len(values) != 1 {
+		return "", nil, unsupported("pattern operators must have a single key")
+	}
+
+	for key, value := range values {
+		return key, value, nil
+	}
+
+	return "", nil, nil
+}
+
+// convertMatch converts the patterns of a Semgrep rule. Negative patterns are
+// converted into filters on the metavariable they differ from a positive
+// pattern by, as Bearer doesn't exclude whole matches.
+func convertMatch(match semgrepMatch, id string) ([]pattern, []auxiliary, []string, error) {
+	var patterns []pattern
+	var auxiliaries []auxiliary
+	var warnings []string
+
+	convertedNegatives := make([]bool, len(match.negatives))
+	usedRegexes := make(map[string]bool)
+
+	for _, positive := range match.positives {
+		text, filters, err := convertPattern(positive)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for _, metavariable := range maputil.SortedStringKeys(match.regexes) {
+			if !containsMetavariable(positive, metavariable) {
+				continue
+			}
+
+			usedRegexes[metavariable] = true
+			filters = append(filters, filter{
+				Variable: strings.TrimPrefix(metavariable, "$"),
+				Regex:    `\A(?:` + match.regexes[metavariable] + `)`,
+			})
+		}
+
+		for i, negative := range match.negatives {
+			metavariable, difference, found := alignNegative(positive, negative)
+			if !found {
+				continue
+			}
+			convertedNegatives[i] = true
+
+			variable := strings.TrimPrefix(metavariable, "$")
+			negativeFilter, negativeAuxiliary, err := differenceFilter(variable, difference, fmt.Sprintf("%s_not_%d", id, i+1))
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			filters = append(filters, filter{Not: &negativeFilter})
+			if negativeAuxiliary != nil && !slices.ContainsFunc(auxiliaries, func(existing auxiliary) bool {
+				return existing.ID == negativeAuxiliary.ID
+			}) {
+				auxiliaries = append(auxiliaries, *negativeAuxiliary)
+			}
+		}
+
+		patterns = append(patterns, pattern{Pattern: text, Filters: filters})
+	}
+
+	for i, converted := range convertedNegatives {
+		if !converted {
+			return nil, nil, nil, unsupported(
+				"pattern-not %q doesn't differ from a pattern by a single metavariable",
+				normalizeWhitespace(match.negatives[i]),
+			)
+		}
+	}
+
+	for _, metavariable := range maputil.SortedStringKeys(match.regexes) {
+		if !usedRegexes[metavariable] {
+			warnings = append(warnings, fmt.Sprintf("metavariable-regex on %s, which no pattern uses, is not converted", metavariable))
+		}
+	}
+
+	return patterns, auxiliaries, warnings, nil
+}
+
+// convertPattern converts the Semgrep pattern syntax into the Bearer one,
+// returning the filters needed for string literal placeholders
+func convertPattern(semgrepPattern string) (string, []filter, error) {
+	if strings.Contains(semgrepPattern, "<...") {
+		return "", nil, unsupported("the deep expression operator <... ...> is not supported")
+	}
+
+	var filters []filter
+	stringCount := 0
+
+	text := semgrepTokenPattern.ReplaceAllStringFunc(semgrepPattern, func(token string) string {
+		switch {
+		case token == `"..."` || token == `'...'`:
+			stringCount++
+			variable := fmt.Sprintf("STRING%d", stringCount)
+			filters = append(filters, filter{Variable: variable, Regex: stringLiteralRegex})
+			return "$<" + variable + ">"
+		case token == "..." || strings.HasPrefix(token, "$..."):
+			return "$<...>"
+		default:
+			return "$<" + strings.TrimPrefix(token, "$") + ">"
+		}
+	})
+
+	return strings.TrimSpace(text) + "\n", filters, nil
+}
+
+// alignNegative finds the metavariable of the positive pattern which the
+// negative pattern replaces, when they are otherwise the same
+func alignNegative(positive string, negative string) (string, string, bool) {
+	positive = normalizeWhitespace(positive)
+	negative = normalizeWhitespace(negative)
+
+	for _, location := range semgrepMetavariablePattern.FindAllStringIndex(positive, -1) {
+		metavariable := positive[location[0]:location[1]]
+		if metavariable == "$_" || strings.HasPrefix(metavariable, "$...") || strings.Count(positive, metavariable) != 1 {
+			continue
+		}
+
+		prefix := positive[:location[0]]
+		suffix := positive[location[1]:]
+		if len(negative) <= len(prefix)+len(suffix) ||
+			!strings.HasPrefix(negative, prefix) ||
+			!strings.HasSuffix(negative, suffix) {
+			continue
+		}
+
+		return metavariable, strings.TrimSpace(negative[len(prefix) : len(negative)-len(suffix)]), true
+	}
+
+	return "", "", false
+}
+
+// differenceFilter matches the variable against the code the negative pattern
+// has in its place. Code with metavariables needs an auxiliary rule.
+func differenceFilter(variable string, difference string, auxiliaryID string) (filter, *auxiliary, error) {
+	if difference == `"..."` || difference == `'...'` {
+		return filter{Variable: variable, Regex: stringLiteralRegex}, nil, nil
+	}
+
+	if !semgrepTokenPattern.MatchString(difference) {
+		return filter{Variable: variable, Regex: `\A` + regexp.QuoteMeta(difference) + `\z`}, nil, nil
+	}
+
+	text, filters, err := convertPattern(difference)
+	if err != nil {
+		return filter{}, nil, err
+	}
+
+	return filter{Variable: variable, Detection: auxiliaryID, Scope: string(settings.CURSOR_SCOPE)},
+		&auxiliary{ID: auxiliaryID, Patterns: []pattern{{Pattern: text, Filters: filters}}},
+		nil
+}
+
+func containsMetavariable(semgrepPattern string, metavariable string) bool {
+	for _, found := range semgrepMetavariablePattern.FindAllString(semgrepPattern, -1) {
+		if found == metavariable {
+			return true
+		}
+	}
+
+	return false
+}
+
+func confidenceOf(semgrepMetadata map[string]any) string {
+	confidence, _ := semgrepMetadata["confidence"].(string)
+	confidence = strings.ToLower(confidence)
+	if !slices.Contains(types.Confidences, confidence) {
+		return ""
+	}
+
+	return confidence
+}
+
+func metadataOf(id string, message string, semgrepMetadata map[string]any) metadata {
+	message = strings.TrimSpace(message)
+	description, _, _ := strings.Cut(message, "\n")
+	if description == "" {
+		description = "Imported from Semgrep."
+	}
+
+	result := metadata{
+		Description:        description,
+		RemediationMessage: "## Description\n" + message + "\n",
+		ID:                 id,
+	}
+
+	for _, cwe := range stringList(semgrepMetadata["cwe"]) {
+		if match := cwePattern.FindStringSubmatch(cwe); match != nil && !slices.Contains(result.CWEIDs, match[1]) {
+			result.CWEIDs = append(result.CWEIDs, match[1])
+		}
+	}
+
+	for _, owasp := range stringList(semgrepMetadata["owasp"]) {
+		if category := owaspPattern.FindString(owasp); category != "" && !slices.Contains(result.OWASP, category) {
+			result.OWASP = append(result.OWASP, category)
+		}
+	}
+
+	if references := stringList(semgrepMetadata["references"]); len(references) != 0 {
+		result.DocumentationUrl = references[0]
+	}
+
+	return result
+}
+
+// stringList returns the strings of a value which is either a string or a list
+func stringList(value any) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		var result []string
+		for _, item := range value {
+			if text, ok := item.(string); ok {
+				result = append(result, text)
+			}
+		}
+
+		return result
+	}
+
+	return nil
+}
+
+// ruleName turns a Semgrep rule id, such as python.django.security.sql-injection,
+// into a Bearer rule name
+func ruleName(semgrepID string) string {
+	return strings.Trim(invalidNameCharPattern.ReplaceAllString(strings.ToLower(semgrepID), "_"), "_")
+}
+
+func normalizeWhitespace(value string) string {
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(value, " "))
+}
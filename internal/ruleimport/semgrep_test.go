@@ -0,0 +1,206 @@
+package ruleimport_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/ruleimport"
+	"github.com/bearer/bearer/internal/ruletest"
+	"github.com/bearer/bearer/internal/version_check"
+)
+
+func loadConfig(dir string) (settings.Config, error) {
+	err := commands.ScanFlags.BindForConfigInit(commands.NewScanCommand())
+	if err != nil {
+		return settings.Config{}, err
+	}
+
+	options, err := commands.ScanFlags.ToOptions([]string{})
+	if err != nil {
+		return settings.Config{}, err
+	}
+	options.DisableDefaultRules = true
+	options.ExternalRuleDir = []string{dir}
+
+	return settings.FromOptions(options, &version_check.VersionMeta{
+		Rules: version_check.RuleVersionMeta{
+			Packages: make(map[string]string),
+		},
+	})
+}
+
+func copyFixtures(t *testing.T, dir string) {
+	entries, err := os.ReadDir(filepath.Join("testdata", "fixtures"))
+	if err != nil {
+		t.Fatalf("failed to read fixtures: %s", err)
+	}
+
+	testdataDir := filepath.Join(dir, settings.TestdataDirName)
+	if err := os.MkdirAll(testdataDir, os.ModePerm); err != nil {
+		t.Fatalf("failed to create testdata directory: %s", err)
+	}
+
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join("testdata", "fixtures", entry.Name()))
+		if err != nil {
+			t.Fatalf("failed to read fixture: %s", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(testdataDir, entry.Name()), content, 0644); err != nil {
+			t.Fatalf("failed to write fixture: %s", err)
+		}
+	}
+}
+
+func TestImportedRulesPassTheirTests(t *testing.T) {
+	dir := t.TempDir()
+
+	report, err := ruleimport.ImportSemgrep(filepath.Join("testdata", "semgrep"), dir, "")
+	if err != nil {
+		t.Fatalf("failed to import rules: %s", err)
+	}
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "python_lang_security_os_system_injection.yml"),
+		filepath.Join(dir, "python_lang_security_weak_hash.yml"),
+		filepath.Join(dir, "python_lang_security_pickle_load.yml"),
+		filepath.Join(dir, "ruby_lang_security_eval.yml"),
+	}, report.Files)
+
+	copyFixtures(t, dir)
+
+	config, err := loadConfig(dir)
+	if err != nil {
+		t.Fatalf("failed to generate default scan settings: %s", err)
+	}
+
+	testReport, err := ruletest.Run(context.Background(), config, dir)
+	if err != nil {
+		t.Fatalf("failed to run rule tests: %s", err)
+	}
+
+	assert.Len(t, testReport.Rules, 4)
+	assert.False(t, testReport.Failed(), testReport.String())
+}
+
+func TestImportSemgrepReport(t *testing.T) {
+	dir := t.TempDir()
+
+	report, err := ruleimport.ImportSemgrep(filepath.Join("testdata", "semgrep"), dir, "")
+	if err != nil {
+		t.Fatalf("failed to import rules: %s", err)
+	}
+
+	pythonFile := filepath.Join("testdata", "semgrep", "python.yml")
+	rubyFile := filepath.Join("testdata", "semgrep", "ruby.yaml")
+
+	assert.Equal(t, []ruleimport.Issue{
+		{
+			File:    pythonFile,
+			Rule:    "python.django.sql-in-view",
+			Level:   ruleimport.LevelSkipped,
+			Message: "pattern-inside is not supported",
+		},
+		{
+			File:    pythonFile,
+			Rule:    "python.lang.security.taint",
+			Level:   ruleimport.LevelSkipped,
+			Message: "taint mode is not supported",
+		},
+		{
+			File:    rubyFile,
+			Rule:    "ruby.lang.security.eval",
+			Level:   ruleimport.LevelWarning,
+			Message: "fix is not converted",
+		},
+		{
+			File:    rubyFile,
+			Rule:    "ruby.lang.security.eval",
+			Level:   ruleimport.LevelWarning,
+			Message: "language generic is not supported",
+		},
+	}, report.Issues)
+
+	// importing again doesn't overwrite the rules
+	report, err = ruleimport.ImportSemgrep(rubyFile, dir, "")
+	if err != nil {
+		t.Fatalf("failed to import rules: %s", err)
+	}
+
+	assert.Empty(t, report.Files)
+	assert.Contains(t, report.Issues, ruleimport.Issue{
+		File:    rubyFile,
+		Rule:    "ruby.lang.security.eval",
+		Level:   ruleimport.LevelSkipped,
+		Message: filepath.Join(dir, "ruby_lang_security_eval.yml") + " already exists",
+	})
+}
+
+func TestImportSemgrepRule(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join("testdata", "semgrep", "python.yml")
+
+	_, err := ruleimport.ImportSemgrep(source, dir, "acme")
+	if err != nil {
+		t.Fatalf("failed to import rules: %s", err)
+	}
+
+	rule, err := os.ReadFile(filepath.Join(dir, "python_lang_security_os_system_injection.yml"))
+	if err != nil {
+		t.Fatalf("failed to read rule: %s", err)
+	}
+
+	assert.Equal(t, `# Imported from Semgrep rule python.lang.security.os-system-injection (`+source+`)
+patterns:
+    - pattern: |
+        os.system($<CMD>)
+      filters:
+        - not:
+            variable: CMD
+            regex: (?s)\A[a-zA-Z]*(".*"|'.*'|`+"`.*`"+`)\z
+languages:
+    - python
+severity: high
+confidence: medium
+metadata:
+    description: Command built from user input passed to os.system.
+    remediation_message: |
+        ## Description
+        Command built from user input passed to os.system.
+        Use subprocess.run with a list of arguments instead.
+    cwe_id:
+        - "78"
+    owasp:
+        - A03:2021
+    documentation_url: https://docs.python.org/3/library/subprocess.html
+    id: acme:python_lang_security_os_system_injection
+`, string(rule))
+
+	rule, err = os.ReadFile(filepath.Join(dir, "python_lang_security_pickle_load.yml"))
+	if err != nil {
+		t.Fatalf("failed to read rule: %s", err)
+	}
+
+	assert.Contains(t, string(rule), `        - not:
+            variable: DATA
+            detection: acme:python_lang_security_pickle_load_not_1
+            scope: cursor
+`)
+	assert.Contains(t, string(rule), `auxiliary:
+    - id: acme:python_lang_security_pickle_load_not_1
+      patterns:
+        - pattern: |
+            trusted($<...>)
+`)
+}
+
+func TestImportSemgrepInvalidNamespace(t *testing.T) {
+	_, err := ruleimport.ImportSemgrep(filepath.Join("testdata", "semgrep"), t.TempDir(), "bearer")
+	assert.ErrorContains(t, err, "the 'bearer' namespace is reserved for default rules")
+}
@@ -0,0 +1,20 @@
+import hashlib
+import os
+import pickle
+
+
+def handler(request):
+    # ruleid: python_lang_security_os_system_injection
+    os.system(request.args["cmd"])
+    # ok: python_lang_security_os_system_injection
+    os.system("ls")
+
+    # ruleid: python_lang_security_weak_hash
+    hashlib.md5(request.args["password"])
+    # ok: python_lang_security_weak_hash
+    hashlib.sha256(request.args["password"])
+
+    # ruleid: python_lang_security_pickle_load
+    pickle.loads(request.data)
+    # ok: python_lang_security_pickle_load
+    pickle.loads(trusted(request.data))
@@ -0,0 +1,8 @@
+# ruleid: ruby_lang_security_eval
+eval(params[:code])
+
+# ruleid: ruby_lang_security_eval
+instance_eval(params[:code])
+
+# ok: ruby_lang_security_eval
+safe_eval(params[:code])
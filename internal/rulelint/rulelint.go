@@ -0,0 +1,387 @@
+package rulelint
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/internal/classification"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/report/customdetectors"
+	"github.com/bearer/bearer/internal/scanner"
+	"github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+)
+
+type Report struct {
+	Files  int     `json:"files" yaml:"files"`
+	Issues []Issue `json:"issues" yaml:"issues"`
+}
+
+type Issue struct {
+	File    string `json:"file" yaml:"file"`
+	Rule    string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	Level   string `json:"level" yaml:"level"`
+	Message string `json:"message" yaml:"message"`
+}
+
+type ruleFile struct {
+	path       string
+	definition settings.RuleDefinition
+}
+
+// Run checks the rule files of the directory. Files are validated against the
+// schema when one is given, and the patterns of the rules that can be loaded
+// are compiled for their languages.
+func Run(config settings.Config, rulesDir string, schema *gojsonschema.Schema) (*Report, error) {
+	report := &Report{}
+	definitions := make(map[string]settings.RuleDefinition)
+	var ruleFiles []ruleFile
+
+	err := filepath.WalkDir(rulesDir, func(filePath string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if dirEntry.IsDir() {
+			if filePath != rulesDir && (strings.HasPrefix(dirEntry.Name(), ".") ||
+				dirEntry.Name() == settings.RecipesDirName ||
+				dirEntry.Name() == settings.TestdataDirName) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		ext := filepath.Ext(dirEntry.Name())
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(rulesDir, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		report.Files++
+		definition, ok := report.readRuleFile(relativePath, content, schema)
+		if !ok {
+			return nil
+		}
+
+		id := definition.Metadata.ID
+		if _, exists := definitions[id]; exists {
+			report.add(relativePath, id, LevelError, "duplicate rule id")
+			return nil
+		}
+
+		definitions[id] = definition
+		ruleFiles = append(ruleFiles, ruleFile{path: relativePath, definition: definition})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules: %w", err)
+	}
+
+	loadable := make(map[string]settings.RuleDefinition)
+	for _, ruleFile := range ruleFiles {
+		id := ruleFile.definition.Metadata.ID
+
+		report.checkDefinition(ruleFile.path, &ruleFile.definition)
+
+		problems := settings.RuleDefinitionProblems(definitions, &ruleFile.definition)
+		for _, problem := range problems {
+			report.add(ruleFile.path, id, LevelError, problem)
+		}
+
+		if len(problems) == 0 && !report.hasErrors(ruleFile.path) {
+			loadable[id] = ruleFile.definition
+		}
+	}
+
+	if err := report.compileRules(config, ruleFiles, loadable); err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(report.Issues, func(a, b Issue) int {
+		return strings.Compare(a.File, b.File)
+	})
+
+	return report, nil
+}
+
+// Failed tells whether any error was found
+func (report *Report) Failed() bool {
+	return report.count(LevelError) != 0
+}
+
+func (report *Report) String() string {
+	var builder strings.Builder
+	builder.WriteString("Rule lint\n\n")
+
+	file := ""
+	for _, issue := range report.Issues {
+		if issue.File != file {
+			if file != "" {
+				builder.WriteString("\n")
+			}
+			file = issue.File
+			fmt.Fprintf(&builder, "%s\n", file)
+		}
+
+		message := issue.Message
+		if issue.Rule != "" {
+			message = issue.Rule + ": " + message
+		}
+		fmt.Fprintf(&builder, "  %-8s %s\n", issue.Level, message)
+	}
+
+	if len(report.Issues) != 0 {
+		builder.WriteString("\n")
+	}
+
+	fmt.Fprintf(
+		&builder,
+		"%s, %s, %s\n",
+		countOf(report.Files, "rule file"),
+		countOf(report.count(LevelError), LevelError),
+		countOf(report.count(LevelWarning), LevelWarning),
+	)
+
+	return builder.String()
+}
+
+func countOf(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+func (report *Report) add(file, rule, level, message string) {
+	report.Issues = append(report.Issues, Issue{File: file, Rule: rule, Level: level, Message: message})
+}
+
+func (report *Report) count(level string) int {
+	count := 0
+	for _, issue := range report.Issues {
+		if issue.Level == level {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (report *Report) hasErrors(file string) bool {
+	for _, issue := range report.Issues {
+		if issue.File == file && issue.Level == LevelError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (report *Report) readRuleFile(
+	file string,
+	content []byte,
+	schema *gojsonschema.Schema,
+) (settings.RuleDefinition, bool) {
+	var definition settings.RuleDefinition
+	if err := yaml.Unmarshal(content, &definition); err != nil {
+		report.add(file, "", LevelError, fmt.Sprintf("invalid yaml: %s", err))
+		return definition, false
+	}
+
+	if schema != nil {
+		issues, err := settings.ValidateRuleSchema(schema, content)
+		if err != nil {
+			report.add(file, "", LevelError, fmt.Sprintf("could not validate against the schema: %s", err))
+		}
+
+		for _, issue := range issues {
+			report.add(file, "", LevelError, issue)
+		}
+	}
+
+	if definition.Metadata == nil || definition.Metadata.ID == "" {
+		report.add(file, "", LevelError, "metadata.id must be specified")
+		return definition, false
+	}
+
+	return definition, true
+}
+
+func (report *Report) checkDefinition(file string, definition *settings.RuleDefinition) {
+	id := definition.Metadata.ID
+	fail := func(message string) { report.add(file, id, LevelError, message) }
+	warn := func(message string) { report.add(file, id, LevelWarning, message) }
+
+	if len(definition.Languages) == 0 {
+		fail("languages must be specified")
+	}
+	for _, language := range definition.Languages {
+		if !settings.GetSupportedRuleLanguages()[language] {
+			fail(fmt.Sprintf("unsupported language '%s'", language))
+		}
+	}
+
+	if len(definition.Patterns) == 0 && !definition.DependencyCheck && len(definition.Detectors) == 0 {
+		fail("rule has no patterns")
+	}
+
+	seenPatterns := make(map[string]int)
+	for i, pattern := range definition.Patterns {
+		key := strings.TrimSpace(pattern.Pattern)
+		if previous, exists := seenPatterns[key]; exists {
+			fail(fmt.Sprintf("pattern %d is unreachable, it is the same as pattern %d", i+1, previous+1))
+			continue
+		}
+
+		seenPatterns[key] = i
+	}
+
+	referencedRuleIDs := filterRuleReferences(definition)
+	for _, auxiliary := range definition.Auxiliary {
+		if !referencedRuleIDs.Has(auxiliary.Id) && definition.SanitizerRuleID != auxiliary.Id {
+			fail(fmt.Sprintf("auxiliary rule '%s' is unreachable, it is not referenced by any filter", auxiliary.Id))
+		}
+	}
+
+	if definition.Type == customdetectors.TypeShared || definition.Type == customdetectors.TypeSanitizer {
+		return
+	}
+
+	if definition.Severity == "" {
+		fail("severity must be specified")
+	} else if !slices.Contains(types.Severities, definition.Severity) {
+		fail(fmt.Sprintf(
+			"invalid severity '%s', expected one of %s",
+			definition.Severity,
+			strings.Join(types.Severities, ", "),
+		))
+	}
+
+	metadata := definition.Metadata
+	if metadata.Description == "" {
+		fail("metadata.description must be specified")
+	}
+	if len(metadata.CWEIDs) == 0 {
+		warn("metadata.cwe_id is missing")
+	}
+	if metadata.RemediationMessage == "" {
+		warn("metadata.remediation_message is missing")
+	}
+	if metadata.DocumentationUrl == "" {
+		warn("metadata.documentation_url is missing")
+	}
+}
+
+// compileRules builds a scanner with each rule and the rules it depends on,
+// so that each error is reported for the rule causing it
+func (report *Report) compileRules(
+	config settings.Config,
+	ruleFiles []ruleFile,
+	definitions map[string]settings.RuleDefinition,
+) error {
+	if len(definitions) == 0 {
+		return nil
+	}
+
+	classifier, err := classification.NewClassifier(&classification.Config{Config: config})
+	if err != nil {
+		return err
+	}
+
+	for _, ruleFile := range ruleFiles {
+		id := ruleFile.definition.Metadata.ID
+		if _, loadable := definitions[id]; !loadable {
+			continue
+		}
+
+		enabledRules := make(map[string]struct{})
+		addDependencies(definitions, enabledRules, id)
+
+		ruleScanner, err := scanner.New(
+			classifier.Schema,
+			settings.BuildRules(definitions, enabledRules),
+			config.Scan.SanitizerAnnotations,
+			0,
+			0,
+			nil,
+		)
+		if err != nil {
+			report.add(ruleFile.path, id, LevelError, fmt.Sprintf("patterns don't compile: %s", err))
+			continue
+		}
+
+		ruleScanner.Close()
+	}
+
+	return nil
+}
+
+func addDependencies(definitions map[string]settings.RuleDefinition, enabledRules map[string]struct{}, id string) {
+	if _, added := enabledRules[id]; added {
+		return
+	}
+	enabledRules[id] = struct{}{}
+
+	for _, importedID := range definitions[id].Imports {
+		if _, exists := definitions[importedID]; exists {
+			addDependencies(definitions, enabledRules, importedID)
+		}
+	}
+}
+
+func filterRuleReferences(definition *settings.RuleDefinition) set.Set[string] {
+	result := set.New[string]()
+
+	var addFilters func(filters []settings.PatternFilter)
+	addFilters = func(filters []settings.PatternFilter) {
+		for _, filter := range filters {
+			if filter.Detection != "" {
+				result.Add(filter.Detection)
+			}
+			if filter.Not != nil {
+				addFilters([]settings.PatternFilter{*filter.Not})
+			}
+			addFilters(filter.Either)
+			addFilters(filter.Filters)
+		}
+	}
+
+	for _, pattern := range definition.Patterns {
+		addFilters(pattern.Filters)
+	}
+	for _, auxiliary := range definition.Auxiliary {
+		for _, pattern := range auxiliary.Patterns {
+			addFilters(pattern.Filters)
+		}
+		if auxiliary.SanitizerRuleID != "" {
+			result.Add(auxiliary.SanitizerRuleID)
+		}
+	}
+
+	return result
+}
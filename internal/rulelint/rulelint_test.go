@@ -0,0 +1,168 @@
+package rulelint_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/rulelint"
+	"github.com/bearer/bearer/internal/version_check"
+)
+
+const validRule = `patterns:
+  - |
+    Rails.application.configure do
+      $<!>config.force_ssl = false
+    end
+languages:
+  - ruby
+severity: low
+metadata:
+  description: "Force all incoming communication through SSL."
+  remediation_message: "Enable force_ssl"
+  documentation_url: https://docs.example.com/force_ssl
+  cwe_id:
+    - 319
+  id: ruby_force_ssl
+`
+
+const invalidRule = `patterns:
+  - pattern: logger.info($<X>)
+    filters:
+      - variable: Y
+        detection: datatype
+  - pattern: logger.info($<X>)
+    filters:
+      - variable: X
+        detection: datatype
+auxiliary:
+  - id: ruby_logger_unused
+    patterns:
+      - puts($<_>)
+languages:
+  - ruby
+severity: urgent
+metadata:
+  description: "Sensitive data logged"
+  id: ruby_logger
+`
+
+const uncompilableRule = `patterns:
+  - pattern: logger.info($<X>)
+    filters:
+      - variable: Y
+        detection: datatype
+languages:
+  - ruby
+severity: low
+metadata:
+  description: "Sensitive data logged"
+  remediation_message: "Don't log sensitive data"
+  documentation_url: https://docs.example.com/logger
+  cwe_id:
+    - 532
+  id: ruby_logger_unknown_variable
+`
+
+func lint(t *testing.T, files map[string]string) *rulelint.Report {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create rules dir, err: %s", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write rule, err: %s", err)
+		}
+	}
+
+	err := commands.ScanFlags.BindForConfigInit(commands.NewScanCommand())
+	if err != nil {
+		t.Fatalf("failed to bind flags: %s", err)
+	}
+
+	options, err := commands.ScanFlags.ToOptions([]string{})
+	if err != nil {
+		t.Fatalf("failed to generate default flags: %s", err)
+	}
+	options.DisableDefaultRules = true
+
+	config, err := settings.FromOptions(options, &version_check.VersionMeta{
+		Rules: version_check.RuleVersionMeta{
+			Packages: make(map[string]string),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate default scan settings: %s", err)
+	}
+
+	report, err := rulelint.Run(config, dir, nil)
+	if err != nil {
+		t.Fatalf("failed to lint rules: %s", err)
+	}
+
+	return report
+}
+
+func TestRunValid(t *testing.T) {
+	report := lint(t, map[string]string{
+		"force_ssl.yml":          validRule,
+		"testdata/fixture.yml":   "not: [valid",
+		"recipes/billing.yml":    "not: [valid",
+		"testdata/config.rb":     "Rails.application.configure do\nend\n",
+		"docs/force_ssl_docs.md": "# Force SSL",
+	})
+
+	assert.Equal(t, 1, report.Files)
+	assert.Empty(t, report.Issues)
+	assert.False(t, report.Failed())
+}
+
+func TestRunInvalid(t *testing.T) {
+	report := lint(t, map[string]string{
+		"logger.yml":    invalidRule,
+		"duplicate.yml": validRule,
+		"force_ssl.yml": validRule,
+		"broken.yml":    "patterns: [",
+	})
+
+	assert.Equal(t, 4, report.Files)
+	assert.ElementsMatch(t, []rulelint.Issue{
+		{File: "broken.yml", Level: rulelint.LevelError, Message: "invalid yaml: yaml: line 1: did not find expected node content"},
+		{File: "force_ssl.yml", Rule: "ruby_force_ssl", Level: rulelint.LevelError, Message: "duplicate rule id"},
+		{File: "logger.yml", Rule: "ruby_logger", Level: rulelint.LevelError, Message: "pattern 2 is unreachable, it is the same as pattern 1"},
+		{
+			File:    "logger.yml",
+			Rule:    "ruby_logger",
+			Level:   rulelint.LevelError,
+			Message: "auxiliary rule 'ruby_logger_unused' is unreachable, it is not referenced by any filter",
+		},
+		{
+			File:    "logger.yml",
+			Rule:    "ruby_logger",
+			Level:   rulelint.LevelError,
+			Message: "invalid severity 'urgent', expected one of critical, high, medium, low, warning",
+		},
+		{File: "logger.yml", Rule: "ruby_logger", Level: rulelint.LevelWarning, Message: "metadata.cwe_id is missing"},
+		{File: "logger.yml", Rule: "ruby_logger", Level: rulelint.LevelWarning, Message: "metadata.remediation_message is missing"},
+		{File: "logger.yml", Rule: "ruby_logger", Level: rulelint.LevelWarning, Message: "metadata.documentation_url is missing"},
+	}, report.Issues)
+	assert.True(t, report.Failed())
+}
+
+func TestRunUncompilable(t *testing.T) {
+	report := lint(t, map[string]string{
+		"logger.yml":    uncompilableRule,
+		"force_ssl.yml": validRule,
+	})
+
+	if assert.Len(t, report.Issues, 1) {
+		assert.Equal(t, "logger.yml", report.Issues[0].File)
+		assert.Equal(t, "ruby_logger_unknown_variable", report.Issues[0].Rule)
+		assert.Contains(t, report.Issues[0].Message, "unknown variable 'Y'")
+	}
+}
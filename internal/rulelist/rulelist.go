@@ -0,0 +1,184 @@
+package rulelist
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rodaine/table"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/report/customdetectors"
+	"github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/maputil"
+)
+
+// coreFramework is the framework part of the id of rules targeting the core
+// of a language, eg. ruby_lang_logger
+const coreFramework = "lang"
+
+type Rule struct {
+	ID          string   `json:"id" yaml:"id"`
+	Description string   `json:"description" yaml:"description"`
+	Languages   []string `json:"languages" yaml:"languages"`
+	Framework   string   `json:"framework,omitempty" yaml:"framework,omitempty"`
+	Severity    string   `json:"severity" yaml:"severity"`
+	CWEIDs      []string `json:"cwe_ids,omitempty" yaml:"cwe_ids,omitempty"`
+	OWASP       []string `json:"owasp,omitempty" yaml:"owasp,omitempty"`
+	Version     string   `json:"version,omitempty" yaml:"version,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+// Filter restricts the rules listed. Empty lists don't restrict the rules, and
+// the query matches the id or description of rules case-insensitively.
+type Filter struct {
+	Query      string
+	Languages  []string
+	Severities []string
+	CWEIDs     []string
+	Frameworks []string
+}
+
+// List returns the rules reported by the definitions matching the filter,
+// sorted by id. Shared, sanitizer and disabled rules don't report findings
+// so they are left out.
+func List(definitions map[string]settings.RuleDefinition, filter Filter) []Rule {
+	var result []Rule
+
+	for _, id := range maputil.SortedStringKeys(definitions) {
+		definition := definitions[id]
+		if definition.Metadata == nil ||
+			definition.Disabled ||
+			definition.Type == customdetectors.TypeShared ||
+			definition.Type == customdetectors.TypeSanitizer {
+			continue
+		}
+
+		rule := newRule(definition)
+		if filter.matches(rule) {
+			result = append(result, rule)
+		}
+	}
+
+	return result
+}
+
+func newRule(definition settings.RuleDefinition) Rule {
+	metadata := definition.Metadata
+
+	severity := definition.Severity
+	if severity == "" {
+		severity = types.LevelLow
+	}
+
+	return Rule{
+		ID:          metadata.ID,
+		Description: metadata.Description,
+		Languages:   definition.Languages,
+		Framework:   framework(metadata.ID, definition.Languages),
+		Severity:    severity,
+		CWEIDs:      settings.NormalizeCWEIDs(metadata.CWEIDs),
+		OWASP:       metadata.OWASP,
+		Version:     metadata.Version,
+		Deprecated:  metadata.DeprecatedBy != "",
+	}
+}
+
+// framework is the framework a rule targets, taken from its id for rules
+// following the lang_framework_rule_name convention, ignoring any namespace.
+// Rules targeting the core of a language have no framework.
+func framework(id string, languages []string) string {
+	_, name := settings.SplitRuleID(id)
+	parts := strings.SplitN(name, "_", 3)
+	if len(parts) != 3 || parts[1] == coreFramework {
+		return ""
+	}
+
+	if !slices.Contains(languages, parts[0]) {
+		return ""
+	}
+
+	return parts[1]
+}
+
+func (filter Filter) matches(rule Rule) bool {
+	if len(filter.Languages) != 0 && !containsAny(filter.Languages, rule.Languages) {
+		return false
+	}
+
+	if len(filter.Severities) != 0 && !containsAny(filter.Severities, []string{rule.Severity}) {
+		return false
+	}
+
+	if len(filter.Frameworks) != 0 && !containsAny(filter.Frameworks, []string{rule.Framework}) {
+		return false
+	}
+
+	if len(filter.CWEIDs) != 0 && !containsAny(settings.NormalizeCWEIDs(filter.CWEIDs), rule.CWEIDs) {
+		return false
+	}
+
+	if query := strings.ToLower(strings.TrimSpace(filter.Query)); query != "" {
+		return strings.Contains(strings.ToLower(rule.ID), query) ||
+			strings.Contains(strings.ToLower(rule.Description), query)
+	}
+
+	return true
+}
+
+func containsAny(wanted []string, values []string) bool {
+	for _, value := range values {
+		for _, wantedValue := range wanted {
+			if strings.EqualFold(strings.TrimSpace(wantedValue), value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// JSON returns the rules as a JSON array
+func JSON(rules []Rule) (string, error) {
+	if rules == nil {
+		rules = []Rule{}
+	}
+
+	content, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal rules: %w", err)
+	}
+
+	return string(content) + "\n", nil
+}
+
+// Table returns the rules as a human readable table
+func Table(rules []Rule) string {
+	result := &strings.Builder{}
+
+	tbl := table.New("Rule", "Languages", "Severity", "CWE", "Description").WithWriter(result)
+	for _, rule := range rules {
+		id := rule.ID
+		if rule.Deprecated {
+			id += " (deprecated)"
+		}
+
+		tbl.AddRow(
+			id,
+			strings.Join(rule.Languages, ", "),
+			rule.Severity,
+			strings.Join(rule.CWEIDs, ", "),
+			rule.Description,
+		)
+	}
+	tbl.Print()
+
+	if len(rules) == 1 {
+		result.WriteString("\n1 rule\n")
+	} else {
+		fmt.Fprintf(result, "\n%d rules\n", len(rules))
+	}
+
+	return result.String()
+}
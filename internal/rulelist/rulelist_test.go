@@ -0,0 +1,131 @@
+package rulelist_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/report/customdetectors"
+	"github.com/bearer/bearer/internal/rulelist"
+)
+
+func definition(id string, language string, severity string, cweIDs ...string) settings.RuleDefinition {
+	return settings.RuleDefinition{
+		Languages: []string{language},
+		Severity:  severity,
+		Metadata: &settings.RuleMetadata{
+			ID:          id,
+			Description: "Description of " + id,
+			CWEIDs:      cweIDs,
+		},
+	}
+}
+
+func ids(rules []rulelist.Rule) []string {
+	result := []string{}
+	for _, rule := range rules {
+		result = append(result, rule.ID)
+	}
+
+	return result
+}
+
+func TestList(t *testing.T) {
+	shared := definition("ruby_shared_params", "ruby", "")
+	shared.Type = customdetectors.TypeShared
+
+	definitions := map[string]settings.RuleDefinition{
+		"ruby_rails_logger":            definition("ruby_rails_logger", "ruby", "high", "532"),
+		"ruby_lang_weak_hash":          definition("ruby_lang_weak_hash", "ruby", "medium", "CWE-328"),
+		"javascript_express_sql":       definition("javascript_express_sql", "javascript", "critical", "89"),
+		"ruby_shared_params":           shared,
+		"custom_org_rule":              definition("custom_org_rule", "java", ""),
+		"javascript_lang_hardcoded_id": definition("javascript_lang_hardcoded_id", "javascript", "low", "798"),
+	}
+
+	tests := []struct {
+		name     string
+		filter   rulelist.Filter
+		expected []string
+	}{
+		{
+			name:     "no filter",
+			expected: []string{"custom_org_rule", "javascript_express_sql", "javascript_lang_hardcoded_id", "ruby_lang_weak_hash", "ruby_rails_logger"},
+		},
+		{
+			name:     "language",
+			filter:   rulelist.Filter{Languages: []string{"Ruby"}},
+			expected: []string{"ruby_lang_weak_hash", "ruby_rails_logger"},
+		},
+		{
+			name:     "severity defaults to low",
+			filter:   rulelist.Filter{Severities: []string{"low"}},
+			expected: []string{"custom_org_rule", "javascript_lang_hardcoded_id"},
+		},
+		{
+			name:     "cwe with prefix",
+			filter:   rulelist.Filter{CWEIDs: []string{"CWE-328", "89"}},
+			expected: []string{"javascript_express_sql", "ruby_lang_weak_hash"},
+		},
+		{
+			name:     "framework",
+			filter:   rulelist.Filter{Frameworks: []string{"rails", "express"}},
+			expected: []string{"javascript_express_sql", "ruby_rails_logger"},
+		},
+		{
+			name:     "query and language",
+			filter:   rulelist.Filter{Query: "LOGGER", Languages: []string{"ruby", "java"}},
+			expected: []string{"ruby_rails_logger"},
+		},
+		{
+			name:     "no match",
+			filter:   rulelist.Filter{Languages: []string{"php"}},
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ids(rulelist.List(definitions, test.filter)))
+		})
+	}
+}
+
+func TestListFields(t *testing.T) {
+	rules := rulelist.List(map[string]settings.RuleDefinition{
+		"acme:ruby_rails_audit_log": definition("acme:ruby_rails_audit_log", "ruby", "medium"),
+		"ruby_lang_weak_hash":       definition("ruby_lang_weak_hash", "ruby", "", "CWE-328"),
+		"ruby_rails_logger":         definition("ruby_rails_logger", "ruby", "high"),
+	}, rulelist.Filter{})
+
+	assert.Equal(t, []rulelist.Rule{
+		{
+			ID:          "acme:ruby_rails_audit_log",
+			Description: "Description of acme:ruby_rails_audit_log",
+			Languages:   []string{"ruby"},
+			Framework:   "rails",
+			Severity:    "medium",
+		},
+		{
+			ID:          "ruby_lang_weak_hash",
+			Description: "Description of ruby_lang_weak_hash",
+			Languages:   []string{"ruby"},
+			Severity:    "low",
+			CWEIDs:      []string{"328"},
+		},
+		{
+			ID:          "ruby_rails_logger",
+			Description: "Description of ruby_rails_logger",
+			Languages:   []string{"ruby"},
+			Framework:   "rails",
+			Severity:    "high",
+		},
+	}, rules)
+}
+
+func TestJSON(t *testing.T) {
+	content, err := rulelist.JSON(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]\n", content)
+}
@@ -0,0 +1,273 @@
+package rulenew
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/types"
+)
+
+var ruleNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Options describe the rule to generate. The severity defaults to medium.
+type Options struct {
+	ID          string
+	Language    string
+	Severity    string
+	Description string
+	CWEIDs      []string
+}
+
+// languageTemplate is the pattern stub of a language, and the fixture code it
+// does and doesn't match. The stub matches calls to a function named after the
+// rule, so that generated rules don't find each other's fixtures.
+type languageTemplate struct {
+	extension string
+	comment   string
+	pattern   string
+	header    string
+	footer    string
+	indent    string
+	finding   string
+	safe      string
+}
+
+var templates = map[string]languageTemplate{
+	"c": {
+		extension: ".c",
+		comment:   "//",
+		pattern:   "%s($<_>);",
+		header:    "int main(int argc, char **argv) {\n",
+		footer:    "}\n",
+		indent:    "  ",
+		finding:   "%s(argv[1]);",
+		safe:      "safe_call(argv[1]);",
+	},
+	"go": {
+		extension: ".go",
+		comment:   "//",
+		pattern:   "%s($<_>)",
+		header:    "package main\n\nimport \"net/http\"\n\nfunc handler(w http.ResponseWriter, r *http.Request) {\n",
+		footer:    "}\n",
+		indent:    "\t",
+		finding:   `%s(r.URL.Query().Get("name"))`,
+		safe:      `safeCall(r.URL.Query().Get("name"))`,
+	},
+	"java": {
+		extension: ".java",
+		comment:   "//",
+		pattern:   "$<_>.%s($<_>);",
+		header:    "public class Example {\n  public void handle(HttpServletRequest request) {\n",
+		footer:    "  }\n}\n",
+		indent:    "    ",
+		finding:   `service.%s(request.getParameter("name"));`,
+		safe:      `service.safeCall(request.getParameter("name"));`,
+	},
+	"javascript": {
+		extension: ".js",
+		comment:   "//",
+		pattern:   "%s($<_>)",
+		finding:   "%s(req.query.name)",
+		safe:      "safeCall(req.query.name)",
+	},
+	"kotlin": {
+		extension: ".kt",
+		comment:   "//",
+		pattern:   "$<_>.%s($<_>)",
+		header:    "fun handle(call: ApplicationCall) {\n",
+		footer:    "}\n",
+		indent:    "    ",
+		finding:   `service.%s(call.parameters["name"])`,
+		safe:      `service.safeCall(call.parameters["name"])`,
+	},
+	"php": {
+		extension: ".php",
+		comment:   "//",
+		pattern:   "%s($<_>);",
+		header:    "<?php\n\n",
+		finding:   `%s($_GET["name"]);`,
+		safe:      `safe_call($_GET["name"]);`,
+	},
+	"python": {
+		extension: ".py",
+		comment:   "#",
+		pattern:   "%s($<_>)",
+		finding:   `%s(request.args["name"])`,
+		safe:      `safe_call(request.args["name"])`,
+	},
+	"ruby": {
+		extension: ".rb",
+		comment:   "#",
+		pattern:   "%s($<_>)",
+		finding:   "%s(params[:name])",
+		safe:      "safe_call(params[:name])",
+	},
+	"rust": {
+		extension: ".rs",
+		comment:   "//",
+		pattern:   "%s($<_>)",
+		header:    "async fn handle(query: web::Query<Params>) {\n",
+		footer:    "}\n",
+		indent:    "    ",
+		finding:   "%s(&query.name);",
+		safe:      "safe_call(&query.name);",
+	},
+	"shell": {
+		extension: ".sh",
+		comment:   "#",
+		pattern:   "%s $<_>",
+		finding:   `%s "$1"`,
+		safe:      `safe_call "$1"`,
+	},
+	"sql": {
+		extension: ".sql",
+		comment:   "--",
+		pattern:   "SELECT %s($<_>)",
+		finding:   "SELECT %s(email) FROM users;",
+		safe:      "SELECT safe_call(email) FROM users;",
+	},
+}
+
+// Languages are the languages rules can be generated for
+func Languages() []string {
+	languages := maps.Keys(templates)
+	slices.Sort(languages)
+	return languages
+}
+
+// Generate writes a rule file and its annotated fixture into the rules
+// directory, returning their paths. Existing files are never overwritten.
+func Generate(rulesDir string, options Options) ([]string, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	template := templates[options.Language]
+	name := ruleName(options.ID)
+
+	rulePath := filepath.Join(rulesDir, name+".yml")
+	fixturePath := filepath.Join(rulesDir, settings.TestdataDirName, name+template.extension)
+
+	for _, filePath := range []string{rulePath, fixturePath} {
+		if _, err := os.Stat(filePath); err == nil {
+			return nil, fmt.Errorf("%s already exists", filePath)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fixturePath), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("could not create testdata directory: %w", err)
+	}
+
+	if err := os.WriteFile(rulePath, []byte(ruleContent(options, template)), 0644); err != nil {
+		return nil, fmt.Errorf("could not write rule file: %w", err)
+	}
+
+	if err := os.WriteFile(fixturePath, []byte(fixtureContent(options.ID, template)), 0644); err != nil {
+		return nil, fmt.Errorf("could not write fixture: %w", err)
+	}
+
+	return []string{rulePath, fixturePath}, nil
+}
+
+func (options *Options) validate() error {
+	if options.ID == "" {
+		return errors.New("a rule id is required")
+	}
+
+	if problem := settings.RuleIDProblem(options.ID); problem != "" {
+		return errors.New(problem)
+	}
+
+	if _, name := settings.SplitRuleID(options.ID); !ruleNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid rule id '%s'; rule names are lowercase letters, digits and '_'", options.ID)
+	}
+
+	if _, supported := templates[options.Language]; !supported {
+		return fmt.Errorf(
+			"unsupported language '%s'; supported languages: %s",
+			options.Language,
+			strings.Join(Languages(), ", "),
+		)
+	}
+
+	if options.Severity == "" {
+		options.Severity = types.LevelMedium
+	}
+
+	if !slices.Contains(types.Severities, options.Severity) {
+		return fmt.Errorf(
+			"invalid severity '%s'; supported values: %s",
+			options.Severity,
+			strings.Join(types.Severities, ", "),
+		)
+	}
+
+	if options.Description == "" {
+		options.Description = "Describe what this rule finds."
+	}
+
+	return nil
+}
+
+func ruleContent(options Options, template languageTemplate) string {
+	var s strings.Builder
+
+	s.WriteString("# Replace the pattern with the code this rule should find. $<_> matches any\n")
+	s.WriteString("# expression, and $<NAME> captures one to use in filters.\n")
+	s.WriteString("patterns:\n")
+	s.WriteString("  - pattern: |\n")
+	fmt.Fprintf(&s, "      %s\n", fmt.Sprintf(template.pattern, ruleName(options.ID)))
+	s.WriteString("languages:\n")
+	fmt.Fprintf(&s, "  - %s\n", options.Language)
+	fmt.Fprintf(&s, "severity: %s\n", options.Severity)
+	s.WriteString("metadata:\n")
+	fmt.Fprintf(&s, "  description: %q\n", options.Description)
+	s.WriteString("  remediation_message: |\n")
+	s.WriteString("    ## Description\n")
+	fmt.Fprintf(&s, "    %s\n", options.Description)
+	s.WriteString("\n")
+	s.WriteString("    ## Remediations\n")
+	s.WriteString("    - Explain how to fix the code found by this rule.\n")
+
+	if len(options.CWEIDs) != 0 {
+		s.WriteString("  cwe_id:\n")
+		for _, cweID := range settings.NormalizeCWEIDs(options.CWEIDs) {
+			fmt.Fprintf(&s, "    - %s\n", cweID)
+		}
+	}
+
+	fmt.Fprintf(&s, "  id: %s\n", options.ID)
+
+	return s.String()
+}
+
+// fixtureContent annotates one line the rule must find and one it must not,
+// so that `bearer rules test` passes for the generated rule
+func fixtureContent(ruleID string, template languageTemplate) string {
+	var s strings.Builder
+
+	s.WriteString(template.header)
+	fmt.Fprintf(&s, "%s%s ruleid: %s\n", template.indent, template.comment, ruleID)
+	fmt.Fprintf(&s, "%s%s\n", template.indent, fmt.Sprintf(template.finding, ruleName(ruleID)))
+	s.WriteString("\n")
+	fmt.Fprintf(&s, "%s%s ok: %s\n", template.indent, template.comment, ruleID)
+	fmt.Fprintf(&s, "%s%s\n", template.indent, template.safe)
+	s.WriteString(template.footer)
+
+	return s.String()
+}
+
+func ruleName(ruleID string) string {
+	_, name := settings.SplitRuleID(ruleID)
+	return name
+}
@@ -0,0 +1,172 @@
+package rulenew_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/rulenew"
+	"github.com/bearer/bearer/internal/ruletest"
+	"github.com/bearer/bearer/internal/version_check"
+)
+
+func loadConfig(dir string) (settings.Config, error) {
+	err := commands.ScanFlags.BindForConfigInit(commands.NewScanCommand())
+	if err != nil {
+		return settings.Config{}, err
+	}
+
+	options, err := commands.ScanFlags.ToOptions([]string{})
+	if err != nil {
+		return settings.Config{}, err
+	}
+	options.DisableDefaultRules = true
+	options.ExternalRuleDir = []string{dir}
+
+	return settings.FromOptions(options, &version_check.VersionMeta{
+		Rules: version_check.RuleVersionMeta{
+			Packages: make(map[string]string),
+		},
+	})
+}
+
+func TestGeneratedRulesPassTheirTests(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, language := range rulenew.Languages() {
+		_, err := rulenew.Generate(dir, rulenew.Options{
+			ID:          "acme:" + language + "_insecure_call",
+			Language:    language,
+			Description: "Insecure call.",
+			CWEIDs:      []string{"CWE-78"},
+		})
+		if err != nil {
+			t.Fatalf("failed to generate %s rule: %s", language, err)
+		}
+	}
+
+	config, err := loadConfig(dir)
+	if err != nil {
+		t.Fatalf("failed to generate default scan settings: %s", err)
+	}
+
+	report, err := ruletest.Run(context.Background(), config, dir)
+	if err != nil {
+		t.Fatalf("failed to run rule tests: %s", err)
+	}
+
+	assert.Len(t, report.Rules, len(rulenew.Languages()))
+	assert.False(t, report.Failed(), report.String())
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+
+	filePaths, err := rulenew.Generate(dir, rulenew.Options{
+		ID:          "ruby_insecure_call",
+		Language:    "ruby",
+		Severity:    "high",
+		Description: "Insecure call.",
+		CWEIDs:      []string{"CWE-78"},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate rule: %s", err)
+	}
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "ruby_insecure_call.yml"),
+		filepath.Join(dir, "testdata", "ruby_insecure_call.rb"),
+	}, filePaths)
+
+	rule, err := os.ReadFile(filePaths[0])
+	if err != nil {
+		t.Fatalf("failed to read rule: %s", err)
+	}
+
+	assert.Equal(t, `# Replace the pattern with the code this rule should find. $<_> matches any
+# expression, and $<NAME> captures one to use in filters.
+patterns:
+  - pattern: |
+      ruby_insecure_call($<_>)
+languages:
+  - ruby
+severity: high
+metadata:
+  description: "Insecure call."
+  remediation_message: |
+    ## Description
+    Insecure call.
+
+    ## Remediations
+    - Explain how to fix the code found by this rule.
+  cwe_id:
+    - 78
+  id: ruby_insecure_call
+`, string(rule))
+
+	fixture, err := os.ReadFile(filePaths[1])
+	if err != nil {
+		t.Fatalf("failed to read fixture: %s", err)
+	}
+
+	assert.Equal(t, `# ruleid: ruby_insecure_call
+ruby_insecure_call(params[:name])
+
+# ok: ruby_insecure_call
+safe_call(params[:name])
+`, string(fixture))
+
+	_, err = rulenew.Generate(dir, rulenew.Options{ID: "ruby_insecure_call", Language: "ruby"})
+	assert.ErrorContains(t, err, "ruby_insecure_call.yml already exists")
+}
+
+func TestGenerateInvalidOptions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options rulenew.Options
+		err     string
+	}{
+		{
+			name:    "missing id",
+			options: rulenew.Options{Language: "ruby"},
+			err:     "a rule id is required",
+		},
+		{
+			name:    "invalid name",
+			options: rulenew.Options{ID: "acme:Insecure-Call", Language: "ruby"},
+			err:     "invalid rule id 'acme:Insecure-Call'",
+		},
+		{
+			name:    "reserved namespace",
+			options: rulenew.Options{ID: "bearer:insecure_call", Language: "ruby"},
+			err:     "the 'bearer' namespace is reserved for default rules",
+		},
+		{
+			name:    "unsupported language",
+			options: rulenew.Options{ID: "insecure_call", Language: "cobol"},
+			err:     "unsupported language 'cobol'; supported languages: c, go, java, javascript, kotlin, php, python, ruby, rust",
+		},
+		{
+			name:    "invalid severity",
+			options: rulenew.Options{ID: "insecure_call", Language: "ruby", Severity: "urgent"},
+			err:     "invalid severity 'urgent'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			_, err := rulenew.Generate(dir, testCase.options)
+			assert.ErrorContains(t, err, testCase.err)
+
+			entries, _ := os.ReadDir(dir)
+			assert.Empty(t, entries)
+		})
+	}
+}
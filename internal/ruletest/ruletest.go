@@ -0,0 +1,366 @@
+package ruletest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bearer/bearer/internal/commands/process/filelist/files"
+	"github.com/bearer/bearer/internal/commands/process/orchestrator/work"
+	"github.com/bearer/bearer/internal/commands/process/orchestrator/worker"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/flag"
+	"github.com/bearer/bearer/internal/report/customdetectors"
+	"github.com/bearer/bearer/internal/report/output"
+	"github.com/bearer/bearer/internal/types"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// Fixtures are the files within `testdata` directories of the rules
+// directory. An annotation comment applies to the next line of code:
+//
+//	# ruleid: my_rule
+//	logger.info(user.email)
+//	# ok: my_rule
+//	logger.info(user.id)
+//
+// `ruleid:` marks a line the rule must report a finding on, and `ok:` a line
+// it must not. Several rules can be given, separated by commas.
+
+const (
+	StatusPassed   = "passed"
+	StatusFailed   = "failed"
+	StatusUntested = "untested"
+
+	annotationRuleID = "ruleid"
+	annotationOK     = "ok"
+)
+
+var annotationPattern = regexp.MustCompile(
+	`^\s*(?:#|//|/\*|<!--|--)\s*(` + annotationRuleID + `|` + annotationOK + `):\s*([^*>]+?)\s*(?:\*/|-->)?\s*$`,
+)
+
+type Report struct {
+	Rules []RuleResult `json:"rules" yaml:"rules"`
+}
+
+type RuleResult struct {
+	Rule   string `json:"rule" yaml:"rule"`
+	Status string `json:"status" yaml:"status"`
+	// locations, as `filename:line`, annotated with `ruleid:` but not found
+	MissingFindings []string `json:"missing_findings,omitempty" yaml:"missing_findings,omitempty"`
+	// locations found but not annotated with `ruleid:`
+	UnexpectedFindings []string `json:"unexpected_findings,omitempty" yaml:"unexpected_findings,omitempty"`
+}
+
+type fixture struct {
+	path     string
+	expected map[string]set.Set[string]
+	ok       map[string]set.Set[string]
+}
+
+// Run scans the fixtures of the rules directory with the rules of the config,
+// and reports for each rule whether the findings match the annotations
+func Run(ctx context.Context, config settings.Config, rulesDir string) (*Report, error) {
+	return run(ctx, config, rulesDir, true)
+}
+
+// run tests the rules of the config. Annotations of unknown rules are an error
+// when strict, and are otherwise ignored so that only some of the rules of the
+// directory can be tested.
+func run(ctx context.Context, config settings.Config, rulesDir string, strict bool) (*Report, error) {
+	fixtures, err := readFixtures(rulesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fixture := range fixtures {
+		for _, annotations := range []map[string]set.Set[string]{fixture.expected, fixture.ok} {
+			for ruleID, locations := range annotations {
+				if _, ok := config.Rules[ruleID]; !ok && strict {
+					return nil, fmt.Errorf("%s: unknown rule %s", locations.Items()[0], ruleID)
+				}
+			}
+		}
+	}
+
+	config.Scan.Target = rulesDir
+	config.Scan.Scanner = []string{flag.ScannerSAST}
+	config.Scan.Quiet = true
+	config.Report.Report = flag.ReportSecurity
+	config.Report.Severity = set.New[string]()
+	config.Report.Severity.AddAll(types.Severities)
+	config.IgnoredFingerprints = nil
+
+	scanWorker := worker.Worker{}
+	if _, err := scanWorker.Setup(config); err != nil {
+		return nil, fmt.Errorf("failed to setup scan worker: %w", err)
+	}
+
+	expected := make(map[string]set.Set[string])
+	ok := make(map[string]set.Set[string])
+	found := make(map[string]set.Set[string])
+	for _, fixture := range fixtures {
+		mergeLocations(expected, fixture.expected)
+		mergeLocations(ok, fixture.ok)
+
+		if err := scanFixture(ctx, &scanWorker, config, rulesDir, fixture.path, found); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &Report{}
+	for _, ruleID := range sortedRuleIDs(config.Rules) {
+		report.Rules = append(report.Rules, ruleResult(ruleID, expected[ruleID], ok[ruleID], found[ruleID]))
+	}
+
+	return report, nil
+}
+
+// Failed tells whether any rule doesn't match its annotations
+func (report *Report) Failed() bool {
+	for _, rule := range report.Rules {
+		if rule.Status == StatusFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (report *Report) String() string {
+	var builder strings.Builder
+	builder.WriteString("Rule tests\n\n")
+
+	passed := 0
+	tested := 0
+	for _, rule := range report.Rules {
+		switch rule.Status {
+		case StatusUntested:
+			fmt.Fprintf(&builder, "%s: no annotated fixtures\n", rule.Rule)
+			continue
+		case StatusPassed:
+			passed++
+		}
+		tested++
+
+		fmt.Fprintf(&builder, "%s: %s\n", rule.Rule, rule.Status)
+		if len(rule.MissingFindings) != 0 {
+			fmt.Fprintf(&builder, "  - missing findings at %s\n", strings.Join(rule.MissingFindings, ", "))
+		}
+		if len(rule.UnexpectedFindings) != 0 {
+			fmt.Fprintf(&builder, "  - unexpected findings at %s\n", strings.Join(rule.UnexpectedFindings, ", "))
+		}
+	}
+
+	fmt.Fprintf(&builder, "\n%d/%d tested rules passed\n", passed, tested)
+
+	return builder.String()
+}
+
+func ruleResult(ruleID string, expected, ok, found set.Set[string]) RuleResult {
+	result := RuleResult{Rule: ruleID, Status: StatusUntested}
+	if len(expected) == 0 && len(ok) == 0 {
+		return result
+	}
+
+	for _, location := range expected.Items() {
+		if !found.Has(location) {
+			result.MissingFindings = append(result.MissingFindings, location)
+		}
+	}
+	for _, location := range found.Items() {
+		if !expected.Has(location) {
+			result.UnexpectedFindings = append(result.UnexpectedFindings, location)
+		}
+	}
+	slices.SortFunc(result.MissingFindings, compareLocations)
+	slices.SortFunc(result.UnexpectedFindings, compareLocations)
+
+	result.Status = StatusPassed
+	if len(result.MissingFindings) != 0 || len(result.UnexpectedFindings) != 0 {
+		result.Status = StatusFailed
+	}
+
+	return result
+}
+
+// sortedRuleIDs are the ids of the rules reporting findings
+func sortedRuleIDs(rules map[string]*settings.Rule) []string {
+	var ruleIDs []string
+	for id, rule := range rules {
+		if rule.IsAuxilary || rule.Type != customdetectors.TypeRisk {
+			continue
+		}
+
+		ruleIDs = append(ruleIDs, id)
+	}
+	slices.Sort(ruleIDs)
+
+	return ruleIDs
+}
+
+func readFixtures(rulesDir string) ([]fixture, error) {
+	var fixtures []fixture
+	err := filepath.WalkDir(rulesDir, func(filePath string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if dirEntry.IsDir() {
+			if filePath != rulesDir && strings.HasPrefix(dirEntry.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(rulesDir, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		if !isFixture(relativePath) {
+			return nil
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		fixture := parseFixture(relativePath, content)
+		if len(fixture.expected) != 0 || len(fixture.ok) != 0 {
+			fixtures = append(fixtures, fixture)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	return fixtures, nil
+}
+
+func parseFixture(filePath string, content []byte) fixture {
+	result := fixture{
+		path:     filePath,
+		expected: make(map[string]set.Set[string]),
+		ok:       make(map[string]set.Set[string]),
+	}
+
+	var pendingKinds []string
+	var pendingRuleIDs []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+
+		if match := annotationPattern.FindStringSubmatch(line); match != nil {
+			for _, ruleID := range strings.Split(match[2], ",") {
+				pendingKinds = append(pendingKinds, match[1])
+				pendingRuleIDs = append(pendingRuleIDs, strings.TrimSpace(ruleID))
+			}
+
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		location := fmt.Sprintf("%s:%d", filePath, lineNumber)
+		for i, ruleID := range pendingRuleIDs {
+			annotations := result.expected
+			if pendingKinds[i] == annotationOK {
+				annotations = result.ok
+			}
+
+			addLocation(annotations, ruleID, location)
+		}
+
+		pendingKinds = nil
+		pendingRuleIDs = nil
+	}
+
+	return result
+}
+
+func scanFixture(
+	ctx context.Context,
+	scanWorker *worker.Worker,
+	config settings.Config,
+	rulesDir string,
+	filePath string,
+	found map[string]set.Set[string],
+) error {
+	reportFile, err := os.CreateTemp("", "rule-test-report.jsonl")
+	if err != nil {
+		return err
+	}
+	reportFile.Close()
+	defer os.Remove(reportFile.Name())
+
+	_, err = scanWorker.Scan(ctx, work.ProcessRequest{
+		File:       files.File{FilePath: filePath},
+		ReportPath: reportFile.Name(),
+		Repository: work.Repository{Dir: rulesDir},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", filePath, err)
+	}
+
+	reportData, err := output.GetData(types.Report{Path: reportFile.Name(), HasFiles: true}, config, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get findings for %s: %w", filePath, err)
+	}
+
+	for _, findings := range reportData.FindingsBySeverity {
+		for _, finding := range findings {
+			if finding.Rule != nil {
+				addLocation(found, finding.Rule.Id, fmt.Sprintf("%s:%d", filePath, finding.LineNumber))
+			}
+		}
+	}
+
+	return nil
+}
+
+// compareLocations orders locations by filename, then by line number
+func compareLocations(a, b string) int {
+	aFilename, aLine, _ := strings.Cut(a, ":")
+	bFilename, bLine, _ := strings.Cut(b, ":")
+	if aFilename != bFilename {
+		return strings.Compare(aFilename, bFilename)
+	}
+
+	aNumber, _ := strconv.Atoi(aLine)
+	bNumber, _ := strconv.Atoi(bLine)
+	return aNumber - bNumber
+}
+
+func addLocation(locations map[string]set.Set[string], ruleID string, location string) {
+	if _, exists := locations[ruleID]; !exists {
+		locations[ruleID] = set.New[string]()
+	}
+
+	locations[ruleID].Add(location)
+}
+
+func mergeLocations(target map[string]set.Set[string], source map[string]set.Set[string]) {
+	for ruleID, locations := range source {
+		for _, location := range locations.Items() {
+			addLocation(target, ruleID, location)
+		}
+	}
+}
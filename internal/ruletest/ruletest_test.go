@@ -0,0 +1,179 @@
+package ruletest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/commands"
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/ruletest"
+	"github.com/bearer/bearer/internal/version_check"
+)
+
+const rule = `patterns:
+  - |
+    Rails.application.configure do
+      $<!>config.force_ssl = false
+    end
+languages:
+  - ruby
+severity: low
+metadata:
+  description: "Force all incoming communication through SSL."
+  remediation_message: ""
+  cwe_id:
+    - 319
+  id: ruby_force_ssl
+`
+
+func writeRulesDir(t *testing.T, fixture string) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "force_ssl.yml"), []byte(rule), 0644); err != nil {
+		t.Fatalf("failed to write rule, err: %s", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "testdata"), 0755); err != nil {
+		t.Fatalf("failed to create testdata dir, err: %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "testdata", "config.rb"), []byte(fixture), 0644); err != nil {
+		t.Fatalf("failed to write fixture, err: %s", err)
+	}
+
+	return dir
+}
+
+func loadConfig(dir string, ruleIDs []string) (settings.Config, error) {
+	err := commands.ScanFlags.BindForConfigInit(commands.NewScanCommand())
+	if err != nil {
+		return settings.Config{}, err
+	}
+
+	options, err := commands.ScanFlags.ToOptions([]string{})
+	if err != nil {
+		return settings.Config{}, err
+	}
+	options.DisableDefaultRules = true
+	options.ExternalRuleDir = []string{dir}
+	if len(ruleIDs) != 0 {
+		options.OnlyRule = make(map[string]bool)
+		for _, id := range ruleIDs {
+			options.OnlyRule[id] = true
+		}
+	}
+
+	return settings.FromOptions(options, &version_check.VersionMeta{
+		Rules: version_check.RuleVersionMeta{
+			Packages: make(map[string]string),
+		},
+	})
+}
+
+func runTests(t *testing.T, dir string) *ruletest.Report {
+	config, err := loadConfig(dir, nil)
+	if err != nil {
+		t.Fatalf("failed to generate default scan settings: %s", err)
+	}
+
+	report, err := ruletest.Run(context.Background(), config, dir)
+	if err != nil {
+		t.Fatalf("failed to run the rule tests: %s", err)
+	}
+
+	return report
+}
+
+func TestRunPassed(t *testing.T) {
+	report := runTests(t, writeRulesDir(t, `Rails.application.configure do
+  # ruleid: ruby_force_ssl
+  config.force_ssl = false
+end
+
+Rails.application.configure do
+  # ok: ruby_force_ssl
+  config.force_ssl = true
+end
+`))
+
+	assert.Equal(t, []ruletest.RuleResult{{Rule: "ruby_force_ssl", Status: ruletest.StatusPassed}}, report.Rules)
+	assert.False(t, report.Failed())
+}
+
+func TestRunFailed(t *testing.T) {
+	report := runTests(t, writeRulesDir(t, `Rails.application.configure do
+  config.force_ssl = false
+end
+
+Rails.application.configure do
+  # ruleid: ruby_force_ssl
+  config.force_ssl = true
+end
+`))
+
+	assert.Equal(t, []ruletest.RuleResult{{
+		Rule:               "ruby_force_ssl",
+		Status:             ruletest.StatusFailed,
+		MissingFindings:    []string{"testdata/config.rb:7"},
+		UnexpectedFindings: []string{"testdata/config.rb:2"},
+	}}, report.Rules)
+	assert.True(t, report.Failed())
+}
+
+func TestWatch(t *testing.T) {
+	dir := writeRulesDir(t, `Rails.application.configure do
+  # ruleid: ruby_force_ssl
+  config.force_ssl = false
+end
+`)
+
+	var loadedRuleIDs [][]string
+	watcher := ruletest.NewWatcher(dir, func(ruleIDs []string) (settings.Config, error) {
+		loadedRuleIDs = append(loadedRuleIDs, ruleIDs)
+		return loadConfig(dir, ruleIDs)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var reports []*ruletest.Report
+	err := watcher.Watch(ctx, 10*time.Millisecond, func(report *ruletest.Report, err error) {
+		if err != nil {
+			t.Errorf("failed to run the rule tests: %s", err)
+			cancel()
+			return
+		}
+
+		reports = append(reports, report)
+		if len(reports) == 2 {
+			cancel()
+			return
+		}
+
+		fixture := `Rails.application.configure do
+  # ruleid: ruby_force_ssl
+  config.force_ssl = true
+end
+`
+		if err := os.WriteFile(filepath.Join(dir, "testdata", "config.rb"), []byte(fixture), 0644); err != nil {
+			t.Errorf("failed to write fixture, err: %s", err)
+			cancel()
+		}
+	})
+	if err != nil {
+		t.Fatalf("failed to watch the rule tests: %s", err)
+	}
+
+	assert.Equal(t, [][]string{nil, {"ruby_force_ssl"}}, loadedRuleIDs)
+	if assert.Len(t, reports, 2) {
+		assert.False(t, reports[0].Failed())
+		assert.Equal(t, []ruletest.RuleResult{{
+			Rule:            "ruby_force_ssl",
+			Status:          ruletest.StatusFailed,
+			MissingFindings: []string{"testdata/config.rb:3"},
+		}}, reports[1].Rules)
+	}
+}
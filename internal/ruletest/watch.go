@@ -0,0 +1,302 @@
+package ruletest
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// LoadFunc builds the config running the given rules of the rules directory,
+// or all of them when no rule ids are given
+type LoadFunc func(ruleIDs []string) (settings.Config, error)
+
+// Watcher runs the tests of a rules directory again whenever its rule files or
+// fixtures change. Only the rules affected by a change are reloaded and run,
+// and their results replace the previous ones in the report.
+type Watcher struct {
+	rulesDir string
+	load     LoadFunc
+	files    map[string]fileState
+	// rule ids and imports by rule file
+	ruleFiles map[string]ruleFileInfo
+	// rule ids annotated by fixture
+	fixtureRules map[string]set.Set[string]
+	refreshed    bool
+	report       *Report
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+type ruleFileInfo struct {
+	id      string
+	imports []string
+}
+
+func NewWatcher(rulesDir string, load LoadFunc) *Watcher {
+	return &Watcher{
+		rulesDir:     rulesDir,
+		load:         load,
+		files:        make(map[string]fileState),
+		ruleFiles:    make(map[string]ruleFileInfo),
+		fixtureRules: make(map[string]set.Set[string]),
+	}
+}
+
+// Watch runs every test, then polls the rules directory at the given interval
+// until the context is done, calling onReport after each run
+func (watcher *Watcher) Watch(ctx context.Context, interval time.Duration, onReport func(*Report, error)) error {
+	if _, err := watcher.refresh(); err != nil {
+		return err
+	}
+	onReport(watcher.runAll(ctx))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		changed, err := watcher.refresh()
+		if err != nil {
+			return err
+		}
+
+		if len(changed) == 0 {
+			continue
+		}
+
+		onReport(watcher.runAffected(ctx, changed))
+	}
+}
+
+func (watcher *Watcher) runAll(ctx context.Context) (*Report, error) {
+	config, err := watcher.load(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := run(ctx, config, watcher.rulesDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher.report = report
+	return report, nil
+}
+
+// runAffected runs the rules affected by the changed files. After a failed
+// run, the next one runs every rule again.
+func (watcher *Watcher) runAffected(ctx context.Context, changed []string) (*Report, error) {
+	if watcher.report == nil {
+		for _, filePath := range changed {
+			watcher.updateFile(filePath)
+		}
+
+		return watcher.runAll(ctx)
+	}
+
+	affected := watcher.affectedRules(changed)
+
+	var loadedIDs []string
+	for _, info := range watcher.ruleFiles {
+		if affected.Has(info.id) {
+			loadedIDs = append(loadedIDs, info.id)
+		}
+	}
+	slices.Sort(loadedIDs)
+
+	partial := &Report{}
+	if len(loadedIDs) != 0 {
+		config, err := watcher.load(loadedIDs)
+		if err != nil {
+			watcher.report = nil
+			return nil, err
+		}
+
+		if partial, err = run(ctx, config, watcher.rulesDir, false); err != nil {
+			watcher.report = nil
+			return nil, err
+		}
+	}
+
+	var results []RuleResult
+	for _, result := range watcher.report.Rules {
+		if !affected.Has(result.Rule) {
+			results = append(results, result)
+		}
+	}
+	results = append(results, partial.Rules...)
+	slices.SortFunc(results, func(a, b RuleResult) int { return strings.Compare(a.Rule, b.Rule) })
+
+	watcher.report = &Report{Rules: results}
+	return watcher.report, nil
+}
+
+// affectedRules are the rules defined or annotated in the changed files, both
+// before and after the change, and the rules importing them
+func (watcher *Watcher) affectedRules(changed []string) set.Set[string] {
+	affected := set.New[string]()
+
+	for _, filePath := range changed {
+		if info, exists := watcher.ruleFiles[filePath]; exists {
+			affected.Add(info.id)
+		}
+		if ruleIDs, exists := watcher.fixtureRules[filePath]; exists {
+			affected.AddAll(ruleIDs.Items())
+		}
+
+		watcher.updateFile(filePath)
+
+		if info, exists := watcher.ruleFiles[filePath]; exists {
+			affected.Add(info.id)
+		}
+		if ruleIDs, exists := watcher.fixtureRules[filePath]; exists {
+			affected.AddAll(ruleIDs.Items())
+		}
+	}
+
+	for added := true; added; {
+		added = false
+
+		for _, info := range watcher.ruleFiles {
+			if affected.Has(info.id) {
+				continue
+			}
+
+			for _, importedID := range info.imports {
+				if affected.Has(importedID) {
+					added = affected.Add(info.id) || added
+					break
+				}
+			}
+		}
+	}
+
+	return affected
+}
+
+// updateFile re-reads the rule ids defined or annotated in a file
+func (watcher *Watcher) updateFile(filePath string) {
+	delete(watcher.ruleFiles, filePath)
+	delete(watcher.fixtureRules, filePath)
+
+	content, err := os.ReadFile(filepath.Join(watcher.rulesDir, filepath.FromSlash(filePath)))
+	if err != nil {
+		return
+	}
+
+	if isFixture(filePath) {
+		fixture := parseFixture(filePath, content)
+
+		ruleIDs := set.New[string]()
+		for _, annotations := range []map[string]set.Set[string]{fixture.expected, fixture.ok} {
+			for ruleID := range annotations {
+				ruleIDs.Add(ruleID)
+			}
+		}
+		watcher.fixtureRules[filePath] = ruleIDs
+
+		return
+	}
+
+	var definition struct {
+		Imports  []string `yaml:"imports"`
+		Metadata *struct {
+			ID string `yaml:"id"`
+		} `yaml:"metadata"`
+	}
+	if err := yaml.Unmarshal(content, &definition); err != nil || definition.Metadata == nil || definition.Metadata.ID == "" {
+		return
+	}
+
+	watcher.ruleFiles[filePath] = ruleFileInfo{id: definition.Metadata.ID, imports: definition.Imports}
+}
+
+// refresh records the state of the rule files and fixtures, returning the
+// paths of the files added, changed or removed since the last refresh
+func (watcher *Watcher) refresh() ([]string, error) {
+	files := make(map[string]fileState)
+
+	err := filepath.WalkDir(watcher.rulesDir, func(filePath string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if dirEntry.IsDir() {
+			if filePath != watcher.rulesDir && strings.HasPrefix(dirEntry.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(watcher.rulesDir, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		ext := path.Ext(relativePath)
+		if !isFixture(relativePath) && ext != ".yml" && ext != ".yaml" {
+			return nil
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return err
+		}
+
+		files[relativePath] = fileState{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for filePath, state := range files {
+		if previous, exists := watcher.files[filePath]; !exists || previous != state {
+			changed = append(changed, filePath)
+		}
+	}
+	for filePath := range watcher.files {
+		if _, exists := files[filePath]; !exists {
+			changed = append(changed, filePath)
+		}
+	}
+	slices.Sort(changed)
+
+	watcher.files = files
+
+	if !watcher.refreshed {
+		watcher.refreshed = true
+		for _, filePath := range changed {
+			watcher.updateFile(filePath)
+		}
+
+		return nil, nil
+	}
+
+	return changed, nil
+}
+
+func isFixture(filePath string) bool {
+	return slices.Contains(strings.Split(path.Dir(filePath), "/"), settings.TestdataDirName)
+}
@@ -3,18 +3,37 @@ package ast
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	sitter "github.com/smacker/go-tree-sitter"
 
 	"github.com/bearer/bearer/internal/scanner/language"
 	"github.com/bearer/bearer/internal/scanner/ruleset"
+	"github.com/bearer/bearer/internal/util/purpose"
+	"github.com/bearer/bearer/internal/util/sanitization"
+	"github.com/bearer/bearer/internal/util/suppression"
 
 	"github.com/bearer/bearer/internal/scanner/ast/query"
 	"github.com/bearer/bearer/internal/scanner/ast/tree"
 )
 
+// Java has separate node types for line and block comments
+var commentTypes = []string{"comment", "line_comment", "block_comment"}
+
+// Linker finds the other files of the project calling the functions of the
+// file being analyzed, so that values passed to them can be followed
+type Linker interface {
+	// Depth returns the number of calls followed, with 1 following only direct
+	// calls to the file's functions
+	Depth() int
+	// Callers returns the content of the files calling any of the functions,
+	// excluding files already returned
+	Callers(functionNames []string) ([][]byte, error)
+}
+
 func Parse(
 	ctx context.Context,
 	language language.Language,
@@ -33,6 +52,8 @@ func ParseAndAnalyze(
 	language language.Language,
 	ruleSet *ruleset.Set,
 	querySet *query.Set,
+	sanitizerAnnotations []string,
+	linker Linker,
 	contentBytes []byte,
 ) (*tree.Tree, error) {
 	builder, err := parseBuilder(ctx, language, contentBytes, len(ruleSet.Rules()))
@@ -40,30 +61,118 @@ func ParseAndAnalyze(
 		return nil, err
 	}
 
-	if err := querySet.Query(ctx, builder, builder.SitterRootNode()); err != nil {
-		return nil, fmt.Errorf("error running ast queries: %w", err)
+	if err := analyzeFile(
+		ctx,
+		language,
+		ruleSet,
+		querySet,
+		sanitizerAnnotations,
+		builder,
+		builder.SitterRootNode(),
+		contentBytes,
+	); err != nil {
+		return nil, err
 	}
 
-	analyzer := language.NewAnalyzer(builder)
-	if err := analyzeNode(ctx, ruleSet, builder, analyzer, builder.SitterRootNode()); err != nil {
-		return nil, fmt.Errorf("error running language analysis: %w", err)
+	if linker != nil && linker.Depth() > 0 {
+		if err := linkFiles(ctx, language, ruleSet, querySet, sanitizerAnnotations, linker, builder); err != nil {
+			return nil, err
+		}
+
+		builder.LinkCalls()
 	}
 
 	return builder.Build(), nil
 }
 
+// linkFiles adds the files calling the functions of the analyzed file, and
+// then the files calling functions of those, up to the linker's depth
+func linkFiles(
+	ctx context.Context,
+	language language.Language,
+	ruleSet *ruleset.Set,
+	querySet *query.Set,
+	sanitizerAnnotations []string,
+	linker Linker,
+	builder *tree.Builder,
+) error {
+	functionCount := 0
+
+	for level := 0; level < linker.Depth(); level++ {
+		functionNames := builder.FunctionNames(functionCount)
+		functionCount = builder.FunctionCount()
+		if len(functionNames) == 0 {
+			return nil
+		}
+
+		callers, err := linker.Callers(functionNames)
+		if err != nil {
+			return fmt.Errorf("error finding callers: %w", err)
+		}
+
+		for _, contentBytes := range callers {
+			sitterTree, err := parse(ctx, language, contentBytes)
+			if err != nil {
+				return err
+			}
+
+			builder.AddLinkedFile(contentBytes, sitterTree.RootNode())
+
+			if err := analyzeFile(
+				ctx,
+				language,
+				ruleSet,
+				querySet,
+				sanitizerAnnotations,
+				builder,
+				sitterTree.RootNode(),
+				contentBytes,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func analyzeFile(
+	ctx context.Context,
+	language language.Language,
+	ruleSet *ruleset.Set,
+	querySet *query.Set,
+	sanitizerAnnotations []string,
+	builder *tree.Builder,
+	sitterRootNode *sitter.Node,
+	contentBytes []byte,
+) error {
+	if err := querySet.Query(ctx, builder, sitterRootNode); err != nil {
+		return fmt.Errorf("error running ast queries: %w", err)
+	}
+
+	analyzer := language.NewAnalyzer(builder)
+	if err := analyzeNode(ctx, ruleSet, builder, analyzer, sitterRootNode); err != nil {
+		return fmt.Errorf("error running language analysis: %w", err)
+	}
+
+	for _, node := range sanitization.Find(sitterRootNode, contentBytes, sanitizerAnnotations) {
+		builder.AddSanitized(node)
+	}
+
+	for _, match := range purpose.Find(sitterRootNode, contentBytes) {
+		builder.AddPurpose(match.Node, match.Annotation)
+	}
+
+	return nil
+}
+
 func parseBuilder(
 	ctx context.Context,
 	language language.Language,
 	contentBytes []byte,
 	ruleCount int,
 ) (*tree.Builder, error) {
-	parser := sitter.NewParser()
-	defer parser.Close()
-
-	parser.SetLanguage(language.SitterLanguage())
-
-	sitterTree, err := parser.ParseCtx(ctx, nil, contentBytes)
+	sitterTree, err := parse(ctx, language, contentBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +180,15 @@ func parseBuilder(
 	return tree.NewBuilder(contentBytes, sitterTree.RootNode(), ruleCount), nil
 }
 
+func parse(ctx context.Context, language language.Language, contentBytes []byte) (*sitter.Tree, error) {
+	parser := sitter.NewParser()
+	defer parser.Close()
+
+	parser.SetLanguage(language.SitterLanguage())
+
+	return parser.ParseCtx(ctx, nil, contentBytes)
+}
+
 func analyzeNode(
 	ctx context.Context,
 	ruleSet *ruleset.Set,
@@ -112,7 +230,7 @@ func addExpectedRules(
 	expectedRules []*ruleset.Rule,
 	node *sitter.Node,
 ) []*ruleset.Rule {
-	if node.Type() == "comment" {
+	if slices.Contains(commentTypes, node.Type()) {
 		nextExpectedRules := expectedRules
 
 		nodeContent := builder.ContentFor(node)
@@ -144,21 +262,26 @@ func addDisabledRules(
 	disabledRules []*ruleset.Rule,
 	node *sitter.Node,
 ) []*ruleset.Rule {
-	if node.Type() == "comment" {
+	if slices.Contains(commentTypes, node.Type()) {
 		nextDisabledRules := disabledRules
 
-		nodeContent := builder.ContentFor(node)
-		if strings.Contains(nodeContent, "bearer:disable") {
-			rawRuleIDs := strings.Split(nodeContent, "bearer:disable")[1]
+		if disable, ok := suppression.Parse(builder.ContentFor(node)); ok {
+			warning, active := disable.Check(time.Now())
 
-			for _, ruleID := range strings.Split(rawRuleIDs, ",") {
-				rule, err := ruleSet.RuleByID(strings.TrimSpace(ruleID))
+			for _, ruleID := range disable.RuleIDs {
+				rule, err := ruleSet.RuleByID(ruleID)
 				if err != nil {
 					log.Debug().Msgf("ignoring unknown disabled rule '%s': %s", ruleID, err)
 					continue
 				}
 
-				nextDisabledRules = append(nextDisabledRules, rule)
+				if warning != "" {
+					builder.AddSuppressionWarning(node, rule, warning)
+				}
+
+				if active {
+					nextDisabledRules = append(nextDisabledRules, rule)
+				}
 			}
 		}
 
@@ -2,6 +2,7 @@ package ast_test
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/bradleyjkemp/cupaloy"
@@ -62,6 +63,8 @@ func TestDisabledRules(t *testing.T) {
 		language,
 		ruleSet,
 		querySet,
+		nil,
+		nil,
 		[]byte(content),
 	)
 
@@ -114,6 +117,8 @@ func TestExpectedRules(t *testing.T) {
 		language,
 		ruleSet,
 		querySet,
+		nil,
+		nil,
 		[]byte(content),
 	)
 
@@ -127,3 +132,125 @@ func TestExpectedRules(t *testing.T) {
 		tree.RootNode().Dump(),
 	)
 }
+
+func TestSanitized(t *testing.T) {
+	content := `
+		# bearer:sanitized
+		def mask(a)
+			a.bar
+		end
+
+		mask(b)
+		c.bar
+	`
+
+	language := ruby.Get()
+
+	ruleSet, err := ruleset.New(language.ID(), map[string]*settings.Rule{})
+	if err != nil {
+		t.Fatalf("failed to create rule set: %s", err)
+	}
+
+	querySet := query.NewSet(language.ID(), language.SitterLanguage())
+	if err := querySet.Compile(); err != nil {
+		t.Fatalf("failed to compile query set: %s", err)
+	}
+
+	tree, err := ast.ParseAndAnalyze(
+		context.Background(),
+		language,
+		ruleSet,
+		querySet,
+		nil,
+		nil,
+		[]byte(content),
+	)
+
+	if err != nil {
+		t.Fatalf("failed to parse and analyze input: %s", err)
+	}
+
+	cupaloy.SnapshotT(t, tree.RootNode().Dump())
+}
+
+type testLinker struct {
+	depth   int
+	callers [][]byte
+}
+
+func (linker *testLinker) Depth() int {
+	return linker.depth
+}
+
+func (linker *testLinker) Callers(functionNames []string) ([][]byte, error) {
+	callers := linker.callers
+	linker.callers = nil
+	return callers, nil
+}
+
+func TestLinkedFiles(t *testing.T) {
+	content := `
+		def find_user(id, other)
+			query(id)
+		end
+
+		def ambiguous(value)
+		end
+	`
+
+	callerContent := `
+		def show
+			find_user(params[:id], *rest)
+			ambiguous(params[:name])
+		end
+
+		def ambiguous(value)
+		end
+	`
+
+	language := ruby.Get()
+
+	ruleSet, err := ruleset.New(language.ID(), map[string]*settings.Rule{})
+	if err != nil {
+		t.Fatalf("failed to create rule set: %s", err)
+	}
+
+	querySet := query.NewSet(language.ID(), language.SitterLanguage())
+	if err := querySet.Compile(); err != nil {
+		t.Fatalf("failed to compile query set: %s", err)
+	}
+
+	tree, err := ast.ParseAndAnalyze(
+		context.Background(),
+		language,
+		ruleSet,
+		querySet,
+		nil,
+		&testLinker{depth: 1, callers: [][]byte{[]byte(callerContent)}},
+		[]byte(content),
+	)
+
+	if err != nil {
+		t.Fatalf("failed to parse and analyze input: %s", err)
+	}
+
+	aliases := make(map[string][]string)
+	nodes := tree.Nodes()
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Linked() || node.Type() != "identifier" {
+			continue
+		}
+
+		for _, aliasOf := range node.AliasOf() {
+			if aliasOf.Linked() {
+				aliases[node.Content()] = append(aliases[node.Content()], aliasOf.Content())
+			}
+		}
+	}
+
+	expected := map[string][]string{"id": {"params[:id]"}}
+	if !reflect.DeepEqual(aliases, expected) {
+		t.Fatalf("expected parameters to be linked as %v, got %v", expected, aliases)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"slices"
 
 	"github.com/bearer/bearer/internal/scanner/ruleset"
+	"github.com/bearer/bearer/internal/util/purpose"
 	"github.com/bits-and-blooms/bitset"
 	sitter "github.com/smacker/go-tree-sitter"
 )
@@ -19,6 +20,25 @@ type Builder struct {
 	sitterRootNode *sitter.Node
 	sitterToNodeID map[*sitter.Node]int
 	ruleCount      int
+	// byteOffset is the position of the file being added within the content
+	byteOffset int
+	linked     bool
+	functions  []function
+	calls      []call
+}
+
+// function is a function definition, with the positional parameters values
+// passed to it can be followed into. A parameter of -1 can't be followed.
+type function struct {
+	name         string
+	parameterIDs []int
+}
+
+// call is a function call, with its positional arguments. An argument of -1
+// can't be followed.
+type call struct {
+	name        string
+	argumentIDs []int
 }
 
 func NewBuilder(contentBytes []byte, sitterRootNode *sitter.Node, ruleCount int) *Builder {
@@ -75,7 +95,94 @@ func (builder *Builder) ChildrenExcept(node, excludedNode *sitter.Node) []*sitte
 }
 
 func (builder *Builder) ContentFor(node *sitter.Node) string {
-	return node.Content(builder.contentBytes)
+	id, ok := builder.sitterToNodeID[node]
+	if !ok {
+		return node.Content(builder.contentBytes)
+	}
+
+	treeNode := &builder.nodes[id]
+	return string(builder.contentBytes[treeNode.ContentStart.Byte:treeNode.ContentEnd.Byte])
+}
+
+// AddLinkedFile adds the tree of another file of the project, so that values
+// can be followed into the scanned file through the calls made in it. Nodes of
+// the file are not part of the tree below the root node.
+func (builder *Builder) AddLinkedFile(contentBytes []byte, sitterRootNode *sitter.Node) {
+	builder.byteOffset = len(builder.contentBytes)
+	builder.contentBytes = append(builder.contentBytes[:builder.byteOffset:builder.byteOffset], contentBytes...)
+	builder.linked = true
+
+	builder.addNode(sitterRootNode)
+}
+
+// AddFunction records the definition of a function, with its positional
+// parameters. A nil parameter is one which values can't be followed into. The
+// node containing the name is marked as the definition of the function.
+func (builder *Builder) AddFunction(nameNode *sitter.Node, parameters []*sitter.Node) {
+	if id, ok := builder.sitterToNodeID[nameNode.Parent()]; ok {
+		builder.nodes[id].function = true
+	}
+
+	builder.functions = append(builder.functions, function{
+		name:         builder.ContentFor(nameNode),
+		parameterIDs: builder.optionalNodeIDs(parameters),
+	})
+}
+
+// AddCall records a call to a function, with its positional arguments. A nil
+// argument is one which can't be followed.
+func (builder *Builder) AddCall(nameNode *sitter.Node, arguments []*sitter.Node) {
+	builder.calls = append(builder.calls, call{
+		name:        builder.ContentFor(nameNode),
+		argumentIDs: builder.optionalNodeIDs(arguments),
+	})
+}
+
+// FunctionCount returns the number of function definitions recorded so far
+func (builder *Builder) FunctionCount() int {
+	return len(builder.functions)
+}
+
+// FunctionNames returns the names of the functions recorded since the given
+// count
+func (builder *Builder) FunctionNames(fromCount int) []string {
+	names := make([]string, 0, len(builder.functions)-fromCount)
+	for _, function := range builder.functions[fromCount:] {
+		if !slices.Contains(names, function.name) {
+			names = append(names, function.name)
+		}
+	}
+
+	return names
+}
+
+// LinkCalls aliases the parameters of functions to the arguments passed to
+// them. Calls are only linked to functions whose name is defined once, as
+// the function called can't be told apart otherwise.
+func (builder *Builder) LinkCalls() {
+	definitions := make(map[string][]*function)
+	for i := range builder.functions {
+		function := &builder.functions[i]
+		definitions[function.name] = append(definitions[function.name], function)
+	}
+
+	for _, call := range builder.calls {
+		functions := definitions[call.name]
+		if len(functions) != 1 {
+			continue
+		}
+
+		parameterIDs := functions[0].parameterIDs
+		for i, argumentID := range call.argumentIDs {
+			if i >= len(parameterIDs) {
+				break
+			}
+
+			if argumentID != -1 && parameterIDs[i] != -1 {
+				builder.aliasOf[parameterIDs[i]] = append(builder.aliasOf[parameterIDs[i]], argumentID)
+			}
+		}
+	}
 }
 
 func (builder *Builder) Dataflow(toNode *sitter.Node, fromNodes ...*sitter.Node) {
@@ -112,6 +219,46 @@ func (builder *Builder) AddDisabledRules(sitterNode *sitter.Node, rules []*rules
 	builder.addDisabledRulesForNode(builder.sitterToNodeID[sitterNode], rules)
 }
 
+// AddSuppressionWarning records a problem with the `bearer:disable` comment
+// of the node, for the given rule
+func (builder *Builder) AddSuppressionWarning(sitterNode *sitter.Node, rule *ruleset.Rule, message string) {
+	node := &builder.nodes[builder.sitterToNodeID[sitterNode]]
+	node.suppressionWarnings = append(node.suppressionWarnings, SuppressionWarning{RuleID: rule.ID(), Message: message})
+}
+
+// AddSanitized marks the node, and everything within it, as holding no
+// sensitive data
+func (builder *Builder) AddSanitized(sitterNode *sitter.Node) {
+	builder.addSanitizedForNode(builder.sitterToNodeID[sitterNode])
+}
+
+func (builder *Builder) addSanitizedForNode(nodeID int) {
+	node := &builder.nodes[nodeID]
+	if node.sanitized {
+		return
+	}
+
+	node.sanitized = true
+
+	for _, childID := range builder.children[nodeID] {
+		builder.addSanitizedForNode(childID)
+	}
+}
+
+// AddPurpose annotates the node, and everything within it, with a processing
+// purpose. Annotations added later take precedence.
+func (builder *Builder) AddPurpose(sitterNode *sitter.Node, annotation purpose.Annotation) {
+	builder.addPurposeForNode(builder.sitterToNodeID[sitterNode], &annotation)
+}
+
+func (builder *Builder) addPurposeForNode(nodeID int, annotation *purpose.Annotation) {
+	builder.nodes[nodeID].purpose = annotation
+
+	for _, childID := range builder.children[nodeID] {
+		builder.addPurposeForNode(childID, annotation)
+	}
+}
+
 func (builder *Builder) addExpectedRulesForNode(nodeID int, rules []*ruleset.Rule) {
 	node := &builder.nodes[nodeID]
 
@@ -145,6 +292,21 @@ func (builder *Builder) sitterToNodeIDs(nodes []*sitter.Node) []int {
 	return ids
 }
 
+func (builder *Builder) optionalNodeIDs(nodes []*sitter.Node) []int {
+	ids := make([]int, len(nodes))
+
+	for i, node := range nodes {
+		id, ok := builder.sitterToNodeID[node]
+		if node == nil || !ok {
+			id = -1
+		}
+
+		ids[i] = id
+	}
+
+	return ids
+}
+
 func (builder *Builder) QueryResult(queryID int, sitterNode *sitter.Node, result map[string]*sitter.Node) {
 	node := &builder.nodes[builder.sitterToNodeID[sitterNode]]
 
@@ -193,15 +355,16 @@ func (builder *Builder) addNode(sitterNode *sitter.Node) int {
 		ID:         id,
 		TypeID:     builder.internType(sitterType),
 		ContentStart: Position{
-			Byte:   int(sitterNode.StartByte()),
+			Byte:   builder.byteOffset + int(sitterNode.StartByte()),
 			Line:   int(startPoint.Row) + 1,
 			Column: int(startPoint.Column) + 1,
 		},
 		ContentEnd: Position{
-			Byte:   int(sitterNode.EndByte()),
+			Byte:   builder.byteOffset + int(sitterNode.EndByte()),
 			Line:   int(endPoint.Row) + 1,
 			Column: int(endPoint.Column) + 1,
 		},
+		linked: builder.linked,
 	})
 
 	builder.children[id] = builder.addChildren(id, sitterNode)
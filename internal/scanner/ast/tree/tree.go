@@ -8,6 +8,8 @@ import (
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v3"
+
+	"github.com/bearer/bearer/internal/util/purpose"
 )
 
 type Tree struct {
@@ -31,7 +33,12 @@ type Node struct {
 	dataflowSources,
 	aliasOf []*Node
 	expectedRules       []string
+	suppressionWarnings []SuppressionWarning
 	disabledRuleIndices *bitset.BitSet
+	sanitized           bool
+	linked              bool
+	function            bool
+	purpose             *purpose.Annotation
 	// FIXME: remove the need for this
 	sitterNode   *sitter.Node
 	queryResults map[int][]QueryResult
@@ -39,6 +46,12 @@ type Node struct {
 	ExecutingDetectors []int
 }
 
+// SuppressionWarning is a `bearer:disable` comment needing review for a rule
+type SuppressionWarning struct {
+	RuleID  string
+	Message string
+}
+
 type Position struct {
 	Byte,
 	Line,
@@ -142,6 +155,12 @@ func (node *Node) ExpectedRules() []string {
 	return node.expectedRules
 }
 
+// SuppressionWarnings returns the problems found with the `bearer:disable`
+// comment held by the node
+func (node *Node) SuppressionWarnings() []SuppressionWarning {
+	return node.suppressionWarnings
+}
+
 func (node *Node) RuleDisabled(index int) bool {
 	if node.disabledRuleIndices == nil {
 		return false
@@ -150,6 +169,36 @@ func (node *Node) RuleDisabled(index int) bool {
 	return node.disabledRuleIndices.Test(uint(index))
 }
 
+// Sanitized tells whether the node is covered by a sanitization contract, in
+// which case data types are not detected within it
+func (node *Node) Sanitized() bool {
+	return node.sanitized
+}
+
+// Linked tells whether the node belongs to another file of the project, added
+// to follow values passed to the functions of the scanned file
+func (node *Node) Linked() bool {
+	return node.linked
+}
+
+// Function returns the closest function definition containing the node, or nil
+// for code outside of any function
+func (node *Node) Function() *Node {
+	for current := node; current != nil; current = current.parent {
+		if current.function {
+			return current
+		}
+	}
+
+	return nil
+}
+
+// Purpose returns the processing purpose annotated on the node, or on the
+// closest node containing it
+func (node *Node) Purpose() *purpose.Annotation {
+	return node.purpose
+}
+
 func (node *Node) QueryResults(queryID int) []QueryResult {
 	if node.queryResults == nil {
 		return nil
@@ -168,6 +217,9 @@ type nodeDump struct {
 	Queries         []int      `yaml:",omitempty"`
 	DisabledRules   []int      `yaml:",omitempty"`
 	ExpectedRules   []string   `yaml:",omitempty"`
+	Sanitized       bool       `yaml:",omitempty"`
+	Linked          bool       `yaml:",omitempty"`
+	Purpose         string     `yaml:",omitempty"`
 	Children        []nodeDump `yaml:",omitempty"`
 }
 
@@ -204,6 +256,11 @@ func (node *Node) dumpValue() nodeDump {
 		expectedRules = append(expectedRules, node.expectedRules...)
 	}
 
+	purposeName := ""
+	if node.purpose != nil {
+		purposeName = node.purpose.Purpose
+	}
+
 	contentRange := fmt.Sprintf(
 		"%d:%d - %d:%d",
 		node.ContentStart.Line,
@@ -228,6 +285,9 @@ func (node *Node) dumpValue() nodeDump {
 		Queries:         queries,
 		DisabledRules:   disabledRules,
 		ExpectedRules:   expectedRules,
+		Sanitized:       node.sanitized,
+		Linked:          node.linked,
+		Purpose:         purposeName,
 	}
 }
 
@@ -28,7 +28,8 @@ type Shared struct {
 func NewShared(rules []*ruleset.Rule) *Shared {
 	ruleIndexSet := set.New[int]()
 	for _, rule := range rules {
-		if rule.Type() == ruleset.RuleTypeBuiltin || rule.Type() == ruleset.RuleTypeShared {
+		switch rule.Type() {
+		case ruleset.RuleTypeBuiltin, ruleset.RuleTypeShared, ruleset.RuleTypeSanitizer:
 			ruleIndexSet.Add(rule.Index())
 		}
 	}
@@ -0,0 +1,122 @@
+package callindex
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/internal/util/file"
+)
+
+// files larger than this are not indexed, as they are unlikely to be
+// hand-written code
+const maxFileSize = 1024 * 1024
+
+// Index lists the files of a project mentioning each identifier, so that the
+// files calling a function can be found without parsing every file
+type Index struct {
+	filenames []string
+	files     map[string][]int32
+}
+
+// New indexes the files below the root directory which are in one of the
+// given languages. Hidden directories and files skipped because of their name,
+// such as tests and dependencies, are not indexed.
+func New(rootDir string, enryLanguages []string) (*Index, error) {
+	index := &Index{files: make(map[string][]int32)}
+
+	err := filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			log.Debug().Msgf("skipping %s from call index due to err: %s", path, err)
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(rootDir, path)
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if relativePath != "." && (strings.HasPrefix(entry.Name(), ".") || file.IsIgnoredFilename(relativePath+"/")) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !entry.Type().IsRegular() || file.IsIgnoredFilename(relativePath) {
+			return nil
+		}
+
+		if language, _ := enry.GetLanguageByExtension(path); !slices.Contains(enryLanguages, language) {
+			return nil
+		}
+
+		if info, err := entry.Info(); err != nil || info.Size() > maxFileSize {
+			return nil
+		}
+
+		contentBytes, err := os.ReadFile(path)
+		if err != nil {
+			log.Debug().Msgf("skipping %s from call index due to err: %s", path, err)
+			return nil
+		}
+
+		index.add(path, contentBytes)
+		return nil
+	})
+
+	return index, err
+}
+
+// Callers returns the files mentioning any of the function names, in the order
+// they were indexed
+func (index *Index) Callers(functionNames []string) []string {
+	var fileIDs []int32
+	for _, name := range functionNames {
+		fileIDs = append(fileIDs, index.files[identifierFor(name)]...)
+	}
+
+	slices.Sort(fileIDs)
+	fileIDs = slices.Compact(fileIDs)
+
+	filenames := make([]string, len(fileIDs))
+	for i, fileID := range fileIDs {
+		filenames[i] = index.filenames[fileID]
+	}
+
+	return filenames
+}
+
+func (index *Index) add(filename string, contentBytes []byte) {
+	fileID := int32(len(index.filenames))
+	index.filenames = append(index.filenames, filename)
+
+	seen := make(map[string]struct{})
+	for _, identifier := range strings.FieldsFunc(string(contentBytes), isSeparator) {
+		if _, ok := seen[identifier]; ok {
+			continue
+		}
+
+		seen[identifier] = struct{}{}
+		index.files[identifier] = append(index.files[identifier], fileID)
+	}
+}
+
+// identifierFor strips the suffixes Ruby allows in method names, eg. `valid?`
+func identifierFor(functionName string) string {
+	return strings.TrimRight(functionName, "?!")
+}
+
+func isSeparator(char rune) bool {
+	return !(char == '_' ||
+		(char >= 'a' && char <= 'z') ||
+		(char >= 'A' && char <= 'Z') ||
+		(char >= '0' && char <= '9') ||
+		char > 127)
+}
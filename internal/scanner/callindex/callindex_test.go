@@ -0,0 +1,42 @@
+package callindex_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/scanner/callindex"
+)
+
+func TestCallers(t *testing.T) {
+	rootDir := t.TempDir()
+	files := map[string]string{
+		"app/controller.rb":       "find_user(params[:id])",
+		"app/other.rb":            "valid?(x)",
+		"app/script.py":           "find_user(request)",
+		"spec/controller_spec.rb": "find_user(1)",
+		".hidden/controller.rb":   "find_user(1)",
+	}
+
+	for filename, content := range files {
+		path := filepath.Join(rootDir, filename)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create directory: %s", err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write file: %s", err)
+		}
+	}
+
+	index, err := callindex.New(rootDir, []string{"Ruby"})
+	if err != nil {
+		t.Fatalf("failed to create index: %s", err)
+	}
+
+	assert.Equal(t, []string{filepath.Join(rootDir, "app/controller.rb")}, index.Callers([]string{"find_user"}))
+	assert.Equal(t, []string{filepath.Join(rootDir, "app/other.rb")}, index.Callers([]string{"valid?"}))
+	assert.Empty(t, index.Callers([]string{"unknown"}))
+}
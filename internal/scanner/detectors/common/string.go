@@ -10,6 +10,10 @@ import (
 
 const NonLiteralValue = "\uFFFD" // unicode Replacement character
 
+// NonLiteralPlaceholder replaces the non-literal parts of strings parsed with
+// an embedded language, as the replacement character isn't valid syntax in them
+const NonLiteralPlaceholder = "BearerNonLiteral"
+
 type String struct {
 	Value     string
 	IsLiteral bool
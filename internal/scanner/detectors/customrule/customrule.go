@@ -18,10 +18,11 @@ import (
 )
 
 type Pattern struct {
-	Index   int
-	Pattern string
-	Query   patternquery.Query
-	Filter  filters.Filter
+	Index      int
+	Pattern    string
+	Confidence string
+	Query      patternquery.Query
+	Filter     filters.Filter
 }
 
 type Detector struct {
@@ -60,10 +61,11 @@ func New(
 		}
 
 		compiledPatterns = append(compiledPatterns, Pattern{
-			Index:   i,
-			Pattern: pattern.Pattern,
-			Query:   patternQuery,
-			Filter:  filter,
+			Index:      i,
+			Pattern:    pattern.Pattern,
+			Confidence: pattern.Confidence,
+			Query:      patternQuery,
+			Filter:     filter,
 		})
 	}
 
@@ -105,9 +107,10 @@ func (detector *Detector) DetectAt(
 
 			for _, match := range filterResult.Matches() {
 				detectionsData = append(detectionsData, types.Data{
-					Pattern:   pattern.Pattern,
-					Datatypes: match.DatatypeDetections(),
-					Variables: match.Variables(),
+					Pattern:    pattern.Pattern,
+					Confidence: pattern.Confidence,
+					Datatypes:  match.DatatypeDetections(),
+					Variables:  match.Variables(),
 				})
 			}
 
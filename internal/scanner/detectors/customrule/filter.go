@@ -146,6 +146,13 @@ func translateFilter(
 		}, nil
 	}
 
+	if sourceFilter.Interpolated != nil {
+		return &filters.Interpolated{
+			Variable: variable,
+			Value:    *sourceFilter.Interpolated,
+		}, nil
+	}
+
 	if sourceFilter.LengthLessThan != nil {
 		return &filters.StringLengthLessThan{
 			Variable: variable,
@@ -226,7 +233,8 @@ func scoreFilter(filter settings.PatternFilter) int {
 		filter.LessThanOrEqual != nil ||
 		filter.GreaterThan != nil ||
 		filter.GreaterThanOrEqual != nil ||
-		filter.FilenameRegex != nil {
+		filter.FilenameRegex != nil ||
+		filter.Interpolated != nil {
 		return 1
 	}
 
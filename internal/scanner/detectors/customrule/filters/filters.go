@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 
@@ -353,6 +354,23 @@ func (filter *Regex) Evaluate(
 	return boolResult(patternVariables, result), nil
 }
 
+// Interpolated matches when the variable's content does, or doesn't, contain a
+// non-literal part of the string the embedded language fragment was parsed from
+type Interpolated struct {
+	Variable *variableshape.Variable
+	Value    bool
+}
+
+func (filter *Interpolated) Evaluate(
+	detectorContext detectortypes.Context,
+	patternVariables variableshape.Values,
+) (*Result, error) {
+	node := patternVariables.Node(filter.Variable)
+	interpolated := strings.Contains(node.Content(), common.NonLiteralPlaceholder)
+
+	return boolResult(patternVariables, interpolated == filter.Value), nil
+}
+
 type StringLengthLessThan struct {
 	Variable *variableshape.Variable
 	Value    int
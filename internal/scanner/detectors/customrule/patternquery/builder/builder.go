@@ -288,7 +288,9 @@ func (builder *builder) compileAnonymousNode(node *asttree.Node) {
 		return
 	}
 
-	builder.write(strconv.Quote(node.Content()))
+	// the type of an anonymous node is its content, unless it is an alias such
+	// as the case insensitive keywords of SQL
+	builder.write(strconv.Quote(node.SitterNode().Type()))
 }
 
 // Leaves match their type and content
@@ -6,7 +6,9 @@ import (
 )
 
 type Data struct {
-	Pattern   string
-	Datatypes []*detectortypes.Detection
-	Variables variableshape.Values
+	Pattern string
+	// Confidence is the confidence declared by the pattern, if any
+	Confidence string
+	Datatypes  []*detectortypes.Detection
+	Variables  variableshape.Values
 }
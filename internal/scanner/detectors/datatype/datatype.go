@@ -66,7 +66,7 @@ func (detector *datatypeDetector) classifyObject(
 	name string,
 	detection *types.Detection,
 ) (Data, classificationschema.Classification, bool) {
-	objectData := detection.Data.(common.Object)
+	objectData := withoutSanitizedProperties(detection.Data.(common.Object))
 
 	classification := detector.classifier.Classify(buildClassificationRequest(detector.detectorType, filename, name, objectData))
 	containsValidClassification := classification.Classification.Decision.State == classify.Valid
@@ -160,3 +160,20 @@ func buildClassificationRequest(
 		Filename:     filename,
 	}
 }
+
+// withoutSanitizedProperties removes the properties declared by sanitized
+// fields, such as fields with a sanitizer annotation
+func withoutSanitizedProperties(object common.Object) common.Object {
+	var properties []common.Property
+	for _, property := range object.Properties {
+		if property.Node == nil || !property.Node.Sanitized() {
+			properties = append(properties, property)
+		}
+	}
+
+	if len(properties) == len(object.Properties) {
+		return object
+	}
+
+	return common.Object{Properties: properties, IsVirtual: object.IsVirtual}
+}
@@ -0,0 +1,149 @@
+package embeddedrule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bearer/bearer/internal/languages/embedded"
+	"github.com/bearer/bearer/internal/scanner/ast"
+	"github.com/bearer/bearer/internal/scanner/ast/query"
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/detectors/common"
+	"github.com/bearer/bearer/internal/scanner/detectors/customrule"
+	detectortypes "github.com/bearer/bearer/internal/scanner/detectors/types"
+	"github.com/bearer/bearer/internal/scanner/language"
+	"github.com/bearer/bearer/internal/scanner/ruleset"
+	"github.com/bearer/bearer/internal/scanner/variableshape"
+)
+
+// Detector matches the patterns of a rule written in an embedded language,
+// eg. SQL, against the strings of the scanned language. Each string is parsed
+// on its own with the embedded language, with its non-literal parts replaced
+// by a placeholder, and detections are reported at the string.
+type Detector struct {
+	detectortypes.DetectorBase
+	rule             *ruleset.Rule
+	ruleSet          *ruleset.Set
+	language         language.Language
+	querySet         *query.Set
+	fragmentDetector detectortypes.Detector
+}
+
+func New(
+	ruleSet *ruleset.Set,
+	variableShapeSet *variableshape.Set,
+	rule *ruleset.Rule,
+) (*Detector, error) {
+	embeddedLanguage := embedded.Get(rule.EmbeddedLanguage())
+	if embeddedLanguage == nil {
+		return nil, fmt.Errorf("unsupported embedded language '%s'", rule.EmbeddedLanguage())
+	}
+
+	querySet := query.NewSet(embeddedLanguage.ID(), embeddedLanguage.SitterLanguage())
+
+	fragmentDetector, err := customrule.New(embeddedLanguage, ruleSet, variableShapeSet, querySet, rule)
+	if err != nil {
+		querySet.Close()
+		return nil, err
+	}
+
+	if err := querySet.Compile(); err != nil {
+		querySet.Close()
+		return nil, fmt.Errorf("error compiling %s query set: %w", embeddedLanguage.ID(), err)
+	}
+
+	return &Detector{
+		rule:             rule,
+		ruleSet:          ruleSet,
+		language:         embeddedLanguage,
+		querySet:         querySet,
+		fragmentDetector: fragmentDetector,
+	}, nil
+}
+
+func (detector *Detector) Rule() *ruleset.Rule {
+	return detector.rule
+}
+
+func (detector *Detector) DetectAt(
+	node *tree.Node,
+	detectorContext detectortypes.Context,
+) ([]interface{}, error) {
+	fragment, err := fragmentAt(node, detectorContext)
+	if err != nil || fragment == "" {
+		return nil, err
+	}
+
+	fragmentTree, err := ast.ParseAndAnalyze(
+		context.TODO(),
+		detector.language,
+		detector.ruleSet,
+		detector.querySet,
+		nil,
+		nil,
+		[]byte(fragment),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s fragment: %w", detector.language.ID(), err)
+	}
+
+	// a string is reported once, at the first match in its fragment
+	var match interface{}
+	err = fragmentTree.RootNode().Walk(func(fragmentNode *tree.Node, visitChildren func() error) error {
+		if match != nil {
+			return nil
+		}
+
+		detectionsData, err := detector.fragmentDetector.DetectAt(fragmentNode, detectorContext)
+		if err != nil {
+			return err
+		}
+
+		if len(detectionsData) != 0 {
+			match = detectionsData[0]
+			return nil
+		}
+
+		return visitChildren()
+	})
+	if err != nil || match == nil {
+		return nil, err
+	}
+
+	if log.Trace().Enabled() {
+		log.Trace().Msgf("%s fragment matched at %s: %s", detector.language.ID(), node.Debug(), fragment)
+	}
+
+	return []interface{}{match}, nil
+}
+
+// Close frees the queries of the embedded language
+func (detector *Detector) Close() {
+	detector.querySet.Close()
+}
+
+// fragmentAt returns the string value at the node, or an empty string if the
+// value is matched elsewhere. Values of variables are matched where they are
+// assigned, and parts of a concatenation are matched as a whole.
+func fragmentAt(node *tree.Node, detectorContext detectortypes.Context) (string, error) {
+	if len(node.AliasOf()) != 0 {
+		return "", nil
+	}
+
+	if parent := node.Parent(); parent != nil && len(parent.AliasOf()) == 0 {
+		parentValue, _, err := common.GetStringValue(parent, detectorContext)
+		if err != nil || parentValue != "" {
+			return "", err
+		}
+	}
+
+	value, _, err := common.GetStringValue(node, detectorContext)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ReplaceAll(value, common.NonLiteralValue, common.NonLiteralPlaceholder), nil
+}
@@ -68,6 +68,7 @@ func RunTest(
 			ruleSet,
 			variableShapeSet,
 			querySet,
+			nil,
 		)
 		if err != nil {
 			tt.Fatalf("failed to create detector set: %s", err)
@@ -82,7 +83,7 @@ func RunTest(
 			tt.Fatalf("failed to read file: %s", err)
 		}
 
-		tree, err := ast.ParseAndAnalyze(context.Background(), language, ruleSet, querySet, contentBytes)
+		tree, err := ast.ParseAndAnalyze(context.Background(), language, ruleSet, querySet, nil, nil, contentBytes)
 		if err != nil {
 			tt.Fatalf("failed to parse file: %s", err)
 		}
@@ -4,15 +4,18 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/bearer/bearer/internal/classification/schema"
 	"github.com/bearer/bearer/internal/scanner/ast/query"
 	"github.com/bearer/bearer/internal/scanner/ast/traversalstrategy"
 	"github.com/bearer/bearer/internal/scanner/ast/tree"
 	"github.com/bearer/bearer/internal/scanner/detectors/customrule"
+	"github.com/bearer/bearer/internal/scanner/detectors/embeddedrule"
 	detectortypes "github.com/bearer/bearer/internal/scanner/detectors/types"
 	"github.com/bearer/bearer/internal/scanner/language"
 	"github.com/bearer/bearer/internal/scanner/ruleset"
+	"github.com/bearer/bearer/internal/scanner/stats"
 	"github.com/bearer/bearer/internal/scanner/variableshape"
 )
 
@@ -30,10 +33,13 @@ type Set interface {
 		rule *ruleset.Rule,
 		detectorContext detectortypes.Context,
 	) (*Result, error)
+	Close()
 }
 
 type detectorSet struct {
-	detectors []detectortypes.Detector
+	detectors         []detectortypes.Detector
+	embeddedDetectors []*embeddedrule.Detector
+	sanitizerRules    []*ruleset.Rule
 }
 
 func New(
@@ -42,8 +48,10 @@ func New(
 	ruleSet *ruleset.Set,
 	variableShapeSet *variableshape.Set,
 	querySet *query.Set,
+	compileStats *stats.CompileStats,
 ) (Set, error) {
 	detectors := make([]detectortypes.Detector, len(ruleSet.Rules()))
+	var embeddedDetectors []*embeddedrule.Detector
 
 	for _, detector := range language.NewBuiltInDetectors(schemaClassifier, querySet) {
 		detectors[detector.Rule().Index()] = detector
@@ -54,19 +62,53 @@ func New(
 			continue
 		}
 
-		detector, err := customrule.New(language, ruleSet, variableShapeSet, querySet, rule)
+		startTime := time.Now()
+		detector, err := newDetector(language, ruleSet, variableShapeSet, querySet, rule)
+		compileStats.Rule(rule.ID(), startTime)
 		if err != nil {
+			closeAll(embeddedDetectors)
 			return nil, fmt.Errorf("failed to create %s detector: %w", rule.ID(), err)
 		}
 
+		if embeddedDetector, isEmbedded := detector.(*embeddedrule.Detector); isEmbedded {
+			embeddedDetectors = append(embeddedDetectors, embeddedDetector)
+		}
+
 		detectors[rule.Index()] = detector
 	}
 
 	return &detectorSet{
-		detectors: detectors,
+		detectors:         detectors,
+		embeddedDetectors: embeddedDetectors,
+		sanitizerRules:    ruleSet.SanitizerRules(),
 	}, nil
 }
 
+func newDetector(
+	language language.Language,
+	ruleSet *ruleset.Set,
+	variableShapeSet *variableshape.Set,
+	querySet *query.Set,
+	rule *ruleset.Rule,
+) (detectortypes.Detector, error) {
+	if rule.EmbeddedLanguage() != "" {
+		return embeddedrule.New(ruleSet, variableShapeSet, rule)
+	}
+
+	return customrule.New(language, ruleSet, variableShapeSet, querySet, rule)
+}
+
+// Close frees the queries of the embedded language rules
+func (set *detectorSet) Close() {
+	closeAll(set.embeddedDetectors)
+}
+
+func closeAll(embeddedDetectors []*embeddedrule.Detector) {
+	for _, detector := range embeddedDetectors {
+		detector.Close()
+	}
+}
+
 func (set *detectorSet) DetectAt(
 	node *tree.Node,
 	rule *ruleset.Rule,
@@ -130,11 +172,43 @@ func (set *detectorSet) isSanitized(
 	node *tree.Node,
 	detectorContext detectortypes.Context,
 ) (bool, error) {
-	sanitizerRule := rule.SanitizerRule()
-	if sanitizerRule == nil {
+	// data types are not detected within nodes covered by a sanitization
+	// contract, so neither classification nor rules on data types see them
+	if rule == ruleset.BuiltinDatatypeRule && node.Sanitized() {
+		return true, nil
+	}
+
+	if sanitizerRule := rule.SanitizerRule(); sanitizerRule != nil {
+		if sanitized, err := isSanitizedBy(node, sanitizerRule, detectorContext); sanitized || err != nil {
+			return sanitized, err
+		}
+	}
+
+	// data doesn't flow through the code matched by the sanitizers of the
+	// language, but the code matched by a rule itself is still reported
+	if rule.Type() == ruleset.RuleTypeTopLevel || rule.Type() == ruleset.RuleTypeSanitizer {
 		return false, nil
 	}
 
+	for _, sanitizerRule := range set.sanitizerRules {
+		// the sanitizer's own filters are being evaluated at this node
+		if slices.Contains(node.ExecutingDetectors, sanitizerRule.Index()) {
+			continue
+		}
+
+		if sanitized, err := isSanitizedBy(node, sanitizerRule, detectorContext); sanitized || err != nil {
+			return sanitized, err
+		}
+	}
+
+	return false, nil
+}
+
+func isSanitizedBy(
+	node *tree.Node,
+	sanitizerRule *ruleset.Rule,
+	detectorContext detectortypes.Context,
+) (bool, error) {
 	detections, err := detectorContext.Scan(node, sanitizerRule, traversalstrategy.CursorStrict)
 	if err != nil {
 		return false, err
@@ -2,9 +2,14 @@ package languagescanner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
@@ -14,11 +19,13 @@ import (
 	"github.com/bearer/bearer/internal/scanner/ast/query"
 	"github.com/bearer/bearer/internal/scanner/ast/traversalstrategy"
 	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	"github.com/bearer/bearer/internal/scanner/callindex"
 	detectortypes "github.com/bearer/bearer/internal/scanner/detectors/types"
 	"github.com/bearer/bearer/internal/scanner/language"
 	"github.com/bearer/bearer/internal/scanner/ruleset"
 	"github.com/bearer/bearer/internal/scanner/variableshape"
 	"github.com/bearer/bearer/internal/util/file"
+	"github.com/bearer/bearer/internal/util/set"
 
 	"github.com/bearer/bearer/internal/scanner/cache"
 	"github.com/bearer/bearer/internal/scanner/detectorset"
@@ -26,17 +33,32 @@ import (
 	"github.com/bearer/bearer/internal/scanner/stats"
 )
 
+// the number of other files linked into a scanned file, bounding the cost of
+// following values across files
+const maxLinkedFiles = 50
+
 type Scanner struct {
-	language    language.Language
-	ruleSet     *ruleset.Set
-	querySet    *query.Set
-	detectorSet detectorset.Set
+	language             language.Language
+	ruleSet              *ruleset.Set
+	querySet             *query.Set
+	detectorSet          detectorset.Set
+	sanitizerAnnotations []string
+	interproceduralDepth int
+	// the maximum time to evaluate a rule on a file, or zero for no limit
+	ruleTimeout    time.Duration
+	callIndexMutex sync.Mutex
+	// call indexes by project root directory, built on first use
+	callIndexes map[string]*callindex.Index
 }
 
 func New(
 	language language.Language,
 	schemaClassifier *schema.Classifier,
 	rules map[string]*settings.Rule,
+	sanitizerAnnotations []string,
+	interproceduralDepth int,
+	ruleTimeout time.Duration,
+	compileStats *stats.CompileStats,
 ) (*Scanner, error) {
 	ruleSet, err := ruleset.New(language.ID(), rules)
 	if err != nil {
@@ -50,22 +72,34 @@ func New(
 
 	querySet := query.NewSet(language.ID(), language.SitterLanguage())
 
-	detectorSet, err := detectorset.New(schemaClassifier, language, ruleSet, variableShapeSet, querySet)
+	detectorSet, err := detectorset.New(
+		schemaClassifier,
+		language,
+		ruleSet,
+		variableShapeSet,
+		querySet,
+		compileStats,
+	)
 	if err != nil {
 		querySet.Close()
 		return nil, fmt.Errorf("failed to create detector set: %w", err)
 	}
 
 	if err = querySet.Compile(); err != nil {
+		detectorSet.Close()
 		querySet.Close()
 		return nil, fmt.Errorf("error compiling query set: %w", err)
 	}
 
 	return &Scanner{
-		language:    language,
-		ruleSet:     ruleSet,
-		querySet:    querySet,
-		detectorSet: detectorSet,
+		language:             language,
+		ruleSet:              ruleSet,
+		querySet:             querySet,
+		detectorSet:          detectorSet,
+		sanitizerAnnotations: sanitizerAnnotations,
+		interproceduralDepth: interproceduralDepth,
+		ruleTimeout:          ruleTimeout,
+		callIndexes:          make(map[string]*callindex.Index),
 	}, nil
 }
 
@@ -73,23 +107,38 @@ func (scanner *Scanner) LanguageID() string {
 	return scanner.language.ID()
 }
 
+// Scan returns the detections, expected detections and suppression warnings of
+// the file, and the ids of the rules skipped as they timed out
 func (scanner *Scanner) Scan(
 	ctx context.Context,
 	fileStats *stats.FileStats,
 	fileInfo *file.FileInfo,
-) ([]*detectortypes.Detection, []*detectortypes.Detection, error) {
+) ([]*detectortypes.Detection, []*detectortypes.Detection, []*detectortypes.Detection, []string, error) {
 	if !slices.Contains(scanner.language.EnryLanguages(), fileInfo.Language) {
-		return nil, nil, nil
+		return nil, nil, nil, nil, nil
 	}
 
 	contentBytes, err := os.ReadFile(fileInfo.AbsolutePath)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	linker, err := scanner.linkerFor(fileInfo)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
 
-	tree, err := ast.ParseAndAnalyze(ctx, scanner.language, scanner.ruleSet, scanner.querySet, contentBytes)
+	tree, err := ast.ParseAndAnalyze(
+		ctx,
+		scanner.language,
+		scanner.ruleSet,
+		scanner.querySet,
+		scanner.sanitizerAnnotations,
+		linker,
+		contentBytes,
+	)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	if log.Trace().Enabled() {
@@ -108,10 +157,10 @@ func (scanner *Scanner) Scan(
 		cache,
 	)
 
-	detections, err := scanner.evaluateRules(ruleScanner, cache, tree)
+	detections, timedOutRuleIDs, err := scanner.evaluateRules(ctx, ruleScanner, cache, tree, fileStats)
 	expectedDetections, _ := scanner.ExpectedDetections(tree)
 
-	return detections, expectedDetections, err
+	return detections, expectedDetections, scanner.SuppressionWarnings(tree), timedOutRuleIDs, err
 }
 
 func (scanner *Scanner) ExpectedDetections(tree *tree.Tree) ([]*detectortypes.Detection, error) {
@@ -119,7 +168,7 @@ func (scanner *Scanner) ExpectedDetections(tree *tree.Tree) ([]*detectortypes.De
 	nodes := tree.Nodes()
 	for i := range tree.Nodes() {
 		node := &nodes[i]
-		if len(node.ExpectedRules()) > 0 {
+		if len(node.ExpectedRules()) > 0 && !node.Linked() {
 			for _, expectedRule := range node.ExpectedRules() {
 				rule, _ := scanner.ruleSet.RuleByID(expectedRule)
 				detections = append(detections, []*detectortypes.Detection{
@@ -135,32 +184,257 @@ func (scanner *Scanner) ExpectedDetections(tree *tree.Tree) ([]*detectortypes.De
 	return detections, nil
 }
 
+// SuppressionWarnings returns a detection for each `bearer:disable` comment
+// needing review, with the warning as data
+func (scanner *Scanner) SuppressionWarnings(tree *tree.Tree) []*detectortypes.Detection {
+	var detections []*detectortypes.Detection
+	nodes := tree.Nodes()
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Linked() {
+			continue
+		}
+
+		for _, warning := range node.SuppressionWarnings() {
+			detections = append(detections, &detectortypes.Detection{
+				RuleID:    warning.RuleID,
+				MatchNode: node,
+				Data:      warning.Message,
+			})
+		}
+	}
+
+	return detections
+}
+
+// evaluateRules returns the detections of the top level rules. Rules taking
+// longer than the rule timeout are skipped, and their ids returned.
 func (scanner *Scanner) evaluateRules(
+	ctx context.Context,
 	ruleScanner *rulescanner.Scanner,
 	cache *cache.Cache,
 	tree *tree.Tree,
+	fileStats *stats.FileStats,
 ) (
 	[]*detectortypes.Detection,
+	[]string,
 	error,
 ) {
 	var detections []*detectortypes.Detection
+	var timedOutRuleIDs []string
+
 	for _, rule := range scanner.ruleSet.Rules() {
 		if rule.Type() != ruleset.RuleTypeTopLevel {
 			continue
 		}
 
-		cache.Clear()
-		ruleDetections, err := ruleScanner.Scan(tree.RootNode(), rule, traversalstrategy.NestedStrict)
+		ruleDetections, err := scanner.evaluateRule(ctx, ruleScanner, cache, tree, rule)
 		if err != nil {
-			return nil, err
+			if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+				log.Debug().Msgf("rule %s timed out after %s, skipping", rule.ID(), scanner.ruleTimeout)
+				timedOutRuleIDs = append(timedOutRuleIDs, rule.ID())
+				continue
+			}
+
+			return nil, nil, err
 		}
 
+		fileStats.RuleMatches(rule.ID(), len(ruleDetections))
 		detections = append(detections, ruleDetections...)
 	}
 
+	return detections, timedOutRuleIDs, nil
+}
+
+func (scanner *Scanner) evaluateRule(
+	ctx context.Context,
+	ruleScanner *rulescanner.Scanner,
+	cache *cache.Cache,
+	tree *tree.Tree,
+	rule *ruleset.Rule,
+) (
+	[]*detectortypes.Detection,
+	error,
+) {
+	if scanner.ruleTimeout != 0 {
+		ruleCtx, cancel := context.WithTimeout(ctx, scanner.ruleTimeout)
+		defer cancel()
+
+		ruleScanner = ruleScanner.WithContext(ruleCtx)
+	}
+
+	cache.Clear()
+	detections, err := ruleScanner.Scan(tree.RootNode(), rule, traversalstrategy.NestedStrict)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.HasConditions() && len(detections) != 0 {
+		return applyConditions(ruleScanner, cache, tree.RootNode(), rule, detections)
+	}
+
 	return detections, nil
 }
 
+// applyConditions keeps the detections of the rule for which each of its
+// required rules match, and none of its excluded rules do, within the same
+// scope. Code outside of any function is a single function scope.
+func applyConditions(
+	ruleScanner *rulescanner.Scanner,
+	cache *cache.Cache,
+	rootNode *tree.Node,
+	rule *ruleset.Rule,
+	detections []*detectortypes.Detection,
+) (
+	[]*detectortypes.Detection,
+	error,
+) {
+	scopeFor := func(detection *detectortypes.Detection) *tree.Node {
+		if rule.ConditionScope() == settings.FUNCTION_CONDITION_SCOPE {
+			return detection.MatchNode.Function()
+		}
+
+		return nil
+	}
+
+	matchingScopes := func(conditionRules []*ruleset.Rule) ([]set.Set[*tree.Node], error) {
+		result := make([]set.Set[*tree.Node], len(conditionRules))
+
+		for i, conditionRule := range conditionRules {
+			cache.Clear()
+			conditionDetections, err := ruleScanner.Scan(rootNode, conditionRule, traversalstrategy.NestedStrict)
+			if err != nil {
+				return nil, err
+			}
+
+			result[i] = set.New[*tree.Node]()
+			for _, detection := range conditionDetections {
+				result[i].Add(scopeFor(detection))
+			}
+		}
+
+		return result, nil
+	}
+
+	requiredScopes, err := matchingScopes(rule.RequiredRules())
+	if err != nil {
+		return nil, err
+	}
+
+	excludedScopes, err := matchingScopes(rule.ExcludedRules())
+	if err != nil {
+		return nil, err
+	}
+
+	matchesConditions := func(scope *tree.Node) bool {
+		for _, scopes := range requiredScopes {
+			if !scopes.Has(scope) {
+				return false
+			}
+		}
+
+		for _, scopes := range excludedScopes {
+			if scopes.Has(scope) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	var result []*detectortypes.Detection
+	for _, detection := range detections {
+		if matchesConditions(scopeFor(detection)) {
+			result = append(result, detection)
+		}
+	}
+
+	return result, nil
+}
+
+// linkerFor returns a linker finding the callers of the file's functions within
+// its project, or nil when values are not followed across files
+func (scanner *Scanner) linkerFor(fileInfo *file.FileInfo) (ast.Linker, error) {
+	if scanner.interproceduralDepth == 0 {
+		return nil, nil
+	}
+
+	index, err := scanner.callIndexFor(projectDir(fileInfo))
+	if err != nil {
+		return nil, fmt.Errorf("failed to index calls: %w", err)
+	}
+
+	seen := set.New[string]()
+	seen.Add(fileInfo.AbsolutePath)
+
+	return &fileLinker{index: index, depth: scanner.interproceduralDepth, seen: seen}, nil
+}
+
+func (scanner *Scanner) callIndexFor(rootDir string) (*callindex.Index, error) {
+	scanner.callIndexMutex.Lock()
+	defer scanner.callIndexMutex.Unlock()
+
+	if index, ok := scanner.callIndexes[rootDir]; ok {
+		return index, nil
+	}
+
+	index, err := callindex.New(rootDir, scanner.language.EnryLanguages())
+	if err != nil {
+		return nil, err
+	}
+
+	scanner.callIndexes[rootDir] = index
+	return index, nil
+}
+
+// projectDir returns the directory the file's relative path is relative to
+func projectDir(fileInfo *file.FileInfo) string {
+	relativePath := strings.TrimPrefix(fileInfo.RelativePath, "/")
+	if strings.HasSuffix(fileInfo.AbsolutePath, "/"+relativePath) {
+		return strings.TrimSuffix(fileInfo.AbsolutePath, "/"+relativePath)
+	}
+
+	return filepath.Dir(fileInfo.AbsolutePath)
+}
+
+// fileLinker returns the files of the project calling the functions of a
+// scanned file, returning each file at most once
+type fileLinker struct {
+	index *callindex.Index
+	depth int
+	seen  set.Set[string]
+}
+
+func (linker *fileLinker) Depth() int {
+	return linker.depth
+}
+
+func (linker *fileLinker) Callers(functionNames []string) ([][]byte, error) {
+	var callers [][]byte
+
+	for _, filename := range linker.index.Callers(functionNames) {
+		// the scanned file itself is seen
+		if len(linker.seen) > maxLinkedFiles {
+			log.Debug().Msgf("linked file limit reached, skipping further callers")
+			break
+		}
+
+		if !linker.seen.Add(filename) {
+			continue
+		}
+
+		contentBytes, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read linked file: %w", err)
+		}
+
+		callers = append(callers, contentBytes)
+	}
+
+	return callers, nil
+}
+
 func (scanner *Scanner) Close() {
+	scanner.detectorSet.Close()
 	scanner.querySet.Close()
 }
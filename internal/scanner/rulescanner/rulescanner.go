@@ -43,6 +43,14 @@ func New(
 	}
 }
 
+// WithContext returns a copy of the scanner which stops scanning once the
+// given context is done
+func (scanner *Scanner) WithContext(ctx context.Context) *Scanner {
+	result := *scanner
+	result.ctx = ctx
+	return &result
+}
+
 func (scanner *Scanner) Scan(
 	rootNode *tree.Node,
 	rule *ruleset.Rule,
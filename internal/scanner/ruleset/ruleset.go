@@ -18,11 +18,13 @@ const (
 	RuleTypeShared
 	RuleTypeBuiltin
 	RuleTypeAuxiliary
+	RuleTypeSanitizer
 )
 
 type Set struct {
-	rules     []*Rule
-	rulesByID map[string]*Rule
+	rules          []*Rule
+	rulesByID      map[string]*Rule
+	sanitizerRules []*Rule
 }
 
 type Rule struct {
@@ -31,6 +33,13 @@ type Rule struct {
 	ruleType      RuleType
 	sanitizerRule *Rule
 	patterns      []settings.RulePattern
+	// embeddedLanguage is the language the patterns match in string literals
+	embeddedLanguage string
+	// requiredRules must match, and excludedRules must not, in the same scope
+	// as a detection of the rule
+	requiredRules,
+	excludedRules []*Rule
+	conditionScope settings.RuleConditionScope
 }
 
 func New(languageID string, settingsRules map[string]*settings.Rule) (*Set, error) {
@@ -39,6 +48,7 @@ func New(languageID string, settingsRules map[string]*settings.Rule) (*Set, erro
 
 	rulesByID := make(map[string]*Rule)
 	var rules []*Rule
+	var sanitizerRules []*Rule
 
 	for _, rule := range builtinRules {
 		if rulesByID[rule.id] != nil {
@@ -51,10 +61,11 @@ func New(languageID string, settingsRules map[string]*settings.Rule) (*Set, erro
 
 	for _, settingsRule := range languageRules {
 		rule := &Rule{
-			index:    len(rules),
-			id:       settingsRule.Id,
-			ruleType: getRuleType(triggerRuleIDs, settingsRule),
-			patterns: settingsRule.Patterns,
+			index:            len(rules),
+			id:               settingsRule.Id,
+			ruleType:         getRuleType(triggerRuleIDs, settingsRule),
+			patterns:         settingsRule.Patterns,
+			embeddedLanguage: settingsRule.EmbeddedLanguage,
 		}
 
 		if rulesByID[rule.id] != nil {
@@ -63,6 +74,19 @@ func New(languageID string, settingsRules map[string]*settings.Rule) (*Set, erro
 
 		rules = append(rules, rule)
 		rulesByID[rule.id] = rule
+
+		if rule.ruleType == RuleTypeSanitizer {
+			sanitizerRules = append(sanitizerRules, rule)
+		}
+	}
+
+	// suppressions of deprecated rules apply to the rule replacing them
+	for _, settingsRule := range languageRules {
+		for _, replacedID := range settingsRule.Replaces {
+			if rulesByID[replacedID] == nil {
+				rulesByID[replacedID] = rulesByID[settingsRule.Id]
+			}
+		}
 	}
 
 	for _, rule := range rules {
@@ -71,6 +95,16 @@ func New(languageID string, settingsRules map[string]*settings.Rule) (*Set, erro
 		}
 
 		settingsRule := settingsRules[rule.id]
+
+		var err error
+		if rule.requiredRules, err = getConditionRules(rulesByID, settingsRule.Trigger.RequiredDetections); err != nil {
+			return nil, err
+		}
+		if rule.excludedRules, err = getConditionRules(rulesByID, settingsRule.Trigger.ExcludedDetections); err != nil {
+			return nil, err
+		}
+		rule.conditionScope = settingsRule.Trigger.Scope
+
 		if settingsRule.SanitizerRuleID == "" {
 			continue
 		}
@@ -84,8 +118,9 @@ func New(languageID string, settingsRules map[string]*settings.Rule) (*Set, erro
 	}
 
 	return &Set{
-		rules:     rules,
-		rulesByID: rulesByID,
+		rules:          rules,
+		rulesByID:      rulesByID,
+		sanitizerRules: sanitizerRules,
 	}, nil
 }
 
@@ -117,8 +152,25 @@ func getTriggerRuleIDs(languageRules []*settings.Rule) set.Set[string] {
 	return triggerRuleIDs
 }
 
+func getConditionRules(rulesByID map[string]*Rule, ruleIDs []string) ([]*Rule, error) {
+	var result []*Rule
+
+	for _, ruleID := range ruleIDs {
+		rule := rulesByID[ruleID]
+		if rule == nil {
+			return nil, fmt.Errorf("invalid rule id for trigger condition '%s'", ruleID)
+		}
+
+		result = append(result, rule)
+	}
+
+	return result, nil
+}
+
 func getRuleType(triggerRuleIDs set.Set[string], settingsRule *settings.Rule) RuleType {
 	switch {
+	case settingsRule.Type == customdetectors.TypeSanitizer:
+		return RuleTypeSanitizer
 	case settingsRule.Type == customdetectors.TypeShared:
 		return RuleTypeShared
 	case !settingsRule.IsAuxilary || triggerRuleIDs.Has(settingsRule.Id):
@@ -145,6 +197,12 @@ func (set *Set) Rules() []*Rule {
 	return set.rules
 }
 
+// SanitizerRules returns the rules matching code which sanitizes values for
+// every other rule of the language
+func (set *Set) SanitizerRules() []*Rule {
+	return set.sanitizerRules
+}
+
 func (rule *Rule) Index() int {
 	return rule.index
 }
@@ -164,3 +222,32 @@ func (rule *Rule) SanitizerRule() *Rule {
 func (rule *Rule) Patterns() []settings.RulePattern {
 	return rule.patterns
 }
+
+// EmbeddedLanguage returns the language the patterns of the rule match within
+// string literals, or an empty string when they match the code itself
+func (rule *Rule) EmbeddedLanguage() string {
+	return rule.embeddedLanguage
+}
+
+// RequiredRules returns the rules which must match in the same scope as a
+// detection of the rule for it to be kept
+func (rule *Rule) RequiredRules() []*Rule {
+	return rule.requiredRules
+}
+
+// ExcludedRules returns the rules which must not match in the same scope as a
+// detection of the rule for it to be kept
+func (rule *Rule) ExcludedRules() []*Rule {
+	return rule.excludedRules
+}
+
+// HasConditions tells whether detections of the rule depend on the detections
+// of other rules
+func (rule *Rule) HasConditions() bool {
+	return len(rule.requiredRules) != 0 || len(rule.excludedRules) != 0
+}
+
+// ConditionScope returns where the required and excluded rules are looked for
+func (rule *Rule) ConditionScope() settings.RuleConditionScope {
+	return rule.conditionScope
+}
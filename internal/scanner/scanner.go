@@ -4,15 +4,21 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	schemaclassifier "github.com/bearer/bearer/internal/classification/schema"
 	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/languages/c"
 	"github.com/bearer/bearer/internal/languages/golang"
 	"github.com/bearer/bearer/internal/languages/java"
 	"github.com/bearer/bearer/internal/languages/javascript"
+	"github.com/bearer/bearer/internal/languages/kotlin"
 	"github.com/bearer/bearer/internal/languages/php"
 	"github.com/bearer/bearer/internal/languages/python"
 	"github.com/bearer/bearer/internal/languages/ruby"
+	"github.com/bearer/bearer/internal/languages/rust"
+	"github.com/bearer/bearer/internal/languages/shell"
+	"github.com/bearer/bearer/internal/languages/sql"
 	"github.com/bearer/bearer/internal/report"
 	reportdetections "github.com/bearer/bearer/internal/report/detections"
 	"github.com/bearer/bearer/internal/report/detectors"
@@ -22,6 +28,7 @@ import (
 	"github.com/bearer/bearer/internal/scanner/detectors/datatype"
 	detectortypes "github.com/bearer/bearer/internal/scanner/detectors/types"
 	"github.com/bearer/bearer/internal/scanner/language"
+	globaltypes "github.com/bearer/bearer/internal/types"
 	"github.com/bearer/bearer/internal/util/file"
 	"github.com/bearer/bearer/internal/util/pluralize"
 
@@ -31,9 +38,18 @@ import (
 
 type Scanner struct {
 	languageScanners []*languagescanner.Scanner
+	ruleTimeout      time.Duration
+	ruleConfidences  map[string]string
 }
 
-func New(schemaClassifier *schemaclassifier.Classifier, rules map[string]*settings.Rule) (*Scanner, error) {
+func New(
+	schemaClassifier *schemaclassifier.Classifier,
+	rules map[string]*settings.Rule,
+	sanitizerAnnotations []string,
+	interproceduralDepth int,
+	ruleTimeout time.Duration,
+	compileStats *stats.CompileStats,
+) (*Scanner, error) {
 	languages := []language.Language{
 		java.Get(),
 		javascript.Get(),
@@ -41,12 +57,25 @@ func New(schemaClassifier *schemaclassifier.Classifier, rules map[string]*settin
 		php.Get(),
 		golang.Get(),
 		python.Get(),
+		kotlin.Get(),
+		rust.Get(),
+		c.Get(),
+		sql.Get(),
+		shell.Get(),
 	}
 
 	languageScanners := make([]*languagescanner.Scanner, len(languages))
 
 	for i, language := range languages {
-		languageScanner, err := languagescanner.New(language, schemaClassifier, rules)
+		languageScanner, err := languagescanner.New(
+			language,
+			schemaClassifier,
+			rules,
+			sanitizerAnnotations,
+			interproceduralDepth,
+			ruleTimeout,
+			compileStats,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("error creating %s language scanner: %w", language.ID(), err)
 		}
@@ -54,7 +83,16 @@ func New(schemaClassifier *schemaclassifier.Classifier, rules map[string]*settin
 		languageScanners[i] = languageScanner
 	}
 
-	return &Scanner{languageScanners: languageScanners}, nil
+	ruleConfidences := make(map[string]string, len(rules))
+	for id, rule := range rules {
+		ruleConfidences[id] = rule.GetConfidence()
+	}
+
+	return &Scanner{
+		languageScanners: languageScanners,
+		ruleTimeout:      ruleTimeout,
+		ruleConfidences:  ruleConfidences,
+	}, nil
 }
 
 func (scanner *Scanner) Scan(
@@ -68,11 +106,19 @@ func (scanner *Scanner) Scan(
 	}
 
 	for _, languageScanner := range scanner.languageScanners {
-		detections, expectedDetections, err := languageScanner.Scan(ctx, fileStats, file)
+		detections, expectedDetections, suppressionWarnings, timedOutRuleIDs, err := languageScanner.Scan(
+			ctx,
+			fileStats,
+			file,
+		)
 		if err != nil {
 			return fmt.Errorf("%s scan failed: %w", languageScanner.LanguageID(), err)
 		}
 
+		for _, ruleID := range timedOutRuleIDs {
+			report.AddRuleTimeout(file.RelativePath, ruleID, scanner.ruleTimeout)
+		}
+
 		for _, detection := range expectedDetections {
 			detectorType := detectors.Type(detection.RuleID)
 			report.AddDetection(reportdetections.TypeExpectedDetection,
@@ -95,9 +141,31 @@ func (scanner *Scanner) Scan(
 				})
 		}
 
+		for _, detection := range suppressionWarnings {
+			report.AddDetection(reportdetections.TypeSuppressionWarning,
+				detectors.Type(detection.RuleID),
+				source.New(
+					file,
+					file.Path,
+					detection.MatchNode.ContentStart.Line,
+					detection.MatchNode.ContentStart.Column,
+					detection.MatchNode.ContentEnd.Line,
+					detection.MatchNode.ContentEnd.Column,
+					detection.Data.(string),
+				),
+				reportschema.Source{
+					StartLineNumber:   detection.MatchNode.ContentStart.Line,
+					EndLineNumber:     detection.MatchNode.ContentEnd.Line,
+					StartColumnNumber: detection.MatchNode.ContentStart.Column,
+					EndColumnNumber:   detection.MatchNode.ContentEnd.Column,
+					Content:           detection.MatchNode.Content(),
+				})
+		}
+
 		for _, detection := range detections {
 			detectorType := detectors.Type(detection.RuleID)
 			data := detection.Data.(customruletypes.Data)
+			confidence := scanner.detectionConfidence(detection.RuleID, data)
 
 			if len(data.Datatypes) == 0 {
 				report.AddDetection(reportdetections.TypeCustomRisk,
@@ -117,6 +185,7 @@ func (scanner *Scanner) Scan(
 						StartColumnNumber: detection.MatchNode.ContentStart.Column,
 						EndColumnNumber:   detection.MatchNode.ContentEnd.Column,
 						Content:           detection.MatchNode.Content(),
+						Confidence:        confidence,
 					})
 			}
 
@@ -128,6 +197,7 @@ func (scanner *Scanner) Scan(
 					detection,
 					datatypeDetection,
 					"",
+					confidence,
 				)
 			}
 		}
@@ -143,22 +213,31 @@ func reportDatatypeDetection(
 	detection,
 	datatypeDetection *detectortypes.Detection,
 	objectName string,
+	confidence string,
 ) {
 	data := datatypeDetection.Data.(datatype.Data)
 
 	for _, property := range data.Properties {
+		// data followed from another file is reported where it's used
+		sourceNode := property.Node
+		if sourceNode.Linked() {
+			sourceNode = detection.MatchNode
+		}
+
+		detectionSource := source.New(
+			file,
+			file.Path,
+			sourceNode.ContentStart.Line,
+			sourceNode.ContentStart.Column,
+			sourceNode.ContentEnd.Line,
+			sourceNode.ContentEnd.Column,
+			"",
+		)
+
 		report.AddDetection(
 			reportdetections.TypeCustomClassified,
 			detectorType,
-			source.New(
-				file,
-				file.Path,
-				property.Node.ContentStart.Line,
-				property.Node.ContentStart.Column,
-				property.Node.ContentEnd.Line,
-				property.Node.ContentEnd.Column,
-				"",
-			),
+			detectionSource,
 			reportschema.Schema{
 				ObjectName:           objectName,
 				NormalizedObjectName: pluralize.Singular(strings.ToLower(objectName)),
@@ -171,10 +250,15 @@ func reportDatatypeDetection(
 					StartColumnNumber: detection.MatchNode.ContentStart.Column,
 					EndColumnNumber:   detection.MatchNode.ContentEnd.Column,
 					Content:           detection.MatchNode.Content(),
+					Confidence:        confidence,
 				},
 			},
 		)
 
+		if annotation := property.Node.Purpose(); annotation != nil {
+			report.AddDetection(reportdetections.TypePurpose, detectorType, detectionSource, *annotation)
+		}
+
 		if property.Datatype != nil {
 			reportDatatypeDetection(
 				report,
@@ -183,11 +267,57 @@ func reportDatatypeDetection(
 				detection,
 				property.Datatype,
 				property.Name,
+				confidence,
 			)
 		}
 	}
 }
 
+// detectionConfidence is the confidence of the pattern matched, or else of the
+// rule, lowered when all the data of the detection was followed from another
+// file. It is empty when it's the same as the confidence of the rule.
+func (scanner *Scanner) detectionConfidence(ruleID string, data customruletypes.Data) string {
+	ruleConfidence := scanner.ruleConfidences[ruleID]
+
+	confidence := data.Confidence
+	if confidence == "" {
+		confidence = ruleConfidence
+	}
+
+	if len(data.Datatypes) != 0 && allLinked(data.Datatypes) {
+		confidence = globaltypes.LowerConfidence(confidence)
+	}
+
+	if confidence == ruleConfidence {
+		return ""
+	}
+
+	return confidence
+}
+
+// allLinked tells whether all the data of the detections was followed from
+// another file. Detections without any properties hold no such data, so they
+// aren't linked.
+func allLinked(datatypeDetections []*detectortypes.Detection) bool {
+	hasProperties := false
+
+	for _, datatypeDetection := range datatypeDetections {
+		for _, property := range datatypeDetection.Data.(datatype.Data).Properties {
+			hasProperties = true
+
+			if !property.Node.Linked() {
+				return false
+			}
+
+			if property.Datatype != nil && !allLinked([]*detectortypes.Detection{property.Datatype}) {
+				return false
+			}
+		}
+	}
+
+	return hasProperties
+}
+
 func (scanner *Scanner) Close() {
 	for _, languageScanner := range scanner.languageScanners {
 		languageScanner.Close()
@@ -0,0 +1,37 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/scanner/ast/tree"
+	customruletypes "github.com/bearer/bearer/internal/scanner/detectors/customrule/types"
+	"github.com/bearer/bearer/internal/scanner/detectors/datatype"
+	detectortypes "github.com/bearer/bearer/internal/scanner/detectors/types"
+	globaltypes "github.com/bearer/bearer/internal/types"
+)
+
+func TestAllLinkedWithoutProperties(t *testing.T) {
+	detections := []*detectortypes.Detection{{Data: datatype.Data{}}}
+
+	assert.False(t, allLinked(detections))
+}
+
+func TestAllLinkedWithLocalProperty(t *testing.T) {
+	detections := []*detectortypes.Detection{{
+		Data: datatype.Data{Properties: []datatype.Property{{Name: "email", Node: &tree.Node{}}}},
+	}}
+
+	assert.False(t, allLinked(detections))
+}
+
+func TestDetectionConfidenceWithoutProperties(t *testing.T) {
+	scanner := &Scanner{ruleConfidences: map[string]string{"test_rule": globaltypes.ConfidenceHigh}}
+
+	confidence := scanner.detectionConfidence("test_rule", customruletypes.Data{
+		Datatypes: []*detectortypes.Detection{{Data: datatype.Data{}}},
+	})
+
+	assert.Equal(t, "", confidence)
+}
@@ -0,0 +1,137 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+// RuleTiming is the time spent on a rule over a scan. The evaluation time of a
+// rule includes the time spent evaluating the rules it refers to.
+type RuleTiming struct {
+	RuleID           string  `json:"rule_id"`
+	CompileTimeMs    float64 `json:"compile_time_ms"`
+	EvaluationTimeMs float64 `json:"evaluation_time_ms"`
+	Matches          int     `json:"matches"`
+}
+
+type RuleTimings struct {
+	CompileTimeMs    float64      `json:"compile_time_ms"`
+	EvaluationTimeMs float64      `json:"evaluation_time_ms"`
+	Rules            []RuleTiming `json:"rules"`
+}
+
+// RuleTimings returns the compile and evaluation time, and the number of
+// findings, of each rule, slowest to evaluate first
+func (stats *Stats) RuleTimings() RuleTimings {
+	ruleIDs := set.New[string]()
+	ruleIDs.AddAll(maps.Keys(stats.rules))
+	ruleIDs.AddAll(maps.Keys(stats.compile))
+	ruleIDs.AddAll(maps.Keys(stats.matches))
+
+	var compileTime, evaluationTime time.Duration
+	timings := RuleTimings{Rules: make([]RuleTiming, 0, len(ruleIDs))}
+
+	for _, ruleID := range ruleIDs.Items() {
+		compileTime += stats.compile[ruleID]
+		evaluationTime += stats.rules[ruleID]
+
+		timings.Rules = append(timings.Rules, RuleTiming{
+			RuleID:           ruleID,
+			CompileTimeMs:    milliseconds(stats.compile[ruleID]),
+			EvaluationTimeMs: milliseconds(stats.rules[ruleID]),
+			Matches:          stats.matches[ruleID],
+		})
+	}
+
+	slices.SortFunc(timings.Rules, func(a, b RuleTiming) int {
+		if a.EvaluationTimeMs != b.EvaluationTimeMs {
+			if a.EvaluationTimeMs > b.EvaluationTimeMs {
+				return -1
+			}
+
+			return 1
+		}
+
+		if a.CompileTimeMs != b.CompileTimeMs {
+			if a.CompileTimeMs > b.CompileTimeMs {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a.RuleID, b.RuleID)
+	})
+
+	timings.CompileTimeMs = milliseconds(compileTime)
+	timings.EvaluationTimeMs = milliseconds(evaluationTime)
+
+	return timings
+}
+
+func (timings RuleTimings) JSON() ([]byte, error) {
+	content, err := json.MarshalIndent(timings, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rule timings: %w", err)
+	}
+
+	return append(content, '\n'), nil
+}
+
+// Summary lists the given number of rules slowest to evaluate
+func (timings RuleTimings) Summary(limit int) string {
+	var s strings.Builder
+
+	fmt.Fprintf(
+		&s,
+		"Slowest rules (compile %s, evaluation %s):\n",
+		duration(timings.CompileTimeMs),
+		duration(timings.EvaluationTimeMs),
+	)
+
+	rules := timings.Rules
+	if len(rules) > limit {
+		rules = rules[:limit]
+	}
+
+	for _, rule := range rules {
+		percentage := 0.0
+		if timings.EvaluationTimeMs != 0 {
+			percentage = (rule.EvaluationTimeMs / timings.EvaluationTimeMs) * 100
+		}
+
+		matches := "matches"
+		if rule.Matches == 1 {
+			matches = "match"
+		}
+
+		fmt.Fprintf(
+			&s,
+			"  - %s [evaluation %s %.2f%%, compile %s, %d %s]\n",
+			rule.RuleID,
+			duration(rule.EvaluationTimeMs),
+			percentage,
+			duration(rule.CompileTimeMs),
+			rule.Matches,
+			matches,
+		)
+	}
+
+	return s.String()
+}
+
+func milliseconds(value time.Duration) float64 {
+	return math.Round(float64(value)/float64(time.Microsecond)) / 1000
+}
+
+func duration(milliseconds float64) time.Duration {
+	return time.Duration(milliseconds * float64(time.Millisecond)).Round(time.Microsecond)
+}
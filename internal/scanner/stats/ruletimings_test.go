@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleTimings(t *testing.T) {
+	stats := New()
+
+	fileStats := NewFileStats()
+	fileStats.rules["fast_rule"] = 2 * time.Millisecond
+	fileStats.rules["slow_rule"] = 6 * time.Millisecond
+	fileStats.RuleMatches("slow_rule", 1)
+	stats.AddFileStats(fileStats)
+
+	otherFileStats := NewFileStats()
+	otherFileStats.rules["slow_rule"] = 2 * time.Millisecond
+	otherFileStats.RuleMatches("slow_rule", 2)
+	stats.AddFileStats(otherFileStats)
+
+	compileStats := NewCompileStats()
+	compileStats.rules["fast_rule"] = time.Millisecond
+	stats.AddCompileStats(compileStats)
+
+	slowerCompileStats := NewCompileStats()
+	slowerCompileStats.rules["fast_rule"] = 3 * time.Millisecond
+	stats.AddCompileStats(slowerCompileStats)
+
+	timings := stats.RuleTimings()
+
+	assert.Equal(t, RuleTimings{
+		CompileTimeMs:    3,
+		EvaluationTimeMs: 10,
+		Rules: []RuleTiming{
+			{RuleID: "slow_rule", EvaluationTimeMs: 8, Matches: 3},
+			{RuleID: "fast_rule", CompileTimeMs: 3, EvaluationTimeMs: 2},
+		},
+	}, timings)
+
+	assert.Equal(t, `Slowest rules (compile 3ms, evaluation 10ms):
+  - slow_rule [evaluation 8ms 80.00%, compile 0s, 3 matches]
+`, timings.Summary(1))
+}
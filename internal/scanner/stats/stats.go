@@ -39,15 +39,24 @@ type failedFile struct {
 }
 
 type FileStats struct {
-	rules map[string]time.Duration
+	rules   map[string]time.Duration
+	matches map[string]int
 }
 
 type fileStatsJSON struct {
-	Rules map[string]time.Duration
+	Rules   map[string]time.Duration
+	Matches map[string]int
+}
+
+// CompileStats are the time taken to compile the patterns of each rule
+type CompileStats struct {
+	rules map[string]time.Duration
 }
 
 type Stats struct {
 	rules             map[string]time.Duration
+	matches           map[string]int
+	compile           map[string]time.Duration
 	slowFiles         []slowFile
 	totalFileDuration time.Duration
 	failedFiles       []failedFile
@@ -55,7 +64,7 @@ type Stats struct {
 }
 
 func NewFileStats() *FileStats {
-	return &FileStats{rules: make(map[string]time.Duration)}
+	return &FileStats{rules: make(map[string]time.Duration), matches: make(map[string]int)}
 }
 
 func (stats *FileStats) Rule(ruleID string, startTime time.Time) {
@@ -67,10 +76,19 @@ func (stats *FileStats) Rule(ruleID string, startTime time.Time) {
 	stats.rules[ruleID] += duration
 }
 
+// RuleMatches records the number of findings a rule reported
+func (stats *FileStats) RuleMatches(ruleID string, count int) {
+	if stats == nil {
+		return
+	}
+
+	stats.matches[ruleID] += count
+}
+
 func (stats *FileStats) MarshalJSON() ([]byte, error) {
 	var statsJSON *fileStatsJSON
 	if stats != nil {
-		statsJSON = &fileStatsJSON{Rules: stats.rules}
+		statsJSON = &fileStatsJSON{Rules: stats.rules, Matches: stats.matches}
 	}
 
 	return json.Marshal(statsJSON)
@@ -84,14 +102,51 @@ func (stats *FileStats) UnmarshalJSON(input []byte) error {
 	}
 
 	if statsJSON != nil {
-		*stats = FileStats{rules: statsJSON.Rules}
+		*stats = FileStats{rules: statsJSON.Rules, matches: statsJSON.Matches}
+	}
+
+	return nil
+}
+
+func NewCompileStats() *CompileStats {
+	return &CompileStats{rules: make(map[string]time.Duration)}
+}
+
+func (stats *CompileStats) Rule(ruleID string, startTime time.Time) {
+	if stats == nil {
+		return
+	}
+
+	stats.rules[ruleID] += time.Since(startTime)
+}
+
+func (stats *CompileStats) MarshalJSON() ([]byte, error) {
+	var rules map[string]time.Duration
+	if stats != nil {
+		rules = stats.rules
 	}
 
+	return json.Marshal(rules)
+}
+
+func (stats *CompileStats) UnmarshalJSON(input []byte) error {
+	var rules map[string]time.Duration
+
+	if err := json.Unmarshal(input, &rules); err != nil {
+		return err
+	}
+
+	*stats = CompileStats{rules: rules}
+
 	return nil
 }
 
 func New() *Stats {
-	return &Stats{rules: make(map[string]time.Duration)}
+	return &Stats{
+		rules:   make(map[string]time.Duration),
+		matches: make(map[string]int),
+		compile: make(map[string]time.Duration),
+	}
 }
 
 func (stats *Stats) File(filename string, startTime time.Time) time.Duration {
@@ -149,6 +204,27 @@ func (stats *Stats) AddFileStats(fileStats *FileStats) {
 	for ruleID, duration := range fileStats.rules {
 		stats.rules[ruleID] += duration
 	}
+
+	for ruleID, count := range fileStats.matches {
+		stats.matches[ruleID] += count
+	}
+}
+
+// AddCompileStats records the compile times of a worker. Every worker
+// compiles the same rules, so the slowest compile of each rule is kept.
+func (stats *Stats) AddCompileStats(compileStats *CompileStats) {
+	if stats == nil || compileStats == nil {
+		return
+	}
+
+	stats.fileMutex.Lock()
+	defer stats.fileMutex.Unlock()
+
+	for ruleID, duration := range compileStats.rules {
+		if duration > stats.compile[ruleID] {
+			stats.compile[ruleID] = duration
+		}
+	}
 }
 
 func (stats *Stats) String() string {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/bearer/bearer/internal/commands/process/settings"
+	"github.com/bearer/bearer/internal/languages/embedded"
 	"github.com/bearer/bearer/internal/scanner/ast/tree"
 	patternquerybuilder "github.com/bearer/bearer/internal/scanner/detectors/customrule/patternquery/builder"
 	"github.com/bearer/bearer/internal/scanner/language"
@@ -154,8 +155,15 @@ func NewSet(language language.Language, ruleSet *ruleset.Set) (*Set, error) {
 func (set *Set) add(language language.Language, rule *ruleset.Rule) error {
 	builder := NewBuilder()
 
+	patternLanguage := language
+	if languageID := rule.EmbeddedLanguage(); languageID != "" {
+		if patternLanguage = embedded.Get(languageID); patternLanguage == nil {
+			return fmt.Errorf("unsupported embedded language '%s'", languageID)
+		}
+	}
+
 	for _, pattern := range rule.Patterns() {
-		if err := addVariablesFromPattern(language, builder, pattern.Pattern); err != nil {
+		if err := addVariablesFromPattern(patternLanguage, builder, pattern.Pattern); err != nil {
 			return err
 		}
 
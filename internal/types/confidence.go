@@ -0,0 +1,37 @@
+package types
+
+var ConfidenceLow = "low"
+var ConfidenceMedium = "medium"
+var ConfidenceHigh = "high"
+
+// these must be kept in order, lowest first
+var Confidences = []string{ConfidenceLow, ConfidenceMedium, ConfidenceHigh}
+
+// LowerConfidence returns the confidence level below the given one. Low and
+// unknown levels are returned unchanged.
+func LowerConfidence(confidence string) string {
+	for i, level := range Confidences {
+		if level == confidence && i != 0 {
+			return Confidences[i-1]
+		}
+	}
+
+	return confidence
+}
+
+// ConfidenceAtLeast reports whether confidence is at or above the minimum
+// confidence level. Unknown levels never meet the minimum.
+func ConfidenceAtLeast(confidence string, minimum string) bool {
+	confidenceIndex := -1
+	minimumIndex := -1
+	for i, level := range Confidences {
+		if level == confidence {
+			confidenceIndex = i
+		}
+		if level == minimum {
+			minimumIndex = i
+		}
+	}
+
+	return confidenceIndex != -1 && minimumIndex != -1 && confidenceIndex >= minimumIndex
+}
@@ -0,0 +1,19 @@
+package types
+
+// categories of the third-party processors receiving data, as given by the
+// `category` of their recipe
+var ProcessorAdvertising = "advertising"
+var ProcessorAnalytics = "analytics"
+var ProcessorCommunication = "communication"
+var ProcessorInfrastructure = "infrastructure"
+var ProcessorMonitoring = "monitoring"
+var ProcessorPayment = "payment"
+
+var ProcessorCategories = []string{
+	ProcessorAdvertising,
+	ProcessorAnalytics,
+	ProcessorCommunication,
+	ProcessorInfrastructure,
+	ProcessorMonitoring,
+	ProcessorPayment,
+}
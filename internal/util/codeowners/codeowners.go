@@ -0,0 +1,93 @@
+package codeowners
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+// locations searched for a CODEOWNERS file, relative to the project root
+var locations = []string{
+	"CODEOWNERS",
+	filepath.Join(".github", "CODEOWNERS"),
+	filepath.Join(".gitlab", "CODEOWNERS"),
+	filepath.Join("docs", "CODEOWNERS"),
+}
+
+type entry struct {
+	pattern *ignore.GitIgnore
+	owners  []string
+}
+
+type CodeOwners struct {
+	entries []entry
+}
+
+// Load reads the CODEOWNERS file of the project at rootDir. When rootDir has
+// no CODEOWNERS file, the result has no owners for any path.
+func Load(rootDir string) (*CodeOwners, error) {
+	for _, location := range locations {
+		file, err := os.Open(filepath.Join(rootDir, location))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		return Parse(bufio.NewScanner(file))
+	}
+
+	return &CodeOwners{}, nil
+}
+
+func Parse(scanner *bufio.Scanner) (*CodeOwners, error) {
+	codeOwners := &CodeOwners{}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// GitLab sections e.g. [Section Name]
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		var owners []string
+		for _, field := range fields[1:] {
+			if strings.HasPrefix(field, "#") {
+				break
+			}
+			owners = append(owners, field)
+		}
+
+		codeOwners.entries = append(codeOwners.entries, entry{
+			pattern: ignore.CompileIgnoreLines(fields[0]),
+			owners:  owners,
+		})
+	}
+
+	return codeOwners, scanner.Err()
+}
+
+// Owners returns the owners of the given path, which is relative to the
+// project root. As with Git hosting providers, the last matching pattern wins.
+func (codeOwners *CodeOwners) Owners(path string) []string {
+	path = filepath.ToSlash(path)
+
+	for i := len(codeOwners.entries) - 1; i >= 0; i-- {
+		if codeOwners.entries[i].pattern.MatchesPath(path) {
+			return codeOwners.entries[i].owners
+		}
+	}
+
+	return nil
+}
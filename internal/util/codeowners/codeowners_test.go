@@ -0,0 +1,45 @@
+package codeowners_test
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/util/codeowners"
+)
+
+const codeOwnersFile = `
+# default owners
+*                @org/everyone
+
+/app/            @org/backend
+*.js             @org/frontend # inline comment
+/app/payments/   @org/payments @alice
+docs/**          @org/docs
+/config/secrets.yml
+`
+
+func TestOwners(t *testing.T) {
+	codeOwners, err := codeowners.Parse(bufio.NewScanner(strings.NewReader(codeOwnersFile)))
+	if err != nil {
+		t.Fatalf("failed to parse code owners: %s", err)
+	}
+
+	for _, test := range []struct {
+		Path     string
+		Expected []string
+	}{
+		{Path: "README.md", Expected: []string{"@org/everyone"}},
+		{Path: "app/models/user.rb", Expected: []string{"@org/backend"}},
+		{Path: "app/assets/main.js", Expected: []string{"@org/frontend"}},
+		{Path: "app/payments/stripe.rb", Expected: []string{"@org/payments", "@alice"}},
+		{Path: "docs/guides/setup.md", Expected: []string{"@org/docs"}},
+		{Path: "config/secrets.yml", Expected: nil},
+	} {
+		t.Run(test.Path, func(tt *testing.T) {
+			assert.Equal(tt, test.Expected, codeOwners.Owners(test.Path))
+		})
+	}
+}
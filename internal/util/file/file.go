@@ -14,6 +14,7 @@ import (
 	"github.com/go-enry/go-enry/v2"
 	"github.com/rs/zerolog/log"
 
+	"github.com/bearer/bearer/internal/util/maputil"
 	"github.com/bearer/bearer/internal/util/regex"
 
 	ignore "github.com/sabhiram/go-gitignore"
@@ -45,6 +46,23 @@ var ignoredFilenames = []*regexp.Regexp{
 	regexp.MustCompile(`\.map\.js$`),
 }
 
+// languageNames maps the languages which file extensions can be assigned to
+// onto their linguist names
+var languageNames = map[string]string{
+	"c":          "C",
+	"go":         "Go",
+	"java":       "Java",
+	"javascript": "JavaScript",
+	"kotlin":     "Kotlin",
+	"php":        "PHP",
+	"python":     "Python",
+	"ruby":       "Ruby",
+	"rust":       "Rust",
+	"shell":      "Shell",
+	"sql":        "SQL",
+	"typescript": "TypeScript",
+}
+
 type AllowDirFunction func(dir *Path) (bool, error)
 type VisitFileFunction func(file *FileInfo) error
 
@@ -82,6 +100,35 @@ func (fileInfo *FileInfo) isGlobalIgnored() bool {
 	return fileInfo.isBinary || fileInfo.isGitIgnored || fileInfo.isImage || fileInfo.isTest
 }
 
+// OverrideLanguage assigns the language configured for the extension of the
+// file, eg. `{"jbuilder": "ruby"}` scans `.jbuilder` files as Ruby
+func (fileInfo *FileInfo) OverrideLanguage(languageExtensions map[string]string) {
+	language, ok := languageExtensions[strings.TrimPrefix(fileInfo.Extension, ".")]
+	if !ok {
+		return
+	}
+
+	name, ok := LanguageName(language)
+	if !ok {
+		return
+	}
+
+	fileInfo.Language = name
+	fileInfo.LanguageType = enry.GetLanguageType(name)
+}
+
+// LanguageName returns the linguist name of a language which file extensions
+// can be assigned to, eg. `Ruby` for `ruby`
+func LanguageName(language string) (string, bool) {
+	name, ok := languageNames[strings.ToLower(language)]
+	return name, ok
+}
+
+// LanguageNames lists the languages which file extensions can be assigned to
+func LanguageNames() []string {
+	return maputil.SortedStringKeys(languageNames)
+}
+
 func (fileInfo *FileInfo) LanguageTypeString() string {
 	switch fileInfo.LanguageType {
 	case enry.Data:
@@ -110,7 +157,21 @@ func (path *Path) Exists() bool {
 	return true
 }
 
-func IterateFilesList(rootDir string, files []string, allowDir AllowDirFunction, visitFile VisitFileFunction) error {
+// IsIgnoredFilename tells whether the file at the relative path is skipped
+// because of its name, such as test files and dependencies
+func IsIgnoredFilename(relativePath string) bool {
+	return regex.AnyMatch(ignoredFilenames, relativePath)
+}
+
+// IterateFilesList visits the given files. Files skipped because of their name
+// are only visited when includeIgnored is true.
+func IterateFilesList(
+	rootDir string,
+	files []string,
+	includeIgnored bool,
+	allowDir AllowDirFunction,
+	visitFile VisitFileFunction,
+) error {
 	gitIgnore := getGitIgnore(rootDir)
 
 	rootDir, err := filepath.Abs(rootDir)
@@ -144,7 +205,7 @@ func IterateFilesList(rootDir string, files []string, allowDir AllowDirFunction,
 			relativePath += "/"
 		}
 
-		if regex.AnyMatch(ignoredFilenames, relativePath) {
+		if !includeIgnored && IsIgnoredFilename(relativePath) {
 			continue
 		}
 
@@ -359,6 +420,37 @@ func ReadFileSinkLines(
 	return extract, nil
 }
 
+// ReadFileLineRange returns the lines between startLine and endLine
+// (inclusive), stopping early at the end of the file
+func ReadFileLineRange(filePath string, startLine int, endLine int) ([]Line, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return []Line{}, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineCounter := 1
+	var lines []Line
+
+	for scanner.Scan() && lineCounter <= endLine {
+		if lineCounter >= startLine {
+			lines = append(lines, Line{
+				Extract:    scanner.Text(),
+				LineNumber: lineCounter,
+			})
+		}
+
+		lineCounter++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return []Line{}, err
+	}
+
+	return lines, nil
+}
+
 func countLeadingSpaces(line string) int {
 	return len(line) - len(strings.TrimLeft(line, " "))
 }
@@ -0,0 +1,53 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/util/file"
+)
+
+func TestOverrideLanguage(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) *file.FileInfo {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write file, err: %s", err)
+		}
+
+		fileInfo, err := file.FileInfoFromPath(path)
+		if err != nil {
+			t.Fatalf("failed to read file info, err: %s", err)
+		}
+
+		return fileInfo
+	}
+
+	languageExtensions := map[string]string{"jbuilder": "ruby", "es6": "javascript", "gsp": "cobol"}
+
+	t.Run("assigns the configured language", func(t *testing.T) {
+		fileInfo := writeFile("show.JBuilder", "json.email user.email\n")
+		fileInfo.OverrideLanguage(languageExtensions)
+
+		assert.Equal(t, "Ruby", fileInfo.Language)
+		assert.Equal(t, "programming", fileInfo.LanguageTypeString())
+	})
+
+	t.Run("keeps the detected language of other extensions", func(t *testing.T) {
+		fileInfo := writeFile("user.rb", "class User\nend\n")
+		fileInfo.OverrideLanguage(map[string]string{"es6": "javascript"})
+
+		assert.Equal(t, "Ruby", fileInfo.Language)
+	})
+
+	t.Run("ignores unsupported languages", func(t *testing.T) {
+		fileInfo := writeFile("show.gsp", "<html></html>\n")
+		language := fileInfo.Language
+		fileInfo.OverrideLanguage(languageExtensions)
+
+		assert.Equal(t, language, fileInfo.Language)
+	})
+}
@@ -0,0 +1,152 @@
+package ignore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	types "github.com/bearer/bearer/internal/util/ignore/types"
+)
+
+var ErrInvalidPathPrefix = errors.New("invalid path prefix; prefixes must be given as old=new pairs, e.g. old/=new/")
+
+// Rename maps a path before a restructure to the path after it. Paths ending
+// with a slash are directory prefixes, others are file paths.
+type Rename struct {
+	From string
+	To   string
+}
+
+// FingerprintFunction returns the fingerprint of the given instance of a
+// rule's finding in a file
+type FingerprintFunction func(ruleID string, filename string, instance int) string
+
+// ParsePathPrefix reads a rename given as `old/=new/`
+func ParsePathPrefix(value string) (Rename, error) {
+	from, to, found := strings.Cut(value, "=")
+	if !found || from == "" || to == "" {
+		return Rename{}, ErrInvalidPathPrefix
+	}
+
+	return Rename{From: from, To: to}, nil
+}
+
+// ReadRenameMap reads a YAML or JSON file mapping old paths to new paths
+func ReadRenameMap(path string) ([]Rename, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rename map %s: %w", path, err)
+	}
+
+	var renameMap map[string]string
+	if err := yaml.Unmarshal(content, &renameMap); err != nil {
+		return nil, fmt.Errorf("error parsing rename map %s: %w", path, err)
+	}
+
+	renames := make([]Rename, 0, len(renameMap))
+	for from, to := range renameMap {
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("error parsing rename map %s: paths can't be empty", path)
+		}
+
+		renames = append(renames, Rename{From: from, To: to})
+	}
+
+	return renames, nil
+}
+
+// PreviousPath returns the path a file had before the renames. The most
+// specific rename applies.
+func PreviousPath(renames []Rename, path string) (string, bool) {
+	previous := ""
+	matchLength := -1
+	for _, rename := range renames {
+		if len(rename.To) <= matchLength {
+			continue
+		}
+
+		if path == rename.To {
+			previous, matchLength = rename.From, len(rename.To)
+		} else if strings.HasSuffix(rename.To, "/") && strings.HasPrefix(path, rename.To) {
+			previous, matchLength = rename.From+strings.TrimPrefix(path, rename.To), len(rename.To)
+		}
+	}
+
+	return previous, matchLength != -1
+}
+
+// RewriteFingerprints finds the ignored fingerprints of findings in files that
+// have been renamed, by computing the fingerprints each rule would have had
+// in the previous path of each file. It returns the new fingerprint for each
+// of them.
+func RewriteFingerprints(
+	ignoredFingerprints map[string]types.IgnoredFingerprint,
+	files []string,
+	ruleIDs []string,
+	renames []Rename,
+	fingerprint FingerprintFunction,
+) map[string]string {
+	// fingerprints are the hash of the rule and filename, followed by the
+	// instance of the finding within the file
+	instancesByHash := make(map[string][]int)
+	for fingerprintID := range ignoredFingerprints {
+		hash, instance, ok := splitFingerprint(fingerprintID)
+		if ok {
+			instancesByHash[hash] = append(instancesByHash[hash], instance)
+		}
+	}
+
+	rewrites := make(map[string]string)
+	for _, filename := range files {
+		previousFilename, ok := PreviousPath(renames, filename)
+		if !ok || previousFilename == filename {
+			continue
+		}
+
+		for _, ruleID := range ruleIDs {
+			hash, _, _ := splitFingerprint(fingerprint(ruleID, previousFilename, 0))
+			for _, instance := range instancesByHash[hash] {
+				rewrites[fmt.Sprintf("%s_%d", hash, instance)] = fingerprint(ruleID, filename, instance)
+			}
+		}
+	}
+
+	return rewrites
+}
+
+// ApplyRewrites re-keys the ignored fingerprints, keeping their metadata. It
+// returns the fingerprints which were rewritten, sorted, skipping those whose
+// new fingerprint is already ignored.
+func ApplyRewrites(ignoredFingerprints map[string]types.IgnoredFingerprint, rewrites map[string]string) []string {
+	var rewritten []string
+	for oldFingerprint, newFingerprint := range rewrites {
+		if _, exists := ignoredFingerprints[newFingerprint]; exists {
+			continue
+		}
+
+		ignoredFingerprints[newFingerprint] = ignoredFingerprints[oldFingerprint]
+		delete(ignoredFingerprints, oldFingerprint)
+		rewritten = append(rewritten, oldFingerprint)
+	}
+
+	sort.Strings(rewritten)
+	return rewritten
+}
+
+func splitFingerprint(fingerprintID string) (string, int, bool) {
+	separator := strings.LastIndex(fingerprintID, "_")
+	if separator == -1 {
+		return "", 0, false
+	}
+
+	var instance int
+	if _, err := fmt.Sscanf(fingerprintID[separator+1:], "%d", &instance); err != nil {
+		return "", 0, false
+	}
+
+	return fingerprintID[:separator], instance, true
+}
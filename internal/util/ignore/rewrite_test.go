@@ -0,0 +1,95 @@
+package ignore_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/util/ignore"
+	types "github.com/bearer/bearer/internal/util/ignore/types"
+)
+
+func testFingerprint(ruleID string, filename string, instance int) string {
+	return fmt.Sprintf("%s:%s_%d", ruleID, filename, instance)
+}
+
+func TestPreviousPath(t *testing.T) {
+	renames := []ignore.Rename{
+		{From: "app/", To: "services/app/"},
+		{From: "app/legacy/", To: "services/app/lib/"},
+		{From: "main.go", To: "cmd/main.go"},
+	}
+
+	tests := []struct {
+		Path     string
+		Previous string
+		Found    bool
+	}{
+		{Path: "services/app/user.go", Previous: "app/user.go", Found: true},
+		{Path: "services/app/lib/user.go", Previous: "app/legacy/user.go", Found: true},
+		{Path: "cmd/main.go", Previous: "main.go", Found: true},
+		{Path: "services/other.go", Previous: "", Found: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Path, func(t *testing.T) {
+			previous, found := ignore.PreviousPath(renames, test.Path)
+			assert.Equal(t, test.Previous, previous)
+			assert.Equal(t, test.Found, found)
+		})
+	}
+}
+
+func TestParsePathPrefix(t *testing.T) {
+	rename, err := ignore.ParsePathPrefix("app/=services/app/")
+	if assert.NoError(t, err) {
+		assert.Equal(t, ignore.Rename{From: "app/", To: "services/app/"}, rename)
+	}
+
+	_, err = ignore.ParsePathPrefix("app/")
+	assert.Equal(t, ignore.ErrInvalidPathPrefix, err)
+}
+
+func TestRewriteFingerprints(t *testing.T) {
+	comment := "false positive"
+	ignoredFingerprints := map[string]types.IgnoredFingerprint{
+		"rule_a:app/user.go_0":  {Comment: &comment},
+		"rule_a:app/user.go_2":  {},
+		"rule_b:app/user.go_0":  {},
+		"rule_b:app/admin.go_0": {},
+		"rule_a:other.go_0":     {},
+		"rule_b:services/app/admin.go_0": {
+			IgnoredAt: "2023-08-28T09:30:01Z",
+		},
+	}
+
+	rewrites := ignore.RewriteFingerprints(
+		ignoredFingerprints,
+		[]string{"services/app/user.go", "services/app/admin.go", "other.go"},
+		[]string{"rule_a", "rule_b"},
+		[]ignore.Rename{{From: "app/", To: "services/app/"}},
+		testFingerprint,
+	)
+
+	assert.Equal(t, map[string]string{
+		"rule_a:app/user.go_0":  "rule_a:services/app/user.go_0",
+		"rule_a:app/user.go_2":  "rule_a:services/app/user.go_2",
+		"rule_b:app/user.go_0":  "rule_b:services/app/user.go_0",
+		"rule_b:app/admin.go_0": "rule_b:services/app/admin.go_0",
+	}, rewrites)
+
+	rewritten := ignore.ApplyRewrites(ignoredFingerprints, rewrites)
+
+	assert.Equal(t, []string{"rule_a:app/user.go_0", "rule_a:app/user.go_2", "rule_b:app/user.go_0"}, rewritten)
+	assert.Equal(t, map[string]types.IgnoredFingerprint{
+		"rule_a:services/app/user.go_0": {Comment: &comment},
+		"rule_a:services/app/user.go_2": {},
+		"rule_b:services/app/user.go_0": {},
+		"rule_b:app/admin.go_0":         {},
+		"rule_a:other.go_0":             {},
+		"rule_b:services/app/admin.go_0": {
+			IgnoredAt: "2023-08-28T09:30:01Z",
+		},
+	}, ignoredFingerprints)
+}
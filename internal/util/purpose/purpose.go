@@ -0,0 +1,123 @@
+package purpose
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+const (
+	purposeComment = "bearer:purpose="
+	retentionKey   = "retention"
+)
+
+var commentTypes = []string{"comment", "line_comment", "block_comment"}
+
+// Annotation is the processing purpose declared by a `bearer:purpose` comment,
+// eg. `bearer:purpose=fraud-detection retention="90 days"`
+type Annotation struct {
+	Purpose   string `json:"purpose" yaml:"purpose"`
+	Retention string `json:"retention,omitempty" yaml:"retention,omitempty"`
+}
+
+// Match is a node covered by a purpose annotation
+type Match struct {
+	Node       *sitter.Node
+	Annotation Annotation
+}
+
+// Parse returns the purpose annotation held by a comment, if any. Values
+// containing spaces must be quoted.
+func Parse(comment string) (Annotation, bool) {
+	index := strings.Index(comment, purposeComment)
+	if index == -1 {
+		return Annotation{}, false
+	}
+
+	content := strings.TrimSuffix(strings.TrimSpace(comment[index+len(purposeComment):]), "*/")
+	name, rest := readValue(content)
+	if name == "" {
+		return Annotation{}, false
+	}
+
+	annotation := Annotation{Purpose: name}
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+
+		key, value, found := strings.Cut(rest, "=")
+		if !found || strings.ContainsFunc(key, unicode.IsSpace) {
+			break
+		}
+
+		value, rest = readValue(value)
+		if key == retentionKey {
+			annotation.Retention = value
+		}
+	}
+
+	return annotation, true
+}
+
+// Find returns the declarations and statements preceded by a `bearer:purpose`
+// comment. An annotation applies to everything within the node, unless a
+// nested node has its own annotation. Nested matches follow their parent.
+func Find(rootNode *sitter.Node, content []byte) []Match {
+	var matches []Match
+	find(rootNode, content, &matches)
+
+	return matches
+}
+
+// For returns the annotation of the innermost match covering the node, if any
+func For(matches []Match, node *sitter.Node) *Annotation {
+	var annotation *Annotation
+	for i, match := range matches {
+		if node.StartByte() >= match.Node.StartByte() && node.EndByte() <= match.Node.EndByte() {
+			annotation = &matches[i].Annotation
+		}
+	}
+
+	return annotation
+}
+
+func find(node *sitter.Node, content []byte, matches *[]Match) {
+	var pending *Annotation
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+
+		if slices.Contains(commentTypes, child.Type()) {
+			if annotation, ok := Parse(child.Content(content)); ok {
+				pending = &annotation
+			}
+
+			continue
+		}
+
+		if pending != nil {
+			*matches = append(*matches, Match{Node: child, Annotation: *pending})
+			pending = nil
+		}
+
+		find(child, content, matches)
+	}
+}
+
+// readValue reads a value up to the next space, or up to the closing quote
+// when the value is quoted, and returns it along with the remaining content
+func readValue(content string) (string, string) {
+	if strings.HasPrefix(content, `"`) {
+		if value, rest, found := strings.Cut(content[1:], `"`); found {
+			return strings.TrimSpace(value), rest
+		}
+	}
+
+	end := strings.IndexFunc(content, unicode.IsSpace)
+	if end == -1 {
+		end = len(content)
+	}
+
+	return content[:end], content[end:]
+}
@@ -0,0 +1,134 @@
+package purpose_test
+
+import (
+	"context"
+	"testing"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/util/purpose"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    purpose.Annotation
+		found   bool
+	}{
+		{
+			name:    "purpose only",
+			comment: "// bearer:purpose=fraud-detection",
+			want:    purpose.Annotation{Purpose: "fraud-detection"},
+			found:   true,
+		},
+		{
+			name:    "quoted values",
+			comment: `# bearer:purpose="Fraud detection" retention="90 days"`,
+			want:    purpose.Annotation{Purpose: "Fraud detection", Retention: "90 days"},
+			found:   true,
+		},
+		{
+			name:    "block comment",
+			comment: "/* bearer:purpose=billing retention=7y*/",
+			want:    purpose.Annotation{Purpose: "billing", Retention: "7y"},
+			found:   true,
+		},
+		{
+			name:    "trailing text",
+			comment: "// bearer:purpose=billing see the invoicing policy",
+			want:    purpose.Annotation{Purpose: "billing"},
+			found:   true,
+		},
+		{
+			name:    "no purpose",
+			comment: "// bearer:purpose=",
+			found:   false,
+		},
+		{
+			name:    "other comment",
+			comment: "// bearer:disable ruby_lang_logger",
+			found:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, found := purpose.Parse(test.comment)
+
+			assert.Equal(t, test.found, found)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	type match struct {
+		content    string
+		annotation purpose.Annotation
+	}
+
+	tests := []struct {
+		name     string
+		language *sitter.Language
+		content  string
+		want     []match
+	}{
+		{
+			name:     "ruby nested annotations",
+			language: ruby.GetLanguage(),
+			content: `# bearer:purpose=fraud-detection retention=90d
+def check(user)
+  # bearer:purpose=analytics
+  track(user.email)
+  score(user.ip_address)
+end
+
+log(user.email)`,
+			want: []match{
+				{
+					content:    "def check(user)\n  # bearer:purpose=analytics\n  track(user.email)\n  score(user.ip_address)\nend",
+					annotation: purpose.Annotation{Purpose: "fraud-detection", Retention: "90d"},
+				},
+				{
+					content:    "track(user.email)",
+					annotation: purpose.Annotation{Purpose: "analytics"},
+				},
+			},
+		},
+		{
+			name:     "java comments",
+			language: java.GetLanguage(),
+			content: `class Billing {
+  /* bearer:purpose=billing retention="7 years" */
+  void charge(User user) { gateway.charge(user.card); }
+}`,
+			want: []match{
+				{
+					content:    "void charge(User user) { gateway.charge(user.card); }",
+					annotation: purpose.Annotation{Purpose: "billing", Retention: "7 years"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content := []byte(test.content)
+			tree, err := sitter.ParseCtx(context.Background(), content, test.language)
+			if err != nil {
+				t.Fatalf("failed to parse content: %s", err)
+			}
+
+			var got []match
+			for _, result := range purpose.Find(tree, content) {
+				got = append(got, match{content: result.Node.Content(content), annotation: result.Annotation})
+			}
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
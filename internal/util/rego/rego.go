@@ -12,29 +12,56 @@ type Module struct {
 	Content string
 }
 
+// Query is a query prepared once, so it can be evaluated for many inputs
+type Query struct {
+	query rego.PreparedEvalQuery
+}
+
 func RunQuery(query string, input interface{}, modules []Module) (rego.Vars, error) {
-	ctx := context.TODO()
+	preparedQuery, err := PrepareQuery(query, modules)
+	if err != nil {
+		return nil, err
+	}
 
+	results, err := preparedQuery.Eval(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) != 1 {
+		return nil, fmt.Errorf("expected single result from query got %d results %#v:\n%s", len(results), results, query)
+	}
+
+	return results[0], nil
+}
+
+func PrepareQuery(query string, modules []Module) (*Query, error) {
 	options := []func(r *rego.Rego){rego.Query(query)}
 	for _, module := range modules {
 		options = append(options, rego.Module(module.Name, module.Content))
 	}
 
 	r := rego.New(options...)
-	regoQuery, err := r.PrepareForEval(ctx)
+	regoQuery, err := r.PrepareForEval(context.TODO())
 	if err != nil {
 		return nil, err
 	}
 
-	// Create a prepared query that can be evaluated.
-	rs, err := regoQuery.Eval(ctx, rego.EvalInput(input))
+	return &Query{query: regoQuery}, nil
+}
+
+// Eval returns the bindings of each result of the query for the input. There
+// are no results when the query is undefined for the input.
+func (query *Query) Eval(input interface{}) ([]rego.Vars, error) {
+	rs, err := query.query.Eval(context.TODO(), rego.EvalInput(input))
 	if err != nil {
 		return nil, err
 	}
 
-	if len(rs) != 1 {
-		return nil, fmt.Errorf("expected single result from query got %d results %#v:\n%s", len(rs), rs, query)
+	results := make([]rego.Vars, len(rs))
+	for i, result := range rs {
+		results[i] = result.Bindings
 	}
 
-	return rs[0].Bindings, nil
+	return results, nil
 }
@@ -0,0 +1,600 @@
+package rulepack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	OCIPrefix = "oci://"
+
+	ociArtifactType      = "application/vnd.bearer.rules.v1"
+	ociConfigMediaType   = "application/vnd.oci.empty.v1+json"
+	ociLayerMediaType    = "application/vnd.bearer.rules.layer.v1.tar+gzip"
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+	// SignatureAnnotation holds the base64 ed25519 signature of the digest of
+	// the rules layer
+	SignatureAnnotation = "sh.bearer.rules.signature"
+
+	registryUsernameEnv = "BEARER_REGISTRY_USERNAME"
+	registryPasswordEnv = "BEARER_REGISTRY_PASSWORD"
+)
+
+var (
+	ErrRegistryOffline   = errors.New("rule packs can't be pulled from a registry in offline mode")
+	ErrSignatureMissing  = errors.New("rule pack is not signed")
+	ErrSignatureInvalid  = errors.New("rule pack signature is not valid for the public key")
+	ErrDigestInvalid     = errors.New("rule pack digest does not match its content")
+	ErrNotRulePackLayout = errors.New("artifact is not a bearer rule pack")
+)
+
+var challengeParamPattern = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// OCIReference identifies a rule pack in an OCI registry, as
+// registry/repository:tag or registry/repository@digest
+type OCIReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+func ParseOCIReference(reference string) (OCIReference, error) {
+	value := strings.TrimPrefix(reference, OCIPrefix)
+
+	registry, repository, found := strings.Cut(value, "/")
+	if !found || registry == "" || repository == "" ||
+		!(strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		return OCIReference{}, fmt.Errorf("invalid rule pack reference %s; expected registry/repository:tag", reference)
+	}
+
+	result := OCIReference{Registry: registry}
+	if name, digest, found := strings.Cut(repository, "@"); found {
+		repository = name
+		result.Digest = digest
+	}
+
+	if separator := strings.LastIndex(repository, ":"); separator > strings.LastIndex(repository, "/") {
+		result.Tag = repository[separator+1:]
+		repository = repository[:separator]
+	}
+
+	if result.Tag == "" && result.Digest == "" {
+		result.Tag = "latest"
+	}
+
+	result.Repository = repository
+	return result, nil
+}
+
+func (reference OCIReference) String() string {
+	result := reference.Registry + "/" + reference.Repository
+	if reference.Tag != "" {
+		result += ":" + reference.Tag
+	}
+	if reference.Digest != "" {
+		result += "@" + reference.Digest
+	}
+
+	return result
+}
+
+// Name is the last element of the repository, which the pack is installed as
+func (reference OCIReference) Name() string {
+	return reference.Repository[strings.LastIndex(reference.Repository, "/")+1:]
+}
+
+func (reference OCIReference) manifestReference() string {
+	if reference.Digest != "" {
+		return reference.Digest
+	}
+
+	return reference.Tag
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int               `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType"`
+	Config        ociDescriptor     `json:"config"`
+	Layers        []ociDescriptor   `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// Push packages the rule files and component recipes of rulesDir, and pushes
+// them to the registry as an OCI artifact, signed with the key if given. It
+// returns the digest of the pushed manifest.
+func Push(reference OCIReference, rulesDir string, signingKey ed25519.PrivateKey) (string, error) {
+	if reference.Tag == "" || reference.Digest != "" {
+		return "", fmt.Errorf("rule packs must be pushed to a tag, got %s", reference)
+	}
+
+	layer, err := buildArchive(rulesDir)
+	if err != nil {
+		return "", err
+	}
+
+	config := []byte("{}")
+	client := newRegistryClient(reference, "pull,push")
+
+	layerDescriptor, err := client.uploadBlob(ociLayerMediaType, layer)
+	if err != nil {
+		return "", err
+	}
+	layerDescriptor.Annotations = map[string]string{"org.opencontainers.image.title": "rules.tar.gz"}
+
+	configDescriptor, err := client.uploadBlob(ociConfigMediaType, config)
+	if err != nil {
+		return "", err
+	}
+
+	manifest := ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		ArtifactType:  ociArtifactType,
+		Config:        configDescriptor,
+		Layers:        []ociDescriptor{layerDescriptor},
+		Annotations: map[string]string{
+			"org.opencontainers.image.created": time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+	if signingKey != nil {
+		signature := ed25519.Sign(signingKey, []byte(layerDescriptor.Digest))
+		manifest.Annotations[SignatureAnnotation] = base64.StdEncoding.EncodeToString(signature)
+	}
+
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := client.do(http.MethodPut, client.url("manifests", reference.Tag), ociManifestMediaType, content, "")
+	if err != nil {
+		return "", err
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("unexpected response status %s pushing manifest for %s", response.Status, reference)
+	}
+
+	return digestOf(content), nil
+}
+
+// Pull downloads a rule pack from the registry and installs it into packDir.
+// When a public key is given, the pack must be signed with the matching key.
+func Pull(reference OCIReference, packDir string, publicKey ed25519.PublicKey, offline bool) (*Provenance, error) {
+	if offline {
+		return nil, ErrRegistryOffline
+	}
+
+	client := newRegistryClient(reference, "pull")
+
+	manifestContent, err := client.get(client.url("manifests", reference.manifestReference()), ociManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+	if reference.Digest != "" && digestOf(manifestContent) != reference.Digest {
+		return nil, ErrDigestInvalid
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(manifestContent, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest for %s: %w", reference, err)
+	}
+	if manifest.ArtifactType != ociArtifactType || len(manifest.Layers) != 1 || manifest.Layers[0].MediaType != ociLayerMediaType {
+		return nil, ErrNotRulePackLayout
+	}
+	layer := manifest.Layers[0]
+
+	if publicKey != nil {
+		encodedSignature, signed := manifest.Annotations[SignatureAnnotation]
+		if !signed {
+			return nil, ErrSignatureMissing
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(encodedSignature)
+		if err != nil || !ed25519.Verify(publicKey, []byte(layer.Digest), signature) {
+			return nil, ErrSignatureInvalid
+		}
+	}
+
+	content, err := client.get(client.url("blobs", layer.Digest), "")
+	if err != nil {
+		return nil, err
+	}
+	if digestOf(content) != layer.Digest {
+		return nil, ErrDigestInvalid
+	}
+
+	provenance := &Provenance{
+		Name:        reference.Name(),
+		Version:     reference.manifestReference(),
+		URL:         OCIPrefix + reference.String(),
+		SHA256:      strings.TrimPrefix(layer.Digest, "sha256:"),
+		InstalledAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if publicKey != nil {
+		provenance.PublicKey = base64.StdEncoding.EncodeToString(publicKey)
+	}
+
+	if err := installArchive(content, provenance, packDir); err != nil {
+		return nil, err
+	}
+
+	return provenance, nil
+}
+
+// ReadProvenance reads the provenance of the pack installed in packDir
+func ReadProvenance(packDir string) (*Provenance, error) {
+	content, err := os.ReadFile(filepath.Join(packDir, ProvenanceFilename))
+	if err != nil {
+		return nil, err
+	}
+
+	var provenance Provenance
+	if err := json.Unmarshal(content, &provenance); err != nil {
+		return nil, fmt.Errorf("invalid provenance in %s: %w", packDir, err)
+	}
+
+	return &provenance, nil
+}
+
+// VerifiedWith reports whether the signature of the pack was verified with
+// the public key when it was pulled
+func (provenance *Provenance) VerifiedWith(publicKey ed25519.PublicKey) bool {
+	return provenance.PublicKey == base64.StdEncoding.EncodeToString(publicKey)
+}
+
+// LoadSigningKey reads a PEM encoded ed25519 private key, such as one
+// generated with `openssl genpkey -algorithm ed25519`
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key %s: %w", path, err)
+	}
+
+	signingKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid signing key %s: only ed25519 keys are supported", path)
+	}
+
+	return signingKey, nil
+}
+
+// LoadPublicKey reads a PEM encoded ed25519 public key, such as one exported
+// with `openssl pkey -pubout`
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key %s: %w", path, err)
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid public key %s: only ed25519 keys are supported", path)
+	}
+
+	return publicKey, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not a PEM encoded key", path)
+	}
+
+	return block, nil
+}
+
+// buildArchive packages the rule files and component recipes of the directory.
+// Entries are written in order without timestamps, so that the same files
+// always produce the same digest.
+func buildArchive(dir string) ([]byte, error) {
+	var names []string
+	if err := filepath.WalkDir(dir, func(path string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if dirEntry.IsDir() {
+			if strings.HasPrefix(dirEntry.Name(), ".") && path != dir {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		if isRuleFile(name) || isRecipeFile(name) {
+			names = append(names, filepath.ToSlash(name))
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no rule files found in %s", dir)
+	}
+
+	sort.Strings(names)
+
+	buffer := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buffer)
+	tw := tar.NewWriter(gzw)
+
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, err
+		}
+
+		header := &tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func digestOf(content []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
+// registryClient implements the parts of the OCI distribution API needed to
+// push and pull rule packs. Credentials are read from the
+// BEARER_REGISTRY_USERNAME and BEARER_REGISTRY_PASSWORD environment variables.
+type registryClient struct {
+	httpClient *http.Client
+	reference  OCIReference
+	actions    string
+	username   string
+	password   string
+	token      string
+}
+
+func newRegistryClient(reference OCIReference, actions string) *registryClient {
+	return &registryClient{
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		reference:  reference,
+		actions:    actions,
+		username:   os.Getenv(registryUsernameEnv),
+		password:   os.Getenv(registryPasswordEnv),
+	}
+}
+
+func (client *registryClient) url(kind string, reference string) string {
+	// registries running locally are commonly served without TLS
+	scheme := "https"
+	host := strings.Split(client.reference.Registry, ":")[0]
+	if host == "localhost" || host == "127.0.0.1" {
+		scheme = "http"
+	}
+
+	return fmt.Sprintf("%s://%s/v2/%s/%s/%s", scheme, client.reference.Registry, client.reference.Repository, kind, reference)
+}
+
+func (client *registryClient) get(location string, accept string) ([]byte, error) {
+	response, err := client.do(http.MethodGet, location, "", nil, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s from %s", response.Status, location)
+	}
+
+	return io.ReadAll(response.Body)
+}
+
+func (client *registryClient) uploadBlob(mediaType string, content []byte) (ociDescriptor, error) {
+	descriptor := ociDescriptor{MediaType: mediaType, Digest: digestOf(content), Size: len(content)}
+
+	response, err := client.do(http.MethodHead, client.url("blobs", descriptor.Digest), "", nil, "")
+	if err != nil {
+		return descriptor, err
+	}
+	response.Body.Close()
+	if response.StatusCode == http.StatusOK {
+		return descriptor, nil
+	}
+
+	response, err = client.do(http.MethodPost, client.url("blobs", "uploads/"), "", nil, "")
+	if err != nil {
+		return descriptor, err
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusAccepted {
+		return descriptor, fmt.Errorf("unexpected response status %s starting blob upload to %s", response.Status, client.reference)
+	}
+
+	location, err := response.Request.URL.Parse(response.Header.Get("Location"))
+	if err != nil {
+		return descriptor, fmt.Errorf("invalid blob upload location: %w", err)
+	}
+	query := location.Query()
+	query.Set("digest", descriptor.Digest)
+	location.RawQuery = query.Encode()
+
+	response, err = client.do(http.MethodPut, location.String(), "application/octet-stream", content, "")
+	if err != nil {
+		return descriptor, err
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return descriptor, fmt.Errorf("unexpected response status %s uploading blob to %s", response.Status, client.reference)
+	}
+
+	return descriptor, nil
+}
+
+// do sends the request, authenticating as requested by the registry when it
+// responds with a challenge
+func (client *registryClient) do(method string, location string, contentType string, body []byte, accept string) (*http.Response, error) {
+	response, err := client.send(method, location, contentType, body, accept)
+	if err != nil || response.StatusCode != http.StatusUnauthorized || client.token != "" {
+		return response, err
+	}
+
+	challenge := response.Header.Get("WWW-Authenticate")
+	response.Body.Close()
+
+	if err := client.authenticate(challenge); err != nil {
+		return nil, err
+	}
+
+	return client.send(method, location, contentType, body, accept)
+}
+
+func (client *registryClient) send(method string, location string, contentType string, body []byte, accept string) (*http.Response, error) {
+	request, err := http.NewRequest(method, location, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+
+	if client.token != "" {
+		request.Header.Set("Authorization", "Bearer "+client.token)
+	} else if client.username != "" {
+		request.SetBasicAuth(client.username, client.password)
+	}
+
+	return client.httpClient.Do(request)
+}
+
+// authenticate exchanges the credentials, if any, for a token as described
+// by a bearer challenge. The token is requested for every action the client
+// needs, rather than the scope of the challenged request.
+func (client *registryClient) authenticate(challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		if client.username == "" {
+			return fmt.Errorf("%s requires credentials; set %s and %s", client.reference.Registry, registryUsernameEnv, registryPasswordEnv)
+		}
+
+		return fmt.Errorf("invalid credentials for %s", client.reference.Registry)
+	}
+
+	values := make(map[string]string)
+	for _, match := range challengeParamPattern.FindAllStringSubmatch(params, -1) {
+		values[match[1]] = match[2]
+	}
+
+	realm, err := url.Parse(values["realm"])
+	if err != nil || values["realm"] == "" {
+		return fmt.Errorf("invalid authentication challenge from %s", client.reference.Registry)
+	}
+
+	query := realm.Query()
+	if service := values["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:%s", client.reference.Repository, client.actions))
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if client.username != "" {
+		request.SetBasicAuth(client.username, client.password)
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("authentication with %s failed with status %s", client.reference.Registry, response.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return fmt.Errorf("invalid authentication response from %s: %w", client.reference.Registry, err)
+	}
+
+	client.token = token.Token
+	if client.token == "" {
+		client.token = token.AccessToken
+	}
+	if client.token == "" {
+		return fmt.Errorf("no token received from %s", client.reference.Registry)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package rulepack_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bearer/bearer/internal/util/rulepack"
+)
+
+// newRegistry serves the parts of the OCI distribution API used by rule packs
+func newRegistry(t *testing.T) *httptest.Server {
+	var lock sync.Mutex
+	blobs := make(map[string][]byte)
+	manifests := make(map[string][]byte)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		path := strings.TrimPrefix(request.URL.Path, "/v2/org/rules/")
+		switch {
+		case path == "blobs/uploads/" && request.Method == http.MethodPost:
+			writer.Header().Set("Location", "/v2/org/rules/blobs/uploads/1")
+			writer.WriteHeader(http.StatusAccepted)
+		case path == "blobs/uploads/1" && request.Method == http.MethodPut:
+			content, _ := io.ReadAll(request.Body)
+			blobs[request.URL.Query().Get("digest")] = content
+			writer.WriteHeader(http.StatusCreated)
+		case strings.HasPrefix(path, "blobs/"):
+			content, ok := blobs[strings.TrimPrefix(path, "blobs/")]
+			if !ok {
+				writer.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writer.Write(content) //nolint:errcheck
+		case strings.HasPrefix(path, "manifests/") && request.Method == http.MethodPut:
+			content, _ := io.ReadAll(request.Body)
+			manifests[strings.TrimPrefix(path, "manifests/")] = content
+			manifests[fmt.Sprintf("sha256:%x", sha256.Sum256(content))] = content
+			writer.WriteHeader(http.StatusCreated)
+		case strings.HasPrefix(path, "manifests/"):
+			content, ok := manifests[strings.TrimPrefix(path, "manifests/")]
+			if !ok {
+				writer.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writer.Write(content) //nolint:errcheck
+		default:
+			t.Errorf("unexpected request %s %s", request.Method, request.URL)
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func writeRules(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"rules/django_sql_injection.yml": "metadata:\n  id: community_django_sql_injection\n",
+		"recipes/billing_service.json":   `{"name": "Billing Service", "type": "internal_service"}`,
+		"README.md":                      "ignored",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create rules dir, err: %s", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write rule, err: %s", err)
+		}
+	}
+
+	return dir
+}
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		Reference string
+		Want      rulepack.OCIReference
+	}{
+		{"ghcr.io/org/rules:v3", rulepack.OCIReference{Registry: "ghcr.io", Repository: "org/rules", Tag: "v3"}},
+		{"oci://ghcr.io/org/rules", rulepack.OCIReference{Registry: "ghcr.io", Repository: "org/rules", Tag: "latest"}},
+		{"localhost:5000/rules@sha256:abc", rulepack.OCIReference{Registry: "localhost:5000", Repository: "rules", Digest: "sha256:abc"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Reference, func(t *testing.T) {
+			reference, err := rulepack.ParseOCIReference(test.Reference)
+			if err != nil {
+				t.Fatalf("failed to parse reference, err: %s", err)
+			}
+
+			if reference != test.Want {
+				t.Errorf("expected %+v, got %+v", test.Want, reference)
+			}
+		})
+	}
+
+	if _, err := rulepack.ParseOCIReference("org/rules:v3"); err == nil {
+		t.Error("expected an error for a reference without a registry")
+	}
+}
+
+func TestPushPull(t *testing.T) {
+	server := newRegistry(t)
+	publicKey, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key, err: %s", err)
+	}
+
+	reference, err := rulepack.ParseOCIReference(strings.TrimPrefix(server.URL, "http://") + "/org/rules:v3")
+	if err != nil {
+		t.Fatalf("failed to parse reference, err: %s", err)
+	}
+
+	digest, err := rulepack.Push(reference, writeRules(t), signingKey)
+	if err != nil {
+		t.Fatalf("failed to push rules, err: %s", err)
+	}
+
+	packDir := filepath.Join(t.TempDir(), "rules")
+	reference.Digest = digest
+	provenance, err := rulepack.Pull(reference, packDir, publicKey, false)
+	if err != nil {
+		t.Fatalf("failed to pull rules, err: %s", err)
+	}
+
+	expectedFiles := []string{"recipes/billing_service.json", "rules/django_sql_injection.yml"}
+	if strings.Join(provenance.Files, ",") != strings.Join(expectedFiles, ",") {
+		t.Errorf("expected files %v, got %v", expectedFiles, provenance.Files)
+	}
+	for _, file := range append(expectedFiles, rulepack.ProvenanceFilename) {
+		if _, err := os.Stat(filepath.Join(packDir, file)); err != nil {
+			t.Errorf("expected %s to be installed, err: %s", file, err)
+		}
+	}
+
+	otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key, err: %s", err)
+	}
+
+	_, err = rulepack.Pull(reference, filepath.Join(t.TempDir(), "rules"), otherKey, false)
+	if !errors.Is(err, rulepack.ErrSignatureInvalid) {
+		t.Errorf("expected signature error, got %v", err)
+	}
+}
+
+func TestPullUnsigned(t *testing.T) {
+	server := newRegistry(t)
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key, err: %s", err)
+	}
+
+	reference, err := rulepack.ParseOCIReference(strings.TrimPrefix(server.URL, "http://") + "/org/rules:v3")
+	if err != nil {
+		t.Fatalf("failed to parse reference, err: %s", err)
+	}
+
+	if _, err := rulepack.Push(reference, writeRules(t), nil); err != nil {
+		t.Fatalf("failed to push rules, err: %s", err)
+	}
+
+	if _, err := rulepack.Pull(reference, filepath.Join(t.TempDir(), "rules"), nil, false); err != nil {
+		t.Errorf("expected unsigned rules to be pulled without a public key, err: %s", err)
+	}
+
+	packDir := filepath.Join(t.TempDir(), "rules")
+	_, err = rulepack.Pull(reference, packDir, publicKey, false)
+	if !errors.Is(err, rulepack.ErrSignatureMissing) {
+		t.Errorf("expected missing signature error, got %v", err)
+	}
+
+	if _, err := os.Stat(packDir); !os.IsNotExist(err) {
+		t.Error("expected the pack not to be installed")
+	}
+}
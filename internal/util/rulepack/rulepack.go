@@ -0,0 +1,334 @@
+package rulepack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const ProvenanceFilename = "provenance.json"
+
+var (
+	ErrRemoteOffline   = errors.New("the rule pack index is not available in offline mode")
+	ErrChecksumInvalid = errors.New("rule pack checksum does not match the index")
+)
+
+type Index struct {
+	Packs []Pack `json:"packs"`
+}
+
+type Pack struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Author      string        `json:"author,omitempty"`
+	Languages   []string      `json:"languages,omitempty"`
+	Tags        []string      `json:"tags,omitempty"`
+	Versions    []PackVersion `json:"versions"`
+}
+
+type PackVersion struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+	SHA256  string `json:"sha256"`
+}
+
+// Provenance records where an installed rule pack came from
+type Provenance struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	URL         string   `json:"url"`
+	SHA256      string   `json:"sha256"`
+	Index       string   `json:"index"`
+	InstalledAt string   `json:"installed_at"`
+	PublicKey   string   `json:"public_key,omitempty"`
+	Files       []string `json:"files"`
+}
+
+func isRemote(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+func open(location string, offline bool) (io.ReadCloser, error) {
+	if !isRemote(location) {
+		return os.Open(location)
+	}
+
+	if offline {
+		return nil, ErrRemoteOffline
+	}
+
+	httpClient := &http.Client{Timeout: 60 * time.Second}
+	response, err := httpClient.Get(location)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 300 {
+		response.Body.Close()
+		return nil, fmt.Errorf("unexpected response status %s from %s", response.Status, location)
+	}
+
+	return response.Body, nil
+}
+
+// FetchIndex loads the rule pack index from a URL or a local file
+func FetchIndex(location string, offline bool) (*Index, error) {
+	reader, err := open(location, offline)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var index Index
+	if err := json.NewDecoder(reader).Decode(&index); err != nil {
+		return nil, fmt.Errorf("invalid rule pack index: %w", err)
+	}
+
+	return &index, nil
+}
+
+// Search returns the packs whose name, description, languages or tags
+// contain the query, ordered by name. An empty query matches every pack.
+func (index *Index) Search(query string) []Pack {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	var result []Pack
+	for _, pack := range index.Packs {
+		fields := append([]string{pack.Name, pack.Description}, pack.Languages...)
+		fields = append(fields, pack.Tags...)
+
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field), query) {
+				result = append(result, pack)
+				break
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// Find looks up a pack in the index, returning the requested version or the
+// latest version when none is given
+func (index *Index) Find(name string, version string) (*Pack, *PackVersion, error) {
+	for i := range index.Packs {
+		pack := &index.Packs[i]
+		if pack.Name != name {
+			continue
+		}
+
+		if version == "" {
+			latest := pack.LatestVersion()
+			if latest == nil {
+				return nil, nil, fmt.Errorf("rule pack %s has no versions", name)
+			}
+
+			return pack, latest, nil
+		}
+
+		for j := range pack.Versions {
+			if strings.TrimPrefix(pack.Versions[j].Version, "v") == strings.TrimPrefix(version, "v") {
+				return pack, &pack.Versions[j], nil
+			}
+		}
+
+		return nil, nil, fmt.Errorf("rule pack %s has no version %s", name, version)
+	}
+
+	return nil, nil, fmt.Errorf("rule pack %s was not found in the index", name)
+}
+
+func (pack Pack) LatestVersion() *PackVersion {
+	var latest *PackVersion
+	for i := range pack.Versions {
+		if latest == nil || semver.Compare(canonicalVersion(pack.Versions[i].Version), canonicalVersion(latest.Version)) > 0 {
+			latest = &pack.Versions[i]
+		}
+	}
+
+	return latest
+}
+
+func canonicalVersion(version string) string {
+	return "v" + strings.TrimPrefix(version, "v")
+}
+
+// Install downloads the pack version, verifies its checksum and extracts its
+// rule files and component recipes into a directory named after the pack
+// within rulesDir. Any previously installed version of the pack is replaced.
+func Install(
+	pack *Pack,
+	version *PackVersion,
+	indexLocation string,
+	rulesDir string,
+	offline bool,
+) (*Provenance, error) {
+	reader, err := open(version.URL, offline)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(content)
+	if !strings.EqualFold(hex.EncodeToString(checksum[:]), version.SHA256) {
+		return nil, ErrChecksumInvalid
+	}
+
+	if pack.Name == "" || pack.Name == ".." || strings.ContainsAny(pack.Name, `/\`) {
+		return nil, fmt.Errorf("invalid rule pack name %q", pack.Name)
+	}
+
+	provenance := &Provenance{
+		Name:        pack.Name,
+		Version:     version.Version,
+		URL:         version.URL,
+		SHA256:      version.SHA256,
+		Index:       indexLocation,
+		InstalledAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if err := installArchive(content, provenance, filepath.Join(rulesDir, pack.Name)); err != nil {
+		return nil, err
+	}
+
+	return provenance, nil
+}
+
+// installArchive extracts the rule files and component recipes of the archive
+// into packDir, along with the provenance, replacing any existing contents
+func installArchive(content []byte, provenance *Provenance, packDir string) error {
+	if err := os.MkdirAll(filepath.Dir(packDir), 0755); err != nil {
+		return err
+	}
+
+	// extract alongside the pack so that a failed install leaves any
+	// existing version in place
+	extractDir, err := os.MkdirTemp(filepath.Dir(packDir), "."+filepath.Base(packDir)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(extractDir)
+
+	files, err := extractRules(content, extractDir)
+	if err != nil {
+		return err
+	}
+	provenance.Files = files
+
+	provenanceContent, err := json.MarshalIndent(provenance, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(extractDir, ProvenanceFilename), provenanceContent, 0644); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(extractDir, 0755); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(packDir); err != nil {
+		return err
+	}
+
+	return os.Rename(extractDir, packDir)
+}
+
+func extractRules(content []byte, dir string) ([]string, error) {
+	gzr, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule pack archive: %w", err)
+	}
+	defer gzr.Close()
+
+	var files []string
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid rule pack archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || !(isRuleFile(header.Name) || isRecipeFile(header.Name)) {
+			continue
+		}
+
+		name := filepath.Clean(header.Name)
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("rule pack archive contains an invalid path %s", header.Name)
+		}
+
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.Copy(file, tr)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+
+		files = append(files, filepath.ToSlash(name))
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func isRuleFile(name string) bool {
+	if strings.Contains(name, ".snapshots") || strings.Contains(name, "testdata") {
+		return false
+	}
+
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// isRecipeFile matches the component recipes a pack can ship in a recipes
+// directory
+func isRecipeFile(name string) bool {
+	if filepath.Ext(name) != ".json" {
+		return false
+	}
+
+	for _, element := range strings.Split(filepath.ToSlash(filepath.Dir(name)), "/") {
+		if element == "recipes" {
+			return true
+		}
+	}
+
+	return false
+}
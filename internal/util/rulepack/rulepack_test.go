@@ -0,0 +1,254 @@
+package rulepack_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bearer/bearer/internal/util/rulepack"
+)
+
+func buildArchive(t *testing.T, files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buffer)
+	tw := tar.NewWriter(gzw)
+
+	for name, content := range files {
+		header := &tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write archive header, err: %s", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write archive content, err: %s", err)
+		}
+	}
+
+	tw.Close()
+	gzw.Close()
+
+	return buffer.Bytes()
+}
+
+func writeIndex(t *testing.T, archive []byte, checksum string) string {
+	dir := t.TempDir()
+
+	archivePath := filepath.Join(dir, "django-1.1.0.tar.gz")
+	if err := os.WriteFile(archivePath, archive, 0644); err != nil {
+		t.Fatalf("failed to write archive, err: %s", err)
+	}
+
+	if checksum == "" {
+		sum := sha256.Sum256(archive)
+		checksum = hex.EncodeToString(sum[:])
+	}
+
+	index := rulepack.Index{
+		Packs: []rulepack.Pack{
+			{
+				Name:        "django",
+				Description: "Extra rules for Django applications",
+				Languages:   []string{"python"},
+				Versions: []rulepack.PackVersion{
+					{Version: "1.1.0", URL: archivePath, SHA256: checksum},
+					{Version: "1.0.0", URL: filepath.Join(dir, "missing.tar.gz")},
+				},
+			},
+			{
+				Name:        "aws",
+				Description: "Rules for AWS SDK usage",
+				Languages:   []string{"javascript", "ruby"},
+				Tags:        []string{"cloud"},
+				Versions:    []rulepack.PackVersion{{Version: "0.2.0"}},
+			},
+		},
+	}
+
+	content, err := json.Marshal(index)
+	if err != nil {
+		t.Fatalf("failed to marshal index, err: %s", err)
+	}
+
+	indexPath := filepath.Join(dir, "index.json")
+	if err := os.WriteFile(indexPath, content, 0644); err != nil {
+		t.Fatalf("failed to write index, err: %s", err)
+	}
+
+	return indexPath
+}
+
+func TestSearch(t *testing.T) {
+	index, err := rulepack.FetchIndex(writeIndex(t, nil, "unused"), false)
+	if err != nil {
+		t.Fatalf("failed to fetch index, err: %s", err)
+	}
+
+	testCases := map[string][]string{
+		"":       {"aws", "django"},
+		"DJANGO": {"django"},
+		"ruby":   {"aws"},
+		"cloud":  {"aws"},
+		"golang": nil,
+	}
+
+	for query, expected := range testCases {
+		var names []string
+		for _, pack := range index.Search(query) {
+			names = append(names, pack.Name)
+		}
+
+		if len(names) != len(expected) {
+			t.Errorf("search %q: expected %v, got %v", query, expected, names)
+			continue
+		}
+
+		for i := range names {
+			if names[i] != expected[i] {
+				t.Errorf("search %q: expected %v, got %v", query, expected, names)
+			}
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	index, err := rulepack.FetchIndex(writeIndex(t, nil, "unused"), false)
+	if err != nil {
+		t.Fatalf("failed to fetch index, err: %s", err)
+	}
+
+	_, latest, err := index.Find("django", "")
+	if err != nil {
+		t.Fatalf("failed to find latest version, err: %s", err)
+	}
+	if latest.Version != "1.1.0" {
+		t.Errorf("expected latest version 1.1.0, got %s", latest.Version)
+	}
+
+	_, specific, err := index.Find("django", "v1.0.0")
+	if err != nil {
+		t.Fatalf("failed to find specific version, err: %s", err)
+	}
+	if specific.Version != "1.0.0" {
+		t.Errorf("expected version 1.0.0, got %s", specific.Version)
+	}
+
+	if _, _, err := index.Find("django", "2.0.0"); err == nil {
+		t.Error("expected an error for an unknown version")
+	}
+
+	if _, _, err := index.Find("rails", ""); err == nil {
+		t.Error("expected an error for an unknown pack")
+	}
+}
+
+func TestInstall(t *testing.T) {
+	archive := buildArchive(t, map[string]string{
+		"rules/django_sql_injection.yml":     "metadata:\n  id: community_django_sql_injection\n",
+		"rules/.snapshots/django_sql.yml":    "ignored",
+		"rules/testdata/main.py":             "ignored",
+		"README.md":                          "ignored",
+		"rules/nested/django_raw_render.yml": "metadata:\n  id: community_django_raw_render\n",
+		"recipes/billing_service.json":       `{"name": "Billing Service", "type": "internal_service"}`,
+		"rules/django_settings.json":         "ignored",
+	})
+
+	indexPath := writeIndex(t, archive, "")
+	index, err := rulepack.FetchIndex(indexPath, false)
+	if err != nil {
+		t.Fatalf("failed to fetch index, err: %s", err)
+	}
+
+	pack, version, err := index.Find("django", "")
+	if err != nil {
+		t.Fatalf("failed to find pack, err: %s", err)
+	}
+
+	rulesDir := filepath.Join(t.TempDir(), "rules")
+	provenance, err := rulepack.Install(pack, version, indexPath, rulesDir, false)
+	if err != nil {
+		t.Fatalf("failed to install pack, err: %s", err)
+	}
+
+	expectedFiles := []string{
+		"recipes/billing_service.json",
+		"rules/django_sql_injection.yml",
+		"rules/nested/django_raw_render.yml",
+	}
+	if len(provenance.Files) != len(expectedFiles) {
+		t.Fatalf("expected files %v, got %v", expectedFiles, provenance.Files)
+	}
+	for i, file := range expectedFiles {
+		if provenance.Files[i] != file {
+			t.Errorf("expected files %v, got %v", expectedFiles, provenance.Files)
+		}
+
+		if _, err := os.Stat(filepath.Join(rulesDir, "django", file)); err != nil {
+			t.Errorf("expected %s to be installed, err: %s", file, err)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(rulesDir, "django", rulepack.ProvenanceFilename))
+	if err != nil {
+		t.Fatalf("failed to read provenance, err: %s", err)
+	}
+
+	var written rulepack.Provenance
+	if err := json.Unmarshal(content, &written); err != nil {
+		t.Fatalf("failed to parse provenance, err: %s", err)
+	}
+
+	if written.Name != "django" || written.Version != "1.1.0" || written.Index != indexPath || written.SHA256 != version.SHA256 {
+		t.Errorf("unexpected provenance %+v", written)
+	}
+
+	entries, err := os.ReadDir(rulesDir)
+	if err != nil {
+		t.Fatalf("failed to read rules dir, err: %s", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the pack directory to remain, got %d entries", len(entries))
+	}
+}
+
+func TestInstallChecksumMismatch(t *testing.T) {
+	archive := buildArchive(t, map[string]string{"rule.yml": "metadata: {}\n"})
+
+	indexPath := writeIndex(t, archive, "0000")
+	index, err := rulepack.FetchIndex(indexPath, false)
+	if err != nil {
+		t.Fatalf("failed to fetch index, err: %s", err)
+	}
+
+	pack, version, err := index.Find("django", "1.1.0")
+	if err != nil {
+		t.Fatalf("failed to find pack, err: %s", err)
+	}
+
+	rulesDir := filepath.Join(t.TempDir(), "rules")
+	_, err = rulepack.Install(pack, version, indexPath, rulesDir, false)
+	if !errors.Is(err, rulepack.ErrChecksumInvalid) {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(rulesDir, "django")); !os.IsNotExist(err) {
+		t.Error("expected the pack not to be installed")
+	}
+}
+
+func TestFetchIndexOffline(t *testing.T) {
+	_, err := rulepack.FetchIndex("https://example.com/index.json", true)
+	if !errors.Is(err, rulepack.ErrRemoteOffline) {
+		t.Fatalf("expected offline error, got %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package sanitization
+
+import (
+	"slices"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/bearer/bearer/internal/util/set"
+)
+
+const sanitizedComment = "bearer:sanitized"
+
+var (
+	commentTypes    = []string{"comment", "line_comment", "block_comment"}
+	annotationTypes = []string{"annotation", "marker_annotation", "decorator"}
+
+	callTypes = []string{
+		"call",
+		"call_expression",
+		"method_invocation",
+		"function_call_expression",
+		"member_call_expression",
+		"scoped_call_expression",
+	}
+
+	functionTypes = []string{
+		"method",
+		"method_declaration",
+		"method_definition",
+		"function_declaration",
+		"function_definition",
+	}
+)
+
+type finder struct {
+	content       []byte
+	annotations   set.Set[string]
+	functionNames set.Set[string]
+	nodes         []*sitter.Node
+}
+
+// Find returns the nodes covered by a sanitization contract. A declaration
+// preceded by a `bearer:sanitized` comment, or annotated with one of the given
+// annotations, is sanitized. When the declaration is a function, the calls to
+// it within the same file are sanitized too.
+func Find(rootNode *sitter.Node, content []byte, annotations []string) []*sitter.Node {
+	finder := &finder{
+		content:       content,
+		annotations:   set.New[string](),
+		functionNames: set.New[string](),
+	}
+	finder.annotations.AddAll(annotations)
+
+	finder.findDeclarations(rootNode)
+	if len(finder.functionNames) != 0 {
+		finder.findCalls(rootNode)
+	}
+
+	return finder.nodes
+}
+
+// Covers tells whether the node is within one of the sanitized nodes
+func Covers(sanitizedNodes []*sitter.Node, node *sitter.Node) bool {
+	for _, sanitizedNode := range sanitizedNodes {
+		if node.StartByte() >= sanitizedNode.StartByte() && node.EndByte() <= sanitizedNode.EndByte() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (finder *finder) findDeclarations(node *sitter.Node) {
+	pending := false
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		pending = finder.visit(pending, child)
+		finder.findDeclarations(child)
+	}
+}
+
+// visit is called for each named child of a node, in order. It returns
+// whether the next declaration is sanitized.
+func (finder *finder) visit(pending bool, node *sitter.Node) bool {
+	if slices.Contains(commentTypes, node.Type()) {
+		content := node.Content(finder.content)
+		return pending || strings.Contains(content, sanitizedComment) || finder.isAttributeComment(content)
+	}
+
+	if slices.Contains(annotationTypes, node.Type()) {
+		if !finder.annotations.Has(annotationName(node.Content(finder.content))) {
+			return pending
+		}
+
+		// Java annotations are part of the modifiers of the declaration, some
+		// grammars nest decorators in the declaration and others have them
+		// precede it
+		parent := node.Parent()
+		switch {
+		case parent != nil && parent.Type() == "modifiers":
+			finder.add(parent.Parent())
+		case parent != nil && slices.Contains(functionTypes, parent.Type()):
+			finder.add(parent)
+		default:
+			return true
+		}
+
+		return pending
+	}
+
+	if pending {
+		finder.add(node)
+	}
+
+	return false
+}
+
+func (finder *finder) add(node *sitter.Node) {
+	if node == nil {
+		return
+	}
+
+	finder.nodes = append(finder.nodes, node)
+
+	// decorated and exported functions are wrapped
+	declaration := node
+	for _, field := range []string{"definition", "declaration"} {
+		if child := node.ChildByFieldName(field); child != nil {
+			declaration = child
+		}
+	}
+
+	if slices.Contains(functionTypes, declaration.Type()) {
+		if name := declaration.ChildByFieldName("name"); name != nil {
+			finder.functionNames.Add(name.Content(finder.content))
+		}
+	}
+}
+
+func (finder *finder) findCalls(node *sitter.Node) {
+	if slices.Contains(callTypes, node.Type()) && finder.functionNames.Has(finder.calleeName(node)) {
+		finder.nodes = append(finder.nodes, node)
+		return
+	}
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		finder.findCalls(node.NamedChild(i))
+	}
+}
+
+func (finder *finder) calleeName(node *sitter.Node) string {
+	for _, field := range []string{"method", "name", "function"} {
+		if callee := node.ChildByFieldName(field); callee != nil {
+			return finder.lastName(callee)
+		}
+	}
+
+	return ""
+}
+
+// lastName returns the name of the function being called, without its
+// receiver or namespace
+func (finder *finder) lastName(node *sitter.Node) string {
+	for _, field := range []string{"property", "attribute", "field", "name"} {
+		if child := node.ChildByFieldName(field); child != nil {
+			return finder.lastName(child)
+		}
+	}
+
+	count := int(node.NamedChildCount())
+	if count == 0 {
+		return node.Content(finder.content)
+	}
+
+	return finder.lastName(node.NamedChild(count - 1))
+}
+
+// isAttributeComment tells whether the comment is a PHP attribute naming one
+// of the annotations. The grammar parses attributes as comments.
+func (finder *finder) isAttributeComment(content string) bool {
+	if !strings.HasPrefix(content, "#[") {
+		return false
+	}
+
+	for _, attribute := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(content, "#["), "]"), ",") {
+		if finder.annotations.Has(annotationName(attribute)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// annotationName returns the name of an annotation without its arguments or
+// namespace, eg. `Redacted` for `@com.acme.Redacted("reason")`
+func annotationName(content string) string {
+	name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(content), "@"))
+	if index := strings.Index(name, "("); index != -1 {
+		name = name[:index]
+	}
+
+	if index := strings.LastIndexAny(name, `.\:`); index != -1 {
+		name = name[index+1:]
+	}
+
+	return strings.TrimSpace(name)
+}
@@ -0,0 +1,85 @@
+package sanitization_test
+
+import (
+	"context"
+	"testing"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/util/sanitization"
+)
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name     string
+		language *sitter.Language
+		content  string
+		want     []string
+	}{
+		{
+			name:     "java annotations",
+			language: java.GetLanguage(),
+			content: `class User {
+  @Redacted private String email;
+  private String name;
+
+  @com.acme.Sanitized("logs")
+  String mask(String value) { return ""; }
+
+  void log() { logger.info(mask(email)); }
+}`,
+			want: []string{
+				"@Redacted private String email;",
+				"@com.acme.Sanitized(\"logs\")\n  String mask(String value) { return \"\"; }",
+				"mask(email)",
+			},
+		},
+		{
+			name:     "java comments",
+			language: java.GetLanguage(),
+			content: `class User {
+  // bearer:sanitized
+  private String email;
+  private String name;
+}`,
+			want: []string{"private String email;"},
+		},
+		{
+			name:     "python decorators and comments",
+			language: python.GetLanguage(),
+			content: `@other
+def log(value):
+    pass
+
+# bearer:sanitized
+def mask(value):
+    return "***"
+
+log(utils.mask(email))`,
+			want: []string{
+				"def mask(value):\n    return \"***\"",
+				"utils.mask(email)",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content := []byte(test.content)
+			tree, err := sitter.ParseCtx(context.Background(), content, test.language)
+			if err != nil {
+				t.Fatalf("failed to parse content: %s", err)
+			}
+
+			var got []string
+			for _, node := range sanitization.Find(tree, content, []string{"Redacted", "Sanitized"}) {
+				got = append(got, node.Content(content))
+			}
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
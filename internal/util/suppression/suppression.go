@@ -0,0 +1,120 @@
+package suppression
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	disableComment = "bearer:disable"
+	reasonKey      = "reason"
+	untilKey       = "until"
+)
+
+// Suppression is a `bearer:disable` comment, disabling rules for the next
+// statement, eg. `bearer:disable ruby_lang_cookies reason="tracked in JIRA-123" until=2025-06-01`
+type Suppression struct {
+	RuleIDs []string
+	Reason  string
+	// Until is the last day the suppression applies, as YYYY-MM-DD
+	Until string
+}
+
+// Parse returns the suppression held by a comment, if any. Rule ids are
+// separated by commas or spaces, and values containing spaces must be quoted.
+func Parse(comment string) (Suppression, bool) {
+	index := strings.Index(comment, disableComment)
+	if index == -1 {
+		return Suppression{}, false
+	}
+
+	content := strings.TrimSuffix(strings.TrimSpace(comment[index+len(disableComment):]), "*/")
+
+	suppression := Suppression{}
+	rest := content
+	for {
+		rest = strings.TrimLeftFunc(rest, isSeparator)
+
+		end := strings.IndexFunc(rest, isSeparator)
+		if end == -1 {
+			end = len(rest)
+		}
+
+		ruleID := rest[:end]
+		if ruleID == "" || strings.Contains(ruleID, "=") {
+			break
+		}
+
+		suppression.RuleIDs = append(suppression.RuleIDs, ruleID)
+		rest = rest[end:]
+	}
+
+	if len(suppression.RuleIDs) == 0 {
+		return Suppression{}, false
+	}
+
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+
+		key, value, found := strings.Cut(rest, "=")
+		if !found || strings.ContainsFunc(key, unicode.IsSpace) {
+			break
+		}
+
+		value, rest = readValue(value)
+		switch key {
+		case reasonKey:
+			suppression.Reason = value
+		case untilKey:
+			suppression.Until = value
+		}
+	}
+
+	return suppression, true
+}
+
+// Check tells whether the suppression still applies at the given time, and
+// returns why it should be reviewed, if at all. Expired suppressions, and ones
+// with an invalid expiry date, no longer apply.
+func (suppression Suppression) Check(now time.Time) (warning string, active bool) {
+	if suppression.Until != "" {
+		until, err := time.Parse(time.DateOnly, suppression.Until)
+		if err != nil {
+			return fmt.Sprintf("invalid expiry date %q, expected YYYY-MM-DD", suppression.Until), false
+		}
+
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		if today.After(until) {
+			return fmt.Sprintf("suppression expired on %s", suppression.Until), false
+		}
+	}
+
+	if suppression.Reason == "" {
+		return "suppression has no reason", true
+	}
+
+	return "", true
+}
+
+func isSeparator(char rune) bool {
+	return char == ',' || unicode.IsSpace(char)
+}
+
+// readValue reads a value up to the next space, or up to the closing quote
+// when the value is quoted, and returns it along with the remaining content
+func readValue(content string) (string, string) {
+	if strings.HasPrefix(content, `"`) {
+		if value, rest, found := strings.Cut(content[1:], `"`); found {
+			return strings.TrimSpace(value), rest
+		}
+	}
+
+	end := strings.IndexFunc(content, unicode.IsSpace)
+	if end == -1 {
+		end = len(content)
+	}
+
+	return content[:end], content[end:]
+}
@@ -0,0 +1,105 @@
+package suppression_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/util/suppression"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    suppression.Suppression
+		found   bool
+	}{
+		{
+			name:    "rules only",
+			comment: "# bearer:disable ruby_lang_cookies, ruby_lang_logger",
+			want:    suppression.Suppression{RuleIDs: []string{"ruby_lang_cookies", "ruby_lang_logger"}},
+			found:   true,
+		},
+		{
+			name:    "reason and expiry",
+			comment: `# bearer:disable ruby_lang_cookies reason="tracked in JIRA-123" until=2025-06-01`,
+			want: suppression.Suppression{
+				RuleIDs: []string{"ruby_lang_cookies"},
+				Reason:  "tracked in JIRA-123",
+				Until:   "2025-06-01",
+			},
+			found: true,
+		},
+		{
+			name:    "block comment",
+			comment: "/* bearer:disable java_lang_logger reason=legacy*/",
+			want:    suppression.Suppression{RuleIDs: []string{"java_lang_logger"}, Reason: "legacy"},
+			found:   true,
+		},
+		{
+			name:    "no rules",
+			comment: "// bearer:disable reason=legacy",
+			found:   false,
+		},
+		{
+			name:    "other comment",
+			comment: "// bearer:expected javascript_lang_logger",
+			found:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, found := suppression.Parse(test.comment)
+
+			assert.Equal(t, test.found, found)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2025, 6, 1, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		suppression suppression.Suppression
+		warning     string
+		active      bool
+	}{
+		{
+			name:        "reason and future expiry",
+			suppression: suppression.Suppression{Reason: "tracked in JIRA-123", Until: "2025-06-01"},
+			active:      true,
+		},
+		{
+			name:        "no reason",
+			suppression: suppression.Suppression{Until: "2025-07-01"},
+			warning:     "suppression has no reason",
+			active:      true,
+		},
+		{
+			name:        "expired",
+			suppression: suppression.Suppression{Reason: "tracked in JIRA-123", Until: "2025-05-31"},
+			warning:     "suppression expired on 2025-05-31",
+			active:      false,
+		},
+		{
+			name:        "invalid expiry",
+			suppression: suppression.Suppression{Reason: "tracked in JIRA-123", Until: "June"},
+			warning:     `invalid expiry date "June", expected YYYY-MM-DD`,
+			active:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			warning, active := test.suppression.Check(now)
+
+			assert.Equal(t, test.warning, warning)
+			assert.Equal(t, test.active, active)
+		})
+	}
+}
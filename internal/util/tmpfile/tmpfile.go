@@ -3,15 +3,18 @@ package tmpfile
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 
 	"github.com/bearer/bearer/internal/util/output"
+	"github.com/bearer/bearer/internal/util/workdir"
 )
 
 var ErrCreateFailed = errors.New("failed to create file")
 
 func Create(ext string) string {
-	outputFile, err := os.CreateTemp("", "*"+ext)
+	outputFile, err := os.CreateTemp(workdir.Dir(), "*"+ext)
 	if err != nil {
 		output.Fatal(fmt.Sprintf("got create fail error %s %s", err, ErrCreateFailed))
 	}
@@ -19,3 +22,46 @@ func Create(ext string) string {
 
 	return outputFile.Name()
 }
+
+// RemoveAllSecurely overwrites the content of every file within the directory
+// with zeros before removing it, so that sensitive content isn't left behind
+// on disk. The directory is removed even when a file can't be overwritten.
+func RemoveAllSecurely(dir string) error {
+	walkErr := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+
+		return overwrite(path)
+	})
+
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	return walkErr
+}
+
+func overwrite(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	zeros := make([]byte, 32*1024)
+	for remaining := info.Size(); remaining > 0; {
+		chunk := min(remaining, int64(len(zeros)))
+		if _, err := file.Write(zeros[:chunk]); err != nil {
+			return err
+		}
+		remaining -= chunk
+	}
+
+	return file.Sync()
+}
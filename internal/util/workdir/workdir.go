@@ -0,0 +1,73 @@
+package workdir
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var dir string
+
+// Setup routes every file bearer writes while scanning, such as temporary
+// reports, the scan cache and downloaded rules, into the given directory.
+// An empty directory uses the system temporary directory.
+func Setup(value string) error {
+	if value == "" {
+		dir = ""
+		return nil
+	}
+
+	absoluteDir, err := filepath.Abs(value)
+	if err != nil {
+		return err
+	}
+
+	dir = absoluteDir
+	return nil
+}
+
+// Dir returns the directory that writable files should be created in
+func Dir() string {
+	if dir == "" {
+		return os.TempDir()
+	}
+
+	return dir
+}
+
+// Preflight checks that files can be written to the work directory, creating
+// it if necessary, so that a read-only filesystem is reported up front
+// instead of partway through a scan
+func Preflight() error {
+	if err := check(Dir()); err != nil {
+		if dir == "" {
+			return fmt.Errorf("temporary directory %s is not writable, use --workdir to set a writable directory: %w", Dir(), err)
+		}
+
+		return fmt.Errorf("work directory %s is not writable: %w", dir, err)
+	}
+
+	return nil
+}
+
+func check(path string) error {
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return err
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !fileInfo.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+
+	probe, err := os.CreateTemp(path, ".bearer-preflight-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+
+	return os.Remove(probe.Name())
+}
@@ -0,0 +1,56 @@
+package workdir_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/util/workdir"
+)
+
+func TestDir(t *testing.T) {
+	defer workdir.Setup("")
+
+	if assert.NoError(t, workdir.Setup("")) {
+		assert.Equal(t, os.TempDir(), workdir.Dir())
+	}
+
+	dir := t.TempDir()
+	if assert.NoError(t, workdir.Setup(dir)) {
+		assert.Equal(t, dir, workdir.Dir())
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	defer workdir.Setup("")
+
+	t.Run("creates the work directory", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "nested", "work")
+		if err := workdir.Setup(dir); err != nil {
+			t.Fatalf("failed to setup work directory, err: %s", err)
+		}
+
+		assert.NoError(t, workdir.Preflight())
+		assert.DirExists(t, dir)
+
+		entries, _ := os.ReadDir(dir)
+		assert.Empty(t, entries, "the preflight check cleans up after itself")
+	})
+
+	t.Run("rejects a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "file")
+		if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+			t.Fatalf("failed to write file, err: %s", err)
+		}
+		if err := workdir.Setup(path); err != nil {
+			t.Fatalf("failed to setup work directory, err: %s", err)
+		}
+
+		err := workdir.Preflight()
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "work directory "+path+" is not writable")
+		}
+	})
+}
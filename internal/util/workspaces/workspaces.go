@@ -0,0 +1,160 @@
+package workspaces
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	ignore "github.com/sabhiram/go-gitignore"
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v3"
+)
+
+type packageJSON struct {
+	Name       string          `json:"name"`
+	Workspaces json.RawMessage `json:"workspaces"`
+}
+
+type pnpmWorkspace struct {
+	Packages []string `yaml:"packages"`
+}
+
+// Workspaces attributes the files of a monorepo to the Go module or
+// JavaScript workspace package they belong to
+type Workspaces struct {
+	rootDir  string
+	patterns *ignore.GitIgnore
+	names    map[string]string
+}
+
+// Load reads the workspace patterns declared in the package.json or
+// pnpm-workspace.yaml file at rootDir. Go modules don't need to be declared,
+// any directory with a go.mod file is a module.
+func Load(rootDir string) (*Workspaces, error) {
+	patterns, err := readPatterns(rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := &Workspaces{
+		rootDir: rootDir,
+		names:   make(map[string]string),
+	}
+
+	if len(patterns) != 0 {
+		workspaces.patterns = ignore.CompileIgnoreLines(patterns...)
+	}
+
+	return workspaces, nil
+}
+
+// Workspace returns the name of the innermost module or workspace package
+// containing the given path, which is relative to the project root. An empty
+// string is returned when the path doesn't belong to any.
+func (workspaces *Workspaces) Workspace(path string) string {
+	if workspaces == nil {
+		return ""
+	}
+
+	dir := filepath.Dir(filepath.Clean(path))
+	for {
+		if name := workspaces.name(dir); name != "" {
+			return name
+		}
+
+		if dir == "." || dir == string(filepath.Separator) {
+			return ""
+		}
+
+		dir = filepath.Dir(dir)
+	}
+}
+
+func (workspaces *Workspaces) name(dir string) string {
+	if name, cached := workspaces.names[dir]; cached {
+		return name
+	}
+
+	name := workspaces.moduleName(dir)
+	if name == "" {
+		name = workspaces.packageName(dir)
+	}
+
+	workspaces.names[dir] = name
+
+	return name
+}
+
+func (workspaces *Workspaces) moduleName(dir string) string {
+	content, err := os.ReadFile(filepath.Join(workspaces.rootDir, dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+
+	return modfile.ModulePath(content)
+}
+
+// packageName returns the name of the package in dir when it matches one of
+// the declared workspaces. The root package is not a workspace.
+func (workspaces *Workspaces) packageName(dir string) string {
+	if workspaces.patterns == nil || dir == "." || !workspaces.patterns.MatchesPath(filepath.ToSlash(dir)) {
+		return ""
+	}
+
+	content, err := os.ReadFile(filepath.Join(workspaces.rootDir, dir, "package.json"))
+	if err != nil {
+		return ""
+	}
+
+	var manifest packageJSON
+	if err := json.Unmarshal(content, &manifest); err != nil || manifest.Name == "" {
+		return filepath.ToSlash(dir)
+	}
+
+	return manifest.Name
+}
+
+func readPatterns(rootDir string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(rootDir, "pnpm-workspace.yaml"))
+	if err == nil {
+		var workspace pnpmWorkspace
+		if err := yaml.Unmarshal(content, &workspace); err != nil {
+			return nil, err
+		}
+
+		return workspace.Packages, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	content, err = os.ReadFile(filepath.Join(rootDir, "package.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest packageJSON
+	if err := json.Unmarshal(content, &manifest); err != nil || len(manifest.Workspaces) == 0 {
+		return nil, nil
+	}
+
+	// workspaces are either a list of patterns or, with Yarn, an object with a
+	// list of packages
+	var patterns []string
+	if err := json.Unmarshal(manifest.Workspaces, &patterns); err == nil {
+		return patterns, nil
+	}
+
+	var yarnWorkspaces struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(manifest.Workspaces, &yarnWorkspaces); err != nil {
+		return nil, nil
+	}
+
+	return yarnWorkspaces.Packages, nil
+}
@@ -0,0 +1,34 @@
+package workspaces_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bearer/bearer/internal/util/workspaces"
+)
+
+func TestWorkspace(t *testing.T) {
+	for _, test := range []struct {
+		Root     string
+		Path     string
+		Expected string
+	}{
+		{Root: "npm", Path: "packages/web/src/app.js", Expected: "@acme/web"},
+		{Root: "npm", Path: "packages/unnamed/index.js", Expected: "packages/unnamed"},
+		{Root: "npm", Path: "services/api/handlers/users.go", Expected: "github.com/acme/api"},
+		{Root: "npm", Path: "tools/scripts/seed.js", Expected: ""},
+		{Root: "npm", Path: "index.js", Expected: ""},
+		{Root: "pnpm", Path: "apps/admin/main.ts", Expected: "admin"},
+	} {
+		t.Run(test.Path, func(tt *testing.T) {
+			result, err := workspaces.Load(filepath.Join("testdata", test.Root))
+			if err != nil {
+				tt.Fatalf("failed to load workspaces: %s", err)
+			}
+
+			assert.Equal(tt, test.Expected, result.Workspace(test.Path))
+		})
+	}
+}
@@ -27,6 +27,12 @@ type BinaryVersionMeta struct {
 }
 
 func GetScanVersionMeta(ctx context.Context, options flag.Options, languages []string) (meta *VersionMeta, err error) {
+	if options.GeneralOptions.Offline {
+		log.Debug().Msg("skipping version API call as offline mode is enabled")
+
+		return offlineVersionMeta(), nil
+	}
+
 	if options.RuleOptions.DisableDefaultRules && options.GeneralOptions.DisableVersionCheck {
 		log.Debug().Msg("skipping version API call as check and default rules both disabled")
 
@@ -41,6 +47,12 @@ func GetScanVersionMeta(ctx context.Context, options flag.Options, languages []s
 }
 
 func GetVersionMeta(ctx context.Context, languages []string) (meta *VersionMeta, err error) {
+	if viper.GetBool(flag.OfflineFlag.ConfigName) {
+		log.Debug().Msg("skipping version API call as offline mode is enabled")
+
+		return offlineVersionMeta(), nil
+	}
+
 	meta, err = GetBearerVersionMeta(languages)
 	if err != nil {
 		log.Debug().Msgf("Bearer version API failed: %s", err)
@@ -75,6 +87,17 @@ func GetVersionMeta(ctx context.Context, languages []string) (meta *VersionMeta,
 	return
 }
 
+func offlineVersionMeta() *VersionMeta {
+	return &VersionMeta{
+		Rules: RuleVersionMeta{
+			Packages: make(map[string]string),
+		},
+		Binary: BinaryVersionMeta{
+			Latest: true,
+		},
+	}
+}
+
 func DisplayBinaryVersionWarning(meta *VersionMeta, Quiet bool) {
 	if !meta.Binary.Latest && checkVersion() {
 		log.Debug().Msg("Binary version is outdated")
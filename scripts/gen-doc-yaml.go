@@ -49,8 +49,10 @@ func writeDocs(cmd *cobra.Command, dir string) error {
 	// create a file to use
 	basename := "bearer.yaml"
 
+	// data files are referenced by name in the templates, so hyphens are
+	// replaced too
 	if cmd.CommandPath() != "" {
-		basename = fmt.Sprintf("%s.yaml", strings.Replace(cmd.CommandPath(), " ", "_", -1))
+		basename = fmt.Sprintf("%s.yaml", strings.NewReplacer(" ", "_", "-", "_").Replace(cmd.CommandPath()))
 	}
 
 	filename := filepath.Join(dir, basename)